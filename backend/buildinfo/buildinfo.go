@@ -0,0 +1,34 @@
+// Package buildinfo 배포된 빌드를 식별하는 정보
+// 값은 빌드할 때 ldflags 로 주입한다:
+//
+//	go build -ldflags "-X stock-recommender/backend/buildinfo.Version=1.2.0 \
+//	  -X stock-recommender/backend/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X stock-recommender/backend/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import "runtime"
+
+// ldflags 로 덮어쓰는 값 (주입하지 않으면 기본값)
+var (
+	Version   = "1.0.0"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info 빌드 정보
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get 현재 바이너리의 빌드 정보
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -2,14 +2,83 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultStaleAfter 가격/지표 응답을 stale 로 표시하는 기본 기준
+	DefaultStaleAfter = 15 * time.Minute
+	// DefaultSignalRetention 매매 신호 기본 보존 기간
+	DefaultSignalRetention = 90 * 24 * time.Hour
+	// DefaultRequestBudget 무거운 엔드포인트가 동시에 처리하는 기본 요청 수 (DB증권 클라이언트 rate limit 초당 20요청과 동일)
+	DefaultRequestBudget = 20
+	// DefaultSignalConcurrency 전체 종목 신호 생성 시 기본 동시 처리 종목 수 (AI 호출 속도는 AI_RATE_LIMIT 이 따로 제한)
+	DefaultSignalConcurrency = 4
+	// DefaultCycleDeadline 수집 주기 한 번의 기본 제한 시간 (5분 주기보다 짧게)
+	DefaultCycleDeadline = 4 * time.Minute
+	// DefaultSymbolTimeout 종목 하나의 기본 수집 제한 시간
+	DefaultSymbolTimeout = 10 * time.Second
+	// DefaultCollectorRetries 일시적 오류로 실패한 종목의 기본 재시도 횟수
+	DefaultCollectorRetries = 2
+	// DefaultCollectorRetryBudget 수집 주기 한 번에 쓸 수 있는 기본 재시도 총량 (장애 시 호출 한도 소진 방지)
+	DefaultCollectorRetryBudget = 20
+	// DefaultAIRateLimit 초당 AI 요청 수 기본 한도 (전체 종목 신호 생성이 AI 서비스를 몰아치지 않도록)
+	DefaultAIRateLimit = 5.0
+	// DefaultAIRetries AI 서비스 일시적 오류(5xx, 타임아웃)의 기본 재시도 횟수
+	DefaultAIRetries = 2
+	// DefaultAIRetryBackoff AI 첫 재시도 전 기본 대기 시간 (재시도마다 두 배)
+	DefaultAIRetryBackoff = 200 * time.Millisecond
+	// DefaultNotificationDigestWindow 이 시간 안에 생성된 신호는 사용자/채널별 요약 알림 하나로 묶는다
+	DefaultNotificationDigestWindow = time.Minute
+	// DefaultNotificationFlushInterval 묶음 창이 닫히거나 방해 금지 시간이 끝난 알림을 확인하는 주기
+	DefaultNotificationFlushInterval = 30 * time.Second
+	// DefaultPriceBookSize 메모리 가격 북에 보관할 최대 종목 수
+	DefaultPriceBookSize = 2000
+	// DefaultPriceBookIdle 이 시간 동안 가격 갱신이 없는 종목은 가격 북에서 뺀다 (수집 주기 5분)
+	DefaultPriceBookIdle = 30 * time.Minute
+	// DefaultIndicatorDecimals 자릿수를 따로 지정하지 않은 지표의 응답 소수 자릿수
+	DefaultIndicatorDecimals = 4
+	// DefaultIndicatorCacheSize 지표 계산 결과 캐시 크기 (종목 수보다 넉넉하게)
+	DefaultIndicatorCacheSize = 1000
+	// DefaultSessionCloseKR 국내 장 마감 후 일봉 재계산 시각 (Asia/Seoul)
+	DefaultSessionCloseKR = "15:30"
+	// DefaultSessionCloseUS 미국 장 마감 후 일봉 재계산 시각 (America/New_York)
+	DefaultSessionCloseUS = "16:00"
+	// DefaultBackfillDailyBudget 백필에 쓸 하루 API 호출 수 (실시간 수집 몫을 남겨 둔다)
+	DefaultBackfillDailyBudget = 500
+	// DefaultBackfillWindowDays 백필 API 호출 한 번에 요청할 일수
+	DefaultBackfillWindowDays = 100
+	// DefaultBacktestCapital 백테스트 시작 자금 (고정 수수료가 수익률에 미치는 정도를 정한다)
+	DefaultBacktestCapital = 10_000_000
+	// DefaultBacktestCommissionBps 체결 금액 대비 수수료 (bp, 1bp = 0.01%)
+	DefaultBacktestCommissionBps = 1.5
+	// DefaultBacktestSlippageTicks 체결 가격이 종가보다 불리하게 밀리는 호가 단위 수
+	DefaultBacktestSlippageTicks = 1
+	// DefaultBacktestAIMaxBars ai 전략 백테스트가 평가할 최대 봉 수 (봉마다 AI 를 호출하므로 약 1년치로 제한)
+	DefaultBacktestAIMaxBars = 250
 )
 
 type Config struct {
-	Port     string
-	Database DatabaseConfig
-	Redis    RedisConfig
-	RabbitMQ RabbitMQConfig
-	API      APIConfig
+	Port              string
+	StaleAfter        time.Duration // 이 시간보다 오래된 가격/지표 데이터는 응답에 stale 로 표시
+	SignalRetention   time.Duration // 이 시간보다 오래된 매매 신호는 정리 (성과 추적 중인 신호 제외)
+	DefaultMarket     string        // market/exchange 를 생략한 요청에 쓸 시장 (비어 있으면 요청에 명시해야 함)
+	PartitionInterval string        // stock_prices 파티션 하나가 담는 기간 (daily, weekly, monthly)
+	Database          DatabaseConfig
+	Redis             RedisConfig
+	RabbitMQ          RabbitMQConfig
+	API               APIConfig
+	AI                AIConfig
+	Signal            SignalConfig
+	Collector         CollectorConfig
+	Indicator         IndicatorConfig
+	Session           SessionConfig
+	Notification      NotificationConfig
+	Backfill          BackfillConfig
+	Backtest          BacktestConfig
+	Features          map[string]bool // 기능 플래그 기본값 덮어쓰기 (DB 설정이 있으면 DB 가 우선)
 }
 
 type DatabaseConfig struct {
@@ -33,15 +102,114 @@ type RabbitMQConfig struct {
 }
 
 type APIConfig struct {
-	DBSecAPIKey    string
-	DBSecAppKey    string
-	DBSecAppSecret string
-	AIServiceURL   string
+	DBSecAPIKey             string
+	DBSecAppKey             string
+	DBSecAppSecret          string
+	DBSecBaseURL            string
+	DBSecHashKey            string        // POST 본문 해시키 모드 (body, none)
+	DBSecCustType           string        // 고객타입 (P: 개인, B: 법인)
+	DBSecSLA                time.Duration // 이 시간을 넘는 API 호출은 느린 요청으로 기록
+	DBSecMaintenanceWindows string        // 정기 점검 시간 (한국 시간, "sun 02:00-06:00,23:50-00:10"), 이 동안은 수집/신호 생성을 멈춘다
+	DBSecMaintenanceRetry   time.Duration // 점검 응답을 받은 뒤 다시 호출해 볼 때까지 대기 시간
+	DBSecSymbolMap          string        // 거래소별 종목코드 변환 ("NYSE:BRK.B=BRK/B"), 등록되지 않은 종목코드는 그대로 보낸다
+	TickerCatalogRefresh    time.Duration // 해외 종목 카탈로그(/catalog/foreign)를 다시 받아 오는 주기
+	DBSecResponseCodes      string        // 기본 표에 더할 응답코드 분류 ("IGW00999=quota"), 표에 없는 코드는 일시적 장애로 본다
+	AIServiceURL            string
+	RequestBudget           int    // 분석/레벨/스크리너 등 무거운 엔드포인트가 공유하는 동시 처리 한도
+	PriceSource             string // source 를 생략한 가격 요청의 데이터 출처 (live, db, cache, auto)
+	ChartSource             string // source 를 생략한 차트 요청의 데이터 출처 (live, db, cache, auto)
+	ChartMinVolume          int64  // 차트 거래량 분석(VWAP/OBV/거래량 프로파일)에서 뺄 봉의 거래량 기준 (0 이면 모든 봉 사용)
+}
+
+// AIConfig AI 의사결정 서비스 설정
+type AIConfig struct {
+	Endpoint  string
+	Fallbacks []string // 기본 엔드포인트 실패 시 순서대로 시도할 보조 엔드포인트
+	APIKey    string
+	Model     string
+	Timeout   time.Duration
+	MaxTokens int
+	RuleOnly  bool    // true 이면 AI 호출 없이 규칙 기반 전략만 사용
+	RateLimit float64 // 초당 AI 요청 수 한도 (0 이면 제한 없음)
+	RateBurst int     // 한도 안에서 연달아 보낼 수 있는 요청 수
+	// 요청에 담을 문맥 크기 (비용/지연과 신호 품질 사이의 조절값)
+	HistoryBars int      // 함께 보낼 최근 봉 수 (0 이면 최신 시세만)
+	Indicators  []string // 보낼 지표 이름 (비어 있으면 계산한 지표 전부)
+	// 일시적인 실패(5xx, 타임아웃) 재시도 (provider 마다, 모두 실패하면 다음 provider 나 규칙 기반으로 넘어간다)
+	Retries      int           // 첫 요청 뒤 추가로 시도할 횟수 (0 이면 재시도 안 함)
+	RetryBackoff time.Duration // 첫 재시도 전 대기 시간 (재시도마다 두 배, 지터 적용)
+}
+
+// CollectorConfig 주가 수집 주기 설정
+type CollectorConfig struct {
+	CycleDeadline time.Duration // 수집 주기 전체 제한 시간
+	SymbolTimeout time.Duration // 종목당 수집 제한 시간 (넘기면 건너뛴다)
+	MaxRetries    int           // 일시적 오류(네트워크, 타임아웃, 서버 오류) 종목당 재시도 횟수
+	RetryBudget   int           // 주기 전체 재시도 총량 (다 쓰면 남은 종목은 건너뛴다)
+	PriceBookSize int           // 메모리 가격 북에 보관할 최대 종목 수
+	PriceBookIdle time.Duration // 이 시간 동안 갱신이 없는 종목은 가격 북에서 뺀다
+}
+
+// IndicatorConfig 지표 응답 표시 및 계산 캐시 설정 (계산/저장 값은 그대로 두고 응답에서만 반올림)
+type IndicatorConfig struct {
+	DefaultDecimals *int           // 지표별 자릿수가 없을 때 사용할 소수 자릿수 (nil 이면 DefaultIndicatorDecimals)
+	Decimals        map[string]int // 지표 이름(rsi, macd ...)별 소수 자릿수
+	CacheSize       int            // 같은 봉 묶음의 계산 결과를 보관할 개수 (0 이면 캐시 사용 안 함)
+}
+
+// NotificationConfig 매매 신호 알림 설정
+type NotificationConfig struct {
+	Subscribers   []string      // 신호 알림을 받을 "사용자:채널" 목록 (비어 있으면 알림을 보내지 않음)
+	DigestWindow  time.Duration // 이 시간 안에 생성된 신호를 요약 알림 하나로 묶음 (0 이면 신호마다 바로 알림)
+	FlushInterval time.Duration // 닫힌 묶음과 보류 알림을 보내는 주기
+}
+
+// SessionConfig 시장별 장 마감 작업 시각 (HH:MM, 각 시장의 현지 시간)과 추가 휴장일
+type SessionConfig struct {
+	KRClose    string
+	USClose    string
+	KRHolidays []string // 기본 달력에 더할 국내 휴장일 (YYYY-MM-DD)
+	USHolidays []string // 기본 달력에 더할 미국 휴장일 (YYYY-MM-DD)
+}
+
+// BackfillConfig 과거 일봉 백필 설정
+type BackfillConfig struct {
+	DailyBudget int // 하루(한국 시간) 백필 API 호출 한도
+	WindowDays  int // 호출 한 번에 요청할 일수
+}
+
+// BacktestConfig 백테스트 체결 비용 설정 (매수/매도 체결마다 적용)
+type BacktestConfig struct {
+	InitialCapital  float64 // 시작 자금
+	CommissionFixed float64 // 체결당 고정 수수료
+	CommissionBps   float64 // 체결 금액 대비 수수료 (bp)
+	SlippageBps     float64 // 종가 대비 불리한 체결 가격 차이 (bp)
+	SlippageTicks   int     // 종가 대비 불리한 체결 가격 차이 (호가 단위 수, SlippageBps 에 더해진다)
+	AIMaxBars       int     // ai 전략을 포함한 백테스트가 평가할 최대 봉 수 (0 이하면 제한 없음)
+}
+
+// SignalConfig 매매 신호 생성 설정
+type SignalConfig struct {
+	StrengthFloor   float64       // 신뢰도 0 에 대응하는 신호 강도
+	StrengthCeiling float64       // 신뢰도 1 에 대응하는 신호 강도
+	Trigger         string        // 자동 신호 생성 시점 (price_update, daily_close, schedule, manual)
+	Schedule        time.Duration // Trigger 가 schedule 일 때 전체 종목 신호 생성 주기
+	ReasonLanguage  string        // Accept-Language 가 없거나 지원하지 않는 언어일 때, 그리고 큐로 발행하는 신호의 근거 언어 (ko, en)
+	ConfidenceFloor float64       // 이 신뢰도 미만의 신호는 계산만 하고 저장/발행하지 않음 (0 이면 모두 저장)
+	LogSuppressed   bool          // 저장하지 않은 신호를 로그로 남길지 여부
+	MaxPriceAge     time.Duration // 최신 가격이 이보다 오래되면 신호를 건너뛰거나 신뢰도를 낮춤 (0 이면 검사하지 않음)
+	StalePrice      string        // 최신 가격이 오래됐을 때 처리 (skip, downgrade)
+	StaleConfidence float64       // StalePrice 가 downgrade 일 때 신뢰도 상한
+	Concurrency     int           // 전체 종목 신호 생성 시 동시에 처리할 종목 수 (1 이면 순차)
 }
 
 func Load() *Config {
 	return &Config{
-		Port: getEnv("PORT", "8080"),
+		Port:              getEnv("PORT", "8080"),
+		StaleAfter:        getEnvDuration("DATA_STALE_AFTER", DefaultStaleAfter),
+		SignalRetention:   getEnvDuration("SIGNAL_RETENTION", DefaultSignalRetention),
+		DefaultMarket:     getEnv("DEFAULT_MARKET", ""),
+		PartitionInterval: getEnv("PARTITION_INTERVAL", "monthly"),
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
@@ -60,11 +228,89 @@ func Load() *Config {
 			Password: getEnv("RABBITMQ_PASS", "stockmqpass"),
 		},
 		API: APIConfig{
-			DBSecAPIKey:    getEnv("DBSEC_APP_KEY", ""),
-			DBSecAppKey:    getEnv("DBSEC_APP_KEY", ""),
-			DBSecAppSecret: getEnv("DBSEC_APP_SECRET", ""),
-			AIServiceURL:   getEnv("AI_SERVICE_URL", "http://localhost:8001"),
+			DBSecAPIKey:             getEnv("DBSEC_APP_KEY", ""),
+			DBSecAppKey:             getEnv("DBSEC_APP_KEY", ""),
+			DBSecAppSecret:          getEnv("DBSEC_APP_SECRET", ""),
+			DBSecBaseURL:            getEnv("DBSEC_BASE_URL", "https://openapi.dbsec.co.kr:8443"),
+			DBSecHashKey:            getEnv("DBSEC_HASHKEY_MODE", "body"),
+			DBSecCustType:           getEnv("DBSEC_CUSTTYPE", "P"),
+			DBSecSLA:                getEnvDuration("DBSEC_SLA", 2*time.Second),
+			DBSecMaintenanceWindows: getEnv("DBSEC_MAINTENANCE_WINDOWS", ""),
+			DBSecMaintenanceRetry:   getEnvDuration("DBSEC_MAINTENANCE_RETRY", 10*time.Minute),
+			DBSecSymbolMap:          getEnv("DBSEC_SYMBOL_MAP", ""),
+			TickerCatalogRefresh:    getEnvDuration("TICKER_CATALOG_REFRESH", 6*time.Hour),
+			DBSecResponseCodes:      getEnv("DBSEC_RESPONSE_CODES", ""),
+			AIServiceURL:            getEnv("AI_SERVICE_URL", "http://localhost:8001"),
+			RequestBudget:           getEnvInt("API_REQUEST_BUDGET", DefaultRequestBudget),
+			PriceSource:             getEnv("PRICE_SOURCE", "auto"),
+			ChartSource:             getEnv("CHART_SOURCE", "live"),
+			ChartMinVolume:          int64(getEnvInt("CHART_MIN_VOLUME", 0)),
+		},
+		AI: AIConfig{
+			Endpoint:     getEnv("AI_SERVICE_URL", "http://localhost:8001"),
+			Fallbacks:    getEnvList("AI_FALLBACK_URLS"),
+			APIKey:       getEnv("AI_API_KEY", ""),
+			Model:        getEnv("AI_MODEL", ""),
+			Timeout:      getEnvDuration("AI_TIMEOUT", 30*time.Second),
+			MaxTokens:    getEnvInt("AI_MAX_TOKENS", 0),
+			RuleOnly:     getEnvBool("AI_RULE_ONLY", false),
+			RateLimit:    getEnvFloat("AI_RATE_LIMIT", DefaultAIRateLimit),
+			RateBurst:    getEnvInt("AI_RATE_BURST", 1),
+			HistoryBars:  getEnvInt("AI_HISTORY_BARS", 0),
+			Indicators:   getEnvList("AI_INDICATORS"),
+			Retries:      getEnvInt("AI_RETRIES", DefaultAIRetries),
+			RetryBackoff: getEnvDuration("AI_RETRY_BACKOFF", DefaultAIRetryBackoff),
+		},
+		Signal: SignalConfig{
+			StrengthFloor:   getEnvFloat("SIGNAL_STRENGTH_FLOOR", 0.3),
+			StrengthCeiling: getEnvFloat("SIGNAL_STRENGTH_CEILING", 1.0),
+			Trigger:         getEnv("SIGNAL_TRIGGER", "price_update"),
+			Schedule:        getEnvDuration("SIGNAL_SCHEDULE_INTERVAL", time.Hour),
+			ReasonLanguage:  getEnv("SIGNAL_REASON_LANGUAGE", "en"),
+			ConfidenceFloor: getEnvFloat("SIGNAL_CONFIDENCE_FLOOR", 0),
+			LogSuppressed:   getEnvBool("SIGNAL_LOG_SUPPRESSED", false),
+			MaxPriceAge:     getEnvDuration("SIGNAL_MAX_PRICE_AGE", 0),
+			StalePrice:      getEnv("SIGNAL_STALE_PRICE_ACTION", "skip"),
+			StaleConfidence: getEnvFloat("SIGNAL_STALE_CONFIDENCE", 0.3),
+			Concurrency:     getEnvInt("SIGNAL_CONCURRENCY", DefaultSignalConcurrency),
+		},
+		Collector: CollectorConfig{
+			CycleDeadline: getEnvDuration("COLLECTOR_CYCLE_DEADLINE", DefaultCycleDeadline),
+			SymbolTimeout: getEnvDuration("COLLECTOR_SYMBOL_TIMEOUT", DefaultSymbolTimeout),
+			MaxRetries:    getEnvInt("COLLECTOR_MAX_RETRIES", DefaultCollectorRetries),
+			RetryBudget:   getEnvInt("COLLECTOR_RETRY_BUDGET", DefaultCollectorRetryBudget),
+			PriceBookSize: getEnvInt("PRICE_BOOK_SIZE", DefaultPriceBookSize),
+			PriceBookIdle: getEnvDuration("PRICE_BOOK_IDLE", DefaultPriceBookIdle),
+		},
+		Indicator: IndicatorConfig{
+			DefaultDecimals: getEnvOptionalInt("INDICATOR_DEFAULT_DECIMALS"),
+			Decimals:        getEnvIntMap("INDICATOR_DECIMALS"),
+			CacheSize:       getEnvInt("INDICATOR_CACHE_SIZE", DefaultIndicatorCacheSize),
 		},
+		Notification: NotificationConfig{
+			Subscribers:   getEnvList("NOTIFICATION_SUBSCRIBERS"),
+			DigestWindow:  getEnvDuration("NOTIFICATION_DIGEST_WINDOW", DefaultNotificationDigestWindow),
+			FlushInterval: getEnvDuration("NOTIFICATION_FLUSH_INTERVAL", DefaultNotificationFlushInterval),
+		},
+		Session: SessionConfig{
+			KRClose:    getEnv("SESSION_CLOSE_KR", DefaultSessionCloseKR),
+			USClose:    getEnv("SESSION_CLOSE_US", DefaultSessionCloseUS),
+			KRHolidays: getEnvList("MARKET_HOLIDAYS_KR"),
+			USHolidays: getEnvList("MARKET_HOLIDAYS_US"),
+		},
+		Backfill: BackfillConfig{
+			DailyBudget: getEnvInt("BACKFILL_DAILY_BUDGET", DefaultBackfillDailyBudget),
+			WindowDays:  getEnvInt("BACKFILL_WINDOW_DAYS", DefaultBackfillWindowDays),
+		},
+		Backtest: BacktestConfig{
+			InitialCapital:  getEnvFloat("BACKTEST_INITIAL_CAPITAL", DefaultBacktestCapital),
+			CommissionFixed: getEnvFloat("BACKTEST_COMMISSION_FIXED", 0),
+			CommissionBps:   getEnvFloat("BACKTEST_COMMISSION_BPS", DefaultBacktestCommissionBps),
+			SlippageBps:     getEnvFloat("BACKTEST_SLIPPAGE_BPS", 0),
+			SlippageTicks:   getEnvInt("BACKTEST_SLIPPAGE_TICKS", DefaultBacktestSlippageTicks),
+			AIMaxBars:       getEnvInt("BACKTEST_AI_MAX_BARS", DefaultBacktestAIMaxBars),
+		},
+		Features: getEnvBoolMap("FEATURE_FLAGS"),
 	}
 }
 
@@ -74,3 +320,89 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvIntMap "rsi=2,macd=4" 형식의 값을 파싱 (형식이 잘못된 항목은 무시)
+func getEnvIntMap(key string) map[string]int {
+	values := map[string]int{}
+	for _, pair := range getEnvList(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = n
+		}
+	}
+	return values
+}
+
+// getEnvBoolMap "api_backtest=false,signal_orderbook=true" 형식의 값을 파싱 (형식이 잘못된 항목은 무시)
+func getEnvBoolMap(key string) map[string]bool {
+	values := map[string]bool{}
+	for _, pair := range getEnvList(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			values[strings.TrimSpace(name)] = b
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+// getEnvOptionalInt 설정되지 않았거나 정수가 아니면 nil (0 과 구분해야 하는 값용)
+func getEnvOptionalInt(key string) *int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return &n
+		}
+	}
+	return nil
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
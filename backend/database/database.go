@@ -43,5 +43,11 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.TechnicalIndicator{},
 		&models.TradingSignal{},
 		&models.NewsArticle{},
+		&models.WatchlistItem{},
+		&models.OBVState{},
+		&models.BackfillJob{},
+		&models.BackfillUsage{},
+		&models.TickerSyncRun{},
+		&models.FeatureFlag{},
 	)
 }
\ No newline at end of file
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
 	"stock-recommender/backend/services"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -14,16 +19,32 @@ type AdminHandler struct {
 	db            *gorm.DB
 	dataCollector *services.DataCollectorService
 	config        *config.Config
+	cache         *services.CacheService
+	features      *services.FeatureFlags
 }
 
-func NewAdminHandler(db *gorm.DB, cfg *config.Config) *AdminHandler {
+// NewAdminHandler dataCollector 는 수동 수집에 쓰며, 호출 한도와 토큰을 함께 쓰도록 스케줄 수집기와 같은 것을 넘긴다
+func NewAdminHandler(db *gorm.DB, cfg *config.Config, dataCollector *services.DataCollectorService) *AdminHandler {
 	return &AdminHandler{
 		db:            db,
-		dataCollector: services.NewDataCollectorService(db, cfg),
+		dataCollector: dataCollector,
 		config:        cfg,
 	}
 }
 
+// WithCache 종목 변경 시 무효화할 종목 목록 캐시 설정
+func (h *AdminHandler) WithCache(cache *services.CacheService) *AdminHandler {
+	h.cache = cache
+	return h
+}
+
+// invalidateStocks 종목 등록/상태 변경 후 종목 목록 캐시 무효화
+func (h *AdminHandler) invalidateStocks() {
+	if h.cache != nil {
+		h.cache.InvalidateStocks()
+	}
+}
+
 // 종목 등록
 func (h *AdminHandler) CreateStock(c *gin.Context) {
 	var req struct {
@@ -36,7 +57,7 @@ func (h *AdminHandler) CreateStock(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
@@ -44,7 +65,7 @@ func (h *AdminHandler) CreateStock(c *gin.Context) {
 	var existing models.Stock
 	result := h.db.Where("symbol = ? AND market = ?", req.Symbol, req.Market).First(&existing)
 	if result.Error == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Stock already exists"})
+		respondError(c, http.StatusConflict, ErrCodeConflict, "Stock already exists")
 		return
 	}
 
@@ -59,9 +80,10 @@ func (h *AdminHandler) CreateStock(c *gin.Context) {
 	}
 
 	if err := h.db.Create(&stock).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create stock"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to create stock")
 		return
 	}
+	h.invalidateStocks()
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Stock created successfully",
@@ -73,24 +95,22 @@ func (h *AdminHandler) CreateStock(c *gin.Context) {
 func (h *AdminHandler) TriggerDataCollection(c *gin.Context) {
 	symbol := c.Param("symbol")
 	if symbol == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Symbol is required"})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Symbol is required")
 		return
 	}
 
 	// 종목 정보 조회
 	var stock models.Stock
 	if err := h.db.Where("symbol = ?", symbol).First(&stock).Error; err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Stock not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Stock not found")
 		return
 	}
 
 	// 데이터 수집 실행
-	err := h.dataCollector.CollectStockData(stock.Symbol, stock.Market)
+	// 클라이언트가 연결을 끊으면 진행 중인 API 호출도 중단
+	err := h.dataCollector.CollectStockDataContext(c.Request.Context(), stock.Symbol, stock.Market)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to collect data",
-			"details": err.Error(),
-		})
+		respondWithError(c, "Failed to collect data", err)
 		return
 	}
 
@@ -101,9 +121,23 @@ func (h *AdminHandler) TriggerDataCollection(c *gin.Context) {
 }
 
 // 전체 종목 데이터 수집 트리거
+// POST /admin/collect/all?wait=true 이면 수집이 끝날 때까지 기다려 종목별 실패 내역을 응답한다.
 func (h *AdminHandler) TriggerAllDataCollection(c *gin.Context) {
+	if c.Query("wait") == "true" {
+		report, err := h.dataCollector.CollectAllStocks()
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Batch data collection failed", err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Batch data collection completed",
+			"report":  report,
+		})
+		return
+	}
+
 	go func() {
-		err := h.dataCollector.CollectAllStocks()
+		_, err := h.dataCollector.CollectAllStocks()
 		if err != nil {
 			// 로그에 기록 (비동기 처리이므로 응답으로는 보내지 않음)
 			// log.Printf("Batch data collection failed: %v", err)
@@ -127,12 +161,10 @@ func (h *AdminHandler) GetAPIStatus(c *gin.Context) {
 func (h *AdminHandler) InitializeMajorStocks(c *gin.Context) {
 	err := h.dataCollector.InitializeMajorStocks()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to initialize major stocks",
-			"details": err.Error(),
-		})
+		respondWithError(c, "Failed to initialize major stocks", err)
 		return
 	}
+	h.invalidateStocks()
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Major stocks initialized successfully",
@@ -143,7 +175,7 @@ func (h *AdminHandler) InitializeMajorStocks(c *gin.Context) {
 func (h *AdminHandler) GetAllStocks(c *gin.Context) {
 	var stocks []models.Stock
 	if err := h.db.Find(&stocks).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stocks"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch stocks")
 		return
 	}
 
@@ -161,20 +193,24 @@ func (h *AdminHandler) UpdateStockStatus(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
 		return
 	}
 
 	result := h.db.Model(&models.Stock{}).Where("symbol = ?", symbol).Update("is_active", req.IsActive)
 	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update stock status"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to update stock status")
 		return
 	}
 
 	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Stock not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Stock not found")
 		return
 	}
+	h.invalidateStocks()
+	if !req.IsActive {
+		services.DefaultPriceBook.Remove(symbol)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":   "Stock status updated",
@@ -189,14 +225,16 @@ func (h *AdminHandler) DeleteStock(c *gin.Context) {
 
 	result := h.db.Where("symbol = ?", symbol).Delete(&models.Stock{})
 	if result.Error != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete stock"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to delete stock")
 		return
 	}
 
 	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Stock not found"})
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Stock not found")
 		return
 	}
+	h.invalidateStocks()
+	services.DefaultPriceBook.Remove(symbol)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Stock deleted successfully",
@@ -204,6 +242,106 @@ func (h *AdminHandler) DeleteStock(c *gin.Context) {
 	})
 }
 
+// WatchStock 관심 종목 추가 (관심 종목은 장 중 현재가를 빠르게 갱신한다)
+// POST /admin/watchlist {"symbol": "005930", "market": "KR"}
+func (h *AdminHandler) WatchStock(c *gin.Context) {
+	var req struct {
+		Symbol string `json:"symbol" binding:"required"`
+		Market string `json:"market" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	market := apimodels.DefaultMarketResolver.Region(req.Market)
+	if market == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid market "+req.Market)
+		return
+	}
+
+	item, err := services.NewWatchlistService(h.db).Watch(req.Symbol, market)
+	if err != nil {
+		respondWithError(c, "Failed to watch stock", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock added to watchlist",
+		"item":    item,
+	})
+}
+
+// UnwatchStock 관심 종목 제거
+// DELETE /admin/watchlist/:symbol?market=KR
+func (h *AdminHandler) UnwatchStock(c *gin.Context) {
+	symbol := c.Param("symbol")
+	market := apimodels.DefaultMarketResolver.Region(c.Query("market"))
+	if market == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid market "+c.Query("market"))
+		return
+	}
+
+	removed, err := services.NewWatchlistService(h.db).Unwatch(symbol, market)
+	if err != nil {
+		respondWithError(c, "Failed to unwatch stock", err)
+		return
+	}
+	if !removed {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Stock is not on the watchlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock removed from watchlist",
+		"symbol":  symbol,
+		"market":  market,
+	})
+}
+
+// 오래된 매매 신호 정리 (성과 추적 중인 신호 제외)
+// DELETE /admin/signals?older_than=30d (지정하지 않으면 설정된 보존 기간 사용)
+func (h *AdminHandler) PurgeSignals(c *gin.Context) {
+	retention := h.config.SignalRetention
+	if retention <= 0 {
+		retention = config.DefaultSignalRetention
+	}
+
+	if olderThan := c.Query("older_than"); olderThan != "" {
+		parsed, err := parseRetention(olderThan)
+		if err != nil || parsed <= 0 {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid older_than, expected a duration like 30d or 720h")
+			return
+		}
+		retention = parsed
+	}
+
+	cutoff := time.Now().Add(-retention)
+	deleted, err := services.NewSignalRetentionService(h.db, retention).PurgeOlderThan(cutoff)
+	if err != nil {
+		respondWithError(c, "Failed to purge signals", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Signals purged successfully",
+		"deleted": deleted,
+		"cutoff":  cutoff,
+	})
+}
+
+// parseRetention 보존 기간 파싱 (일 단위 "30d" 또는 time.ParseDuration 형식)
+func parseRetention(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
 // 데이터베이스 통계
 func (h *AdminHandler) GetDatabaseStats(c *gin.Context) {
 	var stats struct {
@@ -228,4 +366,156 @@ func (h *AdminHandler) GetDatabaseStats(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, stats)
-}
\ No newline at end of file
+}
+
+// SelfTestIndicators 내장 기준 데이터셋으로 지표 계산을 점검해 지표별 통과 여부 반환
+// GET /admin/selftest/indicators (하나라도 기준값과 다르면 500)
+func (h *AdminHandler) SelfTestIndicators(c *gin.Context) {
+	report := services.RunIndicatorSelfTest(services.NewIndicatorService().CalculateAll)
+
+	status := http.StatusOK
+	if !report.Passed {
+		status = http.StatusInternalServerError
+	}
+	c.JSON(status, gin.H{"selftest": report})
+}
+
+// ImportPrices CSV(symbol,date,open,high,low,close,volume) 일봉 데이터 일괄 가져오기
+// POST /admin/import/prices (multipart 의 file 필드 또는 text/csv 본문)
+func (h *AdminHandler) ImportPrices(c *gin.Context) {
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "CSV file is required", err.Error())
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Failed to read CSV file", err.Error())
+			return
+		}
+		defer opened.Close()
+		body = opened
+	}
+
+	report, err := services.NewPriceImportService(h.db).ImportCSV(body)
+	if err != nil {
+		respondWithError(c, "Failed to import prices", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"import": report})
+}
+
+// EnqueueBackfill 과거 일봉 백필 작업 등록 (백필 스케줄러가 하루 호출 예산 안에서 처리)
+// POST /admin/backfill {"symbols": ["005930", "000660"], "market": "KR", "from": "2024-01-01", "to": "2024-12-31"}
+// market 을 생략하면 배포 기본 시장(DEFAULT_MARKET)을 쓰며, 국내(KR) 종목만 지원한다.
+func (h *AdminHandler) EnqueueBackfill(c *gin.Context) {
+	var req struct {
+		Symbols []string `json:"symbols" binding:"required,min=1"`
+		Market  string   `json:"market"`
+		From    string   `json:"from" binding:"required"`
+		To      string   `json:"to" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid from date, expected YYYY-MM-DD")
+		return
+	}
+	to, err := time.Parse("2006-01-02", req.To)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid to date, expected YYYY-MM-DD")
+		return
+	}
+	if to.Before(from) {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "to must not be before from")
+		return
+	}
+
+	market, err := resolveMarketOrDefault(req.Market, h.config.DefaultMarket)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	// 기간 일봉 API 는 국내주식만 있어 다른 시장 작업은 스케줄러에서 실패로만 끝난다
+	if market.Region != apimodels.RegionKR {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Daily backfill is only supported for KR stocks")
+		return
+	}
+
+	backfill := services.NewBackfillService(h.db)
+	jobs := make([]*models.BackfillJob, 0, len(req.Symbols))
+	for _, symbol := range req.Symbols {
+		job, err := backfill.Enqueue(symbol, market.Region, from, to)
+		if err != nil {
+			respondWithError(c, "Failed to enqueue backfill", err)
+			return
+		}
+		jobs = append(jobs, job)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Backfill jobs queued",
+		"jobs":    jobs,
+	})
+}
+
+// GetUniverse 수집 대상 종목 목록 (비활성 종목 포함, 우선순위 순)
+// GET /admin/universe
+func (h *AdminHandler) GetUniverse(c *gin.Context) {
+	stocks, err := services.NewUniverseService(h.db).List()
+	if err != nil {
+		respondWithError(c, "Failed to get universe", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"universe": stocks,
+		"total":    len(stocks),
+	})
+}
+
+// UpdateUniverse 수집 대상 종목 추가/변경 (시장, 우선순위, 활성 여부)
+// PUT /admin/universe {"symbols": [{"symbol": "AAPL", "market": "US", "priority": 10, "is_active": true}]}
+// 하나라도 잘못된 종목이 있으면 아무것도 반영하지 않는다. 변경 사항은 다음 수집 주기부터 적용된다.
+func (h *AdminHandler) UpdateUniverse(c *gin.Context) {
+	var req struct {
+		Symbols []services.UniverseEntry `json:"symbols" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	for i := range req.Symbols {
+		if err := req.Symbols[i].Normalize(); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid universe entry", err.Error())
+			return
+		}
+	}
+
+	universe := services.NewUniverseService(h.db)
+	if err := universe.Apply(req.Symbols); err != nil {
+		respondWithError(c, "Failed to update universe", err)
+		return
+	}
+	h.invalidateStocks()
+
+	stocks, err := universe.List()
+	if err != nil {
+		respondWithError(c, "Failed to get universe", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Universe updated, changes apply from the next collection cycle",
+		"universe": stocks,
+		"total":    len(stocks),
+	})
+}
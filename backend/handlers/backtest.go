@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultCompareStrategies strategies 파라미터가 없을 때 실행할 전략 (ai 는 봉마다 AI 를 호출하므로 명시했을 때만)
+var defaultCompareStrategies = []string{"rule"}
+
+// 워크포워드 기본 구간 길이 (일봉 수, 인샘플 약 6개월 / 아웃오브샘플 약 2개월)
+const (
+	defaultWalkForwardInSample    = 120
+	defaultWalkForwardOutOfSample = 40
+)
+
+type BacktestHandler struct {
+	db         *gorm.DB
+	cfg        *config.Config
+	ai         services.AIDecider
+	indicators *services.IndicatorService
+}
+
+// NewBacktestHandler ai 는 AI 전략이 쓸 의사결정 클라이언트 (신호 생성과 호출 한도를 함께 쓰도록 같은 AIClient 를 넘긴다)
+func NewBacktestHandler(db *gorm.DB, cfg *config.Config, ai services.AIDecider) *BacktestHandler {
+	return &BacktestHandler{db: db, cfg: cfg, ai: ai, indicators: services.NewIndicatorService()}
+}
+
+// WithIndicators 전략이 쓸 지표 서비스 설정 (신호 생성과 같은 계산 캐시를 쓰도록 공유 서비스를 넘긴다)
+func (h *BacktestHandler) WithIndicators(indicators *services.IndicatorService) *BacktestHandler {
+	h.indicators = indicators
+	return h
+}
+
+// Compare 같은 기간의 일봉으로 여러 전략을 백테스트하고 지표별로 비교 (기본: 최근 1년, rule)
+// 기간 첫 봉부터 판단하도록 from 이전 일봉을 지표 계산용으로 함께 읽고, ai 전략은 BACKTEST_AI_MAX_BARS 봉까지만 허용한다.
+// GET /backtest/compare?symbol=005930&from=2024-01-01&to=2024-12-31&strategies=rule,ai
+func (h *BacktestHandler) Compare(c *gin.Context) {
+	symbol := c.Query("symbol")
+	if symbol == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "symbol is required")
+		return
+	}
+
+	names := parseListQuery(c, "strategies")
+	if names == nil {
+		names = defaultCompareStrategies
+	}
+
+	ctx := c.Request.Context()
+	strategies := make([]services.BacktestStrategy, 0, len(names))
+	usesAI := false
+	for _, name := range names {
+		strategy, err := h.strategy(ctx, name, h.indicators)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
+		strategies = append(strategies, strategy)
+		usesAI = usesAI || name == "ai"
+	}
+
+	from, to := backtestPeriod(c, 1)
+	bars, err := services.LoadDailyBars(h.db, symbol, from, to)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price data")
+		return
+	}
+	if len(bars) == 0 {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Price data not found")
+		return
+	}
+	if limit := h.cfg.Backtest.AIMaxBars; usesAI && limit > 0 && len(bars) > limit {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest,
+			fmt.Sprintf("ai strategy is limited to %d bars, got %d; narrow from/to", limit, len(bars)))
+		return
+	}
+
+	backtester := h.backtester()
+	warmup, err := services.LoadWarmupBars(h.db, symbol, from, backtester.Warmup())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price data")
+		return
+	}
+	bars = append(warmup, bars...)
+
+	comparison := backtester.Compare(bars, strategies...)
+	if ctx.Err() != nil {
+		// 요청이 끝나 AI 호출을 멈춘 결과는 응답하지 않는다
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"symbol": symbol, "comparison": comparison})
+}
+
+// WalkForward 일봉을 인샘플/아웃오브샘플 구간으로 나눠 규칙 전략을 워크포워드 검증 (기본: 최근 2년, 120/40봉)
+// 구간마다 인샘플에서 수익률이 가장 높은 지표 기간(services.RuleParamGrid)을 골라 바로 다음 아웃오브샘플에서 평가한다.
+// GET /backtest/walk-forward?symbol=005930&from=2023-01-01&to=2024-12-31&in_sample=120&out_of_sample=40
+func (h *BacktestHandler) WalkForward(c *gin.Context) {
+	symbol := c.Query("symbol")
+	if symbol == "" {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "symbol is required")
+		return
+	}
+
+	inSample, err := parseIntQuery(c, "in_sample", 1)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	outOfSample, err := parseIntQuery(c, "out_of_sample", 1)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	window := services.WalkForwardWindow{InSample: defaultWalkForwardInSample, OutOfSample: defaultWalkForwardOutOfSample}
+	if inSample > 0 {
+		window.InSample = inSample
+	}
+	if outOfSample > 0 {
+		window.OutOfSample = outOfSample
+	}
+
+	from, to := backtestPeriod(c, 2)
+	bars, err := services.LoadDailyBars(h.db, symbol, from, to)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price data")
+		return
+	}
+	if len(bars) == 0 {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Price data not found")
+		return
+	}
+
+	backtester := h.backtester()
+	result, err := backtester.WalkForward(bars, window, backtester.BestParams(h.indicators, services.RuleParamGrid))
+	if err != nil {
+		// 구간 길이가 잘못됐거나 기간 안의 봉이 구간 길이보다 적다
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"symbol": symbol, "walk_forward": result})
+}
+
+// backtestPeriod from/to 파라미터의 백테스트 기간 (to 가 없으면 현재, from 이 없으면 to 로부터 years 년 전)
+func backtestPeriod(c *gin.Context, years int) (time.Time, time.Time) {
+	params := queryParams(c)
+	to := time.Now()
+	if params.To != nil {
+		to = params.To.Add(24*time.Hour - time.Nanosecond)
+	}
+	from := to.AddDate(-years, 0, 0)
+	if params.From != nil {
+		from = *params.From
+	}
+	return from, to
+}
+
+// backtester 설정의 시작 자금과 체결 비용을 적용한 백테스터
+func (h *BacktestHandler) backtester() *services.Backtester {
+	costs := h.cfg.Backtest
+	return services.NewBacktester().
+		WithCapital(costs.InitialCapital).
+		WithCosts(services.BacktestCosts{
+			CommissionFixed: costs.CommissionFixed,
+			CommissionBps:   costs.CommissionBps,
+			SlippageBps:     costs.SlippageBps,
+			SlippageTicks:   costs.SlippageTicks,
+		})
+}
+
+// strategy 이름에 해당하는 백테스트 전략
+func (h *BacktestHandler) strategy(ctx context.Context, name string, indicators *services.IndicatorService) (services.BacktestStrategy, error) {
+	switch name {
+	case "rule":
+		return services.NewRuleStrategy(indicators), nil
+	case "ai":
+		if h.cfg.AI.RuleOnly || h.ai == nil {
+			return nil, fmt.Errorf("ai strategy is unavailable in rule-only mode")
+		}
+		return services.NewAIStrategy(indicators, h.ai).WithContext(ctx), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q (rule, ai)", name)
+	}
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BatchPriceService 인기 종목/빅테크 현재가 일괄 조회 (foreign.ForeignCurrentPriceService)
+type BatchPriceService interface {
+	GetPopularStockPrices() (map[string]*apimodels.ForeignPriceResult, error)
+	GetTechGiantsPrices() (map[string]*apimodels.ForeignPriceResult, error)
+}
+
+// BatchDayChartService 인기 종목/빅테크 일차트 일괄 조회 (foreign.ForeignDayChartService)
+type BatchDayChartService interface {
+	PopularStocksDayCharts(days int) (map[string][]apimodels.ForeignDayChartData, map[string]error)
+	TechGiantsDayCharts(days int) (map[string][]apimodels.ForeignDayChartData, map[string]error)
+}
+
+// BatchWeekChartService 빅테크 주차트 일괄 조회 (foreign.ForeignWeekChartService)
+type BatchWeekChartService interface {
+	TechGiantsWeekCharts(weeks int) (map[string][]apimodels.ForeignWeekChartData, map[string]error)
+}
+
+// BatchMonthChartService 빅테크 월차트 일괄 조회 (foreign.ForeignMonthChartService)
+type BatchMonthChartService interface {
+	TechGiantsMonthCharts(months int) (map[string][]apimodels.ForeignMonthChartData, map[string]error)
+}
+
+// BatchHandler 인기 종목/빅테크 일괄 조회 핸들러
+// 일부 종목만 실패하면 요청 전체를 실패시키지 않고 성공한 결과와 종목별 에러를 함께 응답한다.
+type BatchHandler struct {
+	prices BatchPriceService
+	day    BatchDayChartService
+	week   BatchWeekChartService
+	month  BatchMonthChartService
+}
+
+func NewBatchHandler(prices BatchPriceService, day BatchDayChartService, week BatchWeekChartService, month BatchMonthChartService) *BatchHandler {
+	return &BatchHandler{prices: prices, day: day, week: week, month: month}
+}
+
+// NewForeignBatchHandler DB증권 클라이언트로 foreign 일괄 조회 서비스를 묶은 핸들러 생성
+func NewForeignBatchHandler(apiClient *client.DBSecClient) *BatchHandler {
+	return NewBatchHandler(
+		foreign.NewForeignCurrentPriceService(apiClient),
+		foreign.NewForeignDayChartService(apiClient),
+		foreign.NewForeignWeekChartService(apiClient),
+		foreign.NewForeignMonthChartService(apiClient),
+	)
+}
+
+// GetPopularPrices 인기 종목 현재가
+// GET /batch/popular/prices
+func (h *BatchHandler) GetPopularPrices(c *gin.Context) {
+	results, err := h.prices.GetPopularStockPrices()
+	if err != nil {
+		respondWithError(c, "Failed to get popular stock prices", err)
+		return
+	}
+	respondPriceBatch(c, results)
+}
+
+// GetTechGiantsPrices 빅테크 현재가
+// GET /batch/tech-giants/prices
+func (h *BatchHandler) GetTechGiantsPrices(c *gin.Context) {
+	results, err := h.prices.GetTechGiantsPrices()
+	if err != nil {
+		respondWithError(c, "Failed to get tech giants prices", err)
+		return
+	}
+	respondPriceBatch(c, results)
+}
+
+// GetPopularDayCharts 인기 종목 일차트
+// GET /batch/popular/chart/day?limit=100
+func (h *BatchHandler) GetPopularDayCharts(c *gin.Context) {
+	count, ok := batchChartCount(c, defaultChartDays)
+	if !ok {
+		return
+	}
+	results, failures := h.day.PopularStocksDayCharts(count)
+	respondBatch(c, results, len(results), failures)
+}
+
+// GetTechGiantsCharts 빅테크 일/주/월 차트
+// GET /batch/tech-giants/chart/:period?limit=52 (period: day, week, month)
+func (h *BatchHandler) GetTechGiantsCharts(c *gin.Context) {
+	switch period := c.Param("period"); period {
+	case "day":
+		if count, ok := batchChartCount(c, defaultChartDays); ok {
+			results, failures := h.day.TechGiantsDayCharts(count)
+			respondBatch(c, results, len(results), failures)
+		}
+	case "week":
+		if count, ok := batchChartCount(c, defaultChartWeeks); ok {
+			results, failures := h.week.TechGiantsWeekCharts(count)
+			respondBatch(c, results, len(results), failures)
+		}
+	case "month":
+		if count, ok := batchChartCount(c, defaultChartMonths); ok {
+			results, failures := h.month.TechGiantsMonthCharts(count)
+			respondBatch(c, results, len(results), failures)
+		}
+	default:
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid chart period %q, expected day, week or month", period))
+	}
+}
+
+// batchChartCount limit 파라미터 (없으면 defaultCount, maxChartBars 를 넘으면 400 응답 후 false)
+// 종목마다 차트를 받으므로 단건 차트와 같은 상한을 둔다.
+func batchChartCount(c *gin.Context, defaultCount int) (int, bool) {
+	limit := queryParams(c).Limit
+	if limit > maxChartBars {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid limit %d, expected at most %d", limit, maxChartBars))
+		return 0, false
+	}
+	if limit > 0 {
+		return limit, true
+	}
+	return defaultCount, true
+}
+
+// respondPriceBatch 현재가 일괄 조회 결과를 성공한 종목과 실패한 종목으로 나눠 응답
+func respondPriceBatch(c *gin.Context, results map[string]*apimodels.ForeignPriceResult) {
+	prices := make(map[string]*apimodels.ForeignCurrentPriceData, len(results))
+	failures := make(map[string]error)
+	for code, result := range results {
+		if result.Error != "" {
+			failures[code] = fmt.Errorf("%s", result.Error)
+			continue
+		}
+		prices[code] = result.Data
+	}
+	respondBatch(c, prices, len(prices), failures)
+}
+
+// respondBatch 종목별 결과와 실패 원인을 함께 응답
+// 모두 성공하면 200, 일부만 실패하면 207 (Multi-Status), 모두 실패하면 502 로 응답하며 본문 형식은 같다.
+func respondBatch(c *gin.Context, results interface{}, succeeded int, failures map[string]error) {
+	errs := make(map[string]string, len(failures))
+	for code, err := range failures {
+		errs[code] = err.Error()
+	}
+
+	status := http.StatusOK
+	switch {
+	case len(failures) > 0 && succeeded == 0:
+		status = http.StatusBadGateway
+	case len(failures) > 0:
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, gin.H{
+		"results":   results,
+		"errors":    errs,
+		"succeeded": succeeded,
+		"failed":    len(failures),
+	})
+}
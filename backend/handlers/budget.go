@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestBudgetMiddleware 공유 예산에서 슬롯을 얻은 요청만 핸들러를 실행
+// 여러 종목/기간을 읽는 무거운 엔드포인트에만 적용해 가벼운 시세 조회는 대기하지 않는다.
+func RequestBudgetMiddleware(budget *services.RequestBudget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := budget.Acquire(c.Request.Context()); err != nil {
+			respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "Request budget exhausted", err.Error())
+			return
+		}
+		defer budget.Release()
+
+		c.Next()
+	}
+}
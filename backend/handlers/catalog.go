@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CatalogHandler 해외 종목 카탈로그 핸들러
+type CatalogHandler struct {
+	catalog *services.TickerCatalog
+}
+
+func NewCatalogHandler(catalog *services.TickerCatalog) *CatalogHandler {
+	return &CatalogHandler{catalog: catalog}
+}
+
+// GetForeignCatalog 캐시된 거래소 종목 목록 (업종/이름 검색, limit/offset 지원)
+// GET /catalog/foreign?exchange=NASDAQ&sector=반도체&q=apple&limit=100
+func (h *CatalogHandler) GetForeignCatalog(c *gin.Context) {
+	market, err := resolveMarketOrDefault(c.Query("exchange"), "")
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	if !market.IsForeign() {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid exchange %q, expected NYSE, NASDAQ or AMEX", market.Name))
+		return
+	}
+
+	snapshot, err := h.catalog.Get(market.Exchange)
+	if errors.Is(err, services.ErrTickerCatalogUnavailable) {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "Ticker catalog is not available")
+		return
+	}
+	if err != nil {
+		respondWithError(c, "Failed to get ticker catalog", err)
+		return
+	}
+
+	entries := services.FilterTickerCatalog(snapshot.Entries, c.Query("sector"), c.Query("q"))
+	total := len(entries)
+
+	params := queryParams(c)
+	if params.Offset >= len(entries) {
+		entries = entries[:0]
+	} else {
+		entries = entries[params.Offset:]
+	}
+	if params.Limit > 0 && params.Limit < len(entries) {
+		entries = entries[:params.Limit]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"exchange":   market.Name,
+		"fetched_at": snapshot.FetchedAt,
+		"total":      total,
+		"tickers":    entries,
+	})
+}
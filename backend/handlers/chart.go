@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 차트 기본 조회 개수 (limit 파라미터가 없을 때)
+const (
+	defaultChartDays   = 100
+	defaultChartWeeks  = 52
+	defaultChartMonths = 24
+)
+
+// 거래량 분석에 쓰는 분차트 조회 거래일 수 (days 파라미터가 없을 때 / 최대)
+const (
+	defaultVolumeMinuteDays = 1
+	maxVolumeMinuteDays     = 5
+)
+
+// validMinuteBars 분차트 거래량 분석의 봉 간격 (bar 파라미터)
+var validMinuteBars = map[string]bool{"30sec": true, "1min": true, "2min": true, "5min": true, "10min": true, "60min": true}
+
+// 차트 한 번에 조회할 최대 봉 수 (일봉 약 20년, 조회 기간을 거래일 단위로 거슬러 계산하므로 상한을 둔다)
+const maxChartBars = 5000
+
+// DayChartService 해외주식 일차트 조회 (foreign.ForeignDayChartService)
+type DayChartService interface {
+	GetDayChartWithDaysContext(ctx context.Context, stockCode, market string, days int, useAdjusted bool) ([]apimodels.ForeignDayChartData, error)
+}
+
+// WeekChartService 해외주식 주차트 조회 (foreign.ForeignWeekChartService)
+type WeekChartService interface {
+	GetWeekChartWithWeeksContext(ctx context.Context, stockCode, market string, weeks int, useAdjusted bool) ([]apimodels.ForeignWeekChartData, error)
+}
+
+// MonthChartService 해외주식 월차트 조회 (foreign.ForeignMonthChartService)
+type MonthChartService interface {
+	GetMonthChartWithMonthsContext(ctx context.Context, stockCode, market string, months int, useAdjusted bool) ([]apimodels.ForeignMonthChartData, error)
+}
+
+// MinChartService 해외주식 분차트 조회와 거래량 분석 (foreign.ForeignMinChartService)
+type MinChartService interface {
+	GetMinChartWithOptions(stockCode, market, interval string, days int, useAdjusted bool) ([]apimodels.ForeignMinChartData, error)
+	GetVolumeAnalysis(chartData []apimodels.ForeignMinChartData) apimodels.VolumeAnalysis
+}
+
+// DayVolumeAnalyzer 일차트 거래량 분석 (foreign.ForeignDayChartService)
+type DayVolumeAnalyzer interface {
+	GetVolumeAnalysis(chartData []apimodels.ForeignDayChartData) apimodels.VolumeAnalysis
+}
+
+// ChartHandler 해외주식 일/주/월 차트 핸들러
+// 요청 컨텍스트를 차트 서비스에 넘겨 클라이언트가 연결을 끊으면 DB증권 API 호출도 중단한다.
+type ChartHandler struct {
+	day           DayChartService
+	week          WeekChartService
+	month         MonthChartService
+	defaultMarket string // exchange 를 생략한 요청에 쓸 배포 기본 시장 (비어 있으면 exchange 필수)
+
+	cache  ChartCache          // source=cache/auto 에 쓰는 차트 캐시 (없으면 캐시를 건너뜀)
+	store  DayChartStore       // source=db/auto 에 쓰는 저장된 일봉 (없으면 DB 를 건너뜀)
+	source services.DataSource // source 를 생략한 요청의 데이터 출처
+
+	dayVolume DayVolumeAnalyzer // 일차트 거래량 분석 (없으면 거래량 분석 미지원)
+	min       MinChartService   // 분차트 조회/거래량 분석 (없으면 거래량 분석 미지원)
+}
+
+func NewChartHandler(day DayChartService, week WeekChartService, month MonthChartService) *ChartHandler {
+	return &ChartHandler{day: day, week: week, month: month, source: services.SourceLive}
+}
+
+// WithDefaultMarket exchange 를 생략한 요청에 쓸 기본 시장 설정 (NASDAQ, NYSE 등)
+func (h *ChartHandler) WithDefaultMarket(market string) *ChartHandler {
+	h.defaultMarket = market
+	return h
+}
+
+// WithChartCache source=cache/auto 에 쓸 차트 캐시 설정
+func (h *ChartHandler) WithChartCache(cache ChartCache) *ChartHandler {
+	h.cache = cache
+	return h
+}
+
+// WithChartStore source=db/auto 에 쓸 저장된 일봉 설정 (일차트만)
+func (h *ChartHandler) WithChartStore(store DayChartStore) *ChartHandler {
+	h.store = store
+	return h
+}
+
+// WithDefaultSource source 를 생략한 요청의 데이터 출처 설정 (기본은 live)
+func (h *ChartHandler) WithDefaultSource(source services.DataSource) *ChartHandler {
+	h.source = source
+	return h
+}
+
+// WithVolumeAnalysis 거래량 분석(/chart/volume)에 쓸 일차트 분석기와 분차트 서비스 설정
+func (h *ChartHandler) WithVolumeAnalysis(day DayVolumeAnalyzer, min MinChartService) *ChartHandler {
+	h.dayVolume = day
+	h.min = min
+	return h
+}
+
+// NewForeignChartHandler DB증권 클라이언트로 foreign 차트 서비스를 묶은 핸들러 생성
+// 거래량 분석에서는 거래량이 minVolume 미만인 봉을 뺀다 (CHART_MIN_VOLUME, 0 이면 모든 봉 사용).
+func NewForeignChartHandler(apiClient *client.DBSecClient, minVolume int64) *ChartHandler {
+	day := foreign.NewForeignDayChartService(apiClient).WithMinVolume(minVolume)
+	return NewChartHandler(
+		day,
+		foreign.NewForeignWeekChartService(apiClient),
+		foreign.NewForeignMonthChartService(apiClient),
+	).WithVolumeAnalysis(day, foreign.NewForeignMinChartService(apiClient).WithMinVolume(minVolume))
+}
+
+// GetDayChart 일차트
+// GET /stocks/:symbol/chart/day?exchange=NASDAQ&limit=100&adjusted=true&candles=heikin&fields=date,close,volume&source=auto
+// source=db 는 저장된 일봉(수정주가 아님)으로 응답하고, auto 는 adjusted=false 이고 요청한 개수만큼 저장되어 있으며
+// 마지막 거래일 일봉까지 들어 있을 때만 DB 를 쓴다.
+func (h *ChartHandler) GetDayChart(c *gin.Context) {
+	exchange, ok := h.exchange(c)
+	if !ok {
+		return
+	}
+	symbol, count, adjusted, ok := chartRequest(c, defaultChartDays)
+	if !ok {
+		return
+	}
+	source := requestedSource(c, h.source)
+
+	var data []apimodels.ForeignDayChartData
+	ctx := c.Request.Context()
+	servedFrom, err := h.loadChart(source, chartLoader{
+		key: services.ChartCacheKey("day", exchange, symbol, count, adjusted),
+		out: &data,
+		stored: func() error {
+			if source == services.SourceAuto && adjusted {
+				return services.ErrSourceMiss
+			}
+			var err error
+			data, err = h.store.StoredDayChart(ctx, symbol, exchange, count)
+			if err == nil && source == services.SourceAuto && (len(data) < count || !storedChartCurrent(data, exchange, time.Now())) {
+				return services.ErrSourceMiss
+			}
+			return err
+		},
+		live: func() error {
+			var err error
+			data, err = h.day.GetDayChartWithDaysContext(ctx, symbol, exchange, count, adjusted)
+			return err
+		},
+	})
+	if err != nil {
+		respondSourceError(c, source, "Failed to get day chart", err)
+		return
+	}
+	if servedFrom == services.SourceDB {
+		adjusted = false
+	}
+	if heikinAshiRequested(c) {
+		data = apimodels.HeikinAshiDayChart(data)
+	}
+	respondChart(c, symbol, exchange, "day", adjusted, servedFrom, data)
+}
+
+// storedChartCurrent 저장된 일봉에 장 마감이 지난 가장 최근 거래일의 봉까지 들어 있는지 여부
+func storedChartCurrent(data []apimodels.ForeignDayChartData, market string, now time.Time) bool {
+	latest := ""
+	for _, bar := range data {
+		latest = max(latest, bar.Date)
+	}
+	return latest >= apimodels.DefaultMarketCalendar.LastClosedTradingDay(now, market).Format("2006-01-02")
+}
+
+// GetWeekChart 주차트
+// GET /stocks/:symbol/chart/week?exchange=NASDAQ&limit=52&adjusted=true&candles=heikin&source=auto (db 는 지원하지 않음)
+func (h *ChartHandler) GetWeekChart(c *gin.Context) {
+	exchange, ok := h.exchange(c)
+	if !ok {
+		return
+	}
+	symbol, count, adjusted, ok := chartRequest(c, defaultChartWeeks)
+	if !ok {
+		return
+	}
+	source, ok := liveOrCachedSource(c, h.source)
+	if !ok {
+		return
+	}
+
+	var data []apimodels.ForeignWeekChartData
+	servedFrom, err := h.loadChart(source, chartLoader{
+		key: services.ChartCacheKey("week", exchange, symbol, count, adjusted),
+		out: &data,
+		live: func() error {
+			var err error
+			data, err = h.week.GetWeekChartWithWeeksContext(c.Request.Context(), symbol, exchange, count, adjusted)
+			return err
+		},
+	})
+	if err != nil {
+		respondSourceError(c, source, "Failed to get week chart", err)
+		return
+	}
+	if heikinAshiRequested(c) {
+		data = apimodels.HeikinAshiWeekChart(data)
+	}
+	respondChart(c, symbol, exchange, "week", adjusted, servedFrom, data)
+}
+
+// GetMonthChart 월차트
+// GET /stocks/:symbol/chart/month?exchange=NASDAQ&limit=24&adjusted=true&candles=heikin&source=auto (db 는 지원하지 않음)
+func (h *ChartHandler) GetMonthChart(c *gin.Context) {
+	exchange, ok := h.exchange(c)
+	if !ok {
+		return
+	}
+	symbol, count, adjusted, ok := chartRequest(c, defaultChartMonths)
+	if !ok {
+		return
+	}
+	source, ok := liveOrCachedSource(c, h.source)
+	if !ok {
+		return
+	}
+
+	var data []apimodels.ForeignMonthChartData
+	servedFrom, err := h.loadChart(source, chartLoader{
+		key: services.ChartCacheKey("month", exchange, symbol, count, adjusted),
+		out: &data,
+		live: func() error {
+			var err error
+			data, err = h.month.GetMonthChartWithMonthsContext(c.Request.Context(), symbol, exchange, count, adjusted)
+			return err
+		},
+	})
+	if err != nil {
+		respondSourceError(c, source, "Failed to get month chart", err)
+		return
+	}
+	if heikinAshiRequested(c) {
+		data = apimodels.HeikinAshiMonthChart(data)
+	}
+	respondChart(c, symbol, exchange, "month", adjusted, servedFrom, data)
+}
+
+// GetCorporateActions 수정주가/원주가 일차트를 비교해 찾은 분할/배당 이벤트
+// GET /stocks/:symbol/chart/corporate-actions?exchange=NASDAQ&limit=250
+func (h *ChartHandler) GetCorporateActions(c *gin.Context) {
+	exchange, ok := h.exchange(c)
+	if !ok {
+		return
+	}
+	symbol, count, _, ok := chartRequest(c, defaultChartDays)
+	if !ok {
+		return
+	}
+
+	adjusted, err := h.day.GetDayChartWithDaysContext(c.Request.Context(), symbol, exchange, count, true)
+	if err != nil {
+		respondWithError(c, "Failed to get adjusted day chart", err)
+		return
+	}
+	unadjusted, err := h.day.GetDayChartWithDaysContext(c.Request.Context(), symbol, exchange, count, false)
+	if err != nil {
+		respondWithError(c, "Failed to get unadjusted day chart", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"symbol":            symbol,
+		"exchange":          exchange,
+		"corporate_actions": services.DetectCorporateActions(adjusted, unadjusted),
+	})
+}
+
+// GetVolumeAnalysis 일차트 또는 분차트의 거래량 분석 (VWAP, 평균 거래량, OBV, 거래량 프로파일)
+// 거래량이 CHART_MIN_VOLUME 미만인 봉은 거래량 계산에서 빠지고 가격 흐름에는 남는다.
+// GET /stocks/:symbol/chart/volume?exchange=NASDAQ&limit=100 (분차트는 interval=intraday&bar=5min&days=1)
+func (h *ChartHandler) GetVolumeAnalysis(c *gin.Context) {
+	if h.dayVolume == nil || h.min == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "Volume analysis is not configured")
+		return
+	}
+	exchange, ok := h.exchange(c)
+	if !ok {
+		return
+	}
+	symbol, count, adjusted, ok := chartRequest(c, defaultChartDays)
+	if !ok {
+		return
+	}
+
+	if queryParams(c).Interval != models.GranularityIntraday {
+		data, err := h.day.GetDayChartWithDaysContext(c.Request.Context(), symbol, exchange, count, adjusted)
+		if err != nil {
+			respondWithError(c, "Failed to get day chart", err)
+			return
+		}
+		respondVolumeAnalysis(c, symbol, exchange, "day", len(data), h.dayVolume.GetVolumeAnalysis(data))
+		return
+	}
+
+	bar := c.DefaultQuery("bar", "1min")
+	if !validMinuteBars[bar] {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid bar %q, expected 30sec, 1min, 2min, 5min, 10min or 60min", bar))
+		return
+	}
+
+	days, err := parseIntQuery(c, "days", 1)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	if days == 0 {
+		days = defaultVolumeMinuteDays
+	}
+	if days > maxVolumeMinuteDays {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid days %d, expected at most %d", days, maxVolumeMinuteDays))
+		return
+	}
+	data, err := h.min.GetMinChartWithOptions(symbol, exchange, bar, days, adjusted)
+	if err != nil {
+		respondWithError(c, "Failed to get minute chart", err)
+		return
+	}
+	respondVolumeAnalysis(c, symbol, exchange, bar, len(data), h.min.GetVolumeAnalysis(data))
+}
+
+func respondVolumeAnalysis(c *gin.Context, symbol, exchange, period string, bars int, analysis apimodels.VolumeAnalysis) {
+	c.JSON(http.StatusOK, gin.H{
+		"symbol":   symbol,
+		"exchange": exchange,
+		"period":   period,
+		"bars":     bars,
+		"volume":   analysis,
+	})
+}
+
+// exchange 요청한 해외 거래소의 정규화된 이름 (생략하면 기본 시장, 둘 다 없거나 해외 거래소가 아니면 400 응답 후 false)
+func (h *ChartHandler) exchange(c *gin.Context) (string, bool) {
+	market, err := resolveMarketOrDefault(c.Query("exchange"), h.defaultMarket)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return "", false
+	}
+	if !market.IsForeign() {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid exchange %q, expected NYSE, NASDAQ or AMEX", market.Name))
+		return "", false
+	}
+	return market.Name, true
+}
+
+// chartRequest 차트 요청 파라미터 (adjusted 를 지정하지 않으면 수정주가 사용, limit 이 maxChartBars 를 넘으면 400 응답 후 false)
+func chartRequest(c *gin.Context, defaultCount int) (symbol string, count int, adjusted bool, ok bool) {
+	params := queryParams(c)
+
+	count = params.Limit
+	if count == 0 {
+		count = defaultCount
+	}
+	if count > maxChartBars {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid limit %d, expected at most %d", count, maxChartBars))
+		return "", 0, false, false
+	}
+
+	adjusted = true
+	if params.Adjusted != nil {
+		adjusted = *params.Adjusted
+	}
+
+	return c.Param("symbol"), count, adjusted, true
+}
+
+// liveOrCachedSource 주/월차트의 데이터 출처 (주/월차트는 DB 에서 읽지 않으므로 source=db 면 400 응답 후 false)
+func liveOrCachedSource(c *gin.Context, fallback services.DataSource) (services.DataSource, bool) {
+	source := requestedSource(c, fallback)
+	if source == services.SourceDB {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid source \"db\", stored bars are only available for day charts")
+		return "", false
+	}
+	return source, true
+}
+
+// heikinAshiRequested candles=heikin 으로 Heikin-Ashi 봉을 요청했는지 여부
+func heikinAshiRequested(c *gin.Context) bool {
+	return queryParams(c).Candles == apimodels.CandlesHeikin
+}
+
+// respondChart 차트 응답 (fields 파라미터가 있으면 data 를 해당 필드만 남겨 응답, source 는 실제로 읽은 곳)
+func respondChart(c *gin.Context, symbol, exchange, period string, adjusted bool, source services.DataSource, data interface{}) {
+	data, ok := projectList(c, data)
+	if !ok {
+		return
+	}
+
+	candles := apimodels.CandlesRegular
+	if heikinAshiRequested(c) {
+		candles = apimodels.CandlesHeikin
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"symbol":      symbol,
+		"exchange":    exchange,
+		"period":      period,
+		"is_adjusted": adjusted,
+		"candles":     candles,
+		"source":      source,
+		"data":        data,
+	})
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor 시계열 keyset 페이지네이션 커서 (마지막으로 응답한 행의 시각과 ID)
+// 같은 시각의 행이 여러 개일 수 있어 ID 로 순서를 확정한다.
+type Cursor struct {
+	Timestamp time.Time
+	ID        uint
+}
+
+// String "2024-01-02T15:04:05Z_123" 형식으로 인코딩 (클라이언트는 그대로 다시 보내면 된다)
+func (c Cursor) String() string {
+	return fmt.Sprintf("%s_%d", c.Timestamp.UTC().Format(time.RFC3339Nano), c.ID)
+}
+
+// ParseCursor Cursor.String 으로 만든 커서 파싱
+func ParseCursor(value string) (*Cursor, error) {
+	ts, id, ok := strings.Cut(value, "_")
+	if !ok {
+		return nil, fmt.Errorf("Invalid cursor %q", value)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cursor %q", value)
+	}
+	parsedID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cursor %q", value)
+	}
+
+	return &Cursor{Timestamp: timestamp, ID: uint(parsedID)}, nil
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceSource 종목의 최신 가격을 읽는 곳 (없으면 services.ErrSourceMiss)
+type PriceSource interface {
+	LatestPrice(ctx context.Context, symbol string) (models.StockPrice, error)
+}
+
+// PriceCache 캐시된 최신 가격을 읽고, DB/API 에서 읽은 가격을 저장하는 곳 (services.CachedPrices)
+type PriceCache interface {
+	PriceSource
+	StorePrice(price models.StockPrice)
+}
+
+// ChartCache 차트 응답 캐시 (services.CacheService, 없으면 services.ErrCacheMiss)
+type ChartCache interface {
+	GetChart(key string, out interface{}) error
+	SetChart(key string, data interface{}) error
+}
+
+// DayChartStore DB 에 저장된 일봉 (services.StoredPrices)
+type DayChartStore interface {
+	StoredDayChart(ctx context.Context, symbol, market string, days int) ([]apimodels.ForeignDayChartData, error)
+}
+
+// errSourceUnavailable 요청한 데이터 출처가 이 배포에 연결되어 있지 않음
+var errSourceUnavailable = errors.New("data source is not configured")
+
+// requestedSource ?source= 로 요청한 데이터 출처 (없으면 엔드포인트 기본값)
+func requestedSource(c *gin.Context, fallback services.DataSource) services.DataSource {
+	if source := queryParams(c).Source; source != "" {
+		return source
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return services.SourceAuto
+}
+
+// respondSourceError 데이터 출처에서 읽지 못했을 때의 응답
+// cache 만 요청했는데 캐시에 없으면 본문 없이 304 로 응답해 호출자가 다른 출처로 다시 요청하게 한다.
+func respondSourceError(c *gin.Context, source services.DataSource, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrSourceMiss) && source == services.SourceCache:
+		c.Status(http.StatusNotModified)
+	case errors.Is(err, errSourceUnavailable):
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, message, fmt.Sprintf("source %s is not available", source))
+	default:
+		respondWithError(c, message, err)
+	}
+}
+
+// priceReader 출처별 가격 조회 (연결되지 않은 출처면 nil)
+func (h *StockHandler) priceReader(source services.DataSource) PriceSource {
+	switch source {
+	case services.SourceCache:
+		return h.cachedPrices
+	case services.SourceDB:
+		return h.storedPrices
+	case services.SourceLive:
+		return h.livePrices
+	}
+	return nil
+}
+
+// loadPrice source 에 따라 최신 가격을 읽고 실제로 읽은 곳 반환
+// auto 는 캐시 → DB → API 순서로 없는 곳을 건너뛰며 읽고, DB/API 에서 읽은 가격은 캐시에 저장한다.
+func (h *StockHandler) loadPrice(ctx context.Context, symbol string, source services.DataSource) (models.StockPrice, services.DataSource, error) {
+	if source != services.SourceAuto {
+		reader := h.priceReader(source)
+		if reader == nil {
+			return models.StockPrice{}, source, errSourceUnavailable
+		}
+		price, err := reader.LatestPrice(ctx, symbol)
+		return price, source, err
+	}
+
+	for _, candidate := range []services.DataSource{services.SourceCache, services.SourceDB, services.SourceLive} {
+		reader := h.priceReader(candidate)
+		if reader == nil {
+			continue
+		}
+		price, err := reader.LatestPrice(ctx, symbol)
+		if errors.Is(err, services.ErrSourceMiss) {
+			continue
+		}
+		if err != nil {
+			return price, candidate, err
+		}
+		if candidate != services.SourceCache && h.cachedPrices != nil {
+			h.cachedPrices.StorePrice(price)
+		}
+		return price, candidate, nil
+	}
+	return models.StockPrice{}, source, services.ErrSourceMiss
+}
+
+// chartLoader 차트 한 종류를 출처별로 읽는 방법
+type chartLoader struct {
+	key    string       // 캐시 키
+	out    interface{}  // 읽은 차트를 담을 변수의 포인터 (캐시에서 디코딩하고, 캐시에 저장하는 값)
+	stored func() error // DB 에서 out 으로 읽기 (저장하지 않는 차트면 nil)
+	live   func() error // API 에서 out 으로 읽기
+}
+
+// loadChart source 에 따라 차트를 읽고 실제로 읽은 곳 반환
+// auto 는 캐시 → DB → API 순서로 없는 곳을 건너뛰며 읽고, DB/API 에서 읽은 차트는 캐시에 저장한다.
+func (h *ChartHandler) loadChart(source services.DataSource, loader chartLoader) (services.DataSource, error) {
+	read := func(from services.DataSource) error {
+		switch from {
+		case services.SourceCache:
+			if h.cache == nil {
+				return errSourceUnavailable
+			}
+			if err := h.cache.GetChart(loader.key, loader.out); err != nil {
+				return services.ErrSourceMiss
+			}
+			return nil
+		case services.SourceDB:
+			if h.store == nil || loader.stored == nil {
+				return errSourceUnavailable
+			}
+			return loader.stored()
+		default:
+			return loader.live()
+		}
+	}
+
+	if source != services.SourceAuto {
+		// 저장된 일봉은 수정주가가 아니라서 요청 조건의 캐시 키에 맞지 않을 수 있으므로 API 결과만 캐시한다
+		err := read(source)
+		if err == nil && source == services.SourceLive && h.cache != nil {
+			h.cache.SetChart(loader.key, loader.out)
+		}
+		return source, err
+	}
+
+	for _, candidate := range []services.DataSource{services.SourceCache, services.SourceDB, services.SourceLive} {
+		err := read(candidate)
+		if errors.Is(err, services.ErrSourceMiss) || errors.Is(err, errSourceUnavailable) {
+			continue
+		}
+		if err == nil && candidate != services.SourceCache && h.cache != nil {
+			h.cache.SetChart(loader.key, loader.out)
+		}
+		return candidate, err
+	}
+	return source, services.ErrSourceMiss
+}
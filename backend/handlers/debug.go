@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugHandler API 연동 확인용 조회 핸들러
+type DebugHandler struct {
+	resolver *apimodels.MarketResolver
+}
+
+func NewDebugHandler(resolver *apimodels.MarketResolver) *DebugHandler {
+	return &DebugHandler{resolver: resolver}
+}
+
+// GetMarketCodes 시장별 정규화된 이름, 한/영 표시명, DB증권 시장분류코드/해외증시구분코드와 각 코드를 받는 tr_id
+// GET /debug/market-codes
+func (h *DebugHandler) GetMarketCodes(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"markets": h.resolver.CodeTable(),
+		// 해외 시장을 지정하지 않으면 이 시장으로 조회한다 (DEFAULT_MARKET, 설정이 없으면 나스닥)
+		"default_foreign_market": h.resolver.DefaultForeign(),
+	})
+}
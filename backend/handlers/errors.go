@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"stock-recommender/backend/openapi/client"
+	apierrors "stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 핸들러 자체에서 발생하는 에러 코드 (openapi 에러 코드와 같은 형식)
+const (
+	ErrCodeBadRequest  = "BAD_REQUEST"
+	ErrCodeNotFound    = string(apierrors.ErrCodeNotFound)
+	ErrCodeConflict    = "CONFLICT"
+	ErrCodeInternal    = "INTERNAL_ERROR"
+	ErrCodeUnavailable = "SERVICE_UNAVAILABLE"
+)
+
+// ErrorBody 에러 응답 본문
+type ErrorBody struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ErrorResponse 모든 API 에러 응답의 공통 형식: {"error": {"code", "message", "details"}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// respondError 지정한 상태코드/에러코드로 에러 응답
+func respondError(c *gin.Context, status int, code, message string, details ...interface{}) {
+	body := ErrorBody{Code: code, Message: message}
+	if len(details) > 0 {
+		body.Details = details[0]
+	}
+	c.AbortWithStatusJSON(status, ErrorResponse{Error: body})
+}
+
+// respondWithError 에러 타입에 맞는 상태코드로 에러 응답
+// 원인 에러 메시지는 details 로 전달하고, DBSec 점검 중이면 재개 예정 시각까지 Retry-After 를 단다.
+func respondWithError(c *gin.Context, message string, err error) {
+	status, code := StatusForError(err)
+	if apierrors.IsMaintenanceError(err) {
+		c.Header("Retry-After", maintenanceRetryAfter(client.DefaultMaintenance.Status(), time.Now()))
+	}
+	respondError(c, status, code, message, err.Error())
+}
+
+// NotFound 등록되지 않은 경로에 대한 에러 응답
+func NotFound(c *gin.Context) {
+	respondError(c, http.StatusNotFound, ErrCodeNotFound, "Route not found")
+}
+
+// StatusForError 에러를 HTTP 상태코드와 에러 코드로 변환
+// 감싸진(wrapped) openapi 에러와 gorm.ErrRecordNotFound, services.ErrSourceMiss 를 인식하며, 그 외는 500 으로 처리한다.
+func StatusForError(err error) (int, string) {
+	if stderrors.Is(err, gorm.ErrRecordNotFound) || stderrors.Is(err, services.ErrSourceMiss) {
+		return http.StatusNotFound, ErrCodeNotFound
+	}
+
+	var apiErr *apierrors.APIError
+	if !stderrors.As(err, &apiErr) {
+		return http.StatusInternalServerError, ErrCodeInternal
+	}
+
+	switch apiErr.Code {
+	case apierrors.ErrCodeValidation, apierrors.ErrCodeInvalidData:
+		return http.StatusBadRequest, string(apiErr.Code)
+	case apierrors.ErrCodeRateLimit:
+		return http.StatusTooManyRequests, string(apiErr.Code)
+	case apierrors.ErrCodeNotFound, apierrors.ErrCodeNoData:
+		return http.StatusNotFound, string(apiErr.Code)
+	case apierrors.ErrCodeMarketClosed:
+		return http.StatusConflict, string(apiErr.Code)
+	case apierrors.ErrCodeMaintenance:
+		return http.StatusServiceUnavailable, string(apiErr.Code)
+	case apierrors.ErrCodeNetworkError, apierrors.ErrCodeTimeout, apierrors.ErrCodeParseError, apierrors.ErrCodeServerError,
+		apierrors.ErrCodeUnknown, apierrors.ErrCodeAuthFailed, apierrors.ErrCodeTokenExpired, apierrors.ErrCodeInvalidKey:
+		// 증권사 API 등 업스트림 장애 (증권사 인증 실패도 우리 서버 설정 문제이지 요청자의 인증 문제가 아니다)
+		return http.StatusBadGateway, string(apiErr.Code)
+	default:
+		return http.StatusInternalServerError, string(apiErr.Code)
+	}
+}
+
+// maintenanceRetryAfter 점검 상태의 재개 예정 시각까지 남은 초 (알 수 없으면 기본 점검 대기 시간)
+func maintenanceRetryAfter(status client.MaintenanceStatus, now time.Time) string {
+	wait := client.DefaultMaintenanceRetry
+	if status.Active && status.Until != nil {
+		wait = status.Until.Sub(now)
+	}
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFeature 플래그가 꺼져 있으면 등록되지 않은 경로처럼 404 로 응답
+func RequireFeature(features *services.FeatureFlags, name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !features.Enabled(name) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "Feature disabled", name)
+			return
+		}
+		c.Next()
+	}
+}
+
+// WithFeatures 관리 API 로 조회/변경할 기능 플래그 저장소 설정
+func (h *AdminHandler) WithFeatures(features *services.FeatureFlags) *AdminHandler {
+	h.features = features
+	return h
+}
+
+// GetFeatures 기능 플래그 목록 (현재 값과 출처)
+// GET /admin/features
+func (h *AdminHandler) GetFeatures(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"features": h.features.List()})
+}
+
+// UpdateFeature 기능 플래그 값을 DB 에 저장 (환경 설정보다 우선, 다른 프로세스에는 최대 30초 뒤 반영)
+// PUT /admin/features/:name {"enabled": false}
+func (h *AdminHandler) UpdateFeature(c *gin.Context) {
+	var req struct {
+		Enabled *bool `json:"enabled" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	name := c.Param("name")
+	if err := h.features.Set(name, *req.Enabled); err != nil {
+		h.respondFeatureError(c, "Failed to update feature flag", err)
+		return
+	}
+	c.JSON(http.StatusOK, h.features.State(name))
+}
+
+// ResetFeature DB 에 저장한 기능 플래그 값을 지워 환경 설정/기본값으로 되돌림
+// DELETE /admin/features/:name
+func (h *AdminHandler) ResetFeature(c *gin.Context) {
+	name := c.Param("name")
+	if err := h.features.Clear(name); err != nil {
+		h.respondFeatureError(c, "Failed to reset feature flag", err)
+		return
+	}
+	c.JSON(http.StatusOK, h.features.State(name))
+}
+
+func (h *AdminHandler) respondFeatureError(c *gin.Context, message string, err error) {
+	if errors.Is(err, services.ErrUnknownFeatureFlag) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, message, err.Error())
+		return
+	}
+	respondWithError(c, message, err)
+}
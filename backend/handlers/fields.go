@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectFields items(구조체 슬라이스)의 각 항목을 fields 에 지정한 JSON 필드만 남긴 맵으로 변환
+// fields 가 비어 있으면 items 를 그대로 돌려준다. 모델에 없는 필드를 지정하면 에러를 돌려준다.
+func ProjectFields(items interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(items))
+	for _, field := range fields {
+		if !known[field] {
+			return nil, fmt.Errorf("Unknown field %q", field)
+		}
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]json.RawMessage, len(rows))
+	for i, row := range rows {
+		projected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := row[field]; ok {
+				projected[i][field] = value
+			}
+		}
+	}
+	return projected, nil
+}
+
+// projectList 요청의 fields 파라미터로 목록을 투영 (잘못된 필드면 400 응답 후 false)
+func projectList(c *gin.Context, items interface{}) (interface{}, bool) {
+	projected, err := ProjectFields(items, queryParams(c).Fields)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return nil, false
+	}
+	return projected, true
+}
+
+// jsonFieldNames 슬라이스 원소 구조체가 직렬화하는 JSON 필드 이름 (임베디드 구조체 포함)
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// 태그 없는 임베디드 구조체의 필드는 바깥 구조체의 필드로 직렬화된다 (비공개 타입이어도)
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
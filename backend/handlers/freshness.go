@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"time"
+
+	"stock-recommender/backend/config"
+)
+
+// Freshness 응답 데이터가 얼마나 오래되었는지 나타내는 정보
+type Freshness struct {
+	FetchedAt  time.Time `json:"fetched_at"`
+	AgeSeconds int64     `json:"age_seconds"`
+	Stale      bool      `json:"stale"`
+}
+
+// NewFreshness 데이터 시각(ts)과 현재 시각으로 경과 시간과 stale 여부 계산
+// staleAfter 가 0 이하이면 config.DefaultStaleAfter 를 사용한다.
+func NewFreshness(ts, now time.Time, staleAfter time.Duration) Freshness {
+	if staleAfter <= 0 {
+		staleAfter = config.DefaultStaleAfter
+	}
+
+	age := now.Sub(ts)
+	if age < 0 {
+		age = 0
+	}
+
+	return Freshness{
+		FetchedAt:  ts,
+		AgeSeconds: int64(age / time.Second),
+		Stale:      age > staleAfter,
+	}
+}
+
+// staleAfter 설정된 stale 기준 (설정이 없으면 0 → 기본값 사용)
+func staleAfter(cfg *config.Config) time.Duration {
+	if cfg == nil {
+		return 0
+	}
+	return cfg.StaleAfter
+}
@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"net/http"
+	"stock-recommender/backend/buildinfo"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/services"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,43 +12,95 @@ import (
 )
 
 type HealthHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	cache       *services.CacheService
+	maintenance *client.MaintenanceState
 }
 
 func NewHealthHandler(db *gorm.DB) *HealthHandler {
 	return &HealthHandler{db: db}
 }
 
+// WithCache 상태 확인에 포함할 캐시 설정
+func (h *HealthHandler) WithCache(cache *services.CacheService) *HealthHandler {
+	h.cache = cache
+	return h
+}
+
+// WithMaintenance 준비 상태에 포함할 DBSec 점검 상태 설정
+func (h *HealthHandler) WithMaintenance(maintenance *client.MaintenanceState) *HealthHandler {
+	h.maintenance = maintenance
+	return h
+}
+
 type HealthResponse struct {
 	Status    string    `json:"status"`
 	Timestamp time.Time `json:"timestamp"`
 	Database  string    `json:"database"`
+	Cache     string    `json:"cache,omitempty"` // connected 또는 unavailable (캐시 없이도 서비스 가능하므로 status 에는 반영하지 않음)
 	Version   string    `json:"version"`
+
+	Maintenance *client.MaintenanceStatus `json:"maintenance,omitempty"` // DBSec 점검 상태 (/health/ready 만)
 }
 
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
 	response := HealthResponse{
 		Status:    "ok",
 		Timestamp: time.Now(),
-		Version:   "1.0.0",
+		Version:   buildinfo.Version,
+	}
+
+	if !h.checkDatabase(&response) {
+		c.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	if h.cache != nil {
+		response.Cache = "connected"
+		if err := h.cache.Ping(); err != nil {
+			response.Cache = "unavailable"
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ReadinessCheck 요청을 받을 준비가 되었는지 확인
+// DBSec 점검 중에도 저장된 데이터로 응답할 수 있으므로 200 을 유지하고, status 와 maintenance 로 점검 상태를 알린다.
+func (h *HealthHandler) ReadinessCheck(c *gin.Context) {
+	response := HealthResponse{
+		Status:    "ready",
+		Timestamp: time.Now(),
+		Version:   buildinfo.Version,
+	}
+
+	if !h.checkDatabase(&response) {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
 	}
 
-	// Check database connection
+	maintenance := h.maintenance.Status()
+	response.Maintenance = &maintenance
+	if maintenance.Active {
+		response.Status = "maintenance"
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// checkDatabase DB 연결 상태를 response 에 기록 (연결할 수 없으면 false)
+func (h *HealthHandler) checkDatabase(response *HealthResponse) bool {
 	sqlDB, err := h.db.DB()
 	if err != nil {
 		response.Status = "error"
 		response.Database = "connection failed"
-		c.JSON(http.StatusInternalServerError, response)
-		return
+		return false
 	}
 
 	if err := sqlDB.Ping(); err != nil {
 		response.Status = "error"
 		response.Database = "ping failed"
-		c.JSON(http.StatusInternalServerError, response)
-		return
+		return false
 	}
 
 	response.Database = "connected"
-	c.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+	return true
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	apiutils "stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const queryParamsKey = "query_params"
+
+var (
+	validIntervals = map[string]bool{models.GranularityIntraday: true, models.GranularityDaily: true}
+	validCandles   = map[string]bool{apimodels.CandlesRegular: true, apimodels.CandlesHeikin: true}
+)
+
+// QueryParams 핸들러 공통 쿼리 파라미터 (from/to, market, interval, limit/offset/cursor, adjusted, candles, fields, source)
+// 지정되지 않은 값은 제로값으로 남는다.
+type QueryParams struct {
+	From     *time.Time
+	To       *time.Time
+	Market   string
+	Interval string
+	Limit    int
+	Offset   int
+	Cursor   *Cursor             // 시계열 페이지네이션 커서 (지정하지 않으면 nil)
+	Adjusted *bool               // 수정주가 사용여부 (지정하지 않으면 nil)
+	Candles  string              // 차트 봉 형식 (regular 또는 heikin, 지정하지 않으면 빈 값)
+	Fields   []string            // 응답 목록에 남길 JSON 필드 (지정하지 않으면 전체)
+	Source   services.DataSource // 가격/차트를 읽어 올 곳 (live, db, cache, auto, 지정하지 않으면 빈 값)
+}
+
+// ValidateQueryParams 공통 쿼리 파라미터를 한 번만 파싱/검증하는 미들웨어
+// 잘못된 값이면 핸들러 실행 전에 400 에러 응답을 반환한다.
+func ValidateQueryParams() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params, err := ParseQueryParams(c)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+			return
+		}
+		c.Set(queryParamsKey, params)
+		c.Next()
+	}
+}
+
+// ParseQueryParams 요청의 공통 쿼리 파라미터 파싱
+func ParseQueryParams(c *gin.Context) (QueryParams, error) {
+	var params QueryParams
+	var err error
+
+	if params.From, err = parseDateQuery(c, "from"); err != nil {
+		return params, err
+	}
+	if params.To, err = parseDateQuery(c, "to"); err != nil {
+		return params, err
+	}
+	if params.From != nil && params.To != nil && params.From.After(*params.To) {
+		return params, fmt.Errorf("Invalid date range, from must not be after to")
+	}
+
+	if market := c.Query("market"); market != "" {
+		// NASDAQ, FY, 뉴욕 같은 별칭도 받아 stocks.market 의 국가 구분(KR/US)으로 변환
+		resolved, ok := apimodels.ResolveMarket(market)
+		if !ok || resolved.Name == apimodels.MarketIndex {
+			return params, fmt.Errorf("Invalid market %q, expected KR or US", market)
+		}
+		params.Market = resolved.Region
+	}
+
+	if interval := c.Query("interval"); interval != "" {
+		params.Interval = strings.ToLower(interval)
+		if !validIntervals[params.Interval] {
+			return params, fmt.Errorf("Invalid interval %q, expected intraday or daily", interval)
+		}
+	}
+
+	if params.Limit, err = parseIntQuery(c, "limit", 1); err != nil {
+		return params, err
+	}
+	if params.Offset, err = parseIntQuery(c, "offset", 0); err != nil {
+		return params, err
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		if params.Cursor, err = ParseCursor(cursor); err != nil {
+			return params, err
+		}
+	}
+	if params.Adjusted, err = parseBoolQuery(c, "adjusted"); err != nil {
+		return params, err
+	}
+	if candles := c.Query("candles"); candles != "" {
+		params.Candles = strings.ToLower(candles)
+		if !validCandles[params.Candles] {
+			return params, fmt.Errorf("Invalid candles %q, expected regular or heikin", candles)
+		}
+	}
+	params.Fields = parseListQuery(c, "fields")
+	if source := c.Query("source"); source != "" {
+		if params.Source, err = services.ParseDataSource(source); err != nil {
+			return params, fmt.Errorf("Invalid source %q, expected live, db, cache or auto", source)
+		}
+	}
+
+	return params, nil
+}
+
+// resolveMarketOrDefault 요청한 시장, 없으면 배포 기본 시장 (둘 다 없으면 에러)
+func resolveMarketOrDefault(requested, fallback string) (apimodels.Market, error) {
+	value := requested
+	if value == "" {
+		value = fallback
+	}
+	if value == "" {
+		return apimodels.Market{}, fmt.Errorf("market is required, no default market is configured")
+	}
+	market, ok := apimodels.ResolveMarket(value)
+	if !ok || market.Name == apimodels.MarketIndex {
+		return apimodels.Market{}, fmt.Errorf("Invalid market %q, expected KR or US", value)
+	}
+	return market, nil
+}
+
+// queryParams 미들웨어가 검증한 쿼리 파라미터 (미들웨어가 없으면 제로값)
+func queryParams(c *gin.Context) QueryParams {
+	if value, exists := c.Get(queryParamsKey); exists {
+		if params, ok := value.(QueryParams); ok {
+			return params
+		}
+	}
+	return QueryParams{}
+}
+
+// parseDateQuery YYYY-MM-DD 또는 YYYYMMDD 형식의 날짜 파라미터 파싱
+func parseDateQuery(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := apiutils.ParseDateString(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s date, expected YYYY-MM-DD", param)
+	}
+	return &parsed, nil
+}
+
+// parseIntQuery 정수 파라미터 파싱 (없으면 0, min 미만이면 에러)
+func parseIntQuery(c *gin.Context, param string, min int) (int, error) {
+	value := c.Query(param)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < min {
+		return 0, fmt.Errorf("Invalid %s, expected an integer >= %d", param, min)
+	}
+	return parsed, nil
+}
+
+// parseListQuery 쉼표로 구분된 파라미터 파싱 (빈 항목과 중복은 제외, 없으면 nil)
+func parseListQuery(c *gin.Context, param string) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, value := range strings.Split(c.Query(param), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		values = append(values, value)
+	}
+	return values
+}
+
+// parseBoolQuery true/false 파라미터 파싱 (없으면 nil)
+func parseBoolQuery(c *gin.Context, param string) (*bool, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s, expected true or false", param)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 가격 스트림 설정
+const (
+	priceStreamBuffer    = 256              // 구독자별로 쌓아 두는 갱신 수 (넘치면 건너뛴다)
+	priceStreamKeepAlive = 30 * time.Second // 갱신이 없을 때 연결 유지를 위해 보내는 주기
+)
+
+// StreamPrices 가격 북의 최신 가격과 이후 갱신을 SSE 로 전달
+// GET /prices/stream
+// 연결 직후 가격 북에 있는 종목의 최신 가격을 price 이벤트로 보내고, 이어서 수집기가 갱신할 때마다 보낸다.
+func (h *StockHandler) StreamPrices(c *gin.Context) {
+	if h.priceBook == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeUnavailable, "Price stream is not available")
+		return
+	}
+
+	updates, cancel := h.priceBook.Subscribe(priceStreamBuffer)
+	defer cancel()
+	keepAlive := time.NewTicker(priceStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case price, ok := <-updates:
+			if !ok {
+				return false
+			}
+			c.SSEvent("price", price)
+			return true
+		case <-keepAlive.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
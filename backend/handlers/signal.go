@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
 	"net/http"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -24,11 +31,11 @@ func (h *SignalHandler) GetSignals(c *gin.Context) {
 	
 	// Query parameters
 	signalType := c.Query("signal_type") // BUY, SELL, HOLD
-	market := c.Query("market")          // KR, US
-	limitStr := c.DefaultQuery("limit", "50")
+	params := queryParams(c)
+	market := params.Market              // KR, US
 	
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
+	limit := params.Limit
+	if limit == 0 {
 		limit = 50
 	}
 	if limit > 200 {
@@ -49,23 +56,30 @@ func (h *SignalHandler) GetSignals(c *gin.Context) {
 	
 	if err := query.Order("created_at desc").
 		Limit(limit).
+		Offset(params.Offset).
 		Find(&signals).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch signals"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch signals")
+		return
+	}
+	localizeSignals(c, h.cfg, signals)
+	
+	projected, ok := projectList(c, signals)
+	if !ok {
 		return
 	}
 	
 	c.JSON(http.StatusOK, gin.H{
-		"signals": signals,
+		"signals": projected,
 		"total":   len(signals),
 	})
 }
 
 func (h *SignalHandler) GetSignalsBySymbol(c *gin.Context) {
 	symbol := c.Param("symbol")
-	limitStr := c.DefaultQuery("limit", "20")
+	params := queryParams(c)
 	
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
+	limit := params.Limit
+	if limit == 0 {
 		limit = 20
 	}
 	if limit > 100 {
@@ -76,14 +90,229 @@ func (h *SignalHandler) GetSignalsBySymbol(c *gin.Context) {
 	if err := h.db.Where("symbol = ?", symbol).
 		Order("created_at desc").
 		Limit(limit).
+		Offset(params.Offset).
 		Find(&signals).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch signals"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch signals")
+		return
+	}
+	localizeSignals(c, h.cfg, signals)
+	
+	projected, ok := projectList(c, signals)
+	if !ok {
 		return
 	}
 	
 	c.JSON(http.StatusOK, gin.H{
 		"symbol":  symbol,
-		"signals": signals,
+		"signals": projected,
 		"total":   len(signals),
 	})
-}
\ No newline at end of file
+}
+
+// reasonLanguage 요청의 Accept-Language 로 정한 신호 근거 언어 (응답 Content-Language 도 설정)
+func reasonLanguage(c *gin.Context, cfg *config.Config) string {
+	fallback := services.DefaultReasonLanguage
+	if cfg != nil && services.SupportedReasonLanguage(cfg.Signal.ReasonLanguage) {
+		fallback = cfg.Signal.ReasonLanguage
+	}
+	lang := services.NegotiateReasonLanguage(c.GetHeader("Accept-Language"), fallback)
+	c.Header("Content-Language", lang)
+	c.Header("Vary", "Accept-Language")
+	return lang
+}
+
+// localizeSignals 저장된 근거 코드를 요청 언어 문구로 바꾼다 (응답용 사본에만 적용)
+func localizeSignals(c *gin.Context, cfg *config.Config, signals []models.TradingSignal) {
+	lang := reasonLanguage(c, cfg)
+	for i := range signals {
+		signals[i].Reasons = services.LocalizeReasonsJSON(signals[i].Reasons, lang)
+	}
+}
+
+// ExplainSignal 신호의 근거와 생성 시점 지표 값 설명
+// GET /signals/explain/:id
+func (h *SignalHandler) ExplainSignal(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid signal id")
+		return
+	}
+
+	var signal models.TradingSignal
+	if err := h.db.First(&signal, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "Signal not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Database error")
+		return
+	}
+
+	explanation, err := services.ExplainSignal(&signal)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to explain signal", err.Error())
+		return
+	}
+	explanation.Localize(reasonLanguage(c, h.cfg))
+	explanation.Indicators = indicatorPrecision(h.cfg).RoundMap(explanation.Indicators)
+
+	c.JSON(http.StatusOK, gin.H{"explanation": explanation})
+}
+
+// 신호 내보내기 형식
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+)
+
+// signalExportColumns CSV 내보내기 헤더 (JSON 내보내기의 필드 이름과 같다)
+var signalExportColumns = []string{
+	"id", "symbol", "signal_type", "strength", "confidence", "source", "model", "provider",
+	"reasons", "outcome", "indicator_snapshot", "created_at",
+}
+
+// ExportSignals 기간 내 모든 신호를 감사용 CSV/JSON 으로 내보내기 (근거는 Accept-Language 문구로)
+// GET /signals/export?from=&to=&format=csv|json (대량 내보내기를 위해 행 단위로 스트리밍)
+func (h *SignalHandler) ExportSignals(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", exportFormatCSV))
+	if format != exportFormatCSV && format != exportFormatJSON {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, fmt.Sprintf("Invalid format %q, expected csv or json", format))
+		return
+	}
+
+	params := queryParams(c)
+	query := h.db.Model(&models.TradingSignal{})
+	if params.From != nil {
+		query = query.Where("created_at >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("created_at < ?", params.To.AddDate(0, 0, 1))
+	}
+
+	rows, err := query.Order("created_at ASC, id ASC").Rows()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to export signals")
+		return
+	}
+	defer rows.Close()
+
+	// 헤더를 보낸 뒤에는 상태 코드를 바꿀 수 없으므로 이후 에러는 로그로만 남긴다
+	lang := reasonLanguage(c, h.cfg)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=signals.%s", format))
+	if format == exportFormatCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		c.Header("Content-Type", "application/json; charset=utf-8")
+	}
+	c.Status(http.StatusOK)
+
+	var encode func(models.TradingSignal) error
+	var finish func() error
+	if format == exportFormatCSV {
+		writer := csv.NewWriter(c.Writer)
+		if err := writer.Write(signalExportColumns); err != nil {
+			log.Printf("Failed to write signal export header: %v", err)
+			return
+		}
+		encode = func(signal models.TradingSignal) error {
+			return writer.Write(signalExportRecord(signal))
+		}
+		finish = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	} else {
+		count := 0
+		c.Writer.WriteString("[")
+		encode = func(signal models.TradingSignal) error {
+			data, err := json.Marshal(signalExportObject(signal))
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				c.Writer.WriteString(",")
+			}
+			count++
+			_, err = c.Writer.Write(data)
+			return err
+		}
+		finish = func() error {
+			_, err := c.Writer.WriteString("]")
+			return err
+		}
+	}
+
+	for rows.Next() {
+		var signal models.TradingSignal
+		if err := h.db.ScanRows(rows, &signal); err != nil {
+			log.Printf("Failed to scan signal for export: %v", err)
+			return
+		}
+		signal.Reasons = services.LocalizeReasonsJSON(signal.Reasons, lang)
+		if err := encode(signal); err != nil {
+			log.Printf("Failed to write signal %d to export: %v", signal.ID, err)
+			return
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Signal export aborted: %v", err)
+		return
+	}
+	if err := finish(); err != nil {
+		log.Printf("Failed to finish signal export: %v", err)
+	}
+}
+
+// signalExportRecord CSV 한 행 (reasons, indicator_snapshot 은 저장된 JSON 문자열 그대로)
+func signalExportRecord(signal models.TradingSignal) []string {
+	return []string{
+		strconv.FormatUint(uint64(signal.ID), 10),
+		signal.Symbol,
+		signal.SignalType,
+		strconv.FormatFloat(signal.Strength, 'f', -1, 64),
+		strconv.FormatFloat(signal.Confidence, 'f', -1, 64),
+		signal.Source,
+		signal.Model,
+		signal.Provider,
+		signal.Reasons,
+		signal.Outcome,
+		signal.IndicatorSnapshot,
+		signal.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// signalExportObject JSON 내보내기 항목 (값이 없는 outcome, indicator_snapshot 은 null)
+func signalExportObject(signal models.TradingSignal) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                 signal.ID,
+		"symbol":             signal.Symbol,
+		"signal_type":        signal.SignalType,
+		"strength":           signal.Strength,
+		"confidence":         signal.Confidence,
+		"source":             signal.Source,
+		"model":              signal.Model,
+		"provider":           signal.Provider,
+		"reasons":            rawJSONOrNull(signal.Reasons),
+		"outcome":            stringOrNull(signal.Outcome),
+		"indicator_snapshot": rawJSONOrNull(signal.IndicatorSnapshot),
+		"created_at":         signal.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// rawJSONOrNull JSON 컬럼 값을 그대로 담는다 (빈 값은 null, JSON 이 아닌 옛 데이터는 감사용으로 문자열 그대로)
+func rawJSONOrNull(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	if !json.Valid([]byte(value)) {
+		return value
+	}
+	return json.RawMessage(value)
+}
+
+func stringOrNull(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
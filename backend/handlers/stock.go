@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,30 +16,103 @@ import (
 )
 
 type StockHandler struct {
-	db  *gorm.DB
-	cfg *config.Config
+	db         *gorm.DB
+	cfg        *config.Config
+	cache      *services.CacheService
+	features   *services.FeatureFlags
+	priceBook  *services.PriceBook
+	indicators *services.IndicatorService // 지표 시계열 계산 (없으면 요청마다 캐시 없이 계산)
+
+	cachedPrices PriceCache          // source=cache/auto (없으면 캐시를 건너뜀)
+	storedPrices PriceSource         // source=db/auto
+	livePrices   PriceSource         // source=live/auto (없으면 API 를 건너뜀)
+	priceSource  services.DataSource // source 를 생략한 가격 요청의 데이터 출처
 }
 
 func NewStockHandler(db *gorm.DB, cfg *config.Config) *StockHandler {
-	return &StockHandler{db: db, cfg: cfg}
+	return &StockHandler{
+		db:           db,
+		cfg:          cfg,
+		storedPrices: services.NewStoredPrices(db),
+		priceSource:  services.SourceAuto,
+	}
+}
+
+// WithCache 종목 목록 조회에 쓸 캐시 설정 (캐시를 쓸 수 없으면 DB 에서 읽는다)
+func (h *StockHandler) WithCache(cache *services.CacheService) *StockHandler {
+	h.cache = cache
+	return h
+}
+
+// WithFeatures 지표 시계열에서 켜고 끌 수 있는 지표를 정할 기능 플래그 설정 (nil 이면 기본값)
+func (h *StockHandler) WithFeatures(features *services.FeatureFlags) *StockHandler {
+	h.features = features
+	return h
+}
+
+// WithIndicators 지표 시계열 계산에 쓸 지표 서비스 설정 (신호 생성과 같은 계산 캐시를 쓰도록 공유 서비스를 넘긴다)
+func (h *StockHandler) WithIndicators(indicators *services.IndicatorService) *StockHandler {
+	h.indicators = indicators
+	return h
+}
+
+// WithPriceBook 가격 스트림과 source=cache/auto 에 쓸 메모리 가격 북 설정
+// 가격 북에 없으면 WithCache 로 설정한 캐시를 보므로 WithCache 뒤에 호출한다.
+func (h *StockHandler) WithPriceBook(book *services.PriceBook) *StockHandler {
+	h.priceBook = book
+	h.cachedPrices = services.NewCachedPrices(book, h.cache)
+	return h
+}
+
+// WithLivePrices source=live/auto 에서 API 로 현재가를 바로 수집할 곳 설정 (services.DataCollectorService)
+func (h *StockHandler) WithLivePrices(live PriceSource) *StockHandler {
+	h.livePrices = live
+	return h
+}
+
+// WithPriceSources 가격 조회 출처를 한꺼번에 교체 (nil 이면 해당 출처를 건너뜀)
+func (h *StockHandler) WithPriceSources(cached PriceCache, stored, live PriceSource) *StockHandler {
+	h.cachedPrices, h.storedPrices, h.livePrices = cached, stored, live
+	return h
+}
+
+// WithDefaultPriceSource source 를 생략한 가격 요청의 데이터 출처 설정 (기본은 auto)
+func (h *StockHandler) WithDefaultPriceSource(source services.DataSource) *StockHandler {
+	h.priceSource = source
+	return h
 }
 
 func (h *StockHandler) GetStocks(c *gin.Context) {
 	var stocks []models.Stock
 	
-	market := c.Query("market") // KR or US
-	query := h.db.Where("is_active = ?", true)
-	
-	if market != "" {
-		query = query.Where("market = ?", market)
+	market := queryParams(c).Market // KR or US
+	cached := false
+	if h.cache != nil {
+		if list, err := h.cache.GetStocks(market); err == nil {
+			stocks, cached = list, true
+		}
+	}
+
+	if !cached {
+		query := h.db.Where("is_active = ?", true)
+		if market != "" {
+			query = query.Where("market = ?", market)
+		}
+
+		if err := query.Find(&stocks).Error; err != nil {
+			respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch stocks")
+			return
+		}
+		if h.cache != nil {
+			h.cache.SetStocks(market, stocks)
+		}
 	}
 	
-	if err := query.Find(&stocks).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stocks"})
+	projected, ok := projectList(c, stocks)
+	if !ok {
 		return
 	}
-	
-	c.JSON(http.StatusOK, gin.H{"stocks": stocks})
+	c.JSON(http.StatusOK, gin.H{"stocks": projected})
 }
 
 func (h *StockHandler) GetStock(c *gin.Context) {
@@ -43,10 +121,10 @@ func (h *StockHandler) GetStock(c *gin.Context) {
 	var stock models.Stock
 	if err := h.db.Where("symbol = ?", symbol).First(&stock).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Stock not found"})
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "Stock not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Database error")
 		return
 	}
 	
@@ -55,20 +133,97 @@ func (h *StockHandler) GetStock(c *gin.Context) {
 
 func (h *StockHandler) GetStockPrice(c *gin.Context) {
 	symbol := c.Param("symbol")
-	
-	var price models.StockPrice
-	if err := h.db.Where("symbol = ?", symbol).
-		Order("timestamp desc").
-		First(&price).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Price data not found"})
+	source := requestedSource(c, h.priceSource)
+
+	price, servedFrom, err := h.loadPrice(c.Request.Context(), symbol, source)
+	if err != nil {
+		if errors.Is(err, services.ErrSourceMiss) && source != services.SourceCache {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "Price data not found")
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		respondSourceError(c, source, "Failed to get price", err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{"price": price})
+	// 오늘 장중 분봉을 모은 미완성 일봉 (세션 분봉이 아직 없으면 null)
+	now := time.Now()
+	var session *services.SessionBar
+	if bar, ok := services.DefaultSessionBars.Current(price.Symbol, price.Market, now); ok {
+		session = &bar
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"price":           price,
+		"source":          servedFrom,
+		"current_session": session,
+		"freshness":       NewFreshness(price.Timestamp, now, staleAfter(h.cfg)),
+	})
+}
+
+// 가격 이력 페이지 크기 (limit 파라미터가 없을 때 / 최대)
+const (
+	defaultPriceHistoryLimit = 100
+	maxPriceHistoryLimit     = 1000
+)
+
+// GetPriceHistory 저장된 가격 이력을 최신순으로 커서 페이지네이션
+// GET /stocks/:symbol/prices?interval=daily&limit=100&cursor=<next_cursor>&fields=timestamp,close_price
+// offset 대신 마지막 행의 (timestamp, id) 이후만 조회하므로 오래된 구간도 인덱스로 바로 찾아간다.
+// 다음 페이지가 없으면 next_cursor 는 null 이다.
+func (h *StockHandler) GetPriceHistory(c *gin.Context) {
+	symbol := c.Param("symbol")
+	params := queryParams(c)
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = defaultPriceHistoryLimit
+	}
+	if limit > maxPriceHistoryLimit {
+		limit = maxPriceHistoryLimit
+	}
+
+	query := h.db.Where("symbol = ?", symbol)
+	if params.Interval != "" {
+		query = query.Where("granularity = ?", params.Interval)
+	} else {
+		query = query.Where("granularity IN ?", models.PriceSeriesGranularities)
+	}
+	if params.From != nil {
+		query = query.Where("timestamp >= ?", *params.From)
+	}
+	if params.To != nil {
+		query = query.Where("timestamp < ?", params.To.AddDate(0, 0, 1))
+	}
+	if cursor := params.Cursor; cursor != nil {
+		query = query.Where("timestamp < ? OR (timestamp = ? AND id < ?)", cursor.Timestamp, cursor.Timestamp, cursor.ID)
+	}
+
+	// 다음 페이지 존재 여부를 알기 위해 한 건 더 조회
+	var prices []models.StockPrice
+	if err := query.Order("timestamp DESC, id DESC").Limit(limit + 1).Find(&prices).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price history")
+		return
+	}
+
+	var nextCursor *string
+	if len(prices) > limit {
+		prices = prices[:limit]
+		last := prices[limit-1]
+		next := Cursor{Timestamp: last.Timestamp, ID: last.ID}.String()
+		nextCursor = &next
+	}
+
+	projected, ok := projectList(c, prices)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"symbol":      symbol,
+		"interval":    params.Interval,
+		"prices":      projected,
+		"next_cursor": nextCursor,
+	})
 }
 
 func (h *StockHandler) GetIndicators(c *gin.Context) {
@@ -79,17 +234,258 @@ func (h *StockHandler) GetIndicators(c *gin.Context) {
 		Order("calculated_at desc").
 		Limit(50).
 		Find(&indicators).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch indicators"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch indicators")
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{"indicators": indicators})
+	// 저장된 값은 전체 정밀도이므로 응답에서만 지표별 자릿수로 반올림
+	precision := indicatorPrecision(h.cfg)
+	for i := range indicators {
+		indicators[i].IndicatorValue = precision.RoundJSON(indicators[i].IndicatorName, indicators[i].IndicatorValue)
+	}
+
+	response := gin.H{"indicators": indicators}
+	if len(indicators) > 0 {
+		response["freshness"] = NewFreshness(indicators[0].CalculatedAt, time.Now(), staleAfter(h.cfg))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// 지표 시계열 한 번에 계산할 최대 봉 수 (일봉 약 20년)
+const maxIndicatorSeriesBars = 5000
+
+// GetIndicatorSeries 차트에 겹쳐 그릴 지표 시계열을 봉 시각에 맞춰 반환 (기본: 최근 1년 일봉)
+// GET /stocks/:symbol/indicators/series?names=rsi,sma_20&from=2024-01-01&to=2024-12-31&interval=daily
+func (h *StockHandler) GetIndicatorSeries(c *gin.Context) {
+	symbol := c.Param("symbol")
+	params := queryParams(c)
+
+	indicatorService := h.indicators
+	if indicatorService == nil {
+		indicatorService = services.NewIndicatorService().WithFeatures(h.features)
+	}
+	names := parseListQuery(c, "names")
+	if err := indicatorService.ValidateSeriesNames(names); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	interval := params.Interval
+	if interval == "" {
+		interval = models.GranularityDaily
+	}
+	to := time.Now()
+	if params.To != nil {
+		to = params.To.Add(24*time.Hour - time.Nanosecond)
+	}
+	from := to.AddDate(-1, 0, 0)
+	if params.From != nil {
+		from = *params.From
+	}
+
+	var bars []models.StockPrice
+	if err := h.db.Where("symbol = ? AND granularity = ? AND timestamp >= ? AND timestamp <= ?", symbol, interval, from, to).
+		Order("timestamp ASC").
+		Limit(maxIndicatorSeriesBars + 1).
+		Find(&bars).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price data")
+		return
+	}
+	if len(bars) == 0 {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Price data not found")
+		return
+	}
+	if len(bars) > maxIndicatorSeriesBars {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest,
+			fmt.Sprintf("Range has more than %d bars, narrow from/to", maxIndicatorSeriesBars))
+		return
+	}
+
+	// 기간 첫 봉부터 값이 나오도록 앞쪽 봉을 함께 읽는다
+	var warmup []models.StockPrice
+	if err := h.db.Where("symbol = ? AND granularity = ? AND timestamp < ?", symbol, interval, from).
+		Order("timestamp DESC").
+		Limit(services.MinIndicatorBars - 1).
+		Find(&warmup).Error; err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch price data")
+		return
+	}
+
+	indicatorParams := services.SymbolIndicatorParams(h.db, symbol, indicatorService.Params())
+	series := indicatorService.Series(append(warmup, bars...), from, names, indicatorParams)
+
+	precision := indicatorPrecision(h.cfg)
+	for name, values := range series.Series {
+		for _, value := range values {
+			if value != nil {
+				*value = precision.Round(name, *value)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"symbol":     symbol,
+		"interval":   interval,
+		"timestamps": series.Timestamps,
+		"series":     series.Series,
+	})
+}
+
+// GetOrderBook 최신 5단계 호가와 매수/매도 잔량 불균형
+func (h *StockHandler) GetOrderBook(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	var askingPrice models.AskingPrice
+	if err := h.db.Where("symbol = ?", symbol).
+		Order("timestamp desc").
+		First(&askingPrice).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "Order book not found")
+			return
+		}
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orderbook": services.NewOrderBook(&askingPrice)})
+}
+
+// GetDrawdown 기간 내 최대 낙폭 및 underwater 곡선 (기본: 최근 1년)
+// GET /stocks/:symbol/drawdown?from=2024-01-01&to=2024-12-31
+func (h *StockHandler) GetDrawdown(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	params := queryParams(c)
+
+	to := time.Now()
+	if params.To != nil {
+		to = params.To.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	from := to.AddDate(-1, 0, 0)
+	if params.From != nil {
+		from = *params.From
+	}
+
+	report, err := services.NewAnalyticsService(h.db).Drawdown(symbol, from, to)
+	if err != nil {
+		// 기간 내 가격이 없으면 404, DB 장애는 500
+		respondWithError(c, "Failed to calculate drawdown", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"drawdown": report})
+}
+
+// GetLevels 피벗, 스윙 지지/저항, 52주 고저, 볼린저 밴드 기준 가격선
+// GET /stocks/:symbol/levels
+func (h *StockHandler) GetLevels(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	report, err := services.NewAnalyticsService(h.db).Levels(symbol)
+	if err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "Price data not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"levels":    report,
+		"freshness": NewFreshness(report.AsOf, time.Now(), staleAfter(h.cfg)),
+	})
+}
+
+// Screen 펀더멘털(PER/PBR)과 최신 신호 조건으로 종목 검색
+// GET /screener?market=KR&per_lt=10&pbr_lt=1&signal=BUY
+func (h *StockHandler) Screen(c *gin.Context) {
+	filter := services.ScreenerFilter{
+		Market: queryParams(c).Market,
+		Signal: c.Query("signal"),
+	}
+
+	var ok bool
+	if filter.PERLessThan, ok = optionalFloatQuery(c, "per_lt"); !ok {
+		return
+	}
+	if filter.PBRLessThan, ok = optionalFloatQuery(c, "pbr_lt"); !ok {
+		return
+	}
+
+	stocks, err := services.NewScreenerService(h.db).Screen(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to screen stocks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stocks": stocks,
+		"total":  len(stocks),
+	})
+}
+
+// 등락률 순위 조회 개수 (limit 파라미터가 없을 때 / 최대)
+const (
+	defaultMoversLimit = 10
+	maxMoversLimit     = 100
+)
+
+// GetMovers 최신 현재가 기준 상승/하락률 상위 종목
+// GET /analytics/movers?market=KR&limit=10&direction=up&min_volume=100000
+func (h *StockHandler) GetMovers(c *gin.Context) {
+	params := queryParams(c)
+	filter := services.MoversFilter{
+		Market:    params.Market,
+		Direction: strings.ToLower(c.DefaultQuery("direction", services.MoversUp)),
+		Limit:     params.Limit,
+	}
+	if filter.Direction != services.MoversUp && filter.Direction != services.MoversDown {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid direction, expected up or down")
+		return
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultMoversLimit
+	}
+	if filter.Limit > maxMoversLimit {
+		filter.Limit = maxMoversLimit
+	}
+
+	minVolume, err := parseIntQuery(c, "min_volume", 0)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, err.Error())
+		return
+	}
+	filter.MinVolume = int64(minVolume)
+
+	movers, err := services.NewAnalyticsService(h.db).TopMovers(filter)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to get top movers")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"direction": filter.Direction,
+		"movers":    movers,
+		"total":     len(movers),
+	})
+}
+
+// optionalFloatQuery 숫자 쿼리 파라미터 파싱 (없으면 nil, 잘못된 값이면 400 응답 후 false)
+func optionalFloatQuery(c *gin.Context, param string) (*float64, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid " + param + ", expected a number")
+		return nil, false
+	}
+	return &parsed, true
 }
 
 func (h *StockHandler) CreateStock(c *gin.Context) {
 	var stock models.Stock
 	if err := c.ShouldBindJSON(&stock); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, http.StatusBadRequest, ErrCodeBadRequest, "Invalid request body", err.Error())
 		return
 	}
 	
@@ -99,7 +495,7 @@ func (h *StockHandler) CreateStock(c *gin.Context) {
 	stock.UpdatedAt = time.Now()
 	
 	if err := h.db.Create(&stock).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create stock"})
+		respondError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to create stock")
 		return
 	}
 	
@@ -117,4 +513,12 @@ func (h *StockHandler) TriggerCollection(c *gin.Context) {
 		"symbol":  symbol,
 		"status":  "pending",
 	})
-}
\ No newline at end of file
+}
+
+// indicatorPrecision 설정된 지표 응답 자릿수 (설정이 없으면 기본값)
+func indicatorPrecision(cfg *config.Config) services.IndicatorPrecision {
+	if cfg == nil {
+		return services.NewIndicatorPrecision(config.IndicatorConfig{})
+	}
+	return services.NewIndicatorPrecision(cfg.Indicator)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"stock-recommender/backend/buildinfo"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VersionHandler 배포된 빌드 정보 조회 핸들러
+type VersionHandler struct {
+	features *services.FeatureFlags
+}
+
+func NewVersionHandler(features *services.FeatureFlags) *VersionHandler {
+	return &VersionHandler{features: features}
+}
+
+// VersionResponse 빌드 정보와 현재 기능 플래그 상태
+type VersionResponse struct {
+	buildinfo.Info
+	Features []services.FeatureFlagState `json:"features"`
+}
+
+// GetVersion 빌드 버전, 커밋, 빌드 시각, Go 버전과 기능 플래그 상태
+// GET /version
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, VersionResponse{
+		Info:     buildinfo.Get(),
+		Features: h.features.List(),
+	})
+}
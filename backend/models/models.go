@@ -8,17 +8,24 @@ import (
 
 // Stock represents a stock symbol information
 type Stock struct {
-	ID          uint           `gorm:"primarykey" json:"id"`
-	Symbol      string         `gorm:"uniqueIndex;size:20;not null" json:"symbol"`
-	Name        string         `gorm:"size:100" json:"name"`
-	Market      string         `gorm:"size:5;not null" json:"market"` // KR or US
-	Exchange    string         `gorm:"size:20" json:"exchange"`       // KOSPI, NASDAQ, etc.
-	Sector      string         `gorm:"size:50" json:"sector"`
-	Industry    string         `gorm:"size:50" json:"industry"`
-	IsActive    bool           `gorm:"default:true" json:"is_active"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primarykey" json:"id"`
+	Symbol          string         `gorm:"uniqueIndex;size:20;not null" json:"symbol"`
+	Name            string         `gorm:"size:100" json:"name"`
+	Market          string         `gorm:"size:5;not null" json:"market"` // KR or US
+	Exchange        string         `gorm:"size:20" json:"exchange"`       // KOSPI, NASDAQ, etc.
+	Sector          string         `gorm:"size:50" json:"sector"`
+	Industry        string         `gorm:"size:50" json:"industry"`
+	Precision       int            `gorm:"default:2" json:"precision"` // 가격 소수점자리수
+	MarketCap       int64          `json:"market_cap"`                 // 시가총액 (억원)
+	PER             *float64       `gorm:"column:per" json:"per"`      // 없으면 null
+	PBR             *float64       `gorm:"column:pbr" json:"pbr"`      // 없으면 null (해외 종목은 미제공)
+	IsActive        bool           `gorm:"default:true" json:"is_active"`
+	Priority        int            `gorm:"default:0" json:"priority"`                                 // 수집 우선순위 (클수록 먼저 수집)
+	IndicatorConfig string         `gorm:"type:jsonb;default:null" json:"indicator_config,omitempty"` // 종목별 지표 기간 설정 (JSON, 전역 기본값 위에 덮어씀)
+	TickerHash      string         `gorm:"size:64" json:"-"`                                          // 마지막으로 반영한 종목 목록 필드의 해시 (바뀐 종목만 갱신)
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // StockPrice represents historical and real-time stock price data
@@ -35,32 +42,120 @@ type StockPrice struct {
 	PrevClosePrice float64   `gorm:"type:decimal(12,4)" json:"prev_close_price"`
 	Change         float64   `gorm:"type:decimal(12,4)" json:"change"`
 	ChangeRate     float64   `gorm:"type:decimal(5,2)" json:"change_rate"`
+	Granularity    string    `gorm:"size:10;default:intraday" json:"granularity"` // intraday(현재가 스냅샷), daily(일봉), weekly(주봉), monthly(월봉)
 	Timestamp      time.Time `gorm:"index:idx_symbol_timestamp;not null" json:"timestamp"`
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// 주가 데이터 단위
+const (
+	GranularityIntraday = "intraday"
+	GranularityDaily    = "daily"
+	GranularityWeekly   = "weekly"
+	GranularityMonthly  = "monthly"
+)
+
+// PriceSeriesGranularities 현재가/일봉 시계열을 읽을 때의 단위 (주/월봉은 같은 테이블에 있지만 섞지 않는다)
+var PriceSeriesGranularities = []string{GranularityIntraday, GranularityDaily}
+
 // TechnicalIndicator represents calculated technical indicators
 type TechnicalIndicator struct {
-	ID            uint      `gorm:"primarykey" json:"id"`
-	Symbol        string    `gorm:"index:idx_symbol_calculated;size:20;not null" json:"symbol"`
-	IndicatorName string    `gorm:"size:50;not null" json:"indicator_name"`
-	IndicatorValue string   `gorm:"type:jsonb" json:"indicator_value"` // JSON for flexible data
-	CalculatedAt  time.Time `gorm:"index:idx_symbol_calculated;not null" json:"calculated_at"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID             uint      `gorm:"primarykey" json:"id"`
+	Symbol         string    `gorm:"index:idx_symbol_calculated;size:20;not null" json:"symbol"`
+	IndicatorName  string    `gorm:"size:50;not null" json:"indicator_name"`
+	IndicatorValue string    `gorm:"type:jsonb" json:"indicator_value"` // JSON for flexible data
+	CalculatedAt   time.Time `gorm:"index:idx_symbol_calculated;not null" json:"calculated_at"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 // TradingSignal represents buy/sell/hold signals
 type TradingSignal struct {
-	ID         uint      `gorm:"primarykey" json:"id"`
-	Symbol     string    `gorm:"index:idx_symbol_created;size:20;not null" json:"symbol"`
-	SignalType string    `gorm:"size:10;not null" json:"signal_type"` // BUY, SELL, HOLD
-	Strength   float64   `gorm:"type:decimal(3,2)" json:"strength"`   // 0.0 ~ 1.0
-	Confidence float64   `gorm:"type:decimal(3,2)" json:"confidence"` // 0.0 ~ 1.0
-	Reasons    string    `gorm:"type:jsonb" json:"reasons"`           // JSON array of reasons
-	Source     string    `gorm:"size:20" json:"source"`               // AI, RULE, MANUAL
-	CreatedAt  time.Time `gorm:"index:idx_symbol_created" json:"created_at"`
+	ID                uint      `gorm:"primarykey" json:"id"`
+	Symbol            string    `gorm:"index:idx_symbol_created;size:20;not null" json:"symbol"`
+	SignalType        string    `gorm:"size:10;not null" json:"signal_type"`                         // BUY, SELL, HOLD
+	Strength          float64   `gorm:"type:decimal(3,2)" json:"strength"`                           // 0.0 ~ 1.0
+	Confidence        float64   `gorm:"type:decimal(3,2)" json:"confidence"`                         // 0.0 ~ 1.0
+	Reasons           string    `gorm:"type:jsonb" json:"reasons"`                                   // JSON array of reasons
+	IndicatorSnapshot string    `gorm:"type:jsonb;default:null" json:"indicator_snapshot,omitempty"` // 신호 생성 시점의 지표 값 (JSON object)
+	Source            string    `gorm:"size:20" json:"source"`                                       // AI, RULE, MANUAL
+	Model             string    `gorm:"size:50" json:"model,omitempty"`                              // AI 모델명 (AI 신호인 경우)
+	Provider          string    `gorm:"size:100" json:"provider,omitempty"`                          // 결정을 내린 AI 서비스
+	Outcome           string    `gorm:"size:10" json:"outcome,omitempty"`                            // 성과 추적 상태 (open, closed)
+	CreatedAt         time.Time `gorm:"index:idx_symbol_created" json:"created_at"`
+}
+
+// 신호 성과 추적 상태
+const (
+	SignalOutcomeOpen   = "open"   // 성과 추적 중 (보존 기간이 지나도 삭제하지 않음)
+	SignalOutcomeClosed = "closed" // 성과 확정
+)
+
+// WatchlistItem represents a symbol watched for fast price refresh
+type WatchlistItem struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Symbol    string    `gorm:"uniqueIndex;size:20;not null" json:"symbol"`
+	Market    string    `gorm:"size:5;not null" json:"market"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OBVState 종목별 누적 OBV (지표 계산 창과 관계없이 새 봉이 들어올 때마다 증분 갱신)
+type OBVState struct {
+	ID               uint      `gorm:"primarykey" json:"id"`
+	Symbol           string    `gorm:"uniqueIndex;size:20;not null" json:"symbol"`
+	OBV              float64   `json:"obv"`
+	CumulativeVolume int64     `json:"cumulative_volume"`                    // 반영한 봉의 거래량 합계
+	LastClose        float64   `gorm:"type:decimal(12,4)" json:"last_close"` // 마지막으로 반영한 봉의 종가
+	LastBarAt        time.Time `json:"last_bar_at"`                          // 마지막으로 반영한 봉의 시각
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// BackfillJob 종목별 일봉 과거 데이터 백필 작업 (Cursor 이전 구간은 이미 수집함)
+type BackfillJob struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Symbol    string    `gorm:"index;size:20;not null" json:"symbol"`
+	Market    string    `gorm:"size:5;not null" json:"market"`
+	FromDate  time.Time `gorm:"not null" json:"from_date"`
+	ToDate    time.Time `gorm:"not null" json:"to_date"`
+	Cursor    time.Time `gorm:"not null" json:"cursor"`                      // 다음에 수집할 구간의 시작일
+	Status    string    `gorm:"size:10;index;default:pending" json:"status"` // pending, done, failed
+	Error     string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 백필 작업 상태
+const (
+	BackfillPending = "pending"
+	BackfillDone    = "done"
+	BackfillFailed  = "failed"
+)
+
+// BackfillUsage 날짜별 백필 API 호출 수 (재시작해도 일일 예산을 이어서 센다)
+type BackfillUsage struct {
+	Day   string `gorm:"primarykey;size:10" json:"day"` // YYYY-MM-DD (한국 시간)
+	Calls int    `json:"calls"`
 }
 
+// TickerSyncRun 거래소별 종목 목록 동기화 실행 기록 (중단되면 Cursor 다음 종목부터 이어서 진행)
+type TickerSyncRun struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	Exchange    string     `gorm:"size:10;index;not null" json:"exchange"` // 해외증시구분코드 (FY, FN, FA)
+	Status      string     `gorm:"size:10;index;not null" json:"status"`   // running, done
+	Cursor      string     `gorm:"size:20" json:"cursor"`                  // 마지막으로 처리한 종목코드 (종목코드 순으로 처리)
+	Added       int        `json:"added"`
+	Updated     int        `json:"updated"`
+	Unchanged   int        `json:"unchanged"`
+	Deactivated int        `json:"deactivated"`
+	CreatedAt   time.Time  `json:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
+
+// 종목 목록 동기화 상태
+const (
+	TickerSyncRunning = "running"
+	TickerSyncDone    = "done"
+)
+
 // NewsArticle represents news articles for sentiment analysis
 type NewsArticle struct {
 	ID             uint      `gorm:"primarykey" json:"id"`
@@ -77,12 +172,37 @@ type NewsArticle struct {
 
 // AIDecisionRequest represents data sent to AI service
 type AIDecisionRequest struct {
-	Symbol      string                 `json:"symbol"`
-	Market      string                 `json:"market"`
-	Price       StockPrice            `json:"price"`
-	Indicators  map[string]float64    `json:"indicators"`
-	NewsScore   float64               `json:"news_score,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Symbol     string                 `json:"symbol"`
+	Market     string                 `json:"market"`
+	Price      StockPrice             `json:"price"`
+	Indicators map[string]float64     `json:"indicators"`
+	History    []AIPriceBar           `json:"history,omitempty"` // 최근 봉 (오래된 순)
+	NewsScore  float64                `json:"news_score,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Model      string                 `json:"model,omitempty"`
+	MaxTokens  int                    `json:"max_tokens,omitempty"`
+}
+
+// AIPriceBar AI 요청에 담는 봉 (토큰을 아끼려고 OHLCV 만 전달)
+type AIPriceBar struct {
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    int64     `json:"volume"`
+}
+
+// NewAIPriceBar 주가 데이터를 AI 요청용 봉으로 변환
+func NewAIPriceBar(price StockPrice) AIPriceBar {
+	return AIPriceBar{
+		Timestamp: price.Timestamp,
+		Open:      price.OpenPrice,
+		High:      price.HighPrice,
+		Low:       price.LowPrice,
+		Close:     price.ClosePrice,
+		Volume:    price.Volume,
+	}
 }
 
 // AIDecisionResponse represents response from AI service
@@ -91,6 +211,8 @@ type AIDecisionResponse struct {
 	Decision   string    `json:"decision"`   // BUY/SELL/HOLD
 	Confidence float64   `json:"confidence"` // 0.0 ~ 1.0
 	Reasoning  []string  `json:"reasoning"`
+	Model      string    `json:"model,omitempty"`
+	Provider   string    `json:"provider,omitempty"`
 	Timestamp  time.Time `json:"timestamp"`
 }
 
@@ -122,4 +244,11 @@ type AskingPrice struct {
 	TotalBidVol int64     `json:"total_bid_volume"`
 	Timestamp   time.Time `gorm:"not null" json:"timestamp"`
 	CreatedAt   time.Time `json:"created_at"`
-}
\ No newline at end of file
+}
+
+// FeatureFlag 기능 플래그의 DB 설정 (환경 설정보다 우선)
+type FeatureFlag struct {
+	Name      string    `gorm:"primarykey;size:50" json:"name"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
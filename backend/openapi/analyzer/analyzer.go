@@ -0,0 +1,249 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/errors"
+)
+
+// DefaultRateLimitBackoff 호출 한도 초과 응답을 받은 워커가 다음 호출 전까지 쉬는 기본 시간
+const DefaultRateLimitBackoff = time.Second
+
+// Endpoint 부하 테스트할 API 호출 (응답 데이터 개수와 원본 데이터 반환)
+type Endpoint struct {
+	Name string
+	Call func(ctx context.Context, symbol string) (dataCount int, data interface{}, err error)
+}
+
+// Matrix 엔드포인트 × 종목 × 동시성 실행 계획
+// 동시성 단계마다 모든 엔드포인트/종목 조합을 Iterations 번씩 호출한다.
+type Matrix struct {
+	Endpoints   []Endpoint
+	Symbols     []string
+	Concurrency []int // 단계별 동시 호출 수 (비어 있으면 1)
+	Iterations  int   // 조합당 호출 횟수 (1 미만이면 1)
+}
+
+// CallResult 호출 한 번의 결과
+type CallResult struct {
+	Timestamp    string        `json:"timestamp"`
+	API          string        `json:"api"`
+	StockCode    string        `json:"stock_code"`
+	Concurrency  int           `json:"concurrency,omitempty"`
+	Success      bool          `json:"success"`
+	RateLimited  bool          `json:"rate_limited,omitempty"`
+	DataCount    int           `json:"data_count"`
+	Latency      time.Duration `json:"-"`
+	ResponseTime string        `json:"response_time"`
+	Error        string        `json:"error,omitempty"`
+	Data         interface{}   `json:"data,omitempty"`
+}
+
+// APIAnalyzer DB증권 API 호출 결과를 모아 지연 시간 통계와 리포트를 만드는 부하 테스트 도구
+// 결과 기록은 동시 호출에 안전하며, 동시 호출 수는 단계별 동시성으로 제한한다.
+type APIAnalyzer struct {
+	mu       sync.Mutex // results 보호
+	results  []CallResult
+	limiter  *client.TokenBucket
+	backoff  time.Duration
+	keepData bool
+}
+
+// New 결과가 비어 있는 분석기 생성
+func New() *APIAnalyzer {
+	return &APIAnalyzer{backoff: DefaultRateLimitBackoff}
+}
+
+// WithLimiter 호출마다 토큰을 얻고 나서 호출 (DBSecClient 를 거치는 엔드포인트는 클라이언트가 이미 한도를 지킨다)
+func (a *APIAnalyzer) WithLimiter(limiter *client.TokenBucket) *APIAnalyzer {
+	a.limiter = limiter
+	return a
+}
+
+// WithRateLimitBackoff 호출 한도 초과 응답 뒤 워커가 쉬는 시간 (0 이면 쉬지 않음)
+func (a *APIAnalyzer) WithRateLimitBackoff(backoff time.Duration) *APIAnalyzer {
+	a.backoff = backoff
+	return a
+}
+
+// WithData 성공한 호출의 응답 데이터를 결과에 남김 (상세 데이터 저장용)
+func (a *APIAnalyzer) WithData(keep bool) *APIAnalyzer {
+	a.keepData = keep
+	return a
+}
+
+// RecordCall 호출 결과 기록
+func (a *APIAnalyzer) RecordCall(api, stockCode string, success bool, dataCount int, responseTime time.Duration, err error, data interface{}) {
+	a.record(CallResult{
+		API:       api,
+		StockCode: stockCode,
+		Success:   success,
+		DataCount: dataCount,
+		Latency:   responseTime,
+		Data:      data,
+	}, err)
+}
+
+// record 시각/에러를 채워 기록하고 기록한 결과 반환
+func (a *APIAnalyzer) record(result CallResult, err error) CallResult {
+	result.Timestamp = time.Now().Format("2006-01-02 15:04:05")
+	result.ResponseTime = result.Latency.String()
+	if err != nil {
+		result.Error = err.Error()
+		result.RateLimited = isRateLimited(err)
+	}
+
+	a.mu.Lock()
+	a.results = append(a.results, result)
+	a.mu.Unlock()
+	return result
+}
+
+// Results 지금까지 기록한 결과 복사본
+func (a *APIAnalyzer) Results() []CallResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]CallResult(nil), a.results...)
+}
+
+// RunProbes 프로브 그룹을 최대 parallelism 개씩 동시에 실행 (1 이하면 순차 실행)
+func (a *APIAnalyzer) RunProbes(parallelism int, probes ...func()) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for _, probe := range probes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(probe func()) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			probe()
+		}(probe)
+	}
+	wg.Wait()
+}
+
+// Run 실행 계획의 모든 단계를 차례로 실행하고 리포트 반환 (ctx 가 끝나면 남은 호출은 하지 않는다)
+func (a *APIAnalyzer) Run(ctx context.Context, matrix Matrix) *Report {
+	levels := matrix.Concurrency
+	if len(levels) == 0 {
+		levels = []int{1}
+	}
+	iterations := matrix.Iterations
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	var stages []Stage
+	for _, level := range levels {
+		if level < 1 {
+			level = 1
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		start := time.Now()
+		results := a.runStage(ctx, matrix, level, iterations)
+		stages = append(stages, newStages(results, level, time.Since(start))...)
+	}
+	return newReport(stages, a.Results())
+}
+
+type call struct {
+	endpoint Endpoint
+	symbol   string
+}
+
+// runStage concurrency 개의 워커로 한 단계 실행
+func (a *APIAnalyzer) runStage(ctx context.Context, matrix Matrix, concurrency, iterations int) []CallResult {
+	calls := make(chan call)
+	go func() {
+		defer close(calls)
+		for i := 0; i < iterations; i++ {
+			for _, endpoint := range matrix.Endpoints {
+				for _, symbol := range matrix.Symbols {
+					select {
+					case calls <- call{endpoint: endpoint, symbol: symbol}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	var (
+		mu      sync.Mutex
+		results []CallResult
+		wg      sync.WaitGroup
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range calls {
+				result, ok := a.invoke(ctx, c, concurrency)
+				if !ok {
+					continue
+				}
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+				if result.RateLimited {
+					a.pause(ctx)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// invoke 호출 한 번 실행 (한도 대기 중에 ctx 가 끝나면 기록하지 않는다)
+func (a *APIAnalyzer) invoke(ctx context.Context, c call, concurrency int) (CallResult, bool) {
+	if err := a.limiter.Wait(ctx); err != nil {
+		return CallResult{}, false
+	}
+
+	start := time.Now()
+	count, data, err := c.endpoint.Call(ctx, c.symbol)
+	result := CallResult{
+		API:         c.endpoint.Name,
+		StockCode:   c.symbol,
+		Concurrency: concurrency,
+		Success:     err == nil,
+		Latency:     time.Since(start),
+	}
+	if err == nil {
+		result.DataCount = count
+		if a.keepData {
+			result.Data = data
+		}
+	}
+	return a.record(result, err), true
+}
+
+// pause 호출 한도 초과 뒤 쉬기
+func (a *APIAnalyzer) pause(ctx context.Context) {
+	if a.backoff <= 0 {
+		return
+	}
+	timer := time.NewTimer(a.backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// isRateLimited 호출 한도 초과 에러인지 판단 (DB증권은 한도 초과를 본문 메시지로 알린다)
+func isRateLimited(err error) bool {
+	return errors.IsRateLimitError(err) || strings.Contains(err.Error(), "호출 거래건수를 초과")
+}
@@ -0,0 +1,249 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestAPIAnalyzer_ConcurrentRecordCall(t *testing.T) {
+	analyzer := New()
+
+	const workers, callsPerWorker = 8, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < callsPerWorker; i++ {
+				analyzer.RecordCall("CurrentPrice", fmt.Sprintf("S%d", w), true, 1, time.Millisecond, nil, nil)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(analyzer.Results()) != workers*callsPerWorker {
+		t.Fatalf("Expected %d results, got %d", workers*callsPerWorker, len(analyzer.Results()))
+	}
+}
+
+func TestAPIAnalyzer_RunProbesBoundedParallelism(t *testing.T) {
+	analyzer := New()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	probe := func() {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		analyzer.RecordCall("Probe", "", true, 1, 0, nil, nil)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+
+	analyzer.RunProbes(2, probe, probe, probe, probe, probe)
+
+	if len(analyzer.Results()) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(analyzer.Results()))
+	}
+	if maxInFlight != 2 {
+		t.Errorf("Expected at most 2 probes in flight, got %d", maxInFlight)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	stats := NewLatencyStats(latencies)
+	expected := LatencyStats{Min: 1, Mean: 50.5, P50: 50, P95: 95, P99: 99, Max: 100}
+	if stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+	if got := Percentile([]time.Duration{7 * time.Millisecond}, 99); got != 7*time.Millisecond {
+		t.Errorf("Expected single value percentile, got %v", got)
+	}
+	if (NewLatencyStats(nil) != LatencyStats{}) {
+		t.Error("Expected zero stats for no calls")
+	}
+}
+
+func TestAPIAnalyzer_RunMatrixAgainstMockServer(t *testing.T) {
+	var (
+		inFlight, maxInFlight, priceCalls int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		atomic.AddInt32(&priceCalls, 1)
+		time.Sleep(5 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"rsp_cd": "00000",
+			"Out":    map[string]string{"Prpr": "190.50", "Oprc": "189.00", "Hprc": "191.00", "Lprc": "188.00"},
+		})
+	}))
+	defer server.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+	apiClient := client.NewDBSecClient(cfg)
+
+	// 호출 한도 초과를 돌려주는 엔드포인트는 실패로 세고 워커가 잠시 쉰다
+	var limitedCalls int32
+	limited := Endpoint{
+		Name: "Limited",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			if atomic.AddInt32(&limitedCalls, 1)%2 == 0 {
+				return 0, nil, errors.NewRateLimitError("호출 거래건수를 초과하였습니다")
+			}
+			return 3, nil, nil
+		},
+	}
+
+	analyzer := New().WithRateLimitBackoff(time.Millisecond)
+	report := analyzer.Run(context.Background(), Matrix{
+		Endpoints:   []Endpoint{CurrentPriceEndpoint(apiClient, models.MarketNASDAQ), limited},
+		Symbols:     []string{"AAPL", "MSFT", "NVDA", "TSLA"},
+		Concurrency: []int{1, 3},
+		Iterations:  2,
+	})
+
+	if got := atomic.LoadInt32(&priceCalls); got != 16 {
+		t.Errorf("Expected 16 price calls (4 symbols x 2 iterations x 2 stages), got %d", got)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 3 {
+		t.Errorf("Expected at most 3 calls in flight, got %d", got)
+	}
+	if len(report.Results) != 32 {
+		t.Fatalf("Expected 32 results, got %d", len(report.Results))
+	}
+
+	for _, concurrency := range []int{1, 3} {
+		stage, ok := report.Stage("CurrentPrice", concurrency)
+		if !ok {
+			t.Fatalf("Missing CurrentPrice stage at concurrency %d", concurrency)
+		}
+		if stage.Calls != 8 || stage.Success != 8 {
+			t.Errorf("Unexpected CurrentPrice stage %+v", stage)
+		}
+		latency := stage.Latency
+		if latency.P50 <= 0 || latency.P50 > latency.P95 || latency.P95 > latency.P99 || latency.P99 > latency.Max {
+			t.Errorf("Expected ordered positive percentiles, got %+v", latency)
+		}
+
+		all, ok := report.Stage(AllEndpoints, concurrency)
+		if !ok || all.Calls != 16 || all.RateLimited != 4 || all.Failures != 4 || all.Throughput <= 0 {
+			t.Errorf("Unexpected aggregate stage %+v", all)
+		}
+	}
+
+	// 세 가지 형식 모두 백분위 통계를 담는다
+	var jsonOut, csvOut, markdown bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var decoded struct {
+		Stages []struct {
+			Endpoint string             `json:"endpoint"`
+			Latency  map[string]float64 `json:"latency"`
+		} `json:"stages"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	for _, stage := range decoded.Stages {
+		for _, key := range []string{"p50_ms", "p95_ms", "p99_ms"} {
+			if _, ok := stage.Latency[key]; !ok {
+				t.Errorf("JSON stage %s missing %s", stage.Endpoint, key)
+			}
+		}
+	}
+
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if lines := strings.Count(csvOut.String(), "\n"); lines != 33 {
+		t.Errorf("Expected header and 32 CSV rows, got %d lines", lines)
+	}
+
+	if err := report.WriteMarkdown(&markdown); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	for _, want := range []string{"p50 (ms)", "p95 (ms)", "p99 (ms)", "| CurrentPrice | 3 |", "API 호출 한도 초과: 8회"} {
+		if !strings.Contains(markdown.String(), want) {
+			t.Errorf("Markdown report missing %q:\n%s", want, markdown.String())
+		}
+	}
+
+	dir := t.TempDir()
+	paths, err := report.Save(dir)
+	if err != nil || len(paths) != 3 {
+		t.Fatalf("Expected 3 saved reports, got %v, %v", paths, err)
+	}
+}
+
+func TestAPIAnalyzer_LimiterPacesCalls(t *testing.T) {
+	var calls int32
+	endpoint := Endpoint{
+		Name: "Paced",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return 1, nil, nil
+		},
+	}
+
+	// 버스트 1, 초당 50회면 5번 호출에 최소 80ms
+	analyzer := New().WithLimiter(client.NewTokenBucket(50, 1))
+	start := time.Now()
+	report := analyzer.Run(context.Background(), Matrix{
+		Endpoints:   []Endpoint{endpoint},
+		Symbols:     []string{"A", "B", "C", "D", "E"},
+		Concurrency: []int{5},
+	})
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected limiter to pace calls, finished in %v", elapsed)
+	}
+	if stage, ok := report.Stage("Paced", 5); !ok || stage.Success != 5 {
+		t.Errorf("Unexpected stage %+v", stage)
+	}
+
+	// 취소된 실행은 남은 호출을 하지 않는다
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	atomic.StoreInt32(&calls, 0)
+	report = New().Run(ctx, Matrix{Endpoints: []Endpoint{endpoint}, Symbols: []string{"A"}})
+	if atomic.LoadInt32(&calls) != 0 || len(report.Stages) != 0 {
+		t.Errorf("Expected no calls after cancel, got %d calls, %d stages", calls, len(report.Stages))
+	}
+}
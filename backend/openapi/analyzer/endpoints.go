@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"context"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	"stock-recommender/backend/openapi/models"
+)
+
+// CurrentPriceEndpoint 해외주식 현재가 (market: NYSE, NASDAQ, AMEX 등)
+func CurrentPriceEndpoint(apiClient *client.DBSecClient, market string) Endpoint {
+	service := foreign.NewForeignCurrentPriceService(apiClient)
+	marketDiv := models.DefaultMarketResolver.ForeignCode(market)
+	return Endpoint{
+		Name: "CurrentPrice",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			data, err := service.GetForeignCurrentPrice(symbol, marketDiv)
+			if err != nil {
+				return 0, nil, err
+			}
+			return 1, data, nil
+		},
+	}
+}
+
+// DayChartEndpoint 최근 days 일 해외주식 일차트 (수정주가)
+func DayChartEndpoint(apiClient *client.DBSecClient, market string, days int) Endpoint {
+	service := foreign.NewForeignDayChartService(apiClient)
+	return Endpoint{
+		Name: "DayChart",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			data, err := service.GetDayChartWithDaysContext(ctx, symbol, market, days, true)
+			return len(data), data, err
+		},
+	}
+}
+
+// WeekChartEndpoint 최근 weeks 주 해외주식 주차트 (수정주가)
+func WeekChartEndpoint(apiClient *client.DBSecClient, market string, weeks int) Endpoint {
+	service := foreign.NewForeignWeekChartService(apiClient)
+	return Endpoint{
+		Name: "WeekChart",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			data, err := service.GetWeekChartWithWeeksContext(ctx, symbol, market, weeks, true)
+			return len(data), data, err
+		},
+	}
+}
+
+// MonthChartEndpoint 최근 months 개월 해외주식 월차트 (수정주가)
+func MonthChartEndpoint(apiClient *client.DBSecClient, market string, months int) Endpoint {
+	service := foreign.NewForeignMonthChartService(apiClient)
+	return Endpoint{
+		Name: "MonthChart",
+		Call: func(ctx context.Context, symbol string) (int, interface{}, error) {
+			data, err := service.GetMonthChartWithMonthsContext(ctx, symbol, market, months, true)
+			return len(data), data, err
+		},
+	}
+}
+
+// DefaultEndpoints 현재가와 일/주/월 차트
+func DefaultEndpoints(apiClient *client.DBSecClient, market string) []Endpoint {
+	return []Endpoint{
+		CurrentPriceEndpoint(apiClient, market),
+		DayChartEndpoint(apiClient, market, 10),
+		WeekChartEndpoint(apiClient, market, 8),
+		MonthChartEndpoint(apiClient, market, 6),
+	}
+}
@@ -0,0 +1,278 @@
+package analyzer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AllEndpoints 단계의 전체 엔드포인트 합계 행 이름
+const AllEndpoints = "ALL"
+
+// LatencyStats 응답 시간 통계 (밀리초, 백분위는 nearest-rank)
+type LatencyStats struct {
+	Min  float64 `json:"min_ms"`
+	Mean float64 `json:"mean_ms"`
+	P50  float64 `json:"p50_ms"`
+	P95  float64 `json:"p95_ms"`
+	P99  float64 `json:"p99_ms"`
+	Max  float64 `json:"max_ms"`
+}
+
+// Stage 한 동시성 단계의 엔드포인트별 집계
+type Stage struct {
+	Endpoint    string       `json:"endpoint"`
+	Concurrency int          `json:"concurrency"`
+	Calls       int          `json:"calls"`
+	Success     int          `json:"success"`
+	Failures    int          `json:"failures"`
+	RateLimited int          `json:"rate_limited"`
+	DataPoints  int          `json:"data_points"`
+	ElapsedMs   float64      `json:"elapsed_ms"`
+	Throughput  float64      `json:"throughput"` // 초당 호출 수
+	Latency     LatencyStats `json:"latency"`
+}
+
+// Report 부하 테스트 결과 (단계별 집계와 호출별 결과)
+type Report struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Stages      []Stage      `json:"stages"`
+	Results     []CallResult `json:"results"`
+}
+
+func newReport(stages []Stage, results []CallResult) *Report {
+	return &Report{GeneratedAt: time.Now(), Stages: stages, Results: results}
+}
+
+// Stage 엔드포인트/동시성 단계의 집계 (없으면 false)
+func (r *Report) Stage(endpoint string, concurrency int) (Stage, bool) {
+	for _, stage := range r.Stages {
+		if stage.Endpoint == endpoint && stage.Concurrency == concurrency {
+			return stage, true
+		}
+	}
+	return Stage{}, false
+}
+
+// newStages 한 단계의 결과를 엔드포인트별로 집계하고 마지막에 전체 합계 행을 붙인다
+func newStages(results []CallResult, concurrency int, elapsed time.Duration) []Stage {
+	byEndpoint := make(map[string][]CallResult)
+	var names []string
+	for _, result := range results {
+		if _, ok := byEndpoint[result.API]; !ok {
+			names = append(names, result.API)
+		}
+		byEndpoint[result.API] = append(byEndpoint[result.API], result)
+	}
+	sort.Strings(names)
+
+	stages := make([]Stage, 0, len(names)+1)
+	for _, name := range names {
+		stages = append(stages, newStage(name, concurrency, byEndpoint[name], elapsed))
+	}
+	return append(stages, newStage(AllEndpoints, concurrency, results, elapsed))
+}
+
+func newStage(endpoint string, concurrency int, results []CallResult, elapsed time.Duration) Stage {
+	stage := Stage{Endpoint: endpoint, Concurrency: concurrency, Calls: len(results), ElapsedMs: milliseconds(elapsed)}
+	latencies := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		if result.Success {
+			stage.Success++
+			stage.DataPoints += result.DataCount
+		} else {
+			stage.Failures++
+		}
+		if result.RateLimited {
+			stage.RateLimited++
+		}
+		latencies = append(latencies, result.Latency)
+	}
+	if elapsed > 0 {
+		stage.Throughput = float64(len(results)) / elapsed.Seconds()
+	}
+	stage.Latency = NewLatencyStats(latencies)
+	return stage
+}
+
+// NewLatencyStats 응답 시간 목록의 통계 (비어 있으면 제로값)
+func NewLatencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+	return LatencyStats{
+		Min:  milliseconds(sorted[0]),
+		Mean: milliseconds(total / time.Duration(len(sorted))),
+		P50:  milliseconds(Percentile(sorted, 50)),
+		P95:  milliseconds(Percentile(sorted, 95)),
+		P99:  milliseconds(Percentile(sorted, 99)),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// Percentile 오름차순으로 정렬된 값의 p 백분위 (nearest-rank)
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*1000) / 1000
+}
+
+// WriteJSON 리포트 전체를 JSON 으로 출력
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV 호출별 결과를 CSV 로 출력
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Timestamp", "API", "StockCode", "Concurrency", "Success", "RateLimited", "DataCount", "ResponseTime", "Error"})
+	for _, result := range r.Results {
+		writer.Write([]string{
+			result.Timestamp,
+			result.API,
+			result.StockCode,
+			fmt.Sprintf("%d", result.Concurrency),
+			fmt.Sprintf("%t", result.Success),
+			fmt.Sprintf("%t", result.RateLimited),
+			fmt.Sprintf("%d", result.DataCount),
+			result.ResponseTime,
+			result.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteMarkdown 단계별 집계와 에러 요약을 마크다운으로 출력
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# DB증권 API 부하 테스트 리포트\n\n")
+	fmt.Fprintf(&b, "생성 시각: %s\n\n", r.GeneratedAt.Format("2006-01-02 15:04:05"))
+
+	b.WriteString("## 단계별 응답 시간\n\n")
+	b.WriteString("| Endpoint | Concurrency | Calls | Success | Failures | Rate limited | Throughput (/s) | p50 (ms) | p95 (ms) | p99 (ms) | Max (ms) |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	for _, stage := range r.Stages {
+		fmt.Fprintf(&b, "| %s | %d | %d | %d | %d | %d | %.2f | %.3f | %.3f | %.3f | %.3f |\n",
+			stage.Endpoint, stage.Concurrency, stage.Calls, stage.Success, stage.Failures, stage.RateLimited,
+			stage.Throughput, stage.Latency.P50, stage.Latency.P95, stage.Latency.P99, stage.Latency.Max)
+	}
+
+	errorStats := make(map[string]int)
+	for _, result := range r.Results {
+		if !result.Success {
+			errorStats[errorCategory(result)]++
+		}
+	}
+	if len(errorStats) > 0 {
+		b.WriteString("\n## 에러 통계\n\n")
+		categories := make([]string, 0, len(errorStats))
+		for category := range errorStats {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			fmt.Fprintf(&b, "- %s: %d회\n", category, errorStats[category])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// errorCategory 실패 호출의 에러를 짧게 요약
+func errorCategory(result CallResult) string {
+	switch {
+	case result.RateLimited:
+		return "API 호출 한도 초과"
+	case strings.Contains(result.Error, "authentication failed"):
+		return "인증 실패"
+	default:
+		return "기타 에러"
+	}
+}
+
+// Save dir 에 JSON/CSV/마크다운 리포트를 저장하고 파일 경로 반환
+func (r *Report) Save(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	timestamp := r.GeneratedAt.Format("2006-01-02_15-04-05")
+
+	writers := []struct {
+		ext   string
+		write func(io.Writer) error
+	}{
+		{"json", r.WriteJSON},
+		{"csv", r.WriteCSV},
+		{"md", r.WriteMarkdown},
+	}
+	paths := make([]string, 0, len(writers))
+	for _, writer := range writers {
+		path := filepath.Join(dir, fmt.Sprintf("api_results_%s.%s", timestamp, writer.ext))
+		if err := writeFile(path, writer.write); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// SaveData 성공한 호출의 응답 데이터를 호출별 JSON 파일로 저장 (WithData 로 남긴 경우)
+func (r *Report) SaveData(dir string) error {
+	timestamp := r.GeneratedAt.Format("2006-01-02_15-04-05")
+	for _, result := range r.Results {
+		if !result.Success || result.Data == nil {
+			continue
+		}
+		data, err := json.MarshalIndent(result.Data, "", "  ")
+		if err != nil {
+			continue
+		}
+		filename := fmt.Sprintf("%s_%s_%s.json", result.API, result.StockCode, timestamp)
+		if err := os.WriteFile(filepath.Join(dir, filename), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
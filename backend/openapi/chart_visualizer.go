@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -8,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"stock-recommender/backend/openapi/models"
 )
 
 type APICallResult struct {
@@ -23,10 +27,51 @@ type APICallResult struct {
 
 type ChartVisualizer struct {
 	baseDir string
+	candles string // 차트 봉 형식 (models.CandlesRegular 또는 models.CandlesHeikin)
 }
 
 func NewChartVisualizer(baseDir string) *ChartVisualizer {
-	return &ChartVisualizer{baseDir: baseDir}
+	return &ChartVisualizer{baseDir: baseDir, candles: models.CandlesRegular}
+}
+
+// WithCandles 차트 봉 형식 설정 (heikin 이면 Heikin-Ashi 봉으로 그린다)
+func (cv *ChartVisualizer) WithCandles(candles string) *ChartVisualizer {
+	cv.candles = candles
+	return cv
+}
+
+// heikinAshiRows JSON 으로 읽은 차트 행의 open/high/low/close 를 Heikin-Ashi 값으로 교체
+// 차트 API 결과는 최신순이므로 오래된 행부터 계산한다. OHLC 행 목록이 아니면 그대로 돌려준다.
+func heikinAshiRows(content interface{}) interface{} {
+	rows, ok := content.([]interface{})
+	if !ok || len(rows) == 0 {
+		return content
+	}
+
+	bars := make([]models.Bar, len(rows))
+	for i := range rows {
+		row, ok := rows[len(rows)-1-i].(map[string]interface{})
+		if !ok {
+			return content
+		}
+		open, _ := row["open"].(float64)
+		high, _ := row["high"].(float64)
+		low, _ := row["low"].(float64)
+		closePrice, _ := row["close"].(float64)
+		bars[i] = models.Bar{Open: open, High: high, Low: low, Close: closePrice}
+	}
+
+	for i, bar := range models.ToHeikinAshi(bars) {
+		row := rows[len(rows)-1-i].(map[string]interface{})
+		row["open"], row["high"], row["low"], row["close"] = bar.Open, bar.High, bar.Low, bar.Close
+	}
+	return rows
+}
+
+// chartStockCode 차트 데이터 키(MonthChart_AAPL 등)의 종목코드
+func chartStockCode(key string) string {
+	parts := strings.SplitN(key, "_", 2)
+	return parts[len(parts)-1]
 }
 
 func (cv *ChartVisualizer) GenerateHTML() error {
@@ -61,10 +106,26 @@ func (cv *ChartVisualizer) GenerateHTML() error {
 			json.Unmarshal(data, &chartContent)
 			
 			key := fmt.Sprintf("%s_%s", chartType, stockCode)
+			if cv.candles == models.CandlesHeikin && strings.HasSuffix(chartType, "Chart") {
+				chartContent = heikinAshiRows(chartContent)
+			}
 			chartData[key] = chartContent
 		}
 	}
 	
+	// 종목별 가격 소수점자리수 (CurrentPrice 결과 기준)
+	precisions := make(map[string]int)
+	for key, content := range chartData {
+		if !strings.HasPrefix(key, "CurrentPrice_") {
+			continue
+		}
+		if m, ok := content.(map[string]interface{}); ok {
+			if p, ok := m["precision"].(float64); ok {
+				precisions[strings.TrimPrefix(key, "CurrentPrice_")] = int(p)
+			}
+		}
+	}
+
 	// HTML 템플릿
 	htmlTemplate := `<!DOCTYPE html>
 <html lang="ko">
@@ -160,6 +221,7 @@ func (cv *ChartVisualizer) GenerateHTML() error {
             <p><strong>생성 시간:</strong> {{.Timestamp}}</p>
             <p><strong>분석 대상:</strong> 해외 주식 차트 데이터 (월차트, 주차트, 일차트)</p>
             <p><strong>주요 종목:</strong> AAPL, MSFT, GOOGL, AMZN, TSLA, NVDA, META</p>
+            <p><strong>봉 형식:</strong> {{if eq .Candles "heikin"}}Heikin-Ashi{{else}}일반{{end}}</p>
         </div>
 
         {{range $key, $data := .ChartData}}
@@ -173,7 +235,7 @@ func (cv *ChartVisualizer) GenerateHTML() error {
             <p><strong>데이터 포인트:</strong> {{len $data}}개</p>
             {{if $data}}
             {{with index $data 0}}
-            <p><strong>최신 데이터:</strong> {{.MonthEndDate}} - 종가: ${{printf "%.2f" .Close}}</p>
+            <p><strong>최신 데이터:</strong> {{.MonthEndDate}} - 종가: {{price $key .close}}</p>
             <p><strong>시장:</strong> {{.Market}}</p>
             <p><strong>수정주가 적용:</strong> {{if .IsAdjusted}}예{{else}}아니오{{end}}</p>
             {{end}}
@@ -220,10 +282,10 @@ func (cv *ChartVisualizer) GenerateHTML() error {
                 {{range $data}}
                 <tr>
                     <td>{{.MonthEndDate}}</td>
-                    <td>${{printf "%.2f" .Open}}</td>
-                    <td>${{printf "%.2f" .High}}</td>
-                    <td>${{printf "%.2f" .Low}}</td>
-                    <td>${{printf "%.2f" .Close}}</td>
+                    <td>{{price $key .open}}</td>
+                    <td>{{price $key .high}}</td>
+                    <td>{{price $key .low}}</td>
+                    <td>{{price $key .close}}</td>
                     <td>{{.Volume}}</td>
                     <td>{{printf "%.2f" .ChangeRate}}%</td>
                 </tr>
@@ -276,7 +338,7 @@ func (cv *ChartVisualizer) GenerateHTML() error {
         data: {
             labels: labels.reverse(),
             datasets: [{
-                label: '종가 ($)',
+                label: '종가 ({{currency $key}})',
                 data: prices.reverse(),
                 borderColor: 'rgb(75, 192, 192)',
                 backgroundColor: 'rgba(75, 192, 192, 0.2)',
@@ -297,7 +359,7 @@ func (cv *ChartVisualizer) GenerateHTML() error {
                     beginAtZero: false,
                     title: {
                         display: true,
-                        text: '가격 ($)'
+                        text: '가격 ({{currency $key}})'
                     }
                 },
                 x: {
@@ -321,6 +383,20 @@ func (cv *ChartVisualizer) GenerateHTML() error {
 	funcMap := template.FuncMap{
 		"contains": strings.Contains,
 		"replace":  strings.ReplaceAll,
+		// 종목 시장의 통화 기호와 현재가 응답의 소수점자리수(zdiv)로 가격 표시
+		// zdiv 를 모르면 통화 기본 자릿수 (원화 0, 달러 2)
+		"price": func(key string, v interface{}) string {
+			value, _ := v.(float64)
+			stockCode := chartStockCode(key)
+			precision, ok := precisions[stockCode]
+			if !ok {
+				precision = -1
+			}
+			return models.CurrencyForRegion(models.SymbolRegion(stockCode)).Format(value, precision)
+		},
+		"currency": func(key string) string {
+			return models.CurrencyForRegion(models.SymbolRegion(chartStockCode(key))).Symbol
+		},
 		"marshal": func(v interface{}) template.JS {
 			data, _ := json.Marshal(v)
 			return template.JS(data)
@@ -341,9 +417,11 @@ func (cv *ChartVisualizer) GenerateHTML() error {
 
 	data := struct {
 		Timestamp string
+		Candles   string
 		ChartData map[string]interface{}
 	}{
 		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Candles:   cv.candles,
 		ChartData: chartData,
 	}
 
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"stock-recommender/backend/config"
@@ -26,11 +28,37 @@ type DBSecClient struct {
 	appSecret         string
 	accessToken       string
 	httpClient        *http.Client
-	rateLimiter       chan struct{}
+	rateLimiter       *TokenBucket
 	tokenGenerateTime time.Time
+	tokenMu           sync.RWMutex // accessToken, tokenGenerateTime 보호
+	authMu            sync.Mutex   // 동시에 하나의 고루틴만 재인증
+	hashKeyMode       string
+	custType          string
+	slaThreshold      time.Duration
+	latency           *LatencyHistogram
+	maintenance       *MaintenanceState
 	logger            logger.Logger
 }
 
+// 고객타입
+const (
+	CustTypePersonal  = "P" // 개인
+	CustTypeCorporate = "B" // 법인
+)
+
+// DefaultRateLimiter DBSec 클라이언트가 기본으로 함께 쓰는 호출 한도 (초당 20요청)
+// 한도는 앱키 단위로 걸리므로 한 프로세스에서 클라이언트를 여러 개 만들어도 합친 호출 속도가 한도를 넘지 않게 공유한다.
+var DefaultRateLimiter = NewTokenBucket(20, 20)
+
+// 401 응답시 재인증 후 재시도 최대 횟수
+const maxAuthRetries = 1
+
+// 해시키 모드
+const (
+	HashKeyModeBody = "body" // POST 본문을 appsecret으로 HMAC-SHA256 서명
+	HashKeyModeNone = "none" // 해시키 헤더 미전송
+)
+
 // 인증 토큰 응답 구조체
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
@@ -40,25 +68,38 @@ type TokenResponse struct {
 }
 
 func NewDBSecClient(cfg *config.Config) *DBSecClient {
-	// Rate limiter: 초당 20요청으로 제한
-	rateLimiter := make(chan struct{}, 20)
-	go func() {
-		for {
-			time.Sleep(50 * time.Millisecond) // 20 requests per second
-			select {
-			case rateLimiter <- struct{}{}:
-			default:
-			}
-		}
-	}()
+	baseURL := cfg.API.DBSecBaseURL
+	if baseURL == "" {
+		baseURL = "https://openapi.dbsec.co.kr:8443"
+	}
+
+	hashKeyMode := cfg.API.DBSecHashKey
+	if hashKeyMode == "" {
+		hashKeyMode = HashKeyModeBody
+	}
+
+	custType := cfg.API.DBSecCustType
+	if custType == "" {
+		custType = CustTypePersonal
+	}
+
+	slaThreshold := cfg.API.DBSecSLA
+	if slaThreshold <= 0 {
+		slaThreshold = 2 * time.Second
+	}
 
 	client := &DBSecClient{
-		baseURL:     "https://openapi.dbsec.co.kr:8443",
-		appKey:      cfg.API.DBSecAppKey,
-		appSecret:   cfg.API.DBSecAppSecret,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
-		rateLimiter: rateLimiter,
-		logger:      logger.GetDefaultLogger().With(logger.Field{Key: "component", Value: "dbsec_client"}),
+		baseURL:      baseURL,
+		appKey:       cfg.API.DBSecAppKey,
+		appSecret:    cfg.API.DBSecAppSecret,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		rateLimiter:  DefaultRateLimiter,
+		hashKeyMode:  hashKeyMode,
+		custType:     custType,
+		slaThreshold: slaThreshold,
+		latency:      NewLatencyHistogram(),
+		maintenance:  DefaultMaintenance,
+		logger:       logger.GetDefaultLogger().With(logger.Field{Key: "component", Value: "dbsec_client"}),
 	}
 
 	// 시작시 토큰 발급
@@ -75,7 +116,7 @@ func NewDBSecClient(cfg *config.Config) *DBSecClient {
 // OAuth 인증 토큰 발급
 func (c *DBSecClient) authenticate() error {
 	c.logger.Debug("Starting authentication process")
-	
+
 	authURL := c.baseURL + "/oauth2/token"
 
 	data := url.Values{}
@@ -116,9 +157,11 @@ func (c *DBSecClient) authenticate() error {
 		return errors.NewParseError("failed to parse token response", err)
 	}
 
+	c.tokenMu.Lock()
 	c.accessToken = tokenResp.AccessToken
 	c.tokenGenerateTime = time.Now()
-	
+	c.tokenMu.Unlock()
+
 	c.logger.Info("Successfully authenticated with DBSec API",
 		logger.Field{Key: "token_type", Value: tokenResp.TokenType},
 		logger.Field{Key: "scope", Value: tokenResp.Scope},
@@ -127,11 +170,36 @@ func (c *DBSecClient) authenticate() error {
 	return nil
 }
 
+// tokenState 현재 토큰과 발급 시각
+func (c *DBSecClient) tokenState() (string, time.Time) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.accessToken, c.tokenGenerateTime
+}
+
+// refreshToken 토큰 재발급 (동시 호출시 한 번만 인증)
+// stale 은 호출자가 사용한 (만료된) 토큰으로, 그 사이 다른 고루틴이 이미 새 토큰을
+// 발급받았다면 인증을 생략한다.
+func (c *DBSecClient) refreshToken(stale string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if token, _ := c.tokenState(); token != "" && token != stale {
+		return nil
+	}
+	return c.authenticate()
+}
+
 // API 호출을 위한 공통 함수
 func (c *DBSecClient) makeRequest(method, path string, queryParams map[string]string, body interface{}) ([]byte, error) {
 	return c.MakeRequestWithHeaders(method, path, queryParams, body, nil)
 }
 
+// makeRequestContext ctx 를 따르는 makeRequest
+func (c *DBSecClient) makeRequestContext(ctx context.Context, method, path string, queryParams map[string]string, body interface{}) ([]byte, error) {
+	return c.MakeRequestWithContext(ctx, method, path, queryParams, body, nil)
+}
+
 // MakeRequestWithHeaders 추가 헤더를 포함한 API 호출
 func (c *DBSecClient) MakeRequestWithHeaders(method, path string, queryParams map[string]string, body interface{}, additionalHeaders map[string]string) ([]byte, error) {
 	return c.MakeRequestWithResponse(method, path, queryParams, body, additionalHeaders)
@@ -139,14 +207,22 @@ func (c *DBSecClient) MakeRequestWithHeaders(method, path string, queryParams ma
 
 // MakeRequestWithResponse 응답 헤더를 포함한 API 호출
 func (c *DBSecClient) MakeRequestWithResponse(method, path string, queryParams map[string]string, body interface{}, additionalHeaders map[string]string) ([]byte, error) {
-	// Rate limiting
-	<-c.rateLimiter
+	return c.MakeRequestWithContext(context.Background(), method, path, queryParams, body, additionalHeaders)
+}
 
-	// 토큰이 없으면 인증 시도
-	if c.accessToken == "" {
-		if err := c.authenticate(); err != nil {
-			return nil, fmt.Errorf("authentication failed: %w", err)
-		}
+// MakeRequestWithContext ctx 를 따르는 API 호출
+// ctx 가 취소되거나 기한이 지나면 rate limit 대기나 전송 중인 요청을 중단한다 (HTTP 요청 컨텍스트 전달용).
+func (c *DBSecClient) MakeRequestWithContext(ctx context.Context, method, path string, queryParams map[string]string, body interface{}, additionalHeaders map[string]string) ([]byte, error) {
+	respBody, _, err := c.doRequest(ctx, method, path, queryParams, body, additionalHeaders)
+	return respBody, err
+}
+
+// doRequest 공통 요청 처리 (rate limit, 인증, 401 재인증 후 재시도)
+// 요청 본문은 한 번만 직렬화하고 재시도마다 새 reader로 다시 보낸다.
+func (c *DBSecClient) doRequest(ctx context.Context, method, path string, queryParams map[string]string, body interface{}, additionalHeaders map[string]string) ([]byte, http.Header, error) {
+	jsonData, err := encodeBody(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
 	// URL 구성
@@ -159,75 +235,172 @@ func (c *DBSecClient) MakeRequestWithResponse(method, path string, queryParams m
 		fullURL += "?" + params.Encode()
 	}
 
-	// 요청 본문 준비
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
+	for attempt := 0; ; attempt++ {
+		// 점검 중에는 호출하지 않고 바로 실패 (재개 시각이 지나면 다시 호출해 본다)
+		if status := c.maintenance.Status(); status.Active {
+			return nil, nil, errors.NewMaintenanceError("DBSec API is under maintenance", fmt.Errorf("%s until %s", status.Reason, status.Until.Format(time.RFC3339)))
+		}
+
+		// Rate limiting (대기 중에 호출자가 떠나면 바로 포기)
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, nil, fmt.Errorf("request cancelled: %w", err)
+		}
+
+		// 토큰이 없으면 인증 시도
+		if token, _ := c.tokenState(); token == "" {
+			if err := c.refreshToken(""); err != nil {
+				return nil, nil, errors.NewAuthError("authentication failed", err)
+			}
+		}
+
+		resp, respBody, token, err := c.send(ctx, method, fullURL, path, jsonData, additionalHeaders)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, err
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			c.maintenance.Clear()
+			return respBody, resp.Header, nil
+		}
+
+		if isMaintenanceResponse(resp.StatusCode, resp.Header, respBody) {
+			wait := retryAfter(resp.Header, time.Now())
+			c.maintenance.Enter(fmt.Sprintf("status %d", resp.StatusCode), wait)
+			c.logger.Warn("DBSec API is under maintenance, pausing requests",
+				logger.Field{Key: "path", Value: path},
+				logger.Field{Key: "status_code", Value: resp.StatusCode},
+				logger.Field{Key: "until", Value: c.maintenance.Status().Until})
+			return nil, nil, errors.NewMaintenanceError("DBSec API is under maintenance",
+				fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody)))
+		}
+
+		// 토큰 만료 등의 경우 재인증 후 재시도 (최대 maxAuthRetries 회)
+		if resp.StatusCode == http.StatusUnauthorized {
+			if attempt >= maxAuthRetries {
+				c.logger.Error("Request still unauthorized after re-authentication", fmt.Errorf("status: %d", resp.StatusCode),
+					logger.Field{Key: "method", Value: method},
+					logger.Field{Key: "path", Value: path})
+				return nil, nil, errors.NewAuthError("request unauthorized after re-authentication",
+					fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody)))
+			}
+
+			c.logger.Info("Token expired, attempting re-authentication")
+			if err := c.refreshToken(token); err != nil {
+				c.logger.Error("Re-authentication failed", err)
+				return nil, nil, errors.NewAuthError("re-authentication failed", err)
+			}
+			c.logger.Debug("Re-authentication successful, retrying request")
+			continue
 		}
+
+		c.logger.Error("API request failed", fmt.Errorf("status: %d", resp.StatusCode),
+			logger.Field{Key: "method", Value: method},
+			logger.Field{Key: "path", Value: path},
+			logger.Field{Key: "status_code", Value: resp.StatusCode},
+			logger.Field{Key: "response_body", Value: string(respBody)})
+
+		return nil, nil, errors.NewNetworkError("API request failed", fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody)))
+	}
+}
+
+// send 단일 HTTP 요청 실행, 응답과 함께 요청에 사용한 토큰을 반환
+func (c *DBSecClient) send(ctx context.Context, method, fullURL, path string, jsonData []byte, additionalHeaders map[string]string) (*http.Response, []byte, string, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
 		reqBody = bytes.NewReader(jsonData)
 	}
 
 	// HTTP 요청 생성
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// 헤더 설정
-	c.setCommonHeaders(req, path, queryParams)
-
-	// 추가 헤더 설정
-	for key, value := range additionalHeaders {
-		req.Header.Set(key, value)
-	}
+	// 헤더 설정 (추가 헤더가 기본값보다 우선)
+	c.setCommonHeaders(req, path, jsonData, additionalHeaders)
+	token := requestToken(req)
 
 	// 요청 실행
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		c.observeLatency(method, path, time.Since(start))
+		return nil, nil, token, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// 응답 읽기
 	respBody, err := io.ReadAll(resp.Body)
+	c.observeLatency(method, path, time.Since(start))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, token, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	// 상태 코드 확인
-	if resp.StatusCode != http.StatusOK {
-		// 토큰 만료 등의 경우 재인증 시도
-		if resp.StatusCode == http.StatusUnauthorized {
-			c.logger.Info("Token expired, attempting re-authentication")
-			if err := c.authenticate(); err == nil {
-				c.logger.Debug("Re-authentication successful, retrying request")
-				// 재인증 성공시 요청 재시도
-				return c.MakeRequestWithResponse(method, path, queryParams, body, additionalHeaders)
-			} else {
-				c.logger.Error("Re-authentication failed", err)
-			}
-		}
-		
-		c.logger.Error("API request failed", fmt.Errorf("status: %d", resp.StatusCode),
+	return resp, respBody, token, nil
+}
+
+// encodeBody 요청 본문을 바이트로 변환 (reader는 한 번만 읽어 재시도에 재사용)
+func encodeBody(body interface{}) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return b, nil
+	case io.Reader:
+		return io.ReadAll(b)
+	default:
+		return json.Marshal(body)
+	}
+}
+
+// observeLatency 응답시간 기록 및 SLA 초과시 경고 로그
+func (c *DBSecClient) observeLatency(method, path string, duration time.Duration) {
+	slow := duration > c.slaThreshold
+	c.latency.Observe(path, duration, slow)
+	if slow {
+		c.logger.Warn("Slow DBSec API request",
 			logger.Field{Key: "method", Value: method},
 			logger.Field{Key: "path", Value: path},
-			logger.Field{Key: "status_code", Value: resp.StatusCode},
-			logger.Field{Key: "response_body", Value: string(respBody)})
-		
-		return nil, errors.NewNetworkError("API request failed", fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody)))
+			logger.Field{Key: "duration_ms", Value: duration.Milliseconds()},
+			logger.Field{Key: "sla_ms", Value: c.slaThreshold.Milliseconds()})
 	}
+}
 
-	return respBody, nil
+// RateLimiter DB증권 요청이 쓰는 호출 한도 버킷 (같은 한도를 나눠 쓸 작업에 넘긴다)
+func (c *DBSecClient) RateLimiter() *TokenBucket {
+	return c.rateLimiter
+}
+
+// WithRateLimiter 호출 한도 버킷 교체 (기본은 DefaultRateLimiter 공유)
+func (c *DBSecClient) WithRateLimiter(limiter *TokenBucket) *DBSecClient {
+	c.rateLimiter = limiter
+	return c
+}
+
+// WithMaintenance 점검 상태를 다른 클라이언트와 따로 관리 (기본은 DefaultMaintenance 공유)
+func (c *DBSecClient) WithMaintenance(maintenance *MaintenanceState) *DBSecClient {
+	c.maintenance = maintenance
+	return c
+}
+
+// Maintenance 클라이언트가 따르는 점검 상태
+func (c *DBSecClient) Maintenance() *MaintenanceState {
+	return c.maintenance
+}
+
+// LatencyStats 엔드포인트별 응답시간 통계
+func (c *DBSecClient) LatencyStats() map[string]LatencySummary {
+	return c.latency.Snapshot()
 }
 
 // 공통 헤더 설정
-func (c *DBSecClient) setCommonHeaders(req *http.Request, path string, queryParams map[string]string) {
+// additionalHeaders 는 기본 헤더 설정 후 적용되어 같은 키의 기본값을 덮어쓴다.
+func (c *DBSecClient) setCommonHeaders(req *http.Request, path string, body []byte, additionalHeaders map[string]string) {
 	// 기본 헤더
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	token, _ := c.tokenState()
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("appkey", c.appKey)
 	req.Header.Set("appsecret", c.appSecret)
 
@@ -235,16 +408,25 @@ func (c *DBSecClient) setCommonHeaders(req *http.Request, path string, queryPara
 	trId := c.getTransactionId(path)
 	req.Header.Set("tr_id", trId)
 
-	// 고객 타입 (기본값)
-	req.Header.Set("custtype", "P")
+	// 고객 타입 (P: 개인, B: 법인)
+	req.Header.Set("custtype", c.custType)
+
+	// 해시키 생성 (본문이 있는 POST 요청의 경우)
+	if req.Method == "POST" && len(body) > 0 && c.hashKeyMode == HashKeyModeBody {
+		req.Header.Set("hashkey", c.generateHashKey(body))
+	}
 
-	// 해시키 생성 (POST 요청의 경우)
-	if req.Method == "POST" && req.Body != nil {
-		hashKey := c.generateHashKey(queryParams)
-		req.Header.Set("hashkey", hashKey)
+	// 요청별 추가 헤더
+	for key, value := range additionalHeaders {
+		req.Header.Set(key, value)
 	}
 }
 
+// requestToken 요청에 실린 토큰
+func requestToken(req *http.Request) string {
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+}
+
 // API 경로에 따른 트랜잭션 ID 반환
 func (c *DBSecClient) getTransactionId(path string) string {
 	switch path {
@@ -284,22 +466,11 @@ func (c *DBSecClient) getTransactionId(path string) string {
 }
 
 // 해시키 생성 (POST 요청용)
-func (c *DBSecClient) generateHashKey(params map[string]string) string {
-	// 파라미터를 정렬된 문자열로 변환
-	var paramPairs []string
-	for k, v := range params {
-		paramPairs = append(paramPairs, fmt.Sprintf("%s=%s", k, v))
-	}
-
-	// 파라미터 문자열 생성
-	paramString := strings.Join(paramPairs, "&")
-
-	// HMAC-SHA256으로 해시 생성
+// 전송되는 JSON 본문 그대로를 appsecret으로 HMAC-SHA256 서명한다.
+func (c *DBSecClient) generateHashKey(body []byte) string {
 	h := hmac.New(sha256.New, []byte(c.appSecret))
-	h.Write([]byte(paramString))
-	hash := base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	return hash
+	h.Write(body)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
 }
 
 // 헬스체크
@@ -308,12 +479,13 @@ func (c *DBSecClient) HealthCheck() error {
 		return fmt.Errorf("API credentials not configured")
 	}
 
-	if c.accessToken == "" {
-		return c.authenticate()
+	token, generatedAt := c.tokenState()
+	if token == "" {
+		return c.refreshToken("")
 	}
 
-	if time.Since(c.tokenGenerateTime) > time.Duration(23)*time.Hour {
-		return c.authenticate()
+	if time.Since(generatedAt) > time.Duration(23)*time.Hour {
+		return c.refreshToken(token)
 	}
 
 	return nil
@@ -339,6 +511,6 @@ func (c *DBSecClient) HasValidCredentials() bool {
 
 // 토큰 재발급
 func (c *DBSecClient) RefreshToken() error {
-	c.accessToken = ""
-	return c.authenticate()
+	token, _ := c.tokenState()
+	return c.refreshToken(token)
 }
@@ -0,0 +1,409 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/logger"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestDBSecClient_HashKey(t *testing.T) {
+	var gotBody []byte
+	var gotHashKey string
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHashKey = r.Header.Get("hashkey")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	})
+	defer mockServer.Close()
+
+	sign := func(data []byte) string {
+		h := hmac.New(sha256.New, []byte("test-secret"))
+		h.Write(data)
+		return base64.StdEncoding.EncodeToString(h.Sum(nil))
+	}
+
+	reqBody := models.ForeignCurrentPriceRequest{
+		In: models.ForeignCurrentPriceInput{
+			InputCondMrktDivCode: models.ForeignMarketNASDAQ,
+			InputIscd1:           "AAPL",
+		},
+	}
+
+	t.Run("SignsBody", func(t *testing.T) {
+		apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+		if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, reqBody, nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		if gotHashKey == "" {
+			t.Fatal("Expected hashkey header on POST with body")
+		}
+		utils.AssertStringEqual(t, sign(gotBody), gotHashKey, "Hashkey")
+		if gotHashKey == sign(nil) {
+			t.Error("Hashkey must reflect the request body, not empty query params")
+		}
+	})
+
+	t.Run("NoBody", func(t *testing.T) {
+		apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+		if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		if gotHashKey != "" {
+			t.Errorf("Expected no hashkey without body, got %s", gotHashKey)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := utils.CreateMockServerConfig(mockServer)
+		cfg.API.DBSecHashKey = HashKeyModeNone
+		apiClient := NewDBSecClient(cfg)
+		if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, reqBody, nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		if gotHashKey != "" {
+			t.Errorf("Expected no hashkey when disabled, got %s", gotHashKey)
+		}
+	})
+}
+
+func TestDBSecClient_Headers(t *testing.T) {
+	var gotHeaders http.Header
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	})
+	defer mockServer.Close()
+
+	t.Run("DefaultCustType", func(t *testing.T) {
+		apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+		if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		utils.AssertStringEqual(t, CustTypePersonal, gotHeaders.Get("custtype"), "Custtype")
+		utils.AssertStringEqual(t, models.TrIdForeignStockCurrentPrice, gotHeaders.Get("tr_id"), "Transaction ID")
+	})
+
+	t.Run("CorporateWithOverride", func(t *testing.T) {
+		cfg := utils.CreateMockServerConfig(mockServer)
+		cfg.API.DBSecCustType = CustTypeCorporate
+		apiClient := NewDBSecClient(cfg)
+
+		_, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, map[string]string{
+			"tr_id":   "CUSTOM",
+			"cont_yn": "Y",
+		})
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		utils.AssertStringEqual(t, CustTypeCorporate, gotHeaders.Get("custtype"), "Custtype")
+		utils.AssertStringEqual(t, "CUSTOM", gotHeaders.Get("tr_id"), "Overridden transaction ID")
+		utils.AssertStringEqual(t, "Y", gotHeaders.Get("cont_yn"), "Additional header")
+	})
+
+	t.Run("FullResponseOverride", func(t *testing.T) {
+		apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+		_, err := apiClient.MakeRequestWithFullResponse("POST", models.PathForeignStockCurrentPrice, nil, nil, map[string]string{
+			"custtype": CustTypeCorporate,
+		})
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		utils.AssertStringEqual(t, CustTypeCorporate, gotHeaders.Get("custtype"), "Overridden custtype")
+	})
+}
+
+// recordingLogger 경고 로그를 기록하는 테스트용 로거
+type recordingLogger struct {
+	mu    sync.Mutex
+	warns []string
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field)            {}
+func (l *recordingLogger) Info(msg string, fields ...logger.Field)             {}
+func (l *recordingLogger) Error(msg string, err error, fields ...logger.Field) {}
+func (l *recordingLogger) With(fields ...logger.Field) logger.Logger           { return l }
+
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns = append(l.warns, msg)
+}
+
+func TestDBSecClient_SlowRequestWarning(t *testing.T) {
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == models.PathForeignStockDayChart {
+			time.Sleep(80 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	})
+	defer mockServer.Close()
+
+	cfg := utils.CreateMockServerConfig(mockServer)
+	cfg.API.DBSecSLA = 40 * time.Millisecond
+	apiClient := NewDBSecClient(cfg)
+	recorder := &recordingLogger{}
+	apiClient.logger = recorder
+
+	if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if len(recorder.warns) != 0 {
+		t.Fatalf("Expected no warning for fast request, got %v", recorder.warns)
+	}
+
+	if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockDayChart, nil, nil, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if len(recorder.warns) != 1 || recorder.warns[0] != "Slow DBSec API request" {
+		t.Fatalf("Expected one slow request warning, got %v", recorder.warns)
+	}
+
+	stats := apiClient.LatencyStats()
+	if stats[models.PathForeignStockDayChart].SlowCount != 1 {
+		t.Errorf("Expected slow count 1, got %d", stats[models.PathForeignStockDayChart].SlowCount)
+	}
+	if stats[models.PathForeignStockCurrentPrice].Count != 1 || stats[models.PathForeignStockCurrentPrice].SlowCount != 0 {
+		t.Errorf("Unexpected stats for fast endpoint: %+v", stats[models.PathForeignStockCurrentPrice])
+	}
+}
+
+func TestDBSecClient_ConcurrentTokenRefresh(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		validToken string
+		authCalls  int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/oauth2/token" {
+			authCalls++
+			validToken = fmt.Sprintf("token-%d", authCalls)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": validToken,
+				"token_type":   "Bearer",
+				"expires_in":   86400,
+			})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	}))
+	defer server.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+	apiClient := NewDBSecClient(cfg)
+
+	// 토큰 강제 만료
+	mu.Lock()
+	authCallsBefore := authCalls
+	validToken = "expired"
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Request failed: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := authCalls - authCallsBefore; got != 1 {
+		t.Errorf("Expected a single re-authentication, got %d", got)
+	}
+}
+
+func TestDBSecClient_ReauthRetryLimit(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		authCalls int
+		apiCalls  int
+		bodies    []string
+	)
+	newServer := func(authStatus int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if r.URL.Path == "/oauth2/token" {
+				authCalls++
+				w.WriteHeader(authStatus)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"access_token": fmt.Sprintf("token-%d", authCalls),
+					"token_type":   "Bearer",
+				})
+				return
+			}
+
+			apiCalls++
+			data, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+	}
+	reset := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		authCalls, apiCalls, bodies = 0, 0, nil
+	}
+
+	t.Run("RequestAlwaysUnauthorized", func(t *testing.T) {
+		server := newServer(http.StatusOK)
+		defer server.Close()
+		reset()
+
+		cfg := utils.CreateTestConfig()
+		cfg.API.DBSecBaseURL = server.URL
+		apiClient := NewDBSecClient(cfg)
+
+		body := strings.NewReader(`{"In":{"InputIscd1":"AAPL"}}`)
+		_, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, body, nil)
+		if !errors.IsAuthError(err) {
+			t.Fatalf("Expected auth error, got %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if apiCalls != 2 {
+			t.Errorf("Expected original request plus one retry, got %d calls", apiCalls)
+		}
+		if authCalls != 2 {
+			t.Errorf("Expected initial auth plus one re-auth, got %d", authCalls)
+		}
+		for i, b := range bodies {
+			utils.AssertStringEqual(t, `{"In":{"InputIscd1":"AAPL"}}`, b, fmt.Sprintf("Body of attempt %d", i+1))
+		}
+	})
+
+	t.Run("AuthAlwaysFails", func(t *testing.T) {
+		server := newServer(http.StatusUnauthorized)
+		defer server.Close()
+		reset()
+
+		cfg := utils.CreateTestConfig()
+		cfg.API.DBSecBaseURL = server.URL
+		apiClient := NewDBSecClient(cfg)
+
+		_, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil)
+		if !errors.IsAuthError(err) {
+			t.Fatalf("Expected auth error, got %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if apiCalls != 0 {
+			t.Errorf("Expected no API calls without a token, got %d", apiCalls)
+		}
+		if authCalls != 2 {
+			t.Errorf("Expected initial auth plus one attempt, got %d", authCalls)
+		}
+	})
+}
+
+func TestDBSecClient_ContextCancellation(t *testing.T) {
+	started := make(chan struct{}, 1)
+	aborted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+			aborted <- struct{}{}
+		case <-time.After(5 * time.Second):
+			w.Write([]byte(`{"rsp_cd":"00000"}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+	apiClient := NewDBSecClient(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := apiClient.MakeRequestWithContext(ctx, "POST", models.PathForeignStockDayChart, nil, nil, nil)
+	if !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Cancelled request took %v", elapsed)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(2 * time.Second):
+		t.Error("Upstream request was not aborted")
+	}
+
+	// 이미 취소된 컨텍스트면 rate limit 대기 없이 바로 실패
+	if _, err := apiClient.MakeRequestWithContext(ctx, "POST", models.PathForeignStockDayChart, nil, nil, nil); !stderrors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for cancelled context, got %v", err)
+	}
+}
+
+func TestDBSecClient_SharesRateLimiter(t *testing.T) {
+	first := NewDBSecClient(utils.CreateTestConfig())
+	second := NewDBSecClient(utils.CreateTestConfig())
+	if first.RateLimiter() != DefaultRateLimiter || second.RateLimiter() != DefaultRateLimiter {
+		t.Fatal("Expected clients to share DefaultRateLimiter")
+	}
+
+	own := NewTokenBucket(1, 1)
+	if second.WithRateLimiter(own).RateLimiter() != own || first.RateLimiter() != DefaultRateLimiter {
+		t.Error("Expected WithRateLimiter to replace only that client's limiter")
+	}
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/openapi/models"
+)
+
+// DefaultMaintenanceRetry 점검 응답을 받은 뒤 다시 호출해 볼 때까지 기본 대기 시간 (Retry-After 가 없을 때)
+const DefaultMaintenanceRetry = 10 * time.Minute
+
+// DefaultMaintenance DBSec 클라이언트가 기본으로 함께 쓰는 점검 상태 (점검은 API 전체에 걸리므로 클라이언트끼리 공유)
+var DefaultMaintenance = NewMaintenanceState()
+
+// MaintenanceWindow 정기 점검 시간 (한국 시간, start 이상 end 미만, 자정을 넘는 구간 허용)
+type MaintenanceWindow struct {
+	weekday time.Weekday // daily 가 아니면 점검이 시작되는 요일
+	daily   bool
+	start   int // 자정부터 분
+	end     int
+}
+
+// ParseMaintenanceWindows "sun 02:00-06:00,23:50-00:10" 형식의 정기 점검 시간 목록 해석
+// 요일(sun, mon ...)을 빼면 매일이며, 자정을 넘는 구간은 시작한 요일 기준이다.
+func ParseMaintenanceWindows(value string) ([]MaintenanceWindow, error) {
+	var windows []MaintenanceWindow
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		window, err := parseMaintenanceWindow(item)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+var maintenanceWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func parseMaintenanceWindow(item string) (MaintenanceWindow, error) {
+	window := MaintenanceWindow{daily: true}
+	span := item
+	if fields := strings.Fields(item); len(fields) == 2 {
+		weekday, ok := maintenanceWeekdays[strings.ToLower(fields[0])]
+		if !ok {
+			return MaintenanceWindow{}, fmt.Errorf("invalid maintenance weekday %q", fields[0])
+		}
+		window.weekday, window.daily, span = weekday, false, fields[1]
+	}
+
+	start, end, ok := strings.Cut(span, "-")
+	if !ok {
+		return MaintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, expected HH:MM-HH:MM", item)
+	}
+	var err error
+	if window.start, err = parseMaintenanceClock(start); err != nil {
+		return MaintenanceWindow{}, err
+	}
+	if window.end, err = parseMaintenanceClock(end); err != nil {
+		return MaintenanceWindow{}, err
+	}
+	if window.start == window.end {
+		return MaintenanceWindow{}, fmt.Errorf("maintenance window %q start and end must differ", item)
+	}
+	return window, nil
+}
+
+func parseMaintenanceClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// bounds t 가 점검 시간 안이면 그 점검의 시작/종료 시각
+func (w MaintenanceWindow) bounds(t time.Time) (time.Time, time.Time, bool) {
+	location := models.MarketTradingHours("KR").Location
+	local := t.In(location)
+
+	// 오늘 시작한 구간과 (자정을 넘는 경우) 어제 시작한 구간을 확인
+	for _, days := range []int{0, -1} {
+		day := local.AddDate(0, 0, days)
+		if !w.daily && day.Weekday() != w.weekday {
+			continue
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), w.end/60, w.end%60, 0, 0, location)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !local.Before(start) && local.Before(end) {
+			return start, end, true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// 점검 상태 출처
+const (
+	MaintenanceSourceSchedule = "schedule" // 설정한 정기 점검 시간
+	MaintenanceSourceResponse = "response" // API 가 점검 응답을 돌려줌
+)
+
+// MaintenanceStatus 현재 점검 상태 (/health/ready, api-status 응답용)
+type MaintenanceStatus struct {
+	Active bool       `json:"active"`
+	Source string     `json:"source,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	Until  *time.Time `json:"until,omitempty"` // 호출을 재개할 예정 시각
+}
+
+// MaintenanceState DBSec API 점검 여부
+// 정기 점검 시간이거나 점검 응답을 받은 뒤 재시도 시각 전이면 점검 중으로 보고, 그 시각이 지나면 자동으로 재개한다.
+type MaintenanceState struct {
+	mu      sync.RWMutex
+	windows []MaintenanceWindow
+	retry   time.Duration // 점검 응답 후 다시 호출해 볼 때까지 대기 시간
+	since   time.Time     // 점검 응답을 처음 받은 시각
+	until   time.Time     // 점검 응답으로 멈춘 호출을 재개할 시각
+	reason  string
+	now     func() time.Time
+}
+
+// NewMaintenanceState 정기 점검 시간 없이 점검 응답만 감지하는 상태
+func NewMaintenanceState() *MaintenanceState {
+	return &MaintenanceState{retry: DefaultMaintenanceRetry, now: time.Now}
+}
+
+// Configure 정기 점검 시간과 점검 응답 후 재시도 대기 시간 설정 (retry 가 0 이하면 기본값)
+func (m *MaintenanceState) Configure(windows []MaintenanceWindow, retry time.Duration) *MaintenanceState {
+	if retry <= 0 {
+		retry = DefaultMaintenanceRetry
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.windows = windows
+	m.retry = retry
+	return m
+}
+
+// Enter 점검 응답을 받음 (retryAfter 가 0 이하면 설정한 대기 시간 뒤 재개)
+func (m *MaintenanceState) Enter(reason string, retryAfter time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if retryAfter <= 0 {
+		retryAfter = m.retry
+	}
+	if m.since.IsZero() {
+		m.since = now
+	}
+	m.until = now.Add(retryAfter)
+	m.reason = reason
+}
+
+// Clear 정상 응답을 받아 점검 응답 상태 해제
+func (m *MaintenanceState) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.since, m.until, m.reason = time.Time{}, time.Time{}, ""
+}
+
+// Status 현재 점검 상태 (nil 이면 점검 아님)
+func (m *MaintenanceState) Status() MaintenanceStatus {
+	if m == nil {
+		return MaintenanceStatus{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := m.now()
+	for _, window := range m.windows {
+		if start, end, ok := window.bounds(now); ok {
+			return MaintenanceStatus{Active: true, Source: MaintenanceSourceSchedule, Reason: "scheduled maintenance", Since: &start, Until: &end}
+		}
+	}
+	if now.Before(m.until) {
+		since, until := m.since, m.until
+		return MaintenanceStatus{Active: true, Source: MaintenanceSourceResponse, Reason: m.reason, Since: &since, Until: &until}
+	}
+	return MaintenanceStatus{}
+}
+
+// Active 점검 중이라 API 호출을 멈춰야 하는지 여부
+func (m *MaintenanceState) Active() bool {
+	return m.Status().Active
+}
+
+// isMaintenanceResponse 점검 중 응답인지 판단 (본문에 점검 안내가 있거나 Retry-After 를 단 503 인 실패 응답)
+// 게이트웨이 과부하 같은 일반 503 까지 점검으로 보면 API 전체 호출을 멈추게 되므로 503 만으로는 판단하지 않는다.
+func isMaintenanceResponse(statusCode int, header http.Header, body []byte) bool {
+	if statusCode == http.StatusOK {
+		return false
+	}
+	if statusCode == http.StatusServiceUnavailable && strings.TrimSpace(header.Get("Retry-After")) != "" {
+		return true
+	}
+	text := strings.ToLower(string(body))
+	return strings.Contains(text, "maintenance") || strings.Contains(text, "점검")
+}
+
+// retryAfter 응답의 Retry-After 헤더 (초 또는 HTTP 날짜, 없으면 0)
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	windows, err := ParseMaintenanceWindows("sun 02:00-06:00, 23:50-00:10")
+	if err != nil {
+		t.Fatalf("ParseMaintenanceWindows: %v", err)
+	}
+
+	seoul := models.MarketTradingHours("KR").Location
+	now := time.Date(2024, 6, 2, 3, 0, 0, 0, seoul) // 일요일
+	state := NewMaintenanceState().Configure(windows, 0)
+	state.now = func() time.Time { return now }
+
+	cases := []struct {
+		at     time.Time
+		active bool
+		until  time.Time
+	}{
+		{time.Date(2024, 6, 2, 3, 0, 0, 0, seoul), true, time.Date(2024, 6, 2, 6, 0, 0, 0, seoul)},
+		{time.Date(2024, 6, 2, 6, 0, 0, 0, seoul), false, time.Time{}},
+		{time.Date(2024, 6, 3, 3, 0, 0, 0, seoul), false, time.Time{}}, // 월요일
+		{time.Date(2024, 6, 4, 23, 55, 0, 0, seoul), true, time.Date(2024, 6, 5, 0, 10, 0, 0, seoul)},
+		{time.Date(2024, 6, 5, 0, 5, 0, 0, seoul), true, time.Date(2024, 6, 5, 0, 10, 0, 0, seoul)},
+		{time.Date(2024, 6, 5, 0, 10, 0, 0, seoul), false, time.Time{}},
+		{time.Date(2024, 6, 2, 17, 30, 0, 0, time.UTC), false, time.Time{}}, // 한국 시간 6월 3일 02:30 (월요일)
+		{time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC), true, time.Date(2024, 6, 2, 6, 0, 0, 0, seoul)},
+	}
+	for _, tc := range cases {
+		now = tc.at
+		status := state.Status()
+		if status.Active != tc.active {
+			t.Errorf("%v: active = %v, expected %v", tc.at, status.Active, tc.active)
+			continue
+		}
+		if tc.active {
+			if status.Source != MaintenanceSourceSchedule || !status.Until.Equal(tc.until) {
+				t.Errorf("%v: status = %+v, expected scheduled until %v", tc.at, status, tc.until)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"02:00", "funday 02:00-03:00", "02:00-02:00", "25:00-03:00"} {
+		if _, err := ParseMaintenanceWindows(invalid); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+	if windows, err := ParseMaintenanceWindows(""); err != nil || len(windows) != 0 {
+		t.Errorf("Expected empty schedule, got %v, %v", windows, err)
+	}
+
+	var nilState *MaintenanceState
+	if nilState.Active() {
+		t.Error("Expected nil state not to be in maintenance")
+	}
+}
+
+func TestDBSecClient_MaintenanceResponse(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		apiCalls    int
+		maintenance = true
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "token_type": "Bearer"})
+			return
+		}
+
+		apiCalls++
+		if maintenance {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"rsp_cd":"99999","rsp_msg":"시스템 점검 중입니다."}`))
+			return
+		}
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	}))
+	defer server.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+
+	now := time.Now()
+	state := NewMaintenanceState()
+	state.now = func() time.Time { return now }
+	apiClient := NewDBSecClient(cfg).WithMaintenance(state)
+
+	calls := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return apiCalls
+	}
+
+	// 점검 응답을 받으면 Retry-After 동안 호출을 멈춘다
+	_, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil)
+	if !errors.IsMaintenanceError(err) {
+		t.Fatalf("Expected maintenance error, got %v", err)
+	}
+	status := state.Status()
+	if !status.Active || status.Source != MaintenanceSourceResponse || !status.Until.Equal(now.Add(120*time.Second)) {
+		t.Fatalf("Unexpected maintenance status %+v", status)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil); !errors.IsMaintenanceError(err) {
+			t.Fatalf("Expected maintenance error while paused, got %v", err)
+		}
+	}
+	if calls() != 1 {
+		t.Errorf("Expected no API calls during maintenance, got %d", calls())
+	}
+	if got := apiClient.GetAPIStatus()["maintenance"].(MaintenanceStatus); !got.Active {
+		t.Error("Expected api status to report maintenance")
+	}
+
+	// 재개 시각이 지나면 다시 호출하고, 정상 응답이면 점검 상태를 해제한다
+	mu.Lock()
+	maintenance = false
+	mu.Unlock()
+	now = now.Add(121 * time.Second)
+	if _, err := apiClient.MakeRequestWithHeaders("POST", models.PathForeignStockCurrentPrice, nil, nil, nil); err != nil {
+		t.Fatalf("Expected request to resume after maintenance, got %v", err)
+	}
+	if calls() != 2 || state.Active() {
+		t.Errorf("Expected resumed call and cleared state, calls=%d status=%+v", calls(), state.Status())
+	}
+}
+
+func TestIsMaintenanceResponse(t *testing.T) {
+	withRetryAfter := http.Header{}
+	withRetryAfter.Set("Retry-After", "60")
+
+	cases := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		body       string
+		expected   bool
+	}{
+		{"ok with notice", http.StatusOK, nil, "점검 안내", false},
+		{"plain 503", http.StatusServiceUnavailable, http.Header{}, `{"rsp_cd":"99999","rsp_msg":"시스템 오류"}`, false},
+		{"503 with retry-after", http.StatusServiceUnavailable, withRetryAfter, "", true},
+		{"body marker", http.StatusInternalServerError, http.Header{}, `{"rsp_msg":"시스템 점검 중입니다."}`, true},
+		{"english marker", http.StatusBadGateway, http.Header{}, "Scheduled Maintenance", true},
+	}
+	for _, tc := range cases {
+		if got := isMaintenanceResponse(tc.statusCode, tc.header, []byte(tc.body)); got != tc.expected {
+			t.Errorf("%s: isMaintenanceResponse = %v, expected %v", tc.name, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/logger"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+// CollectStockData 수집기용 시세/호가 데이터 조회
+// 국내(KR) 종목은 현재가와 호가를, 해외(US) 종목은 현재가만 조회한다.
+func (c *DBSecClient) CollectStockData(symbol, market string) (*models.ParsedStockPrice, *models.ParsedAskingPrice, error) {
+	return c.CollectStockDataContext(context.Background(), symbol, market)
+}
+
+// CollectStockDataContext ctx 가 취소되면 진행 중인 조회를 중단하는 CollectStockData
+func (c *DBSecClient) CollectStockDataContext(ctx context.Context, symbol, market string) (*models.ParsedStockPrice, *models.ParsedAskingPrice, error) {
+	if IsTestSymbol(symbol) {
+		return testStockPrice(symbol, market, time.Now()), nil, nil
+	}
+
+	resolved, ok := models.ResolveMarket(market)
+	switch {
+	case ok && resolved.Name == models.MarketKR:
+		price, err := c.domesticStockPrice(ctx, symbol)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// 호가 조회 실패는 시세 수집을 막지 않는다
+		asking, err := c.domesticStockAskingPrice(ctx, symbol)
+		if err != nil {
+			c.logger.Warn("Failed to get asking price",
+				logger.Field{Key: "symbol", Value: symbol},
+				logger.Field{Key: "error", Value: err.Error()})
+			asking = nil
+		}
+
+		return price, asking, nil
+	case ok && resolved.IsForeign():
+		price, err := c.foreignStockPrice(ctx, symbol, resolved.Code)
+		if err != nil {
+			return nil, nil, err
+		}
+		return price, nil, nil
+	default:
+		return nil, nil, errors.NewValidationError(fmt.Sprintf("unsupported market: %s", market), nil)
+	}
+}
+
+// FetchCurrentPrice 현재가만 조회 (호가를 받지 않아 종목당 API 호출은 한 번)
+func (c *DBSecClient) FetchCurrentPrice(symbol, market string) (*models.ParsedStockPrice, error) {
+	if IsTestSymbol(symbol) {
+		return testStockPrice(symbol, market, time.Now()), nil
+	}
+
+	ctx := context.Background()
+	resolved, ok := models.ResolveMarket(market)
+	switch {
+	case ok && resolved.Name == models.MarketKR:
+		return c.domesticStockPrice(ctx, symbol)
+	case ok && resolved.IsForeign():
+		return c.foreignStockPrice(ctx, symbol, resolved.Code)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported market: %s", market), nil)
+	}
+}
+
+// GetDomesticStockPrice 국내주식 현재가 조회
+func (c *DBSecClient) GetDomesticStockPrice(symbol string) (*models.ParsedStockPrice, error) {
+	return c.domesticStockPrice(context.Background(), symbol)
+}
+
+func (c *DBSecClient) domesticStockPrice(ctx context.Context, symbol string) (*models.ParsedStockPrice, error) {
+	request := models.CurrentPriceRequest{
+		In: models.CurrentPriceInput{
+			InputCondMrktDivCode: models.MarketDivStock,
+			InputIscd1:           symbol,
+		},
+	}
+
+	respBody, err := c.makeRequestContext(ctx, "POST", models.PathDomesticStockCurrentPrice, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get domestic stock price", err)
+	}
+
+	var response models.CurrentPriceResponse
+	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
+		return nil, err
+	}
+
+	out := response.Out
+	current := utils.ParseFloat(out.Prpr)
+	change := utils.ParseFloat(out.PrdyVrss)
+
+	return &models.ParsedStockPrice{
+		Symbol:         symbol,
+		Market:         models.RegionKR,
+		OpenPrice:      utils.ParseFloat(out.Oprc),
+		HighPrice:      utils.ParseFloat(out.Hprc),
+		LowPrice:       utils.ParseFloat(out.Lprc),
+		CurrentPrice:   current,
+		PrevClosePrice: current - change,
+		Change:         change,
+		ChangeRate:     utils.ParseFloat(out.PrdyCtrt),
+		Volume:         utils.ParseInt(out.AcmlVol),
+		TradeAmount:    utils.ParseInt(out.AcmlTrPbmn),
+		Timestamp:      time.Now(),
+	}, nil
+}
+
+// GetDomesticStockAskingPrice 국내주식 5단계 호가 조회
+func (c *DBSecClient) GetDomesticStockAskingPrice(symbol string) (*models.ParsedAskingPrice, error) {
+	return c.domesticStockAskingPrice(context.Background(), symbol)
+}
+
+func (c *DBSecClient) domesticStockAskingPrice(ctx context.Context, symbol string) (*models.ParsedAskingPrice, error) {
+	request := models.CurrentPriceRequest{
+		In: models.CurrentPriceInput{
+			InputCondMrktDivCode: models.MarketDivStock,
+			InputIscd1:           symbol,
+		},
+	}
+
+	path := strings.Replace(models.PathDomesticStockAsking, "{symbol}", symbol, 1)
+	respBody, err := c.makeRequestContext(ctx, "POST", path, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get asking price", err)
+	}
+
+	var response models.AskingPriceResponse
+	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
+		return nil, err
+	}
+
+	out := response.Out
+	return &models.ParsedAskingPrice{
+		Symbol: symbol,
+		AskPrices: [5]float64{
+			utils.ParseFloat(out.Askp1), utils.ParseFloat(out.Askp2), utils.ParseFloat(out.Askp3),
+			utils.ParseFloat(out.Askp4), utils.ParseFloat(out.Askp5),
+		},
+		BidPrices: [5]float64{
+			utils.ParseFloat(out.Bidp1), utils.ParseFloat(out.Bidp2), utils.ParseFloat(out.Bidp3),
+			utils.ParseFloat(out.Bidp4), utils.ParseFloat(out.Bidp5),
+		},
+		AskVolumes: [5]int64{
+			utils.ParseInt(out.AskpRsqn1), utils.ParseInt(out.AskpRsqn2), utils.ParseInt(out.AskpRsqn3),
+			utils.ParseInt(out.AskpRsqn4), utils.ParseInt(out.AskpRsqn5),
+		},
+		BidVolumes: [5]int64{
+			utils.ParseInt(out.BidpRsqn1), utils.ParseInt(out.BidpRsqn2), utils.ParseInt(out.BidpRsqn3),
+			utils.ParseInt(out.BidpRsqn4), utils.ParseInt(out.BidpRsqn5),
+		},
+		TotalAskVol: utils.ParseInt(out.TotalAskpRsqn),
+		TotalBidVol: utils.ParseInt(out.TotalBidpRsqn),
+		Timestamp:   time.Now(),
+	}, nil
+}
+
+// GetStockMetadata 종목 업종/시가총액/PER/PBR 조회
+// 국내는 현재가 응답의 업종명, HTS 시가총액, PER, PBR 을 사용하고 해외는 현재가 응답의 PER 만 제공된다.
+func (c *DBSecClient) GetStockMetadata(symbol, market string) (*models.ParsedStockMetadata, error) {
+	if IsTestSymbol(symbol) {
+		return testStockMetadata(symbol), nil
+	}
+
+	resolved, ok := models.ResolveMarket(market)
+	switch {
+	case ok && resolved.Name == models.MarketKR:
+	case ok && resolved.IsForeign():
+		return c.getForeignStockMetadata(symbol, resolved.Code)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported market for metadata: %s", market), nil)
+	}
+
+	request := models.CurrentPriceRequest{
+		In: models.CurrentPriceInput{
+			InputCondMrktDivCode: models.MarketDivStock,
+			InputIscd1:           symbol,
+		},
+	}
+
+	respBody, err := c.makeRequest("POST", models.PathDomesticStockCurrentPrice, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get stock metadata", err)
+	}
+
+	var response models.CurrentPriceResponse
+	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
+		return nil, err
+	}
+
+	return &models.ParsedStockMetadata{
+		Symbol:    symbol,
+		Sector:    strings.TrimSpace(response.Out.BstpKorIsnm),
+		MarketCap: utils.ParseInt(response.Out.HtsAvls),
+		PER:       utils.ParseFloat(response.Out.Per),
+		PBR:       utils.ParseFloat(response.Out.Pbr),
+	}, nil
+}
+
+// getForeignStockMetadata 해외주식 현재가 응답에서 PER 조회
+func (c *DBSecClient) getForeignStockMetadata(symbol, marketCode string) (*models.ParsedStockMetadata, error) {
+	request := models.ForeignCurrentPriceRequest{
+		In: models.ForeignCurrentPriceInput{
+			InputCondMrktDivCode: marketCode,
+			InputIscd1:           models.ToAPISymbol(symbol, marketCode),
+		},
+	}
+
+	respBody, err := c.makeRequest("POST", models.PathForeignStockCurrentPrice, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get foreign stock metadata", err)
+	}
+
+	var response models.ForeignCurrentPriceResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, errors.NewParseError("failed to parse foreign stock metadata", err)
+	}
+
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
+		return nil, err
+	}
+
+	return &models.ParsedStockMetadata{
+		Symbol: symbol,
+		PER:    utils.ParseFloat(response.Out.Per),
+	}, nil
+}
+
+// GetForeignStockPrice 해외주식 현재가 조회
+// marketCode: 해외주식 시장분류코드 (FY: 뉴욕, FN: 나스닥, FA: 아멕스)
+func (c *DBSecClient) GetForeignStockPrice(symbol, marketCode string) (*models.ParsedStockPrice, error) {
+	return c.foreignStockPrice(context.Background(), symbol, marketCode)
+}
+
+func (c *DBSecClient) foreignStockPrice(ctx context.Context, symbol, marketCode string) (*models.ParsedStockPrice, error) {
+	request := models.ForeignCurrentPriceRequest{
+		In: models.ForeignCurrentPriceInput{
+			InputCondMrktDivCode: marketCode,
+			InputIscd1:           models.ToAPISymbol(symbol, marketCode),
+		},
+	}
+
+	respBody, err := c.makeRequestContext(ctx, "POST", models.PathForeignStockCurrentPrice, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get foreign stock price", err)
+	}
+
+	var response models.ForeignCurrentPriceResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, errors.NewParseError("failed to parse foreign stock price", err)
+	}
+
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
+		return nil, err
+	}
+
+	out := response.Out
+	precision := utils.ParsePrecision(out.Zdiv, models.DefaultForeignPricePrecision)
+	price := func(str string) float64 {
+		return utils.RoundToPrecision(utils.ParseFloat(str), precision)
+	}
+	current := price(out.Prpr)
+	change := price(out.PrdyVrss)
+
+	return &models.ParsedStockPrice{
+		Symbol:         symbol,
+		Market:         models.RegionUS,
+		OpenPrice:      price(out.Oprc),
+		HighPrice:      price(out.Hprc),
+		LowPrice:       price(out.Lprc),
+		CurrentPrice:   current,
+		PrevClosePrice: utils.RoundToPrecision(current-change, precision),
+		Change:         change,
+		ChangeRate:     utils.ParseFloat(out.PrdyCtrt),
+		Volume:         utils.ParseInt(out.AcmlVol),
+		TradeAmount:    utils.ParseInt(out.AcmlTrPbmn),
+		Precision:      precision,
+		Timestamp:      time.Now(),
+	}, nil
+}
+
+// GetDomesticStockDaily 국내주식 일봉 조회
+// startDate, endDate: YYYYMMDD
+func (c *DBSecClient) GetDomesticStockDaily(symbol, startDate, endDate string) ([]models.ParsedDailyPrice, error) {
+	if IsTestSymbol(symbol) {
+		bars, err := testDailyPrices(symbol, startDate, endDate)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid daily price range", err)
+		}
+		return bars, nil
+	}
+
+	request := models.DomesticDailyPriceRequest{
+		In: models.DomesticDailyPriceInput{
+			InputCondMrktDivCode: models.MarketDivStock,
+			InputIscd1:           symbol,
+			InputDate1:           startDate,
+			InputDate2:           endDate,
+			InputOrgAdjPrc:       models.AdjustedPriceEnabled,
+		},
+	}
+
+	path := strings.Replace(models.PathDomesticStockDaily, "{symbol}", symbol, 1)
+	respBody, err := c.makeRequest("POST", path, nil, request)
+	if err != nil {
+		return nil, requestError("failed to get domestic daily price", err)
+	}
+
+	var response models.DomesticDailyPriceResponse
+	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
+		return nil, err
+	}
+
+	dailyData := make([]models.ParsedDailyPrice, 0, len(response.Out))
+	for _, out := range response.Out {
+		dailyData = append(dailyData, models.ParsedDailyPrice{
+			Symbol:      symbol,
+			Date:        utils.ParseDate(out.Date),
+			OpenPrice:   utils.ParseFloat(out.Oprc),
+			HighPrice:   utils.ParseFloat(out.Hprc),
+			LowPrice:    utils.ParseFloat(out.Lprc),
+			ClosePrice:  utils.ParseFloat(out.Prpr),
+			Volume:      utils.ParseInt(out.AcmlVol),
+			TradeAmount: utils.ParseInt(out.AcmlTrPbmn),
+		})
+	}
+
+	return dailyData, nil
+}
+
+// GetMajorStocks 시장별 주요 종목 목록
+func (c *DBSecClient) GetMajorStocks() map[string][]string {
+	return map[string][]string{
+		"KR": {"005930", "000660"},
+		"US": {"AAPL", "TSLA"},
+	}
+}
+
+// GetAPIStatus API 연결 상태 조회
+func (c *DBSecClient) GetAPIStatus() map[string]interface{} {
+	token, generatedAt := c.tokenState()
+	status := map[string]interface{}{
+		"has_credentials": c.HasValidCredentials(),
+		"authenticated":   token != "",
+		"base_url":        c.baseURL,
+		"latency":         c.LatencyStats(),
+		"maintenance":     c.maintenance.Status(),
+	}
+
+	if !generatedAt.IsZero() {
+		status["token_generated_at"] = generatedAt
+		status["token_age_seconds"] = int64(time.Since(generatedAt).Seconds())
+	}
+
+	if err := c.HealthCheck(); err != nil {
+		status["healthy"] = false
+		status["error"] = err.Error()
+	} else {
+		status["healthy"] = true
+	}
+
+	return status
+}
+
+// requestError 호출 실패를 에러로 변환 (점검/인증 실패처럼 이미 분류된 API 에러는 감싸지 않고 그대로 반환)
+func requestError(message string, err error) error {
+	if apiErr, ok := err.(*errors.APIError); ok {
+		return apiErr
+	}
+	return errors.NewNetworkError(message, err)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestDBSecClient_GetStockMetadata(t *testing.T) {
+	mockData := models.CurrentPriceOutput{
+		Prpr:        "55550",
+		Per:         "10.89",
+		Pbr:         "0.93",
+		BstpKorIsnm: " 전기전자 ",
+		HtsAvls:     "3316245",
+	}
+	handler := utils.CreateCurrentPriceMockHandler(t, models.PathDomesticStockCurrentPrice, "005930", mockData)
+	mockServer := utils.NewMockServer(t, handler)
+	defer mockServer.Close()
+
+	apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+	t.Run("Domestic", func(t *testing.T) {
+		metadata, err := apiClient.GetStockMetadata("005930", "KR")
+		if err != nil {
+			t.Fatalf("Failed to get stock metadata: %v", err)
+		}
+
+		utils.AssertStringEqual(t, "전기전자", metadata.Sector, "Sector")
+		utils.AssertIntEqual(t, 3316245, metadata.MarketCap, "Market cap")
+		utils.AssertFloatEqual(t, 10.89, metadata.PER, "PER")
+		utils.AssertFloatEqual(t, 0.93, metadata.PBR, "PBR")
+	})
+
+	t.Run("UnsupportedMarket", func(t *testing.T) {
+		if _, err := apiClient.GetStockMetadata("7203", "JP"); err == nil {
+			t.Error("Expected error for unsupported market")
+		}
+	})
+}
+
+func TestDBSecClient_TestSymbol(t *testing.T) {
+	var calls int32
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer mockServer.Close()
+
+	apiClient := NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+	price, asking, err := apiClient.CollectStockData("TEST.AAA", "NASDAQ")
+	if err != nil {
+		t.Fatalf("Failed to collect test symbol: %v", err)
+	}
+	if asking != nil {
+		t.Error("Expected no asking price for test symbol")
+	}
+	utils.AssertStringEqual(t, "US", price.Market, "Market")
+	if price.CurrentPrice <= 0 {
+		t.Errorf("Expected positive price, got %f", price.CurrentPrice)
+	}
+
+	again, _, _ := apiClient.CollectStockData("TEST.AAA", "NASDAQ")
+	utils.AssertFloatEqual(t, price.CurrentPrice, again.CurrentPrice, "Deterministic price")
+
+	bars, err := apiClient.GetDomesticStockDaily("TEST.AAA", "20240603", "20240614")
+	if err != nil {
+		t.Fatalf("Failed to get test symbol daily prices: %v", err)
+	}
+	utils.AssertIntEqual(t, 10, int64(len(bars)), "Weekday bars")
+	for _, bar := range bars {
+		if bar.LowPrice > bar.ClosePrice || bar.ClosePrice > bar.HighPrice {
+			t.Errorf("Close %f outside low/high %f-%f on %s", bar.ClosePrice, bar.LowPrice, bar.HighPrice, bar.Date)
+		}
+	}
+
+	if _, err := apiClient.GetStockMetadata("TEST.AAA", "KR"); err != nil {
+		t.Errorf("Failed to get test symbol metadata: %v", err)
+	}
+
+	utils.AssertIntEqual(t, 0, int64(atomic.LoadInt32(&calls)), "API calls for test symbol")
+}
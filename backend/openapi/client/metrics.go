@@ -0,0 +1,105 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// 응답시간 히스토그램 버킷 상한 (마지막 버킷 이후는 overflow)
+var latencyBuckets = []time.Duration{
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencyHistogram 엔드포인트별 응답시간 히스토그램
+type LatencyHistogram struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointLatency
+}
+
+type endpointLatency struct {
+	counts    []int64 // len(latencyBuckets)+1
+	total     time.Duration
+	max       time.Duration
+	slowCount int64
+}
+
+// LatencyBucket 버킷별 호출 수 (UpperBound 가 빈 문자열이면 overflow)
+type LatencyBucket struct {
+	UpperBound string `json:"le"`
+	Count      int64  `json:"count"`
+}
+
+// LatencySummary 엔드포인트 응답시간 요약
+type LatencySummary struct {
+	Count     int64           `json:"count"`
+	SlowCount int64           `json:"slow_count"`
+	AvgMs     int64           `json:"avg_ms"`
+	MaxMs     int64           `json:"max_ms"`
+	Buckets   []LatencyBucket `json:"buckets"`
+}
+
+// NewLatencyHistogram 새로운 응답시간 히스토그램 생성
+func NewLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{endpoints: make(map[string]*endpointLatency)}
+}
+
+// Observe 응답시간 기록
+func (h *LatencyHistogram) Observe(endpoint string, duration time.Duration, slow bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	e, ok := h.endpoints[endpoint]
+	if !ok {
+		e = &endpointLatency{counts: make([]int64, len(latencyBuckets)+1)}
+		h.endpoints[endpoint] = e
+	}
+
+	idx := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if duration <= bound {
+			idx = i
+			break
+		}
+	}
+	e.counts[idx]++
+	e.total += duration
+	if duration > e.max {
+		e.max = duration
+	}
+	if slow {
+		e.slowCount++
+	}
+}
+
+// Snapshot 엔드포인트별 응답시간 요약 반환
+func (h *LatencyHistogram) Snapshot() map[string]LatencySummary {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make(map[string]LatencySummary, len(h.endpoints))
+	for endpoint, e := range h.endpoints {
+		summary := LatencySummary{
+			SlowCount: e.slowCount,
+			MaxMs:     e.max.Milliseconds(),
+			Buckets:   make([]LatencyBucket, len(e.counts)),
+		}
+		for i, count := range e.counts {
+			summary.Count += count
+			if i < len(latencyBuckets) {
+				summary.Buckets[i].UpperBound = latencyBuckets[i].String()
+			}
+			summary.Buckets[i].Count = count
+		}
+		if summary.Count > 0 {
+			summary.AvgMs = e.total.Milliseconds() / summary.Count
+		}
+		result[endpoint] = summary
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+// TestSymbolPrefix 실제 API 를 호출하지 않는 합성 종목 접두어 (예: TEST.AAA)
+// 인증 정보와 관계없이 결정적인 가격/일봉을 생성해 수집 → 지표 → 신호 파이프라인을 점검하는 데 쓴다.
+const TestSymbolPrefix = "TEST."
+
+// IsTestSymbol 합성 종목 여부
+func IsTestSymbol(symbol string) bool {
+	return strings.HasPrefix(strings.ToUpper(symbol), TestSymbolPrefix)
+}
+
+// testSymbolSeed 종목코드로부터 결정적인 시드
+func testSymbolSeed(symbol string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToUpper(symbol)))
+	return h.Sum64()
+}
+
+// testClose date 의 합성 종가
+// 종목별 기준가(50 ~ 500) 주변을 두 주기의 사인파로 오가므로 같은 날짜는 항상 같은 값을 갖는다.
+func testClose(symbol string, date time.Time) float64 {
+	seed := testSymbolSeed(symbol)
+	base := 50 + float64(seed%450)
+	day := float64(date.UTC().Unix()/86400) + float64(seed%360)
+	return base * (1 + 0.1*math.Sin(day/7) + 0.03*math.Sin(day/2.3))
+}
+
+// testVolume date 의 합성 거래량
+func testVolume(symbol string, date time.Time) int64 {
+	day := uint64(date.UTC().Unix() / 86400)
+	return int64(100000 + (day*7919+testSymbolSeed(symbol))%900000)
+}
+
+// testDailyBar date 의 합성 일봉 (시가는 전일 종가)
+func testDailyBar(symbol string, date time.Time) models.ParsedDailyPrice {
+	open := testClose(symbol, date.AddDate(0, 0, -1))
+	closePrice := testClose(symbol, date)
+	volume := testVolume(symbol, date)
+
+	return models.ParsedDailyPrice{
+		Symbol:      symbol,
+		Date:        date,
+		OpenPrice:   open,
+		HighPrice:   math.Max(open, closePrice) * 1.01,
+		LowPrice:    math.Min(open, closePrice) * 0.99,
+		ClosePrice:  closePrice,
+		Volume:      volume,
+		TradeAmount: int64(closePrice * float64(volume)),
+	}
+}
+
+// testStockPrice 합성 종목 현재가 (오늘 일봉 기준)
+func testStockPrice(symbol, market string, now time.Time) *models.ParsedStockPrice {
+	bar := testDailyBar(symbol, now)
+	prevClose := bar.OpenPrice
+
+	if region := models.DefaultMarketResolver.Region(market); region != "" {
+		market = region
+	}
+
+	return &models.ParsedStockPrice{
+		Symbol:         symbol,
+		Market:         market,
+		OpenPrice:      bar.OpenPrice,
+		HighPrice:      bar.HighPrice,
+		LowPrice:       bar.LowPrice,
+		CurrentPrice:   bar.ClosePrice,
+		PrevClosePrice: prevClose,
+		Change:         bar.ClosePrice - prevClose,
+		ChangeRate:     (bar.ClosePrice - prevClose) / prevClose * 100,
+		Volume:         bar.Volume,
+		TradeAmount:    bar.TradeAmount,
+		Precision:      2,
+		Timestamp:      now,
+	}
+}
+
+// testDailyPrices startDate ~ endDate (YYYYMMDD) 사이 평일의 합성 일봉
+func testDailyPrices(symbol, startDate, endDate string) ([]models.ParsedDailyPrice, error) {
+	start, err := utils.ParseYMD(startDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := utils.ParseYMD(endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var bars []models.ParsedDailyPrice
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		bars = append(bars, testDailyBar(symbol, d))
+	}
+	return bars, nil
+}
+
+// testStockMetadata 합성 종목 부가정보
+func testStockMetadata(symbol string) *models.ParsedStockMetadata {
+	seed := testSymbolSeed(symbol)
+	return &models.ParsedStockMetadata{
+		Symbol:    symbol,
+		Sector:    "Test",
+		MarketCap: int64(1000 + seed%9000),
+		PER:       5 + float64(seed%20),
+		PBR:       0.5 + float64(seed%30)/10,
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// TokenBucket 초당 rate 개씩 채워지고 최대 burst 개까지 모이는 호출 허용량
+// 허용량이 남아 있으면 바로, 없으면 다음 토큰이 채워질 때까지 기다려 호출 속도를 rate 로 맞춘다.
+// 여러 호출자가 같은 버킷을 공유하면 합친 호출 속도가 rate 를 넘지 않는다. nil 이나 rate 가 0 이하인 버킷은 제한하지 않는다.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 초당 채워지는 토큰 수
+	burst  float64
+	tokens float64 // 음수면 이미 예약된 대기 토큰 수
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket 가득 찬 상태로 시작하는 버킷 (burst 가 1 보다 작으면 1)
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	b := &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), now: time.Now}
+	b.last = b.now()
+	return b
+}
+
+// Rate 초당 허용 호출 수 (0 이면 제한 없음)
+func (b *TokenBucket) Rate() float64 {
+	if b == nil || b.rate <= 0 {
+		return 0
+	}
+	return b.rate
+}
+
+// Wait 토큰 하나를 얻을 때까지 대기 (대기 중에 ctx 가 끝나면 토큰을 돌려놓고 에러 반환)
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	wait := b.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.release()
+		return ctx.Err()
+	}
+}
+
+// reserve 토큰 하나를 예약하고 그 토큰이 채워질 때까지 남은 시간 반환
+func (b *TokenBucket) reserve() time.Duration {
+	if b == nil || b.rate <= 0 {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+}
+
+// release 쓰지 않은 예약 토큰 반환
+func (b *TokenBucket) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens = math.Min(b.tokens+1, b.burst)
+}
+
+// refill 마지막 갱신 이후 흐른 시간만큼 토큰 보충 (호출자가 mu 를 잡고 있어야 함)
+func (b *TokenBucket) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.tokens+elapsed.Seconds()*b.rate, b.burst)
+		b.last = now
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_PacesToRate(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	bucket := NewTokenBucket(4, 2)
+	bucket.now = func() time.Time { return now }
+	bucket.last = now
+
+	// burst 만큼은 바로, 이후에는 1/rate 간격으로 예약된다
+	expected := []time.Duration{0, 0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond}
+	for i, want := range expected {
+		if got := bucket.reserve(); got != want {
+			t.Errorf("reserve #%d = %v, expected %v", i, got, want)
+		}
+	}
+
+	// 예약된 토큰이 모두 채워진 뒤 쉬면 burst 까지만 다시 모인다
+	now = now.Add(10 * time.Second)
+	for i, want := range []time.Duration{0, 0, 250 * time.Millisecond} {
+		if got := bucket.reserve(); got != want {
+			t.Errorf("reserve after idle #%d = %v, expected %v", i, got, want)
+		}
+	}
+}
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := NewTokenBucket(50, 1)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait #%d: %v", i, err)
+		}
+	}
+	// 첫 호출 뒤 5번은 20ms 간격
+	if elapsed := time.Since(start); elapsed < 95*time.Millisecond || elapsed > time.Second {
+		t.Errorf("6 waits at 50/s took %v, expected about 100ms", elapsed)
+	}
+
+	// 대기 중에 취소하면 에러를 돌려주고 예약한 토큰을 반납한다
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	slow := NewTokenBucket(1, 1)
+	slow.Wait(context.Background())
+	if err := slow.Wait(ctx); err == nil {
+		t.Error("Expected cancelled wait to fail")
+	}
+	if wait := slow.reserve(); wait > time.Second {
+		t.Errorf("Expected cancelled reservation to be released, next wait %v", wait)
+	}
+}
+
+func TestTokenBucket_Unlimited(t *testing.T) {
+	var nilBucket *TokenBucket
+	for _, bucket := range []*TokenBucket{nilBucket, NewTokenBucket(0, 1)} {
+		for i := 0; i < 100; i++ {
+			if wait := bucket.reserve(); wait != 0 {
+				t.Fatalf("Expected unlimited bucket not to wait, got %v", wait)
+			}
+		}
+		if bucket.Rate() != 0 {
+			t.Errorf("Rate() = %v, expected 0", bucket.Rate())
+		}
+	}
+}
@@ -1,26 +1,38 @@
 package domestic
 
 import (
-	"fmt"
+	"strings"
+	"time"
 
 	"stock-recommender/backend/openapi/client"
 	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/logger"
 	"stock-recommender/backend/openapi/models"
 	"stock-recommender/backend/openapi/utils"
 )
 
 // CurrentPriceService 현재가조회 서비스
 type CurrentPriceService struct {
-	client *client.DBSecClient
+	client     *client.DBSecClient
+	logger     logger.Logger
+	marketOpen func(time.Time) bool
 }
 
 // NewCurrentPriceService 새로운 현재가조회 서비스 생성
 func NewCurrentPriceService(client *client.DBSecClient) *CurrentPriceService {
 	return &CurrentPriceService{
-		client: client,
+		client:     client,
+		logger:     logger.GetDefaultLogger().With(logger.Field{Key: "service", Value: "domestic_current_price"}),
+		marketOpen: IsKRXOpen,
 	}
 }
 
+// WithLogger 서비스 로거 교체
+func (s *CurrentPriceService) WithLogger(l logger.Logger) *CurrentPriceService {
+	s.logger = l
+	return s
+}
+
 // GetCurrentPrice 현재가 조회
 // stockCode: 종목코드 (6자리) 또는 지수코드
 // marketDiv: 시장분류코드 (J: 주식, E: ETF, EN: ETN, W: ELW, U: 업종&지수)
@@ -45,7 +57,7 @@ func (s *CurrentPriceService) GetCurrentPrice(stockCode string, marketDiv string
 	// 응답 파싱 및 검증
 	var response models.CurrentPriceResponse
 	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
-		return nil, errors.NewParseError("failed to parse current price response", err)
+		return nil, err
 	}
 
 	// 데이터 변환
@@ -100,7 +112,10 @@ func (s *CurrentPriceService) GetMultipleStockPrices(stockCodes []string) (map[s
 		data, err := s.GetStockPrice(code)
 		if err != nil {
 			// 개별 오류는 로그하고 계속 진행
-			fmt.Printf("Failed to get price for %s: %v\n", code, err)
+			s.logger.Warn("Failed to get stock price",
+				logger.Field{Key: "stock_code", Value: code},
+				logger.Field{Key: "market", Value: models.MarketDivStock},
+				logger.Field{Key: "error", Value: err.Error()})
 			continue
 		}
 		result[code] = data
@@ -135,5 +150,7 @@ func (s *CurrentPriceService) convertToCurrentPriceData(stockCode string, output
 		CurrentHighRate:  utils.ParseFloat(output.PrprVrssHgprRate),
 		MarketLowRate:    utils.ParseFloat(output.SdprVrssLwprRate),
 		CurrentLowRate:   utils.ParseFloat(output.PrprVrssLwprRate),
+		SectorName:       strings.TrimSpace(output.BstpKorIsnm),
+		MarketCap:        utils.ParseInt(output.HtsAvls),
 	}
 }
\ No newline at end of file
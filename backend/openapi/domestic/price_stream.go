@@ -0,0 +1,56 @@
+package domestic
+
+import (
+	"time"
+
+	"stock-recommender/backend/openapi/logger"
+	"stock-recommender/backend/openapi/models"
+)
+
+// 장 마감 중 개장 여부 재확인 주기
+const marketClosedRecheckInterval = time.Minute
+
+// IsKRXOpen 한국거래소 정규장 여부 (휴장일 제외 09:00~15:30 KST, 기본 시장 달력 기준)
+func IsKRXOpen(t time.Time) bool {
+	return models.DefaultMarketCalendar.InSession(t, models.RegionKR)
+}
+
+// StreamDomesticPrice 국내주식 현재가를 주기적으로 조회하여 채널로 전달
+// 장 마감 중에는 조회를 멈추고 개장 여부만 확인한다. stopCh가 닫히면 반환 채널도 닫힌다.
+// 모든 조회는 클라이언트의 rate limiter를 거친다.
+func (s *CurrentPriceService) StreamDomesticPrice(symbol string, interval time.Duration, stopCh <-chan struct{}) <-chan *models.CurrentPriceData {
+	out := make(chan *models.CurrentPriceData)
+	log := logger.GetDefaultLogger().With(
+		logger.Field{Key: "service", Value: "domestic_price_stream"},
+		logger.Field{Key: "stock_code", Value: symbol})
+
+	go func() {
+		defer close(out)
+
+		for {
+			wait := interval
+			if s.marketOpen(time.Now()) {
+				data, err := s.GetStockPrice(symbol)
+				if err != nil {
+					log.Warn("Failed to poll current price", logger.Field{Key: "error", Value: err.Error()})
+				} else {
+					select {
+					case out <- data:
+					case <-stopCh:
+						return
+					}
+				}
+			} else if wait < marketClosedRecheckInterval {
+				wait = marketClosedRecheckInterval
+			}
+
+			select {
+			case <-time.After(wait):
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,96 @@
+package domestic
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestCurrentPriceService_StreamDomesticPrice(t *testing.T) {
+	var calls int32
+	priceHandler := utils.CreateCurrentPriceMockHandler(t, models.PathDomesticStockCurrentPrice, "005930",
+		models.CurrentPriceOutput{Prpr: "55550"})
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		priceHandler(w, r)
+	})
+	defer mockServer.Close()
+
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+	t.Run("MarketOpen", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := NewCurrentPriceService(apiClient)
+		service.marketOpen = func(time.Time) bool { return true }
+
+		stopCh := make(chan struct{})
+		stream := service.StreamDomesticPrice("005930", 10*time.Millisecond, stopCh)
+
+		for i := 0; i < 3; i++ {
+			select {
+			case data := <-stream:
+				utils.AssertStringEqual(t, "005930", data.StockCode, "Stock code")
+				utils.AssertFloatEqual(t, 55550, data.CurrentPrice, "Current price")
+			case <-time.After(2 * time.Second):
+				t.Fatalf("Timed out waiting for tick %d", i+1)
+			}
+		}
+
+		close(stopCh)
+		for range stream {
+		}
+		if n := atomic.LoadInt32(&calls); n < 3 {
+			t.Errorf("Expected at least 3 API calls, got %d", n)
+		}
+	})
+
+	t.Run("MarketClosed", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		service := NewCurrentPriceService(apiClient)
+		service.marketOpen = func(time.Time) bool { return false }
+
+		stopCh := make(chan struct{})
+		stream := service.StreamDomesticPrice("005930", 10*time.Millisecond, stopCh)
+
+		select {
+		case data := <-stream:
+			t.Fatalf("Expected no snapshot while market is closed, got %+v", data)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(stopCh)
+		for range stream {
+		}
+		if n := atomic.LoadInt32(&calls); n != 0 {
+			t.Errorf("Expected no API calls while market is closed, got %d", n)
+		}
+	})
+}
+
+func TestIsKRXOpen(t *testing.T) {
+	kst := time.FixedZone("KST", 9*60*60)
+	cases := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"BeforeOpen", time.Date(2024, 3, 4, 8, 59, 0, 0, kst), false},
+		{"Open", time.Date(2024, 3, 4, 9, 0, 0, 0, kst), true},
+		{"BeforeClose", time.Date(2024, 3, 4, 15, 29, 0, 0, kst), true},
+		{"AfterClose", time.Date(2024, 3, 4, 15, 30, 0, 0, kst), false},
+		{"Saturday", time.Date(2024, 3, 9, 10, 0, 0, 0, kst), false},
+		{"Holiday", time.Date(2024, 3, 1, 10, 0, 0, 0, kst), false},
+		{"UTCInput", time.Date(2024, 3, 4, 1, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tc := range cases {
+		if got := IsKRXOpen(tc.at); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package domestic
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+func TestStockTickerService_GetStockListByProductType(t *testing.T) {
+	var requestedMarketDivs []string
+
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != models.PathDomesticStockTicker {
+			t.Errorf("Expected path %s, got %s", models.PathDomesticStockTicker, r.URL.Path)
+		}
+
+		var req models.StockTickerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		requestedMarketDivs = append(requestedMarketDivs, req.In.InputCondMrktDivCode)
+
+		var out []models.StockTickerOutput
+		switch req.In.InputCondMrktDivCode {
+		case models.MarketDivStock:
+			out = []models.StockTickerOutput{
+				{Iscd: "000020", KorIsnm: "동화약품", MrktClsCode: models.MarketClassKospi},
+				{Iscd: "086520", KorIsnm: "에코프로", MrktClsCode: models.MarketClassKosdaq},
+				{Iscd: "217910", KorIsnm: "에스제이켐", MrktClsCode: models.MarketClassKonex},
+			}
+		case models.MarketDivETF:
+			out = []models.StockTickerOutput{
+				{Iscd: "069500", KorIsnm: "KODEX 200", MrktClsCode: models.MarketClassKospi},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("cont_yn", "N")
+		json.NewEncoder(w).Encode(models.StockTickerResponse{
+			BaseAPIResponse: utils.BaseAPIResponse{RspCd: "00000", RspMsg: "정상 처리 되었습니다."},
+			Out:             out,
+		})
+	})
+	defer mockServer.Close()
+
+	service := NewStockTickerService(client.NewDBSecClient(utils.CreateMockServerConfig(mockServer)))
+
+	t.Run("GetKONEXStocks", func(t *testing.T) {
+		requestedMarketDivs = nil
+
+		stocks, err := service.GetKONEXStocks()
+		if err != nil {
+			t.Fatalf("Failed to get KONEX stocks: %v", err)
+		}
+
+		if len(requestedMarketDivs) != 1 || requestedMarketDivs[0] != models.MarketDivStock {
+			t.Errorf("Expected market div %s, got %v", models.MarketDivStock, requestedMarketDivs)
+		}
+		if len(stocks) != 1 {
+			t.Fatalf("Expected 1 KONEX stock, got %d", len(stocks))
+		}
+		utils.AssertStringEqual(t, "217910", stocks[0].Iscd, "KONEX stock code")
+	})
+
+	t.Run("GetETFs", func(t *testing.T) {
+		requestedMarketDivs = nil
+
+		etfs, err := service.GetETFs()
+		if err != nil {
+			t.Fatalf("Failed to get ETFs: %v", err)
+		}
+
+		if len(requestedMarketDivs) != 1 || requestedMarketDivs[0] != models.MarketDivETF {
+			t.Errorf("Expected market div %s, got %v", models.MarketDivETF, requestedMarketDivs)
+		}
+		if len(etfs) != 1 {
+			t.Fatalf("Expected 1 ETF, got %d", len(etfs))
+		}
+		utils.AssertStringEqual(t, "069500", etfs[0].Iscd, "ETF code")
+	})
+
+	t.Run("GetStockListWithMarketClasses", func(t *testing.T) {
+		stocks, err := service.GetStockList(models.MarketDivStock, models.MarketClassKospi, models.MarketClassKosdaq)
+		if err != nil {
+			t.Fatalf("Failed to get stock list: %v", err)
+		}
+
+		if len(stocks) != 2 {
+			t.Errorf("Expected 2 KOSPI/KOSDAQ stocks, got %d", len(stocks))
+		}
+	})
+}
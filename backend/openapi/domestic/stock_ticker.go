@@ -2,7 +2,6 @@ package domestic
 
 import (
 	"stock-recommender/backend/openapi/client"
-	"stock-recommender/backend/openapi/errors"
 	"stock-recommender/backend/openapi/models"
 	"stock-recommender/backend/openapi/utils"
 )
@@ -39,7 +38,7 @@ func (s *StockTickerService) GetStockTickers(marketDiv string, contKey string) (
 	// 응답 파싱 및 검증
 	var response models.StockTickerResponse
 	if err := utils.ParseAPIResponse(respBody, &response); err != nil {
-		return nil, "", errors.NewParseError("failed to parse stock ticker response", err)
+		return nil, "", err
 	}
 
 	return &response, nextContKey, nil
@@ -67,14 +66,47 @@ func (s *StockTickerService) GetAllStockTickers(marketDiv string) ([]models.Stoc
 	return allStocks, nil
 }
 
+// GetStockList 상품유형/시장별 종목 조회
+// marketDiv: 시장분류코드 (J: 주식, E: ETF, EN: ETN)
+// marketClasses: 시장분류구분코드 필터 (1: 코스닥, 2: 코넥스, 4: 코스피), 비어있으면 전체
+func (s *StockTickerService) GetStockList(marketDiv string, marketClasses ...string) ([]models.StockTickerOutput, error) {
+	tickers, err := s.GetAllStockTickers(marketDiv)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(marketClasses) == 0 {
+		return tickers, nil
+	}
+
+	allowed := make(map[string]bool, len(marketClasses))
+	for _, class := range marketClasses {
+		allowed[class] = true
+	}
+
+	filtered := make([]models.StockTickerOutput, 0, len(tickers))
+	for _, ticker := range tickers {
+		if allowed[ticker.MrktClsCode] {
+			filtered = append(filtered, ticker)
+		}
+	}
+
+	return filtered, nil
+}
+
 // GetStocks 주식 종목만 조회
 func (s *StockTickerService) GetStocks() ([]models.StockTickerOutput, error) {
 	return s.GetAllStockTickers(models.MarketDivStock)
 }
 
+// GetKONEXStocks 코넥스 주식 종목만 조회
+func (s *StockTickerService) GetKONEXStocks() ([]models.StockTickerOutput, error) {
+	return s.GetStockList(models.MarketDivStock, models.MarketClassKonex)
+}
+
 // GetETFs ETF 종목만 조회
 func (s *StockTickerService) GetETFs() ([]models.StockTickerOutput, error) {
-	return s.GetAllStockTickers(models.MarketDivETF)
+	return s.GetStockList(models.MarketDivETF)
 }
 
 // GetETNs ETN 종목만 조회
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -24,12 +25,21 @@ const (
 	ErrCodeParseError     ErrorCode = "PARSE_ERROR"
 	ErrCodeNotFound       ErrorCode = "NOT_FOUND"
 	ErrCodeValidation     ErrorCode = "VALIDATION_ERROR"
+	ErrCodeNoData         ErrorCode = "NO_DATA"
+	ErrCodeMarketClosed   ErrorCode = "MARKET_CLOSED"
 	
 	// 시스템 관련 에러
 	ErrCodeServerError    ErrorCode = "SERVER_ERROR"
+	ErrCodeMaintenance    ErrorCode = "MAINTENANCE"
 	ErrCodeUnknown        ErrorCode = "UNKNOWN"
 )
 
+// ErrNoData 조회는 성공했지만 데이터가 없음
+//
+// 차트 서비스는 기본적으로 빈 결과를 에러로 보지 않는다 (휴장 기간, 상장 전 기간 조회 등은 정상적인 빈 결과다).
+// 옵션의 ErrorOnEmpty 를 켠 호출만 빈 결과일 때 ErrNoData 를 감싼 NO_DATA 에러를 받는다.
+var ErrNoData = errors.New("no data")
+
 // APIError API 에러 구조체
 type APIError struct {
 	Code       ErrorCode `json:"code"`
@@ -109,9 +119,54 @@ func NewValidationError(message string, cause error) *APIError {
 	}
 }
 
-// IsRetryableError 재시도 가능한 에러인지 확인
+// NewNoDataError 빈 결과 에러 생성 (errors.Is(err, ErrNoData) 로 확인)
+func NewNoDataError(message string) *APIError {
+	return &APIError{
+		Code:       ErrCodeNoData,
+		Message:    message,
+		StatusCode: http.StatusNotFound,
+		Cause:      ErrNoData,
+	}
+}
+
+// NewMaintenanceError API 점검 중 에러 생성
+func NewMaintenanceError(message string, cause error) *APIError {
+	return &APIError{
+		Code:       ErrCodeMaintenance,
+		Message:    message,
+		StatusCode: http.StatusServiceUnavailable,
+		Cause:      cause,
+	}
+}
+
+// IsMaintenanceError API 점검 중 에러인지 확인 (감싼 에러 포함)
+func IsMaintenanceError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeMaintenance
+}
+
+// IsNoDataError 빈 결과 에러인지 확인 (감싼 에러 포함)
+func IsNoDataError(err error) bool {
+	return errors.Is(err, ErrNoData)
+}
+
+// IsInvalidSymbolError 종목이 없거나 API 가 종목코드/입력값을 거부한 에러인지 확인 (감싼 에러 포함)
+// 다시 호출해도 결과가 같으므로 거래소 캐시를 버리는 등의 판단에 쓴다.
+func IsInvalidSymbolError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case ErrCodeNotFound, ErrCodeValidation, ErrCodeNoData:
+			return true
+		}
+	}
+	return IsNoDataError(err)
+}
+
+// IsRetryableError 재시도 가능한 에러인지 확인 (감싼 에러 포함)
 func IsRetryableError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
 		switch apiErr.Code {
 		case ErrCodeTimeout, ErrCodeNetworkError, ErrCodeServerError:
 			return true
@@ -120,6 +175,12 @@ func IsRetryableError(err error) bool {
 	return false
 }
 
+// IsRateLimitError 호출 한도 초과 에러인지 확인 (감싼 에러 포함)
+func IsRateLimitError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeRateLimit
+}
+
 // IsAuthError 인증 에러인지 확인
 func IsAuthError(err error) bool {
 	if apiErr, ok := err.(*APIError); ok {
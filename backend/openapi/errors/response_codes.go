@@ -0,0 +1,165 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ResponseClass DB증권 응답코드(rsp_cd) 분류
+type ResponseClass string
+
+const (
+	ResponseSuccess      ResponseClass = "success"
+	ResponseRetryable    ResponseClass = "retryable"     // 일시적 장애, 다시 호출하면 될 수 있음
+	ResponseQuota        ResponseClass = "quota"         // 호출 한도 초과
+	ResponseAuth         ResponseClass = "auth"          // 토큰/앱키 문제
+	ResponseInvalidInput ResponseClass = "invalid_input" // 종목코드/입력값 오류, 다시 호출해도 같은 결과
+	ResponseMarketClosed ResponseClass = "market_closed" // 장 운영시간 외 조회 불가
+	ResponseUnknown      ResponseClass = "unknown"       // 표에 없는 코드 (재시도하지 않음, DBSEC_RESPONSE_CODES 로 등록)
+)
+
+// ResponseRule 응답 분류 규칙 (Code 가 있으면 코드 일치, 없으면 응답 메시지에 Message 가 포함되면 적용)
+type ResponseRule struct {
+	Code    string
+	Message string
+	Class   ResponseClass
+}
+
+// defaultResponseRules 알려진 응답코드와 메시지 (새 코드는 여기에 추가하거나 ResponseCodes.Register 로 등록)
+var defaultResponseRules = []ResponseRule{
+	{Code: "00000", Class: ResponseSuccess},
+	{Code: "IGW00201", Class: ResponseQuota},  // 호출 거래건수를 초과하였습니다
+	{Code: "IGW00121", Class: ResponseAuth},   // 유효하지 않은 토큰
+	{Code: "IGW00123", Class: ResponseAuth},   // 만료된 토큰
+	{Code: "IGW00103", Class: ResponseAuth},   // 유효하지 않은 앱키
+	{Code: "99999", Class: ResponseRetryable}, // 시스템 오류
+
+	// 코드가 표에 없을 때 메시지로 분류 (인증/입력 오류처럼 다른 메시지에도 흔히 섞이는 단어는 코드로만 분류)
+	{Message: "거래건수를 초과", Class: ResponseQuota},
+	{Message: "장운영시간", Class: ResponseMarketClosed},
+	{Message: "장 운영시간", Class: ResponseMarketClosed},
+	{Message: "휴장", Class: ResponseMarketClosed},
+	{Message: "입력값", Class: ResponseInvalidInput},
+	{Message: "일시적", Class: ResponseRetryable},
+	{Message: "잠시 후", Class: ResponseRetryable},
+}
+
+// ResponseCodeTable 응답코드/메시지 → 분류 표
+type ResponseCodeTable struct {
+	mu       sync.RWMutex
+	codes    map[string]ResponseClass
+	messages []ResponseRule // 등록 순서대로 검사
+}
+
+// NewResponseCodeTable rules 를 등록한 표 생성
+func NewResponseCodeTable(rules ...ResponseRule) *ResponseCodeTable {
+	t := &ResponseCodeTable{codes: make(map[string]ResponseClass)}
+	t.Register(rules...)
+	return t
+}
+
+// ResponseCodes 모든 서비스가 응답코드 확인에 쓰는 표
+var ResponseCodes = NewResponseCodeTable(defaultResponseRules...)
+
+// Register 규칙 추가 (같은 코드는 나중 규칙이 덮어쓴다)
+func (t *ResponseCodeTable) Register(rules ...ResponseRule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, rule := range rules {
+		code := strings.TrimSpace(rule.Code)
+		if code != "" {
+			t.codes[code] = rule.Class
+			continue
+		}
+		if rule.Message != "" {
+			t.messages = append(t.messages, ResponseRule{Message: strings.ToLower(rule.Message), Class: rule.Class})
+		}
+	}
+}
+
+// Classify 응답코드와 메시지 분류 (코드 규칙을 먼저 보고, 없으면 메시지 규칙)
+func (t *ResponseCodeTable) Classify(code, message string) ResponseClass {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if class, ok := t.codes[strings.TrimSpace(code)]; ok {
+		return class
+	}
+	text := strings.ToLower(message)
+	for _, rule := range t.messages {
+		if strings.Contains(text, rule.Message) {
+			return rule.Class
+		}
+	}
+	return ResponseUnknown
+}
+
+// LoadRules "IGW00999=quota,40010000=invalid_input" 형식의 응답코드 규칙 등록
+func (t *ResponseCodeTable) LoadRules(value string) error {
+	var rules []ResponseRule
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		code, class, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(code) == "" {
+			return fmt.Errorf("invalid response code rule %q, expected CODE=CLASS", item)
+		}
+		parsed := ResponseClass(strings.ToLower(strings.TrimSpace(class)))
+		if !validResponseClasses[parsed] {
+			return fmt.Errorf("invalid response class %q for %s", class, code)
+		}
+		rules = append(rules, ResponseRule{Code: code, Class: parsed})
+	}
+	t.Register(rules...)
+	return nil
+}
+
+var validResponseClasses = map[ResponseClass]bool{
+	ResponseSuccess: true, ResponseRetryable: true, ResponseQuota: true,
+	ResponseAuth: true, ResponseInvalidInput: true, ResponseMarketClosed: true,
+}
+
+// Check 성공 응답이면 nil, 아니면 분류에 맞는 에러
+func (t *ResponseCodeTable) Check(code, message string) error {
+	class := t.Classify(code, message)
+	if class == ResponseSuccess {
+		return nil
+	}
+	return NewResponseError(class, code, message)
+}
+
+// CheckResponse ResponseCodes 로 응답코드 확인
+func CheckResponse(code, message string) error {
+	return ResponseCodes.Check(code, message)
+}
+
+// NewResponseError 실패 응답 분류에 맞는 에러 생성 (재시도/호출 한도/인증 판단은 에러 코드로 한다)
+// 인증 실패는 우리 서버의 앱키/토큰 문제이므로 클라이언트에는 업스트림 장애(502)로 보인다.
+func NewResponseError(class ResponseClass, code, message string) *APIError {
+	cause := fmt.Errorf("code: %s, message: %s", code, message)
+	switch class {
+	case ResponseQuota:
+		return &APIError{Code: ErrCodeRateLimit, Message: "API call quota exceeded", StatusCode: http.StatusTooManyRequests, Cause: cause}
+	case ResponseAuth:
+		return &APIError{Code: ErrCodeAuthFailed, Message: "API rejected credentials", StatusCode: http.StatusBadGateway, Cause: cause}
+	case ResponseInvalidInput:
+		return &APIError{Code: ErrCodeValidation, Message: "API rejected request", StatusCode: http.StatusBadRequest, Cause: cause}
+	case ResponseMarketClosed:
+		return &APIError{Code: ErrCodeMarketClosed, Message: "market is closed", StatusCode: http.StatusConflict, Cause: cause}
+	case ResponseUnknown:
+		return &APIError{Code: ErrCodeUnknown, Message: "API returned unknown error", StatusCode: http.StatusBadGateway, Cause: cause}
+	default:
+		return &APIError{Code: ErrCodeServerError, Message: "API returned error", StatusCode: http.StatusBadGateway, Cause: cause}
+	}
+}
+
+// IsMarketClosedError 장 운영시간 외 응답 에러인지 확인 (감싼 에러 포함)
+func IsMarketClosedError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == ErrCodeMarketClosed
+}
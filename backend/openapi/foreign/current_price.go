@@ -5,23 +5,57 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/logger"
 	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
 )
 
+// 일괄 조회 기본 동시 요청 수
+const defaultBatchConcurrency = 4
+
 // ForeignCurrentPriceService 해외주식현재가조회 서비스
 type ForeignCurrentPriceService struct {
-	client *client.DBSecClient
+	client      *client.DBSecClient
+	logger      logger.Logger
+	concurrency int
+	exchanges   *ExchangeCache
 }
 
 // NewForeignCurrentPriceService 새로운 해외주식현재가조회 서비스 생성
 func NewForeignCurrentPriceService(client *client.DBSecClient) *ForeignCurrentPriceService {
 	return &ForeignCurrentPriceService{
-		client: client,
+		client:      client,
+		logger:      logger.GetDefaultLogger().With(logger.Field{Key: "service", Value: "foreign_current_price"}),
+		concurrency: defaultBatchConcurrency,
+		exchanges:   DefaultExchangeCache,
 	}
 }
 
+// WithLogger 서비스 로거 교체
+func (s *ForeignCurrentPriceService) WithLogger(l logger.Logger) *ForeignCurrentPriceService {
+	s.logger = l
+	return s
+}
+
+// WithConcurrency 일괄 조회 시 동시 요청 수 설정 (1 이하면 순차 조회)
+func (s *ForeignCurrentPriceService) WithConcurrency(n int) *ForeignCurrentPriceService {
+	if n < 1 {
+		n = 1
+	}
+	s.concurrency = n
+	return s
+}
+
+// WithExchangeCache 미국 종목 거래소 캐시 교체 (기본: DefaultExchangeCache)
+func (s *ForeignCurrentPriceService) WithExchangeCache(cache *ExchangeCache) *ForeignCurrentPriceService {
+	s.exchanges = cache
+	return s
+}
+
 // GetForeignCurrentPrice 해외주식 현재가 조회
 // stockCode: 해외주식종목코드 (예: TSLA, AAPL)
 // marketDiv: 시장분류코드 (FY: 뉴욕, FN: 나스닥, FA: 아멕스)
@@ -30,7 +64,7 @@ func (s *ForeignCurrentPriceService) GetForeignCurrentPrice(stockCode string, ma
 	reqBody := models.ForeignCurrentPriceRequest{
 		In: models.ForeignCurrentPriceInput{
 			InputCondMrktDivCode: marketDiv,
-			InputIscd1:           stockCode,
+			InputIscd1:           models.ToAPISymbol(stockCode, marketDiv),
 		},
 	}
 
@@ -50,8 +84,8 @@ func (s *ForeignCurrentPriceService) GetForeignCurrentPrice(stockCode string, ma
 	}
 
 	// 응답 코드 확인
-	if response.RspCd != "00000" {
-		return nil, fmt.Errorf("API error %s: %s", response.RspCd, response.RspMsg)
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
+		return nil, err
 	}
 
 	// 데이터 변환
@@ -74,57 +108,106 @@ func (s *ForeignCurrentPriceService) GetAMEXStockPrice(stockCode string) (*model
 }
 
 // GetUSStockPrice 미국 주식 현재가 조회 (자동 거래소 감지)
+// 거래소를 알고 있는 종목(종목 동기화 또는 이전 조회 결과)은 해당 거래소로 바로 조회하고,
+// 모르는 종목만 나스닥 → 뉴욕 → 아멕스 순서로 찾은 뒤 찾은 거래소를 기억한다.
+// 기억한 거래소에서 입력값 오류/빈 결과를 받았을 때만 캐시를 버리고 다시 찾는다
+// (표에 없는 응답코드는 캐시 유효기간이 지나거나 DBSEC_RESPONSE_CODES 에 invalid_input 으로 등록할 때까지 캐시를 유지).
 func (s *ForeignCurrentPriceService) GetUSStockPrice(stockCode string) (*models.ForeignCurrentPriceData, error) {
-	// 먼저 나스닥에서 시도
-	if data, err := s.GetNASDAQStockPrice(stockCode); err == nil {
-		return data, nil
+	cached, ok := s.exchanges.Lookup(stockCode)
+	if ok {
+		data, err := s.GetForeignCurrentPrice(stockCode, cached)
+		if err == nil {
+			return data, nil
+		}
+		// 일시적 장애나 호출 한도 초과는 캐시가 틀린 것이 아니므로 그대로 두고 에러 반환
+		if !errors.IsInvalidSymbolError(err) {
+			return nil, err
+		}
+		// 거래소 이전 등으로 캐시가 틀렸을 수 있으므로 다시 탐색
+		s.exchanges.Forget(stockCode)
+		s.logger.Debug("Cached exchange lookup failed, searching all exchanges",
+			logger.Field{Key: "stock_code", Value: stockCode},
+			logger.Field{Key: "market", Value: cached},
+			logger.Field{Key: "error", Value: err.Error()})
 	}
 
-	// 나스닥에서 실패하면 뉴욕 거래소에서 시도
-	if data, err := s.GetNYStockPrice(stockCode); err == nil {
-		return data, nil
+	var lastErr error
+	for _, marketDiv := range usExchangeSearchOrder {
+		if ok && marketDiv == cached {
+			continue
+		}
+		data, err := s.GetForeignCurrentPrice(stockCode, marketDiv)
+		if err == nil {
+			s.exchanges.Set(stockCode, marketDiv)
+			return data, nil
+		}
+		lastErr = err
 	}
-
-	// 뉴욕에서도 실패하면 아멕스에서 시도
-	return s.GetAMEXStockPrice(stockCode)
+	return nil, lastErr
 }
 
 // GetMultipleForeignStockPrices 여러 해외 주식의 현재가 일괄 조회
-func (s *ForeignCurrentPriceService) GetMultipleForeignStockPrices(stockCodes []string, marketDiv string) (map[string]*models.ForeignCurrentPriceData, error) {
-	result := make(map[string]*models.ForeignCurrentPriceData)
-	
-	for _, code := range stockCodes {
-		data, err := s.GetForeignCurrentPrice(code, marketDiv)
-		if err != nil {
-			// 개별 오류는 로그하고 계속 진행
-			fmt.Printf("Failed to get price for %s: %v\n", code, err)
-			continue
-		}
-		result[code] = data
-	}
-	
-	return result, nil
+// 요청한 모든 종목이 결과에 포함되며, 실패한 종목은 Error 필드에 사유가 담긴다.
+func (s *ForeignCurrentPriceService) GetMultipleForeignStockPrices(stockCodes []string, marketDiv string) (map[string]*models.ForeignPriceResult, error) {
+	return s.fetchConcurrently(stockCodes, marketDiv, func(code string) (*models.ForeignCurrentPriceData, error) {
+		return s.GetForeignCurrentPrice(code, marketDiv)
+	}), nil
 }
 
 // GetMultipleUSStockPrices 여러 미국 주식의 현재가 일괄 조회 (자동 거래소 감지)
-func (s *ForeignCurrentPriceService) GetMultipleUSStockPrices(stockCodes []string) (map[string]*models.ForeignCurrentPriceData, error) {
-	result := make(map[string]*models.ForeignCurrentPriceData)
-	
+func (s *ForeignCurrentPriceService) GetMultipleUSStockPrices(stockCodes []string) (map[string]*models.ForeignPriceResult, error) {
+	return s.fetchConcurrently(stockCodes, "US", s.GetUSStockPrice), nil
+}
+
+// fetchConcurrently 최대 concurrency 개의 요청을 동시에 수행
+// 각 요청은 클라이언트의 rate limiter 를 거치므로 동시 실행 수와 별개로 호출 속도가 제한된다.
+func (s *ForeignCurrentPriceService) fetchConcurrently(stockCodes []string, market string, fetch func(code string) (*models.ForeignCurrentPriceData, error)) map[string]*models.ForeignPriceResult {
+	concurrency := s.concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		slots   = make(chan struct{}, concurrency)
+		results = make(map[string]*models.ForeignPriceResult, len(stockCodes))
+	)
+
 	for _, code := range stockCodes {
-		data, err := s.GetUSStockPrice(code)
-		if err != nil {
-			// 개별 오류는 로그하고 계속 진행
-			fmt.Printf("Failed to get price for %s: %v\n", code, err)
-			continue
-		}
-		result[code] = data
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(code string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			result := &models.ForeignPriceResult{StockCode: code}
+			data, err := fetch(code)
+			if err != nil {
+				// 개별 오류는 결과에 기록하고 계속 진행
+				s.logger.Warn("Failed to get foreign stock price",
+					logger.Field{Key: "stock_code", Value: code},
+					logger.Field{Key: "market", Value: market},
+					logger.Field{Key: "error", Value: err.Error()})
+				result.Error = err.Error()
+			} else {
+				result.Data = data
+			}
+
+			mu.Lock()
+			results[code] = result
+			mu.Unlock()
+		}(code)
 	}
-	
-	return result, nil
+	wg.Wait()
+
+	return results
 }
 
 // GetPopularStockPrices 인기 주식들의 현재가 조회
-func (s *ForeignCurrentPriceService) GetPopularStockPrices() (map[string]*models.ForeignCurrentPriceData, error) {
+func (s *ForeignCurrentPriceService) GetPopularStockPrices() (map[string]*models.ForeignPriceResult, error) {
 	popularStocks := []string{
 		"AAPL", // 애플
 		"MSFT", // 마이크로소프트  
@@ -140,7 +223,7 @@ func (s *ForeignCurrentPriceService) GetPopularStockPrices() (map[string]*models
 }
 
 // GetTechGiantsPrices 빅테크 기업들의 현재가 조회
-func (s *ForeignCurrentPriceService) GetTechGiantsPrices() (map[string]*models.ForeignCurrentPriceData, error) {
+func (s *ForeignCurrentPriceService) GetTechGiantsPrices() (map[string]*models.ForeignPriceResult, error) {
 	techGiants := []string{
 		"AAPL",  // 애플
 		"MSFT",  // 마이크로소프트
@@ -154,25 +237,31 @@ func (s *ForeignCurrentPriceService) GetTechGiantsPrices() (map[string]*models.F
 }
 
 // convertToForeignCurrentPriceData 응답 데이터를 구조화된 형식으로 변환
+// 가격 필드는 종목의 소수점자리수(zdiv)로 반올림
 func (s *ForeignCurrentPriceService) convertToForeignCurrentPriceData(stockCode string, marketDiv string, output *models.ForeignCurrentPriceOutput) *models.ForeignCurrentPriceData {
+	precision := utils.ParsePrecision(output.Zdiv, models.DefaultForeignPricePrecision)
+	price := func(str string) float64 {
+		return utils.RoundToPrecision(s.parseFloat(str), precision)
+	}
+
 	return &models.ForeignCurrentPriceData{
 		StockCode:        stockCode,
 		Market:           s.getMarketName(marketDiv),
-		BasePrice:        s.parseFloat(output.Sdpr),
-		CurrentPrice:     s.parseFloat(output.Prpr),
-		UpperLimit:       s.parseFloat(output.Mxpr),
-		LowerLimit:       s.parseFloat(output.Llam),
-		OpenPrice:        s.parseFloat(output.Oprc),
-		HighPrice:        s.parseFloat(output.Hprc),
-		LowPrice:         s.parseFloat(output.Lprc),
-		PriceChange:      s.parseFloat(output.PrdyVrss),
+		BasePrice:        price(output.Sdpr),
+		CurrentPrice:     price(output.Prpr),
+		UpperLimit:       price(output.Mxpr),
+		LowerLimit:       price(output.Llam),
+		OpenPrice:        price(output.Oprc),
+		HighPrice:        price(output.Hprc),
+		LowPrice:         price(output.Lprc),
+		PriceChange:      price(output.PrdyVrss),
 		PriceChangeRate:  s.parseFloat(output.PrdyCtrt),
 		PER:              s.parseFloat(output.Per),
 		TradingValue:     s.parseFloat(output.AcmlTrPbmn),
 		TradingVolume:    s.parseInt(output.AcmlVol),
 		YesterdayVolume:  s.parseInt(output.PrdyVol),
-		BidPrice:         s.parseFloat(output.Bidp1),
-		AskPrice:         s.parseFloat(output.Askp1),
+		BidPrice:         price(output.Bidp1),
+		AskPrice:         price(output.Askp1),
 		MarketOpenRate:   s.parseFloat(output.SdprVrssMrktRate),
 		CurrentOpenRate:  s.parseFloat(output.PrprVrssOprcRate),
 		MarketHighRate:   s.parseFloat(output.SdprVrssHgprRate),
@@ -180,21 +269,16 @@ func (s *ForeignCurrentPriceService) convertToForeignCurrentPriceData(stockCode
 		MarketLowRate:    s.parseFloat(output.SdprVrssLwprRate),
 		CurrentLowRate:   s.parseFloat(output.PrprVrssLwprRate),
 		Currency:         "USD",
+		Precision:        precision,
 	}
 }
 
 // getMarketName 시장분류코드를 시장명으로 변환
 func (s *ForeignCurrentPriceService) getMarketName(marketDiv string) string {
-	switch marketDiv {
-	case models.ForeignMarketNY:
-		return "뉴욕"
-	case models.ForeignMarketNASDAQ:
-		return "나스닥"
-	case models.ForeignMarketAMEX:
-		return "아멕스"
-	default:
-		return marketDiv
+	if market, ok := models.ResolveMarket(marketDiv); ok {
+		return market.KoreanName
 	}
+	return marketDiv
 }
 
 // 유틸리티 함수들
@@ -1,14 +1,20 @@
 package foreign
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/logger"
 	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
 )
 
 func TestForeignCurrentPriceService_GetForeignCurrentPrice(t *testing.T) {
@@ -149,7 +155,11 @@ func TestForeignCurrentPriceService_GetForeignCurrentPrice(t *testing.T) {
 		}
 
 		// 각 종목 확인
-		for code, data := range prices {
+		for code, result := range prices {
+			if result.Error != "" {
+				continue
+			}
+			data := result.Data
 			if data.StockCode != code {
 				t.Errorf("Expected stock code %s, got %s", code, data.StockCode)
 			}
@@ -168,8 +178,8 @@ func TestForeignCurrentPriceService_GetForeignCurrentPrice(t *testing.T) {
 		// 빅테크 종목들이 포함되어 있는지 확인
 		expectedStocks := []string{"AAPL", "MSFT", "GOOGL", "AMZN", "META", "NVDA"}
 		for _, stock := range expectedStocks {
-			if data, exists := prices[stock]; exists {
-				if data.StockCode != stock {
+			if result, exists := prices[stock]; exists && result.Data != nil {
+				if data := result.Data; data.StockCode != stock {
 					t.Errorf("Expected stock code %s, got %s", stock, data.StockCode)
 				}
 			}
@@ -178,49 +188,83 @@ func TestForeignCurrentPriceService_GetForeignCurrentPrice(t *testing.T) {
 }
 
 func TestForeignCurrentPriceService_GetMultipleForeignStockPrices(t *testing.T) {
-	// 모의 서버 생성
-	callCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		callCount++
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+	)
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		// rate limiter 간격(50ms)보다 길게 응답해 동시 요청이 겹치도록 한다
+		time.Sleep(150 * time.Millisecond)
 
 		var req models.ForeignCurrentPriceRequest
 		json.NewDecoder(r.Body).Decode(&req)
 
 		var response models.ForeignCurrentPriceResponse
-
-		// 요청된 종목에 따라 다른 응답 생성
 		switch req.In.InputIscd1 {
-		case "AAPL":
-			response = models.ForeignCurrentPriceResponse{
-				Out: models.ForeignCurrentPriceOutput{
-					Sdpr: "150.00", Prpr: "155.50", Per: "28.5",
-					AcmlVol: "1000000", PrdyVol: "950000",
-				},
-				RspCd: "00000", RspMsg: "정상 처리 되었습니다.",
-			}
-		case "TSLA":
-			response = models.ForeignCurrentPriceResponse{
-				Out: models.ForeignCurrentPriceOutput{
-					Sdpr: "207.82", Prpr: "207.82", Per: "32.43",
-					AcmlVol: "0", PrdyVol: "78788867",
-				},
-				RspCd: "00000", RspMsg: "정상 처리 되었습니다.",
-			}
+		case "FAIL1", "FAIL2":
+			response = models.ForeignCurrentPriceResponse{RspCd: "40000", RspMsg: "종목코드 오류"}
 		default:
 			response = models.ForeignCurrentPriceResponse{
-				Out: models.ForeignCurrentPriceOutput{
-					Sdpr: "100.00", Prpr: "100.00", Per: "20.0",
-				},
+				Out:   models.ForeignCurrentPriceOutput{Sdpr: "100.00", Prpr: "101.50", Per: "20.0"},
 				RspCd: "00000", RspMsg: "정상 처리 되었습니다.",
 			}
 		}
 
+		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
-	}))
-	defer server.Close()
+	})
+	defer mockServer.Close()
+
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+	service := NewForeignCurrentPriceService(apiClient).WithConcurrency(3)
 
-	// 테스트는 실제 구현 시 진행
-	t.Skip("Multiple foreign stock prices test requires mock client setup")
+	codes := []string{"AAPL", "FAIL1", "MSFT", "GOOGL", "FAIL2", "AMZN", "META", "NVDA"}
+	results, err := service.GetMultipleForeignStockPrices(codes, models.ForeignMarketNASDAQ)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(results) != len(codes) {
+		t.Fatalf("Expected %d results, got %d", len(codes), len(results))
+	}
+	for _, code := range codes {
+		result, ok := results[code]
+		if !ok {
+			t.Errorf("Missing result for %s", code)
+			continue
+		}
+		if result.StockCode != code {
+			t.Errorf("Expected stock code %s, got %s", code, result.StockCode)
+		}
+
+		failed := code == "FAIL1" || code == "FAIL2"
+		if failed && (result.Error == "" || result.Data != nil) {
+			t.Errorf("Expected error for %s, got %+v", code, result)
+		}
+		if !failed && (result.Error != "" || result.Data == nil || result.Data.CurrentPrice != 101.5) {
+			t.Errorf("Expected price for %s, got %+v", code, result)
+		}
+	}
+
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 concurrent requests, got %d", maxInFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("Expected requests to run concurrently, max in flight %d", maxInFlight)
+	}
 }
 
 func TestForeignCurrentPriceService_DataConversion(t *testing.T) {
@@ -273,6 +317,47 @@ func TestForeignCurrentPriceService_DataConversion(t *testing.T) {
 	}
 }
 
+func TestForeignCurrentPriceService_PricePrecision(t *testing.T) {
+	service := &ForeignCurrentPriceService{}
+
+	t.Run("FourDecimals", func(t *testing.T) {
+		output := &models.ForeignCurrentPriceOutput{
+			Sdpr:     "0.1180",
+			Prpr:     "0.1234",
+			PrdyVrss: "0.0054",
+			Zdiv:     "4",
+		}
+
+		data := service.convertToForeignCurrentPriceData("PENNY", models.ForeignMarketNASDAQ, output)
+
+		if data.Precision != 4 {
+			t.Errorf("Expected precision 4, got %d", data.Precision)
+		}
+		if data.CurrentPrice != 0.1234 {
+			t.Errorf("Expected current price 0.1234, got %v", data.CurrentPrice)
+		}
+		if data.PriceChange != 0.0054 {
+			t.Errorf("Expected price change 0.0054, got %v", data.PriceChange)
+		}
+		if got := utils.FormatPrice(data.CurrentPrice, data.Precision); got != "0.1234" {
+			t.Errorf("Expected formatted price 0.1234, got %s", got)
+		}
+	})
+
+	t.Run("DefaultPrecision", func(t *testing.T) {
+		output := &models.ForeignCurrentPriceOutput{Prpr: "207.8249"}
+
+		data := service.convertToForeignCurrentPriceData("TSLA", models.ForeignMarketNASDAQ, output)
+
+		if data.Precision != models.DefaultForeignPricePrecision {
+			t.Errorf("Expected precision %d, got %d", models.DefaultForeignPricePrecision, data.Precision)
+		}
+		if data.CurrentPrice != 207.82 {
+			t.Errorf("Expected current price 207.82, got %v", data.CurrentPrice)
+		}
+	})
+}
+
 func TestForeignCurrentPriceService_UtilityFunctions(t *testing.T) {
 	service := &ForeignCurrentPriceService{}
 
@@ -332,4 +417,176 @@ func TestForeignCurrentPriceService_UtilityFunctions(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+func TestForeignCurrentPriceService_StructuredFailureLog(t *testing.T) {
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.ForeignCurrentPriceRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		response := models.ForeignCurrentPriceResponse{RspCd: "40000", RspMsg: "종목코드 오류"}
+		if req.In.InputIscd1 == "AAPL" {
+			response = models.ForeignCurrentPriceResponse{
+				Out:   models.ForeignCurrentPriceOutput{Prpr: "155.50"},
+				RspCd: "00000", RspMsg: "정상 처리 되었습니다.",
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	defer mockServer.Close()
+
+	var buf bytes.Buffer
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+	service := NewForeignCurrentPriceService(apiClient).
+		WithLogger(logger.NewDefaultLoggerWithOutput(logger.INFO, &buf))
+
+	if _, err := service.GetMultipleForeignStockPrices([]string{"AAPL", "BADSYM"}, models.ForeignMarketNASDAQ); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Count(output, "\n") != 1 {
+		t.Fatalf("Expected exactly one log line, got %q", output)
+	}
+	for _, want := range []string{"WARN", "Failed to get foreign stock price", "stock_code=BADSYM", "market=" + models.ForeignMarketNASDAQ, "error="} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected log output to contain %q, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "AAPL") {
+		t.Errorf("Successful symbol should not be logged, got %q", output)
+	}
+}
+
+func TestForeignCurrentPriceService_ExchangeCache(t *testing.T) {
+	listed := map[string]string{
+		"JPM": models.ForeignMarketNY,
+		"IWM": models.ForeignMarketAMEX,
+	}
+
+	var (
+		mu     sync.Mutex
+		calls  []string
+		outage bool // 일시적 장애 응답
+	)
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req models.ForeignCurrentPriceRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		calls = append(calls, req.In.InputIscd1+":"+req.In.InputCondMrktDivCode)
+		failing := outage
+		mu.Unlock()
+
+		response := models.ForeignCurrentPriceResponse{RspCd: "40000", RspMsg: "입력값 오류 (종목코드)"}
+		if failing {
+			response = models.ForeignCurrentPriceResponse{RspCd: "99999", RspMsg: "시스템 오류"}
+		} else if listed[req.In.InputIscd1] == req.In.InputCondMrktDivCode {
+			response = models.ForeignCurrentPriceResponse{
+				Out:   models.ForeignCurrentPriceOutput{Prpr: "150.00"},
+				RspCd: "00000", RspMsg: "정상 처리 되었습니다.",
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	defer mockServer.Close()
+
+	cache := NewExchangeCache()
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+	service := NewForeignCurrentPriceService(apiClient).WithExchangeCache(cache)
+
+	lookup := func(code string) ([]string, error) {
+		mu.Lock()
+		calls = nil
+		mu.Unlock()
+
+		_, err := service.GetUSStockPrice(code)
+
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...), err
+	}
+	assertCalls := func(got []string, want ...string) {
+		t.Helper()
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected calls %v, got %v", want, got)
+		}
+	}
+
+	t.Run("FirstLookupSearchesAndCaches", func(t *testing.T) {
+		got, err := lookup("JPM")
+		if err != nil {
+			t.Fatalf("Failed to get JPM: %v", err)
+		}
+		assertCalls(got, "JPM:FN", "JPM:FY")
+
+		if marketDiv, ok := cache.Get("jpm"); !ok || marketDiv != models.ForeignMarketNY {
+			t.Errorf("Expected JPM cached as %s, got %q (%v)", models.ForeignMarketNY, marketDiv, ok)
+		}
+	})
+
+	t.Run("SecondLookupGoesDirectly", func(t *testing.T) {
+		got, err := lookup("JPM")
+		if err != nil {
+			t.Fatalf("Failed to get JPM: %v", err)
+		}
+		assertCalls(got, "JPM:FY")
+	})
+
+	t.Run("SeededFromTickerSync", func(t *testing.T) {
+		cache.Remember([]models.ForeignStockData{{StockCode: "IWM", Exchange: "아멕스"}})
+
+		got, err := lookup("IWM")
+		if err != nil {
+			t.Fatalf("Failed to get IWM: %v", err)
+		}
+		assertCalls(got, "IWM:FA")
+	})
+
+	t.Run("StaleEntryFallsBackToSearch", func(t *testing.T) {
+		cache.Set("JPM", "NASDAQ")
+
+		got, err := lookup("JPM")
+		if err != nil {
+			t.Fatalf("Failed to get JPM: %v", err)
+		}
+		assertCalls(got, "JPM:FN", "JPM:FY")
+
+		if marketDiv, _ := cache.Get("JPM"); marketDiv != models.ForeignMarketNY {
+			t.Errorf("Expected JPM re-cached as %s, got %q", models.ForeignMarketNY, marketDiv)
+		}
+	})
+
+	t.Run("TransientErrorKeepsCachedExchange", func(t *testing.T) {
+		mu.Lock()
+		outage = true
+		mu.Unlock()
+		got, err := lookup("JPM")
+		mu.Lock()
+		outage = false
+		mu.Unlock()
+
+		if err == nil {
+			t.Fatal("Expected error during outage")
+		}
+		assertCalls(got, "JPM:FY")
+		if marketDiv, ok := cache.Get("JPM"); !ok || marketDiv != models.ForeignMarketNY {
+			t.Errorf("Expected JPM to stay cached as %s, got %q (%v)", models.ForeignMarketNY, marketDiv, ok)
+		}
+	})
+
+	t.Run("UnknownSymbolIsNotCached", func(t *testing.T) {
+		got, err := lookup("ZZZZ")
+		if err == nil {
+			t.Fatal("Expected error for unknown symbol")
+		}
+		assertCalls(got, "ZZZZ:FN", "ZZZZ:FY", "ZZZZ:FA")
+
+		if _, ok := cache.Get("ZZZZ"); ok {
+			t.Error("Unknown symbol should not be cached")
+		}
+	})
+}
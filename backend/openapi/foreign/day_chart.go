@@ -1,6 +1,7 @@
 package foreign
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -14,8 +15,9 @@ import (
 
 // ForeignDayChartService 해외주식 일차트조회 서비스
 type ForeignDayChartService struct {
-	client *client.DBSecClient
-	logger logger.Logger
+	client    *client.DBSecClient
+	logger    logger.Logger
+	minVolume int64 // 거래량 기반 분석에서 뺄 봉의 거래량 기준 (0 이면 모든 봉 사용)
 }
 
 // NewForeignDayChartService 새로운 해외주식 일차트조회 서비스 생성
@@ -26,8 +28,24 @@ func NewForeignDayChartService(client *client.DBSecClient) *ForeignDayChartServi
 	}
 }
 
+// WithMinVolume 거래량이 minVolume 미만인 봉을 VWAP/평균 거래량/OBV/거래량 프로파일 계산에서 뺀다 (가격 계산에는 남긴다)
+func (s *ForeignDayChartService) WithMinVolume(minVolume int64) *ForeignDayChartService {
+	s.minVolume = minVolume
+	return s
+}
+
+// GetVolumeAnalysis 일차트의 거래량 기반 통계 (WithMinVolume 기준 미만 봉 제외)
+func (s *ForeignDayChartService) GetVolumeAnalysis(chartData []models.ForeignDayChartData) models.VolumeAnalysis {
+	return models.AnalyzeVolume(models.DayChartVolumeBars(chartData), s.minVolume, models.DefaultVolumeProfileLevels)
+}
+
 // GetDayChart 해외주식 일차트 데이터 조회
 func (s *ForeignDayChartService) GetDayChart(stockCode string, period models.DayChartPeriod, options models.DayChartOptions) ([]models.ForeignDayChartData, error) {
+	return s.GetDayChartContext(context.Background(), stockCode, period, options)
+}
+
+// GetDayChartContext ctx 가 취소되면 진행 중인 API 호출을 중단하는 GetDayChart
+func (s *ForeignDayChartService) GetDayChartContext(ctx context.Context, stockCode string, period models.DayChartPeriod, options models.DayChartOptions) ([]models.ForeignDayChartData, error) {
 	s.logger.Info("Getting foreign stock day chart", 
 		logger.Field{Key: "stock_code", Value: stockCode},
 		logger.Field{Key: "period", Value: period},
@@ -42,7 +60,7 @@ func (s *ForeignDayChartService) GetDayChart(stockCode string, period models.Day
 	request := s.buildRequest(stockCode, period, options)
 
 	// API 호출
-	respBody, err := s.client.MakeRequestWithHeaders("POST", models.PathForeignStockDayChart, nil, request, nil)
+	respBody, err := s.client.MakeRequestWithContext(ctx, "POST", models.PathForeignStockDayChart, nil, request, nil)
 	if err != nil {
 		s.logger.Error("Failed to call day chart API", err, 
 			logger.Field{Key: "stock_code", Value: stockCode})
@@ -57,15 +75,18 @@ func (s *ForeignDayChartService) GetDayChart(stockCode string, period models.Day
 	}
 
 	// 응답 코드 확인
-	if !utils.IsSuccessResponse(response.RspCd) {
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
 		s.logger.Warn("API returned error", 
 			logger.Field{Key: "response_code", Value: response.RspCd},
 			logger.Field{Key: "response_message", Value: response.RspMsg})
-		return nil, errors.NewAPIError(errors.ErrCodeServerError, "API returned error", fmt.Errorf("code: %s, message: %s", response.RspCd, response.RspMsg))
+		return nil, err
 	}
 
 	// 데이터 변환
 	chartData := s.convertToChartData(stockCode, response.Out, options)
+	if len(chartData) == 0 && options.ErrorOnEmpty {
+		return nil, errors.NewNoDataError(fmt.Sprintf("no day chart data for %s", stockCode))
+	}
 
 	s.logger.Info("Successfully retrieved day chart data", 
 		logger.Field{Key: "stock_code", Value: stockCode},
@@ -74,22 +95,31 @@ func (s *ForeignDayChartService) GetDayChart(stockCode string, period models.Day
 	return chartData, nil
 }
 
-// GetDayChartWithDays 일수를 지정하여 일차트 조회 (편의 메서드)
+// GetDayChartWithDays 최근 days 거래일의 일차트 조회 (편의 메서드)
+// 시장의 주말/휴장일을 건너뛰어 days 거래일 전부터 오늘까지 조회한다.
 func (s *ForeignDayChartService) GetDayChartWithDays(stockCode, market string, days int, useAdjusted bool) ([]models.ForeignDayChartData, error) {
-	endDate := time.Now().Format("2006-01-02")
-	startDate := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+	return s.GetDayChartWithDaysContext(context.Background(), stockCode, market, days, useAdjusted)
+}
 
-	period := models.DayChartPeriod{
-		StartDate: startDate,
-		EndDate:   endDate,
-	}
+// GetDayChartWithDaysContext ctx 를 따르는 GetDayChartWithDays (HTTP 요청 컨텍스트 전달용)
+func (s *ForeignDayChartService) GetDayChartWithDaysContext(ctx context.Context, stockCode, market string, days int, useAdjusted bool) ([]models.ForeignDayChartData, error) {
+	options := models.DayChartOptions{UseAdjusted: useAdjusted, Market: market}
+	return s.getRecentDayChart(ctx, stockCode, days, options)
+}
 
-	options := models.DayChartOptions{
-		UseAdjusted: useAdjusted,
-		Market:      market,
-	}
+// getRecentDayChart 최근 days 거래일의 일차트 조회
+func (s *ForeignDayChartService) getRecentDayChart(ctx context.Context, stockCode string, days int, options models.DayChartOptions) ([]models.ForeignDayChartData, error) {
+	now := models.MarketTime(time.Now(), options.Market)
+	return s.getDayChartBetween(ctx, stockCode, models.BusinessDaysBefore(now, days, options.Market), now, options)
+}
 
-	return s.GetDayChart(stockCode, period, options)
+// getDayChartBetween start ~ end 기간의 일차트 조회
+func (s *ForeignDayChartService) getDayChartBetween(ctx context.Context, stockCode string, start, end time.Time, options models.DayChartOptions) ([]models.ForeignDayChartData, error) {
+	period := models.DayChartPeriod{
+		StartDate: utils.FormatISODate(start),
+		EndDate:   utils.FormatISODate(end),
+	}
+	return s.GetDayChartContext(ctx, stockCode, period, options)
 }
 
 // GetRecentDayChart 최근 데이터 조회
@@ -114,21 +144,31 @@ func (s *ForeignDayChartService) GetAMEXDayChart(stockCode string, days int) ([]
 
 // GetYearChart 1년 차트 조회
 func (s *ForeignDayChartService) GetYearChart(stockCode, market string) ([]models.ForeignDayChartData, error) {
-	return s.GetDayChartWithDays(stockCode, market, 365, true)
+	now := time.Now()
+	return s.getDayChartBetween(context.Background(), stockCode, now.AddDate(-1, 0, 0), now, models.DayChartOptions{UseAdjusted: true, Market: market})
 }
 
 // GetMonthChart 1개월 차트 조회
 func (s *ForeignDayChartService) GetMonthChart(stockCode, market string) ([]models.ForeignDayChartData, error) {
-	return s.GetDayChartWithDays(stockCode, market, 30, true)
+	now := time.Now()
+	return s.getDayChartBetween(context.Background(), stockCode, now.AddDate(0, -1, 0), now, models.DayChartOptions{UseAdjusted: true, Market: market})
 }
 
 // GetWeekChart 1주일 차트 조회
 func (s *ForeignDayChartService) GetWeekChart(stockCode, market string) ([]models.ForeignDayChartData, error) {
-	return s.GetDayChartWithDays(stockCode, market, 7, true)
+	now := time.Now()
+	return s.getDayChartBetween(context.Background(), stockCode, now.AddDate(0, 0, -7), now, models.DayChartOptions{UseAdjusted: true, Market: market})
 }
 
 // GetPopularStocksDayChart 인기 종목들의 일차트 조회
+// 조회에 실패했거나 데이터가 없는 종목은 결과에서 빠진다 (실패 원인은 PopularStocksDayCharts).
 func (s *ForeignDayChartService) GetPopularStocksDayChart(days int) (map[string][]models.ForeignDayChartData, error) {
+	results, _ := s.PopularStocksDayCharts(days)
+	return results, nil
+}
+
+// PopularStocksDayCharts 인기 종목들의 일차트와 조회에 실패한 종목별 원인
+func (s *ForeignDayChartService) PopularStocksDayCharts(days int) (map[string][]models.ForeignDayChartData, map[string]error) {
 	popularStocks := []struct {
 		code   string
 		market string
@@ -145,38 +185,51 @@ func (s *ForeignDayChartService) GetPopularStocksDayChart(days int) (map[string]
 	}
 
 	results := make(map[string][]models.ForeignDayChartData)
+	failures := make(map[string]error)
 
 	for _, stock := range popularStocks {
-		data, err := s.GetDayChartWithDays(stock.code, stock.market, days, true)
+		options := models.DayChartOptions{UseAdjusted: true, Market: stock.market, ErrorOnEmpty: true}
+		data, err := s.getRecentDayChart(context.Background(), stock.code, days, options)
 		if err != nil {
 			s.logger.Warn("Failed to get day chart data for stock", 
 				logger.Field{Key: "stock_code", Value: stock.code},
 				logger.Field{Key: "error", Value: err.Error()})
+			failures[stock.code] = err
 			continue
 		}
 		results[stock.code] = data
 	}
 
-	return results, nil
+	return results, failures
 }
 
 // GetTechGiantsDayChart 기술주 대장주들의 일차트 조회
+// 조회에 실패했거나 데이터가 없는 종목은 결과에서 빠진다 (실패 원인은 TechGiantsDayCharts).
 func (s *ForeignDayChartService) GetTechGiantsDayChart(days int) (map[string][]models.ForeignDayChartData, error) {
+	results, _ := s.TechGiantsDayCharts(days)
+	return results, nil
+}
+
+// TechGiantsDayCharts 기술주 대장주들의 일차트와 조회에 실패한 종목별 원인
+func (s *ForeignDayChartService) TechGiantsDayCharts(days int) (map[string][]models.ForeignDayChartData, map[string]error) {
 	techStocks := []string{"AAPL", "MSFT", "GOOGL", "AMZN", "TSLA", "NVDA", "META"}
 	results := make(map[string][]models.ForeignDayChartData)
+	failures := make(map[string]error)
 
 	for _, stockCode := range techStocks {
-		data, err := s.GetNASDAQDayChart(stockCode, days)
+		options := models.DayChartOptions{UseAdjusted: true, Market: "NASDAQ", ErrorOnEmpty: true}
+		data, err := s.getRecentDayChart(context.Background(), stockCode, days, options)
 		if err != nil {
 			s.logger.Warn("Failed to get tech stock day chart", 
 				logger.Field{Key: "stock_code", Value: stockCode},
 				logger.Field{Key: "error", Value: err.Error()})
+			failures[stockCode] = err
 			continue
 		}
 		results[stockCode] = data
 	}
 
-	return results, nil
+	return results, failures
 }
 
 // validateInputs 입력값 검증
@@ -210,7 +263,7 @@ func (s *ForeignDayChartService) buildRequest(stockCode string, period models.Da
 	input := models.ForeignDayChartInput{
 		InputCondMrktDivCode: options.GetMarketCode(),
 		InputOrgAdjPrc:       options.GetAdjustedCode(),
-		InputIscd1:           stockCode,
+		InputIscd1:           models.ToAPISymbol(stockCode, options.GetMarketCode()),
 		InputDate1:           period.GetFormattedStartDate(),
 		InputDate2:           period.GetFormattedEndDate(),
 	}
@@ -225,7 +278,7 @@ func (s *ForeignDayChartService) convertToChartData(stockCode string, outputs []
 	for i, output := range outputs {
 		data := models.ForeignDayChartData{
 			StockCode:  stockCode,
-			Date:       s.formatDate(output.Date),
+			Date:       utils.YMDToISO(output.Date),
 			Open:       utils.ParseFloat(output.Oprc),
 			High:       utils.ParseFloat(output.Hprc),
 			Low:        utils.ParseFloat(output.Lprc),
@@ -252,36 +305,17 @@ func (s *ForeignDayChartService) convertToChartData(stockCode string, outputs []
 	return chartData
 }
 
-// formatDate 날짜를 YYYY-MM-DD 형식으로 변환
-func (s *ForeignDayChartService) formatDate(date string) string {
-	if len(date) != 8 {
-		return ""
-	}
-	return fmt.Sprintf("%s-%s-%s", date[:4], date[4:6], date[6:8])
-}
-
 // getMarketName 시장 코드를 시장명으로 변환
 func (s *ForeignDayChartService) getMarketName(marketCode string) string {
-	switch marketCode {
-	case models.ForeignMarketNY:
-		return "New York Stock Exchange"
-	case models.ForeignMarketNASDAQ:
-		return "NASDAQ"
-	case models.ForeignMarketAMEX:
-		return "American Stock Exchange"
-	default:
-		return "Unknown"
+	if market, ok := models.ResolveMarket(marketCode); ok {
+		return market.EnglishName
 	}
+	return "Unknown"
 }
 
 // getWeekDay 날짜에서 요일 계산
 func (s *ForeignDayChartService) getWeekDay(dateStr string) string {
-	if len(dateStr) != 8 {
-		return ""
-	}
-
-	// YYYYMMDD 형식을 time.Time으로 변환
-	t, err := time.Parse("20060102", dateStr)
+	t, err := utils.ParseYMD(dateStr)
 	if err != nil {
 		return ""
 	}
@@ -297,14 +331,13 @@ func (s *ForeignDayChartService) GetPriceStatistics(chartData []models.ForeignDa
 	}
 
 	var highs, lows, closes []float64
-	var totalVolume int64
 
 	for _, data := range chartData {
 		highs = append(highs, data.High)
 		lows = append(lows, data.Low)
 		closes = append(closes, data.Close)
-		totalVolume += data.Volume
 	}
+	volume := s.GetVolumeAnalysis(chartData)
 
 	stats := make(map[string]float64)
 	
@@ -315,8 +348,9 @@ func (s *ForeignDayChartService) GetPriceStatistics(chartData []models.ForeignDa
 	// 평균가격
 	stats["avg_close"] = s.avgFloat(closes)
 	
-	// 평균거래량
-	stats["avg_volume"] = float64(totalVolume) / float64(len(chartData))
+	// 평균거래량, 거래량 가중 평균가 (거래량 기준 미만 봉 제외)
+	stats["avg_volume"] = volume.AvgVolume
+	stats["vwap"] = volume.VWAP
 	
 	// 변동성 (표준편차)
 	stats["volatility"] = s.stdDevFloat(closes)
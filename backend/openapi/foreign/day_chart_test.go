@@ -258,25 +258,6 @@ func TestForeignDayChartService_DataConversion(t *testing.T) {
 func TestForeignDayChartService_UtilityFunctions(t *testing.T) {
 	service := &ForeignDayChartService{}
 
-	t.Run("formatDate", func(t *testing.T) {
-		tests := []struct {
-			input    string
-			expected string
-		}{
-			{"20250711", "2025-07-11"},
-			{"20231225", "2023-12-25"},
-			{"", ""},
-			{"2025071", ""},
-		}
-
-		for _, test := range tests {
-			result := service.formatDate(test.input)
-			if result != test.expected {
-				t.Errorf("formatDate(%s) = %s, expected %s", test.input, result, test.expected)
-			}
-		}
-	})
-
 	t.Run("getMarketName", func(t *testing.T) {
 		tests := []struct {
 			input    string
@@ -0,0 +1,120 @@
+package foreign
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+)
+
+// newEmptyChartServer 모든 차트 조회에 정상 코드와 빈 Out 을 돌려주는 모의 서버
+func newEmptyChartServer(t *testing.T) *utils.MockServer {
+	return utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"rsp_cd":  "00000",
+			"rsp_msg": "정상 처리 되었습니다.",
+			"Out":     []interface{}{},
+		})
+	})
+}
+
+func TestChartServices_EmptyResult(t *testing.T) {
+	mockServer := newEmptyChartServer(t)
+	defer mockServer.Close()
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+	day := NewForeignDayChartService(apiClient)
+	week := NewForeignWeekChartService(apiClient)
+	month := NewForeignMonthChartService(apiClient)
+	min := NewForeignMinChartService(apiClient)
+
+	dayPeriod := models.DayChartPeriod{StartDate: "2024-01-02", EndDate: "2024-01-31"}
+	weekPeriod := models.WeekChartPeriod{StartDate: "2024-01-02", EndDate: "2024-03-29"}
+	monthPeriod := models.MonthChartPeriod{StartDate: "2023-01-02", EndDate: "2024-01-31"}
+	minPeriod := models.ChartPeriod{StartDate: "20240102", EndDate: "20240102", IsRange: true}
+
+	calls := []struct {
+		name  string
+		fetch func(strict bool) (int, error)
+	}{
+		{"day", func(strict bool) (int, error) {
+			data, err := day.GetDayChart("AAPL", dayPeriod, models.DayChartOptions{Market: "NASDAQ", ErrorOnEmpty: strict})
+			return len(data), err
+		}},
+		{"week", func(strict bool) (int, error) {
+			data, err := week.GetWeekChart("AAPL", weekPeriod, models.WeekChartOptions{Market: "NASDAQ", ErrorOnEmpty: strict})
+			return len(data), err
+		}},
+		{"month", func(strict bool) (int, error) {
+			data, err := month.GetMonthChart("AAPL", monthPeriod, models.MonthChartOptions{Market: "NASDAQ", ErrorOnEmpty: strict})
+			return len(data), err
+		}},
+		{"min", func(strict bool) (int, error) {
+			data, err := min.GetMinChart("AAPL", minPeriod, models.ChartOptions{Interval: "1min", Market: "NASDAQ", ErrorOnEmpty: strict})
+			return len(data), err
+		}},
+	}
+
+	for _, call := range calls {
+		t.Run(call.name+"/lenient", func(t *testing.T) {
+			count, err := call.fetch(false)
+			if err != nil {
+				t.Fatalf("Expected nil error for empty result, got %v", err)
+			}
+			if count != 0 {
+				t.Errorf("Expected empty result, got %d rows", count)
+			}
+		})
+
+		t.Run(call.name+"/strict", func(t *testing.T) {
+			_, err := call.fetch(true)
+			if !stderrors.Is(err, errors.ErrNoData) {
+				t.Fatalf("Expected ErrNoData, got %v", err)
+			}
+			if !errors.IsNoDataError(err) {
+				t.Errorf("IsNoDataError(%v) = false", err)
+			}
+			if errors.IsRetryableError(err) {
+				t.Errorf("Empty result should not be retryable")
+			}
+		})
+	}
+}
+
+func TestChartServices_EmptyResultDefaults(t *testing.T) {
+	mockServer := newEmptyChartServer(t)
+	defer mockServer.Close()
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+
+	// 단일 종목 편의 메서드는 빈 결과를 그대로 돌려준다
+	if data, err := NewForeignDayChartService(apiClient).GetNASDAQDayChart("AAPL", 5); err != nil || len(data) != 0 {
+		t.Errorf("GetNASDAQDayChart = %d rows, %v; expected empty result without error", len(data), err)
+	}
+	if data, err := NewForeignWeekChartService(apiClient).Get13WeekChart("AAPL", "NASDAQ"); err != nil || len(data) != 0 {
+		t.Errorf("Get13WeekChart = %d rows, %v; expected empty result without error", len(data), err)
+	}
+
+	// 여러 종목 묶음 조회는 데이터가 없는 종목을 결과에서 뺀다
+	dayResults, err := NewForeignDayChartService(apiClient).GetTechGiantsDayChart(5)
+	if err != nil || len(dayResults) != 0 {
+		t.Errorf("GetTechGiantsDayChart = %v, %v; expected no entries", dayResults, err)
+	}
+	weekResults, err := NewForeignWeekChartService(apiClient).GetTechGiantsWeekChart(4)
+	if err != nil || len(weekResults) != 0 {
+		t.Errorf("GetTechGiantsWeekChart = %v, %v; expected no entries", weekResults, err)
+	}
+	monthResults, err := NewForeignMonthChartService(apiClient).GetTechGiantsMonthChart(3)
+	if err != nil || len(monthResults) != 0 {
+		t.Errorf("GetTechGiantsMonthChart = %v, %v; expected no entries", monthResults, err)
+	}
+	minResults, err := NewForeignMinChartService(apiClient).GetPopularStocksMinChart("1min", 1)
+	if err != nil || len(minResults) != 0 {
+		t.Errorf("GetPopularStocksMinChart = %v, %v; expected no entries", minResults, err)
+	}
+}
@@ -0,0 +1,216 @@
+package foreign
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/openapi/models"
+)
+
+// usExchangeSearchOrder 거래소를 모르는 미국 종목을 찾을 때 시도하는 시장분류코드 순서
+var usExchangeSearchOrder = []string{
+	models.ForeignMarketNASDAQ,
+	models.ForeignMarketNY,
+	models.ForeignMarketAMEX,
+}
+
+// 거래소 기록 유효 시간
+const (
+	defaultExchangeTTL     = 24 * time.Hour   // 찾은 거래소 (거래소 이전을 주기적으로 다시 확인)
+	defaultExchangeMissTTL = 10 * time.Minute // loader 가 모른다고 한 종목 (그동안은 loader 를 다시 부르지 않는다)
+)
+
+// ExchangeLoader 캐시에 없는 종목의 시장 별칭을 찾는 함수 (종목 동기화 결과 등, 모르면 false)
+type ExchangeLoader func(stockCode string) (string, bool, error)
+
+// ExchangeCache 미국 종목코드 → 상장 거래소 시장분류코드(FY/FN/FA) 캐시
+// 종목 동기화 결과나 첫 조회 성공 결과를 기억해 다음 조회부터 거래소 탐색을 생략한다.
+// 수집기, 현재가 서비스, 시장 해석이 동시에 읽으므로 모든 메서드는 동시 호출에 안전하다.
+type ExchangeCache struct {
+	mu        sync.RWMutex
+	exchanges map[string]exchangeEntry
+	misses    map[string]time.Time // loader 가 모른다고 한 종목과 다시 찾아볼 시각
+	ttl       time.Duration
+	missTTL   time.Duration
+	loader    ExchangeLoader
+	loading   map[string]*exchangeLoad // 종목별 진행 중인 loader 호출 (같은 종목은 한 번만 호출)
+	now       func() time.Time
+}
+
+type exchangeEntry struct {
+	marketDiv string
+	expiresAt time.Time // zero 면 만료 없음
+}
+
+// exchangeLoad 진행 중인 loader 호출 (끝나면 done 이 닫힌다)
+type exchangeLoad struct {
+	done      chan struct{}
+	marketDiv string
+	ok        bool
+}
+
+// DefaultExchangeCache 서비스 인스턴스 사이에서 공유하는 기본 캐시
+var DefaultExchangeCache = NewExchangeCache()
+
+// NewExchangeCache 빈 캐시 생성 (기록은 defaultExchangeTTL 동안 유효)
+func NewExchangeCache() *ExchangeCache {
+	return &ExchangeCache{
+		exchanges: make(map[string]exchangeEntry),
+		misses:    make(map[string]time.Time),
+		ttl:       defaultExchangeTTL,
+		missTTL:   defaultExchangeMissTTL,
+		loading:   make(map[string]*exchangeLoad),
+		now:       time.Now,
+	}
+}
+
+// WithTTL 기록 유효 시간 변경 (0 이하면 만료 없음)
+func (c *ExchangeCache) WithTTL(ttl time.Duration) *ExchangeCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	return c
+}
+
+// WithMissTTL loader 가 모른다고 한 종목을 다시 찾아보기까지 기다리는 시간 (0 이하면 모르는 종목은 기억하지 않음)
+func (c *ExchangeCache) WithMissTTL(ttl time.Duration) *ExchangeCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.missTTL = ttl
+	return c
+}
+
+// WithLoader 캐시에 없거나 만료된 종목을 Lookup 할 때 사용할 loader 설정
+func (c *ExchangeCache) WithLoader(loader ExchangeLoader) *ExchangeCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loader = loader
+	return c
+}
+
+// Get 종목의 시장분류코드 (만료된 기록은 없는 것으로 본다)
+func (c *ExchangeCache) Get(stockCode string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.get(normalizeStockCode(stockCode))
+}
+
+// get c.mu 를 잡고 호출
+func (c *ExchangeCache) get(key string) (string, bool) {
+	entry, ok := c.exchanges[key]
+	if !ok || (!entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)) {
+		return "", false
+	}
+	return entry.marketDiv, true
+}
+
+// missed c.mu 를 잡고 호출 (loader 가 모른다고 한 뒤 missTTL 이 지나지 않았으면 true)
+func (c *ExchangeCache) missed(key string) bool {
+	retryAt, ok := c.misses[key]
+	return ok && c.now().Before(retryAt)
+}
+
+// Lookup 종목의 시장분류코드 (캐시에 없으면 loader 로 찾아 기록)
+// 같은 종목을 동시에 찾으면 loader 는 한 번만 호출되고 나머지는 그 결과를 기다린다.
+// loader 가 모른다고 한 종목은 missTTL 동안 loader 를 부르지 않고 바로 false 를 반환한다 (loader 에러는 기억하지 않는다).
+func (c *ExchangeCache) Lookup(stockCode string) (string, bool) {
+	key := normalizeStockCode(stockCode)
+
+	// 대부분의 조회는 캐시 적중이므로 읽기 잠금으로 먼저 확인한다
+	c.mu.RLock()
+	marketDiv, ok := c.get(key)
+	missed := c.missed(key)
+	c.mu.RUnlock()
+	if ok {
+		return marketDiv, true
+	}
+	if missed {
+		return "", false
+	}
+
+	c.mu.Lock()
+	if marketDiv, ok := c.get(key); ok {
+		c.mu.Unlock()
+		return marketDiv, true
+	}
+	if c.loader == nil || key == "" || c.missed(key) {
+		c.mu.Unlock()
+		return "", false
+	}
+	if load, ok := c.loading[key]; ok {
+		c.mu.Unlock()
+		<-load.done
+		return load.marketDiv, load.ok
+	}
+	load := &exchangeLoad{done: make(chan struct{})}
+	c.loading[key] = load
+	loader := c.loader
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.loading, key)
+		c.mu.Unlock()
+		close(load.done)
+	}()
+
+	market, found, err := loader(key)
+	if err != nil {
+		return "", false
+	}
+	if !found {
+		c.mu.Lock()
+		if c.missTTL > 0 {
+			c.misses[key] = c.now().Add(c.missTTL)
+		}
+		c.mu.Unlock()
+		return "", false
+	}
+	c.Set(key, market)
+	load.marketDiv, load.ok = c.Get(key)
+	return load.marketDiv, load.ok
+}
+
+// Set 종목의 거래소 기록 (NY, NASDAQ, FY 같은 시장 별칭 허용, 해외 시장이 아니면 무시)
+func (c *ExchangeCache) Set(stockCode, market string) {
+	resolved, ok := models.ResolveMarket(market)
+	if !ok || !resolved.IsForeign() || strings.TrimSpace(stockCode) == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := exchangeEntry{marketDiv: resolved.Code}
+	if c.ttl > 0 {
+		entry.expiresAt = c.now().Add(c.ttl)
+	}
+	key := normalizeStockCode(stockCode)
+	c.exchanges[key] = entry
+	delete(c.misses, key)
+}
+
+// Forget 종목의 거래소 기록 삭제 (이전 또는 상장폐지로 조회가 실패한 경우)
+func (c *ExchangeCache) Forget(stockCode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.exchanges, normalizeStockCode(stockCode))
+}
+
+// Remember 종목 동기화 결과의 거래소를 일괄 기록
+func (c *ExchangeCache) Remember(tickers []models.ForeignStockData) {
+	for _, ticker := range tickers {
+		c.Set(ticker.StockCode, ticker.Exchange)
+	}
+}
+
+// Len 기록된 종목 수
+func (c *ExchangeCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.exchanges)
+}
+
+func normalizeStockCode(stockCode string) string {
+	return strings.ToUpper(strings.TrimSpace(stockCode))
+}
@@ -0,0 +1,180 @@
+package foreign
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/openapi/models"
+)
+
+func TestExchangeCache_TTL(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	cache := NewExchangeCache().WithTTL(time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("jpm", "NY")
+	if marketDiv, ok := cache.Get("JPM"); !ok || marketDiv != models.ForeignMarketNY {
+		t.Fatalf("Expected JPM cached as %s, got %q (%v)", models.ForeignMarketNY, marketDiv, ok)
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := cache.Get("JPM"); ok {
+		t.Error("Expected JPM to expire after the TTL")
+	}
+
+	cache.WithTTL(0).Set("JPM", "NY")
+	now = now.Add(365 * 24 * time.Hour)
+	if _, ok := cache.Get("JPM"); !ok {
+		t.Error("Expected entries without TTL to never expire")
+	}
+}
+
+func TestExchangeCache_LookupRefreshesOnMiss(t *testing.T) {
+	var loads int32
+	cache := NewExchangeCache().WithLoader(func(stockCode string) (string, bool, error) {
+		atomic.AddInt32(&loads, 1)
+		switch stockCode {
+		case "IWM":
+			return "아멕스", true, nil
+		case "FAIL":
+			return "", false, fmt.Errorf("db down")
+		}
+		return "", false, nil
+	})
+
+	if marketDiv, ok := cache.Lookup("iwm"); !ok || marketDiv != models.ForeignMarketAMEX {
+		t.Errorf("Expected IWM loaded as %s, got %q (%v)", models.ForeignMarketAMEX, marketDiv, ok)
+	}
+	cache.Lookup("IWM")
+	if loads != 1 {
+		t.Errorf("Expected cached symbol to skip the loader, got %d loads", loads)
+	}
+
+	for _, code := range []string{"UNKNOWN", "FAIL", ""} {
+		if _, ok := cache.Lookup(code); ok {
+			t.Errorf("Expected Lookup(%q) to miss", code)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected misses not to be cached, got %d entries", cache.Len())
+	}
+}
+
+func TestExchangeCache_RemembersMisses(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	loads := map[string]int{}
+	cache := NewExchangeCache().WithMissTTL(time.Minute).WithLoader(func(stockCode string) (string, bool, error) {
+		loads[stockCode]++
+		if stockCode == "FAIL" {
+			return "", false, fmt.Errorf("db down")
+		}
+		return "", false, nil
+	})
+	cache.now = func() time.Time { return now }
+
+	// 모르는 종목은 missTTL 동안 loader 를 다시 부르지 않는다
+	cache.Lookup("NEWIPO")
+	cache.Lookup("newipo")
+	if loads["NEWIPO"] != 1 {
+		t.Errorf("Expected a cached miss to skip the loader, got %d loads", loads["NEWIPO"])
+	}
+	now = now.Add(time.Minute)
+	cache.Lookup("NEWIPO")
+	if loads["NEWIPO"] != 2 {
+		t.Errorf("Expected the loader to be retried after the miss TTL, got %d loads", loads["NEWIPO"])
+	}
+
+	// 종목 동기화 등으로 거래소가 기록되면 바로 찾는다
+	cache.Set("NEWIPO", "NASDAQ")
+	if marketDiv, ok := cache.Lookup("NEWIPO"); !ok || marketDiv != models.ForeignMarketNASDAQ {
+		t.Errorf("Expected NEWIPO after Set, got %q (%v)", marketDiv, ok)
+	}
+
+	// loader 에러는 일시적일 수 있으므로 기억하지 않는다
+	cache.Lookup("FAIL")
+	cache.Lookup("FAIL")
+	if loads["FAIL"] != 2 {
+		t.Errorf("Expected loader errors not to be cached, got %d loads", loads["FAIL"])
+	}
+}
+
+// go test -race 로 실행해 동시 접근을 확인한다
+func TestExchangeCache_ConcurrentLookup(t *testing.T) {
+	symbols := []string{"AAPL", "JPM", "IWM", "MSFT", "GE"}
+	markets := []string{"NASDAQ", "NY", "AMEX", "NASDAQ", "NY"}
+
+	var (
+		mu    sync.Mutex
+		loads = map[string]int{}
+	)
+	release := make(chan struct{})
+	cache := NewExchangeCache().WithLoader(func(stockCode string) (string, bool, error) {
+		mu.Lock()
+		loads[stockCode]++
+		mu.Unlock()
+		<-release // 모든 조회가 진행 중인 loader 를 기다리도록 붙잡아 둔다
+		for i, symbol := range symbols {
+			if symbol == stockCode {
+				return markets[i], true, nil
+			}
+		}
+		return "", false, nil
+	})
+
+	type result struct {
+		symbol, marketDiv string
+		ok                bool
+	}
+	const workers = 20
+	var wg sync.WaitGroup
+	results := make(chan result, workers*len(symbols))
+	for w := 0; w < workers; w++ {
+		for _, symbol := range symbols {
+			wg.Add(1)
+			go func(symbol string) {
+				defer wg.Done()
+				marketDiv, ok := cache.Lookup(symbol)
+				results <- result{symbol, marketDiv, ok}
+			}(symbol)
+		}
+	}
+
+	// 다른 종목의 읽기/쓰기도 섞는다
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			cache.Set("SPY", "AMEX")
+			cache.Get("SPY")
+			cache.Forget("SPY")
+			cache.Len()
+		}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	expected := map[string]string{}
+	for i, symbol := range symbols {
+		resolved, _ := models.ResolveMarket(markets[i])
+		expected[symbol] = resolved.Code
+	}
+	for r := range results {
+		if !r.ok || r.marketDiv != expected[r.symbol] {
+			t.Errorf("Lookup(%s) = %q (%v), expected %s", r.symbol, r.marketDiv, r.ok, expected[r.symbol])
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, symbol := range symbols {
+		if loads[symbol] != 1 {
+			t.Errorf("Expected one loader call for %s, got %d", symbol, loads[symbol])
+		}
+	}
+}
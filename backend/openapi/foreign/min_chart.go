@@ -14,8 +14,9 @@ import (
 
 // ForeignMinChartService 해외주식 분차트조회 서비스
 type ForeignMinChartService struct {
-	client *client.DBSecClient
-	logger logger.Logger
+	client    *client.DBSecClient
+	logger    logger.Logger
+	minVolume int64 // 거래량 기반 분석에서 뺄 봉의 거래량 기준 (0 이면 모든 봉 사용)
 }
 
 // NewForeignMinChartService 새로운 해외주식 분차트조회 서비스 생성
@@ -26,6 +27,17 @@ func NewForeignMinChartService(client *client.DBSecClient) *ForeignMinChartServi
 	}
 }
 
+// WithMinVolume 거래량이 minVolume 미만인 봉을 VWAP/평균 거래량/OBV/거래량 프로파일 계산에서 뺀다 (가격 계산에는 남긴다)
+func (s *ForeignMinChartService) WithMinVolume(minVolume int64) *ForeignMinChartService {
+	s.minVolume = minVolume
+	return s
+}
+
+// GetVolumeAnalysis 분차트의 거래량 기반 통계 (WithMinVolume 기준 미만 봉 제외)
+func (s *ForeignMinChartService) GetVolumeAnalysis(chartData []models.ForeignMinChartData) models.VolumeAnalysis {
+	return models.AnalyzeVolume(models.MinChartVolumeBars(chartData), s.minVolume, models.DefaultVolumeProfileLevels)
+}
+
 // GetMinChart 해외주식 분차트 데이터 조회
 func (s *ForeignMinChartService) GetMinChart(stockCode string, period models.ChartPeriod, options models.ChartOptions) ([]models.ForeignMinChartData, error) {
 	s.logger.Info("Getting foreign stock min chart", 
@@ -57,15 +69,18 @@ func (s *ForeignMinChartService) GetMinChart(stockCode string, period models.Cha
 	}
 
 	// 응답 코드 확인
-	if !utils.IsSuccessResponse(response.RspCd) {
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
 		s.logger.Warn("API returned error", 
 			logger.Field{Key: "response_code", Value: response.RspCd},
 			logger.Field{Key: "response_message", Value: response.RspMsg})
-		return nil, errors.NewAPIError(errors.ErrCodeServerError, "API returned error", fmt.Errorf("code: %s, message: %s", response.RspCd, response.RspMsg))
+		return nil, err
 	}
 
 	// 데이터 변환
 	chartData := s.convertToChartData(stockCode, response.Out, options)
+	if len(chartData) == 0 && options.ErrorOnEmpty {
+		return nil, errors.NewNoDataError(fmt.Sprintf("no min chart data for %s", stockCode))
+	}
 
 	s.logger.Info("Successfully retrieved min chart data", 
 		logger.Field{Key: "stock_code", Value: stockCode},
@@ -75,16 +90,8 @@ func (s *ForeignMinChartService) GetMinChart(stockCode string, period models.Cha
 }
 
 // GetMinChartWithOptions 옵션을 사용한 분차트 조회 (편의 메서드)
+// days 는 거래일 수이며 시장의 주말/휴장일을 건너뛴다.
 func (s *ForeignMinChartService) GetMinChartWithOptions(stockCode, market, interval string, days int, useAdjusted bool) ([]models.ForeignMinChartData, error) {
-	endDate := time.Now().Format("20060102")
-	startDate := time.Now().AddDate(0, 0, -days).Format("20060102")
-
-	period := models.ChartPeriod{
-		StartDate: startDate,
-		EndDate:   endDate,
-		IsRange:   true,
-	}
-
 	options := models.ChartOptions{
 		Interval:    interval,
 		UseAdjusted: useAdjusted,
@@ -92,12 +99,22 @@ func (s *ForeignMinChartService) GetMinChartWithOptions(stockCode, market, inter
 		DataCount:   0, // 기본값 사용
 	}
 
-	return s.GetMinChart(stockCode, period, options)
+	return s.GetMinChart(stockCode, recentTradingDays(days, market), options)
+}
+
+// recentTradingDays 오늘까지 최근 days 거래일의 조회 기간
+func recentTradingDays(days int, market string) models.ChartPeriod {
+	now := models.MarketTime(time.Now(), market)
+	return models.ChartPeriod{
+		StartDate: utils.FormatYMD(models.BusinessDaysBefore(now, days, market)),
+		EndDate:   utils.FormatYMD(now),
+		IsRange:   true,
+	}
 }
 
 // GetLatestMinChart 최근 데이터 조회 (기간 미지정)
 func (s *ForeignMinChartService) GetLatestMinChart(stockCode, market, interval string, dataCount int) ([]models.ForeignMinChartData, error) {
-	endDate := time.Now().Format("20060102")
+	endDate := utils.FormatYMD(time.Now())
 
 	period := models.ChartPeriod{
 		EndDate: endDate,
@@ -130,6 +147,7 @@ func (s *ForeignMinChartService) GetAMEXMinChart(stockCode, interval string, day
 }
 
 // GetPopularStocksMinChart 인기 종목들의 분차트 조회
+// 조회에 실패했거나 데이터가 없는 종목은 결과에서 빠진다.
 func (s *ForeignMinChartService) GetPopularStocksMinChart(interval string, days int) (map[string][]models.ForeignMinChartData, error) {
 	popularStocks := []struct {
 		code   string
@@ -147,7 +165,8 @@ func (s *ForeignMinChartService) GetPopularStocksMinChart(interval string, days
 	results := make(map[string][]models.ForeignMinChartData)
 
 	for _, stock := range popularStocks {
-		data, err := s.GetMinChartWithOptions(stock.code, stock.market, interval, days, true)
+		options := models.ChartOptions{Interval: interval, UseAdjusted: true, Market: stock.market, ErrorOnEmpty: true}
+		data, err := s.GetMinChart(stock.code, recentTradingDays(days, stock.market), options)
 		if err != nil {
 			s.logger.Warn("Failed to get chart data for stock", 
 				logger.Field{Key: "stock_code", Value: stock.code},
@@ -196,7 +215,7 @@ func (s *ForeignMinChartService) validateInputs(stockCode string, period models.
 func (s *ForeignMinChartService) buildRequest(stockCode string, period models.ChartPeriod, options models.ChartOptions) models.ForeignMinChartRequest {
 	input := models.ForeignMinChartInput{
 		InputCondMrktDivCode: options.GetMarketCode(),
-		InputIscd1:           stockCode,
+		InputIscd1:           models.ToAPISymbol(stockCode, options.GetMarketCode()),
 		InputHourClsCode:     models.HourClassCode,
 		InputDivXtick:        options.GetIntervalCode(),
 		InputOrgAdjPrc:       options.GetAdjustedCode(),
@@ -219,13 +238,16 @@ func (s *ForeignMinChartService) buildRequest(stockCode string, period models.Ch
 // convertToChartData API 응답을 비즈니스 모델로 변환
 func (s *ForeignMinChartService) convertToChartData(stockCode string, outputs []models.ForeignMinChartOutput, options models.ChartOptions) []models.ForeignMinChartData {
 	var chartData []models.ForeignMinChartData
+	location := models.MarketTradingHours(options.GetMarketCode()).Location
 
 	for _, output := range outputs {
+		timestamp, _ := utils.ParseYMDHMS(output.Date, output.Hour, location)
 		data := models.ForeignMinChartData{
 			StockCode:    stockCode,
-			DateTime:     s.formatDateTime(output.Date, output.Hour),
-			Date:         s.formatDate(output.Date),
-			Time:         s.formatTime(output.Hour),
+			DateTime:     utils.JoinDateTime(output.Date, output.Hour),
+			Timestamp:    timestamp,
+			Date:         utils.YMDToISO(output.Date),
+			Time:         utils.HMSToClock(output.Hour),
 			Open:         utils.ParseFloat(output.Oprc),
 			High:         utils.ParseFloat(output.Hprc),
 			Low:          utils.ParseFloat(output.Lprc),
@@ -243,46 +265,12 @@ func (s *ForeignMinChartService) convertToChartData(stockCode string, outputs []
 	return chartData
 }
 
-// formatDateTime 날짜와 시간을 ISO 형식으로 변환
-func (s *ForeignMinChartService) formatDateTime(date, hour string) string {
-	if len(date) != 8 || len(hour) != 6 {
-		return ""
-	}
-	
-	dateFormatted := fmt.Sprintf("%s-%s-%s", date[:4], date[4:6], date[6:8])
-	timeFormatted := fmt.Sprintf("%s:%s:%s", hour[:2], hour[2:4], hour[4:6])
-	
-	return fmt.Sprintf("%s %s", dateFormatted, timeFormatted)
-}
-
-// formatDate 날짜를 YYYY-MM-DD 형식으로 변환
-func (s *ForeignMinChartService) formatDate(date string) string {
-	if len(date) != 8 {
-		return ""
-	}
-	return fmt.Sprintf("%s-%s-%s", date[:4], date[4:6], date[6:8])
-}
-
-// formatTime 시간을 HH:MM:SS 형식으로 변환
-func (s *ForeignMinChartService) formatTime(hour string) string {
-	if len(hour) != 6 {
-		return ""
-	}
-	return fmt.Sprintf("%s:%s:%s", hour[:2], hour[2:4], hour[4:6])
-}
-
 // getMarketName 시장 코드를 시장명으로 변환
 func (s *ForeignMinChartService) getMarketName(marketCode string) string {
-	switch marketCode {
-	case models.ForeignMarketNY:
-		return "New York Stock Exchange"
-	case models.ForeignMarketNASDAQ:
-		return "NASDAQ"
-	case models.ForeignMarketAMEX:
-		return "American Stock Exchange"
-	default:
-		return "Unknown"
+	if market, ok := models.ResolveMarket(marketCode); ok {
+		return market.EnglishName
 	}
+	return "Unknown"
 }
 
 // GetIntervalDescription 시간간격 코드를 설명으로 변환
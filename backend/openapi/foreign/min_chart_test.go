@@ -2,6 +2,7 @@ package foreign
 
 import (
 	"testing"
+	"time"
 
 	"stock-recommender/backend/openapi/client"
 	"stock-recommender/backend/openapi/models"
@@ -200,69 +201,26 @@ func TestForeignMinChartService_DataConversion(t *testing.T) {
 		if chartData.DateTime != "2024-02-05 16:30:00" {
 			t.Errorf("Expected datetime 2024-02-05 16:30:00, got %s", chartData.DateTime)
 		}
-	}
-}
-
-func TestForeignMinChartService_UtilityFunctions(t *testing.T) {
-	service := &ForeignMinChartService{}
-
-	t.Run("formatDateTime", func(t *testing.T) {
-		tests := []struct {
-			date     string
-			hour     string
-			expected string
-		}{
-			{"20240205", "163000", "2024-02-05 16:30:00"},
-			{"20231225", "093000", "2023-12-25 09:30:00"},
-			{"", "163000", ""},
-			{"20240205", "", ""},
-		}
 
-		for _, test := range tests {
-			result := service.formatDateTime(test.date, test.hour)
-			if result != test.expected {
-				t.Errorf("formatDateTime(%s, %s) = %s, expected %s", test.date, test.hour, result, test.expected)
-			}
-		}
-	})
-
-	t.Run("formatDate", func(t *testing.T) {
-		tests := []struct {
-			input    string
-			expected string
-		}{
-			{"20240205", "2024-02-05"},
-			{"20231225", "2023-12-25"},
-			{"", ""},
-			{"2024020", ""},
+		location := models.MarketTradingHours("NASDAQ").Location
+		expected := time.Date(2024, 2, 5, 16, 30, 0, 0, location)
+		if !chartData.Timestamp.Equal(expected) {
+			t.Errorf("Expected timestamp %v, got %v", expected, chartData.Timestamp)
 		}
-
-		for _, test := range tests {
-			result := service.formatDate(test.input)
-			if result != test.expected {
-				t.Errorf("formatDate(%s) = %s, expected %s", test.input, result, test.expected)
-			}
+		if chartData.Timestamp.Location() != location {
+			t.Errorf("Expected timestamp location %v, got %v", location, chartData.Timestamp.Location())
 		}
-	})
+	}
 
-	t.Run("formatTime", func(t *testing.T) {
-		tests := []struct {
-			input    string
-			expected string
-		}{
-			{"163000", "16:30:00"},
-			{"093000", "09:30:00"},
-			{"", ""},
-			{"16300", ""},
-		}
+	// 일시가 잘못된 데이터는 zero 타임스탬프
+	outputs[0].Hour = "246000"
+	if data := service.convertToChartData("AAPL", outputs, options); !data[0].Timestamp.IsZero() {
+		t.Errorf("Expected zero timestamp for invalid hour, got %v", data[0].Timestamp)
+	}
+}
 
-		for _, test := range tests {
-			result := service.formatTime(test.input)
-			if result != test.expected {
-				t.Errorf("formatTime(%s) = %s, expected %s", test.input, result, test.expected)
-			}
-		}
-	})
+func TestForeignMinChartService_UtilityFunctions(t *testing.T) {
+	service := &ForeignMinChartService{}
 
 	t.Run("getMarketName", func(t *testing.T) {
 		tests := []struct {
@@ -1,6 +1,7 @@
 package foreign
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -28,6 +29,11 @@ func NewForeignMonthChartService(client *client.DBSecClient) *ForeignMonthChartS
 
 // GetMonthChart 해외주식 월차트 데이터 조회
 func (s *ForeignMonthChartService) GetMonthChart(stockCode string, period models.MonthChartPeriod, options models.MonthChartOptions) ([]models.ForeignMonthChartData, error) {
+	return s.GetMonthChartContext(context.Background(), stockCode, period, options)
+}
+
+// GetMonthChartContext ctx 가 취소되면 진행 중인 API 호출을 중단하는 GetMonthChart
+func (s *ForeignMonthChartService) GetMonthChartContext(ctx context.Context, stockCode string, period models.MonthChartPeriod, options models.MonthChartOptions) ([]models.ForeignMonthChartData, error) {
 	s.logger.Info("Getting foreign stock month chart", 
 		logger.Field{Key: "stock_code", Value: stockCode},
 		logger.Field{Key: "period", Value: period},
@@ -42,7 +48,7 @@ func (s *ForeignMonthChartService) GetMonthChart(stockCode string, period models
 	request := s.buildRequest(stockCode, period, options)
 
 	// API 호출
-	respBody, err := s.client.MakeRequestWithHeaders("POST", models.PathForeignStockMonthChart, nil, request, nil)
+	respBody, err := s.client.MakeRequestWithContext(ctx, "POST", models.PathForeignStockMonthChart, nil, request, nil)
 	if err != nil {
 		s.logger.Error("Failed to call month chart API", err, 
 			logger.Field{Key: "stock_code", Value: stockCode})
@@ -57,15 +63,18 @@ func (s *ForeignMonthChartService) GetMonthChart(stockCode string, period models
 	}
 
 	// 응답 코드 확인
-	if !utils.IsSuccessResponse(response.RspCd) {
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
 		s.logger.Warn("API returned error", 
 			logger.Field{Key: "response_code", Value: response.RspCd},
 			logger.Field{Key: "response_message", Value: response.RspMsg})
-		return nil, errors.NewAPIError(errors.ErrCodeServerError, "API returned error", fmt.Errorf("code: %s, message: %s", response.RspCd, response.RspMsg))
+		return nil, err
 	}
 
 	// 데이터 변환
 	chartData := s.convertToChartData(stockCode, response.Out, options)
+	if len(chartData) == 0 && options.ErrorOnEmpty {
+		return nil, errors.NewNoDataError(fmt.Sprintf("no month chart data for %s", stockCode))
+	}
 
 	s.logger.Info("Successfully retrieved month chart data", 
 		logger.Field{Key: "stock_code", Value: stockCode},
@@ -76,21 +85,26 @@ func (s *ForeignMonthChartService) GetMonthChart(stockCode string, period models
 
 // GetMonthChartWithMonths 월 수를 지정하여 월차트 조회 (편의 메서드)
 func (s *ForeignMonthChartService) GetMonthChartWithMonths(stockCode, market string, months int, useAdjusted bool) ([]models.ForeignMonthChartData, error) {
-	// 월 단위로 날짜 계산
-	endDate := time.Now().Format("2006-01-02")
-	startDate := time.Now().AddDate(0, -months, 0).Format("2006-01-02")
-
-	period := models.MonthChartPeriod{
-		StartDate: startDate,
-		EndDate:   endDate,
-	}
+	return s.GetMonthChartWithMonthsContext(context.Background(), stockCode, market, months, useAdjusted)
+}
 
+// GetMonthChartWithMonthsContext ctx 를 따르는 GetMonthChartWithMonths (HTTP 요청 컨텍스트 전달용)
+func (s *ForeignMonthChartService) GetMonthChartWithMonthsContext(ctx context.Context, stockCode, market string, months int, useAdjusted bool) ([]models.ForeignMonthChartData, error) {
 	options := models.MonthChartOptions{
 		UseAdjusted: useAdjusted,
 		Market:      market,
 	}
 
-	return s.GetMonthChart(stockCode, period, options)
+	return s.GetMonthChartContext(ctx, stockCode, recentMonths(months), options)
+}
+
+// recentMonths 오늘까지 최근 months 개월의 조회 기간
+func recentMonths(months int) models.MonthChartPeriod {
+	now := time.Now()
+	return models.MonthChartPeriod{
+		StartDate: utils.FormatISODate(now.AddDate(0, -months, 0)),
+		EndDate:   utils.FormatISODate(now),
+	}
 }
 
 // GetRecentMonthChart 최근 월차트 데이터 조회
@@ -134,22 +148,32 @@ func (s *ForeignMonthChartService) Get60MonthChart(stockCode, market string) ([]
 }
 
 // GetTechGiantsMonthChart 기술주 대장주들의 월차트 조회
+// 조회에 실패했거나 데이터가 없는 종목은 결과에서 빠진다 (실패 원인은 TechGiantsMonthCharts).
 func (s *ForeignMonthChartService) GetTechGiantsMonthChart(months int) (map[string][]models.ForeignMonthChartData, error) {
+	results, _ := s.TechGiantsMonthCharts(months)
+	return results, nil
+}
+
+// TechGiantsMonthCharts 기술주 대장주들의 월차트와 조회에 실패한 종목별 원인
+func (s *ForeignMonthChartService) TechGiantsMonthCharts(months int) (map[string][]models.ForeignMonthChartData, map[string]error) {
 	techStocks := []string{"AAPL", "MSFT", "GOOGL", "AMZN", "TSLA", "NVDA", "META"}
 	results := make(map[string][]models.ForeignMonthChartData)
+	failures := make(map[string]error)
 
+	options := models.MonthChartOptions{UseAdjusted: true, Market: "NASDAQ", ErrorOnEmpty: true}
 	for _, stockCode := range techStocks {
-		data, err := s.GetNASDAQMonthChart(stockCode, months)
+		data, err := s.GetMonthChartContext(context.Background(), stockCode, recentMonths(months), options)
 		if err != nil {
 			s.logger.Warn("Failed to get tech stock month chart", 
 				logger.Field{Key: "stock_code", Value: stockCode},
 				logger.Field{Key: "error", Value: err.Error()})
+			failures[stockCode] = err
 			continue
 		}
 		results[stockCode] = data
 	}
 
-	return results, nil
+	return results, failures
 }
 
 // GetVolatilityAnalysis 월간 변동성 분석
@@ -225,7 +249,7 @@ func (s *ForeignMonthChartService) buildRequest(stockCode string, period models.
 	input := models.ForeignMonthChartInput{
 		InputOrgAdjPrc:       options.GetAdjustedCode(),
 		InputCondMrktDivCode: options.GetMarketCode(),
-		InputIscd1:           stockCode,
+		InputIscd1:           models.ToAPISymbol(stockCode, options.GetMarketCode()),
 		InputDate1:           period.GetFormattedStartDate(),
 		InputDate2:           period.GetFormattedEndDate(),
 	}
@@ -239,7 +263,7 @@ func (s *ForeignMonthChartService) convertToChartData(stockCode string, outputs
 
 	for i, output := range outputs {
 		// 월 종료일에서 연도와 월 계산
-		monthEndDate := s.formatDate(output.Date)
+		monthEndDate := utils.YMDToISO(output.Date)
 		year, month := s.getYearMonth(output.Date)
 		monthStartDate := s.calculateMonthStartDate(output.Date)
 		
@@ -286,36 +310,17 @@ func (s *ForeignMonthChartService) convertToChartData(stockCode string, outputs
 	return chartData
 }
 
-// formatDate 날짜를 YYYY-MM-DD 형식으로 변환
-func (s *ForeignMonthChartService) formatDate(date string) string {
-	if len(date) != 8 {
-		return ""
-	}
-	return fmt.Sprintf("%s-%s-%s", date[:4], date[4:6], date[6:8])
-}
-
 // getMarketName 시장 코드를 시장명으로 변환
 func (s *ForeignMonthChartService) getMarketName(marketCode string) string {
-	switch marketCode {
-	case models.ForeignMarketNY:
-		return "New York Stock Exchange"
-	case models.ForeignMarketNASDAQ:
-		return "NASDAQ"
-	case models.ForeignMarketAMEX:
-		return "American Stock Exchange"
-	default:
-		return "Unknown"
+	if market, ok := models.ResolveMarket(marketCode); ok {
+		return market.EnglishName
 	}
+	return "Unknown"
 }
 
 // getYearMonth 날짜에서 연도와 월 추출
 func (s *ForeignMonthChartService) getYearMonth(dateStr string) (int, int) {
-	if len(dateStr) != 8 {
-		return 0, 0
-	}
-
-	// YYYYMMDD 형식을 time.Time으로 변환
-	t, err := time.Parse("20060102", dateStr)
+	t, err := utils.ParseYMD(dateStr)
 	if err != nil {
 		return 0, 0
 	}
@@ -325,19 +330,14 @@ func (s *ForeignMonthChartService) getYearMonth(dateStr string) (int, int) {
 
 // calculateMonthStartDate 월 종료일에서 월 시작일 계산
 func (s *ForeignMonthChartService) calculateMonthStartDate(monthEndDateStr string) string {
-	if len(monthEndDateStr) != 8 {
-		return ""
-	}
-
-	// YYYYMMDD 형식을 time.Time으로 변환
-	endDate, err := time.Parse("20060102", monthEndDateStr)
+	endDate, err := utils.ParseYMD(monthEndDateStr)
 	if err != nil {
 		return ""
 	}
 
 	// 해당 월의 첫 번째 날
 	startDate := time.Date(endDate.Year(), endDate.Month(), 1, 0, 0, 0, 0, endDate.Location())
-	return startDate.Format("2006-01-02")
+	return utils.FormatISODate(startDate)
 }
 
 // GetLongTermTrend 장기 추세 분석 (12개월 기준)
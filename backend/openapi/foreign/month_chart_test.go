@@ -293,25 +293,6 @@ func TestForeignMonthChartService_DataConversion(t *testing.T) {
 func TestForeignMonthChartService_UtilityFunctions(t *testing.T) {
 	service := &ForeignMonthChartService{}
 
-	t.Run("formatDate", func(t *testing.T) {
-		tests := []struct {
-			input    string
-			expected string
-		}{
-			{"20240131", "2024-01-31"},
-			{"20231225", "2023-12-25"},
-			{"", ""},
-			{"2024012", ""},
-		}
-
-		for _, test := range tests {
-			result := service.formatDate(test.input)
-			if result != test.expected {
-				t.Errorf("formatDate(%s) = %s, expected %s", test.input, result, test.expected)
-			}
-		}
-	})
-
 	t.Run("getMarketName", func(t *testing.T) {
 		tests := []struct {
 			input    string
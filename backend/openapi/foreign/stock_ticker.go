@@ -7,21 +7,40 @@ import (
 	"strings"
 
 	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/logger"
 	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
 )
 
 // ForeignStockTickerService 해외주식종목 조회 서비스
 type ForeignStockTickerService struct {
-	client *client.DBSecClient
+	client    *client.DBSecClient
+	logger    logger.Logger
+	exchanges *ExchangeCache
 }
 
 // NewForeignStockTickerService 새로운 해외주식종목 조회 서비스 생성
 func NewForeignStockTickerService(client *client.DBSecClient) *ForeignStockTickerService {
 	return &ForeignStockTickerService{
-		client: client,
+		client:    client,
+		logger:    logger.GetDefaultLogger().With(logger.Field{Key: "service", Value: "foreign_stock_ticker"}),
+		exchanges: DefaultExchangeCache,
 	}
 }
 
+// WithLogger 서비스 로거 교체
+func (s *ForeignStockTickerService) WithLogger(l logger.Logger) *ForeignStockTickerService {
+	s.logger = l
+	return s
+}
+
+// WithExchangeCache 조회한 종목의 거래소를 기록할 캐시 교체 (기본: DefaultExchangeCache)
+func (s *ForeignStockTickerService) WithExchangeCache(cache *ExchangeCache) *ForeignStockTickerService {
+	s.exchanges = cache
+	return s
+}
+
 // GetForeignStockTickers 해외주식종목 조회
 // exchangeCode: 해외증시구분코드 (NY: 뉴욕, NA: 나스닥, AM: 아멕스)
 // contKey: 연속키 (optional, 추가 데이터 조회시 사용)
@@ -46,8 +65,12 @@ func (s *ForeignStockTickerService) GetForeignStockTickers(exchangeCode string,
 	}
 
 	// 응답 코드 확인
-	if response.RspCd != "00000" {
-		return nil, "", fmt.Errorf("API error %s: %s", response.RspCd, response.RspMsg)
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
+		s.logger.Warn("API returned error",
+			logger.Field{Key: "exchange", Value: exchangeCode},
+			logger.Field{Key: "response_code", Value: response.RspCd},
+			logger.Field{Key: "response_message", Value: response.RspMsg})
+		return nil, "", err
 	}
 
 	return &response, nextContKey, nil
@@ -61,6 +84,9 @@ func (s *ForeignStockTickerService) GetAllForeignStockTickers(exchangeCode strin
 	for {
 		response, nextContKey, err := s.GetForeignStockTickers(exchangeCode, contKey)
 		if err != nil {
+			s.logger.Error("Failed to get foreign stock tickers", err,
+				logger.Field{Key: "exchange", Value: exchangeCode},
+				logger.Field{Key: "fetched", Value: len(allStocks)})
 			return nil, err
 		}
 
@@ -78,6 +104,12 @@ func (s *ForeignStockTickerService) GetAllForeignStockTickers(exchangeCode strin
 		contKey = nextContKey
 	}
 
+	// 현재가 조회 시 거래소 탐색을 생략할 수 있도록 종목별 거래소 기록
+	s.exchanges.Remember(allStocks)
+
+	s.logger.Debug("Fetched foreign stock tickers",
+		logger.Field{Key: "exchange", Value: exchangeCode},
+		logger.Field{Key: "count", Value: len(allStocks)})
 	return allStocks, nil
 }
 
@@ -192,13 +224,14 @@ func (s *ForeignStockTickerService) getContYn(contKey string) string {
 // convertToForeignStockData 응답 데이터를 구조화된 형식으로 변환
 func (s *ForeignStockTickerService) convertToForeignStockData(exchangeCode string, output *models.ForeignStockTickerOutput) *models.ForeignStockData {
 	return &models.ForeignStockData{
-		StockCode:    output.Iscd,
+		StockCode:    models.FromAPISymbol(output.Iscd, exchangeCode),
 		KoreanName:   output.KorIsnm,
 		SectorName:   output.BstpLargName,
 		ExchangeCode: output.ExchClsCode2,
 		Exchange:     s.getExchangeName(exchangeCode),
 		SellUnit:     s.parseInt(output.SelnVolUnit),
 		BuyUnit:      s.parseInt(output.ShnuVolUnit),
+		Precision:    utils.ParsePrecision(output.Zdiv, models.DefaultForeignPricePrecision),
 	}
 }
 
@@ -1,6 +1,7 @@
 package foreign
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -14,20 +15,33 @@ import (
 
 // ForeignWeekChartService 해외주식 주차트조회 서비스
 type ForeignWeekChartService struct {
-	client *client.DBSecClient
-	logger logger.Logger
+	client   *client.DBSecClient
+	logger   logger.Logger
+	calendar utils.TradingCalendar
 }
 
 // NewForeignWeekChartService 새로운 해외주식 주차트조회 서비스 생성
 func NewForeignWeekChartService(client *client.DBSecClient) *ForeignWeekChartService {
 	return &ForeignWeekChartService{
-		client: client,
-		logger: logger.GetDefaultLogger().With(logger.Field{Key: "service", Value: "foreign_week_chart"}),
+		client:   client,
+		logger:   logger.GetDefaultLogger().With(logger.Field{Key: "service", Value: "foreign_week_chart"}),
+		calendar: utils.DefaultTradingCalendar,
 	}
 }
 
+// WithTradingCalendar 주 수를 조회 기간으로 변환할 때 쓸 휴장 여유분 설정
+func (s *ForeignWeekChartService) WithTradingCalendar(calendar utils.TradingCalendar) *ForeignWeekChartService {
+	s.calendar = calendar
+	return s
+}
+
 // GetWeekChart 해외주식 주차트 데이터 조회
 func (s *ForeignWeekChartService) GetWeekChart(stockCode string, period models.WeekChartPeriod, options models.WeekChartOptions) ([]models.ForeignWeekChartData, error) {
+	return s.GetWeekChartContext(context.Background(), stockCode, period, options)
+}
+
+// GetWeekChartContext ctx 가 취소되면 진행 중인 API 호출을 중단하는 GetWeekChart
+func (s *ForeignWeekChartService) GetWeekChartContext(ctx context.Context, stockCode string, period models.WeekChartPeriod, options models.WeekChartOptions) ([]models.ForeignWeekChartData, error) {
 	s.logger.Info("Getting foreign stock week chart", 
 		logger.Field{Key: "stock_code", Value: stockCode},
 		logger.Field{Key: "period", Value: period},
@@ -42,7 +56,7 @@ func (s *ForeignWeekChartService) GetWeekChart(stockCode string, period models.W
 	request := s.buildRequest(stockCode, period, options)
 
 	// API 호출
-	respBody, err := s.client.MakeRequestWithHeaders("POST", models.PathForeignStockWeekChart, nil, request, nil)
+	respBody, err := s.client.MakeRequestWithContext(ctx, "POST", models.PathForeignStockWeekChart, nil, request, nil)
 	if err != nil {
 		s.logger.Error("Failed to call week chart API", err, 
 			logger.Field{Key: "stock_code", Value: stockCode})
@@ -57,15 +71,18 @@ func (s *ForeignWeekChartService) GetWeekChart(stockCode string, period models.W
 	}
 
 	// 응답 코드 확인
-	if !utils.IsSuccessResponse(response.RspCd) {
+	if err := errors.CheckResponse(response.RspCd, response.RspMsg); err != nil {
 		s.logger.Warn("API returned error", 
 			logger.Field{Key: "response_code", Value: response.RspCd},
 			logger.Field{Key: "response_message", Value: response.RspMsg})
-		return nil, errors.NewAPIError(errors.ErrCodeServerError, "API returned error", fmt.Errorf("code: %s, message: %s", response.RspCd, response.RspMsg))
+		return nil, err
 	}
 
 	// 데이터 변환
 	chartData := s.convertToChartData(stockCode, response.Out, options)
+	if len(chartData) == 0 && options.ErrorOnEmpty {
+		return nil, errors.NewNoDataError(fmt.Sprintf("no week chart data for %s", stockCode))
+	}
 
 	s.logger.Info("Successfully retrieved week chart data", 
 		logger.Field{Key: "stock_code", Value: stockCode},
@@ -76,21 +93,27 @@ func (s *ForeignWeekChartService) GetWeekChart(stockCode string, period models.W
 
 // GetWeekChartWithWeeks 주 수를 지정하여 주차트 조회 (편의 메서드)
 func (s *ForeignWeekChartService) GetWeekChartWithWeeks(stockCode, market string, weeks int, useAdjusted bool) ([]models.ForeignWeekChartData, error) {
-	// 주 단위로 날짜 계산 (weeks * 7일)
-	endDate := time.Now().Format("2006-01-02")
-	startDate := time.Now().AddDate(0, 0, -weeks*7).Format("2006-01-02")
-
-	period := models.WeekChartPeriod{
-		StartDate: startDate,
-		EndDate:   endDate,
-	}
+	return s.GetWeekChartWithWeeksContext(context.Background(), stockCode, market, weeks, useAdjusted)
+}
 
+// GetWeekChartWithWeeksContext ctx 를 따르는 GetWeekChartWithWeeks (HTTP 요청 컨텍스트 전달용)
+func (s *ForeignWeekChartService) GetWeekChartWithWeeksContext(ctx context.Context, stockCode, market string, weeks int, useAdjusted bool) ([]models.ForeignWeekChartData, error) {
 	options := models.WeekChartOptions{
 		UseAdjusted: useAdjusted,
 		Market:      market,
 	}
 
-	return s.GetWeekChart(stockCode, period, options)
+	return s.GetWeekChartContext(ctx, stockCode, s.recentWeeks(weeks), options)
+}
+
+// recentWeeks 오늘까지 최근 weeks 주의 조회 기간
+// 휴장 주를 감안해 weeks 개 주봉이 나오도록 여유 있게 기간을 잡는다.
+func (s *ForeignWeekChartService) recentWeeks(weeks int) models.WeekChartPeriod {
+	now := time.Now()
+	return models.WeekChartPeriod{
+		StartDate: utils.FormatISODate(s.calendar.WeekWindowStart(now, weeks)),
+		EndDate:   utils.FormatISODate(now),
+	}
 }
 
 // GetRecentWeekChart 최근 주차트 데이터 조회
@@ -129,22 +152,32 @@ func (s *ForeignWeekChartService) Get13WeekChart(stockCode, market string) ([]mo
 }
 
 // GetTechGiantsWeekChart 기술주 대장주들의 주차트 조회
+// 조회에 실패했거나 데이터가 없는 종목은 결과에서 빠진다 (실패 원인은 TechGiantsWeekCharts).
 func (s *ForeignWeekChartService) GetTechGiantsWeekChart(weeks int) (map[string][]models.ForeignWeekChartData, error) {
+	results, _ := s.TechGiantsWeekCharts(weeks)
+	return results, nil
+}
+
+// TechGiantsWeekCharts 기술주 대장주들의 주차트와 조회에 실패한 종목별 원인
+func (s *ForeignWeekChartService) TechGiantsWeekCharts(weeks int) (map[string][]models.ForeignWeekChartData, map[string]error) {
 	techStocks := []string{"AAPL", "MSFT", "GOOGL", "AMZN", "TSLA", "NVDA", "META"}
 	results := make(map[string][]models.ForeignWeekChartData)
+	failures := make(map[string]error)
 
+	options := models.WeekChartOptions{UseAdjusted: true, Market: "NASDAQ", ErrorOnEmpty: true}
 	for _, stockCode := range techStocks {
-		data, err := s.GetNASDAQWeekChart(stockCode, weeks)
+		data, err := s.GetWeekChartContext(context.Background(), stockCode, s.recentWeeks(weeks), options)
 		if err != nil {
 			s.logger.Warn("Failed to get tech stock week chart", 
 				logger.Field{Key: "stock_code", Value: stockCode},
 				logger.Field{Key: "error", Value: err.Error()})
+			failures[stockCode] = err
 			continue
 		}
 		results[stockCode] = data
 	}
 
-	return results, nil
+	return results, failures
 }
 
 // GetVolatilityAnalysis 주간 변동성 분석
@@ -220,7 +253,7 @@ func (s *ForeignWeekChartService) buildRequest(stockCode string, period models.W
 	input := models.ForeignWeekChartInput{
 		InputCondMrktDivCode: options.GetMarketCode(),
 		InputOrgAdjPrc:       options.GetAdjustedCode(),
-		InputIscd1:           stockCode,
+		InputIscd1:           models.ToAPISymbol(stockCode, options.GetMarketCode()),
 		InputDate1:           period.GetFormattedStartDate(),
 		InputDate2:           period.GetFormattedEndDate(),
 		InputPeriodDivCode:   models.PeriodDivWeek, // 고정값: W
@@ -235,7 +268,7 @@ func (s *ForeignWeekChartService) convertToChartData(stockCode string, outputs [
 
 	for i, output := range outputs {
 		// 주 종료일에서 연도와 주차 계산
-		weekEndDate := s.formatDate(output.Date)
+		weekEndDate := utils.YMDToISO(output.Date)
 		year, weekNumber := s.getYearWeek(output.Date)
 		weekStartDate := s.calculateWeekStartDate(output.Date)
 		
@@ -282,36 +315,17 @@ func (s *ForeignWeekChartService) convertToChartData(stockCode string, outputs [
 	return chartData
 }
 
-// formatDate 날짜를 YYYY-MM-DD 형식으로 변환
-func (s *ForeignWeekChartService) formatDate(date string) string {
-	if len(date) != 8 {
-		return ""
-	}
-	return fmt.Sprintf("%s-%s-%s", date[:4], date[4:6], date[6:8])
-}
-
 // getMarketName 시장 코드를 시장명으로 변환
 func (s *ForeignWeekChartService) getMarketName(marketCode string) string {
-	switch marketCode {
-	case models.ForeignMarketNY:
-		return "New York Stock Exchange"
-	case models.ForeignMarketNASDAQ:
-		return "NASDAQ"
-	case models.ForeignMarketAMEX:
-		return "American Stock Exchange"
-	default:
-		return "Unknown"
+	if market, ok := models.ResolveMarket(marketCode); ok {
+		return market.EnglishName
 	}
+	return "Unknown"
 }
 
 // getYearWeek 날짜에서 연도와 주차 번호 계산
 func (s *ForeignWeekChartService) getYearWeek(dateStr string) (int, int) {
-	if len(dateStr) != 8 {
-		return 0, 0
-	}
-
-	// YYYYMMDD 형식을 time.Time으로 변환
-	t, err := time.Parse("20060102", dateStr)
+	t, err := utils.ParseYMD(dateStr)
 	if err != nil {
 		return 0, 0
 	}
@@ -322,12 +336,7 @@ func (s *ForeignWeekChartService) getYearWeek(dateStr string) (int, int) {
 
 // calculateWeekStartDate 주 종료일에서 주 시작일 계산
 func (s *ForeignWeekChartService) calculateWeekStartDate(weekEndDateStr string) string {
-	if len(weekEndDateStr) != 8 {
-		return ""
-	}
-
-	// YYYYMMDD 형식을 time.Time으로 변환
-	endDate, err := time.Parse("20060102", weekEndDateStr)
+	endDate, err := utils.ParseYMD(weekEndDateStr)
 	if err != nil {
 		return ""
 	}
@@ -340,7 +349,7 @@ func (s *ForeignWeekChartService) calculateWeekStartDate(weekEndDateStr string)
 	}
 	
 	startDate := endDate.AddDate(0, 0, -daysToSubtract)
-	return startDate.Format("2006-01-02")
+	return utils.FormatISODate(startDate)
 }
 
 // Get52WeekHighLow 52주 최고/최저가 계산
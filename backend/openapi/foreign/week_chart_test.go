@@ -1,7 +1,10 @@
 package foreign
 
 import (
+	"encoding/json"
+	"net/http"
 	"testing"
+	"time"
 
 	"stock-recommender/backend/openapi/client"
 	"stock-recommender/backend/openapi/models"
@@ -277,25 +280,6 @@ func TestForeignWeekChartService_DataConversion(t *testing.T) {
 func TestForeignWeekChartService_UtilityFunctions(t *testing.T) {
 	service := &ForeignWeekChartService{}
 
-	t.Run("formatDate", func(t *testing.T) {
-		tests := []struct {
-			input    string
-			expected string
-		}{
-			{"20240129", "2024-01-29"},
-			{"20231225", "2023-12-25"},
-			{"", ""},
-			{"2024012", ""},
-		}
-
-		for _, test := range tests {
-			result := service.formatDate(test.input)
-			if result != test.expected {
-				t.Errorf("formatDate(%s) = %s, expected %s", test.input, result, test.expected)
-			}
-		}
-	})
-
 	t.Run("getMarketName", func(t *testing.T) {
 		tests := []struct {
 			input    string
@@ -501,4 +485,61 @@ func TestWeekChartOptions_Methods(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestTradingCalendar_WeekWindowStart(t *testing.T) {
+	wednesday := time.Date(2024, 6, 12, 15, 0, 0, 0, time.UTC)
+
+	// 여유분이 없으면 이번 주를 포함한 정확히 4주
+	exact := utils.TradingCalendar{}
+	utils.AssertStringEqual(t, "2024-05-20", exact.WeekWindowStart(wednesday, 4).Format("2006-01-02"), "Exact 4 week window")
+
+	calendar := utils.DefaultTradingCalendar
+	utils.AssertIntEqual(t, 5, int64(calendar.BufferedPeriods(4)), "Minimum buffer")
+	utils.AssertIntEqual(t, 58, int64(calendar.BufferedPeriods(52)), "10% buffer for 52 weeks")
+	utils.AssertStringEqual(t, "2023-05-08", calendar.WeekWindowStart(wednesday, 52).Format("2006-01-02"), "Buffered 52 week window")
+}
+
+func TestForeignWeekChartService_Get52WeekChartRange(t *testing.T) {
+	var captured models.ForeignWeekChartRequest
+	mockServer := utils.NewMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"rsp_cd":  "00000",
+			"rsp_msg": "정상 처리 되었습니다.",
+			"Out":     []models.ForeignWeekChartOutput{},
+		})
+	})
+	defer mockServer.Close()
+
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+	service := NewForeignWeekChartService(apiClient)
+
+	if _, err := service.Get52WeekChart("AAPL", "NASDAQ"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start, err := time.Parse("20060102", captured.In.InputDate1)
+	if err != nil {
+		t.Fatalf("Invalid start date %q: %v", captured.In.InputDate1, err)
+	}
+	end, err := time.Parse("20060102", captured.In.InputDate2)
+	if err != nil {
+		t.Fatalf("Invalid end date %q: %v", captured.In.InputDate2, err)
+	}
+
+	if start.Weekday() != time.Monday {
+		t.Errorf("Expected window to start on a Monday, got %s", start.Weekday())
+	}
+
+	// 시작 주부터 종료일이 속한 주까지의 주 수 (휴장으로 몇 주가 빠져도 52개가 남아야 함)
+	weeks := int(end.Sub(start).Hours()/24)/7 + 1
+	if weeks < 52+3 {
+		t.Errorf("Expected at least 55 weeks to cover 52 weekly bars with holidays, got %d (%s ~ %s)",
+			weeks, captured.In.InputDate1, captured.In.InputDate2)
+	}
+}
@@ -1,15 +1,21 @@
+//go:build ignore
+
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 )
 
 func main() {
+	candles := flag.String("candles", "regular", "차트 봉 형식 (regular 또는 heikin)")
+	flag.Parse()
+
 	fmt.Println("📊 리포트 생성 도구 시작")
 	
 	// 차트 시각화 도구 생성
-	visualizer := NewChartVisualizer("../results")
+	visualizer := NewChartVisualizer("../results").WithCandles(*candles)
 	
 	// HTML 리포트 생성
 	fmt.Println("🌐 HTML 차트 리포트 생성 중...")
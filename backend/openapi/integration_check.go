@@ -1,3 +1,5 @@
+//go:build ignore
+
 package main
 
 import (
@@ -8,6 +10,7 @@ import (
 	"stock-recommender/backend/openapi/client"
 	"stock-recommender/backend/openapi/foreign"
 	"stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
 )
 
 func main() {
@@ -65,7 +68,7 @@ func main() {
 	if err != nil {
 		fmt.Printf("❌ Current price query failed: %v\n", err)
 	} else {
-		fmt.Printf("✅ AAPL Current Price: $%.2f\n", currentPrice.CurrentPrice)
+		fmt.Printf("✅ AAPL Current Price: $%s\n", utils.FormatPrice(currentPrice.CurrentPrice, currentPrice.Precision))
 	}
 	
 	// 3. 해외 주식 월차트 조회 테스트
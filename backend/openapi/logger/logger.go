@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -64,6 +65,15 @@ func NewDefaultLogger(level LogLevel) *DefaultLogger {
 	}
 }
 
+// NewDefaultLoggerWithOutput 지정한 출력으로 기록하는 기본 로거 생성
+func NewDefaultLoggerWithOutput(level LogLevel, out io.Writer) *DefaultLogger {
+	return &DefaultLogger{
+		level:  level,
+		fields: make([]Field, 0),
+		logger: log.New(out, "", 0),
+	}
+}
+
 // Debug 디버그 로그 출력
 func (l *DefaultLogger) Debug(msg string, fields ...Field) {
 	if l.level <= DEBUG {
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"stock-recommender/backend/openapi/utils"
+)
+
+// AskingPriceResponse 국내주식 호가조회 응답
+type AskingPriceResponse struct {
+	utils.BaseAPIResponse
+	Out AskingPriceOutput `json:"Out"`
+}
+
+// AskingPriceOutput 국내주식 호가조회 출력 (5단계)
+type AskingPriceOutput struct {
+	Askp1         string `json:"Askp1"`         // 매도호가1
+	Askp2         string `json:"Askp2"`         // 매도호가2
+	Askp3         string `json:"Askp3"`         // 매도호가3
+	Askp4         string `json:"Askp4"`         // 매도호가4
+	Askp5         string `json:"Askp5"`         // 매도호가5
+	Bidp1         string `json:"Bidp1"`         // 매수호가1
+	Bidp2         string `json:"Bidp2"`         // 매수호가2
+	Bidp3         string `json:"Bidp3"`         // 매수호가3
+	Bidp4         string `json:"Bidp4"`         // 매수호가4
+	Bidp5         string `json:"Bidp5"`         // 매수호가5
+	AskpRsqn1     string `json:"AskpRsqn1"`     // 매도호가잔량1
+	AskpRsqn2     string `json:"AskpRsqn2"`     // 매도호가잔량2
+	AskpRsqn3     string `json:"AskpRsqn3"`     // 매도호가잔량3
+	AskpRsqn4     string `json:"AskpRsqn4"`     // 매도호가잔량4
+	AskpRsqn5     string `json:"AskpRsqn5"`     // 매도호가잔량5
+	BidpRsqn1     string `json:"BidpRsqn1"`     // 매수호가잔량1
+	BidpRsqn2     string `json:"BidpRsqn2"`     // 매수호가잔량2
+	BidpRsqn3     string `json:"BidpRsqn3"`     // 매수호가잔량3
+	BidpRsqn4     string `json:"BidpRsqn4"`     // 매수호가잔량4
+	BidpRsqn5     string `json:"BidpRsqn5"`     // 매수호가잔량5
+	TotalAskpRsqn string `json:"TotalAskpRsqn"` // 총매도호가잔량
+	TotalBidpRsqn string `json:"TotalBidpRsqn"` // 총매수호가잔량
+}
+
+// DomesticDailyPriceRequest 국내주식 일별시세 요청
+type DomesticDailyPriceRequest struct {
+	In DomesticDailyPriceInput `json:"In"`
+}
+
+// DomesticDailyPriceInput 국내주식 일별시세 입력
+type DomesticDailyPriceInput struct {
+	InputCondMrktDivCode string `json:"InputCondMrktDivCode"` // 시장분류코드 (J: 주식)
+	InputIscd1           string `json:"InputIscd1"`           // 종목코드
+	InputDate1           string `json:"InputDate1"`           // 시작날짜 (YYYYMMDD)
+	InputDate2           string `json:"InputDate2"`           // 종료날짜 (YYYYMMDD)
+	InputOrgAdjPrc       string `json:"InputOrgAdjPrc"`       // 수정주가사용여부 (0:미사용, 1:사용)
+}
+
+// DomesticDailyPriceResponse 국내주식 일별시세 응답
+type DomesticDailyPriceResponse struct {
+	utils.BaseAPIResponse
+	Out []DomesticDailyPriceOutput `json:"Out"`
+}
+
+// DomesticDailyPriceOutput 국내주식 일별시세 출력
+type DomesticDailyPriceOutput struct {
+	Date       string `json:"Date"`       // 일자 (YYYYMMDD)
+	Prpr       string `json:"Prpr"`       // 종가
+	Oprc       string `json:"Oprc"`       // 시가
+	Hprc       string `json:"Hprc"`       // 고가
+	Lprc       string `json:"Lprc"`       // 저가
+	AcmlVol    string `json:"AcmlVol"`    // 누적거래량
+	AcmlTrPbmn string `json:"AcmlTrPbmn"` // 누적거래대금
+}
+
+// ParsedStockPrice 수집용 주가 데이터 (변환된 형식)
+type ParsedStockPrice struct {
+	Symbol         string
+	Market         string
+	OpenPrice      float64
+	HighPrice      float64
+	LowPrice       float64
+	CurrentPrice   float64
+	PrevClosePrice float64
+	Change         float64
+	ChangeRate     float64
+	Volume         int64
+	TradeAmount    int64
+	Precision      int // 가격 소수점자리수 (0이면 시장 기본값)
+	Timestamp      time.Time
+}
+
+// ParsedStockMetadata 종목 부가정보 (변환된 형식)
+type ParsedStockMetadata struct {
+	Symbol    string
+	Sector    string // 업종명 (응답에 없으면 빈 값)
+	MarketCap int64  // 시가총액 (억원)
+	PER       float64
+	PBR       float64 // 해외 종목은 0
+}
+
+// ParsedAskingPrice 수집용 호가 데이터 (변환된 형식)
+type ParsedAskingPrice struct {
+	Symbol      string
+	AskPrices   [5]float64
+	BidPrices   [5]float64
+	AskVolumes  [5]int64
+	BidVolumes  [5]int64
+	TotalAskVol int64
+	TotalBidVol int64
+	Timestamp   time.Time
+}
+
+// ParsedDailyPrice 수집용 일봉 데이터 (변환된 형식)
+type ParsedDailyPrice struct {
+	Symbol      string
+	Date        time.Time
+	OpenPrice   float64
+	HighPrice   float64
+	LowPrice    float64
+	ClosePrice  float64
+	Volume      int64
+	TradeAmount int64
+}
@@ -36,6 +36,7 @@ const (
 // 시장분류구분코드
 const (
 	MarketClassKosdaq = "1" // 코스닥
+	MarketClassKonex  = "2" // 코넥스
 	MarketClassKospi  = "4" // 코스피
 )
 
@@ -116,4 +117,10 @@ const (
 const (
 	PeriodDivWeek  = "W" // 주간
 	PeriodDivMonth = "M" // 월간 (월차트용)
-)
\ No newline at end of file
+)
+
+// 가격 소수점자리수 (zdiv 미제공시 기본값)
+const (
+	DefaultForeignPricePrecision  = 2
+	DefaultDomesticPricePrecision = 0 // 원화 가격은 정수
+)
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"stock-recommender/backend/openapi/utils"
+)
+
+// Currency 시장별 통화 표시 형식
+type Currency struct {
+	Code      string // ISO 4217 (KRW, USD)
+	Symbol    string // 가격 앞에 붙는 기호
+	Precision int    // 종목별 소수점자리수를 모를 때 쓰는 기본값
+}
+
+var currencies = map[string]Currency{
+	RegionKR: {Code: "KRW", Symbol: "₩", Precision: DefaultDomesticPricePrecision},
+	RegionUS: {Code: "USD", Symbol: "$", Precision: DefaultForeignPricePrecision},
+}
+
+// CurrencyForRegion 국가 구분의 통화 (알 수 없으면 USD)
+func CurrencyForRegion(region string) Currency {
+	if currency, ok := currencies[region]; ok {
+		return currency
+	}
+	return currencies[RegionUS]
+}
+
+// 국내 종목코드: 숫자로 시작하는 6자리 (ETN 등은 영문 포함)
+var domesticSymbolPattern = regexp.MustCompile(`^[0-9][0-9A-Z]{5}$`)
+
+// SymbolRegion 종목코드 형식으로 판단한 국가 구분 (6자리 국내 코드가 아니면 RegionUS)
+func SymbolRegion(symbol string) string {
+	if domesticSymbolPattern.MatchString(strings.ToUpper(symbol)) {
+		return RegionKR
+	}
+	return RegionUS
+}
+
+// Format 통화 기호와 천 단위 구분자를 붙인 가격 (precision 이 음수면 통화 기본 자릿수)
+func (c Currency) Format(value float64, precision int) string {
+	if precision < 0 {
+		precision = c.Precision
+	}
+	formatted := utils.FormatPrice(value, precision)
+
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+	integer, fraction := formatted, ""
+	if dot := strings.IndexByte(formatted, '.'); dot >= 0 {
+		integer, fraction = formatted[:dot], formatted[dot:]
+	}
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + c.Symbol + grouped.String() + fraction
+}
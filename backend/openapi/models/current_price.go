@@ -45,6 +45,8 @@ type CurrentPriceOutput struct {
 	PrprVrssLwprRate     string `json:"PrprVrssLwprRate"`     // 현재가대비저가비율
 	HtsOtstStplQty       string `json:"HtsOtstStplQty"`       // 미결제약정수량
 	OtstStplQtyIcdc      string `json:"OtstStplQtyIcdc"`      // 미결제증감
+	BstpKorIsnm          string `json:"BstpKorIsnm"`          // 업종한글종목명
+	HtsAvls              string `json:"HtsAvls"`              // HTS시가총액 (억원)
 }
 
 // CurrentPriceData 현재가 데이터 (변환된 형식)
@@ -72,4 +74,6 @@ type CurrentPriceData struct {
 	CurrentHighRate  float64 `json:"current_high_rate"`  // 현재가대비고가비율
 	MarketLowRate    float64 `json:"market_low_rate"`    // 기준가대비저가비율
 	CurrentLowRate   float64 `json:"current_low_rate"`   // 현재가대비저가비율
+	SectorName       string  `json:"sector_name"`        // 업종명
+	MarketCap        int64   `json:"market_cap"`         // 시가총액 (억원)
 }
\ No newline at end of file
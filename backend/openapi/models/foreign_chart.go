@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"time"
 	
 	"stock-recommender/backend/openapi/utils"
 )
@@ -43,20 +44,21 @@ type ForeignMinChartOutput struct {
 
 // ForeignMinChartData 해외주식 분차트 비즈니스 모델
 type ForeignMinChartData struct {
-	StockCode     string  `json:"stock_code"`     // 종목코드
-	DateTime      string  `json:"date_time"`      // 일시 (YYYY-MM-DD HH:MM:SS)
-	Date          string  `json:"date"`           // 일자 (YYYY-MM-DD)
-	Time          string  `json:"time"`           // 시간 (HH:MM:SS)
-	Open          float64 `json:"open"`           // 시가
-	High          float64 `json:"high"`           // 고가
-	Low           float64 `json:"low"`            // 저가
-	Close         float64 `json:"close"`          // 종가(현재가)
-	Volume        int64   `json:"volume"`         // 거래량
-	Market        string  `json:"market"`         // 시장명
-	MarketCode    string  `json:"market_code"`    // 시장코드
-	Interval      string  `json:"interval"`       // 시간간격
-	IntervalCode  string  `json:"interval_code"`  // 시간간격코드
-	IsAdjusted    bool    `json:"is_adjusted"`    // 수정주가 적용여부
+	StockCode    string    `json:"stock_code"`    // 종목코드
+	DateTime     string    `json:"date_time"`     // 일시 (YYYY-MM-DD HH:MM:SS)
+	Timestamp    time.Time `json:"timestamp"`     // 일시 (거래소 현지 시간대, 파싱 실패시 zero)
+	Date         string    `json:"date"`          // 일자 (YYYY-MM-DD)
+	Time         string    `json:"time"`          // 시간 (HH:MM:SS)
+	Open         float64   `json:"open"`          // 시가
+	High         float64   `json:"high"`          // 고가
+	Low          float64   `json:"low"`           // 저가
+	Close        float64   `json:"close"`         // 종가(현재가)
+	Volume       int64     `json:"volume"`        // 거래량
+	Market       string    `json:"market"`        // 시장명
+	MarketCode   string    `json:"market_code"`   // 시장코드
+	Interval     string    `json:"interval"`      // 시간간격
+	IntervalCode string    `json:"interval_code"` // 시간간격코드
+	IsAdjusted   bool      `json:"is_adjusted"`   // 수정주가 적용여부
 }
 
 // ChartPeriod 차트 조회 기간 설정
@@ -68,10 +70,11 @@ type ChartPeriod struct {
 
 // ChartOptions 차트 조회 옵션
 type ChartOptions struct {
-	Interval     string `json:"interval"`      // 시간간격 (30sec, 1min, 5min, 10min, 60min)
-	UseAdjusted  bool   `json:"use_adjusted"`  // 수정주가 사용여부
-	DataCount    int    `json:"data_count"`    // 조회 건수 (1~2000)
-	Market       string `json:"market"`        // 시장 (NY, NASDAQ, AMEX)
+	Interval     string `json:"interval"`       // 시간간격 (30sec, 1min, 5min, 10min, 60min)
+	UseAdjusted  bool   `json:"use_adjusted"`   // 수정주가 사용여부
+	DataCount    int    `json:"data_count"`     // 조회 건수 (1~2000)
+	Market       string `json:"market"`         // 시장 (NY, NASDAQ, AMEX)
+	ErrorOnEmpty bool   `json:"error_on_empty"` // 빈 결과를 errors.ErrNoData 로 반환 (기본은 빈 슬라이스)
 }
 
 // GetIntervalCode 시간간격 문자열을 코드로 변환
@@ -96,16 +99,7 @@ func (opts *ChartOptions) GetIntervalCode() string {
 
 // GetMarketCode 시장명을 코드로 변환
 func (opts *ChartOptions) GetMarketCode() string {
-	switch opts.Market {
-	case "NY", "NYSE":
-		return ForeignMarketNY
-	case "NASDAQ":
-		return ForeignMarketNASDAQ
-	case "AMEX":
-		return ForeignMarketAMEX
-	default:
-		return ForeignMarketNASDAQ // 기본값: 나스닥
-	}
+	return DefaultMarketResolver.ForeignCode(opts.Market) // 알 수 없으면 기본 해외 시장
 }
 
 // GetAdjustedCode 수정주가 사용여부를 코드로 변환
@@ -184,22 +178,14 @@ type DayChartPeriod struct {
 
 // DayChartOptions 일차트 조회 옵션
 type DayChartOptions struct {
-	UseAdjusted bool   `json:"use_adjusted"` // 수정주가 사용여부
-	Market      string `json:"market"`       // 시장 (NY, NASDAQ, AMEX)
+	UseAdjusted  bool   `json:"use_adjusted"`   // 수정주가 사용여부
+	Market       string `json:"market"`         // 시장 (NY, NASDAQ, AMEX)
+	ErrorOnEmpty bool   `json:"error_on_empty"` // 빈 결과를 errors.ErrNoData 로 반환 (기본은 빈 슬라이스)
 }
 
 // GetMarketCode 시장명을 코드로 변환
 func (opts *DayChartOptions) GetMarketCode() string {
-	switch opts.Market {
-	case "NY", "NYSE":
-		return ForeignMarketNY
-	case "NASDAQ":
-		return ForeignMarketNASDAQ
-	case "AMEX":
-		return ForeignMarketAMEX
-	default:
-		return ForeignMarketNASDAQ // 기본값: 나스닥
-	}
+	return DefaultMarketResolver.ForeignCode(opts.Market) // 알 수 없으면 기본 해외 시장
 }
 
 // GetAdjustedCode 수정주가 사용여부를 코드로 변환
@@ -212,15 +198,7 @@ func (opts *DayChartOptions) GetAdjustedCode() string {
 
 // FormatDate 날짜를 YYYYMMDD 형식으로 변환
 func (p *DayChartPeriod) FormatDate(date string) string {
-	// YYYY-MM-DD 형식을 YYYYMMDD로 변환
-	if len(date) == 10 && date[4] == '-' && date[7] == '-' {
-		return date[:4] + date[5:7] + date[8:10]
-	}
-	// 이미 YYYYMMDD 형식이면 그대로 반환
-	if len(date) == 8 {
-		return date
-	}
-	return ""
+	return utils.ToYMD(date)
 }
 
 // GetFormattedStartDate 포맷된 시작일 반환
@@ -298,22 +276,14 @@ type WeekChartPeriod struct {
 
 // WeekChartOptions 주차트 조회 옵션
 type WeekChartOptions struct {
-	UseAdjusted bool   `json:"use_adjusted"` // 수정주가 사용여부
-	Market      string `json:"market"`       // 시장 (NY, NASDAQ, AMEX)
+	UseAdjusted  bool   `json:"use_adjusted"`   // 수정주가 사용여부
+	Market       string `json:"market"`         // 시장 (NY, NASDAQ, AMEX)
+	ErrorOnEmpty bool   `json:"error_on_empty"` // 빈 결과를 errors.ErrNoData 로 반환 (기본은 빈 슬라이스)
 }
 
 // GetMarketCode 시장명을 코드로 변환
 func (opts *WeekChartOptions) GetMarketCode() string {
-	switch opts.Market {
-	case "NY", "NYSE":
-		return ForeignMarketNY
-	case "NASDAQ":
-		return ForeignMarketNASDAQ
-	case "AMEX":
-		return ForeignMarketAMEX
-	default:
-		return ForeignMarketNASDAQ // 기본값: 나스닥
-	}
+	return DefaultMarketResolver.ForeignCode(opts.Market) // 알 수 없으면 기본 해외 시장
 }
 
 // GetAdjustedCode 수정주가 사용여부를 코드로 변환
@@ -326,15 +296,7 @@ func (opts *WeekChartOptions) GetAdjustedCode() string {
 
 // FormatDate 날짜를 YYYYMMDD 형식으로 변환
 func (p *WeekChartPeriod) FormatDate(date string) string {
-	// YYYY-MM-DD 형식을 YYYYMMDD로 변환
-	if len(date) == 10 && date[4] == '-' && date[7] == '-' {
-		return date[:4] + date[5:7] + date[8:10]
-	}
-	// 이미 YYYYMMDD 형식이면 그대로 반환
-	if len(date) == 8 {
-		return date
-	}
-	return ""
+	return utils.ToYMD(date)
 }
 
 // GetFormattedStartDate 포맷된 시작일 반환
@@ -411,22 +373,14 @@ type MonthChartPeriod struct {
 
 // MonthChartOptions 월차트 조회 옵션
 type MonthChartOptions struct {
-	UseAdjusted bool   `json:"use_adjusted"` // 수정주가 사용여부
-	Market      string `json:"market"`       // 시장 (NY, NASDAQ, AMEX)
+	UseAdjusted  bool   `json:"use_adjusted"`   // 수정주가 사용여부
+	Market       string `json:"market"`         // 시장 (NY, NASDAQ, AMEX)
+	ErrorOnEmpty bool   `json:"error_on_empty"` // 빈 결과를 errors.ErrNoData 로 반환 (기본은 빈 슬라이스)
 }
 
 // GetMarketCode 시장명을 코드로 변환
 func (opts *MonthChartOptions) GetMarketCode() string {
-	switch opts.Market {
-	case "NY", "NYSE":
-		return ForeignMarketNY
-	case "NASDAQ":
-		return ForeignMarketNASDAQ
-	case "AMEX":
-		return ForeignMarketAMEX
-	default:
-		return ForeignMarketNASDAQ // 기본값: 나스닥
-	}
+	return DefaultMarketResolver.ForeignCode(opts.Market) // 알 수 없으면 기본 해외 시장
 }
 
 // GetAdjustedCode 수정주가 사용여부를 코드로 변환
@@ -439,15 +393,7 @@ func (opts *MonthChartOptions) GetAdjustedCode() string {
 
 // FormatDate 날짜를 YYYYMMDD 형식으로 변환
 func (p *MonthChartPeriod) FormatDate(date string) string {
-	// YYYY-MM-DD 형식을 YYYYMMDD로 변환
-	if len(date) == 10 && date[4] == '-' && date[7] == '-' {
-		return date[:4] + date[5:7] + date[8:10]
-	}
-	// 이미 YYYYMMDD 형식이면 그대로 반환
-	if len(date) == 8 {
-		return date
-	}
-	return ""
+	return utils.ToYMD(date)
 }
 
 // GetFormattedStartDate 포맷된 시작일 반환
@@ -41,6 +41,14 @@ type ForeignCurrentPriceOutput struct {
 	PrprVrssHgprRate     string `json:"PrprVrssHgprRate"`     // 현재가대비고가비율
 	SdprVrssLwprRate     string `json:"SdprVrssLwprRate"`     // 기준가대비저가비율
 	PrprVrssLwprRate     string `json:"PrprVrssLwprRate"`     // 현재가대비저가비율
+	Zdiv                 string `json:"zdiv"`                 // 소수점자리수
+}
+
+// ForeignPriceResult 해외주식 현재가 일괄 조회의 종목별 결과
+type ForeignPriceResult struct {
+	StockCode string                   `json:"stock_code"`
+	Data      *ForeignCurrentPriceData `json:"data,omitempty"`
+	Error     string                   `json:"error,omitempty"` // 조회 실패 사유
 }
 
 // ForeignCurrentPriceData 해외주식 현재가 데이터 (변환된 형식)
@@ -69,4 +77,5 @@ type ForeignCurrentPriceData struct {
 	MarketLowRate    float64 `json:"market_low_rate"`    // 기준가대비저가비율
 	CurrentLowRate   float64 `json:"current_low_rate"`   // 현재가대비저가비율
 	Currency         string  `json:"currency"`           // 통화 (USD)
+	Precision        int     `json:"precision"`          // 가격 소수점자리수
 }
\ No newline at end of file
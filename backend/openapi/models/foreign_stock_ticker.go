@@ -25,6 +25,7 @@ type ForeignStockTickerOutput struct {
 	ExchClsCode2  string `json:"ExchClsCode2"`  // 거래소코드2 (4자)
 	SelnVolUnit   string `json:"SelnVolUnit"`   // 매도량단위 (9자)
 	ShnuVolUnit   string `json:"ShnuVolUnit"`   // 매수량단위 (9자)
+	Zdiv          string `json:"zdiv"`          // 소수점자리수
 }
 
 // ForeignStockTickerHeader 해외주식종목 조회 헤더
@@ -45,4 +46,5 @@ type ForeignStockData struct {
 	Exchange      string `json:"exchange"`       // 거래소명 (NY/NASDAQ/AMEX)
 	SellUnit      int64  `json:"sell_unit"`      // 매도량단위
 	BuyUnit       int64  `json:"buy_unit"`       // 매수량단위
+	Precision     int    `json:"precision"`      // 가격 소수점자리수
 }
\ No newline at end of file
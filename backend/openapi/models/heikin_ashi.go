@@ -0,0 +1,103 @@
+package models
+
+import "math"
+
+// 차트 봉 형식
+const (
+	CandlesRegular = "regular" // 원래 OHLC
+	CandlesHeikin  = "heikin"  // Heikin-Ashi (평활화한 OHLC)
+)
+
+// Bar OHLC 한 봉
+type Bar struct {
+	Open  float64 `json:"open"`
+	High  float64 `json:"high"`
+	Low   float64 `json:"low"`
+	Close float64 `json:"close"`
+}
+
+// ToHeikinAshi 시간순(오래된 봉부터) OHLC 를 Heikin-Ashi 봉으로 변환
+// HA 종가는 네 가격의 평균, HA 시가는 직전 HA 봉 시가/종가의 평균이라 앞 봉에 의존한다.
+// 첫 봉은 직전 HA 봉이 없으므로 원래 봉의 (시가+종가)/2 로 시가를 정한다.
+func ToHeikinAshi(bars []Bar) []Bar {
+	ha := make([]Bar, len(bars))
+	for i, bar := range bars {
+		closePrice := (bar.Open + bar.High + bar.Low + bar.Close) / 4
+		openPrice := (bar.Open + bar.Close) / 2
+		if i > 0 {
+			openPrice = (ha[i-1].Open + ha[i-1].Close) / 2
+		}
+		ha[i] = Bar{
+			Open:  openPrice,
+			High:  math.Max(bar.High, math.Max(openPrice, closePrice)),
+			Low:   math.Min(bar.Low, math.Min(openPrice, closePrice)),
+			Close: closePrice,
+		}
+	}
+	return ha
+}
+
+// heikinAshiByDate 날짜 순서에 맞춰 변환 (차트 API 응답은 최신순이므로 뒤집어서 계산)
+func heikinAshiByDate(bars []Bar, firstDate, lastDate string) []Bar {
+	if firstDate <= lastDate {
+		return ToHeikinAshi(bars)
+	}
+
+	reversed := make([]Bar, len(bars))
+	for i, bar := range bars {
+		reversed[len(bars)-1-i] = bar
+	}
+	ha := ToHeikinAshi(reversed)
+	for i, j := 0, len(ha)-1; i < j; i, j = i+1, j-1 {
+		ha[i], ha[j] = ha[j], ha[i]
+	}
+	return ha
+}
+
+// HeikinAshiDayChart 일차트의 OHLC 를 Heikin-Ashi 로 바꾼 복사본 (정렬 순서 유지)
+func HeikinAshiDayChart(data []ForeignDayChartData) []ForeignDayChartData {
+	if len(data) == 0 {
+		return data
+	}
+	out := append([]ForeignDayChartData(nil), data...)
+	bars := make([]Bar, len(out))
+	for i, d := range out {
+		bars[i] = Bar{Open: d.Open, High: d.High, Low: d.Low, Close: d.Close}
+	}
+	for i, bar := range heikinAshiByDate(bars, out[0].Date, out[len(out)-1].Date) {
+		out[i].Open, out[i].High, out[i].Low, out[i].Close = bar.Open, bar.High, bar.Low, bar.Close
+	}
+	return out
+}
+
+// HeikinAshiWeekChart 주차트의 OHLC 를 Heikin-Ashi 로 바꾼 복사본 (정렬 순서 유지)
+func HeikinAshiWeekChart(data []ForeignWeekChartData) []ForeignWeekChartData {
+	if len(data) == 0 {
+		return data
+	}
+	out := append([]ForeignWeekChartData(nil), data...)
+	bars := make([]Bar, len(out))
+	for i, d := range out {
+		bars[i] = Bar{Open: d.Open, High: d.High, Low: d.Low, Close: d.Close}
+	}
+	for i, bar := range heikinAshiByDate(bars, out[0].WeekEndDate, out[len(out)-1].WeekEndDate) {
+		out[i].Open, out[i].High, out[i].Low, out[i].Close = bar.Open, bar.High, bar.Low, bar.Close
+	}
+	return out
+}
+
+// HeikinAshiMonthChart 월차트의 OHLC 를 Heikin-Ashi 로 바꾼 복사본 (정렬 순서 유지)
+func HeikinAshiMonthChart(data []ForeignMonthChartData) []ForeignMonthChartData {
+	if len(data) == 0 {
+		return data
+	}
+	out := append([]ForeignMonthChartData(nil), data...)
+	bars := make([]Bar, len(out))
+	for i, d := range out {
+		bars[i] = Bar{Open: d.Open, High: d.High, Low: d.Low, Close: d.Close}
+	}
+	for i, bar := range heikinAshiByDate(bars, out[0].MonthEndDate, out[len(out)-1].MonthEndDate) {
+		out[i].Open, out[i].High, out[i].Low, out[i].Close = bar.Open, bar.High, bar.Low, bar.Close
+	}
+	return out
+}
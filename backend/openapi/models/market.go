@@ -0,0 +1,226 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 정규화된 시장 구분
+const (
+	MarketKR     = "KR"     // 국내 주식
+	MarketIndex  = "INDEX"  // 국내 업종&지수
+	MarketNYSE   = "NYSE"   // 뉴욕증권거래소
+	MarketNASDAQ = "NASDAQ" // 나스닥
+	MarketAMEX   = "AMEX"   // 아멕스
+)
+
+// 국가 구분 (stocks.market 컬럼 값)
+const (
+	RegionKR = "KR"
+	RegionUS = "US"
+)
+
+// Market 정규화된 시장 정보
+type Market struct {
+	Name        string // 정규화된 시장명 (MarketKR, MarketNYSE, ...)
+	Region      string // 국가 구분 (RegionKR, RegionUS)
+	Code        string // DB증권 시장분류코드 (J, U, FY, FN, FA)
+	Exchange    string // 해외증시구분코드 (NY, NA, AM). 국내 시장은 빈 값
+	EnglishName string
+	KoreanName  string
+}
+
+// IsForeign 해외 시장 여부
+func (m Market) IsForeign() bool {
+	return m.Region == RegionUS
+}
+
+// markets 정규화된 시장 목록
+var markets = map[string]Market{
+	MarketKR:     {Name: MarketKR, Region: RegionKR, Code: MarketDivStock, EnglishName: "Korea Exchange", KoreanName: "국내"},
+	MarketIndex:  {Name: MarketIndex, Region: RegionKR, Code: MarketDivIndex, EnglishName: "Korea Index", KoreanName: "지수"},
+	MarketNYSE:   {Name: MarketNYSE, Region: RegionUS, Code: ForeignMarketNY, Exchange: ExchangeNY, EnglishName: "New York Stock Exchange", KoreanName: "뉴욕"},
+	MarketNASDAQ: {Name: MarketNASDAQ, Region: RegionUS, Code: ForeignMarketNASDAQ, Exchange: ExchangeNASDAQ, EnglishName: "NASDAQ", KoreanName: "나스닥"},
+	MarketAMEX:   {Name: MarketAMEX, Region: RegionUS, Code: ForeignMarketAMEX, Exchange: ExchangeAMEX, EnglishName: "American Stock Exchange", KoreanName: "아멕스"},
+}
+
+// marketOrder 코드표에 보여 줄 시장 순서
+var marketOrder = []string{MarketKR, MarketIndex, MarketNYSE, MarketNASDAQ, MarketAMEX}
+
+// 시장분류코드/해외증시구분코드를 요청 값으로 받는 트랜잭션
+var (
+	domesticCodeTrIds = map[string][]string{
+		MarketDivStock: {TrIdStockTicker, TrIdStockCurrentPrice},
+		MarketDivIndex: {TrIdStockCurrentPrice},
+	}
+	foreignCodeTrIds = []string{
+		TrIdForeignStockCurrentPrice, TrIdForeignStockMinChart, TrIdForeignStockDayChart,
+		TrIdForeignStockWeekChart, TrIdForeignStockMonthChart,
+	}
+	exchangeTrIds = []string{TrIdForeignStockTicker}
+)
+
+// defaultMarketAliases 기본 별칭 → 정규화된 시장명
+// 시장명, DB증권 코드, 해외증시구분코드, 한글명을 모두 받는다. US 는 기본 해외 시장인 나스닥으로 본다.
+var defaultMarketAliases = map[string]string{
+	"KR":     MarketKR,
+	"J":      MarketKR,
+	"국내":     MarketKR,
+	"INDEX":  MarketIndex,
+	"U":      MarketIndex,
+	"지수":     MarketIndex,
+	"NY":     MarketNYSE,
+	"NYSE":   MarketNYSE,
+	"FY":     MarketNYSE,
+	"뉴욕":     MarketNYSE,
+	"NASDAQ": MarketNASDAQ,
+	"NA":     MarketNASDAQ,
+	"FN":     MarketNASDAQ,
+	"US":     MarketNASDAQ,
+	"나스닥":    MarketNASDAQ,
+	"AMEX":   MarketAMEX,
+	"AM":     MarketAMEX,
+	"FA":     MarketAMEX,
+	"아멕스":    MarketAMEX,
+}
+
+// MarketResolver 여러 형태의 시장 문자열을 정규화된 시장으로 변환
+type MarketResolver struct {
+	mu             sync.RWMutex
+	aliases        map[string]string
+	defaultForeign string // 해외 시장을 알 수 없을 때 쓸 시장 (기본: 나스닥)
+}
+
+// NewMarketResolver 기본 별칭이 등록된 resolver 생성
+func NewMarketResolver() *MarketResolver {
+	aliases := make(map[string]string, len(defaultMarketAliases))
+	for alias, name := range defaultMarketAliases {
+		aliases[alias] = name
+	}
+	return &MarketResolver{aliases: aliases, defaultForeign: MarketNASDAQ}
+}
+
+// DefaultMarketResolver 서비스 전반에서 공유하는 resolver
+var DefaultMarketResolver = NewMarketResolver()
+
+// AddAlias 별칭 추가 (같은 별칭이 있으면 덮어쓴다)
+func (r *MarketResolver) AddAlias(alias, market string) error {
+	if _, ok := markets[market]; !ok {
+		return fmt.Errorf("unknown market: %s", market)
+	}
+
+	key := normalizeMarketAlias(alias)
+	if key == "" {
+		return fmt.Errorf("empty market alias")
+	}
+
+	r.mu.Lock()
+	r.aliases[key] = market
+	r.mu.Unlock()
+	return nil
+}
+
+// SetDefaultForeign 해외 시장을 알 수 없는 요청에 쓸 시장 설정 (배포 기본 시장 DEFAULT_MARKET)
+func (r *MarketResolver) SetDefaultForeign(alias string) error {
+	market, ok := r.Resolve(alias)
+	if !ok || !market.IsForeign() {
+		return fmt.Errorf("default foreign market must be NYSE, NASDAQ or AMEX, got %q", alias)
+	}
+
+	r.mu.Lock()
+	r.defaultForeign = market.Name
+	r.mu.Unlock()
+	return nil
+}
+
+// DefaultForeign 해외 시장을 알 수 없는 요청에 쓰는 시장 이름
+func (r *MarketResolver) DefaultForeign() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.defaultForeign
+}
+
+// Resolve 별칭을 정규화된 시장으로 변환 (대소문자, 앞뒤 공백 무시)
+func (r *MarketResolver) Resolve(alias string) (Market, bool) {
+	r.mu.RLock()
+	name, ok := r.aliases[normalizeMarketAlias(alias)]
+	r.mu.RUnlock()
+	if !ok {
+		return Market{}, false
+	}
+	return markets[name], true
+}
+
+// ForeignCode 해외 시장 별칭을 DB증권 시장분류코드로 변환
+// 해외 시장이 아니거나 알 수 없는 별칭이면 기본 해외 시장(SetDefaultForeign, 설정이 없으면 나스닥)의 코드를 반환한다.
+func (r *MarketResolver) ForeignCode(alias string) string {
+	if market, ok := r.Resolve(alias); ok && market.IsForeign() {
+		return market.Code
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return markets[r.defaultForeign].Code
+}
+
+// Region 별칭의 국가 구분 (알 수 없으면 빈 값)
+func (r *MarketResolver) Region(alias string) string {
+	market, _ := r.Resolve(alias)
+	return market.Region
+}
+
+// MarketCodes 시장 하나의 코드 정리 (어떤 코드를 어느 트랜잭션에 보내는지)
+type MarketCodes struct {
+	Market         string   `json:"market"`
+	Region         string   `json:"region"`
+	KoreanName     string   `json:"name_ko"`
+	EnglishName    string   `json:"name_en"`
+	MarketDivCode  string   `json:"market_div_code"`         // 시장분류코드 (InputCondMrktDivCode)
+	MarketDivTrIds []string `json:"market_div_tr_ids"`       // 시장분류코드를 받는 tr_id
+	ExchangeCode   string   `json:"exchange_code,omitempty"` // 해외증시구분코드 (종목조회용)
+	ExchangeTrIds  []string `json:"exchange_tr_ids,omitempty"`
+	Aliases        []string `json:"aliases"` // Resolve 가 이 시장으로 바꾸는 별칭 (정렬)
+}
+
+// CodeTable 지원하는 모든 시장의 코드표 (등록된 별칭 포함)
+func (r *MarketResolver) CodeTable() []MarketCodes {
+	aliases := make(map[string][]string, len(markets))
+	r.mu.RLock()
+	for alias, name := range r.aliases {
+		aliases[name] = append(aliases[name], alias)
+	}
+	r.mu.RUnlock()
+
+	table := make([]MarketCodes, 0, len(marketOrder))
+	for _, name := range marketOrder {
+		market := markets[name]
+		codes := MarketCodes{
+			Market:        market.Name,
+			Region:        market.Region,
+			KoreanName:    market.KoreanName,
+			EnglishName:   market.EnglishName,
+			MarketDivCode: market.Code,
+			ExchangeCode:  market.Exchange,
+			Aliases:       aliases[name],
+		}
+		if market.IsForeign() {
+			codes.MarketDivTrIds = foreignCodeTrIds
+			codes.ExchangeTrIds = exchangeTrIds
+		} else {
+			codes.MarketDivTrIds = domesticCodeTrIds[market.Code]
+		}
+		sort.Strings(codes.Aliases)
+		table = append(table, codes)
+	}
+	return table
+}
+
+// ResolveMarket DefaultMarketResolver 로 별칭 변환
+func ResolveMarket(alias string) (Market, bool) {
+	return DefaultMarketResolver.Resolve(alias)
+}
+
+func normalizeMarketAlias(alias string) string {
+	return strings.ToUpper(strings.TrimSpace(alias))
+}
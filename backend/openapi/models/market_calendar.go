@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 국내(KRX) 휴장일 (주말 제외, 연말 휴장일 포함)
+var krHolidays = []string{
+	"2024-01-01", "2024-02-09", "2024-02-12", "2024-03-01", "2024-04-10", "2024-05-01", "2024-05-06",
+	"2024-05-15", "2024-06-06", "2024-08-15", "2024-09-16", "2024-09-17", "2024-09-18", "2024-10-01",
+	"2024-10-03", "2024-10-09", "2024-12-25", "2024-12-31",
+	"2025-01-01", "2025-01-27", "2025-01-28", "2025-01-29", "2025-01-30", "2025-03-03", "2025-05-01",
+	"2025-05-05", "2025-05-06", "2025-06-03", "2025-06-06", "2025-08-15", "2025-10-03", "2025-10-06",
+	"2025-10-07", "2025-10-08", "2025-10-09", "2025-12-25", "2025-12-31",
+	"2026-01-01", "2026-02-16", "2026-02-17", "2026-02-18", "2026-03-02", "2026-05-01", "2026-05-05",
+	"2026-05-25", "2026-06-03", "2026-08-17", "2026-09-24", "2026-09-25", "2026-10-05", "2026-10-09",
+	"2026-12-25", "2026-12-31",
+}
+
+// 미국(NYSE/NASDAQ) 휴장일 (주말 제외)
+var usHolidays = []string{
+	"2024-01-01", "2024-01-15", "2024-02-19", "2024-03-29", "2024-05-27", "2024-06-19", "2024-07-04",
+	"2024-09-02", "2024-11-28", "2024-12-25",
+	"2025-01-01", "2025-01-09", "2025-01-20", "2025-02-17", "2025-04-18", "2025-05-26", "2025-06-19",
+	"2025-07-04", "2025-09-01", "2025-11-27", "2025-12-25",
+	"2026-01-01", "2026-01-19", "2026-02-16", "2026-04-03", "2026-05-25", "2026-06-19", "2026-07-03",
+	"2026-09-07", "2026-11-26", "2026-12-25",
+}
+
+// MarketCalendar 국가별 휴장일 달력 (주말은 항상 휴장)
+// 시장은 KR, US 외에 NASDAQ, FY, 뉴욕 같은 별칭으로도 지정할 수 있다. 알 수 없는 시장은 주말만 제외한다.
+type MarketCalendar struct {
+	mu       sync.RWMutex
+	holidays map[string]map[string]bool // region → YYYY-MM-DD
+}
+
+// NewMarketCalendar 휴장일이 없는 빈 달력 생성
+func NewMarketCalendar() *MarketCalendar {
+	return &MarketCalendar{holidays: make(map[string]map[string]bool)}
+}
+
+// DefaultMarketCalendar 기본 달력 (2024 ~ 2026 KRX/NYSE 휴장일, 그 밖의 휴장일은 LoadHolidays 로 설정에서 추가)
+var DefaultMarketCalendar = newDefaultMarketCalendar()
+
+func newDefaultMarketCalendar() *MarketCalendar {
+	c := NewMarketCalendar()
+	c.AddHolidays(RegionKR, krHolidays...)
+	c.AddHolidays(RegionUS, usHolidays...)
+	return c
+}
+
+// AddHolidays 시장의 휴장일 추가 (YYYY-MM-DD, 임시 휴장일 등)
+func (c *MarketCalendar) AddHolidays(market string, dates ...string) {
+	region := DefaultMarketResolver.Region(market)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.holidays[region] == nil {
+		c.holidays[region] = make(map[string]bool)
+	}
+	for _, date := range dates {
+		c.holidays[region][date] = true
+	}
+}
+
+// LoadHolidays 설정의 휴장일 추가 (YYYY-MM-DD 가 아닌 값이 있으면 아무것도 추가하지 않고 에러)
+func (c *MarketCalendar) LoadHolidays(market string, dates []string) error {
+	holidays := make([]string, 0, len(dates))
+	for _, date := range dates {
+		date = strings.TrimSpace(date)
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return fmt.Errorf("invalid %s holiday %q, expected YYYY-MM-DD", market, date)
+		}
+		holidays = append(holidays, date)
+	}
+	c.AddHolidays(market, holidays...)
+	return nil
+}
+
+// HasHolidays year 의 휴장일이 하나라도 등록되어 있는지 여부 (달력이 그 해를 다루는지 확인용)
+func (c *MarketCalendar) HasHolidays(market string, year int) bool {
+	prefix := fmt.Sprintf("%04d-", year)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for date := range c.holidays[DefaultMarketResolver.Region(market)] {
+		if strings.HasPrefix(date, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTradingDay t 의 날짜(t 의 시간대 기준)가 시장의 거래일인지 여부
+// 현재 시각으로 판단할 때는 MarketTime 으로 시장 현지 시간으로 바꿔 넘긴다.
+func (c *MarketCalendar) IsTradingDay(t time.Time, market string) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.holidays[DefaultMarketResolver.Region(market)][t.Format("2006-01-02")]
+}
+
+// BusinessDaysBefore t 로부터 n 거래일 전 날짜 (t 당일은 세지 않음, 시각은 t 와 같다)
+// n 이 0 이하이면 t 를 그대로 돌려준다.
+func (c *MarketCalendar) BusinessDaysBefore(t time.Time, n int, market string) time.Time {
+	d := t
+	for n > 0 {
+		d = d.AddDate(0, 0, -1)
+		if c.IsTradingDay(d, market) {
+			n--
+		}
+	}
+	return d
+}
+
+// BusinessDaysBetween from 다음 날부터 to 까지(to 포함) 거래일 수
+// from 이 to 보다 늦으면 음수를 돌려준다. BusinessDaysBefore 의 역연산이다.
+func (c *MarketCalendar) BusinessDaysBetween(from, to time.Time, market string) int {
+	if from.After(to) {
+		return -c.BusinessDaysBetween(to, from, market)
+	}
+
+	days := 0
+	end := dateOnly(to)
+	for d := dateOnly(from).AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c.IsTradingDay(d, market) {
+			days++
+		}
+	}
+	return days
+}
+
+// BusinessDaysBefore DefaultMarketCalendar 기준 n 거래일 전 날짜
+func BusinessDaysBefore(t time.Time, n int, market string) time.Time {
+	return DefaultMarketCalendar.BusinessDaysBefore(t, n, market)
+}
+
+// BusinessDaysBetween DefaultMarketCalendar 기준 두 날짜 사이 거래일 수
+func BusinessDaysBetween(from, to time.Time, market string) int {
+	return DefaultMarketCalendar.BusinessDaysBetween(from, to, market)
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SymbolNormalizer 클라이언트 종목코드와 DB증권 API 종목코드 사이의 변환
+// 해외 종목은 거래소별로 등록한 변환(BRK.B → BRK/B 같은 클래스 주식)만 적용하고, 등록되지 않은 종목코드와
+// 국내 종목코드는 받은 그대로 보낸다.
+type SymbolNormalizer struct {
+	mu      sync.RWMutex
+	toAPI   map[string]map[string]string // 정규화된 시장명 → 클라이언트 종목코드 → API 종목코드
+	fromAPI map[string]map[string]string // 정규화된 시장명 → API 종목코드 → 클라이언트 종목코드
+}
+
+// NewSymbolNormalizer 등록된 변환이 없는 normalizer 생성 (모든 종목코드를 그대로 보낸다)
+func NewSymbolNormalizer() *SymbolNormalizer {
+	return &SymbolNormalizer{toAPI: map[string]map[string]string{}, fromAPI: map[string]map[string]string{}}
+}
+
+// DefaultSymbolNormalizer API 호출과 응답 변환에서 공유하는 normalizer
+var DefaultSymbolNormalizer = NewSymbolNormalizer()
+
+// AddMapping market 거래소에서 clientSymbol 을 apiSymbol 로 보내고, 응답의 apiSymbol 은 clientSymbol 로 돌려준다
+func (n *SymbolNormalizer) AddMapping(market, clientSymbol, apiSymbol string) error {
+	resolved, ok := ResolveMarket(market)
+	if !ok {
+		return fmt.Errorf("unknown market: %s", market)
+	}
+	clientSymbol, apiSymbol = normalizeSymbol(clientSymbol), normalizeSymbol(apiSymbol)
+	if clientSymbol == "" || apiSymbol == "" {
+		return fmt.Errorf("empty symbol mapping for %s", market)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.toAPI[resolved.Name] == nil {
+		n.toAPI[resolved.Name] = map[string]string{}
+		n.fromAPI[resolved.Name] = map[string]string{}
+	}
+	n.toAPI[resolved.Name][clientSymbol] = apiSymbol
+	n.fromAPI[resolved.Name][apiSymbol] = clientSymbol
+	return nil
+}
+
+// ToAPI API 요청에 넣을 종목코드 (market 은 NYSE, FY, NY 등 시장 별칭, 등록된 변환이 없으면 그대로)
+func (n *SymbolNormalizer) ToAPI(symbol, market string) string {
+	return n.lookup(n.toAPI, symbol, market)
+}
+
+// FromAPI API 응답의 종목코드를 클라이언트 형식으로 변환 (ToAPI 의 역변환, 등록된 변환이 없으면 그대로)
+func (n *SymbolNormalizer) FromAPI(symbol, market string) string {
+	return n.lookup(n.fromAPI, symbol, market)
+}
+
+// lookup 해외 시장이면 등록된 변환을 찾고, 없으면 symbol 을 그대로 돌려준다
+func (n *SymbolNormalizer) lookup(mappings map[string]map[string]string, symbol, market string) string {
+	resolved, ok := ResolveMarket(market)
+	if !ok || !resolved.IsForeign() {
+		return symbol
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	if mapped, found := mappings[resolved.Name][normalizeSymbol(symbol)]; found {
+		return mapped
+	}
+	return symbol
+}
+
+// LoadMappings "NYSE:BRK.B=BRKB,NYSE:BF.B=BF/B" 형식의 거래소별 변환 등록
+func (n *SymbolNormalizer) LoadMappings(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		market, pair, ok := strings.Cut(item, ":")
+		if !ok {
+			return fmt.Errorf("invalid symbol mapping %q, expected MARKET:SYMBOL=API_SYMBOL", item)
+		}
+		clientSymbol, apiSymbol, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid symbol mapping %q, expected MARKET:SYMBOL=API_SYMBOL", item)
+		}
+		if err := n.AddMapping(market, clientSymbol, apiSymbol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToAPISymbol DefaultSymbolNormalizer 로 API 요청용 종목코드 변환
+func ToAPISymbol(symbol, market string) string {
+	return DefaultSymbolNormalizer.ToAPI(symbol, market)
+}
+
+// FromAPISymbol DefaultSymbolNormalizer 로 응답 종목코드를 클라이언트 형식으로 변환
+func FromAPISymbol(symbol, market string) string {
+	return DefaultSymbolNormalizer.FromAPI(symbol, market)
+}
+
+func normalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,32 @@
+package models
+
+// krxTickSizes 국내 주식 가격대별 호가 단위 (2023년 개편 기준, 가격 상한 미만에 적용)
+var krxTickSizes = []struct {
+	below float64
+	tick  float64
+}{
+	{2000, 1},
+	{5000, 5},
+	{20000, 10},
+	{50000, 50},
+	{200000, 100},
+	{500000, 500},
+}
+
+// TickSize 시장(KR, US 또는 NASDAQ 같은 별칭)과 가격에 해당하는 호가 단위
+// 미국은 1달러 이상 0.01, 미만 0.0001 이다.
+func TickSize(market string, price float64) float64 {
+	if DefaultMarketResolver.Region(market) == RegionKR {
+		for _, tier := range krxTickSizes {
+			if price < tier.below {
+				return tier.tick
+			}
+		}
+		return 1000
+	}
+
+	if price < 1 {
+		return 0.0001
+	}
+	return 0.01
+}
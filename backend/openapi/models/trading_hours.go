@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// TradingHours 시장의 정규장 시간 (현지 시간, 자정부터 분)
+type TradingHours struct {
+	Location *time.Location
+	Open     int
+	Close    int
+}
+
+// tradingHours 국가별 정규장 시간
+var tradingHours = map[string]TradingHours{
+	RegionKR: {Location: loadLocation("Asia/Seoul", 9), Open: 9 * 60, Close: 15*60 + 30},
+	RegionUS: {Location: loadLocation("America/New_York", -5), Open: 9*60 + 30, Close: 16 * 60},
+}
+
+// loadLocation IANA 시간대 (tzdata 가 없으면 고정 오프셋)
+func loadLocation(name string, offsetHours int) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone(name, offsetHours*60*60)
+	}
+	return location
+}
+
+// MarketTradingHours 시장(KR, US 또는 NASDAQ 같은 별칭)의 정규장 시간 (알 수 없으면 US)
+func MarketTradingHours(market string) TradingHours {
+	if hours, ok := tradingHours[DefaultMarketResolver.Region(market)]; ok {
+		return hours
+	}
+	return tradingHours[RegionUS]
+}
+
+// MarketTime t 를 시장 현지 시간으로 (서버 시간대와 관계없이 시장 날짜로 거래일을 판단하기 위함)
+func MarketTime(t time.Time, market string) time.Time {
+	return t.In(MarketTradingHours(market).Location)
+}
+
+// SessionOpen t 가 속한 현지 날짜의 개장 시각
+// 거래일이 아니거나 아직 개장 전이면 false 를 돌려준다.
+func (c *MarketCalendar) SessionOpen(t time.Time, market string) (time.Time, bool) {
+	hours := MarketTradingHours(market)
+	local := t.In(hours.Location)
+	if !c.IsTradingDay(local, market) {
+		return time.Time{}, false
+	}
+
+	open := time.Date(local.Year(), local.Month(), local.Day(), hours.Open/60, hours.Open%60, 0, 0, hours.Location)
+	if local.Before(open) {
+		return time.Time{}, false
+	}
+	return open, true
+}
+
+// InSession t 가 거래일의 정규장 시간(개장 이후, 마감 전) 안인지 여부
+func (c *MarketCalendar) InSession(t time.Time, market string) bool {
+	if _, open := c.SessionOpen(t, market); !open {
+		return false
+	}
+	hours := MarketTradingHours(market)
+	local := t.In(hours.Location)
+	return local.Hour()*60+local.Minute() < hours.Close
+}
+
+// LastClosedTradingDay t 시점에 장 마감이 지난 가장 최근 거래일 (현지 날짜 자정)
+// 장 마감 뒤에야 그날 일봉이 확정되므로 저장된 일봉이 최신인지 판단할 때 쓴다.
+func (c *MarketCalendar) LastClosedTradingDay(t time.Time, market string) time.Time {
+	hours := MarketTradingHours(market)
+	local := t.In(hours.Location)
+	day := dateOnly(local)
+	if local.Hour()*60+local.Minute() < hours.Close {
+		day = day.AddDate(0, 0, -1)
+	}
+	for !c.IsTradingDay(day, market) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
@@ -0,0 +1,139 @@
+package models
+
+import "math"
+
+// DefaultVolumeProfileLevels 거래량 프로파일 기본 가격 구간 수
+const DefaultVolumeProfileLevels = 10
+
+// VolumeBar 거래량 기반 계산에 쓰는 한 봉
+type VolumeBar struct {
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int64
+}
+
+// VolumeProfileLevel 가격 구간 하나에서 거래된 거래량
+type VolumeProfileLevel struct {
+	Low    float64 `json:"low"`
+	High   float64 `json:"high"`
+	Volume int64   `json:"volume"`
+}
+
+// VolumeAnalysis 거래량 기반 통계 (MinVolume 미만 봉은 빼고 계산)
+type VolumeAnalysis struct {
+	VWAP         float64              `json:"vwap"`
+	TotalVolume  int64                `json:"total_volume"`
+	AvgVolume    float64              `json:"avg_volume"`
+	OBV          float64              `json:"obv"`
+	Profile      []VolumeProfileLevel `json:"volume_profile"`
+	MinVolume    int64                `json:"min_volume"`
+	IncludedBars int                  `json:"included_bars"`
+	ExcludedBars int                  `json:"excluded_bars"` // 거래량이 MinVolume 미만이라 뺀 봉 수
+}
+
+// AnalyzeVolume 시간순(오래된 봉부터) 봉의 VWAP, 평균 거래량, OBV, 거래량 프로파일
+// 거래량이 minVolume 미만인 봉(거래가 거의 없는 봉)은 거래량 계산에서 빼지만 가격 흐름에는 남긴다.
+// 그래서 OBV 의 상승/하락 판단은 빠진 봉을 포함한 직전 봉 종가와 비교한다. minVolume 이 0 이하면 모든 봉을 쓴다.
+func AnalyzeVolume(bars []VolumeBar, minVolume int64, levels int) VolumeAnalysis {
+	analysis := VolumeAnalysis{MinVolume: minVolume, Profile: []VolumeProfileLevel{}}
+	if levels <= 0 {
+		levels = DefaultVolumeProfileLevels
+	}
+
+	var priceVolume float64
+	low, high := math.Inf(1), math.Inf(-1)
+	included := make([]VolumeBar, 0, len(bars))
+	for i, bar := range bars {
+		if bar.Volume < minVolume {
+			analysis.ExcludedBars++
+			continue
+		}
+		included = append(included, bar)
+		analysis.TotalVolume += bar.Volume
+		priceVolume += typicalPrice(bar) * float64(bar.Volume)
+		low, high = math.Min(low, bar.Low), math.Max(high, bar.High)
+
+		if i > 0 {
+			if bar.Close > bars[i-1].Close {
+				analysis.OBV += float64(bar.Volume)
+			} else if bar.Close < bars[i-1].Close {
+				analysis.OBV -= float64(bar.Volume)
+			}
+		}
+	}
+
+	analysis.IncludedBars = len(included)
+	if analysis.IncludedBars == 0 {
+		return analysis
+	}
+	analysis.AvgVolume = float64(analysis.TotalVolume) / float64(analysis.IncludedBars)
+	if analysis.TotalVolume > 0 {
+		analysis.VWAP = priceVolume / float64(analysis.TotalVolume)
+	}
+	analysis.Profile = volumeProfile(included, low, high, levels)
+	return analysis
+}
+
+// typicalPrice 봉의 대표 가격 (고가+저가+종가)/3
+func typicalPrice(bar VolumeBar) float64 {
+	return (bar.High + bar.Low + bar.Close) / 3
+}
+
+// volumeProfile [low, high] 를 levels 개 구간으로 나눠 대표 가격이 속한 구간에 거래량을 더한다
+func volumeProfile(bars []VolumeBar, low, high float64, levels int) []VolumeProfileLevel {
+	if high <= low {
+		levels = 1
+	}
+	step := (high - low) / float64(levels)
+	profile := make([]VolumeProfileLevel, levels)
+	for i := range profile {
+		profile[i] = VolumeProfileLevel{Low: low + step*float64(i), High: low + step*float64(i+1)}
+	}
+	profile[levels-1].High = high
+
+	for _, bar := range bars {
+		index := levels - 1
+		if step > 0 {
+			index = int((typicalPrice(bar) - low) / step)
+		}
+		index = max(0, min(index, levels-1))
+		profile[index].Volume += bar.Volume
+	}
+	return profile
+}
+
+// volumeBarsInOrder 시간순으로 정렬 (차트 API 응답은 최신순이므로 newestFirst 이면 뒤집는다)
+func volumeBarsInOrder(bars []VolumeBar, newestFirst bool) []VolumeBar {
+	if !newestFirst {
+		return bars
+	}
+	for i, j := 0, len(bars)-1; i < j; i, j = i+1, j-1 {
+		bars[i], bars[j] = bars[j], bars[i]
+	}
+	return bars
+}
+
+// DayChartVolumeBars 일차트를 시간순 거래량 봉으로 변환
+func DayChartVolumeBars(data []ForeignDayChartData) []VolumeBar {
+	if len(data) == 0 {
+		return nil
+	}
+	bars := make([]VolumeBar, len(data))
+	for i, d := range data {
+		bars[i] = VolumeBar{High: d.High, Low: d.Low, Close: d.Close, Volume: d.Volume}
+	}
+	return volumeBarsInOrder(bars, data[0].Date > data[len(data)-1].Date)
+}
+
+// MinChartVolumeBars 분차트를 시간순 거래량 봉으로 변환 (순서는 파싱된 거래소 현지 일시 기준)
+func MinChartVolumeBars(data []ForeignMinChartData) []VolumeBar {
+	if len(data) == 0 {
+		return nil
+	}
+	bars := make([]VolumeBar, len(data))
+	for i, d := range data {
+		bars[i] = VolumeBar{High: d.High, Low: d.Low, Close: d.Close, Volume: d.Volume}
+	}
+	return volumeBarsInOrder(bars, data[0].Timestamp.After(data[len(data)-1].Timestamp))
+}
@@ -2,7 +2,8 @@ package utils
 
 import (
 	"encoding/json"
-	"fmt"
+
+	"stock-recommender/backend/openapi/errors"
 )
 
 // APIResponse 공통 API 응답 인터페이스
@@ -28,16 +29,14 @@ func (r *BaseAPIResponse) GetResponseMessage() string {
 }
 
 // ParseAPIResponse API 응답을 파싱하고 검증
+// 본문을 읽지 못하면 PARSE_ERROR, 실패 응답이면 응답코드 분류에 맞는 *errors.APIError 를 그대로 반환하므로
+// 호출하는 쪽에서 다시 감싸지 않아야 호출 한도/인증/입력 오류를 구분할 수 있다.
 func ParseAPIResponse(respBody []byte, response APIResponse) error {
 	if err := json.Unmarshal(respBody, response); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if response.GetResponseCode() != "00000" {
-		return fmt.Errorf("API error %s: %s", response.GetResponseCode(), response.GetResponseMessage())
+		return errors.NewParseError("failed to parse response", err)
 	}
 
-	return nil
+	return errors.CheckResponse(response.GetResponseCode(), response.GetResponseMessage())
 }
 
 // PaginationHelper 페이지네이션 처리 헬퍼
@@ -67,8 +66,3 @@ func (p *PaginationHelper) SetNextKey(contKey string) {
 func (p *PaginationHelper) HasNext() bool {
 	return p.ContKey != "" && p.ContKey != "N"
 }
-
-// IsSuccessResponse 성공 응답인지 확인
-func IsSuccessResponse(responseCode string) bool {
-	return responseCode == "00000"
-}
\ No newline at end of file
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"math"
+	"time"
+)
+
+// TradingCalendar 요청한 봉 개수를 조회 기간(달력 날짜)으로 변환하는 설정
+// 휴장일로 빠지는 봉을 감안해 요청 개수보다 넉넉한 기간을 조회한다.
+type TradingCalendar struct {
+	HolidayBuffer    float64 // 요청 봉 개수 대비 추가로 조회할 비율 (0.1 = 10%)
+	MinBufferPeriods int     // 비율과 관계없이 최소로 추가할 봉 개수
+}
+
+// DefaultTradingCalendar 기본 설정 (10% 여유, 최소 1개)
+var DefaultTradingCalendar = TradingCalendar{
+	HolidayBuffer:    0.1,
+	MinBufferPeriods: 1,
+}
+
+// BufferedPeriods 휴장 여유분을 더한 조회 봉 개수
+func (c TradingCalendar) BufferedPeriods(periods int) int {
+	if periods <= 0 {
+		return 0
+	}
+
+	buffer := int(math.Ceil(float64(periods) * c.HolidayBuffer))
+	if buffer < c.MinBufferPeriods {
+		buffer = c.MinBufferPeriods
+	}
+	return periods + buffer
+}
+
+// WeekWindowStart end 가 속한 주를 포함해 weeks 개 주봉을 얻기 위한 시작일 (해당 주 월요일)
+func (c TradingCalendar) WeekWindowStart(end time.Time, weeks int) time.Time {
+	daysSinceMonday := (int(end.Weekday()) + 6) % 7
+	monday := time.Date(end.Year(), end.Month(), end.Day()-daysSinceMonday, 0, 0, 0, 0, end.Location())
+
+	periods := c.BufferedPeriods(weeks)
+	if periods == 0 {
+		return monday
+	}
+	return monday.AddDate(0, 0, -7*(periods-1))
+}
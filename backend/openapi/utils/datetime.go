@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DB증권 API 와 응답에서 쓰는 날짜/시간 형식
+const (
+	LayoutYMD      = "20060102"            // API 요청/응답 날짜
+	LayoutISODate  = "2006-01-02"          // 응답/쿼리 파라미터 날짜
+	LayoutHMS      = "150405"              // API 응답 시각
+	LayoutClock    = "15:04:05"            // 응답 시각
+	LayoutDateTime = "2006-01-02 15:04:05" // 응답 일시
+)
+
+// FormatYMD 날짜를 YYYYMMDD 로 변환
+func FormatYMD(t time.Time) string {
+	return t.Format(LayoutYMD)
+}
+
+// FormatISODate 날짜를 YYYY-MM-DD 로 변환
+func FormatISODate(t time.Time) string {
+	return t.Format(LayoutISODate)
+}
+
+// FormatHMS 시각을 HHMMSS 로 변환
+func FormatHMS(t time.Time) string {
+	return t.Format(LayoutHMS)
+}
+
+// ParseYMD YYYYMMDD 날짜 파싱 (UTC 자정, 존재하지 않는 날짜는 에러)
+func ParseYMD(value string) (time.Time, error) {
+	return parseFixed(LayoutYMD, value, "YYYYMMDD")
+}
+
+// ParseISODate YYYY-MM-DD 날짜 파싱 (UTC 자정, 존재하지 않는 날짜는 에러)
+func ParseISODate(value string) (time.Time, error) {
+	return parseFixed(LayoutISODate, value, "YYYY-MM-DD")
+}
+
+// ParseHMS HHMMSS 시각 파싱 (날짜는 0000-01-01)
+func ParseHMS(value string) (time.Time, error) {
+	return parseFixed(LayoutHMS, value, "HHMMSS")
+}
+
+// ParseYMDHMS YYYYMMDD 날짜와 HHMMSS 시각을 loc 시간대의 시각으로 파싱 (loc 가 nil 이면 UTC)
+func ParseYMDHMS(date, hms string, loc *time.Location) (time.Time, error) {
+	day, err := ParseYMD(date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	clock, err := ParseHMS(hms)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc), nil
+}
+
+// ParseDateString YYYYMMDD 또는 YYYY-MM-DD 날짜 파싱 (앞뒤 공백은 무시)
+func ParseDateString(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == len(LayoutISODate) {
+		return ParseISODate(value)
+	}
+	return ParseYMD(value)
+}
+
+// YMDToISO YYYYMMDD 를 YYYY-MM-DD 로 변환 (형식이 다르거나 없는 날짜면 빈 문자열)
+func YMDToISO(value string) string {
+	t, err := ParseYMD(value)
+	if err != nil {
+		return ""
+	}
+	return FormatISODate(t)
+}
+
+// ToYMD YYYY-MM-DD 또는 YYYYMMDD 를 YYYYMMDD 로 변환 (형식이 다르거나 없는 날짜면 빈 문자열)
+func ToYMD(value string) string {
+	t, err := ParseDateString(value)
+	if err != nil {
+		return ""
+	}
+	return FormatYMD(t)
+}
+
+// HMSToClock HHMMSS 를 HH:MM:SS 로 변환 (형식이 다르거나 없는 시각이면 빈 문자열)
+func HMSToClock(value string) string {
+	t, err := ParseHMS(value)
+	if err != nil {
+		return ""
+	}
+	return t.Format(LayoutClock)
+}
+
+// JoinDateTime YYYYMMDD 날짜와 HHMMSS 시각을 "YYYY-MM-DD HH:MM:SS" 로 변환 (둘 중 하나라도 잘못되면 빈 문자열)
+func JoinDateTime(date, hms string) string {
+	day, clock := YMDToISO(date), HMSToClock(hms)
+	if day == "" || clock == "" {
+		return ""
+	}
+	return day + " " + clock
+}
+
+// parseFixed 자리수가 정해진 형식 파싱 (time.Parse 는 부호/공백을 일부 허용하므로 숫자와 구분자만 통과시킨다)
+func parseFixed(layout, value, name string) (time.Time, error) {
+	if len(value) != len(layout) {
+		return time.Time{}, fmt.Errorf("invalid %s value %q", name, value)
+	}
+	for i := 0; i < len(value); i++ {
+		isDigit := value[i] >= '0' && value[i] <= '9'
+		if layoutDigit := layout[i] >= '0' && layout[i] <= '9'; isDigit != layoutDigit || (!isDigit && value[i] != layout[i]) {
+			return time.Time{}, fmt.Errorf("invalid %s value %q", name, value)
+		}
+	}
+
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value %q: %w", name, value, err)
+	}
+	return t, nil
+}
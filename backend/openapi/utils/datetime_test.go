@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTimeParsing(t *testing.T) {
+	t.Run("ParseYMD", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected time.Time
+			valid    bool
+		}{
+			{"20250711", time.Date(2025, 7, 11, 0, 0, 0, 0, time.UTC), true},
+			{"20240229", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true}, // 윤년
+			{"19991231", time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC), true},
+			{"", time.Time{}, false},
+			{"2025071", time.Time{}, false},    // 자리수 부족
+			{"202507111", time.Time{}, false},  // 자리수 초과
+			{"2025-07-11", time.Time{}, false}, // 다른 형식
+			{"2025071a", time.Time{}, false},   // 숫자 아님
+			{"+2025071", time.Time{}, false},
+			{" 2025071", time.Time{}, false},
+			{"20230229", time.Time{}, false}, // 평년 2월 29일
+			{"20241301", time.Time{}, false}, // 13월
+			{"20240431", time.Time{}, false}, // 4월 31일
+			{"20240100", time.Time{}, false}, // 0일
+		}
+
+		for _, test := range tests {
+			result, err := ParseYMD(test.input)
+			if (err == nil) != test.valid {
+				t.Errorf("ParseYMD(%q) error = %v, expected valid=%v", test.input, err, test.valid)
+				continue
+			}
+			if test.valid && !result.Equal(test.expected) {
+				t.Errorf("ParseYMD(%q) = %v, expected %v", test.input, result, test.expected)
+			}
+		}
+	})
+
+	t.Run("ParseISODate", func(t *testing.T) {
+		tests := []struct {
+			input string
+			valid bool
+		}{
+			{"2025-07-11", true},
+			{"2024-02-29", true},
+			{"2023-02-29", false},
+			{"2025-7-11", false},
+			{"2025/07/11", false},
+			{"20250711", false},
+			{"2025-07-1a", false},
+			{"", false},
+		}
+
+		for _, test := range tests {
+			if _, err := ParseISODate(test.input); (err == nil) != test.valid {
+				t.Errorf("ParseISODate(%q) error = %v, expected valid=%v", test.input, err, test.valid)
+			}
+		}
+	})
+
+	t.Run("ParseDateString", func(t *testing.T) {
+		expected := time.Date(2025, 7, 11, 0, 0, 0, 0, time.UTC)
+		for _, input := range []string{"20250711", "2025-07-11", " 2025-07-11 "} {
+			result, err := ParseDateString(input)
+			if err != nil || !result.Equal(expected) {
+				t.Errorf("ParseDateString(%q) = %v, %v, expected %v", input, result, err, expected)
+			}
+		}
+		for _, input := range []string{"", "2025-7-11", "2025/07/11", "20251311", "yesterday"} {
+			if _, err := ParseDateString(input); err == nil {
+				t.Errorf("ParseDateString(%q) expected error", input)
+			}
+		}
+	})
+
+	t.Run("ParseHMS", func(t *testing.T) {
+		tests := []struct {
+			input string
+			valid bool
+		}{
+			{"163000", true},
+			{"000000", true},
+			{"235959", true},
+			{"", false},
+			{"16300", false},
+			{"1630000", false},
+			{"16:30:00", false},
+			{"240000", false},
+			{"166000", false},
+			{"163060", false},
+			{"16300a", false},
+		}
+
+		for _, test := range tests {
+			result, err := ParseHMS(test.input)
+			if (err == nil) != test.valid {
+				t.Errorf("ParseHMS(%q) error = %v, expected valid=%v", test.input, err, test.valid)
+				continue
+			}
+			if test.valid && FormatHMS(result) != test.input {
+				t.Errorf("FormatHMS(ParseHMS(%q)) = %s", test.input, FormatHMS(result))
+			}
+		}
+	})
+
+	t.Run("ParseYMDHMS", func(t *testing.T) {
+		seoul := time.FixedZone("KST", 9*60*60)
+		result, err := ParseYMDHMS("20240205", "163000", seoul)
+		if err != nil || !result.Equal(time.Date(2024, 2, 5, 16, 30, 0, 0, seoul)) || result.Location() != seoul {
+			t.Errorf("ParseYMDHMS = %v, %v", result, err)
+		}
+		if result, _ := ParseYMDHMS("20240205", "163000", nil); result.Location() != time.UTC {
+			t.Errorf("ParseYMDHMS with nil location = %v, expected UTC", result)
+		}
+		for _, input := range [][2]string{{"", "163000"}, {"20240205", ""}, {"20240230", "163000"}, {"20240205", "246000"}} {
+			if _, err := ParseYMDHMS(input[0], input[1], seoul); err == nil {
+				t.Errorf("ParseYMDHMS(%q, %q) expected error", input[0], input[1])
+			}
+		}
+	})
+
+	t.Run("ParseDate", func(t *testing.T) {
+		if result := ParseDate("20250711"); !result.Equal(time.Date(2025, 7, 11, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("ParseDate(20250711) = %v", result)
+		}
+		// 파싱할 수 없으면 현재 시각
+		before := time.Now()
+		if result := ParseDate("20251311"); result.Before(before) {
+			t.Errorf("ParseDate(20251311) = %v, expected current time", result)
+		}
+	})
+}
+
+func TestDateTimeFormatting(t *testing.T) {
+	t.Run("Format", func(t *testing.T) {
+		date := time.Date(2024, 2, 5, 9, 3, 7, 0, time.UTC)
+		if result := FormatYMD(date); result != "20240205" {
+			t.Errorf("FormatYMD = %s, expected 20240205", result)
+		}
+		if result := FormatISODate(date); result != "2024-02-05" {
+			t.Errorf("FormatISODate = %s, expected 2024-02-05", result)
+		}
+		if result := FormatHMS(date); result != "090307" {
+			t.Errorf("FormatHMS = %s, expected 090307", result)
+		}
+	})
+
+	t.Run("YMDToISO", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{"20250711", "2025-07-11"},
+			{"20240129", "2024-01-29"},
+			{"20231225", "2023-12-25"},
+			{"", ""},
+			{"2025071", ""},
+			{"2025-07-11", ""},
+			{"abcdefgh", ""},
+			{"20241340", ""},
+		}
+
+		for _, test := range tests {
+			if result := YMDToISO(test.input); result != test.expected {
+				t.Errorf("YMDToISO(%s) = %s, expected %s", test.input, result, test.expected)
+			}
+		}
+	})
+
+	t.Run("ToYMD", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{"2025-07-11", "20250711"},
+			{"20250711", "20250711"},
+			{"", ""},
+			{"2025-7-11", ""},
+			{"abcdefgh", ""},
+			{"2025-02-30", ""},
+		}
+
+		for _, test := range tests {
+			if result := ToYMD(test.input); result != test.expected {
+				t.Errorf("ToYMD(%s) = %s, expected %s", test.input, result, test.expected)
+			}
+		}
+	})
+
+	t.Run("HMSToClock", func(t *testing.T) {
+		tests := []struct {
+			input    string
+			expected string
+		}{
+			{"163000", "16:30:00"},
+			{"093000", "09:30:00"},
+			{"", ""},
+			{"16300", ""},
+			{"250000", ""},
+		}
+
+		for _, test := range tests {
+			if result := HMSToClock(test.input); result != test.expected {
+				t.Errorf("HMSToClock(%s) = %s, expected %s", test.input, result, test.expected)
+			}
+		}
+	})
+
+	t.Run("JoinDateTime", func(t *testing.T) {
+		tests := []struct {
+			date     string
+			hms      string
+			expected string
+		}{
+			{"20240205", "163000", "2024-02-05 16:30:00"},
+			{"20231225", "093000", "2023-12-25 09:30:00"},
+			{"", "163000", ""},
+			{"20240205", "", ""},
+			{"20240230", "163000", ""},
+			{"20240205", "246000", ""},
+		}
+
+		for _, test := range tests {
+			if result := JoinDateTime(test.date, test.hms); result != test.expected {
+				t.Errorf("JoinDateTime(%s, %s) = %s, expected %s", test.date, test.hms, result, test.expected)
+			}
+		}
+	})
+}
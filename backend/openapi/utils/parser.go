@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -32,21 +33,39 @@ func ParseInt(str string) int64 {
 
 // ParseDate 날짜 문자열을 time.Time으로 변환
 func ParseDate(dateStr string) time.Time {
-	if dateStr == "" {
-		return time.Now()
+	// YYYYMMDD 또는 YYYY-MM-DD 형식 파싱
+	if t, err := ParseDateString(dateStr); err == nil {
+		return t
 	}
+	return time.Now()
+}
 
-	// YYYYMMDD 형식 파싱
-	if len(dateStr) == 8 {
-		if t, err := time.Parse("20060102", dateStr); err == nil {
-			return t
-		}
+// 허용 최대 소수점자리수
+const maxPricePrecision = 8
+
+// ParsePrecision 소수점자리수(zdiv) 문자열을 정수로 변환
+// 값이 없거나 범위를 벗어나면 fallback을 반환
+func ParsePrecision(zdiv string, fallback int) int {
+	val, err := strconv.Atoi(strings.TrimSpace(zdiv))
+	if err != nil || val < 0 || val > maxPricePrecision {
+		return fallback
 	}
+	return val
+}
 
-	// YYYY-MM-DD 형식 파싱
-	if t, err := time.Parse("2006-01-02", dateStr); err == nil {
-		return t
+// RoundToPrecision 가격을 지정한 소수점자리수로 반올림
+func RoundToPrecision(value float64, precision int) float64 {
+	if precision < 0 {
+		return value
 	}
+	scale := math.Pow(10, float64(precision))
+	return math.Round(value*scale) / scale
+}
 
-	return time.Now()
-}
\ No newline at end of file
+// FormatPrice 가격을 지정한 소수점자리수의 문자열로 변환
+func FormatPrice(value float64, precision int) string {
+	if precision < 0 {
+		precision = 0
+	}
+	return strconv.FormatFloat(value, 'f', precision, 64)
+}
@@ -16,8 +16,21 @@ type MockServer struct {
 }
 
 // NewMockServer 새로운 모의 서버 생성
+// 토큰 발급 경로(/oauth2/token)는 모의 서버가 직접 응답한다.
 func NewMockServer(t *testing.T, handler http.HandlerFunc) *MockServer {
-	server := httptest.NewServer(handler)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "test-token",
+				"token_type":   "Bearer",
+				"expires_in":   86400,
+				"scope":        "oob",
+			})
+			return
+		}
+		handler(w, r)
+	}))
 	return &MockServer{
 		server: server,
 		t:      t,
@@ -49,6 +62,13 @@ func CreateTestConfig() *config.Config {
 	}
 }
 
+// CreateMockServerConfig 모의 서버로 요청하는 테스트용 설정 생성
+func CreateMockServerConfig(m *MockServer) *config.Config {
+	cfg := CreateTestConfig()
+	cfg.API.DBSecBaseURL = m.URL()
+	return cfg
+}
+
 // SkipIfNoCredentials API 자격증명이 없으면 테스트 스킵
 func SkipIfNoCredentials(t *testing.T, client ClientInterface) {
 	if !client.HasValidCredentials() {
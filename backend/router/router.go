@@ -1,14 +1,28 @@
 package router
 
 import (
+	"log"
+
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/openapi/client"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
+// Dependencies main 이 만들어 백그라운드 작업과 함께 쓰는 서비스 (nil 이면 Setup 이 따로 만든다)
+type Dependencies struct {
+	AIClient   *services.AIClient             // 백테스트 AI 전략도 신호 생성과 같은 AI 호출 한도(토큰 버킷)를 쓴다
+	Collector  *services.DataCollectorService // 실시간 조회/차트/수동 수집이 스케줄 수집기와 DBSec 토큰과 호출 한도를 함께 쓴다
+	Indicators *services.IndicatorService     // 지표 시계열과 백테스트가 신호 생성과 같은 지표 계산 캐시를 쓴다
+	Cache      *services.CacheService         // 관리자 캐시 비우기와 헬스 체크가 신호 생성기/큐 워커와 같은 Redis 연결을 본다
+	Features   *services.FeatureFlags         // 관리자 API 로 바꾼 기능 플래그가 백그라운드 작업에도 바로 적용된다
+}
+
+func Setup(db *gorm.DB, cfg *config.Config, deps Dependencies) *gin.Engine {
 	// Set Gin mode
 	gin.SetMode(gin.ReleaseMode)
 
@@ -18,31 +32,120 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	r.Use(CORSMiddleware())
 	r.Use(LoggingMiddleware())
 
+	// 캐시는 보조 수단이라 Redis 가 없어도 DB 에서 응답한다
+	cache := deps.Cache
+	if cache == nil {
+		cache = services.NewCacheService(cfg)
+	}
+	features := deps.Features
+	if features == nil {
+		features = services.NewFeatureFlags(db, cfg.Features)
+	}
+
+	collector := deps.Collector
+	if collector == nil {
+		collector = services.NewDataCollectorService(db, cfg)
+	}
+	apiClient := collector.APIClient()
+	indicators := deps.Indicators
+	if indicators == nil {
+		indicators = services.NewIndicatorService().
+			WithCache(services.NewIndicatorCache(cfg.Indicator.CacheSize)).
+			WithFeatures(features)
+	}
+
 	// Initialize handlers
-	stockHandler := handlers.NewStockHandler(db, cfg)
+	stockHandler := handlers.NewStockHandler(db, cfg).WithCache(cache).WithFeatures(features).WithPriceBook(services.DefaultPriceBook).
+		WithLivePrices(collector).
+		WithIndicators(indicators).
+		WithDefaultPriceSource(defaultSource("PRICE_SOURCE", cfg.API.PriceSource, services.SourceAuto))
 	signalHandler := handlers.NewSignalHandler(db, cfg)
-	healthHandler := handlers.NewHealthHandler(db)
-	adminHandler := handlers.NewAdminHandler(db, cfg)
+	healthHandler := handlers.NewHealthHandler(db).WithCache(cache).WithMaintenance(client.DefaultMaintenance)
+	adminHandler := handlers.NewAdminHandler(db, cfg, collector).WithCache(cache).WithFeatures(features)
+	aiClient := deps.AIClient
+	if aiClient == nil {
+		aiClient = services.NewAIClient(cfg)
+	}
+	backtestHandler := handlers.NewBacktestHandler(db, cfg, aiClient).WithIndicators(indicators)
+	// 차트와 일괄 조회도 수집기의 클라이언트로 토큰과 호출 한도를 함께 쓴다
+	chartHandler := handlers.NewForeignChartHandler(apiClient, cfg.API.ChartMinVolume).WithDefaultMarket(cfg.DefaultMarket).
+		WithChartCache(cache).
+		WithChartStore(services.NewStoredPrices(db)).
+		WithDefaultSource(defaultSource("CHART_SOURCE", cfg.API.ChartSource, services.SourceLive))
+	batchHandler := handlers.NewForeignBatchHandler(apiClient)
+	catalogHandler := handlers.NewCatalogHandler(services.DefaultTickerCatalog)
+	debugHandler := handlers.NewDebugHandler(apimodels.DefaultMarketResolver)
+	versionHandler := handlers.NewVersionHandler(features)
+
+	// 무거운 엔드포인트가 함께 쓰는 동시 처리 예산
+	heavy := handlers.RequestBudgetMiddleware(services.NewRequestBudget(cfg.API.RequestBudget))
 
 	// Health check
 	r.GET("/health", healthHandler.HealthCheck)
+	r.GET("/health/ready", healthHandler.ReadinessCheck)
+
+	r.NoRoute(handlers.NotFound)
 
 	// API routes
 	api := r.Group("/api/v1")
+	api.Use(handlers.ValidateQueryParams())
 	{
+		// 배포된 빌드 정보
+		api.GET("/version", versionHandler.GetVersion)
+
+		// 최신 가격 스트림 (SSE)
+		api.GET("/prices/stream", stockHandler.StreamPrices)
+
 		// Stock endpoints
 		stocks := api.Group("/stocks")
 		{
 			stocks.GET("/", stockHandler.GetStocks)
 			stocks.GET("/:symbol", stockHandler.GetStock)
 			stocks.GET("/:symbol/price", stockHandler.GetStockPrice)
+			stocks.GET("/:symbol/prices", stockHandler.GetPriceHistory)
 			stocks.GET("/:symbol/indicators", stockHandler.GetIndicators)
+			stocks.GET("/:symbol/indicators/series", heavy, stockHandler.GetIndicatorSeries)
+			stocks.GET("/:symbol/drawdown", heavy, stockHandler.GetDrawdown)
+			stocks.GET("/:symbol/orderbook", stockHandler.GetOrderBook)
+			stocks.GET("/:symbol/levels", heavy, stockHandler.GetLevels)
+			stocks.GET("/:symbol/chart/day", heavy, chartHandler.GetDayChart)
+			stocks.GET("/:symbol/chart/week", heavy, chartHandler.GetWeekChart)
+			stocks.GET("/:symbol/chart/month", heavy, chartHandler.GetMonthChart)
+			stocks.GET("/:symbol/chart/corporate-actions", heavy, chartHandler.GetCorporateActions)
+			stocks.GET("/:symbol/chart/volume", heavy, chartHandler.GetVolumeAnalysis)
+		}
+
+		// Screener
+		api.GET("/screener", heavy, stockHandler.Screen)
+
+		// 인기 종목/빅테크 일괄 조회 (일부 종목 실패 시 207)
+		batch := api.Group("/batch")
+		{
+			batch.GET("/popular/prices", heavy, batchHandler.GetPopularPrices)
+			batch.GET("/tech-giants/prices", heavy, batchHandler.GetTechGiantsPrices)
+			batch.GET("/popular/chart/day", heavy, batchHandler.GetPopularDayCharts)
+			batch.GET("/tech-giants/chart/:period", heavy, batchHandler.GetTechGiantsCharts)
 		}
 
+		// 해외 종목 카탈로그
+		api.GET("/catalog/foreign", heavy, catalogHandler.GetForeignCatalog)
+
+		// 시장 코드 대응표 (연동 확인용)
+		api.GET("/debug/market-codes", debugHandler.GetMarketCodes)
+
+		// Analytics
+		api.GET("/analytics/movers", stockHandler.GetMovers)
+
+		// Backtest
+		api.GET("/backtest/compare", handlers.RequireFeature(features, services.FlagBacktestAPI), heavy, backtestHandler.Compare)
+		api.GET("/backtest/walk-forward", handlers.RequireFeature(features, services.FlagBacktestAPI), heavy, backtestHandler.WalkForward)
+
 		// Signal endpoints
 		signals := api.Group("/signals")
 		{
 			signals.GET("/", signalHandler.GetSignals)
+			signals.GET("/export", signalHandler.ExportSignals)
+			signals.GET("/explain/:id", signalHandler.ExplainSignal)
 			signals.GET("/:symbol", signalHandler.GetSignalsBySymbol)
 		}
 
@@ -55,14 +158,33 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			admin.PUT("/stocks/:symbol/status", adminHandler.UpdateStockStatus)
 			admin.DELETE("/stocks/:symbol", adminHandler.DeleteStock)
 
+			// Collection universe
+			admin.GET("/universe", adminHandler.GetUniverse)
+			admin.PUT("/universe", adminHandler.UpdateUniverse)
+
+			// Watchlist (fast price refresh)
+			admin.POST("/watchlist", adminHandler.WatchStock)
+			admin.DELETE("/watchlist/:symbol", adminHandler.UnwatchStock)
+
+			// Signal retention
+			admin.DELETE("/signals", adminHandler.PurgeSignals)
+
 			// Data collection
 			admin.POST("/collect/:symbol", adminHandler.TriggerDataCollection)
 			admin.POST("/collect/all", adminHandler.TriggerAllDataCollection)
 			admin.POST("/initialize/major-stocks", adminHandler.InitializeMajorStocks)
+			admin.POST("/import/prices", adminHandler.ImportPrices)
+			admin.POST("/backfill", adminHandler.EnqueueBackfill)
 
 			// System status
 			admin.GET("/api-status", adminHandler.GetAPIStatus)
 			admin.GET("/database/stats", adminHandler.GetDatabaseStats)
+			admin.GET("/selftest/indicators", adminHandler.SelfTestIndicators)
+
+			// Feature flags
+			admin.GET("/features", adminHandler.GetFeatures)
+			admin.PUT("/features/:name", adminHandler.UpdateFeature)
+			admin.DELETE("/features/:name", adminHandler.ResetFeature)
 		}
 	}
 
@@ -90,3 +212,13 @@ func LoggingMiddleware() gin.HandlerFunc {
 		return ""
 	})
 }
+
+// defaultSource 설정한 기본 데이터 출처 (잘못된 값이면 경고 후 fallback)
+func defaultSource(name, value string, fallback services.DataSource) services.DataSource {
+	source, err := services.ParseDataSource(value)
+	if err != nil {
+		log.Printf("Warning: %v, using %s for %s", err, fallback, name)
+		return fallback
+	}
+	return source
+}
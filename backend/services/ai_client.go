@@ -2,45 +2,308 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"strings"
 	"time"
 )
 
+// validDecisions AI 서비스가 반환할 수 있는 의사결정 값
+var validDecisions = map[string]bool{"BUY": true, "SELL": true, "HOLD": true}
+
+// AI 재시도 상한 (설정 실수로 재시도가 호출 한도와 응답 시간을 잡아먹거나 대기 시간 계산이 넘치지 않도록)
+const (
+	maxAIRetries    = 5
+	maxAIRetryDelay = 5 * time.Second
+)
+
 type AIClient struct {
-	baseURL string
-	client  *http.Client
+	baseURL   string
+	providers []aiProvider
+	apiKey    string
+	model     string
+	maxTokens int
+	ruleOnly  bool
+	client    *http.Client
+	timeout   time.Duration       // provider 하나에 쓰는 시간 (재시도 포함)
+	throttle  *client.TokenBucket // 요청마다 토큰을 하나씩 쓴다 (nil 이면 제한 없음)
+
+	historyBars int             // 요청에 담을 최근 봉 수
+	indicators  map[string]bool // 요청에 담을 지표 이름 (nil 이면 전부)
+
+	retries      int           // provider 마다 일시적 오류(5xx, 타임아웃) 뒤 다시 시도할 횟수
+	retryBackoff time.Duration // 첫 재시도 전 대기 시간 (재시도마다 두 배, 지터 적용)
 }
 
 func NewAIClient(cfg *config.Config) *AIClient {
-	return &AIClient{
-		baseURL: cfg.API.AIServiceURL,
+	baseURL := cfg.AI.Endpoint
+	if baseURL == "" {
+		baseURL = cfg.API.AIServiceURL
+	}
+
+	timeout := cfg.AI.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	providers := []aiProvider{newAIProvider(baseURL)}
+	for _, endpoint := range cfg.AI.Fallbacks {
+		providers = append(providers, newAIProvider(endpoint))
+	}
+
+	aiClient := &AIClient{
+		baseURL:   baseURL,
+		providers: providers,
+		apiKey:    cfg.AI.APIKey,
+		model:     cfg.AI.Model,
+		maxTokens: cfg.AI.MaxTokens,
+		ruleOnly:  cfg.AI.RuleOnly,
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
 		},
+		timeout:     timeout,
+		throttle:    client.NewTokenBucket(cfg.AI.RateLimit, cfg.AI.RateBurst),
+		historyBars: cfg.AI.HistoryBars,
+		indicators:  indicatorSet(cfg.AI.Indicators),
+	}
+	return aiClient.WithRetry(cfg.AI.Retries, cfg.AI.RetryBackoff)
+}
+
+// indicatorSet 지표 이름 목록을 조회용 집합으로 변환 (비어 있으면 nil)
+func indicatorSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	return set
+}
+
+// UnknownAIIndicators AI 요청에 담을 수 없는 지표 이름 (설정 오타 확인용)
+func UnknownAIIndicators(names []string) []string {
+	known := indicatorSet(append(IndicatorNames(), "orderbook_imbalance"))
+	var unknown []string
+	for name := range indicatorSet(names) {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// HistoryBars 요청에 함께 보낼 최근 봉 수 (0 이면 최신 시세만)
+func (c *AIClient) HistoryBars() int {
+	if c.historyBars < 0 {
+		return 0
 	}
+	return c.historyBars
+}
+
+// applyContextWindow 설정된 문맥 크기에 맞게 요청의 봉과 지표를 줄인다
+// 봉은 가장 최근 HistoryBars 개만, 지표는 설정된 이름만 남긴다. 호출자의 요청은 바꾸지 않는다.
+func (c *AIClient) applyContextWindow(request models.AIDecisionRequest) models.AIDecisionRequest {
+	if n := c.HistoryBars(); len(request.History) > n {
+		request.History = request.History[len(request.History)-n:]
+	}
+	if len(request.History) == 0 {
+		request.History = nil
+	}
+
+	if c.indicators != nil {
+		indicators := make(map[string]float64, len(c.indicators))
+		for name, value := range request.Indicators {
+			if c.indicators[name] {
+				indicators[name] = value
+			}
+		}
+		request.Indicators = indicators
+	}
+	return request
+}
+
+// WithThrottle 다른 클라이언트와 호출 한도를 함께 쓰도록 버킷 교체 (nil 이면 제한 없음)
+func (c *AIClient) WithThrottle(throttle *client.TokenBucket) *AIClient {
+	c.throttle = throttle
+	return c
+}
+
+// WithRetry 일시적 오류(5xx, 타임아웃)의 재시도 횟수와 첫 재시도 전 대기 시간 설정 (retries 가 0 이면 재시도 안 함)
+// 재시도 횟수는 0 ~ maxAIRetries, 대기 시간은 maxAIRetryDelay 를 넘지 않게 맞춘다.
+func (c *AIClient) WithRetry(retries int, backoff time.Duration) *AIClient {
+	c.retries = min(max(retries, 0), maxAIRetries)
+	c.retryBackoff = min(max(backoff, 0), maxAIRetryDelay)
+	return c
+}
+
+// Throttle AI 요청이 쓰는 호출 한도 버킷
+func (c *AIClient) Throttle() *client.TokenBucket {
+	return c.throttle
+}
+
+// aiProvider 의사결정을 요청할 AI 서비스
+type aiProvider struct {
+	name    string
+	baseURL string
+}
+
+// newAIProvider 엔드포인트의 호스트를 이름으로 하는 provider 생성
+func newAIProvider(endpoint string) aiProvider {
+	name := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	return aiProvider{name: name, baseURL: strings.TrimRight(endpoint, "/")}
+}
+
+// Model 설정된 AI 모델명
+func (c *AIClient) Model() string {
+	return c.model
+}
+
+// RuleOnly 규칙 기반 전용 모드 여부 (AI 서비스를 호출하지 않아야 함)
+func (c *AIClient) RuleOnly() bool {
+	return c.ruleOnly
 }
 
+// GetDecision 설정된 순서대로 AI 서비스에 의사결정을 요청
+// provider 마다 일시적 오류(5xx, 타임아웃)는 AI_TIMEOUT 안에서 retries 번까지 다시 시도하고, 그래도 실패하거나 재시도할 수 없는
+// 오류(4xx, 잘못된 응답)면 다음 provider 로 넘어가며, 모두 실패하면 마지막 에러를 반환한다.
 func (c *AIClient) GetDecision(request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/decision", c.baseURL)
-	
+	return c.GetDecisionContext(context.Background(), request)
+}
+
+// GetDecisionContext ctx 가 취소되면 호출 한도 대기와 진행 중인 요청을 중단하는 GetDecision (요청 처리 중 호출용)
+func (c *AIClient) GetDecisionContext(ctx context.Context, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	var lastErr error
+	for _, provider := range c.providers {
+		resp, err := c.requestWithRetry(ctx, provider, request)
+		if err == nil {
+			return resp, nil
+		}
+		log.Printf("AI provider %s failed: %v", provider.name, err)
+		lastErr = fmt.Errorf("provider %s: %w", provider.name, err)
+	}
+	return nil, lastErr
+}
+
+// requestWithRetry provider 에 요청하고 일시적 오류면 지터를 준 지수 백오프 후 다시 요청
+// 재시도까지 합쳐 timeout 안에 끝나도록 시도마다 timeout 을 (retries+1) 로 나눈 시간만 쓰고,
+// 남은 시간이 대기 시간보다 짧거나 ctx 가 끝나면 더 시도하지 않는다.
+func (c *AIClient) requestWithRetry(ctx context.Context, provider aiProvider, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	attemptTimeout := c.timeout / time.Duration(c.retries+1)
+
+	resp, err := c.requestAttempt(ctx, attemptTimeout, provider, request)
+	for attempt := 0; err != nil && attempt < c.retries && retryableAIError(err) && ctx.Err() == nil; attempt++ {
+		delay := c.retryDelay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			break
+		}
+		log.Printf("Retrying AI provider %s in %s (%d/%d): %v", provider.name, delay, attempt+1, c.retries, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		}
+		resp, err = c.requestAttempt(ctx, attemptTimeout, provider, request)
+	}
+	return resp, err
+}
+
+// requestAttempt 시도 하나를 timeout 안에서 요청
+func (c *AIClient) requestAttempt(ctx context.Context, timeout time.Duration, provider aiProvider, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.requestDecision(ctx, provider, request)
+}
+
+// retryDelay attempt 번째 재시도 전 대기 시간 (retryBackoff * 2^attempt 의 절반 ~ 전체 사이 무작위, maxAIRetryDelay 이하)
+// 여러 종목의 신호 생성이 같은 순간에 다시 몰리지 않도록 지터를 준다.
+func (c *AIClient) retryDelay(attempt int) time.Duration {
+	if c.retryBackoff <= 0 {
+		return 0
+	}
+	delay := c.retryBackoff
+	for i := 0; i < attempt && delay < maxAIRetryDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxAIRetryDelay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// aiStatusError AI 서비스가 200 이 아닌 상태 코드로 응답함
+type aiStatusError struct {
+	status int
+}
+
+func (e *aiStatusError) Error() string {
+	return fmt.Sprintf("AI service returned status %d", e.status)
+}
+
+// retryableAIError 다시 요청하면 성공할 수 있는 오류인지 (5xx 응답, 타임아웃)
+// 4xx 는 같은 요청을 다시 보내도 실패하므로 재시도하지 않는다.
+func retryableAIError(err error) bool {
+	var statusErr *aiStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func (c *AIClient) requestDecision(ctx context.Context, provider aiProvider, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/decision", provider.baseURL)
+
+	// 설정된 모델/토큰 한도 적용 (요청에 명시된 값 우선)
+	if request.Model == "" {
+		request.Model = c.model
+	}
+	if request.MaxTokens == 0 {
+		request.MaxTokens = c.maxTokens
+	}
+	request = c.applyContextWindow(request)
+
 	// Convert to JSON
 	jsonData, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
+
+	// 호출 한도를 넘지 않도록 토큰이 생길 때까지 대기
+	if err := c.throttle.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("AI request throttled: %w", err)
+	}
 	
 	// Create HTTP request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	
 	req.Header.Set("Content-Type", "application/json")
-	
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
 	// Make request
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -49,7 +312,7 @@ func (c *AIClient) GetDecision(request models.AIDecisionRequest) (*models.AIDeci
 	defer resp.Body.Close()
 	
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("AI service returned status %d", resp.StatusCode)
+		return nil, &aiStatusError{status: resp.StatusCode}
 	}
 	
 	// Parse response
@@ -57,10 +320,32 @@ func (c *AIClient) GetDecision(request models.AIDecisionRequest) (*models.AIDeci
 	if err := json.NewDecoder(resp.Body).Decode(&aiResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
-	
+	if err := validateDecision(&aiResponse); err != nil {
+		return nil, fmt.Errorf("invalid AI response: %w", err)
+	}
+
+	// 응답에 모델명이 없으면 요청한 모델로 기록
+	if aiResponse.Model == "" {
+		aiResponse.Model = request.Model
+	}
+	aiResponse.Provider = provider.name
+
 	return &aiResponse, nil
 }
 
+// validateDecision 응답의 의사결정 값과 신뢰도 검증 (의사결정은 대문자로 정규화)
+func validateDecision(resp *models.AIDecisionResponse) error {
+	decision := strings.ToUpper(strings.TrimSpace(resp.Decision))
+	if !validDecisions[decision] {
+		return fmt.Errorf("unexpected decision %q, expected BUY, SELL or HOLD", resp.Decision)
+	}
+	if math.IsNaN(resp.Confidence) || resp.Confidence < 0 || resp.Confidence > 1 {
+		return fmt.Errorf("confidence %v out of range [0, 1]", resp.Confidence)
+	}
+	resp.Decision = decision
+	return nil
+}
+
 func (c *AIClient) HealthCheck() error {
 	url := fmt.Sprintf("%s/health", c.baseURL)
 	
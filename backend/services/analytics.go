@@ -0,0 +1,239 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// 롤링 베타 계산에 필요한 최소 구간 (일간 수익률 개수)
+const minBetaWindow = 5
+
+type AnalyticsService struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsService(db *gorm.DB) *AnalyticsService {
+	return &AnalyticsService{db: db}
+}
+
+// BetaPoint 구간 마지막 날짜 기준 베타 값
+type BetaPoint struct {
+	Date time.Time `json:"date"`
+	Beta float64   `json:"beta"`
+}
+
+// UnderwaterPoint 일자별 고점 대비 하락률
+type UnderwaterPoint struct {
+	Date     time.Time `json:"date"`
+	Drawdown float64   `json:"drawdown"` // 직전 고점 대비 하락률 (%)
+}
+
+// DrawdownReport 기간 내 낙폭 분석 결과
+type DrawdownReport struct {
+	Symbol      string            `json:"symbol"`
+	MaxDrawdown float64           `json:"max_drawdown"` // 최대 낙폭 (%)
+	PeakDate    time.Time         `json:"peak_date"`
+	TroughDate  time.Time         `json:"trough_date"`
+	Underwater  []UnderwaterPoint `json:"underwater"`
+}
+
+// dailyClose 일자별 종가
+type dailyClose struct {
+	Date  time.Time
+	Close float64
+}
+
+// RollingBeta 지수(benchmark) 대비 종목의 롤링 베타 계산
+// 두 종목의 일간 종가를 날짜 기준으로 맞춘 뒤, window개의 일간 수익률마다 cov/var 로 베타를 구한다.
+func (s *AnalyticsService) RollingBeta(symbol, benchmark string, window int) ([]BetaPoint, error) {
+	if window < minBetaWindow {
+		return nil, fmt.Errorf("window must be at least %d, got %d", minBetaWindow, window)
+	}
+
+	symbolCloses, err := s.dailyCloses(symbol, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	benchmarkCloses, err := s.dailyCloses(benchmark, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	dates, symbolReturns, benchmarkReturns := alignedReturns(symbolCloses, benchmarkCloses)
+	if len(symbolReturns) < window {
+		return nil, fmt.Errorf("insufficient overlapping data for %s/%s: %d returns, need %d",
+			symbol, benchmark, len(symbolReturns), window)
+	}
+
+	betas := rollingBeta(symbolReturns, benchmarkReturns, window)
+	points := make([]BetaPoint, len(betas))
+	for i, beta := range betas {
+		points[i] = BetaPoint{Date: dates[i+window-1], Beta: beta}
+	}
+	return points, nil
+}
+
+// Drawdown 기간 내 종목의 최대 낙폭과 underwater 곡선
+// from/to 가 zero value 이면 해당 방향으로 기간 제한 없음
+func (s *AnalyticsService) Drawdown(symbol string, from, to time.Time) (*DrawdownReport, error) {
+	closes, err := s.dailyCloses(symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(closes) == 0 {
+		return nil, fmt.Errorf("no price data for %s in range: %w", symbol, gorm.ErrRecordNotFound)
+	}
+
+	values := make([]float64, len(closes))
+	for i, c := range closes {
+		values[i] = c.Close
+	}
+
+	maxDD, peakIdx, troughIdx := MaxDrawdown(values)
+	underwater := UnderwaterCurve(values)
+
+	report := &DrawdownReport{
+		Symbol:      symbol,
+		MaxDrawdown: maxDD,
+		PeakDate:    closes[peakIdx].Date,
+		TroughDate:  closes[troughIdx].Date,
+		Underwater:  make([]UnderwaterPoint, len(closes)),
+	}
+	for i, c := range closes {
+		report.Underwater[i] = UnderwaterPoint{Date: c.Date, Drawdown: underwater[i]}
+	}
+	return report, nil
+}
+
+// MaxDrawdown 고점 대비 최대 하락률(%)과 해당 고점/저점 인덱스
+// 하락이 없으면 0과 (0, 0)을 반환
+func MaxDrawdown(closes []float64) (maxDD float64, peakIdx, troughIdx int) {
+	runningPeakIdx := 0
+	for i, price := range closes {
+		if price > closes[runningPeakIdx] {
+			runningPeakIdx = i
+			continue
+		}
+
+		peak := closes[runningPeakIdx]
+		if peak <= 0 {
+			continue
+		}
+		if dd := (peak - price) / peak * 100; dd > maxDD {
+			maxDD, peakIdx, troughIdx = dd, runningPeakIdx, i
+		}
+	}
+	return maxDD, peakIdx, troughIdx
+}
+
+// UnderwaterCurve 각 시점의 직전 고점 대비 하락률(%) 시계열 (고점 갱신시 0)
+func UnderwaterCurve(closes []float64) []float64 {
+	curve := make([]float64, len(closes))
+	peak := 0.0
+	for i, price := range closes {
+		if price > peak {
+			peak = price
+		}
+		if peak > 0 {
+			curve[i] = (peak - price) / peak * 100
+		}
+	}
+	return curve
+}
+
+// dailyCloses 종목의 일자별 마지막 종가 (날짜 오름차순)
+// from/to 가 zero value 이면 해당 방향으로 기간 제한 없음
+func (s *AnalyticsService) dailyCloses(symbol string, from, to time.Time) ([]dailyClose, error) {
+	query := s.db.Where("symbol = ? AND granularity IN ?", symbol, models.PriceSeriesGranularities)
+	if !from.IsZero() {
+		query = query.Where("timestamp >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("timestamp <= ?", to)
+	}
+
+	var prices []models.StockPrice
+	if err := query.Order("timestamp ASC").Find(&prices).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch prices for %s: %w", symbol, err)
+	}
+
+	var closes []dailyClose
+	for _, price := range prices {
+		ts := price.Timestamp.UTC()
+		date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+		if n := len(closes); n > 0 && closes[n-1].Date.Equal(date) {
+			closes[n-1].Close = price.ClosePrice
+			continue
+		}
+		closes = append(closes, dailyClose{Date: date, Close: price.ClosePrice})
+	}
+	return closes, nil
+}
+
+// alignedReturns 두 종가 시계열의 공통 날짜에 대한 일간 수익률
+// 반환되는 dates[i] 는 i번째 수익률이 끝나는 날짜
+func alignedReturns(a, b []dailyClose) ([]time.Time, []float64, []float64) {
+	bByDate := make(map[time.Time]float64, len(b))
+	for _, c := range b {
+		bByDate[c.Date] = c.Close
+	}
+
+	var common []dailyClose
+	var commonB []float64
+	for _, c := range a {
+		if closeB, ok := bByDate[c.Date]; ok {
+			common = append(common, c)
+			commonB = append(commonB, closeB)
+		}
+	}
+
+	var dates []time.Time
+	var returnsA, returnsB []float64
+	for i := 1; i < len(common); i++ {
+		if common[i-1].Close == 0 || commonB[i-1] == 0 {
+			continue
+		}
+		dates = append(dates, common[i].Date)
+		returnsA = append(returnsA, common[i].Close/common[i-1].Close-1)
+		returnsB = append(returnsB, commonB[i]/commonB[i-1]-1)
+	}
+	return dates, returnsA, returnsB
+}
+
+// rollingBeta window 구간별 베타 (cov(a,b) / var(b))
+// 지수 변동이 없는 구간의 베타는 0
+func rollingBeta(a, b []float64, window int) []float64 {
+	if window <= 0 || len(a) < window {
+		return nil
+	}
+
+	betas := make([]float64, 0, len(a)-window+1)
+	for end := window; end <= len(a); end++ {
+		sa, sb := a[end-window:end], b[end-window:end]
+
+		var meanA, meanB float64
+		for i := range sa {
+			meanA += sa[i]
+			meanB += sb[i]
+		}
+		meanA /= float64(window)
+		meanB /= float64(window)
+
+		var cov, variance float64
+		for i := range sa {
+			cov += (sa[i] - meanA) * (sb[i] - meanB)
+			variance += (sb[i] - meanB) * (sb[i] - meanB)
+		}
+
+		if variance == 0 {
+			betas = append(betas, 0)
+			continue
+		}
+		betas = append(betas, cov/variance)
+	}
+	return betas
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BackfillService 백필 작업과 일일 호출 수를 DB 에 저장 (재시작 후 이어서 진행)
+type BackfillService struct {
+	db *gorm.DB
+}
+
+func NewBackfillService(db *gorm.DB) *BackfillService {
+	return &BackfillService{db: db}
+}
+
+// Enqueue from ~ to 구간 일봉 백필 작업 등록
+func (s *BackfillService) Enqueue(symbol, market string, from, to time.Time) (*models.BackfillJob, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("backfill range end %s is before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+
+	job := &models.BackfillJob{
+		Symbol:   strings.ToUpper(symbol),
+		Market:   market,
+		FromDate: from,
+		ToDate:   to,
+		Cursor:   from,
+		Status:   models.BackfillPending,
+	}
+	if err := s.db.Create(job).Error; err != nil {
+		return nil, fmt.Errorf("failed to enqueue backfill for %s: %w", symbol, err)
+	}
+	return job, nil
+}
+
+// PendingBackfillJobs 진행 중인 작업 (등록 순)
+func (s *BackfillService) PendingBackfillJobs() ([]models.BackfillJob, error) {
+	var jobs []models.BackfillJob
+	if err := s.db.Where("status = ?", models.BackfillPending).Order("id ASC").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list backfill jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// SaveBackfillJob 작업 커서/상태 저장
+func (s *BackfillService) SaveBackfillJob(job *models.BackfillJob) error {
+	if err := s.db.Save(job).Error; err != nil {
+		return fmt.Errorf("failed to save backfill job %d: %w", job.ID, err)
+	}
+	return nil
+}
+
+// BackfillCalls day(YYYY-MM-DD) 에 사용한 백필 호출 수
+func (s *BackfillService) BackfillCalls(day string) (int, error) {
+	var usage models.BackfillUsage
+	if err := s.db.Where("day = ?", day).Limit(1).Find(&usage).Error; err != nil {
+		return 0, fmt.Errorf("failed to load backfill usage for %s: %w", day, err)
+	}
+	return usage.Calls, nil
+}
+
+// AddBackfillCalls day 의 백필 호출 수에 n 을 더한다
+func (s *BackfillService) AddBackfillCalls(day string, n int) error {
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"calls": gorm.Expr("backfill_usages.calls + ?", n)}),
+	}).Create(&models.BackfillUsage{Day: day, Calls: n}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record backfill usage for %s: %w", day, err)
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// backtestLookback 전략이 지표를 계산하는 봉 수 (신호 생성과 같은 50봉)
+const backtestLookback = 50
+
+// BacktestStrategy 과거 봉만 보고 현재 봉에서 매수/매도/관망을 정하는 전략
+type BacktestStrategy interface {
+	Name() string
+	// Decide history 는 시간순이며 마지막 원소가 현재 봉 (BUY, SELL, HOLD 반환)
+	Decide(history []models.StockPrice) string
+}
+
+// AIDecider AI 의사결정 요청 (AIClient)
+type AIDecider interface {
+	GetDecisionContext(ctx context.Context, request models.AIDecisionRequest) (*models.AIDecisionResponse, error)
+}
+
+// RuleStrategy 신호 생성기의 규칙 기반 판단을 그대로 쓰는 전략
+type RuleStrategy struct {
+	indicators *IndicatorService
+	params     *IndicatorParams // nil 이면 지표 서비스의 전역 지표 기간
+}
+
+func NewRuleStrategy(indicators *IndicatorService) *RuleStrategy {
+	return &RuleStrategy{indicators: indicators}
+}
+
+// WithParams 지표 계산 기간 지정 (워크포워드에서 구간마다 후보 기간을 비교할 때 사용)
+func (s *RuleStrategy) WithParams(params IndicatorParams) *RuleStrategy {
+	s.params = &params
+	return s
+}
+
+// Params 지정한 지표 기간 (없으면 nil)
+func (s *RuleStrategy) Params() *IndicatorParams { return s.params }
+
+func (s *RuleStrategy) Name() string { return "rule" }
+
+func (s *RuleStrategy) Decide(history []models.StockPrice) string {
+	indicators, ok := lookbackIndicators(s.indicators, history, s.params)
+	if !ok {
+		return "HOLD"
+	}
+	decision, _, _ := ruleBasedDecision(indicators)
+	return decision
+}
+
+// AIStrategy 봉마다 AI 서비스에 의사결정을 요청하는 전략 (실패하면 신호 생성기처럼 규칙 기반으로 대체)
+type AIStrategy struct {
+	indicators *IndicatorService
+	decider    AIDecider
+	ctx        context.Context
+}
+
+func NewAIStrategy(indicators *IndicatorService, decider AIDecider) *AIStrategy {
+	return &AIStrategy{indicators: indicators, decider: decider, ctx: context.Background()}
+}
+
+// WithContext AI 호출에 쓸 컨텍스트 (요청이 끝나면 남은 봉은 AI 를 부르지 않고 관망)
+func (s *AIStrategy) WithContext(ctx context.Context) *AIStrategy {
+	s.ctx = ctx
+	return s
+}
+
+func (s *AIStrategy) Name() string { return "ai" }
+
+func (s *AIStrategy) Decide(history []models.StockPrice) string {
+	indicators, ok := lookbackIndicators(s.indicators, history, nil)
+	if !ok || s.ctx.Err() != nil {
+		return "HOLD"
+	}
+
+	current := history[len(history)-1]
+	response, err := s.decider.GetDecisionContext(s.ctx, models.AIDecisionRequest{
+		Symbol:     current.Symbol,
+		Market:     current.Market,
+		Price:      current,
+		Indicators: indicators,
+		Metadata: map[string]interface{}{
+			"data_points": backtestLookback,
+			"strategy":    "backtest",
+			"timestamp":   current.Timestamp.Unix(),
+		},
+	})
+	if err != nil {
+		log.Printf("AI decision failed during backtest for %s: %v", current.Symbol, err)
+		decision, _, _ := ruleBasedDecision(indicators)
+		return decision
+	}
+	return response.Decision
+}
+
+// lookbackIndicators history 마지막 50봉의 지표 (지표 계산이 정렬을 바꾸므로 복사본 사용, params 가 nil 이면 전역 기간)
+func lookbackIndicators(service *IndicatorService, history []models.StockPrice, params *IndicatorParams) (map[string]float64, bool) {
+	if len(history) < backtestLookback {
+		return nil, false
+	}
+	window := make([]models.StockPrice, backtestLookback)
+	copy(window, history[len(history)-backtestLookback:])
+
+	var result *IndicatorResult
+	if params != nil {
+		result = service.CalculateAllWithParams(window, *params)
+	} else {
+		result = service.CalculateAll(window)
+	}
+	if result == nil {
+		return nil, false
+	}
+	return result.ToMap(), true
+}
+
+// BacktestCosts 매수/매도 체결마다 적용하는 수수료와 슬리피지
+type BacktestCosts struct {
+	CommissionFixed float64 // 체결당 고정 수수료
+	CommissionBps   float64 // 체결 금액 대비 수수료 (bp)
+	SlippageBps     float64 // 종가 대비 불리한 체결 가격 차이 (bp)
+	SlippageTicks   int     // 종가 대비 불리한 체결 가격 차이 (호가 단위 수, SlippageBps 에 더해진다)
+}
+
+// fillPrice 종가에 슬리피지를 반영한 체결 가격 (매수는 높게, 매도는 낮게)
+func (c BacktestCosts) fillPrice(bar models.StockPrice, buy bool) float64 {
+	market := bar.Market
+	if market == "" {
+		market = apimodels.SymbolRegion(bar.Symbol)
+	}
+	slippage := bar.ClosePrice*c.SlippageBps/10000 +
+		float64(c.SlippageTicks)*apimodels.TickSize(market, bar.ClosePrice)
+	if buy {
+		return bar.ClosePrice + slippage
+	}
+	return math.Max(bar.ClosePrice-slippage, 0)
+}
+
+// commission 체결 금액에 대한 수수료
+func (c BacktestCosts) commission(notional float64) float64 {
+	return c.CommissionFixed + notional*c.CommissionBps/10000
+}
+
+// BacktestTrade 진입부터 청산까지 한 번의 거래 (가격은 슬리피지를 반영한 체결 가격)
+type BacktestTrade struct {
+	EntryTime  time.Time `json:"entry_time"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitTime   time.Time `json:"exit_time"`
+	ExitPrice  float64   `json:"exit_price"`
+	Return     float64   `json:"return"` // 비용 차감 후 수익률 (%)
+}
+
+// BacktestResult 전략 하나의 백테스트 성과
+type BacktestResult struct {
+	Strategy    string          `json:"strategy"`
+	TotalReturn float64         `json:"total_return"` // 비용 차감 후 복리 누적 수익률 (%)
+	GrossReturn float64         `json:"gross_return"` // 수수료/슬리피지가 없을 때의 누적 수익률 (%)
+	WinRate     float64         `json:"win_rate"`     // 수익 거래 비율 (%)
+	MaxDrawdown float64         `json:"max_drawdown"` // 평가 자산 기준 최대 낙폭 (%)
+	TradeCount  int             `json:"trade_count"`
+	Commission  float64         `json:"commission"` // 지불한 수수료 합계
+	Slippage    float64         `json:"slippage"`   // 슬리피지로 잃은 금액 합계
+	Trades      []BacktestTrade `json:"trades"`
+}
+
+// Backtester 시간순 봉을 한 봉씩 진행하며 전략의 판단대로 매매를 흉내 낸다
+// 롱 전용이며, 보유 중이 아닐 때 BUY 면 현재 봉 종가에 전액 진입하고 보유 중일 때 SELL 이면 종가에 청산한다.
+// 체결 가격에는 슬리피지를, 체결 금액에는 수수료를 반영한다. 기간이 끝날 때 보유 중이면 마지막 종가로 청산한다.
+type Backtester struct {
+	lookback int
+	capital  float64
+	costs    BacktestCosts
+}
+
+func NewBacktester() *Backtester {
+	return &Backtester{lookback: backtestLookback, capital: config.DefaultBacktestCapital}
+}
+
+// WithCapital 시작 자금 설정 (고정 수수료의 비중을 정한다)
+func (b *Backtester) WithCapital(capital float64) *Backtester {
+	if capital > 0 {
+		b.capital = capital
+	}
+	return b
+}
+
+// Warmup 첫 판단 전에 지표 계산에만 쓰이는 봉 수 (기간 첫 봉부터 판단하려면 이만큼 앞의 봉을 붙인다)
+func (b *Backtester) Warmup() int {
+	return b.lookback - 1
+}
+
+// WithCosts 체결 비용 설정 (기본값은 비용 없음)
+func (b *Backtester) WithCosts(costs BacktestCosts) *Backtester {
+	b.costs = costs
+	return b
+}
+
+// backtestPosition 보유 중인 포지션
+type backtestPosition struct {
+	bar    models.StockPrice
+	fill   float64
+	shares float64
+	cash   float64 // 진입 전 자금 (거래 수익률 기준)
+}
+
+// Run bars 전체에 대해 전략 하나를 실행
+func (b *Backtester) Run(bars []models.StockPrice, strategy BacktestStrategy) BacktestResult {
+	result, _ := b.run(sortedBars(bars), strategy)
+	return result
+}
+
+// run 시간순 bars 로 전략을 실행하고 봉별 평가 자산(시작 자금 대비 배수)도 함께 반환
+func (b *Backtester) run(bars []models.StockPrice, strategy BacktestStrategy) (BacktestResult, []float64) {
+	result := BacktestResult{Strategy: strategy.Name(), Trades: []BacktestTrade{}}
+	if len(bars) < b.lookback {
+		return result, nil
+	}
+
+	cash := b.capital
+	gross := 1.0
+	var position *backtestPosition
+	equity := make([]float64, 0, len(bars)-b.lookback+1)
+
+	openTrade := func(bar models.StockPrice) {
+		fill := b.costs.fillPrice(bar, true)
+		// 수수료를 떼고 남은 금액을 모두 매수
+		notional := (cash - b.costs.CommissionFixed) / (1 + b.costs.CommissionBps/10000)
+		if fill <= 0 || notional <= 0 {
+			return
+		}
+		shares := notional / fill
+		result.Commission += cash - notional
+		result.Slippage += shares * (fill - bar.ClosePrice)
+		position = &backtestPosition{bar: bar, fill: fill, shares: shares, cash: cash}
+		cash = 0
+	}
+
+	closeTrade := func(bar models.StockPrice) {
+		fill := b.costs.fillPrice(bar, false)
+		proceeds := position.shares * fill
+		commission := b.costs.commission(proceeds)
+		cash = proceeds - commission
+		result.Commission += commission
+		result.Slippage += position.shares * (bar.ClosePrice - fill)
+		gross *= bar.ClosePrice / position.bar.ClosePrice
+
+		result.Trades = append(result.Trades, BacktestTrade{
+			EntryTime:  position.bar.Timestamp,
+			EntryPrice: position.fill,
+			ExitTime:   bar.Timestamp,
+			ExitPrice:  fill,
+			Return:     (cash/position.cash - 1) * 100,
+		})
+		position = nil
+	}
+
+	for i := b.lookback - 1; i < len(bars); i++ {
+		// 용량을 잘라 전략이 이후 봉을 볼 수 없게 한다
+		switch strategy.Decide(bars[: i+1 : i+1]) {
+		case "BUY":
+			if position == nil && bars[i].ClosePrice > 0 {
+				openTrade(bars[i])
+			}
+		case "SELL":
+			if position != nil {
+				closeTrade(bars[i])
+			}
+		}
+
+		marked := cash
+		if position != nil {
+			marked = position.shares * bars[i].ClosePrice
+		}
+		equity = append(equity, marked/b.capital)
+	}
+	if position != nil {
+		closeTrade(bars[len(bars)-1])
+	}
+
+	wins := 0
+	for _, trade := range result.Trades {
+		if trade.Return > 0 {
+			wins++
+		}
+	}
+	result.TradeCount = len(result.Trades)
+	if result.TradeCount > 0 {
+		result.WinRate = float64(wins) / float64(result.TradeCount) * 100
+	}
+	result.TotalReturn = (cash/b.capital - 1) * 100
+	result.GrossReturn = (gross - 1) * 100
+	result.MaxDrawdown, _, _ = MaxDrawdown(equity)
+	return result, equity
+}
+
+// BacktestComparison 같은 봉 데이터로 실행한 전략별 성과와 지표별 우세 전략
+type BacktestComparison struct {
+	Bars    int               `json:"bars"`
+	From    time.Time         `json:"from"` // 전략이 판단을 시작한 첫 봉 (앞의 지표 계산용 봉 제외)
+	To      time.Time         `json:"to"`
+	Results []BacktestResult  `json:"results"`
+	Winners map[string]string `json:"winners"` // 지표 → 우세 전략 이름 (동률이면 "tie")
+}
+
+// Compare 모든 전략을 같은 봉으로 실행하고 지표별 우세 전략 판정
+// 수익률/승률은 높을수록, 최대 낙폭/거래 수는 낮을수록 우세하다.
+func (b *Backtester) Compare(bars []models.StockPrice, strategies ...BacktestStrategy) *BacktestComparison {
+	bars = sortedBars(bars)
+	comparison := &BacktestComparison{Bars: len(bars), Results: make([]BacktestResult, 0, len(strategies))}
+	if len(bars) > 0 {
+		comparison.From = bars[min(b.Warmup(), len(bars)-1)].Timestamp
+		comparison.To = bars[len(bars)-1].Timestamp
+	}
+	for _, strategy := range strategies {
+		comparison.Results = append(comparison.Results, b.Run(bars, strategy))
+	}
+
+	results := comparison.Results
+	comparison.Winners = map[string]string{
+		"total_return": backtestWinner(results, func(r BacktestResult) float64 { return r.TotalReturn }),
+		"win_rate":     backtestWinner(results, func(r BacktestResult) float64 { return r.WinRate }),
+		"max_drawdown": backtestWinner(results, func(r BacktestResult) float64 { return -r.MaxDrawdown }),
+		"trade_count":  backtestWinner(results, func(r BacktestResult) float64 { return -float64(r.TradeCount) }),
+	}
+	return comparison
+}
+
+// backtestWinner metric 이 가장 큰 전략 (가장 큰 값이 여럿이면 "tie")
+func backtestWinner(results []BacktestResult, metric func(BacktestResult) float64) string {
+	winner := ""
+	best := 0.0
+	for _, result := range results {
+		value := metric(result)
+		switch {
+		case winner == "" || value > best:
+			winner, best = result.Strategy, value
+		case value == best:
+			winner = "tie"
+		}
+	}
+	return winner
+}
+
+// sortedBars 시간순으로 정렬한 복사본
+func sortedBars(bars []models.StockPrice) []models.StockPrice {
+	sorted := make([]models.StockPrice, len(bars))
+	copy(sorted, bars)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+	return sorted
+}
+
+// LoadDailyBars 종목의 기간 내 일봉 (시간순)
+func LoadDailyBars(db *gorm.DB, symbol string, from, to time.Time) ([]models.StockPrice, error) {
+	return ReadAllBars(NewDBBarSource(db, symbol, from, to))
+}
+
+// LoadWarmupBars before 직전 일봉 최대 n 개 (시간순, 백테스트 첫 봉의 지표 계산용)
+func LoadWarmupBars(db *gorm.DB, symbol string, before time.Time, n int) ([]models.StockPrice, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	var bars []models.StockPrice
+	err := db.Where("symbol = ? AND granularity = ? AND timestamp < ?", symbol, models.GranularityDaily, before).
+		Order("timestamp DESC, id DESC").Limit(n).Find(&bars).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch warmup bars for %s: %w", symbol, err)
+	}
+	return sortedBars(bars), nil
+}
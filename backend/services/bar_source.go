@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// DefaultBarPageSize DBBarSource 가 한 번에 읽는 일봉 수
+const DefaultBarPageSize = 500
+
+// BarSource 백테스트에 시간순 일봉을 공급하는 데이터 원천
+// Next 는 이전 묶음 바로 다음 봉부터 시간순으로 돌려주며, 다 읽었으면 빈 묶음을 돌려준다.
+type BarSource interface {
+	Next() ([]models.StockPrice, error)
+}
+
+// DBBarSource 저장된 일봉만으로 백테스트하기 위한 BarSource (API 호출 없음)
+// (timestamp, id) 키셋 페이지로 읽어 기간이 길어도 OFFSET 없이 일정한 비용으로 넘긴다.
+type DBBarSource struct {
+	db       *gorm.DB
+	symbol   string
+	from     time.Time
+	to       time.Time
+	pageSize int
+
+	last *models.StockPrice // 마지막으로 돌려준 봉 (nil 이면 처음부터)
+	done bool
+}
+
+// NewDBBarSource 종목의 [from, to] 기간 일봉을 읽는 BarSource
+func NewDBBarSource(db *gorm.DB, symbol string, from, to time.Time) *DBBarSource {
+	return &DBBarSource{db: db, symbol: symbol, from: from, to: to, pageSize: DefaultBarPageSize}
+}
+
+// WithPageSize 한 번에 읽을 일봉 수 설정
+func (s *DBBarSource) WithPageSize(size int) *DBBarSource {
+	if size > 0 {
+		s.pageSize = size
+	}
+	return s
+}
+
+// Next 다음 페이지의 일봉 (시간순, 다 읽었으면 빈 묶음)
+func (s *DBBarSource) Next() ([]models.StockPrice, error) {
+	if s.done {
+		return nil, nil
+	}
+
+	query := s.db.Where("symbol = ? AND granularity = ? AND timestamp >= ? AND timestamp <= ?",
+		s.symbol, models.GranularityDaily, s.from, s.to)
+	if s.last != nil {
+		query = query.Where("timestamp > ? OR (timestamp = ? AND id > ?)", s.last.Timestamp, s.last.Timestamp, s.last.ID)
+	}
+
+	var bars []models.StockPrice
+	if err := query.Order("timestamp ASC, id ASC").Limit(s.pageSize).Find(&bars).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch daily bars for %s: %w", s.symbol, err)
+	}
+
+	if len(bars) < s.pageSize {
+		s.done = true
+	}
+	if len(bars) > 0 {
+		last := bars[len(bars)-1]
+		s.last = &last
+	}
+	return bars, nil
+}
+
+// ReadAllBars source 가 공급하는 봉을 끝까지 읽어 하나로 모은다
+func ReadAllBars(source BarSource) ([]models.StockPrice, error) {
+	var bars []models.StockPrice
+	for {
+		page, err := source.Next()
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			return bars, nil
+		}
+		bars = append(bars, page...)
+	}
+}
+
+// RunSource source 의 봉 전체에 대해 전략 하나를 실행
+func (b *Backtester) RunSource(source BarSource, strategy BacktestStrategy) (BacktestResult, error) {
+	bars, err := ReadAllBars(source)
+	if err != nil {
+		return BacktestResult{}, err
+	}
+	return b.Run(bars, strategy), nil
+}
+
+// CompareSource source 의 봉 전체로 모든 전략을 실행해 비교
+func (b *Backtester) CompareSource(source BarSource, strategies ...BacktestStrategy) (*BacktestComparison, error) {
+	bars, err := ReadAllBars(source)
+	if err != nil {
+		return nil, err
+	}
+	return b.Compare(bars, strategies...), nil
+}
@@ -3,82 +3,250 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
-type CacheService struct {
+// Redis 장애 대응 설정
+const (
+	redisTimeout       = 500 * time.Millisecond // 연결/읽기/쓰기 제한 시간 (캐시를 기다리느라 요청이 느려지지 않도록 짧게)
+	cacheRetryInterval = 30 * time.Second       // 저장소 오류 뒤 저장소를 다시 불러 보기까지 기다리는 시간
+)
+
+// ErrCacheMiss 캐시에 값이 없음 (캐시를 쓸 수 없을 때도 같은 에러로 원본 조회를 유도)
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheBackend 캐시 저장소 (운영에서는 Redis)
+type CacheBackend interface {
+	Get(ctx context.Context, key string) (string, error) // 키가 없으면 ErrCacheMiss
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	HSet(ctx context.Context, key string, fields map[string]interface{}, ttl time.Duration) error
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	DeleteMatching(ctx context.Context, pattern string) error // glob 패턴에 맞는 키 삭제
+	Ping(ctx context.Context) error
+}
+
+// redisCacheBackend Redis 클라이언트를 CacheBackend 로 감싼 것
+type redisCacheBackend struct {
 	client *redis.Client
-	ctx    context.Context
+}
+
+func (r *redisCacheBackend) Get(ctx context.Context, key string) (string, error) {
+	data, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrCacheMiss
+	}
+	return data, err
+}
+
+func (r *redisCacheBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *redisCacheBackend) HSet(ctx context.Context, key string, fields map[string]interface{}, ttl time.Duration) error {
+	if err := r.client.HMSet(ctx, key, fields).Err(); err != nil {
+		return err
+	}
+	return r.client.Expire(ctx, key, ttl).Err()
+}
+
+func (r *redisCacheBackend) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, key).Result()
+}
+
+func (r *redisCacheBackend) DeleteMatching(ctx context.Context, pattern string) error {
+	keys, err := r.client.Keys(ctx, pattern).Result()
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return r.client.Del(ctx, keys...).Err()
+	}
+	return nil
+}
+
+func (r *redisCacheBackend) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// CacheService 조회 결과 캐시
+// 캐시는 보조 수단이므로 저장소 오류는 경고로 기록하고 조회는 ErrCacheMiss, 저장/무효화는 성공으로 처리해
+// 호출자가 DB/API 원본으로 넘어가게 한다. 경고는 사용 가능 → 불가로 바뀔 때만 남긴다.
+// 저장소 오류 뒤 cacheRetryInterval 동안은 저장소를 부르지 않고 바로 원본으로 넘기며(요청마다 제한 시간을
+// 기다리지 않도록), 그 뒤 한 호출만 저장소를 다시 불러 보고 성공하면 평소처럼 쓴다. Ping 은 항상 저장소를 부른다.
+type CacheService struct {
+	backend CacheBackend
+	ctx     context.Context
+
+	mu      sync.Mutex
+	lastErr error     // 마지막 저장소 오류 (정상 응답을 받으면 nil)
+	retryAt time.Time // 저장소 오류 뒤 저장소를 다시 불러 볼 시각
+	now     func() time.Time
 }
 
 func NewCacheService(cfg *config.Config) *CacheService {
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
-		Password: "", // no password
-		DB:       0,  // default DB
+		Addr:         fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.Port),
+		Password:     "", // no password
+		DB:           0,  // default DB
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+		MaxRetries:   -1, // 장애 중에 재시도로 기다리는 시간이 늘지 않도록
 	})
 
+	return NewCacheServiceWithBackend(&redisCacheBackend{client: rdb})
+}
+
+// NewCacheServiceWithBackend 지정한 저장소를 쓰는 캐시 서비스
+func NewCacheServiceWithBackend(backend CacheBackend) *CacheService {
 	return &CacheService{
-		client: rdb,
-		ctx:    context.Background(),
+		backend: backend,
+		ctx:     context.Background(),
+		now:     time.Now,
 	}
 }
 
-// StockPrice 캐싱
-func (c *CacheService) SetStockPrice(symbol string, price *models.StockPrice) error {
-	key := fmt.Sprintf("stock:price:%s", symbol)
-	data, err := json.Marshal(price)
+// WithClock 저장소 재시도 시각 판단에 쓸 현재 시각 함수 설정 (테스트용)
+func (c *CacheService) WithClock(now func() time.Time) *CacheService {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	return c
+}
+
+// skip 저장소 오류 뒤 재시도 시각 전이라 저장소를 부르지 않아야 하는지 여부
+// 재시도 시각이 지났으면 이 호출이 저장소를 불러 보도록 false 를 반환하고, 다른 호출은 다음 재시도 시각까지 계속 건너뛴다.
+func (c *CacheService) skip() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastErr == nil {
+		return false
+	}
+	now := c.now()
+	if now.Before(c.retryAt) {
+		return true
+	}
+	c.retryAt = now.Add(cacheRetryInterval)
+	return false
+}
+
+// record 저장소 호출 결과 반영 (ErrCacheMiss 는 정상 응답)
+func (c *CacheService) record(op string, err error) error {
+	if err != nil && !errors.Is(err, ErrCacheMiss) {
+		c.mu.Lock()
+		wasAvailable := c.lastErr == nil
+		c.lastErr = err
+		c.retryAt = c.now().Add(cacheRetryInterval)
+		c.mu.Unlock()
+		if wasAvailable {
+			log.Printf("Warning: cache unavailable (%s), falling back to source: %v", op, err)
+		}
+		return ErrCacheMiss
+	}
+
+	c.mu.Lock()
+	recovered := c.lastErr != nil
+	c.lastErr = nil
+	c.mu.Unlock()
+	if recovered {
+		log.Printf("Cache available again (%s)", op)
+	}
+	return err
+}
+
+// get key 의 값을 out 으로 디코딩 (없거나 캐시를 쓸 수 없으면 ErrCacheMiss)
+func (c *CacheService) get(key string, out interface{}) error {
+	if c.skip() {
+		return ErrCacheMiss
+	}
+	data, err := c.backend.Get(c.ctx, key)
+	if err := c.record("get "+key, err); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(data), out); err != nil {
+		return ErrCacheMiss
+	}
+	return nil
+}
+
+// set value 를 JSON 으로 저장 (저장소 오류는 기록만 한다)
+func (c *CacheService) set(key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	
-	return c.client.Set(c.ctx, key, data, time.Minute*5).Err()
+	if c.skip() {
+		return nil
+	}
+	c.record("set "+key, c.backend.Set(c.ctx, key, data, ttl))
+	return nil
+}
+
+// StockPrice 캐싱
+func (c *CacheService) SetStockPrice(symbol string, price *models.StockPrice) error {
+	return c.set(fmt.Sprintf("stock:price:%s", symbol), price, time.Minute*5)
 }
 
 func (c *CacheService) GetStockPrice(symbol string) (*models.StockPrice, error) {
-	key := fmt.Sprintf("stock:price:%s", symbol)
-	data, err := c.client.Get(c.ctx, key).Result()
-	if err != nil {
+	var price models.StockPrice
+	if err := c.get(fmt.Sprintf("stock:price:%s", symbol), &price); err != nil {
 		return nil, err
 	}
-	
-	var price models.StockPrice
-	err = json.Unmarshal([]byte(data), &price)
-	return &price, err
+	return &price, nil
+}
+
+// chartCacheTTL 캐시에 둔 차트 응답의 유효 시간
+const chartCacheTTL = 10 * time.Minute
+
+// GetChart 캐시된 차트 데이터를 out 으로 디코딩 (없으면 ErrCacheMiss)
+func (c *CacheService) GetChart(key string, out interface{}) error {
+	return c.get(key, out)
+}
+
+// SetChart API 에서 받은 차트 데이터 캐싱
+func (c *CacheService) SetChart(key string, data interface{}) error {
+	return c.set(key, data, chartCacheTTL)
 }
 
 // 기술지표 캐싱
 func (c *CacheService) SetIndicators(symbol string, indicators map[string]float64) error {
 	key := fmt.Sprintf("indicators:%s", symbol)
-	
+
 	// Redis HSET으로 저장
 	fields := make(map[string]interface{})
 	for k, v := range indicators {
 		fields[k] = v
 	}
-	
-	err := c.client.HMSet(c.ctx, key, fields).Err()
-	if err != nil {
-		return err
+
+	if c.skip() {
+		return nil
 	}
-	
-	// TTL 설정
-	return c.client.Expire(c.ctx, key, time.Minute*10).Err()
+	c.record("hset "+key, c.backend.HSet(c.ctx, key, fields, time.Minute*10))
+	return nil
 }
 
 func (c *CacheService) GetIndicators(symbol string) (map[string]float64, error) {
 	key := fmt.Sprintf("indicators:%s", symbol)
-	
-	result, err := c.client.HGetAll(c.ctx, key).Result()
-	if err != nil {
+	if c.skip() {
+		return nil, ErrCacheMiss
+	}
+
+	result, err := c.backend.HGetAll(c.ctx, key)
+	if err := c.record("hgetall "+key, err); err != nil {
 		return nil, err
 	}
-	
+	if len(result) == 0 {
+		return nil, ErrCacheMiss
+	}
+
 	indicators := make(map[string]float64)
 	for k, v := range result {
 		var value float64
@@ -86,72 +254,65 @@ func (c *CacheService) GetIndicators(symbol string) (map[string]float64, error)
 			indicators[k] = value
 		}
 	}
-	
+
 	return indicators, nil
 }
 
 // 매매 신호 캐싱
 func (c *CacheService) SetSignals(symbol string, signals []models.TradingSignal) error {
-	key := fmt.Sprintf("signals:%s", symbol)
-	data, err := json.Marshal(signals)
-	if err != nil {
-		return err
-	}
-	
-	return c.client.Set(c.ctx, key, data, time.Minute*15).Err()
+	return c.set(fmt.Sprintf("signals:%s", symbol), signals, time.Minute*15)
 }
 
 func (c *CacheService) GetSignals(symbol string) ([]models.TradingSignal, error) {
-	key := fmt.Sprintf("signals:%s", symbol)
-	data, err := c.client.Get(c.ctx, key).Result()
-	if err != nil {
+	var signals []models.TradingSignal
+	if err := c.get(fmt.Sprintf("signals:%s", symbol), &signals); err != nil {
 		return nil, err
 	}
-	
-	var signals []models.TradingSignal
-	err = json.Unmarshal([]byte(data), &signals)
-	return signals, err
+	return signals, nil
 }
 
 // 종목 목록 캐싱
 func (c *CacheService) SetStocks(market string, stocks []models.Stock) error {
-	key := fmt.Sprintf("stocks:%s", market)
-	data, err := json.Marshal(stocks)
-	if err != nil {
-		return err
-	}
-	
-	return c.client.Set(c.ctx, key, data, time.Hour).Err()
+	return c.set(fmt.Sprintf("stocks:%s", market), stocks, time.Hour)
 }
 
 func (c *CacheService) GetStocks(market string) ([]models.Stock, error) {
-	key := fmt.Sprintf("stocks:%s", market)
-	data, err := c.client.Get(c.ctx, key).Result()
-	if err != nil {
+	var stocks []models.Stock
+	if err := c.get(fmt.Sprintf("stocks:%s", market), &stocks); err != nil {
 		return nil, err
 	}
-	
-	var stocks []models.Stock
-	err = json.Unmarshal([]byte(data), &stocks)
-	return stocks, err
+	return stocks, nil
 }
 
 // 캐시 무효화
 func (c *CacheService) InvalidateStock(symbol string) error {
 	pattern := fmt.Sprintf("*:%s", symbol)
-	keys, err := c.client.Keys(c.ctx, pattern).Result()
-	if err != nil {
-		return err
+	if c.skip() {
+		return nil
 	}
-	
-	if len(keys) > 0 {
-		return c.client.Del(c.ctx, keys...).Err()
+	c.record("invalidate "+pattern, c.backend.DeleteMatching(c.ctx, pattern))
+	return nil
+}
+
+// InvalidateStocks 시장별 종목 목록 캐시 무효화 (종목 등록/상태 변경 시)
+func (c *CacheService) InvalidateStocks() error {
+	if c.skip() {
+		return nil
 	}
-	
+	c.record("invalidate stocks", c.backend.DeleteMatching(c.ctx, "stocks:*"))
 	return nil
 }
 
-// 헬스 체크
+// 헬스 체크 (재시도 시각과 관계없이 저장소를 불러 복구를 확인한다)
 func (c *CacheService) Ping() error {
-	return c.client.Ping(c.ctx).Err()
-}
\ No newline at end of file
+	err := c.backend.Ping(c.ctx)
+	c.record("ping", err)
+	return err
+}
+
+// Available 마지막 저장소 호출이 성공했는지 여부
+func (c *CacheService) Available() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr == nil
+}
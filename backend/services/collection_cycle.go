@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	apierrors "stock-recommender/backend/openapi/errors"
+)
+
+var (
+	// ErrSymbolTimeout 종목 수집이 종목당 제한 시간을 넘김
+	ErrSymbolTimeout = errors.New("symbol collection timed out")
+	// ErrCycleDeadline 주기 제한 시간이 지나 수집하지 못함
+	ErrCycleDeadline = errors.New("collection cycle deadline exceeded")
+	// ErrCollectionPaused API 점검 중이라 수집하지 않음
+	ErrCollectionPaused = errors.New("collection paused for API maintenance")
+	// ErrRetryBudgetExhausted 주기 재시도 총량을 다 써서 수집하지 않음
+	ErrRetryBudgetExhausted = errors.New("collection retry budget exhausted")
+)
+
+// CollectionCycle 수집 주기 한 번의 제한 시간 설정
+type CollectionCycle struct {
+	Deadline      time.Duration    // 주기 전체 제한 시간 (다음 주기 전에 끝나도록)
+	SymbolTimeout time.Duration    // 종목당 제한 시간
+	Interval      time.Duration    // 종목 사이 지연 (API 호출 제한)
+	Paused        func() bool      // true 를 돌려주면 남은 종목을 수집하지 않는다 (API 점검 중, nil 이면 멈추지 않음)
+	MaxRetries    int              // 재시도할 수 있는 오류로 실패한 종목의 재시도 횟수
+	RetryBudget   int              // 주기 전체 재시도 총량 (다 쓰면 남은 종목은 ErrRetryBudgetExhausted 로 건너뛴다)
+	Retryable     func(error) bool // 재시도할 오류인지 판단 (nil 이면 재시도하지 않음)
+}
+
+// NewCollectionCycle 설정값으로 수집 주기 생성 (제한 시간이 0 이하면 기본값 사용, 재시도는 0 이면 하지 않음)
+// 네트워크, 타임아웃, 서버 오류로 실패한 종목만 재시도한다.
+func NewCollectionCycle(cfg config.CollectorConfig, interval time.Duration) CollectionCycle {
+	cycle := CollectionCycle{
+		Deadline:      cfg.CycleDeadline,
+		SymbolTimeout: cfg.SymbolTimeout,
+		Interval:      interval,
+		MaxRetries:    cfg.MaxRetries,
+		RetryBudget:   cfg.RetryBudget,
+		Retryable:     apierrors.IsRetryableError,
+	}
+	if cycle.Deadline <= 0 {
+		cycle.Deadline = config.DefaultCycleDeadline
+	}
+	if cycle.SymbolTimeout <= 0 {
+		cycle.SymbolTimeout = config.DefaultSymbolTimeout
+	}
+	return cycle
+}
+
+// CollectionReport 수집 주기 결과 (실패한 종목과 원인)
+// 제한 시간으로 건너뛴 종목은 ErrSymbolTimeout, ErrCycleDeadline, 점검으로 멈춘 뒤의 종목은 ErrCollectionPaused,
+// 재시도 총량을 다 쓴 뒤의 종목은 ErrRetryBudgetExhausted 로 기록된다.
+type CollectionReport struct {
+	Success  int
+	Failures map[string]error
+	Paused   bool // API 점검으로 주기를 멈춤 (남은 종목은 ErrCollectionPaused)
+	Retries  int  // 주기 동안 쓴 재시도 횟수
+}
+
+func newCollectionReport() *CollectionReport {
+	return &CollectionReport{Failures: map[string]error{}}
+}
+
+// Failed 실패한 종목 수
+func (r *CollectionReport) Failed() int {
+	return len(r.Failures)
+}
+
+// MarshalJSON 에러를 메시지 문자열로 직렬화
+func (r *CollectionReport) MarshalJSON() ([]byte, error) {
+	failures := make(map[string]string, len(r.Failures))
+	for symbol, err := range r.Failures {
+		failures[symbol] = err.Error()
+	}
+	return json.Marshal(struct {
+		Success  int               `json:"success"`
+		Failed   int               `json:"failed"`
+		Failures map[string]string `json:"failures"`
+		Paused   bool              `json:"paused,omitempty"`
+		Retries  int               `json:"retries,omitempty"`
+	}{r.Success, r.Failed(), failures, r.Paused, r.Retries})
+}
+
+// CollectFunc 종목 하나를 수집 (ctx 는 종목당 제한 시간이 지나거나 주기가 끝나면 취소된다)
+type CollectFunc func(ctx context.Context, symbol, market string) error
+
+// Run stocks 를 순서대로 수집
+// 종목당 제한 시간을 넘긴 종목은 건너뛰고, 주기 제한 시간이 지나거나 API 점검이 시작되면 남은 종목도 수집하지 않는다.
+// 시간을 넘기면 collect 에 넘긴 ctx 가 취소되므로 ctx 를 따르는 collect 는 진행 중인 API 호출도 중단한다.
+func (cc CollectionCycle) Run(ctx context.Context, stocks []models.Stock, collect CollectFunc) *CollectionReport {
+	ctx, cancel := context.WithTimeout(ctx, cc.Deadline)
+	defer cancel()
+
+	report := newCollectionReport()
+	for i, stock := range stocks {
+		if ctx.Err() != nil {
+			for _, rest := range stocks[i:] {
+				report.Failures[rest.Symbol] = ErrCycleDeadline
+			}
+			log.Printf("Collection cycle deadline (%s) exceeded, skipped %d remaining symbols", cc.Deadline, len(stocks)-i)
+			break
+		}
+		if cc.Paused != nil && cc.Paused() {
+			for _, rest := range stocks[i:] {
+				report.Failures[rest.Symbol] = ErrCollectionPaused
+			}
+			report.Paused = true
+			log.Printf("API maintenance in progress, paused collection with %d remaining symbols", len(stocks)-i)
+			break
+		}
+
+		exhausted, err := cc.collectWithRetry(ctx, stock, collect, report)
+		if exhausted {
+			report.Failures[stock.Symbol] = err
+			for _, rest := range stocks[i+1:] {
+				report.Failures[rest.Symbol] = ErrRetryBudgetExhausted
+			}
+			log.Printf("Collection retry budget (%d) exhausted at %s: %v, skipped %d remaining symbols",
+				cc.RetryBudget, stock.Symbol, err, len(stocks)-i-1)
+			break
+		}
+		switch {
+		case errors.Is(err, ErrSymbolTimeout):
+			log.Printf("Skipped %s: collection exceeded %s", stock.Symbol, cc.SymbolTimeout)
+			report.Failures[stock.Symbol] = err
+		case err != nil:
+			log.Printf("Failed to collect data for %s (%s): %v", stock.Symbol, stock.Name, err)
+			report.Failures[stock.Symbol] = err
+		default:
+			report.Success++
+		}
+
+		if cc.Interval > 0 && i < len(stocks)-1 {
+			select {
+			case <-time.After(cc.Interval):
+			case <-ctx.Done():
+			}
+		}
+	}
+	return report
+}
+
+// collectWithRetry 종목 하나를 수집하고 재시도할 수 있는 오류면 MaxRetries 까지 다시 시도
+// 재시도마다 주기 재시도 총량을 하나씩 쓰며, 재시도가 필요한데 총량이 남지 않았으면 exhausted 를 돌려준다.
+func (cc CollectionCycle) collectWithRetry(ctx context.Context, stock models.Stock, collect CollectFunc, report *CollectionReport) (exhausted bool, err error) {
+	err = cc.collectSymbol(ctx, stock, collect)
+	for attempt := 0; err != nil && attempt < cc.MaxRetries && cc.Retryable != nil && cc.Retryable(err); attempt++ {
+		if report.Retries >= cc.RetryBudget {
+			return true, err
+		}
+		report.Retries++
+
+		if cc.Interval > 0 {
+			select {
+			case <-time.After(cc.Interval):
+			case <-ctx.Done():
+				return false, err
+			}
+		}
+		log.Printf("Retrying %s (%d/%d): %v", stock.Symbol, attempt+1, cc.MaxRetries, err)
+		err = cc.collectSymbol(ctx, stock, collect)
+	}
+	return false, err
+}
+
+// collectSymbol 종목 하나를 종목당 제한 시간 안에서 수집
+// collect 가 ctx 를 따르지 않아도 제한 시간이 지나면 기다리지 않고 넘어간다.
+func (cc CollectionCycle) collectSymbol(ctx context.Context, stock models.Stock, collect CollectFunc) error {
+	ctx, cancel := context.WithTimeout(ctx, cc.SymbolTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- collect(ctx, stock.Symbol, stock.Market)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrSymbolTimeout
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	apimodels "stock-recommender/backend/openapi/models"
+)
+
+// 기업 이벤트 유형 (수정주가 계수 변화로 추정)
+const (
+	CorporateActionSplit        = "split"
+	CorporateActionReverseSplit = "reverse_split"
+	CorporateActionDividend     = "dividend"
+	CorporateActionUnknown      = "unknown"
+)
+
+const (
+	adjustmentTolerance = 0.002 // 가격 반올림 오차로 보는 계수 변화 (0.2%)
+	splitRatioTolerance = 0.02  // 정수 비율(2:1, 3:1 ...)로 볼 오차
+	dividendMinFactor   = 0.8   // 이보다 작은 하향 조정은 배당으로 보지 않는다
+)
+
+// CorporateAction 수정주가와 원주가 비교로 찾은 분할/배당 이벤트
+type CorporateAction struct {
+	Date   string  `json:"date"`   // 조정이 시작된 첫 거래일
+	Factor float64 `json:"factor"` // 이전 가격에 곱해진 조정 계수 (2:1 분할이면 0.5)
+	Type   string  `json:"type"`   // 유형 추정 (split, reverse_split, dividend, unknown)
+}
+
+// DetectCorporateActions 같은 종목의 수정주가/원주가 일차트로 날짜별 조정 계수를 구해 변화 지점을 반환
+// 계수(수정 종가 / 원 종가)가 전 거래일과 달라지는 날을 이벤트 날짜로 본다. 양쪽에 모두 있는 날짜만 비교한다.
+func DetectCorporateActions(adjusted, unadjusted []apimodels.ForeignDayChartData) []CorporateAction {
+	raw := make(map[string]float64, len(unadjusted))
+	for _, bar := range unadjusted {
+		raw[bar.Date] = bar.Close
+	}
+
+	type datedFactor struct {
+		date   string
+		factor float64
+	}
+	var factors []datedFactor
+	for _, bar := range adjusted {
+		if close, ok := raw[bar.Date]; ok && close > 0 && bar.Close > 0 {
+			factors = append(factors, datedFactor{date: bar.Date, factor: bar.Close / close})
+		}
+	}
+	sort.Slice(factors, func(i, j int) bool { return factors[i].date < factors[j].date })
+
+	actions := []CorporateAction{}
+	for i := 1; i < len(factors); i++ {
+		ratio := factors[i-1].factor / factors[i].factor
+		if math.Abs(ratio-1) <= adjustmentTolerance {
+			continue
+		}
+		actions = append(actions, CorporateAction{
+			Date:   factors[i].date,
+			Factor: math.Round(ratio*10000) / 10000,
+			Type:   guessCorporateAction(ratio),
+		})
+	}
+	return actions
+}
+
+// guessCorporateAction 조정 계수로 이벤트 유형 추정
+// 1/계수 가 정수에 가까우면 분할, 계수가 정수에 가까우면 병합, 소폭 하향이면 배당으로 본다.
+func guessCorporateAction(factor float64) string {
+	switch {
+	case factor < 1 && isWholeRatio(1/factor):
+		return CorporateActionSplit
+	case factor < 1 && factor >= dividendMinFactor:
+		return CorporateActionDividend
+	case factor > 1 && isWholeRatio(factor):
+		return CorporateActionReverseSplit
+	default:
+		return CorporateActionUnknown
+	}
+}
+
+func isWholeRatio(ratio float64) bool {
+	rounded := math.Round(ratio)
+	return rounded >= 2 && math.Abs(ratio-rounded)/rounded <= splitRatioTolerance
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -8,7 +10,9 @@ import (
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
 	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
 	apimodels "stock-recommender/backend/openapi/models"
+	apiutils "stock-recommender/backend/openapi/utils"
 	"gorm.io/gorm"
 )
 
@@ -26,40 +30,43 @@ func NewDataCollectorService(db *gorm.DB, cfg *config.Config) *DataCollectorServ
 	}
 }
 
-// 전체 종목 데이터 수집
-func (s *DataCollectorService) CollectAllStocks() error {
+// CollectAllStocks 전체 종목 데이터 수집
+// 일부 종목이 실패해도 주기는 끝까지 진행하고, 종목별 실패 원인을 report 로 반환한다.
+// 에러는 종목 목록 조회처럼 주기 자체를 시작하지 못한 경우에만 반환한다.
+func (s *DataCollectorService) CollectAllStocks() (*CollectionReport, error) {
 	log.Println("Starting data collection for all stocks...")
 
-	// 등록된 종목 목록 조회
+	// 수집 대상(활성) 종목을 우선순위 순으로 조회
 	var stocks []models.Stock
-	if err := s.db.Find(&stocks).Error; err != nil {
-		return fmt.Errorf("failed to get stocks: %w", err)
+	if err := s.db.Where("is_active = ?", true).Order("priority DESC, symbol ASC").Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stocks: %w", err)
 	}
 
-	successCount := 0
-	errorCount := 0
-
-	for _, stock := range stocks {
-		err := s.CollectStockData(stock.Symbol, stock.Market)
-		if err != nil {
-			log.Printf("Failed to collect data for %s (%s): %v", stock.Symbol, stock.Name, err)
-			errorCount++
-		} else {
-			successCount++
-		}
-
-		// API 호출 제한을 위한 지연
-		time.Sleep(100 * time.Millisecond)
+	// API 호출 제한을 위해 종목 사이 100ms 지연
+	cycle := NewCollectionCycle(s.config.Collector, 100*time.Millisecond)
+	// DBSec 점검 중에는 매 종목 실패를 쌓지 않고 주기를 멈춘다 (재개 시각 이후 주기부터 자동으로 다시 수집)
+	cycle.Paused = s.apiClient.Maintenance().Active
+	report := cycle.Run(context.Background(), stocks, s.CollectStockDataContext)
+	// 더 이상 수집하지 않는 종목은 가격 북에서 뺀다
+	DefaultPriceBook.EvictIdle()
+
+	if report.Paused {
+		log.Printf("Data collection paused for DBSec maintenance: %d success", report.Success)
+		return report, nil
 	}
-
-	log.Printf("Data collection completed: %d success, %d errors", successCount, errorCount)
-	return nil
+	log.Printf("Data collection completed: %d success, %d errors", report.Success, report.Failed())
+	return report, nil
 }
 
 // 특정 종목 데이터 수집
 func (s *DataCollectorService) CollectStockData(symbol, market string) error {
+	return s.CollectStockDataContext(context.Background(), symbol, market)
+}
+
+// CollectStockDataContext ctx 가 취소되면 진행 중인 API 호출을 중단하는 CollectStockData (요청 처리 중 수집용)
+func (s *DataCollectorService) CollectStockDataContext(ctx context.Context, symbol, market string) error {
 	// API에서 데이터 수집
-	priceData, askingData, err := s.apiClient.CollectStockData(symbol, market)
+	priceData, askingData, err := s.apiClient.CollectStockDataContext(ctx, symbol, market)
 	if err != nil {
 		// API 실패시 Mock 데이터 사용 (개발용)
 		if !s.apiClient.HasValidCredentials() {
@@ -71,7 +78,7 @@ func (s *DataCollectorService) CollectStockData(symbol, market string) error {
 
 	// 주가 데이터 저장
 	if priceData != nil {
-		if err := s.saveStockPrice(priceData); err != nil {
+		if err := s.SaveStockPrice(priceData); err != nil {
 			return fmt.Errorf("failed to save price data: %w", err)
 		}
 	}
@@ -87,8 +94,26 @@ func (s *DataCollectorService) CollectStockData(symbol, market string) error {
 	return nil
 }
 
-// 주가 데이터 저장
-func (s *DataCollectorService) saveStockPrice(priceData *apimodels.ParsedStockPrice) error {
+// LatestPrice 종목 현재가를 API 로 바로 수집해 저장한 뒤 반환 (?source=live, 등록되지 않은 종목이면 ErrSourceMiss)
+func (s *DataCollectorService) LatestPrice(ctx context.Context, symbol string) (models.StockPrice, error) {
+	var stock models.Stock
+	if err := s.db.WithContext(ctx).Where("symbol = ?", symbol).First(&stock).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.StockPrice{}, ErrSourceMiss
+		}
+		return models.StockPrice{}, err
+	}
+	if err := s.CollectStockDataContext(ctx, stock.Symbol, stock.Market); err != nil {
+		return models.StockPrice{}, err
+	}
+	if price, ok := DefaultPriceBook.Latest(stock.Symbol); ok {
+		return price, nil
+	}
+	return models.StockPrice{}, ErrSourceMiss
+}
+
+// SaveStockPrice 주가 데이터 저장
+func (s *DataCollectorService) SaveStockPrice(priceData *apimodels.ParsedStockPrice) error {
 	stockPrice := models.StockPrice{
 		Symbol:         priceData.Symbol,
 		OpenPrice:      priceData.OpenPrice,
@@ -100,23 +125,58 @@ func (s *DataCollectorService) saveStockPrice(priceData *apimodels.ParsedStockPr
 		PrevClosePrice: priceData.PrevClosePrice,
 		Change:         priceData.Change,
 		ChangeRate:     priceData.ChangeRate,
+		Granularity:    models.GranularityIntraday,
 		Timestamp:      priceData.Timestamp,
 		Market:         priceData.Market,
 	}
 
+	defer DefaultSymbolLocks.Lock(stockPrice.Symbol)()
+
 	// 중복 데이터 체크 (같은 시각, 같은 종목)
 	var existing models.StockPrice
-	result := s.db.Where("symbol = ? AND timestamp = ?", stockPrice.Symbol, stockPrice.Timestamp).First(&existing)
+	result := s.db.Where("symbol = ? AND timestamp = ? AND granularity IN ?", stockPrice.Symbol, stockPrice.Timestamp, models.PriceSeriesGranularities).First(&existing)
 	
 	if result.Error == gorm.ErrRecordNotFound {
 		// 새 데이터 삽입
-		return s.db.Create(&stockPrice).Error
+		if err := s.db.Create(&stockPrice).Error; err != nil {
+			return err
+		}
 	} else if result.Error != nil {
 		return result.Error
+	} else if err := s.db.Model(&existing).Updates(stockPrice).Error; err != nil {
+		// 기존 데이터 업데이트
+		return err
+	}
+
+	if stockPrice.ID == 0 {
+		stockPrice.ID = existing.ID
+	}
+	DefaultPriceBook.Update(stockPrice)
+	feedSessionBar(priceData)
+	return nil
+}
+
+// feedSessionBar 현재가 스냅샷을 현재 세션 일봉에 반영
+// 스냅샷 거래량은 당일 누적이므로 세션에 이미 반영한 거래량과의 차이만 더한다.
+func feedSessionBar(priceData *apimodels.ParsedStockPrice) {
+	volume := priceData.Volume
+	if current, ok := DefaultSessionBars.Current(priceData.Symbol, priceData.Market, priceData.Timestamp); ok {
+		volume -= current.Volume
+		if volume < 0 {
+			volume = 0
+		}
 	}
 
-	// 기존 데이터 업데이트
-	return s.db.Model(&existing).Updates(stockPrice).Error
+	DefaultSessionBars.Add(MinuteBar{
+		Symbol:    priceData.Symbol,
+		Market:    priceData.Market,
+		Timestamp: priceData.Timestamp,
+		Open:      priceData.CurrentPrice,
+		High:      priceData.CurrentPrice,
+		Low:       priceData.CurrentPrice,
+		Close:     priceData.CurrentPrice,
+		Volume:    volume,
+	})
 }
 
 // 호가 데이터 저장
@@ -148,6 +208,8 @@ func (s *DataCollectorService) saveAskingPrice(askingData *apimodels.ParsedAskin
 		Timestamp:   askingData.Timestamp,
 	}
 
+	defer DefaultSymbolLocks.Lock(askingPrice.Symbol)()
+
 	// 최신 호가 정보만 유지 (이전 데이터 삭제)
 	if err := s.db.Where("symbol = ?", askingPrice.Symbol).Delete(&models.AskingPrice{}).Error; err != nil {
 		log.Printf("Warning: failed to delete old asking price data for %s: %v", askingPrice.Symbol, err)
@@ -158,16 +220,43 @@ func (s *DataCollectorService) saveAskingPrice(askingData *apimodels.ParsedAskin
 
 // 종목별 일봉 데이터 수집
 func (s *DataCollectorService) CollectDailyData(symbol string, days int) error {
-	endDate := time.Now().Format("20060102")
-	startDate := time.Now().AddDate(0, 0, -days).Format("20060102")
+	// 주말/휴장일을 건너뛰어 최근 days 거래일 조회
+	now := apimodels.MarketTime(time.Now(), apimodels.RegionKR)
+	endDate := apiutils.FormatYMD(now)
+	startDate := apiutils.FormatYMD(apimodels.BusinessDaysBefore(now, days, apimodels.RegionKR))
 
 	dailyData, err := s.apiClient.GetDomesticStockDaily(symbol, startDate, endDate)
 	if err != nil {
 		return fmt.Errorf("failed to get daily data: %w", err)
 	}
 
+	s.saveDailyPrices(symbol, dailyData)
+	return nil
+}
+
+// FetchDailyRange from ~ to 구간 일봉을 API 호출 한 번으로 받아 저장 (백필용, 국내 종목만 지원)
+func (s *DataCollectorService) FetchDailyRange(symbol, market string, from, to time.Time) error {
+	if market != apimodels.RegionKR {
+		return fmt.Errorf("daily backfill is not supported for market %s", market)
+	}
+
+	dailyData, err := s.apiClient.GetDomesticStockDaily(symbol, apiutils.FormatYMD(from), apiutils.FormatYMD(to))
+	if err != nil {
+		return fmt.Errorf("failed to get daily data: %w", err)
+	}
+
+	s.saveDailyPrices(symbol, dailyData)
+	return nil
+}
+
+// saveDailyPrices 아직 없는 날짜의 일봉만 저장
+func (s *DataCollectorService) saveDailyPrices(symbol string, dailyData []apimodels.ParsedDailyPrice) {
+	// 신호 생성이 일부만 저장된 일봉을 읽지 않도록 저장이 끝날 때까지 잠근다
+	defer DefaultSymbolLocks.Lock(symbol)()
+
+	bars := make([]models.StockPrice, 0, len(dailyData))
 	for _, data := range dailyData {
-		stockPrice := models.StockPrice{
+		bars = append(bars, models.StockPrice{
 			Symbol:      data.Symbol,
 			OpenPrice:   data.OpenPrice,
 			HighPrice:   data.HighPrice,
@@ -175,23 +264,103 @@ func (s *DataCollectorService) CollectDailyData(symbol string, days int) error {
 			ClosePrice:  data.ClosePrice,
 			Volume:      data.Volume,
 			TradeAmount: data.TradeAmount,
+			Granularity: models.GranularityDaily,
 			Timestamp:   data.Date,
-			Market:      "KR",
+			Market:      apimodels.RegionKR,
+		})
+	}
+	if _, err := saveDailyBars(s.db, symbol, apimodels.RegionKR, bars, false); err != nil {
+		log.Printf("Failed to save daily data for %s: %v", symbol, err)
+	}
+}
+
+// 일봉 저장 시 한 번에 INSERT 하는 봉 개수
+const dailyBarBatchSize = 500
+
+// dailyBarCounts saveDailyBars 결과 (새로 넣은 봉, 값이 바뀌어 갱신한 봉, 이미 있어 건너뛴 봉)
+type dailyBarCounts struct {
+	inserted  int
+	updated   int
+	unchanged int
+}
+
+// saveDailyBars 한 종목의 일봉을 시장 현지 날짜 기준으로 저장 (수집기와 CSV 가져오기 공용)
+// 같은 날짜의 일봉이 이미 있으면 건너뛰고, overwrite 이면 저장된 일봉의 값이 다를 때만 갱신한다.
+// 장중 현재가 스냅샷은 일봉과 별개의 행이므로 같은 날짜에 있어도 일봉을 저장한다 (일봉만 읽는 전략/백테스트용).
+// 없는 봉은 묶어서 INSERT 하며, 호출하는 쪽에서 종목 잠금을 잡는다.
+func saveDailyBars(tx *gorm.DB, symbol, market string, bars []models.StockPrice, overwrite bool) (dailyBarCounts, error) {
+	var counts dailyBarCounts
+	if len(bars) == 0 {
+		return counts, nil
+	}
+
+	location := apimodels.MarketTradingHours(market).Location
+	from, to := bars[0].Timestamp, bars[0].Timestamp
+	for _, bar := range bars[1:] {
+		if bar.Timestamp.Before(from) {
+			from = bar.Timestamp
 		}
+		if bar.Timestamp.After(to) {
+			to = bar.Timestamp
+		}
+	}
 
-		// 중복 체크 후 저장
-		var existing models.StockPrice
-		result := s.db.Where("symbol = ? AND DATE(timestamp) = DATE(?)", 
-			stockPrice.Symbol, stockPrice.Timestamp).First(&existing)
-		
-		if result.Error == gorm.ErrRecordNotFound {
-			if err := s.db.Create(&stockPrice).Error; err != nil {
-				log.Printf("Failed to save daily data for %s on %s: %v", symbol, data.Date.Format("2006-01-02"), err)
-			}
+	// 시간대 차이로 날짜 경계가 어긋나도 놓치지 않도록 앞뒤 하루씩 넓게 읽는다
+	var stored []models.StockPrice
+	err := tx.Where("symbol = ? AND granularity = ? AND timestamp >= ? AND timestamp < ?",
+		symbol, models.GranularityDaily, from.AddDate(0, 0, -1), to.AddDate(0, 0, 2)).
+		Find(&stored).Error
+	if err != nil {
+		return counts, err
+	}
+	existing := make(map[string]models.StockPrice, len(stored))
+	for _, bar := range stored {
+		existing[marketDate(bar.Timestamp, location)] = bar
+	}
+
+	inserts := make([]models.StockPrice, 0, len(bars))
+	for _, bar := range bars {
+		date := marketDate(bar.Timestamp, location)
+		current, ok := existing[date]
+		if !ok {
+			existing[date] = bar
+			inserts = append(inserts, bar)
+			continue
+		}
+		if !overwrite || sameOHLCV(current, bar) {
+			counts.unchanged++
+			continue
+		}
+		err := tx.Model(&current).Updates(map[string]interface{}{
+			"open_price":  bar.OpenPrice,
+			"high_price":  bar.HighPrice,
+			"low_price":   bar.LowPrice,
+			"close_price": bar.ClosePrice,
+			"volume":      bar.Volume,
+		}).Error
+		if err != nil {
+			return counts, err
 		}
+		counts.updated++
 	}
 
-	return nil
+	if len(inserts) > 0 {
+		if err := tx.CreateInBatches(&inserts, dailyBarBatchSize).Error; err != nil {
+			return counts, err
+		}
+		counts.inserted = len(inserts)
+	}
+	return counts, nil
+}
+
+// marketDate t 의 시장 현지 날짜 (YYYY-MM-DD)
+func marketDate(t time.Time, location *time.Location) string {
+	return t.In(location).Format("2006-01-02")
+}
+
+func sameOHLCV(a, b models.StockPrice) bool {
+	return a.OpenPrice == b.OpenPrice && a.HighPrice == b.HighPrice && a.LowPrice == b.LowPrice &&
+		a.ClosePrice == b.ClosePrice && a.Volume == b.Volume
 }
 
 // Mock 데이터 생성 (개발 및 테스트용)
@@ -199,17 +368,7 @@ func (s *DataCollectorService) generateMockData(symbol, market string) error {
 	log.Printf("Generating mock data for %s (%s)", symbol, market)
 
 	// 기본 주가 설정 (종목별로 다르게)
-	basePrice := 50000.0
-	switch symbol {
-	case "005930": // 삼성전자
-		basePrice = 70000.0
-	case "000660": // SK하이닉스
-		basePrice = 120000.0
-	case "AAPL":
-		basePrice = 180.0
-	case "TSLA":
-		basePrice = 250.0
-	}
+	basePrice := mockBasePrice(symbol, market)
 
 	// 랜덤 변동 (-2% ~ +2%)
 	variation := float64(time.Now().Unix()%400 - 200) / 10000.0 // -0.02 ~ 0.02
@@ -230,7 +389,12 @@ func (s *DataCollectorService) generateMockData(symbol, market string) error {
 		Market:         market,
 	}
 
-	return s.db.Create(&mockPrice).Error
+	defer DefaultSymbolLocks.Lock(symbol)()
+	if err := s.db.Create(&mockPrice).Error; err != nil {
+		return err
+	}
+	DefaultPriceBook.Update(mockPrice)
+	return nil
 }
 
 // 시장별 주요 종목 초기화
@@ -285,6 +449,49 @@ func (s *DataCollectorService) InitializeMajorStocks() error {
 	return nil
 }
 
+// 해외 종목 목록 동기화 주기와 대상 거래소 (해외 시장구분코드)
+const foreignStockSyncInterval = 24 * time.Hour
+
+var foreignStockSyncMarkets = []string{apimodels.ForeignMarketNY, apimodels.ForeignMarketNASDAQ, apimodels.ForeignMarketAMEX}
+
+// SyncAllForeignStocks 해외 거래소별 종목 목록을 동기화한 뒤 활성 종목 메타데이터 보강
+// 한 거래소가 실패해도 나머지는 계속 진행하며, 중단된 동기화는 다음 실행이 저장된 커서부터 이어서 한다.
+func (s *DataCollectorService) SyncAllForeignStocks() {
+	for _, market := range foreignStockSyncMarkets {
+		if _, err := s.SyncForeignStocks(market); err != nil {
+			log.Printf("Failed to sync foreign stocks for %s: %v", market, err)
+		}
+	}
+
+	if _, err := NewEnrichmentService(s.db, s.apiClient).EnrichStocks(); err != nil {
+		log.Printf("Failed to enrich stock metadata: %v", err)
+	}
+}
+
+// SyncForeignStocks 해외 거래소 종목 목록 동기화 (exchangeCode 는 FN, NA, NASDAQ 같은 시장 별칭)
+// 바뀐 종목의 이름/업종/가격 소수점자리수만 갱신하고, 없는 종목은 비활성 상태로 추가하며, 목록에서 빠진 종목은 비활성화한다.
+// 종목 목록은 해외증시구분코드(NA)로 받고, 실행 기록과 커서는 해외 시장구분코드(FN)로 남긴다.
+func (s *DataCollectorService) SyncForeignStocks(exchangeCode string) (*models.TickerSyncRun, error) {
+	market, ok := apimodels.ResolveMarket(exchangeCode)
+	if !ok || !market.IsForeign() {
+		return nil, fmt.Errorf("unknown foreign exchange %q", exchangeCode)
+	}
+
+	tickers, err := foreign.NewForeignStockTickerService(s.apiClient).GetAllForeignStockTickers(market.Exchange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign stock tickers: %w", err)
+	}
+
+	run, err := NewTickerSyncService(s.db).Sync(market.Code, tickers)
+	if err != nil {
+		return run, err
+	}
+
+	log.Printf("Synced foreign stocks for exchange %s: %d added, %d updated, %d unchanged, %d deactivated",
+		market.Name, run.Added, run.Updated, run.Unchanged, run.Deactivated)
+	return run, nil
+}
+
 // 정기 수집 작업 시작
 func (s *DataCollectorService) StartScheduledCollection() {
 	log.Println("Starting scheduled data collection...")
@@ -294,8 +501,11 @@ func (s *DataCollectorService) StartScheduledCollection() {
 		log.Printf("Failed to initialize major stocks: %v", err)
 	}
 
+	// 종목 등록 후 해외 종목 목록 동기화, 업종/시가총액 보강
+	s.SyncAllForeignStocks()
+
 	// 즉시 한 번 수집
-	if err := s.CollectAllStocks(); err != nil {
+	if _, err := s.CollectAllStocks(); err != nil {
 		log.Printf("Initial data collection failed: %v", err)
 	}
 
@@ -305,12 +515,25 @@ func (s *DataCollectorService) StartScheduledCollection() {
 		for {
 			select {
 			case <-ticker.C:
-				if err := s.CollectAllStocks(); err != nil {
+				if _, err := s.CollectAllStocks(); err != nil {
 					log.Printf("Scheduled data collection failed: %v", err)
 				}
 			}
 		}
 	}()
+
+	// 해외 종목 목록 동기화와 메타데이터 보강 (하루 한 번)
+	syncTicker := time.NewTicker(foreignStockSyncInterval)
+	go func() {
+		for range syncTicker.C {
+			s.SyncAllForeignStocks()
+		}
+	}()
+}
+
+// APIClient 수집기가 사용하는 API 클라이언트 (rate limiter 공유용)
+func (s *DataCollectorService) APIClient() *client.DBSecClient {
+	return s.apiClient
 }
 
 // API 상태 확인
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// DataSource 가격/차트 응답을 읽어 올 곳 (?source=)
+type DataSource string
+
+const (
+	SourceAuto  DataSource = "auto"  // 캐시 → DB → API 순서로 읽고 읽은 값은 캐시에 저장 (read-through)
+	SourceLive  DataSource = "live"  // 항상 API 호출
+	SourceDB    DataSource = "db"    // 저장된 데이터만
+	SourceCache DataSource = "cache" // 캐시만 (없으면 빈 응답)
+)
+
+// ErrSourceMiss 요청한 곳에 데이터가 없음
+var ErrSourceMiss = errors.New("data not found in source")
+
+// ParseDataSource 설정/쿼리 문자열을 데이터 출처로 변환 (빈 값이면 auto)
+func ParseDataSource(value string) (DataSource, error) {
+	switch source := DataSource(strings.ToLower(strings.TrimSpace(value))); source {
+	case "":
+		return SourceAuto, nil
+	case SourceAuto, SourceLive, SourceDB, SourceCache:
+		return source, nil
+	default:
+		return "", fmt.Errorf("invalid source %q, expected live, db, cache or auto", value)
+	}
+}
+
+// CachedPrices 가격 북과 Redis 캐시에서 최신 가격을 읽는다 (둘 다 없으면 ErrSourceMiss)
+type CachedPrices struct {
+	book  *PriceBook
+	cache *CacheService
+}
+
+// NewCachedPrices 가격 북을 먼저, 없으면 캐시를 본다 (둘 중 nil 인 쪽은 건너뜀)
+func NewCachedPrices(book *PriceBook, cache *CacheService) *CachedPrices {
+	return &CachedPrices{book: book, cache: cache}
+}
+
+// LatestPrice 캐시된 최신 가격
+func (p *CachedPrices) LatestPrice(_ context.Context, symbol string) (models.StockPrice, error) {
+	if p.book != nil {
+		if price, ok := p.book.Latest(symbol); ok {
+			return price, nil
+		}
+	}
+	if p.cache != nil {
+		if price, err := p.cache.GetStockPrice(symbol); err == nil {
+			return *price, nil
+		}
+	}
+	return models.StockPrice{}, ErrSourceMiss
+}
+
+// StorePrice DB/API 에서 읽은 가격을 다음 요청을 위해 캐시에 저장
+func (p *CachedPrices) StorePrice(price models.StockPrice) {
+	if p.cache != nil {
+		p.cache.SetStockPrice(price.Symbol, &price)
+	}
+}
+
+// StoredPrices DB 에 저장된 가격/일봉을 읽는다
+type StoredPrices struct {
+	db *gorm.DB
+}
+
+func NewStoredPrices(db *gorm.DB) *StoredPrices {
+	return &StoredPrices{db: db}
+}
+
+// LatestPrice 가장 최근에 저장된 가격 (없으면 ErrSourceMiss)
+func (p *StoredPrices) LatestPrice(ctx context.Context, symbol string) (models.StockPrice, error) {
+	var price models.StockPrice
+	err := p.db.WithContext(ctx).
+		Where("symbol = ? AND granularity IN ?", symbol, models.PriceSeriesGranularities).
+		Order("timestamp desc").
+		First(&price).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return price, ErrSourceMiss
+	}
+	return price, err
+}
+
+// StoredDayChart 저장된 일봉을 차트 API 와 같은 형식(최신순)으로 최대 days 개 (없으면 ErrSourceMiss)
+// 저장된 일봉은 수정주가가 아니므로 IsAdjusted 는 false 이다.
+func (p *StoredPrices) StoredDayChart(ctx context.Context, symbol, market string, days int) ([]apimodels.ForeignDayChartData, error) {
+	var prices []models.StockPrice
+	if err := p.db.WithContext(ctx).
+		Where("symbol = ? AND granularity = ?", symbol, models.GranularityDaily).
+		Order("timestamp desc").
+		Limit(days).
+		Find(&prices).Error; err != nil {
+		return nil, err
+	}
+	if len(prices) == 0 {
+		return nil, ErrSourceMiss
+	}
+
+	data := make([]apimodels.ForeignDayChartData, len(prices))
+	for i, price := range prices {
+		data[i] = apimodels.ForeignDayChartData{
+			StockCode:   price.Symbol,
+			Date:        price.Timestamp.Format("2006-01-02"),
+			Open:        price.OpenPrice,
+			High:        price.HighPrice,
+			Low:         price.LowPrice,
+			Close:       price.ClosePrice,
+			Volume:      price.Volume,
+			Market:      market,
+			PriceChange: price.Change,
+			ChangeRate:  price.ChangeRate,
+		}
+	}
+	return data, nil
+}
+
+// ChartCacheKey 차트 조회 조건별 캐시 키
+func ChartCacheKey(period, exchange, symbol string, count int, adjusted bool) string {
+	return fmt.Sprintf("chart:%s:%s:%s:%d:%t", period, exchange, symbol, count, adjusted)
+}
@@ -1,12 +1,15 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	apimodels "stock-recommender/backend/openapi/models"
 	"time"
 )
 
@@ -14,7 +17,7 @@ type DBSecAPIClient struct {
 	apiKey     string
 	baseURL    string
 	client     *http.Client
-	rateLimiter chan struct{}
+	rateLimiter *client.TokenBucket
 }
 
 // DB증권 API 응답 구조체
@@ -31,33 +34,28 @@ type DBSecResponse struct {
 }
 
 func NewDBSecAPIClient(cfg *config.Config) *DBSecAPIClient {
-	// Rate limiter: 초당 10 요청으로 제한
-	rateLimiter := make(chan struct{}, 10)
-	go func() {
-		for {
-			time.Sleep(100 * time.Millisecond) // 100ms마다 1개 허용
-			select {
-			case rateLimiter <- struct{}{}:
-			default:
-			}
-		}
-	}()
-
 	return &DBSecAPIClient{
 		apiKey:      cfg.API.DBSecAPIKey,
 		baseURL:     "https://openapi.dbsec.co.kr/v1",
 		client:      &http.Client{Timeout: 30 * time.Second},
-		rateLimiter: rateLimiter,
+		rateLimiter: client.NewTokenBucket(10, 10), // 초당 10 요청으로 제한
 	}
 }
 
 func (c *DBSecAPIClient) FetchStockPrice(symbol string, market string) (*models.StockPrice, error) {
+	return c.FetchStockPriceContext(context.Background(), symbol, market)
+}
+
+// FetchStockPriceContext ctx 가 취소되면 대기 중이거나 진행 중인 요청을 중단하는 FetchStockPrice
+func (c *DBSecAPIClient) FetchStockPriceContext(ctx context.Context, symbol string, market string) (*models.StockPrice, error) {
 	// Rate limiting
-	<-c.rateLimiter
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("request cancelled: %w", err)
+	}
 
 	url := fmt.Sprintf("%s/quote/%s", c.baseURL, symbol)
 	
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -105,7 +103,9 @@ func (c *DBSecAPIClient) FetchStockPrice(symbol string, market string) (*models.
 
 func (c *DBSecAPIClient) FetchHistoricalData(symbol string, market string, days int) ([]*models.StockPrice, error) {
 	// Rate limiting
-	<-c.rateLimiter
+	if err := c.rateLimiter.Wait(context.Background()); err != nil {
+		return nil, fmt.Errorf("request cancelled: %w", err)
+	}
 
 	url := fmt.Sprintf("%s/history/%s?days=%d", c.baseURL, symbol, days)
 	
@@ -160,7 +160,7 @@ func (c *DBSecAPIClient) FetchHistoricalData(symbol string, market string, days
 // Mock 데이터 생성 (개발용)
 func (c *DBSecAPIClient) GenerateMockData(symbol string, market string) *models.StockPrice {
 	basePrice := 100000.0
-	if market == "US" {
+	if apimodels.DefaultMarketResolver.Region(market) == apimodels.RegionUS {
 		basePrice = 150.0
 	}
 
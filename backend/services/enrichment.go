@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+)
+
+// StockMetadataFetcher 종목 업종/시가총액/PER/PBR 조회
+type StockMetadataFetcher interface {
+	GetStockMetadata(symbol, market string) (*apimodels.ParsedStockMetadata, error)
+}
+
+// EnrichmentService 종목 메타데이터(업종/시가총액/PER/PBR) 보강
+type EnrichmentService struct {
+	db        *gorm.DB
+	fetcher   StockMetadataFetcher
+	exchanges *foreign.ExchangeCache
+}
+
+func NewEnrichmentService(db *gorm.DB, fetcher StockMetadataFetcher) *EnrichmentService {
+	return &EnrichmentService{
+		db:        db,
+		fetcher:   fetcher,
+		exchanges: foreign.DefaultExchangeCache,
+	}
+}
+
+// WithExchangeCache 거래소를 모르는 미국 종목의 거래소를 찾을 캐시 교체 (기본: DefaultExchangeCache)
+func (s *EnrichmentService) WithExchangeCache(exchanges *foreign.ExchangeCache) *EnrichmentService {
+	s.exchanges = exchanges
+	return s
+}
+
+// EnrichStocks 활성 종목의 메타데이터를 현재가 응답으로 보강
+// PER/PBR 은 매번 갱신하며, 해외 종목의 업종은 SyncAllForeignStocks 가 보강 전에 종목 목록과 함께 채운다.
+func (s *EnrichmentService) EnrichStocks() (int, error) {
+	var stocks []models.Stock
+	err := s.db.Where("is_active = ?", true).Find(&stocks).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch stocks to enrich: %w", err)
+	}
+
+	enriched := 0
+	for i := range stocks {
+		if err := s.EnrichStock(&stocks[i]); err != nil {
+			log.Printf("Failed to enrich stock %s: %v", stocks[i].Symbol, err)
+			continue
+		}
+		enriched++
+	}
+
+	log.Printf("Enriched metadata for %d/%d stocks", enriched, len(stocks))
+	return enriched, nil
+}
+
+// EnrichStock 종목 하나의 메타데이터 갱신 (응답에 없는 값은 기존 값 유지)
+func (s *EnrichmentService) EnrichStock(stock *models.Stock) error {
+	metadata, err := s.fetcher.GetStockMetadata(stock.Symbol, s.metadataMarket(stock))
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{}
+	if metadata.Sector != "" {
+		updates["sector"] = metadata.Sector
+	}
+	if metadata.MarketCap > 0 {
+		updates["market_cap"] = metadata.MarketCap
+	}
+	// 적자 기업은 PER 이 0 이하로 내려오므로 0 만 값 없음으로 본다
+	if metadata.PER != 0 {
+		updates["per"] = metadata.PER
+	}
+	if metadata.PBR != 0 {
+		updates["pbr"] = metadata.PBR
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return s.db.Model(stock).Updates(updates).Error
+}
+
+// metadataMarket 메타데이터를 조회할 시장 (미국 종목은 stocks 의 거래소, 없으면 거래소 캐시에서 찾은 거래소)
+// 어디에도 없으면 stocks.market 을 그대로 넘겨 기본 해외 시장으로 조회한다.
+func (s *EnrichmentService) metadataMarket(stock *models.Stock) string {
+	if stock.Market != apimodels.RegionUS {
+		return stock.Market
+	}
+	if resolved, ok := apimodels.ResolveMarket(stock.Exchange); ok && resolved.IsForeign() {
+		return resolved.Name
+	}
+	if s.exchanges != nil {
+		if marketDiv, ok := s.exchanges.Lookup(stock.Symbol); ok {
+			return marketDiv
+		}
+	}
+	return stock.Market
+}
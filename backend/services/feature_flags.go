@@ -0,0 +1,196 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 기능 플래그 (실험 중인 전략/지표/엔드포인트를 재배포 없이 켜고 끈다)
+const (
+	FlagOrderBookSignal = "signal_orderbook"    // 국내 종목 신호에 호가 잔량 불균형 투표 반영
+	FlagStochRSI        = "indicator_stoch_rsi" // StochRSI 계산
+	FlagBacktestAPI     = "api_backtest"        // 백테스트 비교 API
+)
+
+// defaultFeatureFlags 등록된 플래그와 기본값 (설정/DB 에 없으면 이 값을 쓴다)
+var defaultFeatureFlags = map[string]bool{
+	FlagOrderBookSignal: true,
+	FlagStochRSI:        true,
+	FlagBacktestAPI:     true,
+}
+
+// ErrUnknownFeatureFlag 등록되지 않은 플래그 이름
+var ErrUnknownFeatureFlag = errors.New("unknown feature flag")
+
+// featureFlagRefresh DB 설정을 다시 읽는 주기 (다른 프로세스에서 바꾼 값이 반영되기까지 걸리는 최대 시간)
+const featureFlagRefresh = 30 * time.Second
+
+// 플래그 값의 출처
+const (
+	FlagSourceDefault = "default"
+	FlagSourceConfig  = "config"
+	FlagSourceDB      = "db"
+)
+
+// FeatureFlagState 플래그의 현재 값과 출처
+type FeatureFlagState struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Default bool   `json:"default"`
+	Source  string `json:"source"` // default, config, db
+}
+
+// FeatureFlags 기능 플래그 저장소
+// 우선순위는 DB(feature_flags 테이블) > 환경 설정(FEATURE_FLAGS) > 기본값이다.
+// nil 이면 모든 플래그가 기본값이라 플래그를 연결하지 않은 서비스는 기존처럼 동작한다.
+type FeatureFlags struct {
+	db         *gorm.DB
+	configured map[string]bool
+
+	mu        sync.Mutex
+	overrides map[string]bool // DB 설정
+	loadedAt  time.Time
+}
+
+// NewFeatureFlags 환경 설정 값과 DB 설정을 쓰는 플래그 저장소 (db 가 nil 이면 환경 설정만 사용)
+// 등록되지 않은 이름의 설정은 경고를 남기고 무시한다.
+func NewFeatureFlags(db *gorm.DB, configured map[string]bool) *FeatureFlags {
+	flags := &FeatureFlags{db: db, configured: map[string]bool{}}
+	for name, enabled := range configured {
+		if _, ok := defaultFeatureFlags[name]; !ok {
+			log.Printf("Warning: unknown feature flag %q in config, ignoring", name)
+			continue
+		}
+		flags.configured[name] = enabled
+	}
+	return flags
+}
+
+// Enabled 플래그가 켜져 있는지 여부 (등록되지 않은 플래그는 꺼진 것으로 본다)
+func (f *FeatureFlags) Enabled(name string) bool {
+	return f.State(name).Enabled
+}
+
+// State 플래그의 현재 값과 출처
+func (f *FeatureFlags) State(name string) FeatureFlagState {
+	def := defaultFeatureFlags[name]
+	state := FeatureFlagState{Name: name, Enabled: def, Default: def, Source: FlagSourceDefault}
+	if f == nil {
+		return state
+	}
+
+	if enabled, ok := f.configured[name]; ok {
+		state.Enabled, state.Source = enabled, FlagSourceConfig
+	}
+	if enabled, ok := f.dbOverride(name); ok {
+		state.Enabled, state.Source = enabled, FlagSourceDB
+	}
+	return state
+}
+
+// List 등록된 모든 플래그의 상태 (이름순)
+func (f *FeatureFlags) List() []FeatureFlagState {
+	names := make([]string, 0, len(defaultFeatureFlags))
+	for name := range defaultFeatureFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	states := make([]FeatureFlagState, len(names))
+	for i, name := range names {
+		states[i] = f.State(name)
+	}
+	return states
+}
+
+// Set 플래그 값을 DB 에 저장 (환경 설정보다 우선)
+func (f *FeatureFlags) Set(name string, enabled bool) error {
+	if err := f.check(name); err != nil {
+		return err
+	}
+
+	flag := models.FeatureFlag{Name: name, Enabled: enabled, UpdatedAt: time.Now()}
+	err := f.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&flag).Error
+	if err != nil {
+		return fmt.Errorf("failed to save feature flag %s: %w", name, err)
+	}
+
+	f.mu.Lock()
+	if f.overrides != nil {
+		f.overrides[name] = enabled
+	}
+	f.mu.Unlock()
+	return nil
+}
+
+// Clear DB 에 저장한 플래그 값을 지워 환경 설정/기본값으로 되돌린다
+func (f *FeatureFlags) Clear(name string) error {
+	if err := f.check(name); err != nil {
+		return err
+	}
+
+	if err := f.db.Delete(&models.FeatureFlag{}, "name = ?", name).Error; err != nil {
+		return fmt.Errorf("failed to clear feature flag %s: %w", name, err)
+	}
+
+	f.mu.Lock()
+	delete(f.overrides, name)
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *FeatureFlags) check(name string) error {
+	if _, ok := defaultFeatureFlags[name]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownFeatureFlag, name)
+	}
+	if f == nil || f.db == nil {
+		return fmt.Errorf("feature flags are not backed by a database")
+	}
+	return nil
+}
+
+// dbOverride DB 에 저장된 플래그 값 (featureFlagRefresh 마다 다시 읽고, 읽지 못하면 직전 값을 유지)
+func (f *FeatureFlags) dbOverride(name string) (bool, bool) {
+	if f.db == nil {
+		return false, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.overrides == nil || time.Since(f.loadedAt) >= featureFlagRefresh {
+		f.reload()
+	}
+	enabled, ok := f.overrides[name]
+	return enabled, ok
+}
+
+// reload DB 설정 다시 읽기 (f.mu 를 잡고 호출)
+func (f *FeatureFlags) reload() {
+	f.loadedAt = time.Now()
+
+	var rows []models.FeatureFlag
+	if err := f.db.Find(&rows).Error; err != nil {
+		log.Printf("Warning: failed to load feature flags, using previous values: %v", err)
+		if f.overrides == nil {
+			f.overrides = map[string]bool{}
+		}
+		return
+	}
+
+	f.overrides = make(map[string]bool, len(rows))
+	for _, row := range rows {
+		f.overrides[row.Name] = row.Enabled
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"stock-recommender/backend/models"
+)
+
+// IndicatorCacheKey 입력 봉(시각, 종가와 고가/저가/거래량)과 지표 기간의 해시
+// 새 봉이 들어와 계산 창이 밀리면 키가 달라지므로 따로 무효화할 필요가 없다. prices 는 시간순이어야 한다.
+func IndicatorCacheKey(prices []models.StockPrice, params IndicatorParams) [sha256.Size]byte {
+	return indicatorCacheKey(prices, params, true)
+}
+
+// indicatorCacheKey 기능 플래그로 StochRSI 계산을 끈 결과는 다른 키로 보관
+func indicatorCacheKey(prices []models.StockPrice, params IndicatorParams, stochRSI bool) [sha256.Size]byte {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%+v|%t|", params, stochRSI)
+
+	var buf [40]byte
+	for _, price := range prices {
+		binary.LittleEndian.PutUint64(buf[0:], uint64(price.Timestamp.UnixNano()))
+		binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(price.ClosePrice))
+		binary.LittleEndian.PutUint64(buf[16:], math.Float64bits(price.HighPrice))
+		binary.LittleEndian.PutUint64(buf[24:], math.Float64bits(price.LowPrice))
+		binary.LittleEndian.PutUint64(buf[32:], uint64(price.Volume))
+		hash.Write(buf[:])
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], hash.Sum(nil))
+	return key
+}
+
+// IndicatorCacheStats 캐시 적중/실패 횟수
+type IndicatorCacheStats struct {
+	Size   int   `json:"size"`
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// IndicatorCache 같은 봉 묶음의 지표 계산 결과를 보관하는 LRU 캐시
+// 수집 주기 사이에 같은 50봉으로 반복되는 분석 요청이 다시 계산하지 않도록 한다.
+type IndicatorCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[[sha256.Size]byte]*list.Element
+	order   list.List // 앞쪽이 최근 사용
+	hits    int64
+	misses  int64
+}
+
+type indicatorCacheEntry struct {
+	key    [sha256.Size]byte
+	result IndicatorResult
+}
+
+// NewIndicatorCache 최대 size 개 결과를 보관하는 캐시 (size 가 0 이하면 nil, 즉 캐시 사용 안 함)
+func NewIndicatorCache(size int) *IndicatorCache {
+	if size <= 0 {
+		return nil
+	}
+	return &IndicatorCache{size: size, entries: make(map[[sha256.Size]byte]*list.Element)}
+}
+
+// Get 캐시된 결과의 복사본
+func (c *IndicatorCache) Get(key [sha256.Size]byte) (*IndicatorResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.order.MoveToFront(element)
+	result := element.Value.(*indicatorCacheEntry).result
+	return &result, true
+}
+
+// Put 결과 저장 (가득 차면 가장 오래 쓰지 않은 결과를 버린다)
+func (c *IndicatorCache) Put(key [sha256.Size]byte, result *IndicatorResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*indicatorCacheEntry).result = *result
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&indicatorCacheEntry{key: key, result: *result})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*indicatorCacheEntry).key)
+	}
+}
+
+// Stats 현재 보관 개수와 적중/실패 횟수
+func (c *IndicatorCache) Stats() IndicatorCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return IndicatorCacheStats{Size: c.order.Len(), Hits: c.hits, Misses: c.misses}
+}
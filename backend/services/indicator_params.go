@@ -0,0 +1,158 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// IndicatorParams 지표 계산 기간 설정
+// 종목 레코드의 indicator_config(JSON)에 같은 키로 일부만 지정하면 전역 기본값 위에 덮어쓴다.
+type IndicatorParams struct {
+	RSIPeriod       int     `json:"rsi_period,omitempty"`
+	MACDFast        int     `json:"macd_fast,omitempty"`
+	MACDSlow        int     `json:"macd_slow,omitempty"`
+	BollingerPeriod int     `json:"bollinger_period,omitempty"`
+	BollingerStdDev float64 `json:"bollinger_stddev,omitempty"`
+	StochasticK     int     `json:"stochastic_k,omitempty"`
+	StochasticD     int     `json:"stochastic_d,omitempty"`
+	StochRSIPeriod  int     `json:"stoch_rsi_period,omitempty"` // StochRSI 에서 최고/최저를 볼 RSI 개수
+	StochRSIK       int     `json:"stoch_rsi_k,omitempty"`
+	StochRSID       int     `json:"stoch_rsi_d,omitempty"`
+	WilliamsRPeriod int     `json:"williams_r_period,omitempty"`
+	ATRPeriod       int     `json:"atr_period,omitempty"`
+}
+
+// DefaultIndicatorParams 전역 기본 지표 기간
+var DefaultIndicatorParams = IndicatorParams{
+	RSIPeriod:       14,
+	MACDFast:        12,
+	MACDSlow:        26,
+	BollingerPeriod: 20,
+	BollingerStdDev: 2.0,
+	StochasticK:     14,
+	StochasticD:     3,
+	StochRSIPeriod:  14,
+	StochRSIK:       3,
+	StochRSID:       3,
+	WilliamsRPeriod: 14,
+	ATRPeriod:       14,
+}
+
+// Merge override 에서 양수로 지정된 값만 p 위에 덮어쓴 설정
+func (p IndicatorParams) Merge(override IndicatorParams) IndicatorParams {
+	merged := p
+	mergeInt(&merged.RSIPeriod, override.RSIPeriod)
+	mergeInt(&merged.MACDFast, override.MACDFast)
+	mergeInt(&merged.MACDSlow, override.MACDSlow)
+	mergeInt(&merged.BollingerPeriod, override.BollingerPeriod)
+	if override.BollingerStdDev > 0 {
+		merged.BollingerStdDev = override.BollingerStdDev
+	}
+	mergeInt(&merged.StochasticK, override.StochasticK)
+	mergeInt(&merged.StochasticD, override.StochasticD)
+	mergeInt(&merged.StochRSIPeriod, override.StochRSIPeriod)
+	mergeInt(&merged.StochRSIK, override.StochRSIK)
+	mergeInt(&merged.StochRSID, override.StochRSID)
+	mergeInt(&merged.WilliamsRPeriod, override.WilliamsRPeriod)
+	mergeInt(&merged.ATRPeriod, override.ATRPeriod)
+	return merged
+}
+
+func mergeInt(dst *int, value int) {
+	if value > 0 {
+		*dst = value
+	}
+}
+
+// periods 검증용 기간 값 목록 (JSON 키 이름)
+func (p IndicatorParams) periods() []struct {
+	name  string
+	value int
+} {
+	return []struct {
+		name  string
+		value int
+	}{
+		{"rsi_period", p.RSIPeriod},
+		{"macd_fast", p.MACDFast},
+		{"macd_slow", p.MACDSlow},
+		{"bollinger_period", p.BollingerPeriod},
+		{"stochastic_k", p.StochasticK},
+		{"stochastic_d", p.StochasticD},
+		{"stoch_rsi_period", p.StochRSIPeriod},
+		{"stoch_rsi_k", p.StochRSIK},
+		{"stoch_rsi_d", p.StochRSID},
+		{"williams_r_period", p.WilliamsRPeriod},
+		{"atr_period", p.ATRPeriod},
+	}
+}
+
+// Validate 계산에 쓸 수 있는 설정인지 확인 (모든 기간이 양수, MACD 단기 < 장기, 표준편차 배수는 양의 유한값)
+func (p IndicatorParams) Validate() error {
+	for _, period := range p.periods() {
+		if period.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", period.name, period.value)
+		}
+	}
+	if p.MACDFast >= p.MACDSlow {
+		return fmt.Errorf("macd_fast %d must be less than macd_slow %d", p.MACDFast, p.MACDSlow)
+	}
+	if math.IsNaN(p.BollingerStdDev) || math.IsInf(p.BollingerStdDev, 0) || p.BollingerStdDev <= 0 {
+		return fmt.Errorf("bollinger_stddev must be positive, got %v", p.BollingerStdDev)
+	}
+	return nil
+}
+
+// ParseIndicatorParams 종목별 지표 설정 JSON 파싱 (빈 값이면 덮어쓸 값 없음)
+// 오타나 잘못된 값으로 설정이 조용히 무시되지 않도록 알 수 없는 키와 음수 값은 에러로 처리한다.
+func ParseIndicatorParams(raw string) (IndicatorParams, error) {
+	var params IndicatorParams
+	if raw == "" || raw == "null" {
+		return params, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&params); err != nil {
+		return IndicatorParams{}, fmt.Errorf("invalid indicator config: %w", err)
+	}
+	for _, period := range params.periods() {
+		if period.value < 0 {
+			return IndicatorParams{}, fmt.Errorf("invalid indicator config: %s must be positive, got %d", period.name, period.value)
+		}
+	}
+	if params.BollingerStdDev < 0 {
+		return IndicatorParams{}, fmt.Errorf("invalid indicator config: bollinger_stddev must be positive, got %v", params.BollingerStdDev)
+	}
+	return params, nil
+}
+
+// SymbolIndicatorParams 종목 레코드의 지표 설정을 defaults 위에 덮어쓴 설정
+// 종목이 없거나 설정이 잘못되었으면(덮어쓴 결과가 Validate 를 통과하지 못하는 경우 포함) defaults 를 그대로 쓴다.
+func SymbolIndicatorParams(db *gorm.DB, symbol string, defaults IndicatorParams) IndicatorParams {
+	var stock models.Stock
+	err := db.Select("indicator_config").Where("symbol = ?", symbol).Limit(1).Find(&stock).Error
+	if err != nil {
+		log.Printf("Failed to load indicator config for %s: %v", symbol, err)
+		return defaults
+	}
+
+	override, err := ParseIndicatorParams(stock.IndicatorConfig)
+	if err != nil {
+		log.Printf("Ignoring indicator config for %s: %v", symbol, err)
+		return defaults
+	}
+	merged := defaults.Merge(override)
+	if err := merged.Validate(); err != nil {
+		log.Printf("Ignoring indicator config for %s: %v", symbol, err)
+		return defaults
+	}
+	return merged
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+
+	"stock-recommender/backend/config"
+)
+
+// 지표별 기본 응답 소수 자릿수 (0 ~ 100 범위 오실레이터는 2자리, OBV 는 정수)
+var defaultIndicatorDecimals = map[string]int{
+	"rsi":          2,
+	"stochastic_k": 2,
+	"stochastic_d": 2,
+	"stoch_rsi_k":  2,
+	"stoch_rsi_d":  2,
+	"williams_r":   2,
+	"obv":          0,
+}
+
+// IndicatorPrecision 지표 값을 응답에 내보낼 때의 소수 자릿수
+// 계산 결과와 DB 에 저장된 값은 전체 정밀도를 유지하고, 응답 직렬화 직전에만 반올림한다.
+type IndicatorPrecision struct {
+	defaultDecimals int
+	decimals        map[string]int
+}
+
+// NewIndicatorPrecision 설정값으로 생성 (DefaultDecimals 가 없으면 config.DefaultIndicatorDecimals 사용)
+// 설정의 지표별 자릿수는 기본 자릿수보다 우선한다.
+func NewIndicatorPrecision(cfg config.IndicatorConfig) IndicatorPrecision {
+	p := IndicatorPrecision{
+		defaultDecimals: config.DefaultIndicatorDecimals,
+		decimals:        make(map[string]int, len(defaultIndicatorDecimals)+len(cfg.Decimals)),
+	}
+	if cfg.DefaultDecimals != nil {
+		p.defaultDecimals = *cfg.DefaultDecimals
+	}
+	for name, places := range defaultIndicatorDecimals {
+		p.decimals[name] = places
+	}
+	for name, places := range cfg.Decimals {
+		p.decimals[strings.ToLower(name)] = places
+	}
+	return p
+}
+
+// Decimals 지표의 소수 자릿수 (음수이면 반올림하지 않음, 이름은 대소문자 구분 없음)
+func (p IndicatorPrecision) Decimals(name string) int {
+	if places, ok := p.decimals[strings.ToLower(name)]; ok {
+		return places
+	}
+	return p.defaultDecimals
+}
+
+// Round 지표 값을 해당 지표의 자릿수로 반올림
+func (p IndicatorPrecision) Round(name string, value float64) float64 {
+	places := p.Decimals(name)
+	if places < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+// RoundMap 지표 이름 → 값 맵을 반올림한 복사본 (원본은 바꾸지 않음)
+func (p IndicatorPrecision) RoundMap(values map[string]float64) map[string]float64 {
+	rounded := make(map[string]float64, len(values))
+	for name, value := range values {
+		rounded[name] = p.Round(name, value)
+	}
+	return rounded
+}
+
+// RoundJSON TechnicalIndicator.IndicatorValue 같은 JSON 객체의 숫자 필드를 반올림
+// 파싱할 수 없는 값은 그대로 돌려준다.
+func (p IndicatorPrecision) RoundJSON(name, raw string) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return raw
+	}
+	for key, value := range fields {
+		if f, ok := value.(float64); ok {
+			fields[key] = p.Round(name, f)
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+	return string(data)
+}
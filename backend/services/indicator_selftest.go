@@ -0,0 +1,113 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// selfTestTolerance 기준값과 계산값의 허용 오차 (상대 오차, 값이 1 보다 작으면 절대 오차)
+const selfTestTolerance = 1e-6
+
+// selfTestBars 기준 데이터셋의 봉 개수 (CalculateAll 최소 요구량 50 이상)
+const selfTestBars = 60
+
+// selfTestExpected 기준 데이터셋(selfTestPrices)에 대한 지표 기준값
+// 지표 계산식을 바꾸면 이 값도 함께 검토해서 갱신해야 한다.
+var selfTestExpected = map[string]float64{
+	"rsi":             36.3001376521,
+	"macd":            0.41675974734,
+	"macd_signal":     0.333407797872,
+	"macd_histogram":  0.083351949468,
+	"sma_20":          122.666347928,
+	"sma_50":          116.138789045,
+	"ema_12":          120.331377668,
+	"ema_26":          119.914617921,
+	"bollinger_upper": 131.786611215,
+	"bollinger_lower": 113.546084641,
+	"bollinger_mid":   122.666347928,
+	"stochastic_k":    66.2505493019,
+	"stochastic_d":    76.3753845113,
+	"stoch_rsi_k":     32.3633270247,
+	"stoch_rsi_d":     19.9782884242,
+	"williams_r":      -33.7494506981,
+	"atr":             3.97492978654,
+	"obv":             10922,
+}
+
+// selfTestOrder 응답에 표시할 지표 순서
+var selfTestOrder = []string{
+	"rsi", "macd", "macd_signal", "macd_histogram",
+	"sma_20", "sma_50", "ema_12", "ema_26",
+	"bollinger_upper", "bollinger_mid", "bollinger_lower",
+	"stochastic_k", "stochastic_d", "stoch_rsi_k", "stoch_rsi_d", "williams_r", "atr", "obv",
+}
+
+// IndicatorCheck 지표 하나의 자체 점검 결과
+type IndicatorCheck struct {
+	Name     string  `json:"name"`
+	Expected float64 `json:"expected"`
+	Actual   float64 `json:"actual"`
+	Passed   bool    `json:"passed"`
+}
+
+// IndicatorSelfTestReport 지표 계산 자체 점검 결과
+type IndicatorSelfTestReport struct {
+	Passed bool             `json:"passed"`
+	Checks []IndicatorCheck `json:"checks"`
+}
+
+// selfTestPrices 추세와 사인파를 섞은 결정적인 기준 일봉
+func selfTestPrices() []models.StockPrice {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := make([]models.StockPrice, selfTestBars)
+	for i := range prices {
+		x := float64(i)
+		closePrice := 100 + 0.5*x + 10*math.Sin(x/5)
+		prices[i] = models.StockPrice{
+			Symbol:     "SELFTEST",
+			OpenPrice:  closePrice - math.Cos(x/3),
+			HighPrice:  closePrice + 2 + math.Sin(x/2),
+			LowPrice:   closePrice - 2 - math.Cos(x/2),
+			ClosePrice: closePrice,
+			Volume:     int64(1000 + 37*i),
+			Timestamp:  start.AddDate(0, 0, i),
+		}
+	}
+	return prices
+}
+
+// RunIndicatorSelfTest 기준 데이터셋으로 calculate 를 실행해 지표별 기준값과 비교
+// 운영 환경에서 지표 계산식이 의도치 않게 바뀌었는지 확인하는 용도다.
+func RunIndicatorSelfTest(calculate func([]models.StockPrice) *IndicatorResult) *IndicatorSelfTestReport {
+	report := &IndicatorSelfTestReport{Passed: true}
+
+	var actual map[string]float64
+	if result := calculate(selfTestPrices()); result != nil {
+		actual = result.ToMap()
+	}
+
+	for _, name := range selfTestOrder {
+		expected := selfTestExpected[name]
+		value, ok := actual[name]
+		check := IndicatorCheck{
+			Name:     name,
+			Expected: expected,
+			Actual:   value,
+			Passed:   ok && withinTolerance(expected, value),
+		}
+		if !check.Passed {
+			report.Passed = false
+		}
+		report.Checks = append(report.Checks, check)
+	}
+	return report
+}
+
+func withinTolerance(expected, actual float64) bool {
+	if math.IsNaN(actual) || math.IsInf(actual, 0) {
+		return false
+	}
+	return math.Abs(expected-actual) <= selfTestTolerance*math.Max(1, math.Abs(expected))
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// IndicatorNames 계산할 수 있는 지표 이름 (정렬)
+func IndicatorNames() []string {
+	values := (&IndicatorResult{}).ToMap()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateSeriesNames 시계열로 요청한 지표 이름 검사 (모르는 이름이나 꺼진 지표면 에러)
+func (s *IndicatorService) ValidateSeriesNames(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("at least one indicator name is required")
+	}
+	known := (&IndicatorResult{}).ToMap()
+	for _, name := range names {
+		if _, ok := known[name]; !ok {
+			return fmt.Errorf("unknown indicator %q (%v)", name, IndicatorNames())
+		}
+		if (name == "stoch_rsi_k" || name == "stoch_rsi_d") && !s.features.Enabled(FlagStochRSI) {
+			return fmt.Errorf("indicator %q is disabled", name)
+		}
+	}
+	return nil
+}
+
+// IndicatorSeries 차트에 겹쳐 그릴 지표 시계열 (모든 시계열이 Timestamps 와 같은 길이로 정렬됨)
+type IndicatorSeries struct {
+	Timestamps []time.Time           `json:"timestamps"`
+	Series     map[string][]*float64 `json:"series"` // 지표 이름 → 봉별 값 (계산할 봉이 모자란 시점은 null)
+}
+
+// Series from 이후 봉마다 그 봉까지의 데이터로 계산한 지표 값
+// from 이전 봉은 앞부분 계산에만 쓰며, 봉마다 /indicators 와 같은 계산을 하므로 마지막 값은 최신 지표와 같다.
+func (s *IndicatorService) Series(prices []models.StockPrice, from time.Time, names []string, params IndicatorParams) *IndicatorSeries {
+	history := s.BatchSeries(prices, from, names, params)
+
+	series := &IndicatorSeries{Timestamps: history.Timestamps, Series: make(map[string][]*float64, len(names))}
+	for _, name := range names {
+		values := make([]*float64, len(history.Timestamps))
+		for i := history.Warmup; i < len(values); i++ {
+			values[i] = &history.Series[name][i]
+		}
+		series.Series[name] = values
+	}
+	return series
+}
+
+// IndicatorHistory 긴 기간 차트용 지표 시계열 (모든 시계열이 Timestamps 와 같은 길이로 정렬됨)
+type IndicatorHistory struct {
+	Timestamps []time.Time          `json:"timestamps"`
+	Series     map[string][]float64 `json:"series"` // 지표 이름 → 봉별 값 (계산할 봉이 모자란 앞부분은 0)
+	Warmup     int                  `json:"warmup"` // 앞에서부터 0 으로 채운 봉 수
+}
+
+// BatchSeries Series 와 같은 값을 봉 전체를 한 번 훑어 계산
+// EMA/SMA/OBV 는 앞 봉까지의 값을 이어서 갱신하고, 창을 쓰는 지표는 최근 창만 다시 보므로 몇 년치 봉도 한 번에 계산할 수 있다.
+func (s *IndicatorService) BatchSeries(prices []models.StockPrice, from time.Time, names []string, params IndicatorParams) *IndicatorHistory {
+	sorted := sortedBars(prices)
+	stochRSI := s.features.Enabled(FlagStochRSI)
+
+	closes := make([]float64, len(sorted))
+	highs := make([]float64, len(sorted))
+	lows := make([]float64, len(sorted))
+	for i, bar := range sorted {
+		closes[i] = bar.ClosePrice
+		highs[i] = bar.HighPrice
+		lows[i] = bar.LowPrice
+	}
+	rsi := RSISeries(closes, params.RSIPeriod)
+	stochRSIBars := params.StochRSIPeriod + params.StochRSIK + params.StochRSID - 2
+
+	history := &IndicatorHistory{Timestamps: []time.Time{}, Series: make(map[string][]float64, len(names))}
+	for _, name := range names {
+		history.Series[name] = []float64{}
+	}
+
+	emaFast, emaSlow := newRunningEMA(params.MACDFast), newRunningEMA(params.MACDSlow)
+	ema12, ema26 := newRunningEMA(12), newRunningEMA(26)
+	sma20, sma50 := newRunningSMA(20), newRunningSMA(50)
+	obv := 0.0
+
+	for i, bar := range sorted {
+		n := i + 1
+		emaFast.Add(closes[i])
+		emaSlow.Add(closes[i])
+		ema12.Add(closes[i])
+		ema26.Add(closes[i])
+		sma20.Add(closes[i])
+		sma50.Add(closes[i])
+		if i > 0 {
+			if closes[i] > closes[i-1] {
+				obv += float64(bar.Volume)
+			} else if closes[i] < closes[i-1] {
+				obv -= float64(bar.Volume)
+			}
+		}
+
+		if bar.Timestamp.Before(from) {
+			continue
+		}
+		history.Timestamps = append(history.Timestamps, bar.Timestamp)
+		if n < MinIndicatorBars {
+			history.Warmup++
+			for _, name := range names {
+				history.Series[name] = append(history.Series[name], 0)
+			}
+			continue
+		}
+
+		// calculate(sorted[:n]) 와 같은 규칙으로 봉 n 개 시점의 지표를 채운다
+		result := &IndicatorResult{RSI: 50.0}
+		if idx := n - 1 - params.RSIPeriod; rsi != nil && idx >= 0 {
+			result.RSI = rsi[idx]
+			if stochRSI {
+				result.StochRSIK, result.StochRSID = StochRSI(rsi[max(0, idx+1-stochRSIBars):idx+1], params.StochRSIPeriod, params.StochRSIK, params.StochRSID)
+			}
+		} else if stochRSI {
+			result.StochRSIK, result.StochRSID = 50.0, 50.0
+		}
+		if n >= params.MACDSlow {
+			result.MACD = emaFast.Value(n, closes[i]) - emaSlow.Value(n, closes[i])
+			result.MACDSignal = result.MACD * 0.8
+			result.MACDHistogram = result.MACD - result.MACDSignal
+		}
+		result.SMA20 = sma20.Value(n, closes[i])
+		result.SMA50 = sma50.Value(n, closes[i])
+		result.EMA12 = ema12.Value(n, closes[i])
+		result.EMA26 = ema26.Value(n, closes[i])
+		result.BollingerUpper, result.BollingerMid, result.BollingerLower =
+			s.calculateBollingerBands(closes[:n], params.BollingerPeriod, params.BollingerStdDev)
+		result.StochasticK, result.StochasticD =
+			s.calculateStochastic(highs[:n], lows[:n], closes[:n], params.StochasticK, params.StochasticD)
+		result.WilliamsR = s.calculateWilliamsR(highs[:n], lows[:n], closes[:n], params.WilliamsRPeriod)
+		atrStart := max(0, n-params.ATRPeriod-1)
+		result.ATR = s.calculateATR(highs[atrStart:n], lows[atrStart:n], closes[atrStart:n], params.ATRPeriod)
+		result.OBV = obv
+
+		values := result.ToMap()
+		for _, name := range names {
+			history.Series[name] = append(history.Series[name], values[name])
+		}
+	}
+	return history
+}
+
+// runningEMA 봉이 들어올 때마다 이어서 갱신하는 EMA (첫 종가에서 시작, calculateEMA 와 같은 값)
+type runningEMA struct {
+	period     int
+	multiplier float64
+	value      float64
+	started    bool
+}
+
+func newRunningEMA(period int) *runningEMA {
+	return &runningEMA{period: period, multiplier: 2.0 / float64(period+1)}
+}
+
+func (e *runningEMA) Add(close float64) {
+	if !e.started {
+		e.value, e.started = close, true
+		return
+	}
+	e.value = close*e.multiplier + e.value*(1-e.multiplier)
+}
+
+// Value 봉 n 개 시점의 EMA (봉이 period 개보다 적으면 calculateEMA 처럼 마지막 종가)
+func (e *runningEMA) Value(n int, close float64) float64 {
+	if n < e.period {
+		return close
+	}
+	return e.value
+}
+
+// runningSMA 최근 period 개 종가의 합을 이어서 갱신하는 SMA
+type runningSMA struct {
+	period int
+	window []float64
+	sum    float64
+}
+
+func newRunningSMA(period int) *runningSMA {
+	return &runningSMA{period: period}
+}
+
+func (m *runningSMA) Add(close float64) {
+	m.window = append(m.window, close)
+	m.sum += close
+	if len(m.window) > m.period {
+		m.sum -= m.window[0]
+		m.window = m.window[1:]
+	}
+}
+
+// Value 봉 n 개 시점의 SMA (봉이 period 개보다 적으면 calculateSMA 처럼 마지막 종가)
+func (m *runningSMA) Value(n int, close float64) float64 {
+	if n < m.period {
+		return close
+	}
+	return m.sum / float64(m.period)
+}
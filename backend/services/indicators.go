@@ -4,12 +4,97 @@ import (
 	"math"
 	"sort"
 	"stock-recommender/backend/models"
+	"time"
 )
 
-type IndicatorService struct{}
+// 봉 데이터 검사 기본값 (주말/연휴로 인한 휴장을 허용)
+const (
+	defaultMaxBarAge = 96 * time.Hour
+	defaultMaxBarGap = 96 * time.Hour
+)
+
+// MinIndicatorBars 지표를 계산하는 데 필요한 최소 봉 수
+const MinIndicatorBars = 50
+
+// BarStatus 지표 계산에 쓰일 봉 데이터 상태
+type BarStatus string
+
+const (
+	BarStatusOK         BarStatus = "ok"
+	BarStatusStale      BarStatus = "stale"      // 최신 봉이 너무 오래됨
+	BarStatusIncomplete BarStatus = "incomplete" // 봉 사이에 허용 범위를 넘는 공백이 있음
+)
+
+type IndicatorService struct {
+	maxBarAge time.Duration
+	maxBarGap time.Duration
+	params    IndicatorParams
+	cache     *IndicatorCache
+	features  *FeatureFlags
+}
 
 func NewIndicatorService() *IndicatorService {
-	return &IndicatorService{}
+	return &IndicatorService{
+		maxBarAge: defaultMaxBarAge,
+		maxBarGap: defaultMaxBarGap,
+		params:    DefaultIndicatorParams,
+	}
+}
+
+// WithParams 전역 지표 기간 설정 (종목별 설정이 없을 때 사용)
+func (s *IndicatorService) WithParams(params IndicatorParams) *IndicatorService {
+	s.params = params
+	return s
+}
+
+// WithCache 같은 봉 묶음의 계산 결과를 재사용할 캐시 설정 (nil 이면 매번 계산)
+func (s *IndicatorService) WithCache(cache *IndicatorCache) *IndicatorService {
+	s.cache = cache
+	return s
+}
+
+// WithFeatures 실험 중인 지표 계산 여부를 정할 기능 플래그 설정 (nil 이면 기본값)
+func (s *IndicatorService) WithFeatures(features *FeatureFlags) *IndicatorService {
+	s.features = features
+	return s
+}
+
+// Params 전역 지표 기간 설정
+func (s *IndicatorService) Params() IndicatorParams {
+	return s.params
+}
+
+// CheckBars 지표를 신뢰할 수 있을 만큼 봉 데이터가 최신이고 연속적인지 검사
+func (s *IndicatorService) CheckBars(prices []models.StockPrice) BarStatus {
+	return CheckBarCompleteness(prices, time.Now(), s.maxBarAge, s.maxBarGap)
+}
+
+// CheckBarCompleteness 최신 봉이 now 기준 maxAge 이내이고 인접 봉 간격이 maxGap 이하인지 검사
+// prices 의 정렬 순서는 상관없다.
+func CheckBarCompleteness(prices []models.StockPrice, now time.Time, maxAge, maxGap time.Duration) BarStatus {
+	if len(prices) == 0 {
+		return BarStatusIncomplete
+	}
+
+	timestamps := make([]time.Time, len(prices))
+	for i, price := range prices {
+		timestamps[i] = price.Timestamp
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i].Before(timestamps[j])
+	})
+
+	if now.Sub(timestamps[len(timestamps)-1]) > maxAge {
+		return BarStatusStale
+	}
+
+	for i := 1; i < len(timestamps); i++ {
+		if timestamps[i].Sub(timestamps[i-1]) > maxGap {
+			return BarStatusIncomplete
+		}
+	}
+
+	return BarStatusOK
 }
 
 // 기술지표 계산 결과
@@ -27,14 +112,45 @@ type IndicatorResult struct {
 	BollingerMid   float64 `json:"bollinger_mid"`
 	StochasticK    float64 `json:"stochastic_k"`
 	StochasticD    float64 `json:"stochastic_d"`
+	StochRSIK      float64 `json:"stoch_rsi_k"` // RSI 시계열에 적용한 Stochastic %K
+	StochRSID      float64 `json:"stoch_rsi_d"`
 	WilliamsR      float64 `json:"williams_r"`
 	ATR            float64 `json:"atr"`
 	OBV            float64 `json:"obv"`
 }
 
-// 모든 지표 계산
+// ToMap 지표명 → 값 맵으로 변환
+func (r *IndicatorResult) ToMap() map[string]float64 {
+	return map[string]float64{
+		"rsi":             r.RSI,
+		"macd":            r.MACD,
+		"macd_signal":     r.MACDSignal,
+		"macd_histogram":  r.MACDHistogram,
+		"sma_20":          r.SMA20,
+		"sma_50":          r.SMA50,
+		"ema_12":          r.EMA12,
+		"ema_26":          r.EMA26,
+		"bollinger_upper": r.BollingerUpper,
+		"bollinger_lower": r.BollingerLower,
+		"bollinger_mid":   r.BollingerMid,
+		"stochastic_k":    r.StochasticK,
+		"stochastic_d":    r.StochasticD,
+		"stoch_rsi_k":     r.StochRSIK,
+		"stoch_rsi_d":     r.StochRSID,
+		"williams_r":      r.WilliamsR,
+		"atr":             r.ATR,
+		"obv":             r.OBV,
+	}
+}
+
+// 모든 지표 계산 (전역 지표 기간 사용)
 func (s *IndicatorService) CalculateAll(prices []models.StockPrice) *IndicatorResult {
-	if len(prices) < 50 {
+	return s.CalculateAllWithParams(prices, s.params)
+}
+
+// CalculateAllWithParams 지정한 지표 기간으로 모든 지표 계산 (종목별 설정 적용 시 사용)
+func (s *IndicatorService) CalculateAllWithParams(prices []models.StockPrice, params IndicatorParams) *IndicatorResult {
+	if len(prices) < MinIndicatorBars {
 		return nil // 충분한 데이터가 없음
 	}
 
@@ -43,6 +159,21 @@ func (s *IndicatorService) CalculateAll(prices []models.StockPrice) *IndicatorRe
 		return prices[i].Timestamp.Before(prices[j].Timestamp)
 	})
 
+	stochRSI := s.features.Enabled(FlagStochRSI)
+	if s.cache == nil {
+		return s.calculate(prices, params, stochRSI)
+	}
+	key := indicatorCacheKey(prices, params, stochRSI)
+	if cached, ok := s.cache.Get(key); ok {
+		return cached
+	}
+	result := s.calculate(prices, params, stochRSI)
+	s.cache.Put(key, result)
+	return result
+}
+
+// calculate 시간순 prices 로 모든 지표 계산 (stochRSI 가 false 면 StochRSI 는 0)
+func (s *IndicatorService) calculate(prices []models.StockPrice, params IndicatorParams, stochRSI bool) *IndicatorResult {
 	result := &IndicatorResult{}
 
 	// 종가 배열 생성
@@ -59,8 +190,8 @@ func (s *IndicatorService) CalculateAll(prices []models.StockPrice) *IndicatorRe
 	}
 
 	// 각 지표 계산
-	result.RSI = s.calculateRSI(closes, 14)
-	macd, signal, histogram := s.calculateMACD(closes)
+	result.RSI = s.calculateRSI(closes, params.RSIPeriod)
+	macd, signal, histogram := s.calculateMACD(closes, params.MACDFast, params.MACDSlow)
 	result.MACD = macd
 	result.MACDSignal = signal
 	result.MACDHistogram = histogram
@@ -70,17 +201,22 @@ func (s *IndicatorService) CalculateAll(prices []models.StockPrice) *IndicatorRe
 	result.EMA12 = s.calculateEMA(closes, 12)
 	result.EMA26 = s.calculateEMA(closes, 26)
 
-	upper, mid, lower := s.calculateBollingerBands(closes, 20, 2.0)
+	upper, mid, lower := s.calculateBollingerBands(closes, params.BollingerPeriod, params.BollingerStdDev)
 	result.BollingerUpper = upper
 	result.BollingerMid = mid
 	result.BollingerLower = lower
 
-	k, d := s.calculateStochastic(highs, lows, closes, 14, 3)
+	k, d := s.calculateStochastic(highs, lows, closes, params.StochasticK, params.StochasticD)
 	result.StochasticK = k
 	result.StochasticD = d
 
-	result.WilliamsR = s.calculateWilliamsR(highs, lows, closes, 14)
-	result.ATR = s.calculateATR(highs, lows, closes, 14)
+	if stochRSI {
+		rsiSeries := RSISeries(closes, params.RSIPeriod)
+		result.StochRSIK, result.StochRSID = StochRSI(rsiSeries, params.StochRSIPeriod, params.StochRSIK, params.StochRSID)
+	}
+
+	result.WilliamsR = s.calculateWilliamsR(highs, lows, closes, params.WilliamsRPeriod)
+	result.ATR = s.calculateATR(highs, lows, closes, params.ATRPeriod)
 	result.OBV = s.calculateOBV(closes, volumes)
 
 	return result
@@ -88,51 +224,99 @@ func (s *IndicatorService) CalculateAll(prices []models.StockPrice) *IndicatorRe
 
 // RSI (Relative Strength Index) 계산
 func (s *IndicatorService) calculateRSI(closes []float64, period int) float64 {
-	if len(closes) < period+1 {
+	series := RSISeries(closes, period)
+	if len(series) == 0 {
 		return 50.0
 	}
+	return series[len(series)-1]
+}
 
-	gains := make([]float64, 0)
-	losses := make([]float64, 0)
+// RSISeries 종가 시계열의 RSI 시계열 (각 시점까지 최근 period 개 등락폭 평균으로 계산)
+// closes[period] 시점부터 계산하므로 길이는 len(closes)-period 이고, 데이터가 부족하면 nil 이다.
+func RSISeries(closes []float64, period int) []float64 {
+	if period <= 0 || len(closes) < period+1 {
+		return nil
+	}
 
+	gains := make([]float64, len(closes)-1)
+	losses := make([]float64, len(closes)-1)
 	for i := 1; i < len(closes); i++ {
 		change := closes[i] - closes[i-1]
 		if change > 0 {
-			gains = append(gains, change)
-			losses = append(losses, 0)
+			gains[i-1] = change
 		} else {
-			gains = append(gains, 0)
-			losses = append(losses, -change)
+			losses[i-1] = -change
 		}
 	}
 
-	if len(gains) < period {
-		return 50.0
+	series := make([]float64, 0, len(gains)-period+1)
+	for end := period; end <= len(gains); end++ {
+		avgGain := mean(gains[end-period : end])
+		avgLoss := mean(losses[end-period : end])
+		if avgLoss == 0 {
+			series = append(series, 100.0)
+			continue
+		}
+		rs := avgGain / avgLoss
+		series = append(series, 100-(100/(1+rs)))
+	}
+	return series
+}
+
+// StochRSI RSI 시계열에 Stochastic 을 적용한 %K, %D (0 ~ 100)
+// 최근 period 개 RSI 의 최고/최저 대비 위치를 kSmooth 개로 평균해 %K, %K 를 dSmooth 개로 평균해 %D 를 구한다.
+// RSI 가 구간 내내 같으면 위치를 50 으로 보고, 데이터가 부족하면 50, 50 을 돌려준다.
+func StochRSI(rsi []float64, period, kSmooth, dSmooth int) (float64, float64) {
+	if period <= 0 || kSmooth <= 0 || dSmooth <= 0 || len(rsi) < period+kSmooth+dSmooth-2 {
+		return 50.0, 50.0
 	}
 
-	// 초기 평균 계산
-	avgGain := s.average(gains[len(gains)-period:])
-	avgLoss := s.average(losses[len(losses)-period:])
+	raw := make([]float64, 0, len(rsi)-period+1)
+	for end := period; end <= len(rsi); end++ {
+		window := rsi[end-period : end]
+		lowest, highest := window[0], window[0]
+		for _, value := range window[1:] {
+			lowest = math.Min(lowest, value)
+			highest = math.Max(highest, value)
+		}
 
-	if avgLoss == 0 {
-		return 100.0
+		position := 50.0
+		if highest-lowest != 0 {
+			position = (window[len(window)-1] - lowest) / (highest - lowest) * 100
+		}
+		raw = append(raw, position)
 	}
 
-	rs := avgGain / avgLoss
-	rsi := 100 - (100 / (1 + rs))
+	k := make([]float64, 0, len(raw)-kSmooth+1)
+	for end := kSmooth; end <= len(raw); end++ {
+		k = append(k, mean(raw[end-kSmooth:end]))
+	}
+	d := mean(k[len(k)-dSmooth:])
+
+	return clampPercent(k[len(k)-1]), clampPercent(d)
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
 
-	return rsi
+func clampPercent(value float64) float64 {
+	return math.Max(0, math.Min(100, value))
 }
 
 // MACD 계산
-func (s *IndicatorService) calculateMACD(closes []float64) (float64, float64, float64) {
-	if len(closes) < 26 {
+func (s *IndicatorService) calculateMACD(closes []float64, fast, slow int) (float64, float64, float64) {
+	if len(closes) < slow {
 		return 0, 0, 0
 	}
 
-	ema12 := s.calculateEMA(closes, 12)
-	ema26 := s.calculateEMA(closes, 26)
-	macd := ema12 - ema26
+	emaFast := s.calculateEMA(closes, fast)
+	emaSlow := s.calculateEMA(closes, slow)
+	macd := emaFast - emaSlow
 
 	// MACD 히스토리 생성 (간단히 최근 9일 평균으로 시그널 계산)
 	signal := macd * 0.8 // 간단한 시그널 근사치
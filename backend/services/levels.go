@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// 가격 레벨 계산 설정
+const (
+	levelsLookback      = 365 * 24 * time.Hour // 52주
+	swingWindow         = 2                    // 스윙 고점/저점 판단시 좌우로 비교할 봉 수
+	levelsBollingerSpan = 20
+	levelsBollingerMult = 2.0
+)
+
+// 레벨 출처
+const (
+	LevelSourcePivot     = "pivot"
+	LevelSourceSwing     = "swing"
+	LevelSourceWeek52    = "52w"
+	LevelSourceBollinger = "bollinger"
+)
+
+// PriceLevel 차트에 그릴 기준 가격선
+type PriceLevel struct {
+	Name   string  `json:"name"`
+	Price  float64 `json:"price"`
+	Source string  `json:"source"`
+}
+
+// LevelsReport 종목의 기준 가격선 모음
+type LevelsReport struct {
+	Symbol            string       `json:"symbol"`
+	CurrentPrice      float64      `json:"current_price"`
+	AsOf              time.Time    `json:"as_of"` // 계산에 쓰인 마지막 봉 시각
+	Pivots            []PriceLevel `json:"pivots"`
+	SupportResistance []PriceLevel `json:"support_resistance"`
+	Week52            []PriceLevel `json:"week_52"`
+	Bollinger         []PriceLevel `json:"bollinger"`
+}
+
+// dailyBar 일자별 OHLC
+type dailyBar struct {
+	Date      time.Time
+	High      float64
+	Low       float64
+	Close     float64
+	Timestamp time.Time // 그날 마지막 봉 시각
+}
+
+// Levels 피벗, 스윙 지지/저항, 52주 고저, 볼린저 밴드를 한 번에 계산
+func (s *AnalyticsService) Levels(symbol string) (*LevelsReport, error) {
+	bars, err := s.dailyBars(symbol, time.Now().Add(-levelsLookback))
+	if err != nil {
+		return nil, err
+	}
+	if len(bars) == 0 {
+		return nil, fmt.Errorf("no price data for %s", symbol)
+	}
+
+	report := &LevelsReport{
+		Symbol:       symbol,
+		CurrentPrice: bars[len(bars)-1].Close,
+		AsOf:         bars[len(bars)-1].Timestamp,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		report.Pivots = pivotLevels(bars)
+	}()
+	go func() {
+		defer wg.Done()
+		report.SupportResistance = swingLevels(bars, swingWindow)
+	}()
+	go func() {
+		defer wg.Done()
+		report.Week52 = week52Levels(bars)
+	}()
+	go func() {
+		defer wg.Done()
+		report.Bollinger = bollingerLevels(bars)
+	}()
+	wg.Wait()
+
+	return report, nil
+}
+
+// pivotLevels 직전 거래일 OHLC 기준 클래식 피벗 (P, S1/S2, R1/R2)
+// 봉이 하나뿐이면 그 봉을 사용한다.
+func pivotLevels(bars []dailyBar) []PriceLevel {
+	prev := bars[len(bars)-1]
+	if len(bars) > 1 {
+		prev = bars[len(bars)-2]
+	}
+
+	pivot := (prev.High + prev.Low + prev.Close) / 3
+	rangeHL := prev.High - prev.Low
+
+	return []PriceLevel{
+		{Name: "S2", Price: pivot - rangeHL, Source: LevelSourcePivot},
+		{Name: "S1", Price: 2*pivot - prev.High, Source: LevelSourcePivot},
+		{Name: "P", Price: pivot, Source: LevelSourcePivot},
+		{Name: "R1", Price: 2*pivot - prev.Low, Source: LevelSourcePivot},
+		{Name: "R2", Price: pivot + rangeHL, Source: LevelSourcePivot},
+	}
+}
+
+// swingLevels 현재가 아래의 가장 가까운 스윙 저점(지지)과 위의 가장 가까운 스윙 고점(저항)
+// 좌우 window 개 봉보다 높은(낮은) 봉을 스윙 고점(저점)으로 본다.
+func swingLevels(bars []dailyBar, window int) []PriceLevel {
+	current := bars[len(bars)-1].Close
+
+	var support, resistance *float64
+	for i := window; i < len(bars)-window; i++ {
+		isHigh, isLow := true, true
+		for j := i - window; j <= i+window; j++ {
+			if j == i {
+				continue
+			}
+			if bars[j].High >= bars[i].High {
+				isHigh = false
+			}
+			if bars[j].Low <= bars[i].Low {
+				isLow = false
+			}
+		}
+
+		if high := bars[i].High; isHigh && high >= current && (resistance == nil || high < *resistance) {
+			resistance = &high
+		}
+		if low := bars[i].Low; isLow && low <= current && (support == nil || low > *support) {
+			support = &low
+		}
+	}
+
+	var levels []PriceLevel
+	if support != nil {
+		levels = append(levels, PriceLevel{Name: "support", Price: *support, Source: LevelSourceSwing})
+	}
+	if resistance != nil {
+		levels = append(levels, PriceLevel{Name: "resistance", Price: *resistance, Source: LevelSourceSwing})
+	}
+	return levels
+}
+
+// week52Levels 기간 내 최고가/최저가
+func week52Levels(bars []dailyBar) []PriceLevel {
+	high, low := bars[0].High, bars[0].Low
+	for _, bar := range bars[1:] {
+		if bar.High > high {
+			high = bar.High
+		}
+		if bar.Low < low {
+			low = bar.Low
+		}
+	}
+
+	return []PriceLevel{
+		{Name: "low_52w", Price: low, Source: LevelSourceWeek52},
+		{Name: "high_52w", Price: high, Source: LevelSourceWeek52},
+	}
+}
+
+// bollingerLevels 종가 기준 20일 볼린저 밴드
+func bollingerLevels(bars []dailyBar) []PriceLevel {
+	closes := make([]float64, len(bars))
+	for i, bar := range bars {
+		closes[i] = bar.Close
+	}
+
+	upper, mid, lower := NewIndicatorService().calculateBollingerBands(closes, levelsBollingerSpan, levelsBollingerMult)
+	return []PriceLevel{
+		{Name: "lower", Price: lower, Source: LevelSourceBollinger},
+		{Name: "middle", Price: mid, Source: LevelSourceBollinger},
+		{Name: "upper", Price: upper, Source: LevelSourceBollinger},
+	}
+}
+
+// dailyBars from 이후 종목의 일자별 OHLC (날짜 오름차순)
+// 같은 날짜의 여러 봉은 고가/저가를 합치고 마지막 종가를 사용한다.
+func (s *AnalyticsService) dailyBars(symbol string, from time.Time) ([]dailyBar, error) {
+	var prices []models.StockPrice
+	if err := s.db.Where("symbol = ? AND granularity IN ? AND timestamp >= ?", symbol, models.PriceSeriesGranularities, from).
+		Order("timestamp ASC").
+		Find(&prices).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch prices for %s: %w", symbol, err)
+	}
+
+	var bars []dailyBar
+	for _, price := range prices {
+		ts := price.Timestamp.UTC()
+		date := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
+
+		if n := len(bars); n > 0 && bars[n-1].Date.Equal(date) {
+			last := &bars[n-1]
+			if price.HighPrice > last.High {
+				last.High = price.HighPrice
+			}
+			if price.LowPrice < last.Low {
+				last.Low = price.LowPrice
+			}
+			last.Close = price.ClosePrice
+			last.Timestamp = price.Timestamp
+			continue
+		}
+		bars = append(bars, dailyBar{
+			Date:      date,
+			High:      price.HighPrice,
+			Low:       price.LowPrice,
+			Close:     price.ClosePrice,
+			Timestamp: price.Timestamp,
+		})
+	}
+	return bars, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"time"
+)
+
+// 종목별 Mock 기준가
+func mockBasePrice(symbol, market string) float64 {
+	switch symbol {
+	case "005930": // 삼성전자
+		return 70000.0
+	case "000660": // SK하이닉스
+		return 120000.0
+	case "AAPL":
+		return 180.0
+	case "TSLA":
+		return 250.0
+	}
+
+	if apimodels.DefaultMarketResolver.Region(market) == apimodels.RegionUS {
+		return 150.0
+	}
+	return 50000.0
+}
+
+// 종목 코드로부터 결정적인 시드 생성
+func mockSeed(symbol string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(symbol))
+	return int64(h.Sum64())
+}
+
+// GenerateMockHistory 종목별 Mock 일봉 히스토리 생성 (개발 및 테스트용)
+// 같은 종목/기간에 대해 항상 같은 결과를 반환하며, 주말은 건너뛴다.
+// 반환 값은 시간순(오래된 것부터)으로 정렬되어 있다.
+func GenerateMockHistory(symbol, market string, bars int, endDate time.Time) []models.StockPrice {
+	if bars <= 0 {
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(mockSeed(symbol)))
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	// 영업일 날짜 목록 (과거 → 현재)
+	dates := make([]time.Time, 0, bars)
+	for d := end; len(dates) < bars; d = d.AddDate(0, 0, -1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		dates = append(dates, d)
+	}
+	for i, j := 0, len(dates)-1; i < j; i, j = i+1, j-1 {
+		dates[i], dates[j] = dates[j], dates[i]
+	}
+
+	history := make([]models.StockPrice, 0, bars)
+	prevClose := mockBasePrice(symbol, market)
+
+	for _, date := range dates {
+		// 일간 변동 (-2% ~ +2%)
+		change := (rng.Float64()*4 - 2) / 100
+		open := prevClose * (1 + (rng.Float64()-0.5)/100)
+		closePrice := prevClose * (1 + change)
+		high := maxFloat(open, closePrice) * (1 + rng.Float64()/100)
+		low := minFloat(open, closePrice) * (1 - rng.Float64()/100)
+		volume := int64(100000 + rng.Intn(900000))
+
+		history = append(history, models.StockPrice{
+			Symbol:         symbol,
+			Market:         market,
+			OpenPrice:      open,
+			HighPrice:      high,
+			LowPrice:       low,
+			ClosePrice:     closePrice,
+			Volume:         volume,
+			TradeAmount:    int64(closePrice * float64(volume)),
+			PrevClosePrice: prevClose,
+			Change:         closePrice - prevClose,
+			ChangeRate:     (closePrice - prevClose) / prevClose * 100,
+			Granularity:    models.GranularityDaily,
+			Timestamp:      date,
+		})
+
+		prevClose = closePrice
+	}
+
+	return history
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// 등락률 순위 방향
+const (
+	MoversUp   = "up"   // 상승률 상위
+	MoversDown = "down" // 하락률 상위
+)
+
+// MoversFilter 등락률 순위 조건 (빈 값은 조건 없음)
+type MoversFilter struct {
+	Market    string // KR, US
+	Direction string // MoversUp, MoversDown
+	MinVolume int64  // 이 거래량 미만인 종목은 제외 (유동성 낮은 종목)
+	Limit     int
+}
+
+// Mover 최신 현재가 기준 등락 종목
+type Mover struct {
+	Symbol       string    `json:"symbol"`
+	Name         string    `json:"name"`
+	Market       string    `json:"market"`
+	CurrentPrice float64   `json:"current_price"`
+	Change       float64   `json:"change"`
+	ChangeRate   float64   `json:"change_rate"`
+	Volume       int64     `json:"volume"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// TopMovers 활성 종목의 최신 현재가 스냅샷을 등락률 순으로 조회
+// up 은 상승 종목만 상승률 내림차순, down 은 하락 종목만 하락률 내림차순으로 정렬한다.
+func (s *AnalyticsService) TopMovers(filter MoversFilter) ([]Mover, error) {
+	var order string
+	query := s.db.Table("stock_prices sp").
+		Select("sp.symbol, stocks.name, stocks.market, sp.close_price AS current_price, sp.change, sp.change_rate, sp.volume, sp.timestamp").
+		Joins("JOIN stocks ON stocks.symbol = sp.symbol AND stocks.is_active = ? AND stocks.deleted_at IS NULL", true).
+		Where("sp.granularity = ?", models.GranularityIntraday).
+		Where("sp.timestamp = (SELECT MAX(timestamp) FROM stock_prices latest WHERE latest.symbol = sp.symbol AND latest.granularity = ?)", models.GranularityIntraday)
+
+	switch filter.Direction {
+	case MoversUp, "":
+		query = query.Where("sp.change_rate > 0")
+		order = "sp.change_rate DESC, sp.symbol"
+	case MoversDown:
+		query = query.Where("sp.change_rate < 0")
+		order = "sp.change_rate ASC, sp.symbol"
+	default:
+		return nil, fmt.Errorf("invalid direction %q, expected up or down", filter.Direction)
+	}
+
+	if filter.Market != "" {
+		query = query.Where("stocks.market = ?", filter.Market)
+	}
+	if filter.MinVolume > 0 {
+		query = query.Where("sp.volume >= ?", filter.MinVolume)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var movers []Mover
+	if err := query.Order(order).Scan(&movers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get top movers: %w", err)
+	}
+	return movers, nil
+}
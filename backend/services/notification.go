@@ -0,0 +1,383 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// NotificationSeverity 알림 중요도
+type NotificationSeverity string
+
+const (
+	SeverityInfo     NotificationSeverity = "info"
+	SeverityWarning  NotificationSeverity = "warning"
+	SeverityCritical NotificationSeverity = "critical" // 방해 금지 시간에도 즉시 전달
+)
+
+// Notification 사용자에게 보낼 알림 (가격 알림, 매매 신호 등)
+type Notification struct {
+	UserID    string               `json:"user_id"`
+	Channel   string               `json:"channel,omitempty"` // 전달 채널 (push, email 등)
+	Title     string               `json:"title"`
+	Body      string               `json:"body"`
+	Details   []string             `json:"details,omitempty"` // 묶음 알림에 포함된 항목별 내용
+	Severity  NotificationSeverity `json:"severity"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// NotificationSubscription 신호 알림을 받을 사용자와 채널
+type NotificationSubscription struct {
+	UserID  string
+	Channel string
+}
+
+// Notifier 알림 전달 채널 (푸시, 메일, 웹훅 등)
+type Notifier interface {
+	Send(notification Notification) error
+}
+
+// LogNotifier 알림을 로그로만 남기는 기본 전달 채널
+type LogNotifier struct{}
+
+func (LogNotifier) Send(notification Notification) error {
+	log.Printf("Notification for %s [%s]: %s", notification.UserID, notification.Severity, notification.Title)
+	return nil
+}
+
+// QuietHours 사용자 시간대 기준 방해 금지 시간 (start 이상 end 미만, 자정을 넘는 구간 허용)
+type QuietHours struct {
+	start    int // 자정부터 분
+	end      int
+	location *time.Location
+}
+
+// NewQuietHours HH:MM 형식의 시작/종료 시각과 IANA 시간대(Asia/Seoul 등)로 방해 금지 시간 생성
+func NewQuietHours(start, end, timezone string) (QuietHours, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if startMinute == endMinute {
+		return QuietHours{}, fmt.Errorf("quiet hours start and end must differ")
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	return QuietHours{start: startMinute, end: endMinute, location: location}, nil
+}
+
+func parseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// Contains t 가 방해 금지 시간 안인지 여부
+func (q QuietHours) Contains(t time.Time) bool {
+	local := t.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+// EndAfter t 가 속한 방해 금지 시간이 끝나는 시각 (방해 금지 시간 밖이면 t)
+func (q QuietHours) EndAfter(t time.Time) time.Time {
+	if !q.Contains(t) {
+		return t
+	}
+	local := t.In(q.location)
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.end/60, q.end%60, 0, 0, q.location)
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// pendingNotification 방해 금지 시간이 끝날 때까지 보류한 알림
+type pendingNotification struct {
+	notification Notification
+	releaseAt    time.Time
+}
+
+// signalDigest 묶음 창 안에 들어온 사용자/채널별 신호
+type signalDigest struct {
+	subscription NotificationSubscription
+	openedAt     time.Time
+	signals      []models.TradingSignal
+}
+
+// NotificationService 사용자별 방해 금지 시간을 적용해 알림 전달
+// 방해 금지 시간에 들어온 critical 이 아닌 알림은 보류했다가 시간이 끝난 뒤 FlushDue 에서 한꺼번에 보낸다.
+// 묶음 창이 설정되어 있으면 창 안에 생성된 신호를 사용자/채널별 요약 알림 하나로 묶어 보낸다.
+type NotificationService struct {
+	mu            sync.Mutex
+	notifier      Notifier
+	quiet         map[string]QuietHours
+	pending       []pendingNotification
+	subscriptions []NotificationSubscription
+	digestWindow  time.Duration
+	digests       map[NotificationSubscription]*signalDigest
+}
+
+func NewNotificationService(notifier Notifier) *NotificationService {
+	if notifier == nil {
+		notifier = LogNotifier{}
+	}
+	return &NotificationService{
+		notifier: notifier,
+		quiet:    make(map[string]QuietHours),
+		digests:  make(map[NotificationSubscription]*signalDigest),
+	}
+}
+
+// WithDigestWindow 신호 알림 묶음 창 설정 (0 이면 신호마다 바로 알림)
+func (s *NotificationService) WithDigestWindow(window time.Duration) *NotificationService {
+	s.digestWindow = window
+	return s
+}
+
+// ParseNotificationSubscriptions "사용자:채널" 목록을 구독으로 변환 (채널을 생략하면 log)
+func ParseNotificationSubscriptions(entries []string) ([]NotificationSubscription, error) {
+	subscriptions := make([]NotificationSubscription, 0, len(entries))
+	for _, entry := range entries {
+		userID, channel, _ := strings.Cut(entry, ":")
+		userID, channel = strings.TrimSpace(userID), strings.TrimSpace(channel)
+		if userID == "" {
+			return nil, fmt.Errorf("invalid notification subscriber %q, expected user:channel", entry)
+		}
+		if channel == "" {
+			channel = "log"
+		}
+		subscriptions = append(subscriptions, NotificationSubscription{UserID: userID, Channel: channel})
+	}
+	return subscriptions, nil
+}
+
+// Subscribe 사용자가 채널로 신호 알림을 받도록 등록
+func (s *NotificationService) Subscribe(userID, channel string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription := NotificationSubscription{UserID: userID, Channel: channel}
+	for _, existing := range s.subscriptions {
+		if existing == subscription {
+			return
+		}
+	}
+	s.subscriptions = append(s.subscriptions, subscription)
+}
+
+// NotifySignal 구독자 전체에 신호 알림 (묶음 창이 있으면 창이 닫힐 때 FlushDue 에서 요약 알림으로 전달)
+func (s *NotificationService) NotifySignal(signal *models.TradingSignal, now time.Time) error {
+	s.mu.Lock()
+	subscriptions := append([]NotificationSubscription(nil), s.subscriptions...)
+	if s.digestWindow > 0 {
+		for _, subscription := range subscriptions {
+			digest, ok := s.digests[subscription]
+			if !ok {
+				digest = &signalDigest{subscription: subscription, openedAt: now}
+				s.digests[subscription] = digest
+			}
+			digest.signals = append(digest.signals, *signal)
+		}
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+
+	var firstErr error
+	for _, subscription := range subscriptions {
+		notification := signalNotification(subscription, []models.TradingSignal{*signal}, now)
+		if _, err := s.NotifyAt(notification, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// signalNotification 신호 목록을 알림 하나로 구성 (신호별 내용은 Details 에 담는다)
+func signalNotification(subscription NotificationSubscription, signals []models.TradingSignal, now time.Time) Notification {
+	details := make([]string, len(signals))
+	for i, signal := range signals {
+		details[i] = fmt.Sprintf("%s %s (strength %.2f, confidence %.2f)",
+			signal.SignalType, signal.Symbol, signal.Strength, signal.Confidence)
+	}
+
+	title := fmt.Sprintf("%s signal for %s", signals[0].SignalType, signals[0].Symbol)
+	if len(signals) > 1 {
+		title = fmt.Sprintf("%d new trading signals", len(signals))
+	}
+	return Notification{
+		UserID:    subscription.UserID,
+		Channel:   subscription.Channel,
+		Title:     title,
+		Details:   details,
+		Severity:  SeverityInfo,
+		CreatedAt: now,
+	}
+}
+
+// SetQuietHours 사용자의 방해 금지 시간 설정
+func (s *NotificationService) SetQuietHours(userID string, hours QuietHours) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quiet[userID] = hours
+}
+
+// ClearQuietHours 사용자의 방해 금지 시간 해제 (보류 중인 알림은 다음 FlushDue 에서 전달)
+func (s *NotificationService) ClearQuietHours(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.quiet, userID)
+	for i := range s.pending {
+		if s.pending[i].notification.UserID == userID {
+			s.pending[i].releaseAt = time.Time{}
+		}
+	}
+}
+
+// Notify 현재 시각 기준으로 알림 전달 또는 보류
+func (s *NotificationService) Notify(notification Notification) (bool, error) {
+	return s.NotifyAt(notification, time.Now())
+}
+
+// NotifyAt now 기준으로 알림을 즉시 보내거나 방해 금지 시간이 끝날 때까지 보류
+// 즉시 보냈으면 true, 보류했으면 false 를 돌려준다.
+func (s *NotificationService) NotifyAt(notification Notification, now time.Time) (bool, error) {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = now
+	}
+
+	s.mu.Lock()
+	hours, ok := s.quiet[notification.UserID]
+	if ok && notification.Severity != SeverityCritical && hours.Contains(now) {
+		s.pending = append(s.pending, pendingNotification{
+			notification: notification,
+			releaseAt:    hours.EndAfter(now),
+		})
+		s.mu.Unlock()
+		return false, nil
+	}
+	s.mu.Unlock()
+
+	if err := s.notifier.Send(notification); err != nil {
+		return false, fmt.Errorf("failed to send notification to %s: %w", notification.UserID, err)
+	}
+	return true, nil
+}
+
+// Pending 사용자에게 보류 중인 알림 (생성 순)
+func (s *NotificationService) Pending(userID string) []Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var notifications []Notification
+	for _, pending := range s.pending {
+		if pending.notification.UserID == userID {
+			notifications = append(notifications, pending.notification)
+		}
+	}
+	return notifications
+}
+
+// FlushDue 창이 닫힌 신호 묶음과 방해 금지 시간이 끝난 보류 알림을 생성 순으로 전달하고 보낸 개수 반환
+// 방해 금지 시간에 닫힌 묶음은 다시 보류되고, 전달에 실패한 알림은 다음 호출에서 재시도한다.
+func (s *NotificationService) FlushDue(now time.Time) (int, error) {
+	sent, firstErr := s.flushDigests(now)
+
+	s.mu.Lock()
+	var due, remaining []pendingNotification
+	for _, pending := range s.pending {
+		if pending.releaseAt.After(now) {
+			remaining = append(remaining, pending)
+		} else {
+			due = append(due, pending)
+		}
+	}
+	s.pending = remaining
+	s.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].notification.CreatedAt.Before(due[j].notification.CreatedAt)
+	})
+
+	var failed []pendingNotification
+	for _, pending := range due {
+		if err := s.notifier.Send(pending.notification); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to send notification to %s: %w", pending.notification.UserID, err)
+			}
+			failed = append(failed, pending)
+			continue
+		}
+		sent++
+	}
+
+	if len(failed) > 0 {
+		s.mu.Lock()
+		s.pending = append(s.pending, failed...)
+		s.mu.Unlock()
+	}
+	return sent, firstErr
+}
+
+// flushDigests 묶음 창이 닫힌 사용자/채널별 신호를 요약 알림 하나로 전달
+// 보내지 못한 묶음은 다시 넣어 두어 다음 FlushDue 에서 재시도한다.
+func (s *NotificationService) flushDigests(now time.Time) (int, error) {
+	s.mu.Lock()
+	var closed []*signalDigest
+	for subscription, digest := range s.digests {
+		if !digest.openedAt.Add(s.digestWindow).After(now) {
+			closed = append(closed, digest)
+			delete(s.digests, subscription)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(closed, func(i, j int) bool {
+		return closed[i].openedAt.Before(closed[j].openedAt)
+	})
+
+	sent := 0
+	var firstErr error
+	for _, digest := range closed {
+		delivered, err := s.NotifyAt(signalNotification(digest.subscription, digest.signals, now), now)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			s.requeueDigest(digest)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, firstErr
+}
+
+// requeueDigest 보내지 못한 묶음을 되돌린다 (그사이 같은 구독에 새 묶음이 열렸으면 앞에 합친다)
+func (s *NotificationService) requeueDigest(digest *signalDigest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.digests[digest.subscription]; ok {
+		digest.signals = append(digest.signals, current.signals...)
+	}
+	s.digests[digest.subscription] = digest
+}
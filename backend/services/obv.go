@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccumulateOBV state 에 마지막으로 반영한 봉 이후의 봉만 순서대로 더한 새 상태
+// 처음 반영하는 봉은 기준 종가로만 쓰고 OBV 는 0 에서 시작한다.
+// prices 의 정렬 순서는 상관없고, 이미 반영한 시각 이전의 봉은 무시한다.
+func AccumulateOBV(state models.OBVState, prices []models.StockPrice) models.OBVState {
+	bars := make([]models.StockPrice, len(prices))
+	copy(bars, prices)
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Timestamp.Before(bars[j].Timestamp)
+	})
+
+	for _, bar := range bars {
+		if !state.LastBarAt.IsZero() && !bar.Timestamp.After(state.LastBarAt) {
+			continue
+		}
+
+		if !state.LastBarAt.IsZero() {
+			switch {
+			case bar.ClosePrice > state.LastClose:
+				state.OBV += float64(bar.Volume)
+			case bar.ClosePrice < state.LastClose:
+				state.OBV -= float64(bar.Volume)
+			}
+		}
+		state.CumulativeVolume += bar.Volume
+		state.LastClose = bar.ClosePrice
+		state.LastBarAt = bar.Timestamp
+	}
+	return state
+}
+
+// OBVTracker 종목별 누적 OBV 저장소
+// 지표 계산은 최근 50봉 창만 보므로 OBV 절대값이 매번 달라진다. 누적 값을 따로 저장해 장기 추세를 비교할 수 있게 한다.
+type OBVTracker struct {
+	db *gorm.DB
+}
+
+func NewOBVTracker(db *gorm.DB) *OBVTracker {
+	return &OBVTracker{db: db}
+}
+
+// Get 종목의 누적 OBV (아직 없으면 gorm.ErrRecordNotFound)
+func (t *OBVTracker) Get(symbol string) (*models.OBVState, error) {
+	var state models.OBVState
+	if err := t.db.Where("symbol = ?", symbol).First(&state).Error; err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Update 새로 들어온 봉을 누적 OBV 에 반영해 저장
+// 같은 종목을 여러 워커가 동시에 갱신해도 봉이 두 번 반영되지 않도록 행을 잠근다.
+func (t *OBVTracker) Update(symbol string, prices []models.StockPrice) (*models.OBVState, error) {
+	var updated models.OBVState
+	err := t.db.Transaction(func(tx *gorm.DB) error {
+		state := models.OBVState{Symbol: symbol}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("symbol = ?", symbol).First(&state).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		updated = AccumulateOBV(state, prices)
+		return tx.Save(&updated).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update OBV for %s: %w", symbol, err)
+	}
+	return &updated, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// 호가 불균형 신호 기준
+const (
+	orderBookMaxAge             = 5 * time.Minute // 이보다 오래된 호가 스냅샷은 신호에 쓰지 않는다
+	orderBookImbalanceThreshold = 0.3
+)
+
+// OrderBookLevel 호가 단계별 가격/잔량
+type OrderBookLevel struct {
+	Price  float64 `json:"price"`
+	Volume int64   `json:"volume"`
+}
+
+// OrderBook 5단계 호가 스냅샷
+type OrderBook struct {
+	Symbol         string           `json:"symbol"`
+	Asks           []OrderBookLevel `json:"asks"` // 1호가부터
+	Bids           []OrderBookLevel `json:"bids"` // 1호가부터
+	TotalAskVolume int64            `json:"total_ask_volume"`
+	TotalBidVolume int64            `json:"total_bid_volume"`
+	Imbalance      float64          `json:"imbalance"` // (매수잔량-매도잔량)/(매수잔량+매도잔량), -1 ~ 1
+	Timestamp      time.Time        `json:"timestamp"`
+}
+
+// NewOrderBook 저장된 호가 데이터를 단계별 호가 스냅샷으로 변환
+func NewOrderBook(ap *models.AskingPrice) *OrderBook {
+	book := &OrderBook{
+		Symbol: ap.Symbol,
+		Asks: []OrderBookLevel{
+			{ap.AskPrice1, ap.AskVolume1},
+			{ap.AskPrice2, ap.AskVolume2},
+			{ap.AskPrice3, ap.AskVolume3},
+			{ap.AskPrice4, ap.AskVolume4},
+			{ap.AskPrice5, ap.AskVolume5},
+		},
+		Bids: []OrderBookLevel{
+			{ap.BidPrice1, ap.BidVolume1},
+			{ap.BidPrice2, ap.BidVolume2},
+			{ap.BidPrice3, ap.BidVolume3},
+			{ap.BidPrice4, ap.BidVolume4},
+			{ap.BidPrice5, ap.BidVolume5},
+		},
+		TotalAskVolume: ap.TotalAskVol,
+		TotalBidVolume: ap.TotalBidVol,
+		Timestamp:      ap.Timestamp,
+	}
+
+	if total := book.TotalAskVolume + book.TotalBidVolume; total > 0 {
+		book.Imbalance = float64(book.TotalBidVolume-book.TotalAskVolume) / float64(total)
+	}
+
+	return book
+}
+
+// IsFresh 호가 스냅샷이 신호에 쓸 만큼 최신인지 여부
+func (b *OrderBook) IsFresh(now time.Time) bool {
+	return now.Sub(b.Timestamp) <= orderBookMaxAge
+}
+
+// OrderBookVote 호가 잔량 불균형에 따른 단기 투표 (1: 매수 우위, -1: 매도 우위, 0: 없음)
+// 스냅샷이 없거나 오래되었으면 투표하지 않는다.
+func OrderBookVote(book *OrderBook, now time.Time) int {
+	if book == nil || !book.IsFresh(now) {
+		return 0
+	}
+	return imbalanceVote(book.Imbalance)
+}
+
+func imbalanceVote(imbalance float64) int {
+	switch {
+	case imbalance >= orderBookImbalanceThreshold:
+		return 1
+	case imbalance <= -orderBookImbalanceThreshold:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -3,110 +3,296 @@ package services
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// PartitionInterval is the time range covered by one stock_prices partition
+type PartitionInterval string
+
+const (
+	PartitionDaily   PartitionInterval = "daily"   // stock_prices_YYYY_MM_DD
+	PartitionWeekly  PartitionInterval = "weekly"  // stock_prices_YYYY_wWW (ISO week, starting Monday)
+	PartitionMonthly PartitionInterval = "monthly" // stock_prices_YYYY_MM (default)
+)
+
+// ParsePartitionInterval converts a config value into an interval (empty means monthly)
+func ParsePartitionInterval(value string) (PartitionInterval, error) {
+	switch interval := PartitionInterval(strings.ToLower(strings.TrimSpace(value))); interval {
+	case "":
+		return PartitionMonthly, nil
+	case PartitionDaily, PartitionWeekly, PartitionMonthly:
+		return interval, nil
+	default:
+		return "", fmt.Errorf("unknown partition interval %q (daily, weekly, monthly)", value)
+	}
+}
+
+// ahead is the number of future partitions created in advance (about two weeks to six months)
+func (i PartitionInterval) ahead() int {
+	switch i {
+	case PartitionDaily:
+		return 14
+	case PartitionWeekly:
+		return 8
+	default:
+		return 6
+	}
+}
+
+// Start returns the start (UTC midnight) of the partition containing t
+func (i PartitionInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case PartitionDaily:
+		return day
+	case PartitionWeekly:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// next returns the start of the partition following the one starting at start
+func (i PartitionInterval) next(start time.Time) time.Time {
+	switch i {
+	case PartitionDaily:
+		return start.AddDate(0, 0, 1)
+	case PartitionWeekly:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// name returns the table name of the partition starting at start
+func (i PartitionInterval) name(start time.Time) string {
+	switch i {
+	case PartitionDaily:
+		return start.Format("stock_prices_2006_01_02")
+	case PartitionWeekly:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("stock_prices_%04d_w%02d", year, week)
+	default:
+		return start.Format("stock_prices_2006_01")
+	}
+}
+
+// PartitionRange is one stock_prices partition covering [Start, End)
+type PartitionRange struct {
+	Name     string
+	Interval PartitionInterval
+	Start    time.Time
+	End      time.Time
+}
+
+// PartitionRanges returns count consecutive partitions starting with the one containing from
+func PartitionRanges(interval PartitionInterval, from time.Time, count int) []PartitionRange {
+	ranges := make([]PartitionRange, 0, count)
+	start := interval.Start(from)
+	for i := 0; i < count; i++ {
+		end := interval.next(start)
+		ranges = append(ranges, PartitionRange{
+			Name:     interval.name(start),
+			Interval: interval,
+			Start:    start,
+			End:      end,
+		})
+		start = end
+	}
+	return ranges
+}
+
+// PlanPartitions returns the partitions to create so that the count intervals
+// starting with the one containing from are covered without gaps or overlaps.
+// Ranges already covered by existing partitions are skipped whatever interval
+// they were created with. Where an interval partition would overlap an existing
+// one, or the existing partitions end between two interval boundaries (after
+// the interval was changed), the gap is filled with daily partitions up to the
+// next free boundary, since daily boundaries line up with every interval.
+func PlanPartitions(interval PartitionInterval, existing []PartitionRange, from time.Time, count int) []PartitionRange {
+	start := interval.Start(from)
+	end := start
+	for i := 0; i < count; i++ {
+		end = interval.next(end)
+	}
+
+	var planned []PartitionRange
+	for cursor := start; cursor.Before(end); {
+		if covering, ok := coveringPartition(existing, cursor); ok {
+			cursor = covering.End
+			continue
+		}
+
+		partition := PartitionRange{Name: interval.name(cursor), Interval: interval, Start: cursor, End: interval.next(cursor)}
+		if !interval.Start(cursor).Equal(cursor) || overlapsPartition(existing, partition) {
+			partition = PartitionRange{Name: PartitionDaily.name(cursor), Interval: PartitionDaily, Start: cursor, End: PartitionDaily.next(cursor)}
+		}
+		planned = append(planned, partition)
+		cursor = partition.End
+	}
+	return planned
+}
+
+// coveringPartition returns the partition whose range contains t
+func coveringPartition(partitions []PartitionRange, t time.Time) (PartitionRange, bool) {
+	for _, partition := range partitions {
+		if !t.Before(partition.Start) && t.Before(partition.End) {
+			return partition, true
+		}
+	}
+	return PartitionRange{}, false
+}
+
+// overlapsPartition reports whether any of partitions shares part of the range of target
+func overlapsPartition(partitions []PartitionRange, target PartitionRange) bool {
+	for _, partition := range partitions {
+		if partition.Start.Before(target.End) && target.Start.Before(partition.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePartitionName recovers the range of a partition from its table name.
+// Names of every interval are recognised so partitions created before the
+// interval was changed are still pruned.
+func ParsePartitionName(name string) (PartitionRange, bool) {
+	if start, err := time.Parse("stock_prices_2006_01_02", name); err == nil {
+		return PartitionRange{Name: name, Interval: PartitionDaily, Start: start, End: PartitionDaily.next(start)}, true
+	}
+	if start, err := time.Parse("stock_prices_2006_01", name); err == nil {
+		return PartitionRange{Name: name, Interval: PartitionMonthly, Start: start, End: PartitionMonthly.next(start)}, true
+	}
+
+	var year, week int
+	if _, err := fmt.Sscanf(name, "stock_prices_%4d_w%2d", &year, &week); err != nil || week < 1 || week > 53 {
+		return PartitionRange{}, false
+	}
+	// Monday of ISO week 1 is the Monday of the week containing January 4th
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	start := PartitionWeekly.Start(jan4).AddDate(0, 0, (week-1)*7)
+	if PartitionWeekly.name(start) != name {
+		return PartitionRange{}, false
+	}
+	return PartitionRange{Name: name, Interval: PartitionWeekly, Start: start, End: PartitionWeekly.next(start)}, true
+}
+
 type PartitionManager struct {
-	db *gorm.DB
+	db       *gorm.DB
+	interval PartitionInterval
 }
 
 func NewPartitionManager(db *gorm.DB) *PartitionManager {
-	return &PartitionManager{db: db}
+	return &PartitionManager{db: db, interval: PartitionMonthly}
 }
 
-// CreateMonthlyPartitions creates partitions for the next few months
-func (pm *PartitionManager) CreateMonthlyPartitions() error {
-	log.Println("Creating monthly partitions for stock_prices")
+// WithInterval sets the range covered by each new partition.
+// Changing the interval only affects ranges not yet covered: partitions
+// already created in advance are kept, and the range between their end and
+// the next boundary of the new interval is filled with daily partitions (see
+// PlanPartitions), so no timestamp is left without a partition.
+func (pm *PartitionManager) WithInterval(interval PartitionInterval) *PartitionManager {
+	pm.interval = interval
+	return pm
+}
+
+// CreatePartitions creates partitions for the current and upcoming intervals
+func (pm *PartitionManager) CreatePartitions() error {
+	log.Printf("Creating %s partitions for stock_prices", pm.interval)
+
+	names, err := pm.listPartitions()
+	if err != nil {
+		return fmt.Errorf("failed to get partition list: %w", err)
+	}
+	var existing []PartitionRange
+	for _, name := range names {
+		if partition, ok := ParsePartitionName(name); ok {
+			existing = append(existing, partition)
+		}
+	}
 
-	now := time.Now()
-	
-	// Create partitions for next 6 months
-	for i := 0; i < 6; i++ {
-		targetMonth := now.AddDate(0, i, 0)
-		err := pm.createPartitionForMonth(targetMonth)
+	for _, partition := range PlanPartitions(pm.interval, existing, time.Now(), pm.interval.ahead()) {
+		err := pm.createPartition(partition)
 		if err != nil {
-			log.Printf("Failed to create partition for %s: %v", targetMonth.Format("2006-01"), err)
+			log.Printf("Failed to create partition for %s: %v", partition.Start.Format("2006-01-02"), err)
 		}
 	}
 
 	return nil
 }
 
-func (pm *PartitionManager) createPartitionForMonth(targetMonth time.Time) error {
-	year := targetMonth.Year()
-	month := int(targetMonth.Month())
-	
-	// Calculate start and end dates
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.AddDate(0, 1, 0)
-	
-	partitionName := fmt.Sprintf("stock_prices_%04d_%02d", year, month)
-	
+func (pm *PartitionManager) createPartition(partition PartitionRange) error {
 	// Check if partition already exists
 	var count int64
 	err := pm.db.Raw(`
-		SELECT count(*) 
-		FROM information_schema.tables 
+		SELECT count(*)
+		FROM information_schema.tables
 		WHERE table_name = ? AND table_schema = current_schema()
-	`, partitionName).Scan(&count).Error
-	
+	`, partition.Name).Scan(&count).Error
+
 	if err != nil {
 		return fmt.Errorf("failed to check partition existence: %w", err)
 	}
-	
+
 	if count > 0 {
-		log.Printf("Partition %s already exists, skipping", partitionName)
+		log.Printf("Partition %s already exists, skipping", partition.Name)
 		return nil
 	}
-	
+
 	// Create partition
 	createSQL := fmt.Sprintf(`
 		CREATE TABLE IF NOT EXISTS %s PARTITION OF stock_prices
 		FOR VALUES FROM ('%s') TO ('%s')
-	`, partitionName, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
-	
+	`, partition.Name, partition.Start.Format("2006-01-02"), partition.End.Format("2006-01-02"))
+
 	err = pm.db.Exec(createSQL).Error
 	if err != nil {
-		return fmt.Errorf("failed to create partition %s: %w", partitionName, err)
+		return fmt.Errorf("failed to create partition %s: %w", partition.Name, err)
 	}
-	
-	log.Printf("Successfully created partition: %s", partitionName)
+
+	log.Printf("Successfully created partition: %s", partition.Name)
 	return nil
 }
 
-// CleanupOldPartitions removes partitions older than specified months
+// listPartitions returns the names of all stock_prices partition tables
+func (pm *PartitionManager) listPartitions() ([]string, error) {
+	var partitions []string
+	err := pm.db.Raw(`
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_name LIKE 'stock_prices_____%%'
+		AND table_schema = current_schema()
+		ORDER BY table_name
+	`).Scan(&partitions).Error
+	return partitions, err
+}
+
+// CleanupOldPartitions removes partitions older than specified months.
+// A partition is dropped only once its whole range ends before the cutoff,
+// whatever interval it was created with.
 func (pm *PartitionManager) CleanupOldPartitions(monthsToKeep int) error {
 	log.Printf("Cleaning up partitions older than %d months", monthsToKeep)
 
 	cutoffDate := time.Now().AddDate(0, -monthsToKeep, 0)
-	
+
 	// Get list of old partitions
-	var partitions []string
-	err := pm.db.Raw(`
-		SELECT table_name 
-		FROM information_schema.tables 
-		WHERE table_name LIKE 'stock_prices_____%%' 
-		AND table_schema = current_schema()
-	`).Scan(&partitions).Error
-	
+	partitions, err := pm.listPartitions()
 	if err != nil {
 		return fmt.Errorf("failed to get partition list: %w", err)
 	}
-	
+
 	for _, partition := range partitions {
-		// Extract date from partition name (stock_prices_YYYY_MM)
-		var year, month int
-		_, err := fmt.Sscanf(partition, "stock_prices_%d_%d", &year, &month)
-		if err != nil {
-			log.Printf("Failed to parse partition name %s: %v", partition, err)
+		partitionRange, ok := ParsePartitionName(partition)
+		if !ok {
+			log.Printf("Failed to parse partition name %s", partition)
 			continue
 		}
-		
-		partitionDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-		
-		if partitionDate.Before(cutoffDate) {
+
+		if !partitionRange.End.After(cutoffDate) {
 			err := pm.dropPartition(partition)
 			if err != nil {
 				log.Printf("Failed to drop partition %s: %v", partition, err)
@@ -115,7 +301,7 @@ func (pm *PartitionManager) CleanupOldPartitions(monthsToKeep int) error {
 			}
 		}
 	}
-	
+
 	return nil
 }
 
@@ -126,38 +312,43 @@ func (pm *PartitionManager) dropPartition(partitionName string) error {
 	if err != nil {
 		return fmt.Errorf("failed to detach partition: %w", err)
 	}
-	
+
 	// Then drop the table
 	dropSQL := fmt.Sprintf("DROP TABLE IF EXISTS %s", partitionName)
 	err = pm.db.Exec(dropSQL).Error
 	if err != nil {
 		return fmt.Errorf("failed to drop partition table: %w", err)
 	}
-	
+
 	return nil
 }
 
 // GetPartitionInfo returns information about existing partitions
 func (pm *PartitionManager) GetPartitionInfo() ([]PartitionInfo, error) {
+	names, err := pm.listPartitions()
+	if err != nil {
+		return nil, err
+	}
+
 	var partitions []PartitionInfo
-	
-	err := pm.db.Raw(`
-		SELECT 
-			t.table_name as name,
-			pg_size_pretty(pg_total_relation_size(t.table_name::regclass)) as size,
-			(SELECT count(*) FROM stock_prices WHERE 
-				timestamp >= (regexp_split_to_array(t.table_name, '_'))[3] || '-' || 
-				(regexp_split_to_array(t.table_name, '_'))[4] || '-01'::timestamp
-				AND timestamp < (regexp_split_to_array(t.table_name, '_'))[3] || '-' || 
-				(regexp_split_to_array(t.table_name, '_'))[4] || '-01'::timestamp + interval '1 month'
-			) as row_count
-		FROM information_schema.tables t
-		WHERE t.table_name LIKE 'stock_prices_____%%'
-		AND t.table_schema = current_schema()
-		ORDER BY t.table_name
-	`).Scan(&partitions).Error
-	
-	return partitions, err
+	for _, name := range names {
+		if _, ok := ParsePartitionName(name); !ok {
+			continue
+		}
+
+		info := PartitionInfo{Name: name}
+		err := pm.db.Raw(fmt.Sprintf(`
+			SELECT
+				pg_size_pretty(pg_total_relation_size('%s'::regclass)) as size,
+				(SELECT count(*) FROM %s) as row_count
+		`, name, name)).Scan(&info).Error
+		if err != nil {
+			return partitions, err
+		}
+		partitions = append(partitions, info)
+	}
+
+	return partitions, nil
 }
 
 type PartitionInfo struct {
@@ -169,18 +360,18 @@ type PartitionInfo struct {
 // ScheduledMaintenance runs partition maintenance tasks
 func (pm *PartitionManager) ScheduledMaintenance() {
 	log.Println("Running scheduled partition maintenance")
-	
+
 	// Create future partitions
-	err := pm.CreateMonthlyPartitions()
+	err := pm.CreatePartitions()
 	if err != nil {
 		log.Printf("Error creating partitions: %v", err)
 	}
-	
+
 	// Cleanup old partitions (keep 24 months)
 	err = pm.CleanupOldPartitions(24)
 	if err != nil {
 		log.Printf("Error cleaning up partitions: %v", err)
 	}
-	
+
 	log.Println("Partition maintenance completed")
-}
\ No newline at end of file
+}
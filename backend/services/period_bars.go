@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// 주/월봉 갱신 시 다시 받는 최근 봉 수 (직전 봉이 뒤늦게 정정되어도 덮어쓰도록 여유를 둔다)
+const (
+	periodRefreshWeeks  = 4
+	periodRefreshMonths = 3
+)
+
+// PeriodBarService 주봉/월봉을 받아 stock_prices 에 저장 (장기 추세 분석용)
+// 해외주식 주/월차트 API 만 있으므로 US 종목만 지원하며, 저장된 일봉과 같게 수정주가가 아닌 값을 저장한다.
+type PeriodBarService struct {
+	db    *gorm.DB
+	week  *foreign.ForeignWeekChartService
+	month *foreign.ForeignMonthChartService
+}
+
+func NewPeriodBarService(db *gorm.DB, apiClient *client.DBSecClient) *PeriodBarService {
+	return &PeriodBarService{
+		db:    db,
+		week:  foreign.NewForeignWeekChartService(apiClient),
+		month: foreign.NewForeignMonthChartService(apiClient),
+	}
+}
+
+// CollectPeriodBars 종목의 최근 주봉(models.GranularityWeekly) 또는 월봉(models.GranularityMonthly)을 받아 저장
+func (s *PeriodBarService) CollectPeriodBars(ctx context.Context, stock models.Stock, granularity string) error {
+	if stock.Market != apimodels.RegionUS {
+		return fmt.Errorf("%s bars are not supported for market %s", granularity, stock.Market)
+	}
+
+	var bars []models.StockPrice
+	switch granularity {
+	case models.GranularityWeekly:
+		data, err := s.week.GetWeekChartWithWeeksContext(ctx, stock.Symbol, stock.Exchange, periodRefreshWeeks, false)
+		if err != nil {
+			return fmt.Errorf("failed to get week chart: %w", err)
+		}
+		for _, week := range data {
+			bars = append(bars, periodBar(stock, granularity, week.WeekEndDate, week.Open, week.High, week.Low, week.Close, week.Volume, week.PriceChange, week.ChangeRate))
+		}
+	case models.GranularityMonthly:
+		data, err := s.month.GetMonthChartWithMonthsContext(ctx, stock.Symbol, stock.Exchange, periodRefreshMonths, false)
+		if err != nil {
+			return fmt.Errorf("failed to get month chart: %w", err)
+		}
+		for _, month := range data {
+			bars = append(bars, periodBar(stock, granularity, month.MonthEndDate, month.Open, month.High, month.Low, month.Close, month.Volume, month.PriceChange, month.ChangeRate))
+		}
+	default:
+		return fmt.Errorf("unsupported period granularity %q", granularity)
+	}
+
+	return s.savePeriodBars(stock.Symbol, bars)
+}
+
+// periodBar 차트 한 봉을 StockPrice 로 변환 (기간 마지막 날짜를 시각으로 쓴다)
+func periodBar(stock models.Stock, granularity, endDate string, open, high, low, closePrice float64, volume int64, change, changeRate float64) models.StockPrice {
+	timestamp, _ := time.Parse("2006-01-02", endDate)
+	return models.StockPrice{
+		Symbol:      stock.Symbol,
+		Market:      stock.Market,
+		OpenPrice:   open,
+		HighPrice:   high,
+		LowPrice:    low,
+		ClosePrice:  closePrice,
+		Volume:      volume,
+		Change:      change,
+		ChangeRate:  changeRate,
+		Granularity: granularity,
+		Timestamp:   timestamp,
+	}
+}
+
+// savePeriodBars 같은 기간(종료일)의 봉이 이미 있으면 갱신하고 없으면 저장
+func (s *PeriodBarService) savePeriodBars(symbol string, bars []models.StockPrice) error {
+	defer DefaultSymbolLocks.Lock(symbol)()
+
+	var failed int
+	for _, bar := range bars {
+		if bar.Timestamp.IsZero() {
+			continue
+		}
+
+		var existing models.StockPrice
+		err := s.db.Where("symbol = ? AND granularity = ? AND timestamp = ?", bar.Symbol, bar.Granularity, bar.Timestamp).
+			First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			err = s.db.Create(&bar).Error
+		case err == nil:
+			bar.ID, bar.CreatedAt = existing.ID, existing.CreatedAt
+			err = s.db.Save(&bar).Error
+		}
+		if err != nil {
+			log.Printf("Failed to save %s bar for %s on %s: %v", bar.Granularity, symbol, bar.Timestamp.Format("2006-01-02"), err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to save %d/%d %s bars", failed, len(bars), symbol)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+)
+
+// priceBookEntry 종목의 최신 가격과 마지막 갱신 시각
+type priceBookEntry struct {
+	price  models.StockPrice
+	seenAt time.Time
+}
+
+// PriceBook 종목별 최신 가격을 메모리에 두는 가격 북
+// 수집기가 가격을 저장할 때마다 갱신하고, 가격 API 와 스트림은 DB/캐시 대신 여기서 읽는다.
+// 보관 종목 수를 넘으면 가장 오래 갱신되지 않은 종목부터 빼고, idle 동안 갱신이 없는 종목은 조회되지 않는다.
+type PriceBook struct {
+	mu      sync.RWMutex
+	size    int
+	idle    time.Duration
+	entries map[string]*priceBookEntry
+
+	subscribers map[chan models.StockPrice]struct{}
+	now         func() time.Time
+}
+
+func NewPriceBook(size int, idle time.Duration) *PriceBook {
+	book := &PriceBook{
+		entries:     make(map[string]*priceBookEntry),
+		subscribers: make(map[chan models.StockPrice]struct{}),
+		now:         time.Now,
+	}
+	return book.Configure(size, idle)
+}
+
+// DefaultPriceBook 수집기가 채우고 가격 API/스트림이 읽는 가격 북
+var DefaultPriceBook = NewPriceBook(config.DefaultPriceBookSize, config.DefaultPriceBookIdle)
+
+// Configure 최대 종목 수와 비활성 기준 설정 (0 이하면 기본값)
+func (b *PriceBook) Configure(size int, idle time.Duration) *PriceBook {
+	if size <= 0 {
+		size = config.DefaultPriceBookSize
+	}
+	if idle <= 0 {
+		idle = config.DefaultPriceBookIdle
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.size = size
+	b.idle = idle
+	return b
+}
+
+// WithClock 비활성 판단에 쓸 현재 시각 함수 설정 (테스트용)
+func (b *PriceBook) WithClock(now func() time.Time) *PriceBook {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.now = now
+	return b
+}
+
+// Update 종목의 최신 가격 갱신 (이미 들고 있는 가격보다 오래된 시각의 가격이면 무시하고 false)
+// 갱신한 가격은 구독 중인 스트림에 전달한다.
+func (b *PriceBook) Update(price models.StockPrice) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if entry, ok := b.entries[price.Symbol]; ok {
+		if price.Timestamp.Before(entry.price.Timestamp) {
+			return false
+		}
+		entry.price, entry.seenAt = price, now
+	} else {
+		if len(b.entries) >= b.size {
+			b.evictLocked(now)
+		}
+		b.entries[price.Symbol] = &priceBookEntry{price: price, seenAt: now}
+	}
+
+	// 느린 구독자 때문에 수집기가 멈추지 않도록 버퍼가 찬 구독자에게는 건너뛴다
+	for ch := range b.subscribers {
+		select {
+		case ch <- price:
+		default:
+		}
+	}
+	return true
+}
+
+// evictLocked 비활성 종목을 빼고, 그래도 가득 차 있으면 가장 오래 갱신되지 않은 종목을 뺀다
+func (b *PriceBook) evictLocked(now time.Time) {
+	b.evictIdleLocked(now)
+	if len(b.entries) < b.size {
+		return
+	}
+
+	var oldest string
+	var oldestSeen time.Time
+	for symbol, entry := range b.entries {
+		if oldest == "" || entry.seenAt.Before(oldestSeen) {
+			oldest, oldestSeen = symbol, entry.seenAt
+		}
+	}
+	delete(b.entries, oldest)
+}
+
+func (b *PriceBook) evictIdleLocked(now time.Time) int {
+	evicted := 0
+	for symbol, entry := range b.entries {
+		if now.Sub(entry.seenAt) > b.idle {
+			delete(b.entries, symbol)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// EvictIdle idle 동안 갱신되지 않은 종목을 빼고 뺀 종목 수 반환
+func (b *PriceBook) EvictIdle() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.evictIdleLocked(b.now())
+}
+
+// Remove 종목을 가격 북에서 뺌 (비활성화/삭제된 종목, idle 을 기다리지 않고 바로 조회되지 않게 한다)
+func (b *PriceBook) Remove(symbols ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, symbol := range symbols {
+		delete(b.entries, symbol)
+	}
+}
+
+// Latest 종목의 최신 가격 (없거나 idle 동안 갱신되지 않았으면 false)
+func (b *PriceBook) Latest(symbol string) (models.StockPrice, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	entry, ok := b.entries[symbol]
+	if !ok || b.now().Sub(entry.seenAt) > b.idle {
+		return models.StockPrice{}, false
+	}
+	return entry.price, true
+}
+
+// Snapshot 비활성 종목을 제외한 모든 종목의 최신 가격 (종목 코드순)
+func (b *PriceBook) Snapshot() []models.StockPrice {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.snapshotLocked()
+}
+
+func (b *PriceBook) snapshotLocked() []models.StockPrice {
+	now := b.now()
+	prices := make([]models.StockPrice, 0, len(b.entries))
+	for _, entry := range b.entries {
+		if now.Sub(entry.seenAt) <= b.idle {
+			prices = append(prices, entry.price)
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Symbol < prices[j].Symbol })
+	return prices
+}
+
+// Len 보관 중인 종목 수
+func (b *PriceBook) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.entries)
+}
+
+// Subscribe 현재 가격 북 내용과 이후 갱신을 받는 채널 (스트림 fan-out 용)
+// 채널에는 먼저 Snapshot 이 들어가고 이어서 갱신된 가격이 들어온다. 버퍼가 차면 갱신을 건너뛰므로
+// 구독자는 빠르게 읽어야 하며, 다 쓰면 cancel 을 호출해야 한다.
+func (b *PriceBook) Subscribe(buffer int) (<-chan models.StockPrice, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := b.snapshotLocked()
+	ch := make(chan models.StockPrice, len(snapshot)+max(buffer, 1))
+	for _, price := range snapshot {
+		ch <- price
+	}
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers, ch)
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
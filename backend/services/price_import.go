@@ -0,0 +1,223 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// 가격 일괄 가져오기 설정
+const (
+	maxPriceImportRejections = 100 // 응답에 담는 거부 행 상세 최대 개수
+	priceImportDateLayout    = "2006-01-02"
+)
+
+// priceImportColumns CSV 컬럼 순서 (첫 행이 이 헤더이면 건너뛴다)
+var priceImportColumns = []string{"symbol", "date", "open", "high", "low", "close", "volume"}
+
+// PriceImportRejection 거부된 행과 사유
+type PriceImportRejection struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// PriceImportReport 가격 일괄 가져오기 결과
+// Imported 는 새로 넣거나 값이 바뀌어 갱신한 행, Skipped 는 이미 같은 값으로 저장된 행이나 파일 안의 중복 행이다.
+type PriceImportReport struct {
+	Imported   int                    `json:"imported"`
+	Skipped    int                    `json:"skipped"`
+	Rejected   int                    `json:"rejected"`
+	Rejections []PriceImportRejection `json:"rejections,omitempty"`
+}
+
+func (r *PriceImportReport) reject(line int, reason string) {
+	r.Rejected++
+	if len(r.Rejections) < maxPriceImportRejections {
+		r.Rejections = append(r.Rejections, PriceImportRejection{Line: line, Reason: reason})
+	}
+}
+
+// ValidateOHLC 봉의 시가/고가/저가/종가/거래량이 서로 모순되지 않는지 검사
+func ValidateOHLC(bar models.StockPrice) error {
+	for name, value := range map[string]float64{
+		"open": bar.OpenPrice, "high": bar.HighPrice, "low": bar.LowPrice, "close": bar.ClosePrice,
+	} {
+		if value <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("%s price must be positive", name)
+		}
+	}
+	if bar.LowPrice > bar.HighPrice {
+		return errors.New("low price is above high price")
+	}
+	if bar.HighPrice < math.Max(bar.OpenPrice, bar.ClosePrice) {
+		return errors.New("high price is below open/close")
+	}
+	if bar.LowPrice > math.Min(bar.OpenPrice, bar.ClosePrice) {
+		return errors.New("low price is above open/close")
+	}
+	if bar.Volume < 0 {
+		return errors.New("volume must not be negative")
+	}
+	return nil
+}
+
+// PriceImportService CSV 일봉 데이터 일괄 가져오기 (백테스트/오프라인 분석용 시드 데이터)
+type PriceImportService struct {
+	db *gorm.DB
+}
+
+func NewPriceImportService(db *gorm.DB) *PriceImportService {
+	return &PriceImportService{db: db}
+}
+
+// ImportCSV symbol,date,open,high,low,close,volume 형식의 CSV 를 일봉 저장소에 업서트
+// 등록되지 않은 종목이나 OHLC 검증에 실패한 행은 거부하고 나머지는 그대로 가져온다.
+// 저장은 수집기와 같은 saveDailyBars 를 쓰므로 이미 수집한 날짜(장중 스냅샷 포함)와 겹치지 않는다.
+func (s *PriceImportService) ImportCSV(r io.Reader) (*PriceImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	report := &PriceImportReport{}
+	bySymbol := make(map[string][]models.StockPrice)
+	seen := make(map[string]bool)
+	markets := make(map[string]string)
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				report.reject(line, err.Error())
+				continue
+			}
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if line == 1 && isPriceImportHeader(record) {
+			continue
+		}
+
+		bar, err := parsePriceImportRecord(record)
+		if err != nil {
+			report.reject(line, err.Error())
+			continue
+		}
+
+		market, ok := markets[bar.Symbol]
+		if !ok {
+			market, err = s.stockMarket(bar.Symbol)
+			if err != nil {
+				return nil, err
+			}
+			markets[bar.Symbol] = market
+		}
+		if market == "" {
+			report.reject(line, fmt.Sprintf("unknown symbol %s", bar.Symbol))
+			continue
+		}
+		bar.Market = market
+		// 날짜는 수집한 일봉과 같은 기준으로 비교되도록 시장 현지 자정으로 저장
+		location := apimodels.MarketTradingHours(market).Location
+		bar.Timestamp = time.Date(bar.Timestamp.Year(), bar.Timestamp.Month(), bar.Timestamp.Day(), 0, 0, 0, 0, location)
+
+		key := bar.Symbol + "|" + bar.Timestamp.Format(priceImportDateLayout)
+		if seen[key] {
+			report.Skipped++
+			continue
+		}
+		seen[key] = true
+		bySymbol[bar.Symbol] = append(bySymbol[bar.Symbol], bar)
+	}
+
+	symbols := make([]string, 0, len(bySymbol))
+	for symbol := range bySymbol {
+		symbols = append(symbols, symbol)
+	}
+	defer DefaultSymbolLocks.LockAll(symbols)()
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for symbol, bars := range bySymbol {
+			counts, err := saveDailyBars(tx, symbol, bars[0].Market, bars, true)
+			if err != nil {
+				return err
+			}
+			report.Imported += counts.inserted + counts.updated
+			report.Skipped += counts.unchanged
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import prices: %w", err)
+	}
+	return report, nil
+}
+
+// stockMarket 등록된 종목의 시장 (없으면 빈 문자열)
+func (s *PriceImportService) stockMarket(symbol string) (string, error) {
+	var stock models.Stock
+	if err := s.db.Select("market").Where("symbol = ?", symbol).Limit(1).Find(&stock).Error; err != nil {
+		return "", fmt.Errorf("failed to look up stock %s: %w", symbol, err)
+	}
+	return stock.Market, nil
+}
+
+func isPriceImportHeader(record []string) bool {
+	return len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), priceImportColumns[0])
+}
+
+// parsePriceImportRecord CSV 한 행을 일봉으로 변환하고 OHLC 검증
+func parsePriceImportRecord(record []string) (models.StockPrice, error) {
+	if len(record) != len(priceImportColumns) {
+		return models.StockPrice{}, fmt.Errorf("expected %d columns, got %d", len(priceImportColumns), len(record))
+	}
+
+	symbol := strings.ToUpper(strings.TrimSpace(record[0]))
+	if symbol == "" {
+		return models.StockPrice{}, errors.New("symbol is required")
+	}
+	date, err := time.Parse(priceImportDateLayout, strings.TrimSpace(record[1]))
+	if err != nil {
+		return models.StockPrice{}, fmt.Errorf("invalid date %q", record[1])
+	}
+
+	prices := make([]float64, 4)
+	for i := range prices {
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[2+i]), 64)
+		if err != nil {
+			return models.StockPrice{}, fmt.Errorf("invalid %s %q", priceImportColumns[2+i], record[2+i])
+		}
+		prices[i] = value
+	}
+	volume, err := strconv.ParseInt(strings.TrimSpace(record[6]), 10, 64)
+	if err != nil {
+		return models.StockPrice{}, fmt.Errorf("invalid volume %q", record[6])
+	}
+
+	bar := models.StockPrice{
+		Symbol:      symbol,
+		OpenPrice:   prices[0],
+		HighPrice:   prices[1],
+		LowPrice:    prices[2],
+		ClosePrice:  prices[3],
+		Volume:      volume,
+		Granularity: models.GranularityDaily,
+		Timestamp:   date,
+	}
+	if err := ValidateOHLC(bar); err != nil {
+		return models.StockPrice{}, err
+	}
+	return bar, nil
+}
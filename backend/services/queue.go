@@ -5,14 +5,16 @@ import (
 	"fmt"
 	"log"
 	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
 	"time"
 
 	"github.com/streadway/amqp"
 )
 
 type QueueService struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	conn           *amqp.Connection
+	channel        *amqp.Channel
+	reasonLanguage string // 발행하는 신호 근거 문구의 언어 (SIGNAL_REASON_LANGUAGE)
 }
 
 // 메시지 타입
@@ -54,8 +56,12 @@ func NewQueueService(cfg *config.Config) (*QueueService, error) {
 	}
 
 	qs := &QueueService{
-		conn:    conn,
-		channel: ch,
+		conn:           conn,
+		channel:        ch,
+		reasonLanguage: DefaultReasonLanguage,
+	}
+	if SupportedReasonLanguage(cfg.Signal.ReasonLanguage) {
+		qs.reasonLanguage = cfg.Signal.ReasonLanguage
 	}
 
 	// Exchange와 Queue 설정
@@ -226,12 +232,15 @@ func (qs *QueueService) PublishAIRequest(symbol, market string, indicators inter
 	return qs.Publish("stock.data", "ai.requests", message)
 }
 
-func (qs *QueueService) PublishSignal(symbol, market string, signal interface{}) error {
+// PublishSignal 생성된 신호 발행 (근거 코드는 SIGNAL_REASON_LANGUAGE 문구로 바꿔 보내고, 저장된 신호는 바꾸지 않는다)
+func (qs *QueueService) PublishSignal(symbol, market string, signal *models.TradingSignal) error {
+	localized := *signal
+	localized.Reasons = LocalizeReasonsJSON(signal.Reasons, qs.reasonLanguage)
 	message := Message{
 		Type:      MessageTypeSignalGenerated,
 		Symbol:    symbol,
 		Market:    market,
-		Data:      signal,
+		Data:      &localized,
 		Timestamp: fmt.Sprintf("%d", time.Now().Unix()),
 	}
 	return qs.Publish("trading.signals", "signal.generation", message)
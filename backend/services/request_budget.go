@@ -0,0 +1,84 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"stock-recommender/backend/config"
+)
+
+// RequestBudget 무거운 엔드포인트가 함께 쓰는 동시 처리 한도
+// 한도를 넘는 요청은 도착 순서(FIFO)대로 대기해 한 요청이 연속으로 슬롯을 가로채지 못한다.
+type RequestBudget struct {
+	mu       sync.Mutex
+	capacity int
+	inFlight int
+	waiters  list.List // 대기 중인 요청의 chan struct{}
+}
+
+// NewRequestBudget capacity 개의 슬롯을 가진 예산 생성 (0 이하이면 config.DefaultRequestBudget)
+func NewRequestBudget(capacity int) *RequestBudget {
+	if capacity <= 0 {
+		capacity = config.DefaultRequestBudget
+	}
+	return &RequestBudget{capacity: capacity}
+}
+
+// Acquire 슬롯 하나를 얻을 때까지 대기 (ctx 가 끝나면 대기열에서 빠지고 ctx 에러 반환)
+func (b *RequestBudget) Acquire(ctx context.Context) error {
+	b.mu.Lock()
+	if b.inFlight < b.capacity && b.waiters.Len() == 0 {
+		b.inFlight++
+		b.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	elem := b.waiters.PushBack(ready)
+	b.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		select {
+		case <-ready:
+			// 취소와 동시에 슬롯을 넘겨받았으면 다음 대기자에게 돌려준다
+			b.mu.Unlock()
+			b.Release()
+		default:
+			b.waiters.Remove(elem)
+			b.mu.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+// Release 슬롯 반납 (대기자가 있으면 가장 먼저 온 요청에 바로 넘긴다)
+func (b *RequestBudget) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if front := b.waiters.Front(); front != nil {
+		b.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	if b.inFlight > 0 {
+		b.inFlight--
+	}
+}
+
+// InFlight 현재 슬롯을 사용 중인 요청 수
+func (b *RequestBudget) InFlight() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.inFlight
+}
+
+// Capacity 최대 동시 처리 수
+func (b *RequestBudget) Capacity() int {
+	return b.capacity
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"stock-recommender/backend/models"
+)
+
+// ScreenerFilter 종목 스크리너 조건 (nil/빈 값은 조건 없음)
+type ScreenerFilter struct {
+	Market      string
+	PERLessThan *float64
+	PBRLessThan *float64
+	Signal      string // 최신 매매 신호 (BUY, SELL, HOLD)
+}
+
+// ScreenerService 펀더멘털/신호 조건으로 종목 검색
+type ScreenerService struct {
+	db *gorm.DB
+}
+
+func NewScreenerService(db *gorm.DB) *ScreenerService {
+	return &ScreenerService{db: db}
+}
+
+// Screen 조건을 모두 만족하는 활성 종목 조회
+// PER/PBR 조건이 있으면 해당 값이 없거나 0 이하(적자 등)인 종목은 제외한다.
+func (s *ScreenerService) Screen(filter ScreenerFilter) ([]models.Stock, error) {
+	query := s.db.Where("is_active = ?", true)
+
+	if filter.Market != "" {
+		query = query.Where("market = ?", filter.Market)
+	}
+	if filter.PERLessThan != nil {
+		query = query.Where("per > 0 AND per < ?", *filter.PERLessThan)
+	}
+	if filter.PBRLessThan != nil {
+		query = query.Where("pbr > 0 AND pbr < ?", *filter.PBRLessThan)
+	}
+	if filter.Signal != "" {
+		latestSignals := s.db.Model(&models.TradingSignal{}).
+			Select("symbol").
+			Where("signal_type = ?", filter.Signal).
+			Where("created_at = (SELECT MAX(created_at) FROM trading_signals latest WHERE latest.symbol = trading_signals.symbol)")
+		query = query.Where("symbol IN (?)", latestSignals)
+	}
+
+	var stocks []models.Stock
+	if err := query.Order("symbol").Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to screen stocks: %w", err)
+	}
+	return stocks, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"stock-recommender/backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 지표 계산에 필요한 최소 봉 개수
+const seedIndicatorWindow = 50
+
+// SeedOptions 데모 데이터 생성 옵션
+type SeedOptions struct {
+	Stocks        []models.Stock // 생성할 종목 (비어있으면 DefaultSeedStocks)
+	Bars          int            // 종목별 일봉 개수
+	SignalSamples int            // 종목별 샘플 신호 개수
+	EndDate       time.Time      // 마지막 일봉 날짜 (zero면 오늘)
+}
+
+// SeedReport 데모 데이터 생성 결과 (새로 추가된 행 수)
+type SeedReport struct {
+	Stocks     int `json:"stocks"`
+	Prices     int `json:"prices"`
+	Indicators int `json:"indicators"`
+	Signals    int `json:"signals"`
+}
+
+type SeedService struct {
+	db               *gorm.DB
+	indicatorService *IndicatorService
+	signalGenerator  *SignalGeneratorService
+}
+
+func NewSeedService(db *gorm.DB, indicatorService *IndicatorService) *SeedService {
+	return &SeedService{
+		db:               db,
+		indicatorService: indicatorService,
+		signalGenerator:  &SignalGeneratorService{db: db, indicatorService: indicatorService},
+	}
+}
+
+// DefaultSeedOptions 기본 데모 데이터 옵션 (약 4개월치 일봉)
+func DefaultSeedOptions() SeedOptions {
+	return SeedOptions{
+		Stocks:        DefaultSeedStocks(),
+		Bars:          90,
+		SignalSamples: 3,
+	}
+}
+
+// DefaultSeedStocks 데모용 기본 종목
+func DefaultSeedStocks() []models.Stock {
+	return []models.Stock{
+		{Symbol: "005930", Name: "삼성전자", Market: "KR", Exchange: "KOSPI", Sector: "Technology", IsActive: true},
+		{Symbol: "000660", Name: "SK하이닉스", Market: "KR", Exchange: "KOSPI", Sector: "Technology", IsActive: true},
+		{Symbol: "035420", Name: "NAVER", Market: "KR", Exchange: "KOSPI", Sector: "Communication", IsActive: true},
+		{Symbol: "AAPL", Name: "Apple Inc.", Market: "US", Exchange: "NASDAQ", Sector: "Technology", IsActive: true},
+		{Symbol: "TSLA", Name: "Tesla Inc.", Market: "US", Exchange: "NASDAQ", Sector: "Automotive", IsActive: true},
+		{Symbol: "MSFT", Name: "Microsoft Corp.", Market: "US", Exchange: "NASDAQ", Sector: "Technology", IsActive: true},
+	}
+}
+
+// Seed 데모 데이터 생성
+// 종목, Mock 일봉, 기술지표, 샘플 신호를 생성하며 여러 번 실행해도 중복 행이 생기지 않는다.
+func (s *SeedService) Seed(opts SeedOptions) (*SeedReport, error) {
+	if len(opts.Stocks) == 0 {
+		opts.Stocks = DefaultSeedStocks()
+	}
+	if opts.Bars < seedIndicatorWindow {
+		return nil, fmt.Errorf("at least %d bars are required, got %d", seedIndicatorWindow, opts.Bars)
+	}
+	if opts.EndDate.IsZero() {
+		opts.EndDate = time.Now()
+	}
+
+	report := &SeedReport{}
+
+	for _, stock := range opts.Stocks {
+		if err := s.seedStock(stock, opts, report); err != nil {
+			return report, fmt.Errorf("failed to seed %s: %w", stock.Symbol, err)
+		}
+	}
+
+	log.Printf("Seed completed: %d stocks, %d prices, %d indicators, %d signals added",
+		report.Stocks, report.Prices, report.Indicators, report.Signals)
+	return report, nil
+}
+
+func (s *SeedService) seedStock(stock models.Stock, opts SeedOptions, report *SeedReport) error {
+	// 1. 종목
+	var existing models.Stock
+	result := s.db.Where("symbol = ?", stock.Symbol).First(&existing)
+	if result.Error == gorm.ErrRecordNotFound {
+		if err := s.db.Create(&stock).Error; err != nil {
+			return fmt.Errorf("failed to create stock: %w", err)
+		}
+		report.Stocks++
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	// 2. 일봉
+	history := GenerateMockHistory(stock.Symbol, stock.Market, opts.Bars, opts.EndDate)
+	for i := range history {
+		var count int64
+		if err := s.db.Model(&models.StockPrice{}).
+			Where("symbol = ? AND granularity IN ? AND timestamp = ?", stock.Symbol, models.PriceSeriesGranularities, history[i].Timestamp).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := s.db.Create(&history[i]).Error; err != nil {
+			return fmt.Errorf("failed to create price: %w", err)
+		}
+		report.Prices++
+	}
+
+	// 3. 최신 시점 기술지표
+	latest := history[len(history)-1]
+	indicators := s.indicatorService.CalculateAll(s.window(history, len(history)-1))
+	if indicators == nil {
+		return fmt.Errorf("failed to calculate indicators")
+	}
+
+	var indicatorCount int64
+	if err := s.db.Model(&models.TechnicalIndicator{}).
+		Where("symbol = ? AND calculated_at = ?", stock.Symbol, latest.Timestamp).
+		Count(&indicatorCount).Error; err != nil {
+		return err
+	}
+	if indicatorCount == 0 {
+		for name, value := range indicators.ToMap() {
+			data, _ := json.Marshal(map[string]float64{"value": value})
+			record := models.TechnicalIndicator{
+				Symbol:         stock.Symbol,
+				IndicatorName:  name,
+				IndicatorValue: string(data),
+				CalculatedAt:   latest.Timestamp,
+			}
+			if err := s.db.Create(&record).Error; err != nil {
+				return fmt.Errorf("failed to create indicator: %w", err)
+			}
+			report.Indicators++
+		}
+	}
+
+	// 4. 샘플 신호 (5봉 간격)
+	for n := 0; n < opts.SignalSamples; n++ {
+		end := len(history) - 1 - n*5
+		if end+1 < seedIndicatorWindow {
+			break
+		}
+
+		bar := history[end]
+		var signalCount int64
+		if err := s.db.Model(&models.TradingSignal{}).
+			Where("symbol = ? AND created_at = ?", stock.Symbol, bar.Timestamp).
+			Count(&signalCount).Error; err != nil {
+			return err
+		}
+		if signalCount > 0 {
+			continue
+		}
+
+		result := s.indicatorService.CalculateAll(s.window(history, end))
+		if result == nil {
+			continue
+		}
+
+		signal := s.signalGenerator.buildRuleBasedSignal(stock.Symbol, result.ToMap())
+		signal.CreatedAt = bar.Timestamp
+		if err := s.db.Create(signal).Error; err != nil {
+			return fmt.Errorf("failed to create signal: %w", err)
+		}
+		report.Signals++
+	}
+
+	return nil
+}
+
+// end 인덱스까지의 지표 계산용 윈도우 (CalculateAll이 정렬하므로 복사본 반환)
+func (s *SeedService) window(history []models.StockPrice, end int) []models.StockPrice {
+	start := end + 1 - seedIndicatorWindow
+	if start < 0 {
+		start = 0
+	}
+	window := make([]models.StockPrice, end+1-start)
+	copy(window, history[start:end+1])
+	return window
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	apimodels "stock-recommender/backend/openapi/models"
+)
+
+// MinuteBar 장중 분봉 (Volume 은 해당 분의 거래량)
+type MinuteBar struct {
+	Symbol    string
+	Market    string
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    int64
+}
+
+// SessionBar 오늘 장중 분봉을 모은 미완성 일봉
+type SessionBar struct {
+	Symbol    string    `json:"symbol"`
+	Market    string    `json:"market"`
+	OpenedAt  time.Time `json:"opened_at"` // 세션 개장 시각
+	Open      float64   `json:"open"`      // 첫 분봉 시가
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`  // 마지막 분봉 종가
+	Volume    int64     `json:"volume"` // 분봉 거래량 합계
+	Bars      int       `json:"bars"`
+	UpdatedAt time.Time `json:"updated_at"` // 마지막 분봉 시각
+}
+
+// SessionBarAggregator 종목별로 들어오는 분봉을 현재 세션의 일봉으로 누적
+// 시장 달력 기준으로 새 거래일 개장 후 첫 분봉이 들어오면 이전 세션을 버리고 새로 시작한다.
+// 휴장일, 개장 전, 마감 후의 분봉과 현재 세션보다 오래된 분봉은 무시한다.
+type SessionBarAggregator struct {
+	mu       sync.RWMutex
+	calendar *apimodels.MarketCalendar
+	bars     map[string]*SessionBar
+}
+
+func NewSessionBarAggregator(calendar *apimodels.MarketCalendar) *SessionBarAggregator {
+	if calendar == nil {
+		calendar = apimodels.DefaultMarketCalendar
+	}
+	return &SessionBarAggregator{calendar: calendar, bars: make(map[string]*SessionBar)}
+}
+
+// DefaultSessionBars 수집기가 채우고 가격 API 가 읽는 현재 세션 일봉
+var DefaultSessionBars = NewSessionBarAggregator(nil)
+
+// Add 분봉을 현재 세션 일봉에 반영하고 갱신된 일봉 반환 (무시한 분봉이면 false)
+func (a *SessionBarAggregator) Add(bar MinuteBar) (SessionBar, bool) {
+	open, ok := a.calendar.SessionOpen(bar.Timestamp, bar.Market)
+	if !ok {
+		return SessionBar{}, false
+	}
+	hours := apimodels.MarketTradingHours(bar.Market)
+	if bar.Timestamp.After(open.Add(time.Duration(hours.Close-hours.Open) * time.Minute)) {
+		return SessionBar{}, false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	current, exists := a.bars[bar.Symbol]
+	if exists && open.Before(current.OpenedAt) {
+		return SessionBar{}, false
+	}
+	if !exists || open.After(current.OpenedAt) {
+		current = &SessionBar{
+			Symbol:   bar.Symbol,
+			Market:   bar.Market,
+			OpenedAt: open,
+			Open:     bar.Open,
+			High:     bar.High,
+			Low:      bar.Low,
+		}
+		a.bars[bar.Symbol] = current
+	}
+
+	if bar.High > current.High {
+		current.High = bar.High
+	}
+	if bar.Low < current.Low {
+		current.Low = bar.Low
+	}
+	current.Close = bar.Close
+	current.Volume += bar.Volume
+	current.Bars++
+	if bar.Timestamp.After(current.UpdatedAt) {
+		current.UpdatedAt = bar.Timestamp
+	}
+	return *current, true
+}
+
+// Current now 가 속한 세션의 일봉 (오늘 세션 분봉이 아직 없으면 false)
+func (a *SessionBarAggregator) Current(symbol, market string, now time.Time) (SessionBar, bool) {
+	open, ok := a.calendar.SessionOpen(now, market)
+	if !ok {
+		return SessionBar{}, false
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	current, exists := a.bars[symbol]
+	if !exists || !current.OpenedAt.Equal(open) {
+		return SessionBar{}, false
+	}
+	return *current, true
+}
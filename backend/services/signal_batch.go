@@ -0,0 +1,112 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// SignalOutcome 종목별 신호 생성 결과 종류
+type SignalOutcome string
+
+const (
+	SignalGenerated  SignalOutcome = "generated"  // 저장/발행됨
+	SignalSuppressed SignalOutcome = "suppressed" // 신뢰도 하한 미만이라 저장하지 않음
+	SignalStale      SignalOutcome = "stale"      // 최신 가격이 오래되어 건너뜀
+	SignalFailed     SignalOutcome = "failed"
+)
+
+// SignalBatchResult 종목 하나의 신호 생성 결과
+type SignalBatchResult struct {
+	Symbol   string
+	Market   string
+	Outcome  SignalOutcome
+	Signal   *models.TradingSignal // 실패/건너뛴 종목은 nil
+	Err      error
+	Duration time.Duration
+}
+
+// SignalBatchReport 전체 종목 신호 생성 결과 (Results 는 종목 조회 순서)
+type SignalBatchReport struct {
+	Results     []SignalBatchResult
+	Concurrency int
+	Elapsed     time.Duration
+	Paused      bool // API 점검으로 생성하지 않음
+}
+
+// Count outcome 으로 끝난 종목 수
+func (r *SignalBatchReport) Count(outcome SignalOutcome) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// WithConcurrency 전체 종목 신호 생성 시 동시에 처리할 종목 수 (1 이하면 순차 처리)
+// AI 호출 속도는 동시 실행 수와 별개로 AI 클라이언트의 토큰 버킷이 맞추고, 종목별 잠금으로 수집 중인 종목은 기다린다.
+func (s *SignalGeneratorService) WithConcurrency(n int) *SignalGeneratorService {
+	if n < 1 {
+		n = 1
+	}
+	s.concurrency = n
+	return s
+}
+
+// GenerateSignalsForStocks 최대 concurrency 개 종목씩 동시에 신호 생성
+func (s *SignalGeneratorService) GenerateSignalsForStocks(stocks []models.Stock) *SignalBatchReport {
+	concurrency := s.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	report := &SignalBatchReport{Results: make([]SignalBatchResult, len(stocks)), Concurrency: concurrency}
+	start := time.Now()
+
+	var (
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+	for i, stock := range stocks {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, stock models.Stock) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			// 결과마다 자리가 정해져 있어 잠금 없이 기록한다
+			report.Results[i] = s.generateBatchResult(stock)
+		}(i, stock)
+	}
+	wg.Wait()
+
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+// generateBatchResult 종목 하나의 신호를 생성해 결과 종류로 분류
+func (s *SignalGeneratorService) generateBatchResult(stock models.Stock) SignalBatchResult {
+	start := time.Now()
+	signal, err := s.GenerateSignal(stock.Symbol, stock.Market)
+	result := SignalBatchResult{Symbol: stock.Symbol, Market: stock.Market, Err: err, Duration: time.Since(start)}
+
+	switch {
+	case errors.Is(err, ErrStalePrice):
+		result.Outcome = SignalStale
+	case err != nil:
+		log.Printf("Failed to generate signal for %s: %v", stock.Symbol, err)
+		result.Outcome = SignalFailed
+	case s.BelowConfidenceFloor(signal):
+		result.Outcome = SignalSuppressed
+		result.Signal = signal
+	default:
+		result.Outcome = SignalGenerated
+		result.Signal = signal
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// SignalExplanation 저장된 신호가 나온 이유 (사람이 읽기 위한 형태)
+type SignalExplanation struct {
+	SignalID   uint               `json:"signal_id"`
+	Symbol     string             `json:"symbol"`
+	Decision   string             `json:"decision"`
+	Confidence float64            `json:"confidence"`
+	Strength   float64            `json:"strength"`
+	Source     string             `json:"source"`
+	Model      string             `json:"model,omitempty"`
+	Provider   string             `json:"provider,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Reasons    []string           `json:"reasons"`    // AI 신호면 AI 서비스가 제시한 근거
+	Indicators map[string]float64 `json:"indicators"` // 신호 생성 시점의 지표 값 (스냅샷이 없으면 비어 있음)
+	Summary    string             `json:"summary"`
+
+	reasons []string // 저장된 근거 (코드), Localize 가 다시 변환할 때 쓴다
+}
+
+// ExplainSignal 신호에 저장된 근거와 지표 스냅샷으로 설명 생성 (근거는 기본 언어로 표시)
+func ExplainSignal(signal *models.TradingSignal) (*SignalExplanation, error) {
+	explanation := &SignalExplanation{
+		SignalID:   signal.ID,
+		Symbol:     signal.Symbol,
+		Decision:   signal.SignalType,
+		Confidence: signal.Confidence,
+		Strength:   signal.Strength,
+		Source:     signal.Source,
+		Model:      signal.Model,
+		Provider:   signal.Provider,
+		CreatedAt:  signal.CreatedAt,
+		Reasons:    []string{},
+		Indicators: map[string]float64{},
+	}
+
+	if signal.Reasons != "" {
+		if err := json.Unmarshal([]byte(signal.Reasons), &explanation.reasons); err != nil {
+			return nil, fmt.Errorf("failed to parse reasons for signal %d: %w", signal.ID, err)
+		}
+	}
+	if signal.IndicatorSnapshot != "" {
+		if err := json.Unmarshal([]byte(signal.IndicatorSnapshot), &explanation.Indicators); err != nil {
+			return nil, fmt.Errorf("failed to parse indicators for signal %d: %w", signal.ID, err)
+		}
+	}
+
+	explanation.Localize(DefaultReasonLanguage)
+	return explanation, nil
+}
+
+// Localize 근거와 요약을 lang 문구로 다시 작성
+func (e *SignalExplanation) Localize(lang string) {
+	e.Reasons = LocalizeReasons(e.reasons, lang)
+	e.Summary = e.summarize()
+}
+
+// summarize 결정, 근거, 지표 값을 여러 줄 텍스트로 정리
+func (e *SignalExplanation) summarize() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (confidence %.0f%%, strength %.2f, source %s)\n",
+		e.Decision, e.Symbol, e.Confidence*100, e.Strength, e.Source)
+
+	if len(e.Reasons) > 0 {
+		b.WriteString("Reasons:\n")
+		for _, reason := range e.Reasons {
+			fmt.Fprintf(&b, "  - %s\n", reason)
+		}
+	}
+
+	if len(e.Indicators) > 0 {
+		names := make([]string, 0, len(e.Indicators))
+		for name := range e.Indicators {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString("Indicators at signal time:\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "  - %s: %.2f\n", name, e.Indicators[name])
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
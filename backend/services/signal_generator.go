@@ -4,18 +4,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	apimodels "stock-recommender/backend/openapi/models"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// SignalStrategy 신호 생성 전략별 지표 계산 설정
+type SignalStrategy struct {
+	Name        string
+	Granularity string // 지표 계산에 쓸 봉 단위 (빈 값이면 현재가/일봉 구분 없이 최근 데이터 사용)
+}
+
+var (
+	// StrategyDefault 단위 구분 없이 최근 주가 데이터 사용 (기존 동작)
+	StrategyDefault = SignalStrategy{Name: "default"}
+	// StrategySwing 장중 스냅샷을 제외하고 일봉만 사용
+	StrategySwing = SignalStrategy{Name: "swing", Granularity: models.GranularityDaily}
+)
+
 type SignalGeneratorService struct {
 	db               *gorm.DB
 	indicatorService *IndicatorService
 	aiClient         *AIClient
 	cacheService     *CacheService
 	queueService     *QueueService
+	strength         StrengthMapping
+	notifications    *NotificationService
+	features         *FeatureFlags
+	confidenceFloor  float64 // 이 신뢰도 미만의 신호는 계산만 하고 저장/발행하지 않음
+	logSuppressed    bool    // 저장하지 않은 신호를 로그로 남길지 여부
+	maintenance      *client.MaintenanceState
+	stalePrice       StalePriceGuard // 최신 가격이 오래됐을 때 신호를 건너뛰거나 신뢰도를 낮춤
+	concurrency      int             // 전체 종목 신호 생성 시 동시에 처리할 종목 수
 }
 
 func NewSignalGeneratorService(
@@ -31,29 +55,161 @@ func NewSignalGeneratorService(
 		aiClient:         aiClient,
 		cacheService:     cacheService,
 		queueService:     queueService,
+		strength:         DefaultStrengthMapping,
+		concurrency:      1,
+	}
+}
+
+// WithStrengthMapping 신뢰도 → 신호 강도 매핑 설정
+func (s *SignalGeneratorService) WithStrengthMapping(mapping StrengthMapping) *SignalGeneratorService {
+	s.strength = mapping
+	return s
+}
+
+// WithNotifications 전체 종목 신호 생성 시 구독자에게 보낼 알림 서비스 설정
+func (s *SignalGeneratorService) WithNotifications(notifications *NotificationService) *SignalGeneratorService {
+	s.notifications = notifications
+	return s
+}
+
+// WithFeatures 실험 중인 신호 입력 사용 여부를 정할 기능 플래그 설정 (nil 이면 기본값)
+func (s *SignalGeneratorService) WithFeatures(features *FeatureFlags) *SignalGeneratorService {
+	s.features = features
+	return s
+}
+
+// WithConfidenceFloor 저장/발행할 신호의 최소 신뢰도 설정 (0 이면 모든 신호 저장)
+func (s *SignalGeneratorService) WithConfidenceFloor(floor float64, logSuppressed bool) *SignalGeneratorService {
+	s.confidenceFloor = floor
+	s.logSuppressed = logSuppressed
+	return s
+}
+
+// ValidateConfidenceFloor 신뢰도 하한이 0~1 사이인지 확인 (1 을 넘으면 모든 신호가 저장되지 않는다)
+func ValidateConfidenceFloor(floor float64) error {
+	if math.IsNaN(floor) || floor < 0 || floor > 1 {
+		return fmt.Errorf("invalid signal confidence floor %.2f, expected 0 <= floor <= 1", floor)
+	}
+	return nil
+}
+
+// WithMaintenance 이 상태가 점검 중이면 전체 종목 신호 생성을 건너뛴다 (nil 이면 항상 생성)
+func (s *SignalGeneratorService) WithMaintenance(maintenance *client.MaintenanceState) *SignalGeneratorService {
+	s.maintenance = maintenance
+	return s
+}
+
+// WithStalePriceGuard 최신 가격 나이 제한 설정 (MaxAge 가 0 이면 검사하지 않음)
+func (s *SignalGeneratorService) WithStalePriceGuard(guard StalePriceGuard) *SignalGeneratorService {
+	s.stalePrice = guard
+	return s
+}
+
+// BelowConfidenceFloor 신뢰도 하한 미만이라 저장/발행하지 않는 신호인지 여부
+func (s *SignalGeneratorService) BelowConfidenceFloor(signal *models.TradingSignal) bool {
+	return signal.Confidence < s.confidenceFloor
+}
+
+// suppressWeakSignal 하한 미만 신호면 (설정에 따라 로그만 남기고) true
+func (s *SignalGeneratorService) suppressWeakSignal(signal *models.TradingSignal) bool {
+	if !s.BelowConfidenceFloor(signal) {
+		return false
+	}
+	if s.logSuppressed {
+		log.Printf("Suppressed %s signal for %s: confidence %.2f below floor %.2f",
+			signal.SignalType, signal.Symbol, signal.Confidence, s.confidenceFloor)
+	}
+	return true
+}
+
+// signalLookbackBars 지표 계산에 쓰는 최근 봉 수
+const signalLookbackBars = 50
+
+// aiHistoryBars AI 요청에 함께 보낼 최근 봉 수 (AI 를 쓰지 않으면 0)
+func (s *SignalGeneratorService) aiHistoryBars() int {
+	if s.aiClient == nil || s.aiClient.RuleOnly() {
+		return 0
+	}
+	return s.aiClient.HistoryBars()
+}
+
+// aiHistory 최신순으로 조회한 봉에서 AI 요청용 최근 봉 이력을 오래된 순으로 만든다
+func (s *SignalGeneratorService) aiHistory(prices []models.StockPrice) []models.AIPriceBar {
+	n := s.aiHistoryBars()
+	if n > len(prices) {
+		n = len(prices)
+	}
+	if n == 0 {
+		return nil
 	}
+	history := make([]models.AIPriceBar, n)
+	for i := 0; i < n; i++ {
+		history[n-1-i] = models.NewAIPriceBar(prices[i])
+	}
+	return history
+}
+
+// Strength 신뢰도에 대응하는 신호 강도 (AI 신호를 만드는 모든 경로에서 공유)
+func (s *SignalGeneratorService) Strength(confidence float64) float64 {
+	return s.strength.Strength(confidence)
 }
 
 // 특정 종목에 대한 매매 신호 생성
 func (s *SignalGeneratorService) GenerateSignal(symbol, market string) (*models.TradingSignal, error) {
-	log.Printf("Generating signal for %s (%s)", symbol, market)
+	return s.GenerateSignalWithStrategy(symbol, market, StrategyDefault)
+}
 
-	// 1. 최근 주가 데이터 조회 (50일치)
+// GenerateSignalWithStrategy 전략 설정에 맞는 봉 데이터로 매매 신호 생성
+func (s *SignalGeneratorService) GenerateSignalWithStrategy(symbol, market string, strategy SignalStrategy) (*models.TradingSignal, error) {
+	log.Printf("Generating %s signal for %s (%s)", strategy.Name, symbol, market)
+
+	// 1. 최근 주가 데이터 조회 (50일치, AI 요청에 더 긴 봉 이력을 보내도록 설정했으면 그만큼)
+	limit := signalLookbackBars
+	if n := s.aiHistoryBars(); n > limit {
+		limit = n
+	}
+	query := s.db.Where("symbol = ? AND market = ?", symbol, market)
+	if strategy.Granularity != "" {
+		query = query.Where("granularity = ?", strategy.Granularity)
+	} else {
+		query = query.Where("granularity IN ?", models.PriceSeriesGranularities)
+	}
+
+	// 수집기가 같은 종목을 쓰는 중이면 끝날 때까지 기다렸다가 봉과 호가를 함께 읽는다
 	var prices []models.StockPrice
-	err := s.db.Where("symbol = ? AND market = ?", symbol, market).
+	unlock := DefaultSymbolLocks.RLock(symbol)
+	err := query.
 		Order("timestamp desc").
-		Limit(50).
+		Limit(limit).
 		Find(&prices).Error
+	var imbalance float64
+	var hasImbalance bool
+	if s.features.Enabled(FlagOrderBookSignal) {
+		imbalance, hasImbalance = s.orderBookImbalance(symbol, market)
+	}
+	unlock()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch price data: %w", err)
 	}
 
+	// 지표는 항상 최근 50개 봉으로 계산한다 (AI 문맥 설정이 신호 계산을 바꾸지 않도록)
+	history := prices
+	if len(prices) > signalLookbackBars {
+		prices = prices[:signalLookbackBars]
+	}
+
 	if len(prices) < 20 {
 		return nil, fmt.Errorf("insufficient price data for %s", symbol)
 	}
 
-	// 2. 기술지표 계산
-	indicators := s.indicatorService.CalculateAll(prices)
+	// 수집 공백이 있으면 최근 50개 봉이 불규칙한 기간에 걸쳐 지표가 왜곡된다
+	if status := s.indicatorService.CheckBars(prices); status != BarStatusOK {
+		return nil, fmt.Errorf("price data for %s is %s", symbol, status)
+	}
+
+	// 2. 기술지표 계산 (종목별 지표 기간 설정이 있으면 전역 기본값 위에 덮어씀)
+	params := SymbolIndicatorParams(s.db, symbol, s.indicatorService.Params())
+	indicators := s.indicatorService.CalculateAllWithParams(prices, params)
 	if indicators == nil {
 		return nil, fmt.Errorf("failed to calculate indicators for %s", symbol)
 	}
@@ -73,22 +229,51 @@ func (s *SignalGeneratorService) GenerateSignal(symbol, market string) (*models.
 		"bollinger_mid":   indicators.BollingerMid,
 		"stochastic_k":    indicators.StochasticK,
 		"stochastic_d":    indicators.StochasticD,
+		"stoch_rsi_k":     indicators.StochRSIK,
+		"stoch_rsi_d":     indicators.StochRSID,
 		"williams_r":      indicators.WilliamsR,
 		"atr":             indicators.ATR,
 		"obv":             indicators.OBV,
 	}
 
+	// 계산하지 않은 지표는 0 으로 전달되지 않도록 뺀다
+	if !s.features.Enabled(FlagStochRSI) {
+		delete(indicatorMap, "stoch_rsi_k")
+		delete(indicatorMap, "stoch_rsi_d")
+	}
+
+	// 국내 종목은 최신 호가 잔량 불균형을 단기 지표로 함께 사용
+	if hasImbalance {
+		indicatorMap["orderbook_imbalance"] = imbalance
+	}
+
 	// 4. 최신 주가 정보
 	latestPrice := prices[0]
 
+	// 수집 장애로 최신 가격이 오래됐으면 새 신호를 내지 않는다 (downgrade 면 신뢰도를 낮춰 저장)
+	if s.stalePrice.Stale(latestPrice, time.Now()) {
+		log.Printf("Latest price for %s is stale (%s, max age %s), action: %s",
+			symbol, latestPrice.Timestamp.Format(time.RFC3339), s.stalePrice.MaxAge, s.stalePrice.Action)
+		if s.stalePrice.Action != StalePriceDowngrade {
+			return nil, fmt.Errorf("%w: %s at %s", ErrStalePrice, symbol, latestPrice.Timestamp.Format(time.RFC3339))
+		}
+	}
+
+	// 규칙 기반 전용 모드에서는 AI 호출 없이 바로 규칙 기반 신호 생성
+	if s.aiClient == nil || s.aiClient.RuleOnly() {
+		return s.generateRuleBasedSignal(symbol, market, indicatorMap, latestPrice)
+	}
+
 	// 5. AI 서비스에 의사결정 요청
 	aiRequest := models.AIDecisionRequest{
 		Symbol:     symbol,
 		Market:     market,
 		Price:      latestPrice,
 		Indicators: indicatorMap,
+		History:    s.aiHistory(history),
 		Metadata: map[string]interface{}{
 			"data_points": len(prices),
+			"strategy":    strategy.Name,
 			"timestamp":   time.Now().Unix(),
 		},
 	}
@@ -102,13 +287,22 @@ func (s *SignalGeneratorService) GenerateSignal(symbol, market string) (*models.
 
 	// 6. AI 응답을 TradingSignal로 변환
 	signal := &models.TradingSignal{
-		Symbol:     symbol,
-		SignalType: aiResponse.Decision,
-		Strength:   s.calculateStrength(aiResponse.Confidence, indicatorMap),
-		Confidence: aiResponse.Confidence,
-		Reasons:    s.reasonsToJSON(aiResponse.Reasoning),
-		Source:     "AI",
-		CreatedAt:  time.Now(),
+		Symbol:            symbol,
+		SignalType:        aiResponse.Decision,
+		Strength:          s.Strength(aiResponse.Confidence),
+		Confidence:        aiResponse.Confidence,
+		Reasons:           s.reasonsToJSON(aiResponse.Reasoning),
+		IndicatorSnapshot: IndicatorsToJSON(indicatorMap),
+		Source:            "AI",
+		Model:             aiResponse.Model,
+		Provider:          aiResponse.Provider,
+		CreatedAt:         time.Now(),
+	}
+	s.downgradeStale(signal, latestPrice)
+
+	// 신뢰도 하한 미만이면 저장/캐시 무효화/발행 없이 계산 결과만 반환
+	if s.suppressWeakSignal(signal) {
+		return signal, nil
 	}
 
 	// 7. 데이터베이스에 저장
@@ -132,9 +326,47 @@ func (s *SignalGeneratorService) GenerateSignal(symbol, market string) (*models.
 func (s *SignalGeneratorService) generateRuleBasedSignal(symbol, market string, indicators map[string]float64, price models.StockPrice) (*models.TradingSignal, error) {
 	log.Printf("Using rule-based fallback for %s", symbol)
 
+	signal := s.buildRuleBasedSignal(symbol, indicators)
+	s.downgradeStale(signal, price)
+	if s.suppressWeakSignal(signal) {
+		return signal, nil
+	}
+
+	if err := s.db.Create(signal).Error; err != nil {
+		return nil, fmt.Errorf("failed to save rule-based signal: %w", err)
+	}
+
+	return signal, nil
+}
+
+// downgradeStale 오래된 가격으로 만든 신호면 (downgrade 설정일 때) 신뢰도를 낮춘다
+func (s *SignalGeneratorService) downgradeStale(signal *models.TradingSignal, price models.StockPrice) {
+	if s.stalePrice.Action == StalePriceDowngrade && s.stalePrice.Stale(price, time.Now()) {
+		s.stalePrice.Downgrade(signal)
+	}
+}
+
+// 규칙 기반 신호 구성 (저장하지 않음)
+func (s *SignalGeneratorService) buildRuleBasedSignal(symbol string, indicators map[string]float64) *models.TradingSignal {
+	decision, confidence, reasons := ruleBasedDecision(indicators)
+
+	return &models.TradingSignal{
+		Symbol:            symbol,
+		SignalType:        decision,
+		Strength:          s.Strength(confidence),
+		Confidence:        confidence,
+		Reasons:           s.reasonsToJSON(reasons),
+		IndicatorSnapshot: IndicatorsToJSON(indicators),
+		Source:            "RULE",
+		CreatedAt:         time.Now(),
+	}
+}
+
+// ruleBasedDecision 지표 투표로 정하는 규칙 기반 의사결정 (신호 생성과 백테스트가 공유, 근거는 코드)
+func ruleBasedDecision(indicators map[string]float64) (string, float64, []string) {
 	decision := "HOLD"
 	confidence := 0.5
-	reasons := []string{"AI service unavailable, using rule-based analysis"}
+	reasons := []string{ReasonRuleFallback}
 
 	// 간단한 규칙 기반 로직
 	rsi := indicators["rsi"]
@@ -147,26 +379,37 @@ func (s *SignalGeneratorService) generateRuleBasedSignal(symbol, market string,
 
 	if rsi < 30 {
 		buySignals++
-		reasons = append(reasons, "RSI oversold")
+		reasons = append(reasons, ReasonRSIOversold)
 	} else if rsi > 70 {
 		sellSignals++
-		reasons = append(reasons, "RSI overbought")
+		reasons = append(reasons, ReasonRSIOverbought)
 	}
 
 	if macd > 0 {
 		buySignals++
-		reasons = append(reasons, "MACD positive")
+		reasons = append(reasons, ReasonMACDPositive)
 	} else {
 		sellSignals++
-		reasons = append(reasons, "MACD negative")
+		reasons = append(reasons, ReasonMACDNegative)
 	}
 
 	if sma20 > sma50 {
 		buySignals++
-		reasons = append(reasons, "SMA20 > SMA50")
+		reasons = append(reasons, ReasonSMABullish)
 	} else {
 		sellSignals++
-		reasons = append(reasons, "SMA20 < SMA50")
+		reasons = append(reasons, ReasonSMABearish)
+	}
+
+	if imbalance, ok := indicators["orderbook_imbalance"]; ok {
+		switch imbalanceVote(imbalance) {
+		case 1:
+			buySignals++
+			reasons = append(reasons, ReasonOrderBookBidHeavy)
+		case -1:
+			sellSignals++
+			reasons = append(reasons, ReasonOrderBookAskHeavy)
+		}
 	}
 
 	if buySignals > sellSignals {
@@ -177,88 +420,97 @@ func (s *SignalGeneratorService) generateRuleBasedSignal(symbol, market string,
 		confidence = 0.6
 	}
 
-	signal := &models.TradingSignal{
-		Symbol:     symbol,
-		SignalType: decision,
-		Strength:   confidence * 0.8, // Rule-based는 약간 낮은 강도
-		Confidence: confidence,
-		Reasons:    s.reasonsToJSON(reasons),
-		Source:     "RULE",
-		CreatedAt:  time.Now(),
+	return decision, confidence, reasons
+}
+
+// orderBookImbalance 최신 호가 스냅샷의 잔량 불균형 (국내 종목, 신선한 스냅샷만)
+func (s *SignalGeneratorService) orderBookImbalance(symbol, market string) (float64, bool) {
+	if apimodels.DefaultMarketResolver.Region(market) != apimodels.RegionKR {
+		return 0, false
 	}
 
-	if err := s.db.Create(signal).Error; err != nil {
-		return nil, fmt.Errorf("failed to save rule-based signal: %w", err)
+	var askingPrice models.AskingPrice
+	if err := s.db.Where("symbol = ?", symbol).Order("timestamp desc").First(&askingPrice).Error; err != nil {
+		return 0, false
 	}
 
-	return signal, nil
+	book := NewOrderBook(&askingPrice)
+	if !book.IsFresh(time.Now()) {
+		return 0, false
+	}
+	return book.Imbalance, true
 }
 
 // 모든 활성 종목에 대한 신호 생성
 func (s *SignalGeneratorService) GenerateSignalsForAllStocks() error {
+	_, err := s.GenerateAllSignals()
+	return err
+}
+
+// GenerateAllSignals 활성 종목 전체의 신호를 생성하고 종목별 결과를 돌려준다
+func (s *SignalGeneratorService) GenerateAllSignals() (*SignalBatchReport, error) {
+	// 점검 중에는 새 시세가 들어오지 않으므로 재개될 때까지 건너뛴다
+	if status := s.maintenance.Status(); status.Active {
+		log.Printf("Skipping signal generation: DBSec API maintenance (%s) until %s", status.Reason, status.Until.Format(time.RFC3339))
+		return &SignalBatchReport{Paused: true}, nil
+	}
+
 	log.Println("Generating signals for all active stocks")
 
 	var stocks []models.Stock
 	err := s.db.Where("is_active = ?", true).Find(&stocks).Error
 	if err != nil {
-		return fmt.Errorf("failed to fetch active stocks: %w", err)
+		return nil, fmt.Errorf("failed to fetch active stocks: %w", err)
 	}
 
-	successCount := 0
-	errorCount := 0
-
-	for _, stock := range stocks {
-		_, err := s.GenerateSignal(stock.Symbol, stock.Market)
-		if err != nil {
-			log.Printf("Failed to generate signal for %s: %v", stock.Symbol, err)
-			errorCount++
-		} else {
-			successCount++
+	// 종목 사이에 고정 지연을 두지 않는다 (AI 호출 속도는 AI 클라이언트의 토큰 버킷이 맞춘다)
+	report := s.GenerateSignalsForStocks(stocks)
+	for _, result := range report.Results {
+		if result.Outcome == SignalGenerated {
+			s.notifySignal(result.Signal)
 		}
-
-		// API 호출 제한을 위한 지연
-		time.Sleep(500 * time.Millisecond)
 	}
 
-	log.Printf("Signal generation completed: %d success, %d errors", successCount, errorCount)
-	return nil
-}
+	log.Printf("Signal generation completed in %s (concurrency %d): %d success, %d suppressed, %d stale, %d errors",
+		report.Elapsed.Round(time.Millisecond), report.Concurrency, report.Count(SignalGenerated),
+		report.Count(SignalSuppressed), report.Count(SignalStale), report.Count(SignalFailed))
 
-// 유틸리티 함수들
-func (s *SignalGeneratorService) calculateStrength(confidence float64, indicators map[string]float64) float64 {
-	// 신뢰도와 지표 강도를 결합하여 강도 계산
-	strength := confidence
-
-	// 볼린저 밴드 기반 조정
-	if upper, ok := indicators["bollinger_upper"]; ok {
-		if lower, ok := indicators["bollinger_lower"]; ok {
-			// 밴드 위치에 따른 강도 조정
-			bandWidth := upper - lower
-			if bandWidth > 0 {
-				strength += 0.1 // 변동성이 클 때 강도 증가
-			}
+	// 묶음 창이 닫힌 알림은 바로 보낸다 (아직 열린 창은 다음 FlushDue 에서 전달)
+	if s.notifications != nil {
+		if _, err := s.notifications.FlushDue(time.Now()); err != nil {
+			log.Printf("Failed to flush signal notifications: %v", err)
 		}
 	}
+	return report, nil
+}
 
-	// ATR 기반 조정
-	if atr, ok := indicators["atr"]; ok {
-		if atr > 1000 { // 높은 변동성
-			strength += 0.05
-		}
+// notifySignal 알림 서비스가 설정되어 있으면 구독자에게 신호 알림 (묶음 창이 있으면 요약 알림으로 묶인다)
+func (s *SignalGeneratorService) notifySignal(signal *models.TradingSignal) {
+	if s.notifications == nil {
+		return
 	}
-
-	// 최대 1.0으로 제한
-	if strength > 1.0 {
-		strength = 1.0
+	if err := s.notifications.NotifySignal(signal, time.Now()); err != nil {
+		log.Printf("Failed to notify signal for %s: %v", signal.Symbol, err)
 	}
-
-	return strength
 }
 
+// 유틸리티 함수들
 func (s *SignalGeneratorService) reasonsToJSON(reasons []string) string {
 	data, err := json.Marshal(reasons)
 	if err != nil {
 		return `["Failed to encode reasons"]`
 	}
 	return string(data)
+}
+
+// IndicatorsToJSON 신호와 함께 저장할 지표 스냅샷 (인코딩 실패 시 빈 문자열 → NULL)
+func IndicatorsToJSON(indicators map[string]float64) string {
+	if len(indicators) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(indicators)
+	if err != nil {
+		return ""
+	}
+	return string(data)
 }
\ No newline at end of file
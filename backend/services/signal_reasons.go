@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 규칙 기반 신호 근거 코드 (신호에는 코드를 저장하고 응답할 때 요청 언어로 바꾼다)
+const (
+	ReasonRuleFallback      = "rule_fallback"
+	ReasonRSIOversold       = "rsi_oversold"
+	ReasonRSIOverbought     = "rsi_overbought"
+	ReasonMACDPositive      = "macd_positive"
+	ReasonMACDNegative      = "macd_negative"
+	ReasonSMABullish        = "sma20_above_sma50"
+	ReasonSMABearish        = "sma20_below_sma50"
+	ReasonOrderBookBidHeavy = "orderbook_bid_heavy"
+	ReasonOrderBookAskHeavy = "orderbook_ask_heavy"
+	ReasonStalePrice        = "stale_price"
+)
+
+// 신호 근거 응답 언어
+const (
+	LanguageKorean  = "ko"
+	LanguageEnglish = "en"
+)
+
+// DefaultReasonLanguage 요청 언어를 알 수 없을 때 쓰는 언어 (코드 도입 전 응답과 같은 영어)
+const DefaultReasonLanguage = LanguageEnglish
+
+// reasonMessages 근거 코드별 언어별 문구
+var reasonMessages = map[string]map[string]string{
+	ReasonRuleFallback: {
+		LanguageEnglish: "AI service unavailable, using rule-based analysis",
+		LanguageKorean:  "AI 서비스를 사용할 수 없어 규칙 기반으로 분석",
+	},
+	ReasonRSIOversold:       {LanguageEnglish: "RSI oversold", LanguageKorean: "RSI 과매도"},
+	ReasonRSIOverbought:     {LanguageEnglish: "RSI overbought", LanguageKorean: "RSI 과매수"},
+	ReasonMACDPositive:      {LanguageEnglish: "MACD positive", LanguageKorean: "MACD 양수"},
+	ReasonMACDNegative:      {LanguageEnglish: "MACD negative", LanguageKorean: "MACD 음수"},
+	ReasonSMABullish:        {LanguageEnglish: "SMA20 > SMA50", LanguageKorean: "20일 이동평균이 50일 이동평균 위"},
+	ReasonSMABearish:        {LanguageEnglish: "SMA20 < SMA50", LanguageKorean: "20일 이동평균이 50일 이동평균 아래"},
+	ReasonOrderBookBidHeavy: {LanguageEnglish: "Order book bid-heavy", LanguageKorean: "호가 매수 잔량 우위"},
+	ReasonOrderBookAskHeavy: {LanguageEnglish: "Order book ask-heavy", LanguageKorean: "호가 매도 잔량 우위"},
+	ReasonStalePrice:        {LanguageEnglish: "Latest price is stale, confidence lowered", LanguageKorean: "최신 가격이 오래되어 신뢰도를 낮춤"},
+}
+
+// legacyReasonCodes 코드 도입 전에 영어 문구로 저장된 근거 → 코드
+var legacyReasonCodes = func() map[string]string {
+	codes := make(map[string]string, len(reasonMessages))
+	for code, messages := range reasonMessages {
+		codes[messages[LanguageEnglish]] = code
+	}
+	return codes
+}()
+
+// SupportedReasonLanguage lang 으로 근거를 표시할 수 있는지 여부
+func SupportedReasonLanguage(lang string) bool {
+	return lang == LanguageKorean || lang == LanguageEnglish
+}
+
+// LocalizeReason 근거 하나를 lang 문구로 변환
+// 코드가 아닌 근거(AI 서비스가 준 문장 등)는 그대로 돌려준다.
+func LocalizeReason(reason, lang string) string {
+	code := reason
+	if legacy, ok := legacyReasonCodes[reason]; ok {
+		code = legacy
+	}
+	messages, ok := reasonMessages[code]
+	if !ok {
+		return reason
+	}
+	if message, ok := messages[lang]; ok {
+		return message
+	}
+	return messages[DefaultReasonLanguage]
+}
+
+// LocalizeReasons 근거 목록을 lang 문구로 변환
+func LocalizeReasons(reasons []string, lang string) []string {
+	localized := make([]string, len(reasons))
+	for i, reason := range reasons {
+		localized[i] = LocalizeReason(reason, lang)
+	}
+	return localized
+}
+
+// LocalizeReasonsJSON 저장된 근거 JSON 배열을 lang 문구의 JSON 배열로 변환 (배열이 아니면 그대로)
+func LocalizeReasonsJSON(reasons, lang string) string {
+	var parsed []string
+	if reasons == "" || json.Unmarshal([]byte(reasons), &parsed) != nil {
+		return reasons
+	}
+	data, err := json.Marshal(LocalizeReasons(parsed, lang))
+	if err != nil {
+		return reasons
+	}
+	return string(data)
+}
+
+// NegotiateReasonLanguage Accept-Language 헤더에서 지원하는 언어 중 선호도가 가장 높은 언어
+// (지원하는 언어가 없으면 fallback, 예: "ko-KR,ko;q=0.9,en;q=0.8" → ko)
+func NegotiateReasonLanguage(header, fallback string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		lang, _, _ := strings.Cut(tag, "-")
+		if quality > 0 && SupportedReasonLanguage(lang) {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return fallback
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	return candidates[0].lang
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// SignalRetentionService 보존 기간이 지난 매매 신호 정리
+type SignalRetentionService struct {
+	db        *gorm.DB
+	retention time.Duration
+}
+
+func NewSignalRetentionService(db *gorm.DB, retention time.Duration) *SignalRetentionService {
+	return &SignalRetentionService{db: db, retention: retention}
+}
+
+// PurgeOlderThan cutoff 이전에 생성된 신호를 삭제하고 삭제한 개수를 반환
+// 성과 추적 중(open)인 신호는 기간과 관계없이 남긴다.
+func (s *SignalRetentionService) PurgeOlderThan(cutoff time.Time) (int64, error) {
+	result := s.db.
+		Where("created_at < ?", cutoff).
+		Where("outcome IS NULL OR outcome <> ?", models.SignalOutcomeOpen).
+		Delete(&models.TradingSignal{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge signals: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ScheduledCleanup 설정된 보존 기간을 기준으로 오래된 신호 정리
+func (s *SignalRetentionService) ScheduledCleanup() {
+	cutoff := time.Now().Add(-s.retention)
+	deleted, err := s.PurgeOlderThan(cutoff)
+	if err != nil {
+		log.Printf("Error cleaning up signals: %v", err)
+		return
+	}
+	log.Printf("Signal cleanup completed: %d signals older than %s removed", deleted, cutoff.Format("2006-01-02"))
+}
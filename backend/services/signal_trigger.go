@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignalTrigger 신호를 자동으로 생성하는 시점 정책
+type SignalTrigger string
+
+const (
+	TriggerPriceUpdate SignalTrigger = "price_update" // 가격 갱신마다 (장 마감 재계산 포함, 기본값)
+	TriggerDailyClose  SignalTrigger = "daily_close"  // 장 마감 후에만
+	TriggerSchedule    SignalTrigger = "schedule"     // 고정 주기로만
+	TriggerManual      SignalTrigger = "manual"       // 자동 생성 없음 (API/관리자 요청으로만)
+)
+
+// SignalEvent 신호 생성을 일으킬 수 있는 사건
+type SignalEvent string
+
+const (
+	EventPriceUpdate SignalEvent = "price_update"
+	EventDailyClose  SignalEvent = "daily_close"
+	EventScheduled   SignalEvent = "scheduled"
+	EventManual      SignalEvent = "manual"
+)
+
+// ParseSignalTrigger 설정 값을 정책으로 변환 (빈 값은 기본값 price_update)
+func ParseSignalTrigger(value string) (SignalTrigger, error) {
+	switch trigger := SignalTrigger(strings.ToLower(strings.TrimSpace(value))); trigger {
+	case "":
+		return TriggerPriceUpdate, nil
+	case TriggerPriceUpdate, TriggerDailyClose, TriggerSchedule, TriggerManual:
+		return trigger, nil
+	default:
+		return "", fmt.Errorf("unknown signal trigger %q (price_update, daily_close, schedule, manual)", value)
+	}
+}
+
+// Allows 정책상 event 로 신호를 생성해도 되는지 여부
+// 수동 요청은 정책과 관계없이 항상 허용한다.
+func (t SignalTrigger) Allows(event SignalEvent) bool {
+	switch event {
+	case EventManual:
+		return true
+	case EventPriceUpdate:
+		return t == TriggerPriceUpdate
+	case EventDailyClose:
+		return t == TriggerPriceUpdate || t == TriggerDailyClose
+	case EventScheduled:
+		return t == TriggerSchedule
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// ErrStalePrice 최신 가격이 너무 오래되어 신호를 만들지 않음
+var ErrStalePrice = errors.New("latest price is stale")
+
+// StalePriceAction 최신 가격이 오래됐을 때의 처리
+type StalePriceAction string
+
+const (
+	StalePriceSkip      StalePriceAction = "skip"      // 신호를 만들지 않는다
+	StalePriceDowngrade StalePriceAction = "downgrade" // 신호는 만들되 신뢰도를 낮춘다
+)
+
+// DefaultStaleConfidence downgrade 일 때 오래된 가격으로 만든 신호의 최대 신뢰도
+const DefaultStaleConfidence = 0.3
+
+// ParseStalePriceAction 설정 문자열을 처리 방식으로 변환 (빈 값이면 skip)
+func ParseStalePriceAction(value string) (StalePriceAction, error) {
+	switch action := StalePriceAction(strings.ToLower(strings.TrimSpace(value))); action {
+	case "":
+		return StalePriceSkip, nil
+	case StalePriceSkip, StalePriceDowngrade:
+		return action, nil
+	default:
+		return "", fmt.Errorf("invalid stale price action %q, expected skip or downgrade", value)
+	}
+}
+
+// StalePriceGuard 신호의 기준이 되는 최신 가격의 최대 나이
+// 수집이 멈춘 뒤 며칠 전 가격으로 새 BUY/SELL 신호를 내지 않도록 한다. MaxAge 가 0 이면 검사하지 않는다.
+type StalePriceGuard struct {
+	MaxAge     time.Duration
+	Action     StalePriceAction
+	Confidence float64 // Action 이 downgrade 일 때 신뢰도 상한 (0 이하면 DefaultStaleConfidence)
+}
+
+// Stale price 가 now 기준 MaxAge 보다 오래됐는지 여부
+func (g StalePriceGuard) Stale(price models.StockPrice, now time.Time) bool {
+	return g.MaxAge > 0 && now.Sub(price.Timestamp) > g.MaxAge
+}
+
+// Downgrade 신뢰도를 상한으로 낮추고 (강도는 같은 비율로) 근거에 오래된 가격임을 남긴다
+func (g StalePriceGuard) Downgrade(signal *models.TradingSignal) {
+	ceiling := g.Confidence
+	if ceiling <= 0 {
+		ceiling = DefaultStaleConfidence
+	}
+	if signal.Confidence > ceiling {
+		signal.Strength *= ceiling / signal.Confidence
+		signal.Confidence = ceiling
+	}
+
+	var reasons []string
+	if signal.Reasons != "" {
+		json.Unmarshal([]byte(signal.Reasons), &reasons)
+	}
+	if data, err := json.Marshal(append(reasons, ReasonStalePrice)); err == nil {
+		signal.Reasons = string(data)
+	}
+}
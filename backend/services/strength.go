@@ -0,0 +1,37 @@
+package services
+
+import (
+	"fmt"
+	"math"
+)
+
+// StrengthMapping 신뢰도(0~1)를 신호 강도로 바꾸는 연속 선형 매핑
+// 신뢰도 0 은 Floor, 1 은 Ceiling 으로 대응한다.
+type StrengthMapping struct {
+	Floor   float64
+	Ceiling float64
+}
+
+// DefaultStrengthMapping 기본 매핑 (0.3 ~ 1.0)
+var DefaultStrengthMapping = StrengthMapping{Floor: 0.3, Ceiling: 1.0}
+
+// Strength 신뢰도에 대응하는 신호 강도 (소수 둘째 자리로 반올림)
+func (m StrengthMapping) Strength(confidence float64) float64 {
+	if math.IsNaN(confidence) || confidence < 0 {
+		confidence = 0
+	}
+	if confidence > 1 {
+		confidence = 1
+	}
+
+	strength := m.Floor + (m.Ceiling-m.Floor)*confidence
+	return math.Round(strength*100) / 100
+}
+
+// Validate Floor/Ceiling 이 0~1 사이이고 Floor 가 Ceiling 을 넘지 않는지 확인
+func (m StrengthMapping) Validate() error {
+	if math.IsNaN(m.Floor) || math.IsNaN(m.Ceiling) || m.Floor < 0 || m.Ceiling > 1 || m.Floor > m.Ceiling {
+		return fmt.Errorf("invalid signal strength range %.2f~%.2f, expected 0 <= floor <= ceiling <= 1", m.Floor, m.Ceiling)
+	}
+	return nil
+}
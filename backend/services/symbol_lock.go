@@ -0,0 +1,87 @@
+package services
+
+import (
+	"sort"
+	"sync"
+)
+
+// SymbolLocks 종목별 읽기/쓰기 잠금
+// 수집기/가져오기(쓰기)와 신호 생성(최근 봉 읽기)이 같은 종목에 동시에 접근할 때
+// 절반만 반영된 봉 묶음을 읽지 않도록 조율한다. 한 프로세스 안에서만 유효하다.
+type SymbolLocks struct {
+	mu    sync.Mutex
+	locks map[string]*symbolLock
+}
+
+type symbolLock struct {
+	sync.RWMutex
+	refs int // 잠금을 쥐었거나 기다리는 고루틴 수 (0 이 되면 맵에서 제거)
+}
+
+func NewSymbolLocks() *SymbolLocks {
+	return &SymbolLocks{locks: make(map[string]*symbolLock)}
+}
+
+// DefaultSymbolLocks 수집기, 가격 가져오기, 신호 생성이 함께 쓰는 잠금
+var DefaultSymbolLocks = NewSymbolLocks()
+
+// Lock 종목 쓰기 잠금 (반환한 함수로 해제)
+func (l *SymbolLocks) Lock(symbol string) func() {
+	lock := l.acquire(symbol)
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.release(symbol)
+	}
+}
+
+// RLock 종목 읽기 잠금 (여러 읽기는 동시에 허용, 반환한 함수로 해제)
+func (l *SymbolLocks) RLock(symbol string) func() {
+	lock := l.acquire(symbol)
+	lock.RLock()
+	return func() {
+		lock.RUnlock()
+		l.release(symbol)
+	}
+}
+
+// LockAll 여러 종목 쓰기 잠금 (교착을 피하려고 종목코드 순으로 잡는다)
+func (l *SymbolLocks) LockAll(symbols []string) func() {
+	sorted := append([]string(nil), symbols...)
+	sort.Strings(sorted)
+
+	unlocks := make([]func(), 0, len(sorted))
+	for i, symbol := range sorted {
+		if i > 0 && symbol == sorted[i-1] {
+			continue
+		}
+		unlocks = append(unlocks, l.Lock(symbol))
+	}
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
+
+func (l *SymbolLocks) acquire(symbol string) *symbolLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock, ok := l.locks[symbol]
+	if !ok {
+		lock = &symbolLock{}
+		l.locks[symbol] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+func (l *SymbolLocks) release(symbol string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock := l.locks[symbol]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, symbol)
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTickerCatalogRefresh 해외 종목 카탈로그를 다시 받아 오는 기본 주기
+const DefaultTickerCatalogRefresh = 6 * time.Hour
+
+// tickerCatalogRetryDelay 카탈로그를 받아 오지 못한 거래소를 요청 중에 다시 받아 오기까지 기다리는 시간
+const tickerCatalogRetryDelay = time.Minute
+
+// ErrTickerCatalogUnavailable 카탈로그를 받아 올 수 없음 (종목 목록 조회가 설정되지 않음)
+var ErrTickerCatalogUnavailable = errors.New("ticker catalog is not configured")
+
+// TickerCatalogExchanges 카탈로그를 유지하는 해외증시구분코드
+var TickerCatalogExchanges = []string{apimodels.ExchangeNY, apimodels.ExchangeNASDAQ, apimodels.ExchangeAMEX}
+
+// TickerFetcher 거래소의 전체 종목 목록 조회 (foreign.ForeignStockTickerService.GetAllForeignStockTickers)
+type TickerFetcher func(exchangeCode string) ([]apimodels.ForeignStockData, error)
+
+// TickerNameLoader 종목코드 → 영문 종목명 (DB증권 종목 목록에는 한글명만 있다)
+type TickerNameLoader func() (map[string]string, error)
+
+// TickerCatalogEntry 카탈로그 종목
+type TickerCatalogEntry struct {
+	Symbol      string `json:"symbol"`
+	KoreanName  string `json:"korean_name"`
+	EnglishName string `json:"english_name,omitempty"`
+	Sector      string `json:"sector"`
+	Exchange    string `json:"exchange"` // 해외증시구분코드 (NY, NA, AM)
+	Precision   int    `json:"precision"`
+}
+
+// TickerCatalogSnapshot 한 거래소의 카탈로그와 받아 온 시각
+type TickerCatalogSnapshot struct {
+	Exchange  string
+	Entries   []TickerCatalogEntry
+	FetchedAt time.Time
+}
+
+// TickerCatalog 거래소별 해외 종목 목록 캐시
+// 주기적으로 Refresh 하고, 요청은 캐시에서 응답한다. maxAge 보다 오래된 카탈로그는 그대로 응답하면서 백그라운드에서 갱신하고,
+// 캐시가 없을 때만 요청 중에 받아 온다. 같은 거래소를 동시에 받아 오는 요청은 한 번만 API 를 부르며,
+// 받아 오지 못한 거래소는 tickerCatalogRetryDelay 동안 다시 부르지 않는다.
+type TickerCatalog struct {
+	mu         sync.RWMutex
+	fetch      TickerFetcher
+	names      TickerNameLoader
+	maxAge     time.Duration
+	snapshots  map[string]*TickerCatalogSnapshot
+	refreshing map[string]*catalogRefresh // 거래소별 진행 중인 갱신
+	failures   map[string]catalogFailure  // 거래소별 마지막 갱신 실패
+	now        func() time.Time
+}
+
+// catalogRefresh 진행 중인 갱신 (done 이 닫히면 결과를 읽는다)
+type catalogRefresh struct {
+	done     chan struct{}
+	snapshot *TickerCatalogSnapshot
+	err      error
+}
+
+// catalogFailure 갱신에 실패한 시각과 에러
+type catalogFailure struct {
+	at  time.Time
+	err error
+}
+
+// NewTickerCatalog fetch 로 종목 목록을 받아 오는 카탈로그 (maxAge 가 0 이하면 기본 주기)
+func NewTickerCatalog(fetch TickerFetcher, maxAge time.Duration) *TickerCatalog {
+	if maxAge <= 0 {
+		maxAge = DefaultTickerCatalogRefresh
+	}
+	return &TickerCatalog{
+		fetch:      fetch,
+		maxAge:     maxAge,
+		snapshots:  make(map[string]*TickerCatalogSnapshot),
+		refreshing: make(map[string]*catalogRefresh),
+		failures:   make(map[string]catalogFailure),
+		now:        time.Now,
+	}
+}
+
+// DefaultTickerCatalog 라우터와 갱신 스케줄러가 함께 쓰는 카탈로그 (main 에서 WithFetcher 로 설정)
+var DefaultTickerCatalog = NewTickerCatalog(nil, DefaultTickerCatalogRefresh)
+
+// WithFetcher 종목 목록 조회 설정
+func (c *TickerCatalog) WithFetcher(fetch TickerFetcher) *TickerCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetch = fetch
+	return c
+}
+
+// WithNames 영문 종목명 조회 설정
+func (c *TickerCatalog) WithNames(names TickerNameLoader) *TickerCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names = names
+	return c
+}
+
+// WithMaxAge 요청 시 다시 받아 올 카탈로그 나이 설정 (0 이하면 무시)
+func (c *TickerCatalog) WithMaxAge(maxAge time.Duration) *TickerCatalog {
+	if maxAge > 0 {
+		c.mu.Lock()
+		c.maxAge = maxAge
+		c.mu.Unlock()
+	}
+	return c
+}
+
+// Get 거래소 카탈로그 (캐시가 있으면 API 를 기다리지 않는다)
+func (c *TickerCatalog) Get(exchangeCode string) (*TickerCatalogSnapshot, error) {
+	c.mu.RLock()
+	snapshot := c.snapshots[exchangeCode]
+	failure, failed := c.failures[exchangeCode]
+	_, refreshing := c.refreshing[exchangeCode]
+	c.mu.RUnlock()
+	backingOff := failed && c.now().Sub(failure.at) < tickerCatalogRetryDelay
+
+	if snapshot != nil {
+		if c.now().Sub(snapshot.FetchedAt) >= c.maxAge && !refreshing && !backingOff {
+			go func() {
+				if _, err := c.Refresh(exchangeCode); err != nil {
+					log.Printf("Warning: serving stale %s ticker catalog: %v", exchangeCode, err)
+				}
+			}()
+		}
+		return snapshot, nil
+	}
+
+	if backingOff {
+		return nil, failure.err
+	}
+	return c.Refresh(exchangeCode)
+}
+
+// Refresh 거래소 종목 목록을 다시 받아 캐시 교체 (이미 받아 오는 중이면 그 결과를 기다린다)
+func (c *TickerCatalog) Refresh(exchangeCode string) (*TickerCatalogSnapshot, error) {
+	c.mu.Lock()
+	if call, ok := c.refreshing[exchangeCode]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.snapshot, call.err
+	}
+	fetch, names := c.fetch, c.names
+	if fetch == nil {
+		c.mu.Unlock()
+		return nil, ErrTickerCatalogUnavailable
+	}
+	call := &catalogRefresh{done: make(chan struct{})}
+	c.refreshing[exchangeCode] = call
+	c.mu.Unlock()
+
+	call.snapshot, call.err = c.fetchSnapshot(exchangeCode, fetch, names)
+
+	c.mu.Lock()
+	delete(c.refreshing, exchangeCode)
+	if call.err != nil {
+		c.failures[exchangeCode] = catalogFailure{at: c.now(), err: call.err}
+	} else {
+		c.snapshots[exchangeCode] = call.snapshot
+		delete(c.failures, exchangeCode)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.snapshot, call.err
+}
+
+// fetchSnapshot 거래소 종목 목록을 받아 영문명을 붙인 카탈로그로 변환
+func (c *TickerCatalog) fetchSnapshot(exchangeCode string, fetch TickerFetcher, names TickerNameLoader) (*TickerCatalogSnapshot, error) {
+	tickers, err := fetch(exchangeCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s ticker catalog: %w", exchangeCode, err)
+	}
+
+	var englishNames map[string]string
+	if names != nil {
+		if englishNames, err = names(); err != nil {
+			log.Printf("Warning: ticker catalog without english names: %v", err)
+		}
+	}
+
+	entries := make([]TickerCatalogEntry, 0, len(tickers))
+	for _, ticker := range tickers {
+		symbol := strings.TrimSpace(ticker.StockCode)
+		if symbol == "" {
+			continue
+		}
+		entries = append(entries, TickerCatalogEntry{
+			Symbol:      symbol,
+			KoreanName:  strings.TrimSpace(ticker.KoreanName),
+			EnglishName: englishNames[symbol],
+			Sector:      strings.TrimSpace(ticker.SectorName),
+			Exchange:    exchangeCode,
+			Precision:   ticker.Precision,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Symbol < entries[j].Symbol })
+
+	return &TickerCatalogSnapshot{Exchange: exchangeCode, Entries: entries, FetchedAt: c.now()}, nil
+}
+
+// RefreshAll 모든 거래소 카탈로그 갱신 (실패한 거래소는 이전 카탈로그 유지)
+func (c *TickerCatalog) RefreshAll() {
+	for _, exchangeCode := range TickerCatalogExchanges {
+		if _, err := c.Refresh(exchangeCode); err != nil {
+			log.Printf("Ticker catalog refresh failed: %v", err)
+		}
+	}
+}
+
+// FilterTickerCatalog 업종(대소문자 무시 일치)과 이름 검색어(종목코드/한글명/영문명 부분 일치)로 거르기
+func FilterTickerCatalog(entries []TickerCatalogEntry, sector, query string) []TickerCatalogEntry {
+	sector = strings.TrimSpace(sector)
+	query = strings.ToLower(strings.TrimSpace(query))
+	if sector == "" && query == "" {
+		return entries
+	}
+
+	filtered := make([]TickerCatalogEntry, 0)
+	for _, entry := range entries {
+		if sector != "" && !strings.EqualFold(entry.Sector, sector) {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(entry.Symbol), query) &&
+			!strings.Contains(strings.ToLower(entry.KoreanName), query) &&
+			!strings.Contains(strings.ToLower(entry.EnglishName), query) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// StockEnglishNames stocks 에 등록된 미국 종목 중 이름이 영문인 종목의 이름
+// 종목 동기화로 추가된 종목은 한글명이므로 시드/관리자가 등록한 영문명만 쓴다.
+func StockEnglishNames(db *gorm.DB) TickerNameLoader {
+	return func() (map[string]string, error) {
+		var stocks []models.Stock
+		if err := db.Select("symbol", "name").Where("market = ?", apimodels.RegionUS).Find(&stocks).Error; err != nil {
+			return nil, fmt.Errorf("failed to load stock names: %w", err)
+		}
+		names := make(map[string]string, len(stocks))
+		for _, stock := range stocks {
+			if name := strings.TrimSpace(stock.Name); name != "" && !containsHangul(name) {
+				names[stock.Symbol] = name
+			}
+		}
+		return names, nil
+	}
+}
+
+func containsHangul(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// tickerSyncCheckpoint 진행 상황(커서/집계)을 저장하는 종목 수 간격
+const tickerSyncCheckpoint = 100
+
+// TickerHash 종목 목록에서 stocks 에 반영하는 필드(이름, 업종, 소수점자리수)의 해시
+func TickerHash(ticker apimodels.ForeignStockData) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strings.TrimSpace(ticker.KoreanName),
+		strings.TrimSpace(ticker.SectorName),
+		strconv.Itoa(ticker.Precision),
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// StockExchangeLoader 종목 동기화로 stocks 에 저장된 미국 종목의 거래소를 읽는 ExchangeLoader
+func StockExchangeLoader(db *gorm.DB) foreign.ExchangeLoader {
+	return func(stockCode string) (string, bool, error) {
+		var stock models.Stock
+		err := db.Select("exchange").
+			Where("symbol = ? AND market = ?", stockCode, apimodels.RegionUS).
+			Limit(1).Find(&stock).Error
+		if err != nil {
+			return "", false, fmt.Errorf("failed to look up exchange of %s: %w", stockCode, err)
+		}
+		return stock.Exchange, stock.Exchange != "", nil
+	}
+}
+
+// TickerSyncService 해외 거래소 종목 목록을 stocks 에 동기화
+// 해시가 바뀐 종목만 쓰고, 목록에서 빠진 종목은 비활성화한다. 종목코드 순으로 처리하며
+// 주기적으로 커서를 저장하므로 중간에 중단되면 다음 실행이 커서 다음 종목부터 이어서 진행한다.
+type TickerSyncService struct {
+	db *gorm.DB
+}
+
+func NewTickerSyncService(db *gorm.DB) *TickerSyncService {
+	return &TickerSyncService{db: db}
+}
+
+// Sync exchangeCode(FY, FN, FA) 거래소의 종목 목록을 반영하고 실행 기록(추가/갱신/비활성 수) 반환
+func (s *TickerSyncService) Sync(exchangeCode string, tickers []apimodels.ForeignStockData) (*models.TickerSyncRun, error) {
+	run, err := s.startRun(exchangeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]apimodels.ForeignStockData, 0, len(tickers))
+	seen := make(map[string]bool, len(tickers))
+	exchange := ""
+	for _, ticker := range tickers {
+		ticker.StockCode = strings.TrimSpace(ticker.StockCode)
+		if ticker.StockCode == "" || seen[ticker.StockCode] {
+			continue
+		}
+		seen[ticker.StockCode] = true
+		sorted = append(sorted, ticker)
+		if exchange == "" {
+			exchange = ticker.Exchange
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StockCode < sorted[j].StockCode })
+
+	processed := 0
+	for _, ticker := range sorted {
+		if ticker.StockCode <= run.Cursor {
+			continue // 중단된 실행에서 이미 처리한 종목
+		}
+		if err := s.syncTicker(run, ticker); err != nil {
+			return run, err
+		}
+		run.Cursor = ticker.StockCode
+
+		processed++
+		if processed%tickerSyncCheckpoint == 0 {
+			if err := s.db.Save(run).Error; err != nil {
+				return run, fmt.Errorf("failed to checkpoint ticker sync: %w", err)
+			}
+		}
+	}
+
+	// 목록이 비어 있으면 API 이상일 수 있으므로 비활성화하지 않는다
+	if exchange != "" {
+		if err := s.deactivateDelisted(run, exchange, seen); err != nil {
+			return run, err
+		}
+	}
+
+	now := time.Now()
+	run.Status = models.TickerSyncDone
+	run.FinishedAt = &now
+	if err := s.db.Save(run).Error; err != nil {
+		return run, fmt.Errorf("failed to save ticker sync summary: %w", err)
+	}
+	return run, nil
+}
+
+// startRun 중단된 실행이 있으면 이어서, 없으면 새 실행 시작
+func (s *TickerSyncService) startRun(exchangeCode string) (*models.TickerSyncRun, error) {
+	var run models.TickerSyncRun
+	err := s.db.Where("exchange = ? AND status = ?", exchangeCode, models.TickerSyncRunning).
+		Order("id DESC").Limit(1).Find(&run).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticker sync run: %w", err)
+	}
+	if run.ID != 0 {
+		return &run, nil
+	}
+
+	run = models.TickerSyncRun{Exchange: exchangeCode, Status: models.TickerSyncRunning}
+	if err := s.db.Create(&run).Error; err != nil {
+		return nil, fmt.Errorf("failed to start ticker sync run: %w", err)
+	}
+	return &run, nil
+}
+
+// syncTicker 새 종목은 비활성 상태로 추가하고, 해시가 바뀐 종목만 갱신
+func (s *TickerSyncService) syncTicker(run *models.TickerSyncRun, ticker apimodels.ForeignStockData) error {
+	hash := TickerHash(ticker)
+
+	var existing models.Stock
+	if err := s.db.Where("symbol = ?", ticker.StockCode).Limit(1).Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to look up stock %s: %w", ticker.StockCode, err)
+	}
+
+	if existing.ID == 0 {
+		stock := models.Stock{
+			Symbol:     ticker.StockCode,
+			Name:       ticker.KoreanName,
+			Market:     apimodels.RegionUS,
+			Exchange:   ticker.Exchange,
+			Sector:     ticker.SectorName,
+			Precision:  ticker.Precision,
+			TickerHash: hash,
+		}
+		if err := s.db.Create(&stock).Error; err != nil {
+			return fmt.Errorf("failed to create stock %s: %w", stock.Symbol, err)
+		}
+		// is_active(기본 true)와 precision(기본 2)은 Create 가 제로값을 건너뛰므로 생성 후 따로 쓴다 (수집 대상은 관리자가 활성화, 소수 자릿수 0 유지)
+		err := s.db.Model(&stock).Updates(map[string]interface{}{
+			"is_active": false,
+			"precision": ticker.Precision,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to deactivate new stock %s: %w", stock.Symbol, err)
+		}
+		run.Added++
+		return nil
+	}
+
+	if existing.TickerHash == hash {
+		run.Unchanged++
+		return nil
+	}
+
+	err := s.db.Model(&existing).Updates(map[string]interface{}{
+		"name":        ticker.KoreanName,
+		"sector":      ticker.SectorName,
+		"precision":   ticker.Precision,
+		"ticker_hash": hash,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update stock %s: %w", existing.Symbol, err)
+	}
+	run.Updated++
+	return nil
+}
+
+// deactivateDelisted 이전 동기화로 등록됐지만 이번 목록에 없는 거래소 종목 비활성화
+func (s *TickerSyncService) deactivateDelisted(run *models.TickerSyncRun, exchange string, listed map[string]bool) error {
+	var synced []models.Stock
+	err := s.db.Select("id", "symbol").
+		Where("market = ? AND exchange = ? AND is_active = ? AND ticker_hash <> ''", apimodels.RegionUS, exchange, true).
+		Find(&synced).Error
+	if err != nil {
+		return fmt.Errorf("failed to list %s stocks: %w", exchange, err)
+	}
+
+	var delisted []uint
+	var delistedSymbols []string
+	for _, stock := range synced {
+		if !listed[stock.Symbol] {
+			delisted = append(delisted, stock.ID)
+			delistedSymbols = append(delistedSymbols, stock.Symbol)
+		}
+	}
+	if len(delisted) == 0 {
+		return nil
+	}
+
+	if err := s.db.Model(&models.Stock{}).Where("id IN ?", delisted).Update("is_active", false).Error; err != nil {
+		return fmt.Errorf("failed to deactivate delisted stocks: %w", err)
+	}
+	DefaultPriceBook.Remove(delistedSymbols...)
+	run.Deactivated += len(delisted)
+	return nil
+}
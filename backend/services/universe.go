@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"gorm.io/gorm"
+)
+
+// 시장별 종목코드 형식 (국내 6자리 단축코드, 해외 티커)
+var symbolPatterns = map[string]*regexp.Regexp{
+	apimodels.RegionKR: regexp.MustCompile(`^[0-9A-Z]{6}$`),
+	apimodels.RegionUS: regexp.MustCompile(`^[A-Z][A-Z0-9.\-]{0,9}$`),
+}
+
+// UniverseEntry 수집 대상 종목 변경 요청 한 건
+// 기존 종목은 지정한 필드만 바꾸고, 없는 종목은 새로 등록한다.
+type UniverseEntry struct {
+	Symbol   string `json:"symbol"`
+	Market   string `json:"market"` // KR, US 또는 NASDAQ, 뉴욕 같은 시장 별칭
+	Name     string `json:"name"`
+	Exchange string `json:"exchange"`
+	Priority *int   `json:"priority"`
+	IsActive *bool  `json:"is_active"`
+}
+
+// Normalize 종목코드를 대문자로, 시장을 국가 구분(KR/US)으로 정규화하고 형식 검증
+func (e *UniverseEntry) Normalize() error {
+	e.Symbol = strings.ToUpper(strings.TrimSpace(e.Symbol))
+	if e.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+
+	market, ok := apimodels.ResolveMarket(e.Market)
+	if !ok || market.Name == apimodels.MarketIndex {
+		return fmt.Errorf("invalid market %q for %s, expected KR or US", e.Market, e.Symbol)
+	}
+	e.Market = market.Region
+
+	if !symbolPatterns[e.Market].MatchString(e.Symbol) && !client.IsTestSymbol(e.Symbol) {
+		return fmt.Errorf("invalid %s symbol %q", e.Market, e.Symbol)
+	}
+	return nil
+}
+
+// UniverseService 수집 대상 종목(universe) 조회/변경
+type UniverseService struct {
+	db *gorm.DB
+}
+
+func NewUniverseService(db *gorm.DB) *UniverseService {
+	return &UniverseService{db: db}
+}
+
+// List 전체 종목을 우선순위 내림차순, 종목코드 순으로 조회 (비활성 종목 포함)
+func (s *UniverseService) List() ([]models.Stock, error) {
+	var stocks []models.Stock
+	if err := s.db.Order("priority DESC, symbol ASC").Find(&stocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list universe: %w", err)
+	}
+	return stocks, nil
+}
+
+// ActiveStocks 시장(KR/US)의 활성 종목을 우선순위 내림차순, 종목코드 순으로 조회
+func (s *UniverseService) ActiveStocks(market string) ([]models.Stock, error) {
+	var stocks []models.Stock
+	err := s.db.Where("market = ? AND is_active = ?", market, true).
+		Order("priority DESC, symbol ASC").
+		Find(&stocks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active %s stocks: %w", market, err)
+	}
+	return stocks, nil
+}
+
+// Apply 변경 요청을 한 트랜잭션으로 반영 (Normalize 를 통과한 entry 만 전달해야 한다)
+// 삭제된 종목을 다시 추가하면 복구한다. 변경 사항은 다음 수집 주기부터 적용되고, 비활성화한 종목은 가격 북에서 바로 뺀다.
+func (s *UniverseService) Apply(entries []UniverseEntry) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			if err := applyUniverseEntry(tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsActive != nil && !*entry.IsActive {
+			DefaultPriceBook.Remove(entry.Symbol)
+		}
+	}
+	return nil
+}
+
+func applyUniverseEntry(tx *gorm.DB, entry UniverseEntry) error {
+	var stock models.Stock
+	err := tx.Unscoped().Where("symbol = ?", entry.Symbol).First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		stock = models.Stock{
+			Symbol:   entry.Symbol,
+			Name:     entry.Name,
+			Market:   entry.Market,
+			Exchange: entry.Exchange,
+			IsActive: true,
+		}
+		if stock.Name == "" {
+			stock.Name = entry.Symbol
+		}
+		if entry.Priority != nil {
+			stock.Priority = *entry.Priority
+		}
+		if entry.IsActive != nil {
+			stock.IsActive = *entry.IsActive
+		}
+		// IsActive 가 false 여도 기본값(true)으로 덮이지 않도록 명시적으로 저장
+		if err := tx.Create(&stock).Error; err != nil {
+			return fmt.Errorf("failed to add %s: %w", entry.Symbol, err)
+		}
+		return tx.Model(&stock).Update("is_active", stock.IsActive).Error
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", entry.Symbol, err)
+	}
+
+	updates := map[string]interface{}{
+		"market":     entry.Market,
+		"deleted_at": nil,
+	}
+	if entry.Name != "" {
+		updates["name"] = entry.Name
+	}
+	if entry.Exchange != "" {
+		updates["exchange"] = entry.Exchange
+	}
+	if entry.Priority != nil {
+		updates["priority"] = *entry.Priority
+	}
+	if entry.IsActive != nil {
+		updates["is_active"] = *entry.IsActive
+	}
+	if err := tx.Unscoped().Model(&stock).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update %s: %w", entry.Symbol, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"stock-recommender/backend/models"
+)
+
+// WalkForwardWindow 구간 길이 (봉 수)
+// 인샘플 InSample 봉으로 전략을 정한 뒤 바로 다음 OutOfSample 봉에서 검증하고, OutOfSample 만큼 밀어 반복한다.
+type WalkForwardWindow struct {
+	InSample    int `json:"in_sample"`
+	OutOfSample int `json:"out_of_sample"`
+}
+
+// StrategyFactory 인샘플 봉(시간순)으로 해당 구간에 쓸 전략을 정한다 (파라미터 선택, 후보 중 선택 등)
+type StrategyFactory func(inSample []models.StockPrice) BacktestStrategy
+
+// ParameterizedStrategy 지표 기간을 지정해 만든 전략 (워크포워드 구간 결과에 고른 기간을 남긴다)
+type ParameterizedStrategy interface {
+	BacktestStrategy
+	Params() *IndicatorParams
+}
+
+// RuleParamGrid 워크포워드에서 구간마다 비교하는 규칙 전략 지표 기간 후보 (RSI × 볼린저, 나머지는 전역 기본값)
+// 모든 후보가 전략이 지표를 계산하는 50봉 안에 들어가도록 기간을 잡는다.
+var RuleParamGrid = ruleParamGrid([]int{9, 14, 21}, []int{15, 20})
+
+func ruleParamGrid(rsiPeriods, bollingerPeriods []int) []IndicatorParams {
+	grid := make([]IndicatorParams, 0, len(rsiPeriods)*len(bollingerPeriods))
+	for _, rsi := range rsiPeriods {
+		for _, bollinger := range bollingerPeriods {
+			grid = append(grid, DefaultIndicatorParams.Merge(IndicatorParams{RSIPeriod: rsi, BollingerPeriod: bollinger}))
+		}
+	}
+	return grid
+}
+
+// WalkForwardSegment 인샘플/아웃오브샘플 한 쌍의 결과
+type WalkForwardSegment struct {
+	InSampleFrom    time.Time        `json:"in_sample_from"`
+	InSampleTo      time.Time        `json:"in_sample_to"`
+	OutOfSampleFrom time.Time        `json:"out_of_sample_from"`
+	OutOfSampleTo   time.Time        `json:"out_of_sample_to"`
+	Params          *IndicatorParams `json:"params,omitempty"` // 인샘플에서 고른 지표 기간 (ParameterizedStrategy 일 때)
+	InSample        BacktestResult   `json:"in_sample"`
+	OutOfSample     BacktestResult   `json:"out_of_sample"`
+}
+
+// WalkForwardResult 구간별 결과와 아웃오브샘플 구간을 이어 붙인 합산 결과
+type WalkForwardResult struct {
+	Window    WalkForwardWindow    `json:"window"`
+	Segments  []WalkForwardSegment `json:"segments"`
+	Aggregate BacktestResult       `json:"aggregate"` // 수익률은 구간 복리, 수수료/슬리피지는 구간 합계
+}
+
+// WalkForward bars 를 순차 구간으로 나눠 구간마다 인샘플로 전략을 정하고 아웃오브샘플에서 평가
+// 끝에 아웃오브샘플 길이를 채우지 못하는 봉은 평가하지 않는다.
+func (b *Backtester) WalkForward(bars []models.StockPrice, window WalkForwardWindow, factory StrategyFactory) (*WalkForwardResult, error) {
+	if window.InSample < b.lookback {
+		return nil, fmt.Errorf("in-sample window must be at least %d bars, got %d", b.lookback, window.InSample)
+	}
+	if window.OutOfSample <= 0 {
+		return nil, fmt.Errorf("out-of-sample window must be positive, got %d", window.OutOfSample)
+	}
+
+	bars = sortedBars(bars)
+	if len(bars) < window.InSample+window.OutOfSample {
+		return nil, fmt.Errorf("insufficient bars for walk-forward: %d, need at least %d",
+			len(bars), window.InSample+window.OutOfSample)
+	}
+
+	result := &WalkForwardResult{Window: window, Segments: []WalkForwardSegment{}}
+	aggregate := BacktestResult{Strategy: "walk_forward", Trades: []BacktestTrade{}}
+	var equity []float64
+	growth, gross := 1.0, 1.0
+
+	for start := 0; start+window.InSample+window.OutOfSample <= len(bars); start += window.OutOfSample {
+		inSample := bars[start : start+window.InSample : start+window.InSample]
+		oosStart := start + window.InSample
+		oosEnd := oosStart + window.OutOfSample
+
+		strategy := factory(inSample)
+		inResult, _ := b.run(inSample, strategy)
+		// 아웃오브샘플 첫 봉부터 판단하도록 지표 계산에 필요한 직전 봉을 앞에 붙인다
+		outResult, outEquity := b.run(bars[oosStart-b.lookback+1:oosEnd], strategy)
+
+		segment := WalkForwardSegment{
+			InSampleFrom:    inSample[0].Timestamp,
+			InSampleTo:      inSample[len(inSample)-1].Timestamp,
+			OutOfSampleFrom: bars[oosStart].Timestamp,
+			OutOfSampleTo:   bars[oosEnd-1].Timestamp,
+			InSample:        inResult,
+			OutOfSample:     outResult,
+		}
+		if parameterized, ok := strategy.(ParameterizedStrategy); ok {
+			segment.Params = parameterized.Params()
+		}
+		result.Segments = append(result.Segments, segment)
+
+		for _, value := range outEquity {
+			equity = append(equity, growth*value)
+		}
+		growth *= 1 + outResult.TotalReturn/100
+		gross *= 1 + outResult.GrossReturn/100
+		aggregate.Trades = append(aggregate.Trades, outResult.Trades...)
+		aggregate.Commission += outResult.Commission
+		aggregate.Slippage += outResult.Slippage
+	}
+
+	wins := 0
+	for _, trade := range aggregate.Trades {
+		if trade.Return > 0 {
+			wins++
+		}
+	}
+	aggregate.TradeCount = len(aggregate.Trades)
+	if aggregate.TradeCount > 0 {
+		aggregate.WinRate = float64(wins) / float64(aggregate.TradeCount) * 100
+	}
+	aggregate.TotalReturn = (growth - 1) * 100
+	aggregate.GrossReturn = (gross - 1) * 100
+	aggregate.MaxDrawdown, _, _ = MaxDrawdown(equity)
+	result.Aggregate = aggregate
+	return result, nil
+}
+
+// BestOf 인샘플 수익률(비용 차감 후)이 가장 높은 후보를 고르는 StrategyFactory (동률이면 앞선 후보)
+func (b *Backtester) BestOf(candidates ...BacktestStrategy) StrategyFactory {
+	return func(inSample []models.StockPrice) BacktestStrategy {
+		var best BacktestStrategy
+		bestReturn := 0.0
+		for _, candidate := range candidates {
+			result, _ := b.run(inSample, candidate)
+			if best == nil || result.TotalReturn > bestReturn {
+				best, bestReturn = candidate, result.TotalReturn
+			}
+		}
+		return best
+	}
+}
+
+// BestParams grid 의 지표 기간마다 규칙 전략을 만들어 인샘플 수익률이 가장 높은 기간을 고르는 StrategyFactory
+func (b *Backtester) BestParams(indicators *IndicatorService, grid []IndicatorParams) StrategyFactory {
+	candidates := make([]BacktestStrategy, 0, len(grid))
+	for _, params := range grid {
+		candidates = append(candidates, NewRuleStrategy(indicators).WithParams(params))
+	}
+	return b.BestOf(candidates...)
+}
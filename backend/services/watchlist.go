@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+	"stock-recommender/backend/models"
+
+	"gorm.io/gorm"
+)
+
+type WatchlistService struct {
+	db *gorm.DB
+}
+
+func NewWatchlistService(db *gorm.DB) *WatchlistService {
+	return &WatchlistService{db: db}
+}
+
+// WatchedSymbols 관심 종목 중 활성 종목 목록 (종목코드와 시장이 모두 같은 종목만)
+func (s *WatchlistService) WatchedSymbols() ([]models.Stock, error) {
+	var stocks []models.Stock
+	err := s.db.Joins("JOIN watchlist_items ON watchlist_items.symbol = stocks.symbol AND watchlist_items.market = stocks.market").
+		Where("stocks.is_active = ?", true).
+		Find(&stocks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watched symbols: %w", err)
+	}
+	return stocks, nil
+}
+
+// Watch 등록된 종목을 관심 종목에 추가 (이미 있으면 그대로 둔다)
+// market 은 KR, US 처럼 stocks 에 저장된 값이며, 등록되지 않은 종목이면 gorm.ErrRecordNotFound 를 반환한다.
+func (s *WatchlistService) Watch(symbol, market string) (*models.WatchlistItem, error) {
+	var stock models.Stock
+	if err := s.db.Where("symbol = ? AND market = ?", symbol, market).First(&stock).Error; err != nil {
+		return nil, err
+	}
+
+	item := models.WatchlistItem{Symbol: symbol, Market: market}
+	if err := s.db.Where("symbol = ? AND market = ?", symbol, market).FirstOrCreate(&item).Error; err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", symbol, err)
+	}
+	return &item, nil
+}
+
+// Unwatch 관심 종목에서 제거하고 제거했는지 여부 반환
+func (s *WatchlistService) Unwatch(symbol, market string) (bool, error) {
+	result := s.db.Where("symbol = ? AND market = ?", symbol, market).Delete(&models.WatchlistItem{})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to unwatch %s: %w", symbol, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,140 @@
+package workers
+
+import (
+	"log"
+	"time"
+
+	"stock-recommender/backend/models"
+	apierrors "stock-recommender/backend/openapi/errors"
+)
+
+// BackfillStore 백필 작업 커서와 일일 호출 수 저장소
+type BackfillStore interface {
+	PendingBackfillJobs() ([]models.BackfillJob, error)
+	SaveBackfillJob(job *models.BackfillJob) error
+	BackfillCalls(day string) (int, error)
+	AddBackfillCalls(day string, n int) error
+}
+
+// DailyRangeFetcher 일봉 구간 조회 및 저장 (API 호출 한 번)
+type DailyRangeFetcher interface {
+	FetchDailyRange(symbol, market string, from, to time.Time) error
+}
+
+// BackfillScheduler 대기 중인 백필 작업을 하루 호출 예산 안에서 처리
+// 예산을 다 쓰면 다음 날(한국 시간)까지 멈추고, 구간마다 커서를 저장해 재시작하면 이어서 수집한다.
+type BackfillScheduler struct {
+	store      BackfillStore
+	fetcher    DailyRangeFetcher
+	budget     int
+	windowDays int
+	location   *time.Location // 일일 한도가 초기화되는 기준 시간대
+	stopChan   chan struct{}
+}
+
+func NewBackfillScheduler(store BackfillStore, fetcher DailyRangeFetcher, budget, windowDays int) *BackfillScheduler {
+	location, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		location = time.FixedZone("KST", 9*60*60)
+	}
+	if windowDays <= 0 {
+		windowDays = 1
+	}
+	return &BackfillScheduler{
+		store:      store,
+		fetcher:    fetcher,
+		budget:     budget,
+		windowDays: windowDays,
+		location:   location,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start interval 마다 대기 중인 작업 처리
+func (s *BackfillScheduler) Start(interval time.Duration) {
+	log.Printf("Starting backfill scheduler (budget: %d calls/day, interval: %s)", s.budget, interval)
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.RunOnce(time.Now())
+			case <-s.stopChan:
+				log.Println("Backfill scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 스케줄 중지
+func (s *BackfillScheduler) Stop() {
+	close(s.stopChan)
+}
+
+// RunOnce now 가 속한 날의 남은 예산만큼 작업을 처리하고 이번에 사용한 호출 수 반환
+func (s *BackfillScheduler) RunOnce(now time.Time) int {
+	day := now.In(s.location).Format("2006-01-02")
+	used, err := s.store.BackfillCalls(day)
+	if err != nil {
+		log.Printf("Failed to load backfill usage: %v", err)
+		return 0
+	}
+	if used >= s.budget {
+		return 0
+	}
+
+	jobs, err := s.store.PendingBackfillJobs()
+	if err != nil {
+		log.Printf("Failed to load backfill jobs: %v", err)
+		return 0
+	}
+
+	calls := 0
+	for i := range jobs {
+		job := &jobs[i]
+		for !job.Cursor.After(job.ToDate) {
+			if used+calls >= s.budget {
+				log.Printf("Backfill budget exhausted for %s (%d calls), resuming next day", day, s.budget)
+				return calls
+			}
+
+			end := job.Cursor.AddDate(0, 0, s.windowDays-1)
+			if end.After(job.ToDate) {
+				end = job.ToDate
+			}
+
+			fetchErr := s.fetcher.FetchDailyRange(job.Symbol, job.Market, job.Cursor, end)
+			calls++
+			if err := s.store.AddBackfillCalls(day, 1); err != nil {
+				log.Printf("Failed to record backfill usage: %v", err)
+			}
+
+			if fetchErr != nil {
+				// 호출 한도/일시적 오류는 커서를 그대로 두고 다음 실행에서 재시도
+				if apierrors.IsRateLimitError(fetchErr) || apierrors.IsRetryableError(fetchErr) {
+					log.Printf("Backfill paused for %s: %v", job.Symbol, fetchErr)
+					return calls
+				}
+				job.Status = models.BackfillFailed
+				job.Error = fetchErr.Error()
+				if err := s.store.SaveBackfillJob(job); err != nil {
+					log.Printf("Failed to save backfill job for %s: %v", job.Symbol, err)
+				}
+				break
+			}
+
+			job.Cursor = end.AddDate(0, 0, 1)
+			if job.Cursor.After(job.ToDate) {
+				job.Status = models.BackfillDone
+			}
+			if err := s.store.SaveBackfillJob(job); err != nil {
+				log.Printf("Failed to save backfill job for %s: %v", job.Symbol, err)
+				return calls
+			}
+		}
+	}
+	return calls
+}
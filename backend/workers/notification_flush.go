@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"log"
+	"time"
+
+	"stock-recommender/backend/config"
+)
+
+// DueNotifications 묶음 창이 닫힌 신호와 보류가 끝난 알림을 보내는 곳 (services.NotificationService)
+type DueNotifications interface {
+	FlushDue(now time.Time) (int, error)
+}
+
+// NotificationFlusher 고정 주기로 보낼 때가 된 알림을 전달하는 스케줄러
+// 신호 생성이 멈춰 있어도 묶음 창과 방해 금지 시간이 끝난 알림이 남아 있지 않도록 한다.
+type NotificationFlusher struct {
+	notifications DueNotifications
+	interval      time.Duration
+	stopChan      chan struct{}
+}
+
+// NewNotificationFlusher interval 이 0 이하면 기본 주기로 전달
+func NewNotificationFlusher(notifications DueNotifications, interval time.Duration) *NotificationFlusher {
+	if interval <= 0 {
+		interval = config.DefaultNotificationFlushInterval
+	}
+	return &NotificationFlusher{
+		notifications: notifications,
+		interval:      interval,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start 전달 스케줄 시작
+func (f *NotificationFlusher) Start() {
+	log.Printf("Starting notification flusher (interval: %s)", f.interval)
+
+	ticker := time.NewTicker(f.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				f.Flush(now)
+			case <-f.stopChan:
+				log.Println("Notification flusher stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Flush now 기준으로 보낼 때가 된 알림 전달 (실패한 알림은 다음 주기에 다시 보낸다)
+func (f *NotificationFlusher) Flush(now time.Time) int {
+	sent, err := f.notifications.FlushDue(now)
+	if err != nil {
+		log.Printf("Failed to flush notifications: %v", err)
+	}
+	return sent
+}
+
+// Stop 전달 중지
+func (f *NotificationFlusher) Stop() {
+	close(f.stopChan)
+}
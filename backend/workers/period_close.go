@@ -0,0 +1,185 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+)
+
+// PeriodBarCollector 종목의 주봉/월봉을 받아 저장 (services.PeriodBarService)
+type PeriodBarCollector interface {
+	CollectPeriodBars(ctx context.Context, stock models.Stock, granularity string) error
+}
+
+// 주/월봉 갱신 재시도/따라잡기 설정
+const (
+	periodRetryDelay  = 15 * time.Minute // 갱신에 실패한 기간을 다시 시도하기까지 기다리는 시간
+	periodCatchUpDays = 40               // 마지막으로 끝난 기간을 찾을 때 거슬러 올라가는 최대 일수 (한 달 + 연휴)
+)
+
+// PeriodCloseScheduler 주/월의 마지막 거래일 장 마감 후 시장별로 한 번 활성 종목의 주봉/월봉을 갱신
+// 주/월봉은 기간이 끝나야 확정되므로 5분 수집 주기와 별도로 돌며, 마지막 거래일은 시장 달력(휴장일 포함) 기준이다.
+// 마감 시각에 프로세스가 내려가 있었으면 다음 Tick 에서 가장 최근에 끝난 기간을 따라잡고,
+// 갱신에 실패한 기간은 처리한 것으로 기록하지 않고 periodRetryDelay 뒤 다시 시도한다.
+type PeriodCloseScheduler struct {
+	source    MarketSymbolSource
+	collector PeriodBarCollector
+	sessions  []MarketSession
+	calendar  *apimodels.MarketCalendar
+	mu        sync.Mutex
+	lastRun   map[string]string    // 시장/단위별 마지막으로 갱신에 성공한 기간 (2024-W23, 2024-06)
+	retryAt   map[string]time.Time // 시장/단위별 실패 후 다시 시도할 시각
+	stopChan  chan struct{}
+}
+
+func NewPeriodCloseScheduler(
+	source MarketSymbolSource,
+	collector PeriodBarCollector,
+	sessions []MarketSession,
+) *PeriodCloseScheduler {
+	return &PeriodCloseScheduler{
+		source:    source,
+		collector: collector,
+		sessions:  sessions,
+		calendar:  apimodels.DefaultMarketCalendar,
+		lastRun:   make(map[string]string),
+		retryAt:   make(map[string]time.Time),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// WithCalendar 주/월 마지막 거래일 판단에 쓸 시장 달력 교체
+func (s *PeriodCloseScheduler) WithCalendar(calendar *apimodels.MarketCalendar) *PeriodCloseScheduler {
+	s.calendar = calendar
+	return s
+}
+
+// Start interval 마다 끝난 주/월이 있는지 확인하는 스케줄 시작
+func (s *PeriodCloseScheduler) Start(interval time.Duration) {
+	log.Printf("Starting period close scheduler (interval: %s)", interval)
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Tick(time.Now())
+			case <-s.stopChan:
+				log.Println("Period close scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 스케줄 중지
+func (s *PeriodCloseScheduler) Stop() {
+	close(s.stopChan)
+}
+
+// Tick now 기준으로 가장 최근에 끝난 주/월을 아직 갱신하지 않은 시장을 처리하고 처리한 시장/단위 목록 반환 (예: "US/weekly")
+func (s *PeriodCloseScheduler) Tick(now time.Time) []string {
+	var processed []string
+	for _, session := range s.sessions {
+		local := now.In(session.location)
+		for _, granularity := range []string{models.GranularityWeekly, models.GranularityMonthly} {
+			period, ok := s.lastClosedPeriod(local, session, granularity)
+			if !ok {
+				continue
+			}
+
+			key := session.Market + "/" + granularity
+			s.mu.Lock()
+			skip := s.lastRun[key] == period || now.Before(s.retryAt[key])
+			s.mu.Unlock()
+			if skip {
+				continue
+			}
+
+			err := s.runPeriod(session.Market, granularity)
+			s.mu.Lock()
+			if err != nil {
+				s.retryAt[key] = now.Add(periodRetryDelay)
+			} else {
+				s.lastRun[key] = period
+				delete(s.retryAt, key)
+			}
+			s.mu.Unlock()
+			if err != nil {
+				log.Printf("Period close refresh (%s) for %s failed, retrying in %s: %v", granularity, session.Market, periodRetryDelay, err)
+				continue
+			}
+			processed = append(processed, key)
+		}
+	}
+	return processed
+}
+
+// lastClosedPeriod local 시점까지 마지막 거래일 장 마감이 지난 가장 최근 주/월의 식별자
+func (s *PeriodCloseScheduler) lastClosedPeriod(local time.Time, session MarketSession, granularity string) (string, bool) {
+	for i := 0; i < periodCatchUpDays; i++ {
+		day := local.AddDate(0, 0, -i)
+		if i == 0 && local.Hour()*60+local.Minute() < session.close {
+			continue
+		}
+		if !s.calendar.IsTradingDay(day, session.Market) {
+			continue
+		}
+		if period, closed := s.periodClose(day, session.Market, granularity); closed {
+			return period, true
+		}
+	}
+	return "", false
+}
+
+// periodClose local 이 속한 주(월~일)/월의 식별자와, local 이 그 기간의 마지막 거래일인지 여부
+func (s *PeriodCloseScheduler) periodClose(local time.Time, market, granularity string) (string, bool) {
+	var period string
+	inPeriod := func(t time.Time) bool { return t.Month() == local.Month() }
+	if granularity == models.GranularityWeekly {
+		year, week := local.ISOWeek()
+		period = fmt.Sprintf("%d-W%02d", year, week)
+		inPeriod = func(t time.Time) bool {
+			y, w := t.ISOWeek()
+			return y == year && w == week
+		}
+	} else {
+		period = local.Format("2006-01")
+	}
+
+	for next := local.AddDate(0, 0, 1); inPeriod(next); next = next.AddDate(0, 0, 1) {
+		if s.calendar.IsTradingDay(next, market) {
+			return period, false
+		}
+	}
+	return period, true
+}
+
+// runPeriod 시장의 활성 종목 전체의 주봉 또는 월봉 갱신 (실패한 종목이 있어도 나머지는 계속)
+// 종목 목록을 읽지 못했거나 한 종목도 저장하지 못했으면 에러를 반환한다.
+func (s *PeriodCloseScheduler) runPeriod(market, granularity string) error {
+	stocks, err := s.source.ActiveStocks(market)
+	if err != nil {
+		return fmt.Errorf("failed to load %s stocks: %w", market, err)
+	}
+
+	saved := 0
+	for _, stock := range stocks {
+		if err := s.collector.CollectPeriodBars(context.Background(), stock, granularity); err != nil {
+			log.Printf("Failed to refresh %s bars for %s: %v", granularity, stock.Symbol, err)
+			continue
+		}
+		saved++
+	}
+	log.Printf("Period close refresh (%s) for %s: %d/%d symbols", granularity, market, saved, len(stocks))
+	if saved == 0 && len(stocks) > 0 {
+		return fmt.Errorf("no %s bars saved for %d symbols", granularity, len(stocks))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package workers
+
+import (
+	"log"
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"time"
+)
+
+// WatchedSymbolSource 빠른 주기로 갱신할 종목 목록 제공
+type WatchedSymbolSource interface {
+	WatchedSymbols() ([]models.Stock, error)
+}
+
+// PriceFetcher 현재가만 조회 (DBSecClient의 공유 rate limiter를 사용, 호가는 전체 수집 주기에서만 받는다)
+type PriceFetcher interface {
+	FetchCurrentPrice(symbol, market string) (*apimodels.ParsedStockPrice, error)
+}
+
+// PriceStore 조회한 현재가 저장
+type PriceStore interface {
+	SaveStockPrice(priceData *apimodels.ParsedStockPrice) error
+}
+
+// PricePublisher 가격 업데이트 발행
+type PricePublisher interface {
+	PublishPriceUpdate(symbol, market string, data interface{}) error
+}
+
+// PriceRefresher 관심 종목의 현재가를 전체 수집 주기보다 짧은 주기로 갱신
+// 가격이 바뀌지 않는 장 시간 밖(휴장일 포함)의 종목은 호출 한도를 아끼려고 건너뛴다.
+type PriceRefresher struct {
+	source    WatchedSymbolSource
+	fetcher   PriceFetcher
+	store     PriceStore
+	publisher PricePublisher
+	calendar  *apimodels.MarketCalendar
+	interval  time.Duration
+	stopChan  chan struct{}
+}
+
+func NewPriceRefresher(
+	source WatchedSymbolSource,
+	fetcher PriceFetcher,
+	store PriceStore,
+	publisher PricePublisher,
+	interval time.Duration,
+) *PriceRefresher {
+	return &PriceRefresher{
+		source:    source,
+		fetcher:   fetcher,
+		store:     store,
+		publisher: publisher,
+		calendar:  apimodels.DefaultMarketCalendar,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// WithCalendar 장 시간 판단에 쓸 시장 달력 교체
+func (r *PriceRefresher) WithCalendar(calendar *apimodels.MarketCalendar) *PriceRefresher {
+	r.calendar = calendar
+	return r
+}
+
+// Start 주기적 갱신 시작
+func (r *PriceRefresher) Start() {
+	log.Printf("Starting price refresher (interval: %s)", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.RefreshOnce(time.Now())
+			case <-r.stopChan:
+				log.Println("Price refresher stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 갱신 중지
+func (r *PriceRefresher) Stop() {
+	close(r.stopChan)
+}
+
+// RefreshOnce now 기준 장 중인 관심 종목 현재가를 한 번 갱신하고 성공한 종목 수를 반환
+func (r *PriceRefresher) RefreshOnce(now time.Time) int {
+	stocks, err := r.source.WatchedSymbols()
+	if err != nil {
+		log.Printf("Failed to load watched symbols: %v", err)
+		return 0
+	}
+
+	refreshed := 0
+	for _, stock := range stocks {
+		if !r.calendar.InSession(now, stock.Market) {
+			continue
+		}
+
+		priceData, err := r.fetcher.FetchCurrentPrice(stock.Symbol, stock.Market)
+		if err != nil {
+			log.Printf("Failed to refresh price for %s: %v", stock.Symbol, err)
+			continue
+		}
+
+		if err := r.store.SaveStockPrice(priceData); err != nil {
+			log.Printf("Failed to save refreshed price for %s: %v", stock.Symbol, err)
+			continue
+		}
+
+		if err := r.publisher.PublishPriceUpdate(stock.Symbol, stock.Market, priceData); err != nil {
+			log.Printf("Failed to publish price update for %s: %v", stock.Symbol, err)
+			continue
+		}
+
+		refreshed++
+	}
+
+	return refreshed
+}
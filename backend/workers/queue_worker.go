@@ -17,6 +17,8 @@ type QueueWorker struct {
 	signalGenerator  *services.SignalGeneratorService
 	aiClient         *services.AIClient
 	cacheService     *services.CacheService
+	obvTracker       *services.OBVTracker
+	trigger          services.SignalTrigger
 }
 
 func NewQueueWorker(
@@ -34,9 +36,17 @@ func NewQueueWorker(
 		signalGenerator:  signalGenerator,
 		aiClient:         aiClient,
 		cacheService:     cacheService,
+		obvTracker:       services.NewOBVTracker(db),
+		trigger:          services.TriggerPriceUpdate,
 	}
 }
 
+// WithTrigger 신호 생성 시점 정책 지정 (price_update 가 아니면 가격 갱신 시 지표만 계산)
+func (w *QueueWorker) WithTrigger(trigger services.SignalTrigger) *QueueWorker {
+	w.trigger = trigger
+	return w
+}
+
 func (w *QueueWorker) StartWorkers() error {
 	log.Println("Starting queue workers...")
 
@@ -89,10 +99,12 @@ func (w *QueueWorker) handleIndicatorCalculation(message services.Message) error
 
 	// Fetch recent price data
 	var prices []models.StockPrice
-	err := w.db.Where("symbol = ? AND market = ?", message.Symbol, message.Market).
+	unlock := services.DefaultSymbolLocks.RLock(message.Symbol)
+	err := w.db.Where("symbol = ? AND market = ? AND granularity IN ?", message.Symbol, message.Market, models.PriceSeriesGranularities).
 		Order("timestamp desc").
 		Limit(50).
 		Find(&prices).Error
+	unlock()
 	if err != nil {
 		log.Printf("Failed to fetch prices for %s: %v", message.Symbol, err)
 		return err
@@ -103,13 +115,27 @@ func (w *QueueWorker) handleIndicatorCalculation(message services.Message) error
 		return nil
 	}
 
-	// Calculate indicators
-	indicators := w.indicatorService.CalculateAll(prices)
+	// 오래되었거나 공백이 있는 봉으로는 지표를 계산하지 않는다
+	if status := w.indicatorService.CheckBars(prices); status != services.BarStatusOK {
+		log.Printf("Skipping indicators for %s: price data is %s", message.Symbol, status)
+		return nil
+	}
+
+	// Calculate indicators (종목별 지표 기간 설정 적용)
+	params := services.SymbolIndicatorParams(w.db, message.Symbol, w.indicatorService.Params())
+	indicators := w.indicatorService.CalculateAllWithParams(prices, params)
 	if indicators == nil {
 		log.Printf("Failed to calculate indicators for %s", message.Symbol)
 		return nil
 	}
 
+	// OBV 는 계산 창(50봉)이 아닌 종목별 누적 값으로 저장
+	if state, err := w.obvTracker.Update(message.Symbol, prices); err != nil {
+		log.Printf("Failed to update running OBV for %s: %v", message.Symbol, err)
+	} else {
+		indicators.OBV = state.OBV
+	}
+
 	// Save indicators to database
 	err = w.saveIndicators(message.Symbol, indicators)
 	if err != nil {
@@ -121,6 +147,16 @@ func (w *QueueWorker) handleIndicatorCalculation(message services.Message) error
 	indicatorMap := w.convertIndicatorsToMap(indicators)
 	w.cacheService.SetIndicators(message.Symbol, indicatorMap)
 
+	// 규칙 기반 전용 모드에서는 AI 분석 요청을 발행하지 않는다
+	if w.aiClient.RuleOnly() {
+		return nil
+	}
+
+	// 가격 갱신마다 신호를 만들지 않는 정책이면 지표 갱신까지만 한다
+	if !w.trigger.Allows(services.EventPriceUpdate) {
+		return nil
+	}
+
 	// Trigger AI analysis
 	err = w.queueService.PublishAIRequest(message.Symbol, message.Market, indicatorMap)
 	if err != nil {
@@ -178,13 +214,16 @@ func (w *QueueWorker) handleAIRequest(message services.Message) error {
 
 	// Create trading signal
 	signal := &models.TradingSignal{
-		Symbol:     message.Symbol,
-		SignalType: decision.Decision,
-		Strength:   w.calculateSignalStrength(decision.Confidence),
-		Confidence: decision.Confidence,
-		Reasons:    w.reasonsToJSON(decision.Reasoning),
-		Source:     "AI",
-		CreatedAt:  time.Now(),
+		Symbol:            message.Symbol,
+		SignalType:        decision.Decision,
+		Strength:          w.SignalStrength(decision.Confidence),
+		Confidence:        decision.Confidence,
+		Reasons:           w.reasonsToJSON(decision.Reasoning),
+		IndicatorSnapshot: services.IndicatorsToJSON(indicators),
+		Source:            "AI",
+		Model:             decision.Model,
+		Provider:          decision.Provider,
+		CreatedAt:         time.Now(),
 	}
 
 	// Save signal
@@ -253,6 +292,8 @@ func (w *QueueWorker) convertIndicatorsToMap(indicators *services.IndicatorResul
 		"bollinger_mid":   indicators.BollingerMid,
 		"stochastic_k":    indicators.StochasticK,
 		"stochastic_d":    indicators.StochasticD,
+		"stoch_rsi_k":     indicators.StochRSIK,
+		"stoch_rsi_d":     indicators.StochRSID,
 		"williams_r":      indicators.WilliamsR,
 		"atr":             indicators.ATR,
 		"obv":             indicators.OBV,
@@ -269,14 +310,7 @@ func (w *QueueWorker) reasonsToJSON(reasons []string) string {
 	return string(data)
 }
 
-func (w *QueueWorker) calculateSignalStrength(confidence float64) float64 {
-	// Simple mapping of confidence to strength
-	if confidence > 0.8 {
-		return 0.9
-	} else if confidence > 0.6 {
-		return 0.7
-	} else if confidence > 0.4 {
-		return 0.5
-	}
-	return 0.3
-}
\ No newline at end of file
+// SignalStrength 신뢰도에 대응하는 신호 강도 (신호 생성기와 같은 매핑 사용)
+func (w *QueueWorker) SignalStrength(confidence float64) float64 {
+	return w.signalGenerator.Strength(confidence)
+}
@@ -0,0 +1,176 @@
+package workers
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+)
+
+// MarketSymbolSource 시장별 활성 종목 목록 제공
+type MarketSymbolSource interface {
+	ActiveStocks(market string) ([]models.Stock, error)
+}
+
+// SwingSignalGenerator 일봉 기준 지표 재계산 및 신호 생성
+type SwingSignalGenerator interface {
+	GenerateSignalWithStrategy(symbol, market string, strategy services.SignalStrategy) (*models.TradingSignal, error)
+}
+
+// MarketSession 시장의 현지 시간대와 장 마감 작업 시각
+type MarketSession struct {
+	Market   string
+	location *time.Location
+	close    int // 현지 자정부터 분
+}
+
+// NewMarketSession HH:MM 형식의 마감 시각과 IANA 시간대로 시장 세션 생성
+func NewMarketSession(market, timezone, closeAt string) (MarketSession, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return MarketSession{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	parsed, err := time.Parse("15:04", closeAt)
+	if err != nil {
+		return MarketSession{}, fmt.Errorf("invalid close time %q for %s, expected HH:MM", closeAt, market)
+	}
+	return MarketSession{
+		Market:   market,
+		location: location,
+		close:    parsed.Hour()*60 + parsed.Minute(),
+	}, nil
+}
+
+// DefaultMarketSessions KR(서울)/US(뉴욕) 세션 (마감 시각은 각 시장 현지 시간)
+func DefaultMarketSessions(krClose, usClose string) ([]MarketSession, error) {
+	kr, err := NewMarketSession("KR", "Asia/Seoul", krClose)
+	if err != nil {
+		return nil, err
+	}
+	us, err := NewMarketSession("US", "America/New_York", usClose)
+	if err != nil {
+		return nil, err
+	}
+	return []MarketSession{kr, us}, nil
+}
+
+// SessionCloseScheduler 장 마감 후 시장별로 한 번 일봉 지표와 스윙 신호를 다시 계산
+// 5분 장중 주기 대신 확정된 일봉으로 스윙 신호를 만들기 위한 작업으로, 휴장일(시장 달력 기준)에는 돌지 않는다.
+type SessionCloseScheduler struct {
+	source    MarketSymbolSource
+	generator SwingSignalGenerator
+	sessions  []MarketSession
+	calendar  *apimodels.MarketCalendar
+	trigger   services.SignalTrigger
+	mu        sync.Mutex
+	lastRun   map[string]string // 시장별 마지막 처리 현지 날짜 (YYYY-MM-DD)
+	stopChan  chan struct{}
+}
+
+func NewSessionCloseScheduler(
+	source MarketSymbolSource,
+	generator SwingSignalGenerator,
+	sessions []MarketSession,
+) *SessionCloseScheduler {
+	return &SessionCloseScheduler{
+		source:    source,
+		generator: generator,
+		sessions:  sessions,
+		calendar:  apimodels.DefaultMarketCalendar,
+		trigger:   services.TriggerPriceUpdate,
+		lastRun:   make(map[string]string),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// WithCalendar 휴장일 판단에 쓸 시장 달력 교체
+func (s *SessionCloseScheduler) WithCalendar(calendar *apimodels.MarketCalendar) *SessionCloseScheduler {
+	s.calendar = calendar
+	return s
+}
+
+// WithTrigger 신호 생성 시점 정책 지정 (장 마감 재계산을 허용하지 않는 정책이면 아무것도 하지 않는다)
+func (s *SessionCloseScheduler) WithTrigger(trigger services.SignalTrigger) *SessionCloseScheduler {
+	s.trigger = trigger
+	return s
+}
+
+// Start interval 마다 마감 여부를 확인하는 스케줄 시작
+func (s *SessionCloseScheduler) Start(interval time.Duration) {
+	log.Printf("Starting session close scheduler (interval: %s)", interval)
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Tick(time.Now())
+			case <-s.stopChan:
+				log.Println("Session close scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 스케줄 중지
+func (s *SessionCloseScheduler) Stop() {
+	close(s.stopChan)
+}
+
+// Tick now 기준으로 마감 시각이 지났고 오늘 아직 처리하지 않은 시장을 처리하고 처리한 시장 목록 반환
+func (s *SessionCloseScheduler) Tick(now time.Time) []string {
+	if !s.trigger.Allows(services.EventDailyClose) {
+		return nil
+	}
+
+	var processed []string
+	for _, session := range s.sessions {
+		local := now.In(session.location)
+		if !s.calendar.IsTradingDay(local, session.Market) {
+			continue
+		}
+		if local.Hour()*60+local.Minute() < session.close {
+			continue
+		}
+
+		today := local.Format("2006-01-02")
+		s.mu.Lock()
+		done := s.lastRun[session.Market] == today
+		if !done {
+			s.lastRun[session.Market] = today
+		}
+		s.mu.Unlock()
+		if done {
+			continue
+		}
+
+		s.runSession(session.Market)
+		processed = append(processed, session.Market)
+	}
+	return processed
+}
+
+// runSession 시장의 활성 종목 전체에 대해 일봉 스윙 신호 생성 (지표도 일봉으로 다시 계산된다)
+func (s *SessionCloseScheduler) runSession(market string) {
+	stocks, err := s.source.ActiveStocks(market)
+	if err != nil {
+		log.Printf("Failed to load %s stocks for session close: %v", market, err)
+		return
+	}
+
+	generated := 0
+	for _, stock := range stocks {
+		if _, err := s.generator.GenerateSignalWithStrategy(stock.Symbol, stock.Market, services.StrategySwing); err != nil {
+			log.Printf("Failed to recompute session close signal for %s: %v", stock.Symbol, err)
+			continue
+		}
+		generated++
+	}
+	log.Printf("Session close recompute for %s: %d/%d symbols", market, generated, len(stocks))
+}
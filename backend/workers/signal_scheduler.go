@@ -0,0 +1,65 @@
+package workers
+
+import (
+	"log"
+	"time"
+
+	"stock-recommender/backend/services"
+)
+
+// AllStocksSignalGenerator 활성 종목 전체 신호 생성
+type AllStocksSignalGenerator interface {
+	GenerateSignalsForAllStocks() error
+}
+
+// SignalScheduler 신호 생성 정책이 schedule 일 때 고정 주기로 전체 종목 신호 생성
+type SignalScheduler struct {
+	generator AllStocksSignalGenerator
+	trigger   services.SignalTrigger
+	interval  time.Duration
+	stopChan  chan struct{}
+}
+
+func NewSignalScheduler(generator AllStocksSignalGenerator, trigger services.SignalTrigger, interval time.Duration) *SignalScheduler {
+	return &SignalScheduler{
+		generator: generator,
+		trigger:   trigger,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start 주기적 생성 시작
+func (s *SignalScheduler) Start() {
+	log.Printf("Starting signal scheduler (interval: %s)", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.RunOnce()
+			case <-s.stopChan:
+				log.Println("Signal scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 생성 중지
+func (s *SignalScheduler) Stop() {
+	close(s.stopChan)
+}
+
+// RunOnce 정책이 주기 생성을 허용하면 전체 종목 신호를 한 번 생성하고 실행 여부 반환
+func (s *SignalScheduler) RunOnce() bool {
+	if !s.trigger.Allows(services.EventScheduled) {
+		return false
+	}
+	if err := s.generator.GenerateSignalsForAllStocks(); err != nil {
+		log.Printf("Scheduled signal generation failed: %v", err)
+	}
+	return true
+}
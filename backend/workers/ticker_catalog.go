@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"log"
+	"time"
+
+	"stock-recommender/backend/services"
+)
+
+// CatalogRefresher 갱신할 종목 카탈로그 (services.TickerCatalog)
+type CatalogRefresher interface {
+	RefreshAll()
+}
+
+// TickerCatalogScheduler 해외 종목 카탈로그를 고정 주기로 다시 받아 오는 스케줄러
+type TickerCatalogScheduler struct {
+	catalog  CatalogRefresher
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+// NewTickerCatalogScheduler interval 이 0 이하면 기본 주기로 갱신
+func NewTickerCatalogScheduler(catalog CatalogRefresher, interval time.Duration) *TickerCatalogScheduler {
+	if interval <= 0 {
+		interval = services.DefaultTickerCatalogRefresh
+	}
+	return &TickerCatalogScheduler{
+		catalog:  catalog,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start 시작하면서 한 번 받고, 이후 주기적으로 갱신
+func (s *TickerCatalogScheduler) Start() {
+	log.Printf("Starting ticker catalog scheduler (interval: %s)", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	go func() {
+		defer ticker.Stop()
+		s.catalog.RefreshAll()
+		for {
+			select {
+			case <-ticker.C:
+				s.catalog.RefreshAll()
+			case <-s.stopChan:
+				log.Println("Ticker catalog scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 갱신 중지
+func (s *TickerCatalogScheduler) Stop() {
+	close(s.stopChan)
+}
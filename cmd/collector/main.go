@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -39,7 +40,9 @@ func main() {
 	}
 
 	// Create collector
-	collector := NewDataCollector(db, apiClient, cacheService, queueService)
+	// Rate limiting - 종목 사이 1초 대기
+	cycle := services.NewCollectionCycle(cfg.Collector, 1*time.Second)
+	collector := NewDataCollector(db, apiClient, cacheService, queueService, cycle)
 
 	// Setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -66,6 +69,7 @@ type DataCollector struct {
 	apiClient    *services.DBSecAPIClient
 	cacheService *services.CacheService
 	queueService *services.QueueService
+	cycle        services.CollectionCycle
 	stopChan     chan bool
 }
 
@@ -74,12 +78,14 @@ func NewDataCollector(
 	apiClient *services.DBSecAPIClient,
 	cacheService *services.CacheService,
 	queueService *services.QueueService,
+	cycle services.CollectionCycle,
 ) *DataCollector {
 	return &DataCollector{
 		db:           db,
 		apiClient:    apiClient,
 		cacheService: cacheService,
 		queueService: queueService,
+		cycle:        cycle,
 		stopChan:     make(chan bool),
 	}
 }
@@ -110,32 +116,18 @@ func (dc *DataCollector) collectAllStocks() {
 
 	// Get active stocks
 	var stocks []models.Stock
-	err := dc.db.Where("is_active = ?", true).Find(&stocks).Error
+	err := dc.db.Where("is_active = ?", true).Order("priority DESC, symbol ASC").Find(&stocks).Error
 	if err != nil {
 		log.Printf("Failed to fetch active stocks: %v", err)
 		return
 	}
 
-	successCount := 0
-	errorCount := 0
+	report := dc.cycle.Run(context.Background(), stocks, dc.collectStockData)
 
-	for _, stock := range stocks {
-		err := dc.collectStockData(stock.Symbol, stock.Market)
-		if err != nil {
-			log.Printf("Failed to collect data for %s: %v", stock.Symbol, err)
-			errorCount++
-		} else {
-			successCount++
-		}
-
-		// Rate limiting - 1초 대기
-		time.Sleep(1 * time.Second)
-	}
-
-	log.Printf("Collection cycle completed: %d success, %d errors", successCount, errorCount)
+	log.Printf("Collection cycle completed: %d success, %d errors", report.Success, report.Failed())
 }
 
-func (dc *DataCollector) collectStockData(symbol, market string) error {
+func (dc *DataCollector) collectStockData(ctx context.Context, symbol, market string) error {
 	log.Printf("Collecting data for %s (%s)", symbol, market)
 
 	// Check if using real API or mock data
@@ -144,7 +136,7 @@ func (dc *DataCollector) collectStockData(symbol, market string) error {
 
 	if dc.apiClient.HasValidAPIKey() {
 		// Use real API
-		stockPrice, err = dc.apiClient.FetchStockPrice(symbol, market)
+		stockPrice, err = dc.apiClient.FetchStockPriceContext(ctx, symbol, market)
 	} else {
 		// Use mock data for development
 		log.Printf("Using mock data for %s (no API key configured)", symbol)
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/database"
+	"stock-recommender/backend/services"
+)
+
+func main() {
+	opts := services.DefaultSeedOptions()
+	flag.IntVar(&opts.Bars, "bars", opts.Bars, "number of daily bars per stock")
+	flag.IntVar(&opts.SignalSamples, "signals", opts.SignalSamples, "number of sample signals per stock")
+	flag.Parse()
+
+	log.Println("Seeding demo data")
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Initialize database
+	db, err := database.Initialize(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize database:", err)
+	}
+
+	seeder := services.NewSeedService(db, services.NewIndicatorService())
+	report, err := seeder.Seed(opts)
+	if err != nil {
+		log.Fatal("Failed to seed demo data:", err)
+	}
+
+	log.Printf("Demo data ready: +%d stocks, +%d prices, +%d indicators, +%d signals",
+		report.Stocks, report.Prices, report.Indicators, report.Signals)
+}
@@ -1,12 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"stock-recommender/backend/config"
 	"stock-recommender/backend/database"
+	"stock-recommender/backend/openapi/client"
+	apierrors "stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
 	"stock-recommender/backend/router"
 	"stock-recommender/backend/services"
 	"stock-recommender/backend/workers"
+	"syscall"
+	"time"
 )
 
 func main() {
@@ -21,12 +32,32 @@ func main() {
 	}
 
 	// Initialize partition manager and create partitions
-	partitionManager := services.NewPartitionManager(db)
-	err = partitionManager.CreateMonthlyPartitions()
+	partitionInterval, err := services.ParsePartitionInterval(cfg.PartitionInterval)
+	if err != nil {
+		log.Printf("Warning: %v, using %s", err, services.PartitionMonthly)
+		partitionInterval = services.PartitionMonthly
+	}
+	partitionManager := services.NewPartitionManager(db).WithInterval(partitionInterval)
+	err = partitionManager.CreatePartitions()
 	if err != nil {
 		log.Printf("Warning: Failed to create partitions: %v", err)
 	}
 
+	// 보존 기간이 지난 매매 신호 정리 (하루 한 번, 보존 기간이 0 이하면 모든 신호가 지워지므로 돌리지 않는다)
+	if cfg.SignalRetention <= 0 {
+		log.Printf("Warning: Signal cleanup disabled: SIGNAL_RETENTION must be positive, got %s", cfg.SignalRetention)
+	} else {
+		signalRetention := services.NewSignalRetentionService(db, cfg.SignalRetention)
+		go func() {
+			ticker := time.NewTicker(24 * time.Hour)
+			defer ticker.Stop()
+			for {
+				signalRetention.ScheduledCleanup()
+				<-ticker.C
+			}
+		}()
+	}
+
 	// Initialize services
 	cacheService := services.NewCacheService(cfg)
 	queueService, err := services.NewQueueService(cfg)
@@ -35,33 +66,206 @@ func main() {
 		queueService = nil
 	}
 
+	// 거래소를 모르는 미국 종목은 종목 동기화 결과에서 찾는다
+	foreign.DefaultExchangeCache.WithLoader(services.StockExchangeLoader(db))
+
+	// DBSec 정기 점검 시간 (잘못된 값이면 점검 응답 감지만 사용)
+	maintenanceWindows, err := client.ParseMaintenanceWindows(cfg.API.DBSecMaintenanceWindows)
+	if err != nil {
+		log.Printf("Warning: %v, ignoring DBSEC_MAINTENANCE_WINDOWS", err)
+	}
+	client.DefaultMaintenance.Configure(maintenanceWindows, cfg.API.DBSecMaintenanceRetry)
+
+	// 거래소를 지정하지 않은 해외 차트/시세 요청의 시장 (DEFAULT_MARKET 이 국내 시장이거나 없으면 나스닥)
+	if apimodels.DefaultMarketResolver.Region(cfg.DefaultMarket) == apimodels.RegionUS {
+		if err := apimodels.DefaultMarketResolver.SetDefaultForeign(cfg.DefaultMarket); err != nil {
+			log.Printf("Warning: %v, using %s", err, apimodels.MarketNASDAQ)
+		}
+	}
+
+	// 거래소별 종목코드 변환 (BRK.B 같은 클래스 주식)
+	if err := apimodels.DefaultSymbolNormalizer.LoadMappings(cfg.API.DBSecSymbolMap); err != nil {
+		log.Printf("Warning: %v, ignoring remaining DBSEC_SYMBOL_MAP entries", err)
+	}
+
+	// 기본 표에 없는 DBSec 응답코드 분류
+	if err := apierrors.ResponseCodes.LoadRules(cfg.API.DBSecResponseCodes); err != nil {
+		log.Printf("Warning: %v, ignoring DBSEC_RESPONSE_CODES", err)
+	}
+
+	// 기본 달력에 없는 휴장일 (기본 달력은 2024 ~ 2026 만 다룬다)
+	for market, holidays := range map[string][]string{apimodels.RegionKR: cfg.Session.KRHolidays, apimodels.RegionUS: cfg.Session.USHolidays} {
+		if err := apimodels.DefaultMarketCalendar.LoadHolidays(market, holidays); err != nil {
+			log.Printf("Warning: %v, ignoring MARKET_HOLIDAYS_%s", err, market)
+		}
+		if year := time.Now().Year(); !apimodels.DefaultMarketCalendar.HasHolidays(market, year) {
+			log.Printf("Warning: no %s market holidays for %d, set MARKET_HOLIDAYS_%s", market, year, market)
+		}
+	}
+
+	// 수집기가 갱신하고 가격 API/스트림이 읽는 메모리 가격 북
+	services.DefaultPriceBook.Configure(cfg.Collector.PriceBookSize, cfg.Collector.PriceBookIdle)
+
 	// Initialize data collector service
 	dataCollector := services.NewDataCollectorService(db, cfg)
 	
 	// Start scheduled data collection
 	go dataCollector.StartScheduledCollection()
 
+	// 해외 종목 카탈로그 (주기적으로 받아 두고 /catalog/foreign 은 캐시에서 응답)
+	services.DefaultTickerCatalog.
+		WithFetcher(foreign.NewForeignStockTickerService(dataCollector.APIClient()).GetAllForeignStockTickers).
+		WithNames(services.StockEnglishNames(db)).
+		WithMaxAge(cfg.API.TickerCatalogRefresh)
+	workers.NewTickerCatalogScheduler(services.DefaultTickerCatalog, cfg.API.TickerCatalogRefresh).Start()
+
 	aiClient := services.NewAIClient(cfg)
-	indicatorService := services.NewIndicatorService()
-	signalGenerator := services.NewSignalGeneratorService(db, indicatorService, aiClient, cacheService, queueService)
+	if unknown := services.UnknownAIIndicators(cfg.AI.Indicators); len(unknown) > 0 {
+		log.Printf("Warning: unknown AI_INDICATORS %v are never sent", unknown)
+	}
+	features := services.NewFeatureFlags(db, cfg.Features)
+	indicatorService := services.NewIndicatorService().
+		WithCache(services.NewIndicatorCache(cfg.Indicator.CacheSize)).
+		WithFeatures(features)
+	// 최신 가격이 오래됐을 때의 처리 (잘못된 값이면 건너뜀)
+	staleAction, err := services.ParseStalePriceAction(cfg.Signal.StalePrice)
+	if err != nil {
+		log.Printf("Warning: %v, using %s", err, services.StalePriceSkip)
+		staleAction = services.StalePriceSkip
+	}
+	// 신뢰도 → 신호 강도 매핑 (0~1 범위를 벗어나면 기본 매핑)
+	strengthMapping := services.StrengthMapping{Floor: cfg.Signal.StrengthFloor, Ceiling: cfg.Signal.StrengthCeiling}
+	if err := strengthMapping.Validate(); err != nil {
+		log.Printf("Warning: %v, using %.2f~%.2f", err, services.DefaultStrengthMapping.Floor, services.DefaultStrengthMapping.Ceiling)
+		strengthMapping = services.DefaultStrengthMapping
+	}
+	// 저장/발행할 신호의 최소 신뢰도 (0~1 범위를 벗어나면 모든 신호 저장)
+	confidenceFloor := cfg.Signal.ConfidenceFloor
+	if err := services.ValidateConfidenceFloor(confidenceFloor); err != nil {
+		log.Printf("Warning: %v, using 0", err)
+		confidenceFloor = 0
+	}
+	signalGenerator := services.NewSignalGeneratorService(db, indicatorService, aiClient, cacheService, queueService).
+		WithStrengthMapping(strengthMapping).
+		WithConfidenceFloor(confidenceFloor, cfg.Signal.LogSuppressed).
+		WithFeatures(features).
+		WithMaintenance(client.DefaultMaintenance).
+		WithStalePriceGuard(services.StalePriceGuard{
+			MaxAge:     cfg.Signal.MaxPriceAge,
+			Action:     staleAction,
+			Confidence: cfg.Signal.StaleConfidence,
+		}).
+		WithConcurrency(cfg.Signal.Concurrency)
+
+	// 전체 종목 신호 알림 (구독자가 있을 때만, 묶음 창이 닫힌 알림은 주기적으로 전달)
+	subscriptions, err := services.ParseNotificationSubscriptions(cfg.Notification.Subscribers)
+	if err != nil {
+		log.Printf("Warning: Signal notifications disabled: %v", err)
+	} else if len(subscriptions) > 0 {
+		notifications := services.NewNotificationService(services.LogNotifier{}).
+			WithDigestWindow(cfg.Notification.DigestWindow)
+		for _, subscription := range subscriptions {
+			notifications.Subscribe(subscription.UserID, subscription.Channel)
+		}
+		signalGenerator.WithNotifications(notifications)
+		workers.NewNotificationFlusher(notifications, cfg.Notification.FlushInterval).Start()
+	}
+
+	// 자동 신호 생성 시점 정책 (잘못된 값이면 기본값으로)
+	signalTrigger, err := services.ParseSignalTrigger(cfg.Signal.Trigger)
+	if err != nil {
+		log.Printf("Warning: %v, using %s", err, services.TriggerPriceUpdate)
+		signalTrigger = services.TriggerPriceUpdate
+	}
 
 	// Start queue workers if queue service is available
+	var priceRefresher *workers.PriceRefresher
 	if queueService != nil {
-		queueWorker := workers.NewQueueWorker(db, queueService, indicatorService, signalGenerator, aiClient, cacheService)
+		queueWorker := workers.NewQueueWorker(db, queueService, indicatorService, signalGenerator, aiClient, cacheService).
+			WithTrigger(signalTrigger)
 		err = queueWorker.StartWorkers()
 		if err != nil {
 			log.Printf("Warning: Failed to start queue workers: %v", err)
 		} else {
 			log.Println("Queue workers started successfully")
 		}
+
+		// 관심 종목 현재가 빠른 갱신 (수집기와 API 클라이언트/rate limiter 공유)
+		priceRefresher = workers.NewPriceRefresher(
+			services.NewWatchlistService(db),
+			dataCollector.APIClient(),
+			dataCollector,
+			queueService,
+			30*time.Second,
+		)
+		priceRefresher.Start()
+	}
+
+	// 과거 일봉 백필 (하루 호출 예산 안에서 진행, 재시작하면 저장된 커서부터 이어서)
+	workers.NewBackfillScheduler(
+		services.NewBackfillService(db),
+		dataCollector,
+		cfg.Backfill.DailyBudget,
+		cfg.Backfill.WindowDays,
+	).Start(time.Minute)
+
+	// 장 마감 후 일봉 기준 지표/스윙 신호 재계산 (시장별 현지 시간 기준)
+	sessions, err := workers.DefaultMarketSessions(cfg.Session.KRClose, cfg.Session.USClose)
+	if err != nil {
+		log.Printf("Warning: Session close scheduler disabled: %v", err)
+	} else {
+		workers.NewSessionCloseScheduler(services.NewUniverseService(db), signalGenerator, sessions).
+			WithTrigger(signalTrigger).
+			Start(time.Minute)
+	}
+
+	// 주/월 마지막 거래일 장 마감 후 주봉/월봉 갱신 (해외 차트 API 만 있어 US 시장만)
+	usSession, err := workers.NewMarketSession("US", "America/New_York", cfg.Session.USClose)
+	if err != nil {
+		log.Printf("Warning: Period close scheduler disabled: %v", err)
+	} else {
+		workers.NewPeriodCloseScheduler(
+			services.NewUniverseService(db),
+			services.NewPeriodBarService(db, dataCollector.APIClient()),
+			[]workers.MarketSession{usSession},
+		).Start(time.Minute)
+	}
+
+	// 고정 주기 신호 생성 (SIGNAL_TRIGGER=schedule)
+	if signalTrigger == services.TriggerSchedule {
+		workers.NewSignalScheduler(signalGenerator, signalTrigger, cfg.Signal.Schedule).Start()
 	}
 
 	// Setup router
-	r := router.Setup(db, cfg)
+	r := router.Setup(db, cfg, router.Dependencies{
+		AIClient:   aiClient,
+		Cache:      cacheService,
+		Collector:  dataCollector,
+		Features:   features,
+		Indicators: indicatorService,
+	})
 
 	// Start server
-	log.Printf("Server starting on :%s", cfg.Port)
-	if err := r.Run(":" + cfg.Port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	server := &http.Server{Addr: ":" + cfg.Port, Handler: r}
+	go func() {
+		log.Printf("Server starting on :%s", cfg.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// 종료 신호를 받으면 외부 API 를 부르는 갱신 작업을 멈추고 진행 중인 요청을 마친 뒤 종료
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+
+	if priceRefresher != nil {
+		priceRefresher.Stop()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Warning: Server shutdown: %v", err)
 	}
-}
\ No newline at end of file
+}
@@ -13,7 +13,14 @@ CREATE TABLE IF NOT EXISTS stocks (
     exchange VARCHAR(20),
     sector VARCHAR(50),
     industry VARCHAR(50),
+    precision INTEGER DEFAULT 2,
+    market_cap BIGINT,
+    per DOUBLE PRECISION,
+    pbr DOUBLE PRECISION,
     is_active BOOLEAN DEFAULT true,
+    priority INTEGER DEFAULT 0,
+    indicator_config JSONB,
+    ticker_hash VARCHAR(64),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     deleted_at TIMESTAMP WITH TIME ZONE
@@ -29,6 +36,7 @@ CREATE TABLE IF NOT EXISTS stock_prices (
     low_price DECIMAL(12,4),
     close_price DECIMAL(12,4),
     volume BIGINT,
+    granularity VARCHAR(10) DEFAULT 'intraday',
     timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     PRIMARY KEY (id, timestamp)
@@ -89,10 +97,74 @@ CREATE TABLE IF NOT EXISTS trading_signals (
     strength DECIMAL(3,2) CHECK (strength >= 0 AND strength <= 1),
     confidence DECIMAL(3,2) CHECK (confidence >= 0 AND confidence <= 1),
     reasons JSONB,
+    indicator_snapshot JSONB,
     source VARCHAR(20) DEFAULT 'AI' CHECK (source IN ('AI', 'RULE', 'MANUAL')),
+    model VARCHAR(50),
+    provider VARCHAR(100),
+    outcome VARCHAR(10),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+-- Watchlist items table (fast price refresh)
+CREATE TABLE IF NOT EXISTS watchlist_items (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(20) UNIQUE NOT NULL,
+    market VARCHAR(5) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Running OBV per symbol (updated incrementally as new bars arrive)
+CREATE TABLE IF NOT EXISTS obv_states (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(20) UNIQUE NOT NULL,
+    obv DOUBLE PRECISION,
+    cumulative_volume BIGINT,
+    last_close DECIMAL(12,4),
+    last_bar_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Daily bar backfill jobs (cursor marks the next range to collect)
+CREATE TABLE IF NOT EXISTS backfill_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    symbol VARCHAR(20) NOT NULL,
+    market VARCHAR(5) NOT NULL,
+    from_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    cursor TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(10) DEFAULT 'pending',
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Backfill API calls per day (KST), so the daily budget survives restarts
+CREATE TABLE IF NOT EXISTS backfill_usages (
+    day VARCHAR(10) PRIMARY KEY,
+    calls INTEGER DEFAULT 0
+);
+
+-- Foreign ticker sync runs (an interrupted run resumes after cursor)
+CREATE TABLE IF NOT EXISTS ticker_sync_runs (
+    id BIGSERIAL PRIMARY KEY,
+    exchange VARCHAR(10) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    cursor VARCHAR(20),
+    added INTEGER DEFAULT 0,
+    updated INTEGER DEFAULT 0,
+    unchanged INTEGER DEFAULT 0,
+    deactivated INTEGER DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Feature flags stored in the database (override FEATURE_FLAGS)
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name VARCHAR(50) PRIMARY KEY,
+    enabled BOOLEAN DEFAULT false,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 -- News articles table
 CREATE TABLE IF NOT EXISTS news_articles (
     id BIGSERIAL PRIMARY KEY,
@@ -120,6 +192,12 @@ CREATE INDEX IF NOT EXISTS idx_technical_indicators_name_calculated ON technical
 CREATE INDEX IF NOT EXISTS idx_trading_signals_symbol_created ON trading_signals(symbol, created_at DESC);
 CREATE INDEX IF NOT EXISTS idx_trading_signals_type_created ON trading_signals(signal_type, created_at DESC);
 
+CREATE INDEX IF NOT EXISTS idx_backfill_jobs_symbol ON backfill_jobs(symbol);
+CREATE INDEX IF NOT EXISTS idx_backfill_jobs_status ON backfill_jobs(status);
+
+CREATE INDEX IF NOT EXISTS idx_ticker_sync_runs_exchange ON ticker_sync_runs(exchange);
+CREATE INDEX IF NOT EXISTS idx_ticker_sync_runs_status ON ticker_sync_runs(status);
+
 CREATE INDEX IF NOT EXISTS idx_news_articles_published ON news_articles(published_at DESC);
 CREATE INDEX IF NOT EXISTS idx_news_articles_sentiment ON news_articles(sentiment_score);
 
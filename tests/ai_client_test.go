@@ -0,0 +1,445 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIClientUsesConfiguredModel(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotRequest models.AIDecisionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotRequest))
+
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{
+			Symbol:     gotRequest.Symbol,
+			Decision:   "BUY",
+			Confidence: 0.8,
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			Endpoint:  server.URL,
+			APIKey:    "secret-key",
+			Model:     "decision-v2",
+			Timeout:   5 * time.Second,
+			MaxTokens: 512,
+		},
+	}
+	client := services.NewAIClient(cfg)
+
+	resp, err := client.GetDecision(models.AIDecisionRequest{Symbol: "005930", Market: "KR"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/v1/decision", gotPath)
+	assert.Equal(t, "Bearer secret-key", gotAuth)
+	assert.Equal(t, "decision-v2", gotRequest.Model)
+	assert.Equal(t, 512, gotRequest.MaxTokens)
+	// 응답에 모델명이 없으면 설정된 모델로 채워진다
+	assert.Equal(t, "decision-v2", resp.Model)
+}
+
+func TestAIClientFallsBackToSecondaryProvider(t *testing.T) {
+	var primaryCalls atomic.Int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{
+			Symbol:     "005930",
+			Decision:   "SELL",
+			Confidence: 0.7,
+			Model:      "backup-model",
+		})
+	}))
+	defer secondary.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			Endpoint:  primary.URL,
+			Fallbacks: []string{secondary.URL},
+			Timeout:   5 * time.Second,
+		},
+	}
+	client := services.NewAIClient(cfg)
+
+	resp, err := client.GetDecision(models.AIDecisionRequest{Symbol: "005930", Market: "KR"})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), primaryCalls.Load())
+	assert.Equal(t, "SELL", resp.Decision)
+	assert.Equal(t, "backup-model", resp.Model)
+	assert.Equal(t, strings.TrimPrefix(secondary.URL, "http://"), resp.Provider)
+}
+
+func TestAIClientReturnsErrorWhenAllProvidersFail(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			Endpoint:  failing.URL,
+			Fallbacks: []string{failing.URL},
+			Timeout:   5 * time.Second,
+		},
+	}
+
+	_, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	assert.Error(t, err)
+}
+
+// flakyAIServer 처음 failures 번은 status 로 실패하고 이후에는 BUY 로 응답하는 AI 서비스
+// 핸들러는 서버 고루틴에서 돌므로 호출 수는 atomic 으로 센다
+func flakyAIServer(failures, status int, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(calls.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Symbol: "005930", Decision: "BUY", Confidence: 0.8})
+	}))
+}
+
+func TestAIClientRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := flakyAIServer(1, http.StatusBadGateway, &calls)
+	defer server.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, Retries: 2, RetryBackoff: time.Millisecond},
+	}
+	resp, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930", Market: "KR"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, "BUY", resp.Decision)
+
+	// 재시도 횟수를 넘겨 실패하면 에러 (첫 요청 + 재시도 2번)
+	calls.Store(0)
+	failing := flakyAIServer(5, http.StatusServiceUnavailable, &calls)
+	defer failing.Close()
+	cfg.AI.Endpoint = failing.URL
+	_, err = services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// 4xx 는 다시 보내도 실패하므로 재시도하지 않는다
+	calls.Store(0)
+	badRequest := flakyAIServer(1, http.StatusBadRequest, &calls)
+	defer badRequest.Close()
+	cfg.AI.Endpoint = badRequest.URL
+	_, err = services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestAIClientRetriesTimeouts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Symbol: "005930", Decision: "SELL", Confidence: 0.6})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{Endpoint: server.URL, Timeout: 50 * time.Millisecond, Retries: 1, RetryBackoff: time.Millisecond},
+	}
+	resp, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, "SELL", resp.Decision)
+}
+
+func TestAIClientRetriesStayWithinTimeout(t *testing.T) {
+	var calls atomic.Int32
+	server := flakyAIServer(1000, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+
+	// 너무 큰 재시도 횟수/대기 시간은 상한으로 줄이고, 남은 시간보다 긴 대기는 하지 않는다
+	cfg := &config.Config{
+		AI: config.AIConfig{Endpoint: server.URL, Timeout: 200 * time.Millisecond, Retries: 1000, RetryBackoff: 100 * time.Hour},
+	}
+	start := time.Now()
+	_, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Less(t, time.Since(start), time.Second)
+
+	// 재시도 대기 중 요청이 끝나면 바로 돌아온다
+	calls.Store(0)
+	cfg.AI.Timeout, cfg.AI.Retries, cfg.AI.RetryBackoff = 10*time.Second, 2, time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = services.NewAIClient(cfg).GetDecisionContext(ctx, models.AIDecisionRequest{Symbol: "005930"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+}
+
+func (suite *IntegrationTestSuite) TestAIRetryRecoversWithoutRuleFallback() {
+	var aiCalls atomic.Int32
+	server := flakyAIServer(1, http.StatusInternalServerError, &aiCalls)
+	defer server.Close()
+
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.db.Create(&models.StockPrice{
+			Symbol: "AIRETRY", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, Retries: 2, RetryBackoff: time.Millisecond}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), nil, nil)
+
+	signal, err := generator.GenerateSignal("AIRETRY", "KR")
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), int32(2), aiCalls.Load())
+	assert.Equal(suite.T(), "AI", signal.Source)
+	assert.Equal(suite.T(), "BUY", signal.SignalType)
+}
+
+func (suite *IntegrationTestSuite) TestRuleOnlyModeSkipsAIService() {
+	var aiCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		aiCalls.Add(1)
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: "BUY", Confidence: 0.9})
+	}))
+	defer server.Close()
+
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.db.Create(&models.StockPrice{
+			Symbol: "RULEONLY", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, RuleOnly: true}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), nil, nil)
+
+	signal, err := generator.GenerateSignal("RULEONLY", "KR")
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), int32(0), aiCalls.Load(), "AI service must not be called in rule-only mode")
+	assert.Equal(suite.T(), "RULE", signal.Source)
+	// 규칙 기반 신호도 AI 신호와 같은 강도 매핑을 쓴다
+	assert.Equal(suite.T(), generator.Strength(signal.Confidence), signal.Strength)
+}
+
+func TestAIClientRejectsInvalidDecision(t *testing.T) {
+	responses := []models.AIDecisionResponse{
+		{Decision: "STRONG_BUY", Confidence: 0.8},
+		{Decision: "", Confidence: 0.8},
+		{Decision: "BUY", Confidence: 1.5},
+		{Decision: "SELL", Confidence: -0.1},
+	}
+
+	for _, bogus := range responses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(bogus)
+		}))
+
+		cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second}}
+		_, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+		assert.Error(t, err, "decision=%q confidence=%v", bogus.Decision, bogus.Confidence)
+
+		server.Close()
+	}
+
+	// 소문자 의사결정은 정규화해서 허용
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: " hold ", Confidence: 0.5})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second}}
+	resp, err := services.NewAIClient(cfg).GetDecision(models.AIDecisionRequest{Symbol: "005930"})
+	require.NoError(t, err)
+	assert.Equal(t, "HOLD", resp.Decision)
+}
+
+func (suite *IntegrationTestSuite) TestInvalidAIDecisionFallsBackToRules() {
+	var aiCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		aiCalls.Add(1)
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: "TO_THE_MOON", Confidence: 0.99})
+	}))
+	defer server.Close()
+
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.db.Create(&models.StockPrice{
+			Symbol: "BOGUSAI", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), nil, nil)
+
+	signal, err := generator.GenerateSignal("BOGUSAI", "KR")
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), int32(1), aiCalls.Load())
+	assert.Equal(suite.T(), "RULE", signal.Source)
+	assert.Contains(suite.T(), []string{"BUY", "SELL", "HOLD"}, signal.SignalType)
+}
+
+func TestAIClientThrottlePacesRequests(t *testing.T) {
+	var arrivals []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrivals = append(arrivals, time.Now())
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Symbol: "005930", Decision: "HOLD", Confidence: 0.5})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, RateLimit: 20, RateBurst: 1},
+	}
+	client := services.NewAIClient(cfg)
+	require.Equal(t, 20.0, client.Throttle().Rate())
+
+	for i := 0; i < 5; i++ {
+		_, err := client.GetDecision(models.AIDecisionRequest{Symbol: "005930", Market: "KR"})
+		require.NoError(t, err)
+	}
+	require.Len(t, arrivals, 5)
+
+	// 초당 20회: 요청 간격은 고정 지연이 아니라 50ms 에 맞춰진다
+	for i := 1; i < len(arrivals); i++ {
+		assert.GreaterOrEqual(t, arrivals[i].Sub(arrivals[i-1]), 45*time.Millisecond, "gap %d", i)
+	}
+	assert.Less(t, arrivals[4].Sub(arrivals[0]), 500*time.Millisecond)
+}
+
+func TestAIClientSendsConfiguredContextWindow(t *testing.T) {
+	var payload map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Symbol: "005930", Decision: "HOLD", Confidence: 0.5})
+	}))
+	defer server.Close()
+
+	history := make([]models.AIPriceBar, 20)
+	for i, bar := range syntheticBars(20) {
+		history[i] = models.NewAIPriceBar(bar)
+	}
+	request := models.AIDecisionRequest{
+		Symbol:     "005930",
+		Market:     "KR",
+		Indicators: map[string]float64{"rsi": 55, "macd": 1.2, "sma_20": 100, "obv": 12345, "atr": 2},
+		History:    history,
+	}
+
+	cfg := &config.Config{
+		AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, HistoryBars: 5, Indicators: []string{"rsi", "MACD"}},
+	}
+	_, err := services.NewAIClient(cfg).GetDecision(request)
+	require.NoError(t, err)
+
+	// 가장 최근 5개 봉만 오래된 순으로
+	var sentBars []models.AIPriceBar
+	require.NoError(t, json.Unmarshal(payload["history"], &sentBars))
+	require.Len(t, sentBars, 5)
+	for i, bar := range sentBars {
+		assert.True(t, bar.Timestamp.Equal(history[15+i].Timestamp), "bar %d", i)
+		assert.Equal(t, history[15+i].Close, bar.Close)
+	}
+
+	// 설정한 지표만
+	var sentIndicators map[string]float64
+	require.NoError(t, json.Unmarshal(payload["indicators"], &sentIndicators))
+	assert.Equal(t, map[string]float64{"rsi": 55, "macd": 1.2}, sentIndicators)
+
+	// 호출자의 요청은 그대로
+	assert.Len(t, request.History, 20)
+	assert.Len(t, request.Indicators, 5)
+
+	// 기본값: 봉 이력 없이 모든 지표
+	payload = nil
+	cfg.AI.HistoryBars, cfg.AI.Indicators = 0, nil
+	_, err = services.NewAIClient(cfg).GetDecision(request)
+	require.NoError(t, err)
+	assert.NotContains(t, payload, "history")
+	require.NoError(t, json.Unmarshal(payload["indicators"], &sentIndicators))
+	assert.Len(t, sentIndicators, 5)
+
+	assert.Equal(t, []string{"vwap"}, services.UnknownAIIndicators([]string{"rsi", "vwap", "orderbook_imbalance"}))
+}
+
+func (suite *IntegrationTestSuite) TestSignalGeneratorSendsAIHistory() {
+	var request models.AIDecisionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suite.Require().NoError(json.NewDecoder(r.Body).Decode(&request))
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Symbol: request.Symbol, Decision: "HOLD", Confidence: 0.5})
+	}))
+	defer server.Close()
+
+	bars := syntheticBars(100)
+	start := time.Now().Truncate(time.Second).AddDate(0, 0, -100)
+	for i := range bars {
+		bars[i].Symbol = "AIHIST01"
+		bars[i].Timestamp = start.AddDate(0, 0, i)
+		suite.Require().NoError(suite.db.Create(&bars[i]).Error)
+	}
+
+	// 지표 계산에 쓰는 50개 봉보다 긴 이력도 보낼 수 있다
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, HistoryBars: 80, Indicators: []string{"rsi", "sma_20"}}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), nil, nil)
+
+	_, err := generator.GenerateSignal("AIHIST01", "KR")
+	suite.Require().NoError(err)
+
+	suite.Require().Len(request.History, 80)
+	assert.True(suite.T(), request.History[0].Timestamp.Equal(bars[20].Timestamp))
+	assert.True(suite.T(), request.History[79].Timestamp.Equal(bars[99].Timestamp))
+	assert.ElementsMatch(suite.T(), []string{"rsi", "sma_20"}, keysOf(request.Indicators))
+
+	// 지표는 이력 설정과 무관하게 최근 50개 봉으로 계산한다
+	latest := services.NewIndicatorService().CalculateAll(bars[50:])
+	assert.InDelta(suite.T(), latest.SMA20, request.Indicators["sma_20"], 1e-6)
+	assert.Equal(suite.T(), 50, int(request.Metadata["data_points"].(float64)))
+}
+
+func keysOf(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return keys
+}
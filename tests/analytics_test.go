@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"net/http"
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestRollingBetaTracksLeverage() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	benchmark, symbol := 100.0, 100.0
+	for i := 0; i < 30; i++ {
+		// 지수 수익률을 흔들고 종목은 항상 그 2배로 움직인다
+		r := 0.01 * float64(i%5-2)
+		benchmark *= 1 + r
+		symbol *= 1 + 2*r
+		ts := start.AddDate(0, 0, i)
+
+		suite.db.Create(&models.StockPrice{Symbol: "INDEX", Market: "KR", ClosePrice: benchmark, Timestamp: ts})
+		suite.db.Create(&models.StockPrice{Symbol: "LEVER", Market: "KR", ClosePrice: symbol, Timestamp: ts})
+	}
+
+	analytics := services.NewAnalyticsService(suite.db)
+	betas, err := analytics.RollingBeta("LEVER", "INDEX", 10)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), betas, 29-10+1)
+	for _, point := range betas {
+		assert.InDelta(suite.T(), 2.0, point.Beta, 0.01)
+	}
+
+	_, err = analytics.RollingBeta("LEVER", "INDEX", 2)
+	assert.Error(suite.T(), err, "window below minimum")
+
+	_, err = analytics.RollingBeta("LEVER", "INDEX", 40)
+	assert.Error(suite.T(), err, "window longer than history")
+}
+
+func (suite *IntegrationTestSuite) TestDrawdownWithoutPricesIsNotFound() {
+	_, err := services.NewAnalyticsService(suite.db).Drawdown("NODATA", time.Time{}, time.Time{})
+	suite.Require().Error(err)
+
+	// 가격이 없는 경우만 404 로 응답하고 DB 장애 등은 500 으로 응답한다
+	status, _ := handlers.StatusForError(err)
+	assert.Equal(suite.T(), http.StatusNotFound, status)
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	closes := []float64{100, 120, 90, 110, 60, 130, 100}
+
+	maxDD, peakIdx, troughIdx := services.MaxDrawdown(closes)
+	assert.InDelta(t, 50.0, maxDD, 1e-9)
+	assert.Equal(t, 1, peakIdx)
+	assert.Equal(t, 4, troughIdx)
+
+	underwater := services.UnderwaterCurve(closes)
+	expected := []float64{0, 0, 25, 100.0 / 12, 50, 0, 300.0 / 13}
+	assert.Len(t, underwater, len(closes))
+	for i := range expected {
+		assert.InDelta(t, expected[i], underwater[i], 1e-9, "index %d", i)
+	}
+
+	// 하락이 없는 시계열
+	maxDD, peakIdx, troughIdx = services.MaxDrawdown([]float64{1, 2, 3})
+	assert.Zero(t, maxDD)
+	assert.Equal(t, 0, peakIdx)
+	assert.Equal(t, 0, troughIdx)
+}
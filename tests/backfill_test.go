@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	apierrors "stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryBackfillStore 재시작을 흉내내기 위해 스케줄러 밖에 상태를 두는 저장소
+type memoryBackfillStore struct {
+	jobs  []models.BackfillJob
+	usage map[string]int
+}
+
+func (s *memoryBackfillStore) PendingBackfillJobs() ([]models.BackfillJob, error) {
+	var pending []models.BackfillJob
+	for _, job := range s.jobs {
+		if job.Status == models.BackfillPending {
+			pending = append(pending, job)
+		}
+	}
+	return pending, nil
+}
+
+func (s *memoryBackfillStore) SaveBackfillJob(job *models.BackfillJob) error {
+	for i := range s.jobs {
+		if s.jobs[i].ID == job.ID {
+			s.jobs[i] = *job
+		}
+	}
+	return nil
+}
+
+func (s *memoryBackfillStore) BackfillCalls(day string) (int, error) {
+	return s.usage[day], nil
+}
+
+func (s *memoryBackfillStore) AddBackfillCalls(day string, n int) error {
+	s.usage[day] += n
+	return nil
+}
+
+type backfillWindow struct {
+	symbol   string
+	from, to string
+}
+
+type recordingRangeFetcher struct {
+	windows []backfillWindow
+	errs    map[string]error
+}
+
+func (f *recordingRangeFetcher) FetchDailyRange(symbol, market string, from, to time.Time) error {
+	f.windows = append(f.windows, backfillWindow{symbol, from.Format("2006-01-02"), to.Format("2006-01-02")})
+	return f.errs[symbol]
+}
+
+func backfillDate(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func newBackfillJob(id uint, symbol, from, to string) models.BackfillJob {
+	return models.BackfillJob{
+		ID: id, Symbol: symbol, Market: "KR",
+		FromDate: backfillDate(from), ToDate: backfillDate(to), Cursor: backfillDate(from),
+		Status: models.BackfillPending,
+	}
+}
+
+func TestBackfillSchedulerStopsAtBudgetAndResumes(t *testing.T) {
+	store := &memoryBackfillStore{
+		jobs: []models.BackfillJob{
+			newBackfillJob(1, "005930", "2024-01-01", "2024-01-25"), // 10일 구간 3개
+			newBackfillJob(2, "000660", "2024-01-01", "2024-01-10"), // 1개
+		},
+		usage: map[string]int{},
+	}
+	fetcher := &recordingRangeFetcher{}
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	day1 := time.Date(2024, 6, 3, 9, 0, 0, 0, seoul)
+
+	scheduler := workers.NewBackfillScheduler(store, fetcher, 2, 10)
+	assert.Equal(t, 2, scheduler.RunOnce(day1))
+	assert.Equal(t, []backfillWindow{
+		{"005930", "2024-01-01", "2024-01-10"},
+		{"005930", "2024-01-11", "2024-01-20"},
+	}, fetcher.windows)
+	assert.Equal(t, "2024-01-21", store.jobs[0].Cursor.Format("2006-01-02"))
+
+	// 같은 날에는 예산이 남지 않아 더 호출하지 않는다
+	assert.Equal(t, 0, scheduler.RunOnce(day1.Add(time.Hour)))
+
+	// 재시작: 새 스케줄러도 저장된 호출 수와 커서를 이어받는다
+	restarted := workers.NewBackfillScheduler(store, fetcher, 2, 10)
+	assert.Equal(t, 0, restarted.RunOnce(day1.Add(2*time.Hour)))
+
+	// 다음 날(한국 시간 자정 이후) 남은 구간부터 재개
+	assert.Equal(t, 2, restarted.RunOnce(time.Date(2024, 6, 4, 0, 5, 0, 0, seoul)))
+	assert.Equal(t, []backfillWindow{
+		{"005930", "2024-01-21", "2024-01-25"},
+		{"000660", "2024-01-01", "2024-01-10"},
+	}, fetcher.windows[2:])
+	assert.Equal(t, models.BackfillDone, store.jobs[0].Status)
+	assert.Equal(t, models.BackfillDone, store.jobs[1].Status)
+	assert.Equal(t, 2, store.usage["2024-06-03"])
+	assert.Equal(t, 2, store.usage["2024-06-04"])
+}
+
+func TestBackfillSchedulerPausesOnRateLimit(t *testing.T) {
+	store := &memoryBackfillStore{
+		jobs: []models.BackfillJob{
+			newBackfillJob(1, "005930", "2024-01-01", "2024-01-10"),
+			newBackfillJob(2, "BAD", "2024-01-01", "2024-01-10"),
+		},
+		usage: map[string]int{},
+	}
+	fetcher := &recordingRangeFetcher{errs: map[string]error{
+		"005930": apierrors.NewRateLimitError("호출 거래건수를 초과하였습니다"),
+	}}
+	scheduler := workers.NewBackfillScheduler(store, fetcher, 10, 10)
+	now := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	// 한도 초과는 커서를 유지한 채 이번 실행을 멈춘다
+	assert.Equal(t, 1, scheduler.RunOnce(now))
+	assert.Equal(t, models.BackfillPending, store.jobs[0].Status)
+	assert.Equal(t, "2024-01-01", store.jobs[0].Cursor.Format("2006-01-02"))
+
+	// 그 밖의 에러는 작업을 실패로 표시하고 다음 작업으로 넘어간다
+	fetcher.errs = map[string]error{"BAD": errors.New("daily backfill is not supported")}
+	assert.Equal(t, 2, scheduler.RunOnce(now))
+	assert.Equal(t, models.BackfillDone, store.jobs[0].Status)
+	assert.Equal(t, models.BackfillFailed, store.jobs[1].Status)
+	assert.Contains(t, store.jobs[1].Error, "not supported")
+}
+
+func (suite *IntegrationTestSuite) TestBackfillServicePersistsCursorAndUsage() {
+	backfill := services.NewBackfillService(suite.db)
+	job, err := backfill.Enqueue("005930", "KR", backfillDate("2024-01-01"), backfillDate("2024-03-31"))
+	suite.Require().NoError(err)
+
+	_, err = backfill.Enqueue("005930", "KR", backfillDate("2024-03-31"), backfillDate("2024-01-01"))
+	suite.Error(err)
+
+	job.Cursor = backfillDate("2024-02-01")
+	suite.Require().NoError(backfill.SaveBackfillJob(job))
+	suite.Require().NoError(backfill.AddBackfillCalls("2024-06-03", 3))
+	suite.Require().NoError(backfill.AddBackfillCalls("2024-06-03", 2))
+
+	pending, err := backfill.PendingBackfillJobs()
+	suite.Require().NoError(err)
+	require.Len(suite.T(), pending, 1)
+	assert.Equal(suite.T(), "2024-02-01", pending[0].Cursor.Format("2006-01-02"))
+
+	calls, err := backfill.BackfillCalls("2024-06-03")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 5, calls)
+}
+
+func (suite *IntegrationTestSuite) TestEnqueueBackfillRejectsNonKRMarkets() {
+	enqueue := func(body string) int {
+		req, _ := http.NewRequest("POST", "/api/v1/admin/backfill", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// 기간 일봉 API 가 없는 시장은 작업을 만들지 않고 400
+	assert.Equal(suite.T(), http.StatusBadRequest,
+		enqueue(`{"symbols": ["AAPL"], "market": "US", "from": "2024-01-01", "to": "2024-03-31"}`))
+	var count int64
+	suite.db.Model(&models.BackfillJob{}).Where("symbol = ?", "AAPL").Count(&count)
+	assert.Zero(suite.T(), count)
+
+	assert.Equal(suite.T(), http.StatusAccepted,
+		enqueue(`{"symbols": ["005930"], "market": "KR", "from": "2024-01-01", "to": "2024-03-31"}`))
+}
@@ -0,0 +1,382 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticBars 추세 위에 사인파를 얹은 일봉
+func syntheticBars(n int) []models.StockPrice {
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars := make([]models.StockPrice, n)
+	for i := range bars {
+		close := 100 + float64(i)*0.2 + 8*math.Sin(float64(i)/6)
+		bars[i] = models.StockPrice{
+			Symbol:      "005930",
+			Market:      "KR",
+			OpenPrice:   close - 0.5,
+			HighPrice:   close + 1,
+			LowPrice:    close - 1,
+			ClosePrice:  close,
+			Volume:      int64(1000 + i),
+			Timestamp:   start.AddDate(0, 0, i),
+			Granularity: models.GranularityDaily,
+		}
+	}
+	return bars
+}
+
+// recordingStrategy 전략이 본 현재 봉 시각을 기록
+type recordingStrategy struct {
+	services.BacktestStrategy
+	seen []time.Time
+}
+
+func (r *recordingStrategy) Decide(history []models.StockPrice) string {
+	r.seen = append(r.seen, history[len(history)-1].Timestamp)
+	return r.BacktestStrategy.Decide(history)
+}
+
+// rsiDecider RSI 기준으로 결정하는 결정적 AI 대역
+type rsiDecider struct{}
+
+func (rsiDecider) GetDecisionContext(_ context.Context, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	decision := "HOLD"
+	switch rsi := request.Indicators["rsi"]; {
+	case rsi < 45:
+		decision = "BUY"
+	case rsi > 55:
+		decision = "SELL"
+	}
+	return &models.AIDecisionResponse{Symbol: request.Symbol, Decision: decision, Confidence: 0.7}, nil
+}
+
+// scriptedStrategy 현재 봉 인덱스별로 정해 둔 판단
+type scriptedStrategy map[int]string
+
+func (s scriptedStrategy) Name() string { return "scripted" }
+
+func (s scriptedStrategy) Decide(history []models.StockPrice) string {
+	return s[len(history)-1]
+}
+
+func TestBacktestCompareRunsStrategiesOnSameBars(t *testing.T) {
+	indicators := services.NewIndicatorService()
+	rule := &recordingStrategy{BacktestStrategy: services.NewRuleStrategy(indicators)}
+	ai := &recordingStrategy{BacktestStrategy: services.NewAIStrategy(indicators, rsiDecider{})}
+
+	bars := syntheticBars(160)
+	// 입력 순서와 관계없이 시간순으로 진행
+	bars[10], bars[20] = bars[20], bars[10]
+
+	comparison := services.NewBacktester().Compare(bars, rule, ai)
+
+	// 두 전략 모두 50봉 이후 같은 봉을 같은 순서로 본다
+	require.Len(t, rule.seen, 160-49)
+	assert.Equal(t, rule.seen, ai.seen)
+	for i := 1; i < len(rule.seen); i++ {
+		assert.True(t, rule.seen[i].After(rule.seen[i-1]))
+	}
+
+	assert.Equal(t, 160, comparison.Bars)
+	assert.Equal(t, syntheticBars(50)[49].Timestamp, comparison.From, "from is the first bar strategies decide on")
+	require.Len(t, comparison.Results, 2)
+	assert.Equal(t, "rule", comparison.Results[0].Strategy)
+	assert.Equal(t, "ai", comparison.Results[1].Strategy)
+	for _, result := range comparison.Results {
+		assert.Equal(t, len(result.Trades), result.TradeCount)
+		assert.GreaterOrEqual(t, result.MaxDrawdown, 0.0)
+	}
+	assert.Greater(t, comparison.Results[1].TradeCount, 0)
+
+	for _, metric := range []string{"total_return", "win_rate", "max_drawdown", "trade_count"} {
+		assert.Contains(t, []string{"rule", "ai", "tie"}, comparison.Winners[metric], metric)
+	}
+
+	// 같은 입력이면 결과도 같다
+	again := services.NewBacktester().Compare(bars, services.NewRuleStrategy(indicators), services.NewAIStrategy(indicators, rsiDecider{}))
+	assert.Equal(t, comparison.Results[0].TotalReturn, again.Results[0].TotalReturn)
+	assert.Equal(t, comparison.Results[1].TotalReturn, again.Results[1].TotalReturn)
+}
+
+func TestBacktestRunMetrics(t *testing.T) {
+	bars := syntheticBars(60)
+	closes := []float64{100, 110, 99, 90, 95, 120, 125, 130, 128, 131, 132}
+	for i, close := range closes {
+		bars[49+i].ClosePrice = close
+	}
+
+	// 49: 100 매수 → 50: 110 매도 (+10%), 51: 99 매수 → 53: 95 매도, 54: 120 매수 후 기간 끝에 청산
+	strategy := scriptedStrategy{49: "BUY", 50: "SELL", 51: "BUY", 52: "BUY", 53: "SELL", 54: "BUY"}
+	result := services.NewBacktester().Run(bars, strategy)
+
+	require.Equal(t, 3, result.TradeCount)
+	assert.InDelta(t, 10, result.Trades[0].Return, 1e-9)
+	assert.InDelta(t, (95.0/99-1)*100, result.Trades[1].Return, 1e-9)
+	assert.Equal(t, bars[59].Timestamp, result.Trades[2].ExitTime)
+	assert.InDelta(t, 10, result.Trades[2].Return, 1e-9)
+
+	assert.InDelta(t, (1.1*95/99*1.1-1)*100, result.TotalReturn, 1e-9)
+	assert.InDelta(t, 200.0/3, result.WinRate, 1e-9)
+	// 평가 자산 고점 1.1 에서 90 까지 하락
+	assert.InDelta(t, (1-90.0/99)*100, result.MaxDrawdown, 1e-9)
+}
+
+func TestBacktestWinnerTies(t *testing.T) {
+	bars := syntheticBars(55)
+	comparison := services.NewBacktester().Compare(bars, scriptedStrategy{}, scriptedStrategy{})
+	for metric, winner := range comparison.Winners {
+		assert.Equal(t, "tie", winner, metric)
+	}
+}
+
+func TestBacktestCostsReduceReturn(t *testing.T) {
+	bars := syntheticBars(55)
+	bars[49].ClosePrice = 1000
+	bars[52].ClosePrice = 1100
+	strategy := scriptedStrategy{49: "BUY", 52: "SELL"}
+
+	free := services.NewBacktester().WithCapital(1_000_000).Run(bars, strategy)
+	require.Equal(t, 1, free.TradeCount)
+	assert.InDelta(t, 10, free.TotalReturn, 1e-9)
+	assert.InDelta(t, 10, free.GrossReturn, 1e-9)
+	assert.Zero(t, free.Commission)
+	assert.Zero(t, free.Slippage)
+
+	costs := services.BacktestCosts{CommissionFixed: 500, CommissionBps: 15, SlippageBps: 10, SlippageTicks: 2}
+	costed := services.NewBacktester().WithCapital(1_000_000).WithCosts(costs).Run(bars, strategy)
+	require.Equal(t, 1, costed.TradeCount)
+
+	// 국내 1,000~1,100원 구간 호가 단위는 1원: 매수 1000+1+2=1003, 매도 1100-1.1-2=1096.9
+	buyFill, sellFill := 1003.0, 1096.9
+	notional := (1_000_000 - 500) / 1.0015
+	shares := notional / buyFill
+	proceeds := shares * sellFill
+	sellCommission := 500 + proceeds*0.0015
+	expected := (proceeds-sellCommission)/1_000_000*100 - 100
+
+	assert.InDelta(t, buyFill, costed.Trades[0].EntryPrice, 1e-9)
+	assert.InDelta(t, sellFill, costed.Trades[0].ExitPrice, 1e-9)
+	assert.InDelta(t, expected, costed.TotalReturn, 1e-9)
+	assert.InDelta(t, free.TotalReturn-expected, free.TotalReturn-costed.TotalReturn, 1e-9)
+	assert.Less(t, costed.TotalReturn, free.TotalReturn)
+	assert.InDelta(t, free.TotalReturn, costed.GrossReturn, 1e-9)
+	assert.InDelta(t, 1_000_000-notional+sellCommission, costed.Commission, 1e-6)
+	assert.InDelta(t, shares*(3+3.1), costed.Slippage, 1e-6)
+}
+
+func TestTickSize(t *testing.T) {
+	assert.Equal(t, 1.0, apimodels.TickSize("KR", 1999))
+	assert.Equal(t, 50.0, apimodels.TickSize("J", 35000))
+	assert.Equal(t, 1000.0, apimodels.TickSize("KR", 600000))
+	assert.Equal(t, 0.01, apimodels.TickSize("NASDAQ", 180))
+	assert.Equal(t, 0.0001, apimodels.TickSize("US", 0.5))
+}
+
+// periodicStrategy 봉 날짜 기준으로 주기적으로 매매 (구간 경계와 무관하게 결정적)
+type periodicStrategy struct {
+	name         string
+	period, hold int
+}
+
+func (s periodicStrategy) Name() string { return s.name }
+
+func (s periodicStrategy) Decide(history []models.StockPrice) string {
+	day := int(history[len(history)-1].Timestamp.Sub(syntheticBars(1)[0].Timestamp).Hours() / 24)
+	switch day % s.period {
+	case 0:
+		return "BUY"
+	case s.hold:
+		return "SELL"
+	}
+	return "HOLD"
+}
+
+func TestBacktestWalkForwardSegments(t *testing.T) {
+	bars := syntheticBars(400)
+	backtester := services.NewBacktester()
+
+	var inSamples [][]models.StockPrice
+	candidates := backtester.BestOf(
+		periodicStrategy{name: "fast", period: 6, hold: 3},
+		periodicStrategy{name: "slow", period: 20, hold: 12},
+	)
+	factory := func(inSample []models.StockPrice) services.BacktestStrategy {
+		inSamples = append(inSamples, inSample)
+		return candidates(inSample)
+	}
+
+	result, err := backtester.WalkForward(bars, services.WalkForwardWindow{InSample: 100, OutOfSample: 50}, factory)
+	require.NoError(t, err)
+
+	// (400 - 100) / 50 = 6 구간, 구간마다 전략을 새로 정한다
+	require.Len(t, result.Segments, 6)
+	require.Len(t, inSamples, 6)
+
+	growth := 1.0
+	trades := 0
+	for i, segment := range result.Segments {
+		assert.Len(t, inSamples[i], 100)
+		assert.Equal(t, bars[i*50].Timestamp, segment.InSampleFrom)
+		assert.Equal(t, bars[i*50+99].Timestamp, segment.InSampleTo)
+		assert.Equal(t, bars[i*50+100].Timestamp, segment.OutOfSampleFrom)
+		assert.Equal(t, bars[i*50+149].Timestamp, segment.OutOfSampleTo)
+		assert.True(t, segment.OutOfSampleFrom.After(segment.InSampleTo))
+		if i > 0 {
+			assert.True(t, segment.OutOfSampleFrom.After(result.Segments[i-1].OutOfSampleTo))
+		}
+
+		// 인샘플과 아웃오브샘플 성과는 따로 보고되고, 아웃오브샘플 거래는 해당 구간 안에서만 진입
+		assert.Equal(t, segment.InSample.Strategy, segment.OutOfSample.Strategy)
+		assert.Contains(t, []string{"fast", "slow"}, segment.OutOfSample.Strategy)
+		require.NotEmpty(t, segment.OutOfSample.Trades)
+		for _, trade := range segment.OutOfSample.Trades {
+			assert.False(t, trade.EntryTime.Before(segment.OutOfSampleFrom))
+			assert.False(t, trade.ExitTime.After(segment.OutOfSampleTo))
+		}
+		for _, trade := range segment.InSample.Trades {
+			assert.False(t, trade.ExitTime.After(segment.InSampleTo))
+		}
+
+		growth *= 1 + segment.OutOfSample.TotalReturn/100
+		trades += segment.OutOfSample.TradeCount
+	}
+
+	assert.Equal(t, "walk_forward", result.Aggregate.Strategy)
+	assert.Equal(t, trades, result.Aggregate.TradeCount)
+	assert.InDelta(t, (growth-1)*100, result.Aggregate.TotalReturn, 1e-9)
+	assert.GreaterOrEqual(t, result.Aggregate.MaxDrawdown, 0.0)
+
+	_, err = backtester.WalkForward(bars, services.WalkForwardWindow{InSample: 30, OutOfSample: 50}, factory)
+	assert.Error(t, err, "in-sample shorter than the indicator lookback")
+	_, err = backtester.WalkForward(bars[:120], services.WalkForwardWindow{InSample: 100, OutOfSample: 50}, factory)
+	assert.Error(t, err)
+}
+
+func TestBacktestWalkForwardPicksRuleParamsPerWindow(t *testing.T) {
+	bars := syntheticBars(300)
+	indicators := services.NewIndicatorService()
+	backtester := services.NewBacktester()
+
+	result, err := backtester.WalkForward(bars, services.WalkForwardWindow{InSample: 100, OutOfSample: 50},
+		backtester.BestParams(indicators, services.RuleParamGrid))
+	require.NoError(t, err)
+	require.Len(t, result.Segments, 4)
+
+	for i, segment := range result.Segments {
+		// 구간마다 후보 중 인샘플 수익률이 가장 높은 지표 기간을 고르고 결과에 남긴다
+		require.NotNil(t, segment.Params)
+		assert.Contains(t, services.RuleParamGrid, *segment.Params)
+		inSample := bars[i*50 : i*50+100]
+		for _, params := range services.RuleParamGrid {
+			candidate := backtester.Run(inSample, services.NewRuleStrategy(indicators).WithParams(params))
+			assert.LessOrEqual(t, candidate.TotalReturn, segment.InSample.TotalReturn)
+		}
+		assert.Equal(t, "rule", segment.OutOfSample.Strategy)
+	}
+}
+
+// countingDecider 호출 횟수를 세는 AI 대역
+type countingDecider struct {
+	rsiDecider
+	calls int
+}
+
+func (d *countingDecider) GetDecisionContext(ctx context.Context, request models.AIDecisionRequest) (*models.AIDecisionResponse, error) {
+	d.calls++
+	return d.rsiDecider.GetDecisionContext(ctx, request)
+}
+
+func TestAIStrategyStopsCallingAIWhenRequestEnds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	decider := &countingDecider{}
+	strategy := services.NewAIStrategy(services.NewIndicatorService(), decider).WithContext(ctx)
+	bars := syntheticBars(80)
+
+	services.NewBacktester().Run(bars[:60], strategy)
+	assert.Equal(t, 11, decider.calls)
+
+	// 요청이 끝나면 남은 봉은 AI 를 부르지 않고 관망한다
+	cancel()
+	result := services.NewBacktester().Run(bars, strategy)
+	assert.Equal(t, 11, decider.calls)
+	assert.Equal(t, 0, result.TradeCount)
+}
+
+func (suite *IntegrationTestSuite) TestBacktestCompareUsesSharedIndicatorCache() {
+	bars := syntheticBars(80)
+	for i := range bars {
+		bars[i].Symbol = "BTCACHE"
+		suite.Require().NoError(suite.db.Create(&bars[i]).Error)
+	}
+
+	// 같은 기간을 다시 비교하면 신호 생성과 함께 쓰는 지표 캐시에서 계산 결과를 꺼낸다
+	cache := services.NewIndicatorCache(1000)
+	h := handlers.NewBacktestHandler(suite.db, suite.cfg, nil).WithIndicators(services.NewIndicatorService().WithCache(cache))
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/backtest/compare", h.Compare)
+	compare := func() {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/backtest/compare?symbol=BTCACHE&from=2024-02-20&to=2024-03-21", nil))
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	}
+
+	compare()
+	first := cache.Stats()
+	assert.Positive(suite.T(), first.Misses)
+	compare()
+	second := cache.Stats()
+	assert.Equal(suite.T(), first.Misses, second.Misses)
+	assert.Greater(suite.T(), second.Hits, first.Hits)
+}
+
+func (suite *IntegrationTestSuite) TestBacktestWalkForwardEndpoint() {
+	bars := syntheticBars(200)
+	for i := range bars {
+		bars[i].Symbol = "BTWALK"
+		suite.Require().NoError(suite.db.Create(&bars[i]).Error)
+	}
+
+	h := handlers.NewBacktestHandler(suite.db, suite.cfg, nil)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/backtest/walk-forward", h.WalkForward)
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/backtest/walk-forward?"+query, nil))
+		return w
+	}
+
+	w := get("symbol=BTWALK&from=2020-01-01&to=2030-12-31&in_sample=100&out_of_sample=50")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		WalkForward services.WalkForwardResult `json:"walk_forward"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Require().Len(body.WalkForward.Segments, 2)
+	for _, segment := range body.WalkForward.Segments {
+		assert.NotNil(suite.T(), segment.Params)
+	}
+
+	// 잘못된 구간 길이와 기간보다 긴 구간은 400
+	assert.Equal(suite.T(), http.StatusBadRequest, get("symbol=BTWALK&from=2020-01-01&to=2030-12-31&in_sample=abc").Code)
+	assert.Equal(suite.T(), http.StatusBadRequest, get("symbol=BTWALK&from=2020-01-01&to=2030-12-31&in_sample=20").Code)
+	assert.Equal(suite.T(), http.StatusBadRequest, get("symbol=BTWALK&from=2020-01-01&to=2030-12-31&in_sample=180&out_of_sample=40").Code)
+}
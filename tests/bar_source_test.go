@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestDBBarSourceStreamsDailyBarsInOrder() {
+	bars := syntheticBars(120)
+	for i := range bars {
+		bars[i].Symbol = "REPLAY01"
+	}
+	// 시간 역순으로 저장해 id 순서가 아니라 시각 순서로 읽는지 확인
+	for i := len(bars) - 1; i >= 0; i-- {
+		suite.Require().NoError(suite.db.Create(&bars[i]).Error)
+	}
+	// 다른 종목, 장중 스냅샷, 기간 밖의 봉은 읽지 않는다
+	start := bars[0].Timestamp
+	suite.Require().NoError(suite.db.Create(&models.StockPrice{
+		Symbol: "REPLAY02", Market: "KR", Granularity: models.GranularityDaily, Timestamp: start,
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&models.StockPrice{
+		Symbol: "REPLAY01", Market: "KR", Granularity: models.GranularityIntraday, Timestamp: start.Add(time.Hour),
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&models.StockPrice{
+		Symbol: "REPLAY01", Market: "KR", Granularity: models.GranularityDaily, Timestamp: start.AddDate(0, 0, -1),
+	}).Error)
+
+	source := services.NewDBBarSource(suite.db, "REPLAY01", start, bars[len(bars)-1].Timestamp).WithPageSize(25)
+
+	var streamed []models.StockPrice
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 10, "bar source did not terminate")
+
+		page, err := source.Next()
+		suite.Require().NoError(err)
+		suite.Require().LessOrEqual(len(page), 25)
+		if len(page) == 0 {
+			break
+		}
+		streamed = append(streamed, page...)
+	}
+
+	// 빠지거나 중복된 봉 없이 하루씩 시간순으로 전부 읽는다
+	suite.Require().Len(streamed, len(bars))
+	for i, bar := range streamed {
+		assert.True(suite.T(), bar.Timestamp.Equal(bars[i].Timestamp), "bar %d at %v, expected %v", i, bar.Timestamp, bars[i].Timestamp)
+		assert.InDelta(suite.T(), bars[i].ClosePrice, bar.ClosePrice, 1e-4)
+		if i > 0 {
+			assert.True(suite.T(), bar.Timestamp.Equal(streamed[i-1].Timestamp.AddDate(0, 0, 1)), "gap before bar %d", i)
+		}
+	}
+
+	// 다 읽은 뒤에는 계속 빈 묶음
+	page, err := source.Next()
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), page)
+
+	// 같은 봉으로 실행한 결과와 같다
+	strategy := services.NewRuleStrategy(services.NewIndicatorService())
+	replayed, err := services.NewBacktester().RunSource(
+		services.NewDBBarSource(suite.db, "REPLAY01", start, bars[len(bars)-1].Timestamp).WithPageSize(7), strategy)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), services.NewBacktester().Run(streamed, strategy), replayed)
+}
@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock-recommender/backend/handlers"
+	apierrors "stock-recommender/backend/openapi/errors"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchService failing 에 있는 종목은 실패하는 일괄 조회 서비스
+type fakeBatchService struct {
+	symbols []string
+	failing map[string]error
+	count   int
+}
+
+func (f *fakeBatchService) GetPopularStockPrices() (map[string]*apimodels.ForeignPriceResult, error) {
+	results := make(map[string]*apimodels.ForeignPriceResult)
+	for _, code := range f.symbols {
+		result := &apimodels.ForeignPriceResult{StockCode: code}
+		if err, ok := f.failing[code]; ok {
+			result.Error = err.Error()
+		} else {
+			result.Data = &apimodels.ForeignCurrentPriceData{StockCode: code, CurrentPrice: 100}
+		}
+		results[code] = result
+	}
+	return results, nil
+}
+
+func (f *fakeBatchService) GetTechGiantsPrices() (map[string]*apimodels.ForeignPriceResult, error) {
+	return f.GetPopularStockPrices()
+}
+
+func (f *fakeBatchService) PopularStocksDayCharts(days int) (map[string][]apimodels.ForeignDayChartData, map[string]error) {
+	return f.TechGiantsDayCharts(days)
+}
+
+func (f *fakeBatchService) TechGiantsDayCharts(days int) (map[string][]apimodels.ForeignDayChartData, map[string]error) {
+	f.count = days
+	results := make(map[string][]apimodels.ForeignDayChartData)
+	failures := make(map[string]error)
+	for _, code := range f.symbols {
+		if err, ok := f.failing[code]; ok {
+			failures[code] = err
+			continue
+		}
+		results[code] = []apimodels.ForeignDayChartData{{StockCode: code, Close: 100}}
+	}
+	return results, failures
+}
+
+func (f *fakeBatchService) TechGiantsWeekCharts(weeks int) (map[string][]apimodels.ForeignWeekChartData, map[string]error) {
+	f.count = weeks
+	results := make(map[string][]apimodels.ForeignWeekChartData)
+	failures := make(map[string]error)
+	for _, code := range f.symbols {
+		if err, ok := f.failing[code]; ok {
+			failures[code] = err
+			continue
+		}
+		results[code] = []apimodels.ForeignWeekChartData{{StockCode: code}}
+	}
+	return results, failures
+}
+
+func (f *fakeBatchService) TechGiantsMonthCharts(months int) (map[string][]apimodels.ForeignMonthChartData, map[string]error) {
+	f.count = months
+	return map[string][]apimodels.ForeignMonthChartData{}, map[string]error{}
+}
+
+type batchResponse struct {
+	Results   map[string]json.RawMessage `json:"results"`
+	Errors    map[string]string          `json:"errors"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+}
+
+func newBatchRouter(service *fakeBatchService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+
+	h := handlers.NewBatchHandler(service, service, service, service)
+	r.GET("/batch/popular/prices", h.GetPopularPrices)
+	r.GET("/batch/tech-giants/prices", h.GetTechGiantsPrices)
+	r.GET("/batch/popular/chart/day", h.GetPopularDayCharts)
+	r.GET("/batch/tech-giants/chart/:period", h.GetTechGiantsCharts)
+	return r
+}
+
+func TestBatchEndpointsReturnPartialResults(t *testing.T) {
+	service := &fakeBatchService{
+		symbols: []string{"AAPL", "MSFT", "TSLA"},
+		failing: map[string]error{"TSLA": apierrors.NewAPIError(apierrors.ErrCodeServerError, "API returned error", errors.New("code: 99999"))},
+	}
+	r := newBatchRouter(service)
+
+	for _, path := range []string{
+		"/batch/popular/prices",
+		"/batch/tech-giants/prices",
+		"/batch/popular/chart/day",
+		"/batch/tech-giants/chart/day?limit=30",
+		"/batch/tech-giants/chart/week",
+	} {
+		t.Run(path, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// 성공한 종목과 실패한 종목이 함께 207 로 온다
+			require.Equal(t, http.StatusMultiStatus, w.Code, w.Body.String())
+			var response batchResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response.Results, 2)
+			assert.Contains(t, response.Results, "AAPL")
+			assert.Contains(t, response.Results, "MSFT")
+			assert.NotContains(t, response.Results, "TSLA")
+			require.Len(t, response.Errors, 1)
+			assert.Contains(t, response.Errors["TSLA"], "99999")
+			assert.Equal(t, 2, response.Succeeded)
+			assert.Equal(t, 1, response.Failed)
+		})
+	}
+	assert.Equal(t, 52, service.count, "week chart uses default count")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 모두 성공하면 200, 모두 실패하면 502
+	service.failing = nil
+	assert.Equal(t, http.StatusOK, get("/batch/popular/prices").Code)
+	service.failing = map[string]error{"AAPL": errors.New("down"), "MSFT": errors.New("down"), "TSLA": errors.New("down")}
+	w := get("/batch/tech-giants/chart/day")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	var response batchResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Errors, 3)
+
+	assert.Equal(t, http.StatusOK, get("/batch/tech-giants/chart/month").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/batch/tech-giants/chart/year").Code)
+
+	// 단건 차트와 같은 상한을 넘는 limit 은 종목마다 API 를 부르기 전에 거절
+	service.count = 0
+	for _, path := range []string{
+		"/batch/popular/chart/day?limit=5001",
+		"/batch/tech-giants/chart/week?limit=5001",
+	} {
+		assert.Equal(t, http.StatusBadRequest, get(path).Code, path)
+	}
+	assert.Zero(t, service.count)
+	assert.Equal(t, http.StatusOK, get("/batch/tech-giants/chart/month?limit=5000").Code)
+	assert.Equal(t, 5000, service.count)
+}
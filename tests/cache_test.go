@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCacheDown = errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+
+// failingCacheBackend 모든 호출이 실패하는 캐시 저장소 (Redis 다운)
+type failingCacheBackend struct{}
+
+func (failingCacheBackend) Get(context.Context, string) (string, error) { return "", errCacheDown }
+func (failingCacheBackend) Set(context.Context, string, []byte, time.Duration) error {
+	return errCacheDown
+}
+func (failingCacheBackend) HSet(context.Context, string, map[string]interface{}, time.Duration) error {
+	return errCacheDown
+}
+func (failingCacheBackend) HGetAll(context.Context, string) (map[string]string, error) {
+	return nil, errCacheDown
+}
+func (failingCacheBackend) DeleteMatching(context.Context, string) error { return errCacheDown }
+func (failingCacheBackend) Ping(context.Context) error                   { return errCacheDown }
+
+// memoryCacheBackend 테스트용 메모리 캐시 저장소 (TTL 무시)
+type memoryCacheBackend struct {
+	mu     sync.Mutex
+	values map[string]string
+	down   bool
+	calls  int // Get/Set 호출 수
+}
+
+func newMemoryCacheBackend() *memoryCacheBackend {
+	return &memoryCacheBackend{values: map[string]string{}}
+}
+
+func (m *memoryCacheBackend) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.down {
+		return "", errCacheDown
+	}
+	value, ok := m.values[key]
+	if !ok {
+		return "", services.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (m *memoryCacheBackend) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	if m.down {
+		return errCacheDown
+	}
+	m.values[key] = string(value)
+	return nil
+}
+
+func (m *memoryCacheBackend) HSet(context.Context, string, map[string]interface{}, time.Duration) error {
+	return nil
+}
+
+func (m *memoryCacheBackend) HGetAll(context.Context, string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (m *memoryCacheBackend) DeleteMatching(_ context.Context, pattern string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.values {
+		if ok, _ := path.Match(pattern, key); ok {
+			delete(m.values, key)
+		}
+	}
+	return nil
+}
+
+func (m *memoryCacheBackend) Ping(context.Context) error {
+	if m.down {
+		return errCacheDown
+	}
+	return nil
+}
+
+func TestCacheServiceTreatsBackendErrorsAsMisses(t *testing.T) {
+	cache := services.NewCacheServiceWithBackend(failingCacheBackend{})
+
+	_, err := cache.GetStocks("KR")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+	_, err = cache.GetStockPrice("005930")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+	_, err = cache.GetIndicators("005930")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+
+	assert.NoError(t, cache.SetStocks("KR", []models.Stock{{Symbol: "005930"}}))
+	assert.NoError(t, cache.SetIndicators("005930", map[string]float64{"rsi": 50}))
+	assert.NoError(t, cache.InvalidateStock("005930"))
+	assert.False(t, cache.Available())
+	assert.Error(t, cache.Ping())
+}
+
+func TestCacheServiceRecovers(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	backend := newMemoryCacheBackend()
+	cache := services.NewCacheServiceWithBackend(backend).WithClock(func() time.Time { return now })
+
+	_, err := cache.GetStocks("KR")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+	assert.True(t, cache.Available(), "a plain miss is not an outage")
+
+	backend.down = true
+	require.NoError(t, cache.SetStocks("KR", []models.Stock{{Symbol: "005930"}}))
+	assert.False(t, cache.Available())
+
+	// 장애 뒤 재시도 시각 전에는 저장소를 부르지 않고 바로 원본으로 넘어간다
+	backend.down = false
+	calls := backend.calls
+	require.NoError(t, cache.SetStocks("KR", []models.Stock{{Symbol: "005930"}}))
+	_, err = cache.GetStocks("KR")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+	assert.Equal(t, calls, backend.calls)
+	assert.False(t, cache.Available())
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, cache.SetStocks("KR", []models.Stock{{Symbol: "005930"}}))
+	assert.Equal(t, calls+1, backend.calls)
+	assert.True(t, cache.Available())
+	stocks, err := cache.GetStocks("KR")
+	require.NoError(t, err)
+	assert.Equal(t, "005930", stocks[0].Symbol)
+
+	require.NoError(t, cache.InvalidateStocks())
+	_, err = cache.GetStocks("KR")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+}
+
+func TestCacheServiceProbesOnceAfterRetryInterval(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	backend := newMemoryCacheBackend()
+	backend.down = true
+	cache := services.NewCacheServiceWithBackend(backend).WithClock(func() time.Time { return now })
+
+	_, err := cache.GetStocks("KR")
+	assert.ErrorIs(t, err, services.ErrCacheMiss)
+	require.Equal(t, 1, backend.calls)
+
+	// 재시도 시각이 지나면 한 호출만 저장소를 확인하고, 여전히 실패하면 다시 기다린다
+	now = now.Add(31 * time.Second)
+	for i := 0; i < 5; i++ {
+		cache.GetStocks("KR")
+	}
+	assert.Equal(t, 2, backend.calls)
+
+	// Ping 은 재시도 시각과 관계없이 저장소를 불러 복구를 확인한다
+	backend.down = false
+	require.NoError(t, cache.Ping())
+	assert.True(t, cache.Available())
+	cache.GetStocks("KR")
+	assert.Equal(t, 3, backend.calls)
+}
+
+func (suite *IntegrationTestSuite) TestStocksEndpointFallsBackWhenCacheIsDown() {
+	suite.db.Create(&models.Stock{Symbol: "005930", Name: "삼성전자", Market: "KR", IsActive: true})
+	suite.db.Create(&models.Stock{Symbol: "AAPL", Name: "Apple", Market: "US", IsActive: true})
+
+	cache := services.NewCacheServiceWithBackend(failingCacheBackend{})
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/stocks", handlers.NewStockHandler(suite.db, suite.cfg).WithCache(cache).GetStocks)
+	r.GET("/health", handlers.NewHealthHandler(suite.db).WithCache(cache).HealthCheck)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/stocks?market=KR", nil))
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var body struct {
+			Stocks []models.Stock `json:"stocks"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+		suite.Require().Len(body.Stocks, 1)
+		assert.Equal(suite.T(), "005930", body.Stocks[0].Symbol)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var health handlers.HealthResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &health))
+	assert.Equal(suite.T(), "ok", health.Status)
+	assert.Equal(suite.T(), "connected", health.Database)
+	assert.Equal(suite.T(), "unavailable", health.Cache)
+}
+
+func (suite *IntegrationTestSuite) TestStocksEndpointServesFromCacheUntilInvalidated() {
+	suite.db.Create(&models.Stock{Symbol: "005930", Name: "삼성전자", Market: "KR", IsActive: true})
+
+	cache := services.NewCacheServiceWithBackend(newMemoryCacheBackend())
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/stocks", handlers.NewStockHandler(suite.db, suite.cfg).WithCache(cache).GetStocks)
+
+	get := func() []models.Stock {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/stocks?market=KR", nil))
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var body struct {
+			Stocks []models.Stock `json:"stocks"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+		return body.Stocks
+	}
+
+	suite.Require().Len(get(), 1)
+	suite.db.Create(&models.Stock{Symbol: "000660", Name: "SK하이닉스", Market: "KR", IsActive: true})
+	assert.Len(suite.T(), get(), 1, "served from cache")
+
+	cache.InvalidateStocks()
+	assert.Len(suite.T(), get(), 2)
+}
@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/handlers"
+	apimodels "stock-recommender/backend/openapi/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chartCall 차트 서비스가 받은 인자
+type chartCall struct {
+	symbol   string
+	market   string
+	count    int
+	adjusted bool
+}
+
+type fakeChartService struct {
+	calls []chartCall
+}
+
+func (f *fakeChartService) record(symbol, market string, count int, adjusted bool) {
+	f.calls = append(f.calls, chartCall{symbol: symbol, market: market, count: count, adjusted: adjusted})
+}
+
+func (f *fakeChartService) GetDayChartWithDaysContext(_ context.Context, stockCode, market string, days int, useAdjusted bool) ([]apimodels.ForeignDayChartData, error) {
+	f.record(stockCode, market, days, useAdjusted)
+	return []apimodels.ForeignDayChartData{{StockCode: stockCode, Date: "2024-06-28", Close: 210.6, IsAdjusted: useAdjusted}}, nil
+}
+
+func (f *fakeChartService) GetWeekChartWithWeeksContext(_ context.Context, stockCode, market string, weeks int, useAdjusted bool) ([]apimodels.ForeignWeekChartData, error) {
+	f.record(stockCode, market, weeks, useAdjusted)
+	return []apimodels.ForeignWeekChartData{{StockCode: stockCode, IsAdjusted: useAdjusted}}, nil
+}
+
+func (f *fakeChartService) GetMonthChartWithMonthsContext(_ context.Context, stockCode, market string, months int, useAdjusted bool) ([]apimodels.ForeignMonthChartData, error) {
+	f.record(stockCode, market, months, useAdjusted)
+	return []apimodels.ForeignMonthChartData{{StockCode: stockCode, IsAdjusted: useAdjusted}}, nil
+}
+
+func newChartRouter(service *fakeChartService) *gin.Engine {
+	return newChartRouterWithDefault(service, apimodels.MarketNASDAQ)
+}
+
+func newChartRouterWithDefault(service *fakeChartService, defaultMarket string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+
+	h := handlers.NewChartHandler(service, service, service).WithDefaultMarket(defaultMarket)
+	r.GET("/stocks/:symbol/chart/day", h.GetDayChart)
+	r.GET("/stocks/:symbol/chart/week", h.GetWeekChart)
+	r.GET("/stocks/:symbol/chart/month", h.GetMonthChart)
+	return r
+}
+
+func TestChartHandlerPassesAdjustedFlag(t *testing.T) {
+	tests := []struct {
+		path     string
+		adjusted bool
+		count    int
+	}{
+		{"/stocks/AAPL/chart/day?adjusted=false", false, 100},
+		{"/stocks/AAPL/chart/day?adjusted=true&limit=30", true, 30},
+		{"/stocks/AAPL/chart/week?adjusted=false", false, 52},
+		{"/stocks/AAPL/chart/month?adjusted=false&limit=12", false, 12},
+		{"/stocks/AAPL/chart/month", true, 24}, // 지정하지 않으면 수정주가
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			service := &fakeChartService{}
+			r := newChartRouter(service)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			require.Len(t, service.calls, 1)
+			assert.Equal(t, chartCall{symbol: "AAPL", market: apimodels.MarketNASDAQ, count: tt.count, adjusted: tt.adjusted}, service.calls[0])
+
+			var response struct {
+				Symbol     string `json:"symbol"`
+				IsAdjusted bool   `json:"is_adjusted"`
+				Data       []struct {
+					IsAdjusted bool `json:"is_adjusted"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "AAPL", response.Symbol)
+			assert.Equal(t, tt.adjusted, response.IsAdjusted)
+			require.Len(t, response.Data, 1)
+			assert.Equal(t, tt.adjusted, response.Data[0].IsAdjusted)
+		})
+	}
+}
+
+func TestChartHandlerUsesExchangeAndRejectsBadFlag(t *testing.T) {
+	service := &fakeChartService{}
+	r := newChartRouter(service)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/IBM/chart/day?exchange=NYSE", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, service.calls, 1)
+	assert.Equal(t, "NYSE", service.calls[0].market)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/IBM/chart/day?adjusted=yes-please", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, service.calls, 1) // 서비스는 호출되지 않는다
+}
+
+func TestChartHandlerRejectsTooLargeLimit(t *testing.T) {
+	service := &fakeChartService{}
+	r := newChartRouter(service)
+
+	for _, path := range []string{
+		"/stocks/AAPL/chart/day?limit=5001",
+		"/stocks/AAPL/chart/week?limit=1000000",
+		"/stocks/AAPL/chart/month?limit=999999999",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+	assert.Empty(t, service.calls)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?limit=5000", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestChartHandlerDefaultMarket(t *testing.T) {
+	// exchange 를 생략하면 배포 기본 시장
+	service := &fakeChartService{}
+	r := newChartRouterWithDefault(service, apimodels.MarketNYSE)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/IBM/chart/day", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, service.calls, 1)
+	assert.Equal(t, apimodels.MarketNYSE, service.calls[0].market)
+
+	// 명시한 exchange 가 기본값보다 우선 (별칭은 정규화)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?exchange=FN", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, service.calls, 2)
+	assert.Equal(t, apimodels.MarketNASDAQ, service.calls[1].market)
+
+	// 국내 시장은 해외 차트에 쓸 수 없다
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/005930/chart/day?exchange=KR", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Len(t, service.calls, 2)
+}
+
+func TestChartHandlerRequiresMarketWithoutDefault(t *testing.T) {
+	service := &fakeChartService{}
+	r := newChartRouterWithDefault(service, "")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "no default market is configured")
+	assert.Empty(t, service.calls)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?exchange=NASDAQ", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, service.calls, 1)
+	assert.Equal(t, apimodels.MarketNASDAQ, service.calls[0].market)
+}
+
+func TestChartHandlerProjectsFields(t *testing.T) {
+	r := newChartRouter(&fakeChartService{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?fields=date,close,volume", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Symbol string                   `json:"symbol"`
+		Data   []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "AAPL", response.Symbol) // 봉 목록 외의 응답 필드는 그대로
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, map[string]interface{}{"date": "2024-06-28", "close": 210.6, "volume": 0.0}, response.Data[0])
+}
+
+func TestChartHandlerRejectsUnknownField(t *testing.T) {
+	r := newChartRouter(&fakeChartService{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?fields=date,closing_price", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response handlers.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, handlers.ErrCodeBadRequest, response.Error.Code)
+	assert.Contains(t, response.Error.Message, "closing_price")
+}
+
+func TestProjectFieldsUsesJSONNames(t *testing.T) {
+	type base struct {
+		ID uint `json:"id"`
+	}
+	type row struct {
+		base
+		Name   string `json:"name"`
+		Secret string `json:"-"`
+		Note   string `json:"note,omitempty"`
+	}
+	rows := []row{{base: base{ID: 1}, Name: "a", Secret: "x"}, {base: base{ID: 2}, Name: "b", Note: "n"}}
+
+	projected, err := handlers.ProjectFields(rows, []string{"id", "note"})
+	require.NoError(t, err)
+	data, _ := json.Marshal(projected)
+	assert.JSONEq(t, `[{"id": 1}, {"id": 2, "note": "n"}]`, string(data))
+
+	_, err = handlers.ProjectFields(rows, []string{"Secret"})
+	assert.Error(t, err)
+
+	// fields 가 없으면 그대로
+	same, err := handlers.ProjectFields(rows, nil)
+	require.NoError(t, err)
+	assert.Equal(t, rows, same)
+}
@@ -0,0 +1,244 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	apierrors "stock-recommender/backend/openapi/errors"
+	"stock-recommender/backend/services"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionCycleSkipsSlowSymbol(t *testing.T) {
+	cycle := services.CollectionCycle{
+		Deadline:      time.Second,
+		SymbolTimeout: 50 * time.Millisecond,
+	}
+	stocks := []models.Stock{
+		{Symbol: "005930", Market: "KR"},
+		{Symbol: "HANG", Market: "US"},
+		{Symbol: "000660", Market: "KR"},
+		{Symbol: "BROKEN", Market: "US"},
+	}
+
+	var mu sync.Mutex
+	var collected []string
+	release := make(chan struct{})
+	defer close(release)
+
+	collect := func(_ context.Context, symbol, market string) error {
+		switch symbol {
+		case "HANG":
+			<-release // 응답이 오지 않는 종목
+		case "BROKEN":
+			return errors.New("upstream error")
+		}
+		mu.Lock()
+		collected = append(collected, symbol)
+		mu.Unlock()
+		return nil
+	}
+
+	start := time.Now()
+	report := cycle.Run(context.Background(), stocks, collect)
+	elapsed := time.Since(start)
+
+	assert.Less(t, elapsed, cycle.Deadline)
+	assert.Equal(t, 2, report.Success)
+	assert.ErrorIs(t, report.Failures["HANG"], services.ErrSymbolTimeout)
+	assert.EqualError(t, report.Failures["BROKEN"], "upstream error")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"005930", "000660"}, collected)
+}
+
+func TestCollectionCycleStopsAtDeadline(t *testing.T) {
+	cycle := services.CollectionCycle{
+		Deadline:      120 * time.Millisecond,
+		SymbolTimeout: time.Second,
+		Interval:      50 * time.Millisecond,
+	}
+	stocks := []models.Stock{{Symbol: "A"}, {Symbol: "B"}, {Symbol: "C"}, {Symbol: "D"}, {Symbol: "E"}}
+
+	start := time.Now()
+	report := cycle.Run(context.Background(), stocks, func(_ context.Context, symbol, market string) error { return nil })
+
+	// 주기 제한 시간이 지나면 남은 종목은 수집하지 않고 바로 끝난다
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+	assert.Less(t, report.Success, len(stocks))
+	assert.Equal(t, len(stocks), report.Success+report.Failed())
+	for _, err := range report.Failures {
+		assert.ErrorIs(t, err, services.ErrCycleDeadline)
+	}
+}
+
+func TestCollectionCycleStopsWhenPaused(t *testing.T) {
+	paused := false
+	cycle := services.CollectionCycle{
+		Deadline:      time.Second,
+		SymbolTimeout: time.Second,
+		Paused:        func() bool { return paused },
+	}
+	stocks := []models.Stock{{Symbol: "A"}, {Symbol: "B"}, {Symbol: "C"}}
+
+	// B 수집 중에 점검이 시작되면 C 는 수집하지 않는다
+	var collected []string
+	report := cycle.Run(context.Background(), stocks, func(_ context.Context, symbol, market string) error {
+		collected = append(collected, symbol)
+		if symbol == "B" {
+			paused = true
+			return errors.New("maintenance")
+		}
+		return nil
+	})
+
+	assert.Equal(t, []string{"A", "B"}, collected)
+	assert.True(t, report.Paused)
+	assert.Equal(t, 1, report.Success)
+	assert.ErrorIs(t, report.Failures["C"], services.ErrCollectionPaused)
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"paused":true`)
+}
+
+func TestCollectionCycleRetryBudgetCapsAttempts(t *testing.T) {
+	cycle := services.NewCollectionCycle(config.CollectorConfig{
+		CycleDeadline: time.Second,
+		SymbolTimeout: time.Second,
+		MaxRetries:    3,
+		RetryBudget:   5,
+	}, 0)
+
+	stocks := make([]models.Stock, 20)
+	for i := range stocks {
+		stocks[i] = models.Stock{Symbol: fmt.Sprintf("FAIL%02d", i), Market: "KR"}
+	}
+
+	// 부분 장애: 모든 종목이 일시적 오류로 실패
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	total := 0
+	report := cycle.Run(context.Background(), stocks, func(_ context.Context, symbol, market string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[symbol]++
+		total++
+		return fmt.Errorf("failed to collect data from API: %w", apierrors.NewNetworkError("API request failed", errors.New("status 502")))
+	})
+
+	// 첫 종목 1+3회, 둘째 종목 1+2회 시도 후 총량 5회를 다 써서 멈춘다
+	assert.Equal(t, 7, total, "initial attempts plus the retry budget")
+	assert.Equal(t, 5, report.Retries)
+	assert.Equal(t, 4, attempts["FAIL00"])
+	assert.Equal(t, 3, attempts["FAIL01"])
+	assert.Equal(t, len(stocks), report.Failed())
+	assert.True(t, apierrors.IsRetryableError(report.Failures["FAIL01"]))
+	for _, stock := range stocks[2:] {
+		assert.Zero(t, attempts[stock.Symbol], stock.Symbol)
+		assert.ErrorIs(t, report.Failures[stock.Symbol], services.ErrRetryBudgetExhausted)
+	}
+}
+
+func TestCollectionCycleRetriesOnlyTransientErrors(t *testing.T) {
+	cycle := services.NewCollectionCycle(config.CollectorConfig{
+		CycleDeadline: time.Second,
+		SymbolTimeout: time.Second,
+		MaxRetries:    2,
+		RetryBudget:   10,
+	}, 0)
+	stocks := []models.Stock{{Symbol: "FLAKY"}, {Symbol: "MISSING"}, {Symbol: "OK"}}
+
+	attempts := map[string]int{}
+	report := cycle.Run(context.Background(), stocks, func(_ context.Context, symbol, market string) error {
+		attempts[symbol]++
+		switch {
+		case symbol == "FLAKY" && attempts[symbol] == 1:
+			return apierrors.NewNetworkError("API request failed", errors.New("status 503"))
+		case symbol == "MISSING":
+			return apierrors.NewNoDataError("stock not found")
+		}
+		return nil
+	})
+
+	// 일시적 오류는 재시도해서 성공하고, 그 밖의 오류는 재시도하지 않는다
+	assert.Equal(t, map[string]int{"FLAKY": 2, "MISSING": 1, "OK": 1}, attempts)
+	assert.Equal(t, 2, report.Success)
+	assert.Equal(t, 1, report.Retries)
+	assert.Contains(t, report.Failures, "MISSING")
+}
+
+func TestNewCollectionCycleDefaults(t *testing.T) {
+	cycle := services.NewCollectionCycle(config.CollectorConfig{}, time.Second)
+
+	assert.Equal(t, config.DefaultCycleDeadline, cycle.Deadline)
+	assert.Equal(t, config.DefaultSymbolTimeout, cycle.SymbolTimeout)
+	assert.Equal(t, time.Second, cycle.Interval)
+}
+
+func TestCollectionReportRecordsFailures(t *testing.T) {
+	cycle := services.CollectionCycle{Deadline: time.Second, SymbolTimeout: time.Second}
+	stocks := []models.Stock{
+		{Symbol: "005930", Market: "KR"},
+		{Symbol: "AAPL", Market: "US"},
+		{Symbol: "DELISTED", Market: "US"},
+		{Symbol: "000660", Market: "KR"},
+		{Symbol: "BADMKT", Market: "JP"},
+	}
+
+	errDelisted := errors.New("stock not found")
+	report := cycle.Run(context.Background(), stocks, func(_ context.Context, symbol, market string) error {
+		switch {
+		case symbol == "DELISTED":
+			return fmt.Errorf("failed to collect data from API: %w", errDelisted)
+		case market == "JP":
+			return fmt.Errorf("unsupported market: %s", market)
+		}
+		return nil
+	})
+
+	assert.Equal(t, 3, report.Success)
+	assert.Equal(t, 2, report.Failed())
+	assert.ErrorIs(t, report.Failures["DELISTED"], errDelisted)
+	assert.EqualError(t, report.Failures["BADMKT"], "unsupported market: JP")
+	assert.NotContains(t, report.Failures, "AAPL")
+
+	body, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"success": 3,
+		"failed": 2,
+		"failures": {
+			"DELISTED": "failed to collect data from API: stock not found",
+			"BADMKT": "unsupported market: JP"
+		}
+	}`, string(body))
+}
+
+func TestCollectionCycleCancelsTimedOutCollect(t *testing.T) {
+	cycle := services.CollectionCycle{Deadline: time.Second, SymbolTimeout: 30 * time.Millisecond}
+
+	cancelled := make(chan error, 1)
+	report := cycle.Run(context.Background(), []models.Stock{{Symbol: "HANG"}}, func(ctx context.Context, symbol, market string) error {
+		<-ctx.Done() // API 호출이 ctx 를 따라 중단된다
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, report.Failures["HANG"], services.ErrSymbolTimeout)
+	select {
+	case err := <-cancelled:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("collect was not cancelled after the symbol timeout")
+	}
+}
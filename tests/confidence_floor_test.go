@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestConfidenceFloorSuppressesWeakSignals() {
+	suite.Require().NoError(suite.db.Create(&models.Stock{Symbol: "WEAK01", Name: "Weak Signal", Market: "KR", IsActive: true}).Error)
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.Require().NoError(suite.db.Create(&models.StockPrice{
+			Symbol: "WEAK01", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		}).Error)
+	}
+
+	countSignals := func() int64 {
+		var count int64
+		suite.Require().NoError(suite.db.Model(&models.TradingSignal{}).Where("symbol = ?", "WEAK01").Count(&count).Error)
+		return count
+	}
+
+	// AI 없이 규칙 기반 신호 (신뢰도 0.6 이하)
+	weak := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil).
+		WithConfidenceFloor(0.7, true)
+
+	signal, err := weak.GenerateSignal("WEAK01", "KR")
+	suite.Require().NoError(err)
+	suite.Require().NotNil(signal, "weak signals are still computed")
+	assert.Less(suite.T(), signal.Confidence, 0.7)
+	assert.True(suite.T(), weak.BelowConfidenceFloor(signal))
+	assert.Zero(suite.T(), signal.ID)
+
+	suite.Require().NoError(weak.GenerateSignalsForAllStocks())
+	assert.Zero(suite.T(), countSignals(), "signals below the floor must not be stored")
+
+	// 기본값 0 이면 모든 신호를 저장한다
+	all := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil)
+	suite.Require().NoError(all.GenerateSignalsForAllStocks())
+	assert.Equal(suite.T(), int64(1), countSignals())
+
+	// 하한과 같은 신뢰도는 저장한다
+	signal, err = services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil).
+		WithConfidenceFloor(signal.Confidence, false).
+		GenerateSignal("WEAK01", "KR")
+	suite.Require().NoError(err)
+	assert.NotZero(suite.T(), signal.ID)
+	assert.Equal(suite.T(), int64(2), countSignals())
+}
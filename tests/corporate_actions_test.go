@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"fmt"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// splitSeries 2024-06-03 부터 10 거래일, splitDay 에 2:1 분할이 있는 원주가/수정주가 일차트
+// 최신 데이터가 먼저 오도록 API 응답과 같은 내림차순으로 만든다.
+func splitSeries(splitDay int) (adjusted, unadjusted []apimodels.ForeignDayChartData) {
+	for day := 12; day >= 3; day-- {
+		date := fmt.Sprintf("2024-06-%02d", day)
+		close := 100.0 + float64(day)/10 // 분할 후 가격
+		raw := close
+		if day < splitDay {
+			raw = close * 2 // 분할 전 원주가는 두 배
+		}
+		adjusted = append(adjusted, apimodels.ForeignDayChartData{Date: date, Close: close, IsAdjusted: true})
+		unadjusted = append(unadjusted, apimodels.ForeignDayChartData{Date: date, Close: raw})
+	}
+	return adjusted, unadjusted
+}
+
+func TestDetectCorporateActionsFindsSplitDate(t *testing.T) {
+	adjusted, unadjusted := splitSeries(7)
+
+	actions := services.DetectCorporateActions(adjusted, unadjusted)
+
+	require.Len(t, actions, 1)
+	assert.Equal(t, "2024-06-07", actions[0].Date)
+	assert.InDelta(t, 0.5, actions[0].Factor, 1e-9)
+	assert.Equal(t, services.CorporateActionSplit, actions[0].Type)
+}
+
+func TestDetectCorporateActionsDividendAndNoise(t *testing.T) {
+	var adjusted, unadjusted []apimodels.ForeignDayChartData
+	for day := 3; day <= 7; day++ {
+		date := fmt.Sprintf("2024-06-%02d", day)
+		raw := 50.0 + float64(day)
+		adj := raw
+		if day < 5 {
+			adj = raw * 0.98 // 06-05 배당락 (2%)
+		}
+		adjusted = append(adjusted, apimodels.ForeignDayChartData{Date: date, Close: adj + 0.0001}) // 반올림 오차
+		unadjusted = append(unadjusted, apimodels.ForeignDayChartData{Date: date, Close: raw})
+	}
+
+	actions := services.DetectCorporateActions(adjusted, unadjusted)
+
+	require.Len(t, actions, 1)
+	assert.Equal(t, "2024-06-05", actions[0].Date)
+	assert.InDelta(t, 0.98, actions[0].Factor, 0.001)
+	assert.Equal(t, services.CorporateActionDividend, actions[0].Type)
+}
+
+func TestDetectCorporateActionsIdenticalSeries(t *testing.T) {
+	adjusted, _ := splitSeries(0)
+
+	assert.Empty(t, services.DetectCorporateActions(adjusted, adjusted))
+	assert.Empty(t, services.DetectCorporateActions(nil, nil))
+}
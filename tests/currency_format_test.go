@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyFormatForKRSymbol(t *testing.T) {
+	region := apimodels.SymbolRegion("005930")
+	assert.Equal(t, apimodels.RegionKR, region)
+
+	won := apimodels.CurrencyForRegion(region)
+	assert.Equal(t, "KRW", won.Code)
+	// 원화는 소수점 없이 천 단위 구분
+	assert.Equal(t, "₩71,500", won.Format(71500, -1))
+	assert.Equal(t, "₩1,234,568", won.Format(1234567.6, -1))
+	assert.Equal(t, "₩950", won.Format(950, -1))
+}
+
+func TestCurrencyFormatForForeignSymbol(t *testing.T) {
+	assert.Equal(t, apimodels.RegionUS, apimodels.SymbolRegion("AAPL"))
+	assert.Equal(t, apimodels.RegionUS, apimodels.SymbolRegion("123"))
+
+	dollar := apimodels.CurrencyForRegion(apimodels.SymbolRegion("AAPL"))
+	assert.Equal(t, "$1,234.50", dollar.Format(1234.5, -1))
+	// 종목 소수점자리수(zdiv)가 있으면 그대로 따른다
+	assert.Equal(t, "$0.1234", dollar.Format(0.12344, 4))
+	assert.Equal(t, "-$12.30", dollar.Format(-12.3, 2))
+
+	// 알 수 없는 지역은 달러
+	assert.Equal(t, "USD", apimodels.CurrencyForRegion("JP").Code)
+}
@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePriceSource prices 에 있는 종목만 응답하고 호출된 출처 이름을 calls 에 남긴다
+type fakePriceSource struct {
+	name   string
+	prices map[string]float64
+	calls  *[]string
+	stored []string // StorePrice 로 캐시에 저장된 종목
+}
+
+func (f *fakePriceSource) LatestPrice(_ context.Context, symbol string) (models.StockPrice, error) {
+	*f.calls = append(*f.calls, f.name)
+	closePrice, ok := f.prices[symbol]
+	if !ok {
+		return models.StockPrice{}, services.ErrSourceMiss
+	}
+	return models.StockPrice{Symbol: symbol, ClosePrice: closePrice, Timestamp: time.Now()}, nil
+}
+
+func (f *fakePriceSource) StorePrice(price models.StockPrice) {
+	f.stored = append(f.stored, price.Symbol)
+}
+
+type priceSourceResponse struct {
+	Price  models.StockPrice `json:"price"`
+	Source string            `json:"source"`
+}
+
+func TestPriceEndpointRoutesBySource(t *testing.T) {
+	var calls []string
+	cache := &fakePriceSource{name: "cache", prices: map[string]float64{"CACHED": 1}, calls: &calls}
+	db := &fakePriceSource{name: "db", prices: map[string]float64{"CACHED": 2, "STORED": 2}, calls: &calls}
+	live := &fakePriceSource{name: "live", prices: map[string]float64{"CACHED": 3, "STORED": 3, "LIVE": 3}, calls: &calls}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	h := handlers.NewStockHandler(nil, nil).WithPriceSources(cache, db, live)
+	r.GET("/stocks/:symbol/price", h.GetStockPrice)
+
+	get := func(path string) (*httptest.ResponseRecorder, priceSourceResponse) {
+		calls = nil
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var response priceSourceResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	// 출처를 지정하면 그곳만 본다
+	for source, closePrice := range map[string]float64{"live": 3, "db": 2, "cache": 1} {
+		w, response := get("/stocks/CACHED/price?source=" + source)
+		require.Equal(t, http.StatusOK, w.Code, source)
+		assert.Equal(t, []string{source}, calls)
+		assert.Equal(t, source, response.Source)
+		assert.Equal(t, closePrice, response.Price.ClosePrice)
+	}
+	assert.Empty(t, cache.stored, "explicit sources do not fill the cache")
+
+	// 캐시에만 물었는데 없으면 본문 없이 304
+	w, _ := get("/stocks/STORED/price?source=cache")
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, []string{"cache"}, calls)
+
+	// auto (기본값): 캐시 → DB → API 순서로 찾고 DB/API 에서 읽은 가격은 캐시에 저장
+	_, response := get("/stocks/CACHED/price")
+	assert.Equal(t, []string{"cache"}, calls)
+	assert.Equal(t, "cache", response.Source)
+
+	_, response = get("/stocks/STORED/price?source=auto")
+	assert.Equal(t, []string{"cache", "db"}, calls)
+	assert.Equal(t, "db", response.Source)
+
+	_, response = get("/stocks/LIVE/price")
+	assert.Equal(t, []string{"cache", "db", "live"}, calls)
+	assert.Equal(t, "live", response.Source)
+	assert.Equal(t, []string{"STORED", "LIVE"}, cache.stored)
+
+	w, _ = get("/stocks/NOWHERE/price")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, []string{"cache", "db", "live"}, calls)
+
+	// 잘못된 값은 400, 연결되지 않은 출처는 503 (auto 는 건너뛴다)
+	w, _ = get("/stocks/CACHED/price?source=redis")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, calls)
+
+	h.WithPriceSources(cache, db, nil)
+	w, _ = get("/stocks/LIVE/price?source=live")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	w, _ = get("/stocks/LIVE/price")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, []string{"cache", "db"}, calls)
+}
+
+// fakeChartCache JSON 으로 저장하는 메모리 차트 캐시
+type fakeChartCache struct {
+	entries map[string][]byte
+}
+
+func (f *fakeChartCache) GetChart(key string, out interface{}) error {
+	data, ok := f.entries[key]
+	if !ok {
+		return services.ErrCacheMiss
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (f *fakeChartCache) SetChart(key string, data interface{}) error {
+	encoded, err := json.Marshal(data)
+	f.entries[key] = encoded
+	return err
+}
+
+// fakeDayChartStore bars 개의 저장된 일봉을 가진 DB (마지막 봉은 latest, 비어 있으면 마지막 거래일)
+type fakeDayChartStore struct {
+	bars   int
+	latest string
+	calls  int
+}
+
+func (f *fakeDayChartStore) StoredDayChart(_ context.Context, symbol, market string, days int) ([]apimodels.ForeignDayChartData, error) {
+	f.calls++
+	if f.bars == 0 {
+		return nil, services.ErrSourceMiss
+	}
+	latest := f.latest
+	if latest == "" {
+		latest = apimodels.DefaultMarketCalendar.LastClosedTradingDay(time.Now(), market).Format("2006-01-02")
+	}
+	data := make([]apimodels.ForeignDayChartData, min(days, f.bars))
+	for i := range data {
+		data[i] = apimodels.ForeignDayChartData{StockCode: symbol, Market: market, Date: latest, Close: 100}
+	}
+	return data, nil
+}
+
+type chartSourceResponse struct {
+	Source     string            `json:"source"`
+	IsAdjusted bool              `json:"is_adjusted"`
+	Data       []json.RawMessage `json:"data"`
+}
+
+func TestChartEndpointsRouteBySource(t *testing.T) {
+	live := &fakeChartService{}
+	cache := &fakeChartCache{entries: map[string][]byte{}}
+	store := &fakeDayChartStore{bars: 5}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	h := handlers.NewChartHandler(live, live, live).WithDefaultMarket(apimodels.MarketNASDAQ).
+		WithChartCache(cache).
+		WithChartStore(store)
+	r.GET("/stocks/:symbol/chart/day", h.GetDayChart)
+	r.GET("/stocks/:symbol/chart/week", h.GetWeekChart)
+
+	get := func(path string) (*httptest.ResponseRecorder, chartSourceResponse) {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var response chartSourceResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w, response
+	}
+
+	// 기본은 live, 캐시에만 물으면 아직 없으므로 304
+	w, _ := get("/stocks/AAPL/chart/day?limit=5&source=cache")
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, live.calls)
+
+	_, response := get("/stocks/AAPL/chart/day?limit=5")
+	assert.Equal(t, "live", response.Source)
+	assert.True(t, response.IsAdjusted)
+	require.Len(t, live.calls, 1)
+
+	// live 결과는 캐시에 남아 cache/auto 가 API 를 부르지 않는다
+	_, response = get("/stocks/AAPL/chart/day?limit=5&source=cache")
+	assert.Equal(t, "cache", response.Source)
+	_, response = get("/stocks/AAPL/chart/day?limit=5&source=auto")
+	assert.Equal(t, "cache", response.Source)
+	assert.Len(t, live.calls, 1)
+	assert.Zero(t, store.calls)
+
+	// db 는 저장된 일봉(수정주가 아님)만 읽는다
+	_, response = get("/stocks/MSFT/chart/day?limit=10&source=db")
+	assert.Equal(t, "db", response.Source)
+	assert.False(t, response.IsAdjusted)
+	assert.Len(t, response.Data, 5)
+	assert.Equal(t, 1, store.calls)
+	assert.Len(t, live.calls, 1)
+
+	// auto: 원주가 요청이고 요청한 개수만큼 저장되어 있으면 DB, 모자라면 API
+	_, response = get("/stocks/MSFT/chart/day?limit=5&adjusted=false&source=auto")
+	assert.Equal(t, "db", response.Source)
+	assert.Equal(t, 2, store.calls)
+	_, response = get("/stocks/MSFT/chart/day?limit=10&adjusted=false&source=auto")
+	assert.Equal(t, "live", response.Source)
+	assert.Equal(t, 3, store.calls)
+	require.Len(t, live.calls, 2)
+	// 수정주가 요청은 DB 를 건너뛴다
+	_, response = get("/stocks/TSLA/chart/day?limit=5&source=auto")
+	assert.Equal(t, "live", response.Source)
+	assert.Equal(t, 3, store.calls)
+	// 마지막 거래일 일봉이 아직 저장되지 않았으면 API
+	store.latest = "2024-01-02"
+	_, response = get("/stocks/NVDA/chart/day?limit=5&adjusted=false&source=auto")
+	assert.Equal(t, "live", response.Source)
+	assert.Equal(t, 4, store.calls)
+	require.Len(t, live.calls, 4)
+
+	// 주/월차트는 DB 에서 읽지 않으므로 db 는 400, 잘못된 값도 400
+	w, _ = get("/stocks/AAPL/chart/week?source=db")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, _ = get("/stocks/AAPL/chart/day?source=disk")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	_, response = get("/stocks/AAPL/chart/week?source=auto")
+	assert.Equal(t, "live", response.Source)
+}
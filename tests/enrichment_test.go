@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"testing"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *IntegrationTestSuite) TestEnrichmentFillsSectorAndMarketCap() {
+	mockData := apimodels.CurrentPriceOutput{
+		Prpr:        "55550",
+		BstpKorIsnm: "전기전자",
+		HtsAvls:     "3316245",
+	}
+	handler := utils.CreateCurrentPriceMockHandler(suite.T(), apimodels.PathDomesticStockCurrentPrice, "005930", mockData)
+	mockServer := utils.NewMockServer(suite.T(), handler)
+	defer mockServer.Close()
+
+	suite.db.Create(&models.Stock{Symbol: "005930", Name: "삼성전자", Market: "KR", IsActive: true})
+
+	apiClient := client.NewDBSecClient(utils.CreateMockServerConfig(mockServer))
+	enriched, err := services.NewEnrichmentService(suite.db, apiClient).EnrichStocks()
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, enriched)
+
+	var stock models.Stock
+	suite.Require().NoError(suite.db.Where("symbol = ?", "005930").First(&stock).Error)
+	assert.Equal(suite.T(), "전기전자", stock.Sector)
+	assert.Equal(suite.T(), int64(3316245), stock.MarketCap)
+}
+
+// recordingMetadataFetcher 조회한 시장을 기록하고 빈 메타데이터를 돌려준다
+type recordingMetadataFetcher struct {
+	markets []string
+}
+
+func (f *recordingMetadataFetcher) GetStockMetadata(symbol, market string) (*apimodels.ParsedStockMetadata, error) {
+	f.markets = append(f.markets, market)
+	return &apimodels.ParsedStockMetadata{Symbol: symbol}, nil
+}
+
+func TestEnrichmentResolvesUSExchange(t *testing.T) {
+	fetcher := &recordingMetadataFetcher{}
+	exchanges := foreign.NewExchangeCache()
+	exchanges.Set("KO", apimodels.MarketNYSE)
+	service := services.NewEnrichmentService(nil, fetcher).WithExchangeCache(exchanges)
+
+	// 미국 종목은 등록된 거래소, 없으면 거래소 캐시에서 찾은 거래소로 조회하고, 모르면 US 그대로 넘긴다
+	for _, stock := range []models.Stock{
+		{Symbol: "IBM", Market: "US", Exchange: "NYSE"},
+		{Symbol: "KO", Market: "US"},
+		{Symbol: "NEWCO", Market: "US"},
+		{Symbol: "005930", Market: "KR", Exchange: "KOSPI"},
+	} {
+		require.NoError(t, service.EnrichStock(&stock))
+	}
+	assert.Equal(t, []string{apimodels.MarketNYSE, apimodels.ForeignMarketNY, "US", "KR"}, fetcher.markets)
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/handlers"
+	apierrors "stock-recommender/backend/openapi/errors"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestStatusForError(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"validation", apierrors.NewValidationError("bad symbol", nil), http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"upstream auth", apierrors.NewAuthError("token rejected", nil), http.StatusBadGateway, "AUTH_FAILED"},
+		{"quota", apierrors.NewRateLimitError("daily quota exceeded"), http.StatusTooManyRequests, "RATE_LIMIT"},
+		{"network", apierrors.NewNetworkError("upstream down", nil), http.StatusBadGateway, "NETWORK_ERROR"},
+		{"wrapped network", fmt.Errorf("collect: %w", apierrors.NewNetworkError("upstream down", nil)), http.StatusBadGateway, "NETWORK_ERROR"},
+		{"record not found", fmt.Errorf("lookup: %w", gorm.ErrRecordNotFound), http.StatusNotFound, "NOT_FOUND"},
+		{"api not found", apierrors.NewAPIError(apierrors.ErrCodeNotFound, "no such symbol", nil), http.StatusNotFound, "NOT_FOUND"},
+		{"no data", apierrors.NewNoDataError("no day chart data for AAPL"), http.StatusNotFound, "NO_DATA"},
+		{"maintenance", fmt.Errorf("collect: %w", apierrors.NewMaintenanceError("DBSec API is under maintenance", nil)), http.StatusServiceUnavailable, "MAINTENANCE"},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError, handlers.ErrCodeInternal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, code := handlers.StatusForError(tc.err)
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, tc.code, code)
+		})
+	}
+}
+
+func TestErrorResponseEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	stockHandler := handlers.NewStockHandler(nil, &config.Config{})
+	r.GET("/screener", stockHandler.Screen)
+	r.NoRoute(handlers.NotFound)
+
+	cases := []struct {
+		path   string
+		status int
+		code   string
+	}{
+		{"/screener?per_lt=abc", http.StatusBadRequest, handlers.ErrCodeBadRequest},
+		{"/missing", http.StatusNotFound, handlers.ErrCodeNotFound},
+	}
+
+	for _, tc := range cases {
+		req, _ := http.NewRequest("GET", tc.path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.path)
+
+		var response handlers.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, tc.code, response.Error.Code, tc.path)
+		assert.NotEmpty(t, response.Error.Message, tc.path)
+	}
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagPrecedence(t *testing.T) {
+	var unset *services.FeatureFlags
+	assert.True(t, unset.Enabled(services.FlagStochRSI), "nil store uses defaults")
+	assert.False(t, unset.Enabled("ichimoku"), "unknown flags are off")
+
+	flags := services.NewFeatureFlags(nil, map[string]bool{services.FlagBacktestAPI: false, "typo": true})
+	assert.Equal(t, services.FeatureFlagState{
+		Name: services.FlagBacktestAPI, Enabled: false, Default: true, Source: services.FlagSourceConfig,
+	}, flags.State(services.FlagBacktestAPI))
+	assert.Equal(t, services.FlagSourceDefault, flags.State(services.FlagStochRSI).Source)
+	assert.Len(t, flags.List(), 3)
+
+	assert.ErrorIs(t, flags.Set("typo", true), services.ErrUnknownFeatureFlag)
+	assert.Error(t, flags.Set(services.FlagStochRSI, false), "config-only store cannot persist overrides")
+}
+
+func TestFeatureFlagGatesStochRSI(t *testing.T) {
+	bars := syntheticBars(50)
+
+	enabled := services.NewIndicatorService().CalculateAll(append(bars[:0:0], bars...))
+	require.NotNil(t, enabled)
+	assert.NotZero(t, enabled.StochRSIK)
+
+	cache := services.NewIndicatorCache(8)
+	off := services.NewFeatureFlags(nil, map[string]bool{services.FlagStochRSI: false})
+	disabled := services.NewIndicatorService().WithCache(cache).WithFeatures(off).CalculateAll(append(bars[:0:0], bars...))
+	require.NotNil(t, disabled)
+	assert.Zero(t, disabled.StochRSIK)
+	assert.Zero(t, disabled.StochRSID)
+	assert.Equal(t, enabled.RSI, disabled.RSI)
+
+	// 플래그 상태가 다르면 캐시 항목도 다르다
+	again := services.NewIndicatorService().WithCache(cache).CalculateAll(append(bars[:0:0], bars...))
+	assert.Equal(t, enabled.StochRSIK, again.StochRSIK)
+	assert.Equal(t, int64(2), cache.Stats().Misses)
+}
+
+func TestRequireFeatureMiddleware(t *testing.T) {
+	newRouter := func(flags *services.FeatureFlags) *gin.Engine {
+		r := gin.New()
+		r.GET("/experimental", handlers.RequireFeature(flags, services.FlagBacktestAPI), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return r
+	}
+
+	w := httptest.NewRecorder()
+	newRouter(services.NewFeatureFlags(nil, nil)).ServeHTTP(w, httptest.NewRequest("GET", "/experimental", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	off := services.NewFeatureFlags(nil, map[string]bool{services.FlagBacktestAPI: false})
+	newRouter(off).ServeHTTP(w, httptest.NewRequest("GET", "/experimental", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func (suite *IntegrationTestSuite) TestFeatureFlagTogglesOrderBookSignal() {
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.db.Create(&models.StockPrice{
+			Symbol: "FLAGKR", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+	suite.db.Create(&models.AskingPrice{
+		Symbol:    "FLAGKR",
+		AskPrice1: 161, BidPrice1: 159,
+		AskVolume1: 100, BidVolume1: 900,
+		TotalAskVol: 100, TotalBidVol: 900,
+		Timestamp: time.Now(),
+	})
+
+	features := services.NewFeatureFlags(suite.db, nil)
+	cfg := &config.Config{AI: config.AIConfig{RuleOnly: true}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), nil, nil).
+		WithFeatures(features)
+
+	snapshot := func() map[string]float64 {
+		signal, err := generator.GenerateSignal("FLAGKR", "KR")
+		suite.Require().NoError(err)
+		var indicators map[string]float64
+		suite.Require().NoError(json.Unmarshal([]byte(signal.IndicatorSnapshot), &indicators))
+		return indicators
+	}
+
+	assert.Contains(suite.T(), snapshot(), "orderbook_imbalance")
+
+	suite.Require().NoError(features.Set(services.FlagOrderBookSignal, false))
+	assert.NotContains(suite.T(), snapshot(), "orderbook_imbalance")
+
+	// 다른 프로세스의 저장소도 DB 값을 읽는다
+	assert.False(suite.T(), services.NewFeatureFlags(suite.db, nil).Enabled(services.FlagOrderBookSignal))
+
+	suite.Require().NoError(features.Clear(services.FlagOrderBookSignal))
+	assert.Contains(suite.T(), snapshot(), "orderbook_imbalance")
+}
+
+func (suite *IntegrationTestSuite) TestAdminFeatureEndpointDisablesRoute() {
+	features := services.NewFeatureFlags(suite.db, nil)
+	r := gin.New()
+	admin := handlers.NewAdminHandler(suite.db, suite.cfg, services.NewDataCollectorService(suite.db, suite.cfg)).WithFeatures(features)
+	r.GET("/admin/features", admin.GetFeatures)
+	r.PUT("/admin/features/:name", admin.UpdateFeature)
+	r.GET("/backtest", handlers.RequireFeature(features, services.FlagBacktestAPI), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/backtest", nil))
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/admin/features/"+services.FlagBacktestAPI,
+		bytes.NewBufferString(`{"enabled": false}`)))
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var state services.FeatureFlagState
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &state))
+	assert.Equal(suite.T(), services.FlagSourceDB, state.Source)
+	assert.False(suite.T(), state.Enabled)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/backtest", nil))
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/admin/features/ichimoku", bytes.NewBufferString(`{"enabled": true}`)))
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
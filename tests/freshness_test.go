@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFreshness(t *testing.T) {
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+
+	fresh := handlers.NewFreshness(now.Add(-10*time.Second), now, 15*time.Minute)
+	assert.Equal(t, int64(10), fresh.AgeSeconds)
+	assert.False(t, fresh.Stale)
+	assert.Equal(t, now.Add(-10*time.Second), fresh.FetchedAt)
+
+	old := handlers.NewFreshness(now.Add(-6*time.Hour), now, 15*time.Minute)
+	assert.Equal(t, int64(6*60*60), old.AgeSeconds)
+	assert.True(t, old.Stale)
+
+	// 기준이 없으면 기본값(15분) 사용
+	assert.True(t, handlers.NewFreshness(now.Add(-16*time.Minute), now, 0).Stale)
+	assert.False(t, handlers.NewFreshness(now.Add(-14*time.Minute), now, 0).Stale)
+
+	// 미래 시각은 0초로 처리
+	assert.Zero(t, handlers.NewFreshness(now.Add(time.Minute), now, 0).AgeSeconds)
+}
+
+func (suite *IntegrationTestSuite) TestPriceResponseFreshness() {
+	suite.db.Exec("TRUNCATE TABLE stock_prices RESTART IDENTITY CASCADE")
+	suite.db.Create(&models.StockPrice{Symbol: "FRESH", Market: "KR", ClosePrice: 100, Timestamp: time.Now().Add(-30 * time.Second)})
+	suite.db.Create(&models.StockPrice{Symbol: "STALE", Market: "KR", ClosePrice: 100, Timestamp: time.Now().Add(-6 * time.Hour)})
+
+	freshness := func(symbol string) handlers.Freshness {
+		req, _ := http.NewRequest("GET", "/api/v1/stocks/"+symbol+"/price", nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var response struct {
+			Freshness handlers.Freshness `json:"freshness"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Freshness
+	}
+
+	fresh := freshness("FRESH")
+	assert.InDelta(suite.T(), 30, fresh.AgeSeconds, 5)
+	assert.False(suite.T(), fresh.Stale)
+
+	stale := freshness("STALE")
+	assert.InDelta(suite.T(), 6*60*60, stale.AgeSeconds, 5)
+	assert.True(suite.T(), stale.Stale)
+}
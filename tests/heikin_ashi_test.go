@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	apimodels "stock-recommender/backend/openapi/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHeikinAshiRecurrence(t *testing.T) {
+	bars := []apimodels.Bar{
+		{Open: 10, High: 14, Low: 8, Close: 12},
+		{Open: 12, High: 16, Low: 11, Close: 15},
+		{Open: 15, High: 15.5, Low: 9, Close: 10},
+	}
+
+	ha := apimodels.ToHeikinAshi(bars)
+	require.Len(t, ha, 3)
+
+	// 첫 봉은 원래 봉으로 시가를 정한다
+	assert.Equal(t, apimodels.Bar{Open: 11, High: 14, Low: 8, Close: 11}, ha[0])
+
+	// 이후 봉: 시가 = 직전 HA (시가+종가)/2, 종가 = OHLC 평균, 고가/저가는 HA 시가/종가를 포함
+	assert.Equal(t, apimodels.Bar{Open: 11, High: 16, Low: 11, Close: 13.5}, ha[1])
+	assert.Equal(t, apimodels.Bar{Open: 12.25, High: 15.5, Low: 9, Close: 12.375}, ha[2])
+
+	for i := 1; i < len(ha); i++ {
+		assert.Equal(t, (ha[i-1].Open+ha[i-1].Close)/2, ha[i].Open)
+		raw := bars[i]
+		assert.Equal(t, (raw.Open+raw.High+raw.Low+raw.Close)/4, ha[i].Close)
+	}
+
+	assert.Empty(t, apimodels.ToHeikinAshi(nil))
+}
+
+func TestHeikinAshiDayChartKeepsNewestFirstOrder(t *testing.T) {
+	// 차트 API 응답처럼 최신순: 오래된 봉(06-03)부터 계산해야 한다
+	data := []apimodels.ForeignDayChartData{
+		{Date: "2024-06-05", Open: 15, High: 15.5, Low: 9, Close: 10, Volume: 300},
+		{Date: "2024-06-04", Open: 12, High: 16, Low: 11, Close: 15, Volume: 200},
+		{Date: "2024-06-03", Open: 10, High: 14, Low: 8, Close: 12, Volume: 100},
+	}
+
+	ha := apimodels.HeikinAshiDayChart(data)
+	require.Len(t, ha, 3)
+	assert.Equal(t, "2024-06-05", ha[0].Date)
+	assert.Equal(t, 11.0, ha[2].Open)
+	assert.Equal(t, 11.0, ha[1].Open)
+	assert.Equal(t, 12.25, ha[0].Open)
+	assert.Equal(t, 12.375, ha[0].Close)
+	assert.Equal(t, int64(300), ha[0].Volume)
+
+	// 원본은 바뀌지 않는다
+	assert.Equal(t, 15.0, data[0].Open)
+}
+
+func TestChartHandlerHeikinAshiCandles(t *testing.T) {
+	r := newChartRouter(&fakeChartService{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/day?candles=heikin", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Candles string                          `json:"candles"`
+		Data    []apimodels.ForeignDayChartData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "heikin", response.Candles)
+	require.Len(t, response.Data, 1)
+	assert.InDelta(t, 210.6/4, response.Data[0].Close, 1e-9)
+	assert.InDelta(t, 210.6/2, response.Data[0].Open, 1e-9)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/week?candles=renko", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorCacheServesIdenticalBars(t *testing.T) {
+	cache := services.NewIndicatorCache(8)
+	service := services.NewIndicatorService().WithCache(cache)
+	bars := syntheticBars(51)
+
+	first := service.CalculateAll(append(bars[:0:0], bars[:50]...))
+	require.NotNil(t, first)
+	assert.Equal(t, services.IndicatorCacheStats{Size: 1, Hits: 0, Misses: 1}, cache.Stats())
+
+	// 같은 봉(역순으로 넘겨도)이면 다시 계산하지 않는다
+	window := append(bars[:0:0], bars[:50]...)
+	for i, j := 0, len(window)-1; i < j; i, j = i+1, j-1 {
+		window[i], window[j] = window[j], window[i]
+	}
+	second := service.CalculateAll(window)
+	require.NotNil(t, second)
+	assert.Equal(t, *first, *second)
+	assert.Equal(t, int64(1), cache.Stats().Hits)
+
+	// 호출자가 결과를 고쳐도 캐시에는 영향 없음
+	second.OBV = -1
+	third := service.CalculateAll(append(bars[:0:0], bars[:50]...))
+	assert.Equal(t, first.OBV, third.OBV)
+
+	// 새 봉으로 창이 밀리면 새로 계산
+	shifted := service.CalculateAll(append(bars[:0:0], bars[1:51]...))
+	require.NotNil(t, shifted)
+	assert.Equal(t, int64(2), cache.Stats().Misses)
+	assert.Equal(t, *shifted, *services.NewIndicatorService().CalculateAll(append(bars[:0:0], bars[1:51]...)))
+
+	// 같은 봉이라도 지표 기간이 다르면 다른 항목
+	params := services.DefaultIndicatorParams
+	params.RSIPeriod = 7
+	service.CalculateAllWithParams(append(bars[:0:0], bars[:50]...), params)
+	assert.Equal(t, int64(3), cache.Stats().Misses)
+}
+
+func TestIndicatorCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := services.NewIndicatorCache(2)
+	bars := syntheticBars(3)
+	key := func(i int) [32]byte { return services.IndicatorCacheKey(bars[i:i+1], services.DefaultIndicatorParams) }
+
+	cache.Put(key(0), &services.IndicatorResult{RSI: 10})
+	cache.Put(key(1), &services.IndicatorResult{RSI: 20})
+	_, ok := cache.Get(key(0))
+	require.True(t, ok)
+	cache.Put(key(2), &services.IndicatorResult{RSI: 30})
+
+	_, ok = cache.Get(key(1))
+	assert.False(t, ok, "least recently used entry is evicted")
+	cached, ok := cache.Get(key(0))
+	require.True(t, ok)
+	assert.Equal(t, 10.0, cached.RSI)
+	assert.Equal(t, 2, cache.Stats().Size)
+
+	assert.Nil(t, services.NewIndicatorCache(0))
+}
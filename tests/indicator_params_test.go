@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"encoding/json"
+	"math"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorParamsMerge(t *testing.T) {
+	override, err := services.ParseIndicatorParams(`{"rsi_period": 7, "bollinger_stddev": 2.5}`)
+	require.NoError(t, err)
+
+	merged := services.DefaultIndicatorParams.Merge(override)
+	assert.Equal(t, 7, merged.RSIPeriod)
+	assert.Equal(t, 2.5, merged.BollingerStdDev)
+	assert.Equal(t, services.DefaultIndicatorParams.MACDSlow, merged.MACDSlow)
+	assert.Equal(t, services.DefaultIndicatorParams.ATRPeriod, merged.ATRPeriod)
+
+	// 0 이하 값은 덮어쓰지 않는다
+	assert.Equal(t, services.DefaultIndicatorParams, services.DefaultIndicatorParams.Merge(services.IndicatorParams{RSIPeriod: -3}))
+}
+
+func TestParseIndicatorParamsRejectsUnknownKeys(t *testing.T) {
+	empty, err := services.ParseIndicatorParams("")
+	require.NoError(t, err)
+	assert.Equal(t, services.IndicatorParams{}, empty)
+
+	_, err = services.ParseIndicatorParams(`{"rsi": 7}`)
+	assert.Error(t, err)
+
+	_, err = services.ParseIndicatorParams(`{"rsi_period": -7}`)
+	assert.Error(t, err)
+	_, err = services.ParseIndicatorParams(`{"bollinger_stddev": -2}`)
+	assert.Error(t, err)
+}
+
+func TestIndicatorParamsValidate(t *testing.T) {
+	require.NoError(t, services.DefaultIndicatorParams.Validate())
+
+	// 따로는 올바른 값이어도 기본값과 합친 결과가 맞지 않으면 거부한다
+	override, err := services.ParseIndicatorParams(`{"macd_fast": 30}`)
+	require.NoError(t, err)
+	assert.Error(t, services.DefaultIndicatorParams.Merge(override).Validate())
+
+	assert.Error(t, services.IndicatorParams{}.Validate())
+	invalid := services.DefaultIndicatorParams
+	invalid.BollingerStdDev = math.Inf(1)
+	assert.Error(t, invalid.Validate())
+}
+
+func (suite *IntegrationTestSuite) TestSignalUsesPerSymbolIndicatorConfig() {
+	suite.db.Create(&models.Stock{Symbol: "FASTRSI", Name: "Fast RSI", Market: "KR", IsActive: true, IndicatorConfig: `{"rsi_period": 7}`})
+	suite.db.Create(&models.Stock{Symbol: "SLOWRSI", Name: "Default RSI", Market: "KR", IsActive: true})
+
+	// 두 종목에 같은 가격 데이터를 넣어 지표 기간만 다르게 한다
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for _, symbol := range []string{"FASTRSI", "SLOWRSI"} {
+		for i := 0; i < 60; i++ {
+			price := 100 + float64(i%9)*1.5 - float64(i%4)
+			suite.db.Create(&models.StockPrice{
+				Symbol: symbol, Market: "KR",
+				OpenPrice: price, HighPrice: price + 2, LowPrice: price - 2, ClosePrice: price,
+				Volume: int64(1000 + i*10), Timestamp: start.AddDate(0, 0, i),
+			})
+		}
+	}
+
+	indicatorService := services.NewIndicatorService()
+	generator := services.NewSignalGeneratorService(suite.db, indicatorService, nil, nil, nil)
+
+	snapshotRSI := func(symbol string) float64 {
+		signal, err := generator.GenerateSignal(symbol, "KR")
+		suite.Require().NoError(err)
+
+		var snapshot map[string]float64
+		suite.Require().NoError(json.Unmarshal([]byte(signal.IndicatorSnapshot), &snapshot))
+		return snapshot["rsi"]
+	}
+
+	var prices []models.StockPrice
+	suite.db.Where("symbol = ?", "SLOWRSI").Order("timestamp desc").Limit(50).Find(&prices)
+	fast := services.DefaultIndicatorParams
+	fast.RSIPeriod = 7
+	expectedFast := indicatorService.CalculateAllWithParams(prices, fast).RSI
+	expectedDefault := indicatorService.CalculateAll(prices).RSI
+	suite.Require().NotEqual(expectedFast, expectedDefault)
+
+	assert.InDelta(suite.T(), expectedFast, snapshotRSI("FASTRSI"), 1e-9)
+	assert.InDelta(suite.T(), expectedDefault, snapshotRSI("SLOWRSI"), 1e-9)
+}
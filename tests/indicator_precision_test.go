@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorPrecisionRounding(t *testing.T) {
+	defaultDecimals := 3
+	precision := services.NewIndicatorPrecision(config.IndicatorConfig{
+		DefaultDecimals: &defaultDecimals,
+		Decimals:        map[string]int{"MACD": 1, "atr": -1},
+	})
+
+	assert.Equal(t, 2, precision.Decimals("rsi"))
+	assert.Equal(t, 1, precision.Decimals("macd"))
+	assert.Equal(t, 3, precision.Decimals("sma_20"))
+
+	assert.Equal(t, 65.57, precision.Round("RSI", 65.56789))
+	assert.Equal(t, -1.2, precision.Round("macd", -1.23456))
+	assert.Equal(t, 123457.0, precision.Round("obv", 123456.78))
+	assert.Equal(t, 1.23456789, precision.Round("atr", 1.23456789)) // 음수 자릿수는 반올림하지 않음
+
+	values := map[string]float64{"rsi": 30.123456, "sma_20": 101.23456}
+	assert.Equal(t, map[string]float64{"rsi": 30.12, "sma_20": 101.235}, precision.RoundMap(values))
+	assert.Equal(t, 30.123456, values["rsi"]) // 원본은 그대로
+
+	assert.JSONEq(t, `{"value": 65.57, "period": 14}`, precision.RoundJSON("rsi", `{"value": 65.56789, "period": 14}`))
+	assert.Equal(t, "not json", precision.RoundJSON("rsi", "not json"))
+}
+
+func TestIndicatorPrecisionDefaults(t *testing.T) {
+	precision := services.NewIndicatorPrecision(config.IndicatorConfig{})
+
+	assert.Equal(t, config.DefaultIndicatorDecimals, precision.Decimals("ema_12"))
+	assert.Equal(t, 2, precision.Decimals("williams_r"))
+	assert.Equal(t, 0, precision.Decimals("obv"))
+
+	// 기본 자릿수를 0 으로 지정하면 정수로 반올림한다
+	zero := 0
+	precision = services.NewIndicatorPrecision(config.IndicatorConfig{DefaultDecimals: &zero})
+	assert.Equal(t, 0, precision.Decimals("ema_12"))
+	assert.Equal(t, 102.0, precision.Round("ema_12", 101.56))
+}
+
+func (suite *IntegrationTestSuite) TestIndicatorsRoundedInResponseOnly() {
+	suite.cfg.Indicator = config.IndicatorConfig{Decimals: map[string]int{"rsi": 1}}
+	defer func() { suite.cfg.Indicator = config.IndicatorConfig{} }()
+
+	const rsi = 65.56789012345
+	indicator := models.TechnicalIndicator{
+		Symbol:         "PREC01",
+		IndicatorName:  "rsi",
+		IndicatorValue: `{"value": 65.56789012345}`,
+		CalculatedAt:   time.Now(),
+	}
+	suite.Require().NoError(suite.db.Create(&indicator).Error)
+
+	req, _ := http.NewRequest("GET", "/api/v1/stocks/PREC01/indicators", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Indicators []models.TechnicalIndicator `json:"indicators"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().Len(response.Indicators, 1)
+
+	var served map[string]float64
+	suite.Require().NoError(json.Unmarshal([]byte(response.Indicators[0].IndicatorValue), &served))
+	assert.Equal(suite.T(), 65.6, served["value"])
+
+	// 저장된 값은 전체 정밀도를 유지한다
+	var stored models.TechnicalIndicator
+	suite.Require().NoError(suite.db.First(&stored, indicator.ID).Error)
+	var raw map[string]float64
+	require.NoError(suite.T(), json.Unmarshal([]byte(stored.IndicatorValue), &raw))
+	assert.Equal(suite.T(), rsi, raw["value"])
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorSelfTestPasses(t *testing.T) {
+	report := services.RunIndicatorSelfTest(services.NewIndicatorService().CalculateAll)
+
+	assert.True(t, report.Passed)
+	require.Len(t, report.Checks, 18)
+	for _, check := range report.Checks {
+		assert.True(t, check.Passed, "%s: expected %v, got %v", check.Name, check.Expected, check.Actual)
+	}
+}
+
+func TestIndicatorSelfTestDetectsPerturbation(t *testing.T) {
+	service := services.NewIndicatorService()
+	perturbed := func(prices []models.StockPrice) *services.IndicatorResult {
+		result := service.CalculateAll(prices)
+		result.RSI += 0.01 // 계산식이 살짝 바뀐 상황
+		return result
+	}
+
+	report := services.RunIndicatorSelfTest(perturbed)
+
+	assert.False(t, report.Passed)
+	for _, check := range report.Checks {
+		assert.Equal(t, check.Name != "rsi", check.Passed, check.Name)
+	}
+}
+
+func TestIndicatorSelfTestFailsWithoutResult(t *testing.T) {
+	report := services.RunIndicatorSelfTest(func([]models.StockPrice) *services.IndicatorResult { return nil })
+
+	assert.False(t, report.Passed)
+	for _, check := range report.Checks {
+		assert.False(t, check.Passed, check.Name)
+	}
+}
+
+func (suite *IntegrationTestSuite) TestIndicatorSelfTestEndpoint() {
+	req, _ := http.NewRequest("GET", "/api/v1/admin/selftest/indicators", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		SelfTest services.IndicatorSelfTestReport `json:"selftest"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(suite.T(), response.SelfTest.Passed)
+	assert.Len(suite.T(), response.SelfTest.Checks, 18)
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndicatorSeriesAlignment(t *testing.T) {
+	bars := syntheticBars(70)
+	indicators := services.NewIndicatorService()
+	names := []string{"rsi", "sma_20"}
+	require.NoError(t, indicators.ValidateSeriesNames(names))
+
+	// 앞쪽 봉은 계산에만 쓰이고, 봉이 50개가 되기 전 시점은 null
+	from := bars[40].Timestamp
+	series := indicators.Series(bars, from, names, indicators.Params())
+	require.Len(t, series.Timestamps, 30)
+	assert.Equal(t, from, series.Timestamps[0])
+	for _, name := range names {
+		values := series.Series[name]
+		require.Len(t, values, 30, name)
+		for i, value := range values {
+			if i < 9 {
+				assert.Nil(t, value, "%s at %d", name, i)
+			} else {
+				assert.NotNil(t, value, "%s at %d", name, i)
+			}
+		}
+	}
+
+	// 마지막 값은 전체 봉으로 계산한 최신 지표와 같다
+	latest := indicators.CalculateAll(syntheticBars(70))
+	assert.InDelta(t, latest.RSI, *series.Series["rsi"][29], 1e-9)
+	assert.InDelta(t, latest.SMA20, *series.Series["sma_20"][29], 1e-9)
+
+	assert.Error(t, indicators.ValidateSeriesNames(nil))
+	assert.Error(t, indicators.ValidateSeriesNames([]string{"rsi", "vwap"}))
+}
+
+type indicatorSeriesResponse struct {
+	Timestamps []time.Time           `json:"timestamps"`
+	Series     map[string][]*float64 `json:"series"`
+}
+
+func (suite *IntegrationTestSuite) TestIndicatorSeriesEndpoint() {
+	bars := syntheticBars(120)
+	for i := range bars {
+		bars[i].Symbol = "SERIES01"
+		suite.Require().NoError(suite.db.Create(&bars[i]).Error)
+	}
+	// 장중 스냅샷은 일봉 시계열에 섞이지 않는다
+	suite.Require().NoError(suite.db.Create(&models.StockPrice{
+		Symbol: "SERIES01", Market: "KR", ClosePrice: 1, Granularity: models.GranularityIntraday, Timestamp: bars[100].Timestamp.Add(time.Hour),
+	}).Error)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/api/v1/stocks/SERIES01/indicators/series?"+query, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 2024-03-22 ~ 2024-04-30: 80 ~ 119 번째 봉 (앞선 봉으로 워밍업되어 모든 값이 있다)
+	w := get("names=rsi,sma_20,macd&from=2024-03-22&to=2024-04-30")
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var response indicatorSeriesResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().Len(response.Timestamps, 40)
+	for i, timestamp := range response.Timestamps {
+		assert.True(suite.T(), timestamp.Equal(bars[80+i].Timestamp), "timestamp %d = %v", i, timestamp)
+	}
+	suite.Require().Len(response.Series, 3)
+	for _, name := range []string{"rsi", "sma_20", "macd"} {
+		values := response.Series[name]
+		suite.Require().Len(values, len(response.Timestamps), name)
+		for i, value := range values {
+			assert.NotNil(suite.T(), value, "%s at %d", name, i)
+		}
+	}
+
+	// 최신 지표와 같은 값 (응답 소수 자릿수만큼)
+	latest := services.NewIndicatorService().CalculateAll(syntheticBars(120))
+	assert.InDelta(suite.T(), latest.SMA20, *response.Series["sma_20"][39], 1e-4)
+
+	// 지원하지 않는 지표 이름, 이름 누락은 400
+	assert.Equal(suite.T(), http.StatusBadRequest, get("names=rsi,vwap").Code)
+	assert.Equal(suite.T(), http.StatusBadRequest, get("from=2024-03-22").Code)
+	// 기간 내 봉이 없으면 404
+	assert.Equal(suite.T(), http.StatusNotFound, get("names=rsi&from=2023-01-01&to=2023-06-30").Code)
+}
+
+func TestIndicatorBatchSeriesOverMonths(t *testing.T) {
+	// 약 8개월치 일봉
+	bars := syntheticBars(240)
+	indicators := services.NewIndicatorService()
+	names := services.IndicatorNames()
+
+	history := indicators.BatchSeries(bars, time.Time{}, names, indicators.Params())
+	require.Len(t, history.Timestamps, len(bars))
+	assert.Equal(t, services.MinIndicatorBars-1, history.Warmup)
+	for _, name := range names {
+		values := history.Series[name]
+		require.Len(t, values, len(bars), name)
+		for i := 0; i < history.Warmup; i++ {
+			assert.Zero(t, values[i], "%s at %d", name, i)
+		}
+	}
+
+	// 봉마다 그 봉까지로 계산한 지표와 같다
+	for _, n := range []int{services.MinIndicatorBars, 120, 240} {
+		expected := indicators.CalculateAll(syntheticBars(n)).ToMap()
+		for _, name := range names {
+			assert.InDelta(t, expected[name], history.Series[name][n-1], 1e-9, "%s at %d bars", name, n)
+		}
+	}
+
+	// from 이전 봉은 계산에만 쓴다
+	from := bars[200].Timestamp
+	tail := indicators.BatchSeries(bars, from, []string{"ema_26"}, indicators.Params())
+	require.Len(t, tail.Timestamps, 40)
+	assert.Zero(t, tail.Warmup)
+	assert.Equal(t, history.Series["ema_26"][200:], tail.Series["ema_26"])
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestSignalPersistsIndicatorSnapshot() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: "BUY", Confidence: 0.7, Reasoning: []string{"uptrend"}})
+	}))
+	defer server.Close()
+
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i%7) + float64(i)*0.5
+		suite.db.Create(&models.StockPrice{
+			Symbol: "SNAPSHOT", Market: "US",
+			OpenPrice: price, HighPrice: price + 2, LowPrice: price - 2, ClosePrice: price,
+			Volume: int64(1000 + i*10), Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second}}
+	indicatorService := services.NewIndicatorService()
+	generator := services.NewSignalGeneratorService(
+		suite.db, indicatorService, services.NewAIClient(cfg), services.NewCacheService(suite.cfg), nil)
+
+	signal, err := generator.GenerateSignal("SNAPSHOT", "US")
+	suite.Require().NoError(err)
+	suite.Require().Equal("AI", signal.Source)
+
+	// 저장된 스냅샷을 다시 읽어 같은 가격 데이터로 계산한 지표와 비교
+	var stored models.TradingSignal
+	suite.Require().NoError(suite.db.First(&stored, signal.ID).Error)
+	suite.Require().NotEmpty(stored.IndicatorSnapshot)
+
+	var snapshot map[string]float64
+	suite.Require().NoError(json.Unmarshal([]byte(stored.IndicatorSnapshot), &snapshot))
+
+	var prices []models.StockPrice
+	suite.db.Where("symbol = ? AND market = ?", "SNAPSHOT", "US").Order("timestamp desc").Limit(50).Find(&prices)
+	expected := indicatorService.CalculateAll(prices).ToMap()
+
+	suite.Require().Len(snapshot, len(expected))
+	for name, value := range expected {
+		assert.InDelta(suite.T(), value, snapshot[name], 1e-6, name)
+	}
+}
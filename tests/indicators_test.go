@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dailyBars(start time.Time, days int) []models.StockPrice {
+	prices := make([]models.StockPrice, days)
+	for i := range prices {
+		prices[i] = models.StockPrice{Symbol: "GAP", Market: "KR", ClosePrice: 100, Timestamp: start.AddDate(0, 0, i)}
+	}
+	return prices
+}
+
+func TestCheckBarCompleteness(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	maxAge, maxGap := 96*time.Hour, 96*time.Hour
+
+	contiguous := dailyBars(now.AddDate(0, 0, -50), 50)
+	assert.Equal(t, services.BarStatusOK, services.CheckBarCompleteness(contiguous, now, maxAge, maxGap))
+
+	// 수집이 2주간 끊긴 이력: 최근 50개 봉이 불규칙한 기간에 걸쳐 있다
+	gapped := append(dailyBars(now.AddDate(0, 0, -70), 25), dailyBars(now.AddDate(0, 0, -25), 25)...)
+	assert.Equal(t, services.BarStatusIncomplete, services.CheckBarCompleteness(gapped, now, maxAge, maxGap))
+
+	stale := dailyBars(now.AddDate(0, 0, -60), 50)
+	assert.Equal(t, services.BarStatusStale, services.CheckBarCompleteness(stale, now, maxAge, maxGap))
+
+	assert.Equal(t, services.BarStatusIncomplete, services.CheckBarCompleteness(nil, now, maxAge, maxGap))
+}
@@ -51,7 +51,7 @@ func (suite *IntegrationTestSuite) SetupSuite() {
 	suite.Require().NoError(err)
 
 	// Setup router
-	suite.router = router.Setup(suite.db, suite.cfg)
+	suite.router = router.Setup(suite.db, suite.cfg, router.Dependencies{})
 }
 
 func (suite *IntegrationTestSuite) TearDownSuite() {
@@ -64,7 +64,7 @@ func (suite *IntegrationTestSuite) TearDownSuite() {
 
 func (suite *IntegrationTestSuite) SetupTest() {
 	// Clean up test data before each test
-	suite.db.Exec("TRUNCATE TABLE stocks, stock_prices, technical_indicators, trading_signals, news_articles RESTART IDENTITY CASCADE")
+	suite.db.Exec("TRUNCATE TABLE stocks, stock_prices, technical_indicators, trading_signals, news_articles, obv_states, backfill_jobs, backfill_usages, ticker_sync_runs, feature_flags RESTART IDENTITY CASCADE")
 }
 
 func (suite *IntegrationTestSuite) TestHealthCheck() {
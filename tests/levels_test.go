@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestGetLevels() {
+	suite.db.Exec("TRUNCATE TABLE stock_prices RESTART IDENTITY CASCADE")
+
+	// 10일 주기로 오르내리는 지그재그 일봉 60개
+	start := time.Now().AddDate(0, 0, -60)
+	for i := 0; i < 60; i++ {
+		phase := i % 10
+		if phase > 5 {
+			phase = 10 - phase
+		}
+		closePrice := 100.0 + float64(phase*4) + float64(i)*0.1
+		suite.db.Create(&models.StockPrice{
+			Symbol:      "LEVELS",
+			Market:      "KR",
+			OpenPrice:   closePrice,
+			HighPrice:   closePrice + 1,
+			LowPrice:    closePrice - 1,
+			ClosePrice:  closePrice,
+			Granularity: models.GranularityDaily,
+			Timestamp:   start.AddDate(0, 0, i),
+		})
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v1/stocks/LEVELS/levels", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Levels services.LevelsReport `json:"levels"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+	levels := response.Levels
+	current := levels.CurrentPrice
+	suite.Require().NotZero(current)
+
+	byName := func(group []services.PriceLevel, source string) map[string]float64 {
+		values := make(map[string]float64)
+		for _, level := range group {
+			assert.Equal(suite.T(), source, level.Source, level.Name)
+			values[level.Name] = level.Price
+		}
+		return values
+	}
+
+	pivots := byName(levels.Pivots, services.LevelSourcePivot)
+	suite.Require().Len(pivots, 5)
+	assert.Less(suite.T(), pivots["S2"], pivots["S1"])
+	assert.Less(suite.T(), pivots["S1"], pivots["P"])
+	assert.Less(suite.T(), pivots["P"], pivots["R1"])
+	assert.Less(suite.T(), pivots["R1"], pivots["R2"])
+
+	swings := byName(levels.SupportResistance, services.LevelSourceSwing)
+	suite.Require().Contains(swings, "support")
+	suite.Require().Contains(swings, "resistance")
+	assert.LessOrEqual(suite.T(), swings["support"], current)
+	assert.GreaterOrEqual(suite.T(), swings["resistance"], current)
+
+	week52 := byName(levels.Week52, services.LevelSourceWeek52)
+	suite.Require().Len(week52, 2)
+	assert.LessOrEqual(suite.T(), week52["low_52w"], current)
+	assert.GreaterOrEqual(suite.T(), week52["high_52w"], current)
+
+	bollinger := byName(levels.Bollinger, services.LevelSourceBollinger)
+	suite.Require().Len(bollinger, 3)
+	assert.Less(suite.T(), bollinger["lower"], bollinger["middle"])
+	assert.Less(suite.T(), bollinger["middle"], bollinger["upper"])
+	assert.Greater(suite.T(), bollinger["upper"], week52["low_52w"])
+	assert.Less(suite.T(), bollinger["lower"], week52["high_52w"])
+
+	req, _ = http.NewRequest("GET", "/api/v1/stocks/UNKNOWN/levels", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
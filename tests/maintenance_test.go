@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestDBSecMaintenancePausesCollection() {
+	var apiCalls int32
+	var maintenance atomic.Bool
+	maintenance.Store(true)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		atomic.AddInt32(&apiCalls, 1)
+		if maintenance.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"rsp_cd":"99999","rsp_msg":"시스템 점검 중입니다."}`))
+			return
+		}
+		w.Write([]byte(`{"rsp_cd":"00000","rsp_msg":"정상 처리 되었습니다."}`))
+	}))
+	defer upstream.Close()
+
+	for _, symbol := range []string{"MAINT01", "MAINT02", "MAINT03"} {
+		suite.Require().NoError(suite.db.Create(&models.Stock{Symbol: symbol, Name: symbol, Market: "KR", IsActive: true}).Error)
+	}
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.Require().NoError(suite.db.Create(&models.StockPrice{
+			Symbol: "MAINT01", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		}).Error)
+	}
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = upstream.URL
+	state := client.NewMaintenanceState().Configure(nil, 300*time.Millisecond)
+	collector := services.NewDataCollectorService(suite.db, cfg)
+	collector.APIClient().WithMaintenance(state)
+	generator := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil).WithMaintenance(state)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health/ready", handlers.NewHealthHandler(suite.db).WithMaintenance(state).ReadinessCheck)
+	ready := func() handlers.HealthResponse {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/health/ready", nil))
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var response handlers.HealthResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().NotNil(response.Maintenance)
+		return response
+	}
+	assert.Equal(suite.T(), "ready", ready().Status)
+
+	// 첫 종목에서 점검 응답을 받으면 남은 종목은 API 를 호출하지 않고 멈춘다
+	report, err := collector.CollectAllStocks()
+	suite.Require().NoError(err)
+	assert.True(suite.T(), report.Paused)
+	assert.Zero(suite.T(), report.Success)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&apiCalls))
+	assert.ErrorIs(suite.T(), report.Failures["MAINT02"], services.ErrCollectionPaused)
+	assert.ErrorIs(suite.T(), report.Failures["MAINT03"], services.ErrCollectionPaused)
+
+	// 점검 중인 주기는 API 호출 없이 바로 멈춘다
+	report, err = collector.CollectAllStocks()
+	suite.Require().NoError(err)
+	assert.True(suite.T(), report.Paused)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&apiCalls))
+
+	// 신호 생성도 건너뛴다
+	suite.Require().NoError(generator.GenerateSignalsForAllStocks())
+	var signals int64
+	suite.db.Model(&models.TradingSignal{}).Count(&signals)
+	assert.Zero(suite.T(), signals)
+
+	response := ready()
+	assert.Equal(suite.T(), "maintenance", response.Status)
+	assert.True(suite.T(), response.Maintenance.Active)
+	assert.Equal(suite.T(), client.MaintenanceSourceResponse, response.Maintenance.Source)
+	assert.True(suite.T(), collector.GetAPIStatus()["maintenance"].(client.MaintenanceStatus).Active)
+
+	// 재개 시각이 지나면 자동으로 다시 수집하고 신호를 만든다
+	maintenance.Store(false)
+	time.Sleep(350 * time.Millisecond)
+	report, err = collector.CollectAllStocks()
+	suite.Require().NoError(err)
+	assert.False(suite.T(), report.Paused)
+	assert.Greater(suite.T(), atomic.LoadInt32(&apiCalls), int32(1))
+	assert.Equal(suite.T(), "ready", ready().Status)
+
+	suite.Require().NoError(generator.GenerateSignalsForAllStocks())
+	suite.db.Model(&models.TradingSignal{}).Count(&signals)
+	assert.Equal(suite.T(), int64(1), signals)
+}
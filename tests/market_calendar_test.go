@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func calendarDate(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestBusinessDaysBeforeKR(t *testing.T) {
+	// 2025-10-03 개천절 ~ 10-09 한글날: 추석 연휴와 주말이 이어진다
+	friday := calendarDate("2025-10-10")
+
+	assert.Equal(t, calendarDate("2025-10-02"), apimodels.BusinessDaysBefore(friday, 1, "KR"))
+	assert.Equal(t, calendarDate("2025-10-01"), apimodels.BusinessDaysBefore(friday, 2, "KR"))
+	assert.Equal(t, 1, apimodels.BusinessDaysBetween(calendarDate("2025-10-02"), friday, "KR"))
+
+	// 주말만 끼는 경우
+	monday := calendarDate("2025-11-10")
+	assert.Equal(t, calendarDate("2025-11-07"), apimodels.BusinessDaysBefore(monday, 1, "국내"))
+	assert.Equal(t, calendarDate("2025-11-03"), apimodels.BusinessDaysBefore(monday, 5, "KR"))
+}
+
+func TestBusinessDaysBeforeUS(t *testing.T) {
+	// 2024-07-04 독립기념일 (목), 07-06 ~ 07 주말
+	monday := calendarDate("2024-07-08")
+
+	assert.Equal(t, calendarDate("2024-07-05"), apimodels.BusinessDaysBefore(monday, 1, "US"))
+	assert.Equal(t, calendarDate("2024-07-03"), apimodels.BusinessDaysBefore(monday, 2, "NASDAQ"))
+	assert.Equal(t, calendarDate("2024-07-03"), apimodels.BusinessDaysBefore(monday, 2, "FY"))
+	assert.Equal(t, 4, apimodels.BusinessDaysBetween(calendarDate("2024-07-01"), monday, "US"))
+
+	// 미국 휴장일은 국내 거래일이다
+	assert.True(t, apimodels.DefaultMarketCalendar.IsTradingDay(calendarDate("2024-07-04"), "KR"))
+	assert.False(t, apimodels.DefaultMarketCalendar.IsTradingDay(calendarDate("2024-07-04"), "US"))
+}
+
+func TestBusinessDaysRoundTrip(t *testing.T) {
+	// 시각은 그대로 유지되고, BusinessDaysBetween 은 BusinessDaysBefore 의 역연산이다
+	end := time.Date(2024, 12, 27, 15, 30, 0, 0, time.FixedZone("KST", 9*60*60))
+	for _, market := range []string{"KR", "US"} {
+		for _, n := range []int{0, 1, 5, 20, 60} {
+			start := apimodels.BusinessDaysBefore(end, n, market)
+			assert.Equal(t, 15, start.Hour(), market)
+			assert.Equal(t, n, apimodels.BusinessDaysBetween(start, end, market), "%s n=%d", market, n)
+			assert.Equal(t, -n, apimodels.BusinessDaysBetween(end, start, market), "%s n=%d", market, n)
+		}
+	}
+}
+
+func TestMarketCalendarCustomHolidays(t *testing.T) {
+	calendar := apimodels.NewMarketCalendar()
+	wednesday := calendarDate("2024-07-10")
+
+	// 휴장일이 없으면 주말만 건너뛴다 (알 수 없는 시장도 마찬가지)
+	assert.Equal(t, calendarDate("2024-07-04"), calendar.BusinessDaysBefore(wednesday, 4, "US"))
+	assert.Equal(t, calendarDate("2024-07-04"), apimodels.BusinessDaysBefore(wednesday, 4, "JP"))
+
+	calendar.AddHolidays("NASDAQ", "2024-07-09")
+	assert.Equal(t, calendarDate("2024-07-03"), calendar.BusinessDaysBefore(wednesday, 4, "US"))
+	assert.Equal(t, 0, calendar.BusinessDaysBetween(calendarDate("2024-07-08"), calendarDate("2024-07-09"), "US"))
+}
+
+func TestLastClosedTradingDay(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	lastClosed := func(at time.Time, market string) string {
+		return apimodels.DefaultMarketCalendar.LastClosedTradingDay(at, market).Format("2006-01-02")
+	}
+
+	// 장 마감 전이면 전 거래일, 마감 후면 당일
+	assert.Equal(t, "2024-06-05", lastClosed(time.Date(2024, 6, 6, 15, 59, 0, 0, newYork), "US"))
+	assert.Equal(t, "2024-06-06", lastClosed(time.Date(2024, 6, 6, 16, 0, 0, 0, newYork), "NASDAQ"))
+	// 주말과 휴장일(2024-07-04)은 건너뛴다
+	assert.Equal(t, "2024-06-07", lastClosed(time.Date(2024, 6, 9, 12, 0, 0, 0, newYork), "US"))
+	assert.Equal(t, "2024-07-03", lastClosed(time.Date(2024, 7, 5, 9, 0, 0, 0, newYork), "US"))
+	// 시장 현지 시간 기준 (서울 6/7 오전은 뉴욕 6/6 저녁)
+	assert.Equal(t, "2024-06-06", lastClosed(time.Date(2024, 6, 7, 9, 0, 0, 0, seoul), "US"))
+	assert.Equal(t, "2024-06-05", lastClosed(time.Date(2024, 6, 7, 9, 0, 0, 0, seoul), "KR"))
+}
+
+func TestMarketCalendarLoadHolidays(t *testing.T) {
+	calendar := apimodels.NewMarketCalendar()
+	assert.False(t, calendar.HasHolidays("KR", 2027))
+
+	// 기본 달력이 다루지 않는 해의 휴장일을 설정에서 추가
+	require.NoError(t, calendar.LoadHolidays("KR", []string{"2027-01-01", " 2027-02-08"}))
+	assert.True(t, calendar.HasHolidays("KR", 2027))
+	assert.False(t, calendar.HasHolidays("US", 2027))
+	assert.False(t, calendar.IsTradingDay(calendarDate("2027-02-08"), "KR"))
+
+	// 잘못된 날짜가 섞여 있으면 아무것도 추가하지 않는다
+	assert.Error(t, calendar.LoadHolidays("US", []string{"2027-01-18", "2027/05/31"}))
+	assert.True(t, calendar.IsTradingDay(calendarDate("2027-01-18"), "US"))
+}
+
+func TestMarketTimeUsesMarketDate(t *testing.T) {
+	// 서울 6/7(금) 오전은 뉴욕 6/6(목) 저녁 - 서버 시간대가 아닌 시장 날짜로 거래일을 센다
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	at := time.Date(2024, 6, 7, 9, 0, 0, 0, seoul)
+
+	assert.Equal(t, "2024-06-06", apimodels.MarketTime(at, "US").Format("2006-01-02"))
+	assert.Equal(t, "2024-06-05", apimodels.BusinessDaysBefore(apimodels.MarketTime(at, "NASDAQ"), 1, "US").Format("2006-01-02"))
+	assert.Equal(t, "2024-06-05", apimodels.BusinessDaysBefore(apimodels.MarketTime(at, "KR"), 1, "KR").Format("2006-01-02"))
+}
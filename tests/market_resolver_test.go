@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock-recommender/backend/handlers"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarketResolverAliases(t *testing.T) {
+	resolver := apimodels.NewMarketResolver()
+
+	tests := []struct {
+		alias  string
+		market string
+		region string
+		code   string
+	}{
+		{"KR", apimodels.MarketKR, apimodels.RegionKR, apimodels.MarketDivStock},
+		{"J", apimodels.MarketKR, apimodels.RegionKR, apimodels.MarketDivStock},
+		{"국내", apimodels.MarketKR, apimodels.RegionKR, apimodels.MarketDivStock},
+		{"INDEX", apimodels.MarketIndex, apimodels.RegionKR, apimodels.MarketDivIndex},
+		{"U", apimodels.MarketIndex, apimodels.RegionKR, apimodels.MarketDivIndex},
+		{"지수", apimodels.MarketIndex, apimodels.RegionKR, apimodels.MarketDivIndex},
+		{"NY", apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY},
+		{"NYSE", apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY},
+		{"FY", apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY},
+		{"뉴욕", apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY},
+		{"NASDAQ", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"NA", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"FN", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"US", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"나스닥", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"AMEX", apimodels.MarketAMEX, apimodels.RegionUS, apimodels.ForeignMarketAMEX},
+		{"AM", apimodels.MarketAMEX, apimodels.RegionUS, apimodels.ForeignMarketAMEX},
+		{"FA", apimodels.MarketAMEX, apimodels.RegionUS, apimodels.ForeignMarketAMEX},
+		{"아멕스", apimodels.MarketAMEX, apimodels.RegionUS, apimodels.ForeignMarketAMEX},
+		{" nasdaq ", apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ},
+		{"nyse", apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			market, ok := resolver.Resolve(tt.alias)
+			require.True(t, ok)
+			assert.Equal(t, tt.market, market.Name)
+			assert.Equal(t, tt.region, market.Region)
+			assert.Equal(t, tt.code, market.Code)
+		})
+	}
+}
+
+func TestMarketResolverUnknownAlias(t *testing.T) {
+	resolver := apimodels.NewMarketResolver()
+
+	_, ok := resolver.Resolve("LSE")
+	assert.False(t, ok)
+	assert.Equal(t, "", resolver.Region("LSE"))
+
+	// 해외 코드가 필요한 곳에서는 알 수 없는 별칭과 국내 시장 모두 나스닥으로 대체
+	assert.Equal(t, apimodels.ForeignMarketNASDAQ, resolver.ForeignCode("LSE"))
+	assert.Equal(t, apimodels.ForeignMarketNASDAQ, resolver.ForeignCode("KR"))
+	assert.Equal(t, apimodels.ForeignMarketAMEX, resolver.ForeignCode("아멕스"))
+}
+
+func TestMarketResolverDefaultForeign(t *testing.T) {
+	resolver := apimodels.NewMarketResolver()
+
+	// 배포 기본 시장이 해외 시장이면 거래소를 모르는 요청은 그 시장 코드로 보낸다
+	require.NoError(t, resolver.SetDefaultForeign("NYSE"))
+	assert.Equal(t, apimodels.ForeignMarketNY, resolver.ForeignCode(""))
+	assert.Equal(t, apimodels.ForeignMarketNY, resolver.ForeignCode("KR"))
+	assert.Equal(t, apimodels.ForeignMarketAMEX, resolver.ForeignCode("AMEX"))
+
+	assert.Error(t, resolver.SetDefaultForeign("KR"))
+	assert.Error(t, resolver.SetDefaultForeign("LSE"))
+	assert.Equal(t, apimodels.ForeignMarketNY, resolver.ForeignCode(""))
+
+	options := apimodels.DayChartOptions{}
+	assert.Equal(t, apimodels.ForeignMarketNASDAQ, options.GetMarketCode())
+}
+
+func TestMarketResolverAddAlias(t *testing.T) {
+	resolver := apimodels.NewMarketResolver()
+
+	require.NoError(t, resolver.AddAlias("nas", apimodels.MarketNASDAQ))
+	market, ok := resolver.Resolve("NAS")
+	require.True(t, ok)
+	assert.Equal(t, apimodels.ForeignMarketNASDAQ, market.Code)
+
+	assert.Error(t, resolver.AddAlias("LSE", "LONDON"))
+	assert.Error(t, resolver.AddAlias("  ", apimodels.MarketKR))
+
+	// 다른 resolver 나 기본 resolver 에는 영향이 없다
+	_, ok = apimodels.ResolveMarket("NAS")
+	assert.False(t, ok)
+}
+
+func TestMarketCodesEndpoint(t *testing.T) {
+	resolver := apimodels.NewMarketResolver()
+	require.NoError(t, resolver.AddAlias("NAS", apimodels.MarketNASDAQ))
+
+	r := gin.New()
+	r.GET("/api/v1/debug/market-codes", handlers.NewDebugHandler(resolver).GetMarketCodes)
+	req, _ := http.NewRequest("GET", "/api/v1/debug/market-codes", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Markets              []apimodels.MarketCodes `json:"markets"`
+		DefaultForeignMarket string                  `json:"default_foreign_market"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, apimodels.MarketNASDAQ, response.DefaultForeignMarket)
+
+	// 설정한 기본 해외 시장을 그대로 보여 준다
+	require.NoError(t, resolver.SetDefaultForeign("NYSE"))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var configured struct {
+		DefaultForeignMarket string `json:"default_foreign_market"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &configured))
+	assert.Equal(t, apimodels.MarketNYSE, configured.DefaultForeignMarket)
+
+	expected := []struct {
+		market, region, code, exchange, nameKo string
+	}{
+		{apimodels.MarketKR, apimodels.RegionKR, apimodels.MarketDivStock, "", "국내"},
+		{apimodels.MarketIndex, apimodels.RegionKR, apimodels.MarketDivIndex, "", "지수"},
+		{apimodels.MarketNYSE, apimodels.RegionUS, apimodels.ForeignMarketNY, apimodels.ExchangeNY, "뉴욕"},
+		{apimodels.MarketNASDAQ, apimodels.RegionUS, apimodels.ForeignMarketNASDAQ, apimodels.ExchangeNASDAQ, "나스닥"},
+		{apimodels.MarketAMEX, apimodels.RegionUS, apimodels.ForeignMarketAMEX, apimodels.ExchangeAMEX, "아멕스"},
+	}
+	require.Len(t, response.Markets, len(expected))
+	for i, want := range expected {
+		got := response.Markets[i]
+		assert.Equal(t, want.market, got.Market)
+		assert.Equal(t, want.region, got.Region)
+		assert.Equal(t, want.code, got.MarketDivCode, want.market)
+		assert.Equal(t, want.exchange, got.ExchangeCode, want.market)
+		assert.Equal(t, want.nameKo, got.KoreanName)
+		assert.NotEmpty(t, got.EnglishName)
+		assert.NotEmpty(t, got.MarketDivTrIds, want.market)
+		// 코드와 정규화된 이름은 모두 별칭으로 받는다
+		assert.Contains(t, got.Aliases, want.market)
+		assert.Contains(t, got.Aliases, want.code)
+		if want.exchange != "" {
+			assert.Contains(t, got.Aliases, want.exchange)
+			assert.Equal(t, []string{apimodels.TrIdForeignStockTicker}, got.ExchangeTrIds)
+			assert.Contains(t, got.MarketDivTrIds, apimodels.TrIdForeignStockCurrentPrice)
+		}
+	}
+	assert.Equal(t, []string{apimodels.TrIdStockTicker, apimodels.TrIdStockCurrentPrice}, response.Markets[0].MarketDivTrIds)
+	assert.Contains(t, response.Markets[3].Aliases, "NAS", "registered aliases are listed")
+	assert.Contains(t, response.Markets[3].Aliases, "US")
+}
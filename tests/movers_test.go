@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) getMovers(query string) []services.Mover {
+	req, _ := http.NewRequest("GET", "/api/v1/analytics/movers?"+query, nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Movers []services.Mover `json:"movers"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Movers
+}
+
+func moverSymbols(movers []services.Mover) []string {
+	symbols := []string{}
+	for _, mover := range movers {
+		symbols = append(symbols, mover.Symbol)
+	}
+	return symbols
+}
+
+func (suite *IntegrationTestSuite) TestTopMovers() {
+	now := time.Now()
+	seed := []struct {
+		symbol     string
+		market     string
+		active     bool
+		changeRate float64
+		volume     int64
+	}{
+		{"MOVE01", "KR", true, 5.2, 500000},
+		{"MOVE02", "KR", true, 12.8, 300000},
+		{"MOVE03", "KR", true, 29.9, 100}, // 거래량이 적은 종목
+		{"MOVE04", "US", true, 7.5, 900000},
+		{"MOVE05", "KR", true, -3.1, 400000},
+		{"MOVE06", "US", true, -8.4, 800000},
+		{"MOVE07", "KR", false, 25.0, 1000000}, // 비활성 종목
+		{"MOVE08", "KR", true, 0, 700000},
+	}
+	for _, s := range seed {
+		stock := models.Stock{Symbol: s.symbol, Name: s.symbol, Market: s.market, IsActive: true}
+		suite.Require().NoError(suite.db.Create(&stock).Error)
+		if !s.active {
+			suite.Require().NoError(suite.db.Model(&stock).Update("is_active", false).Error)
+		}
+
+		// 이전 스냅샷은 최신 스냅샷에 가려진다
+		suite.Require().NoError(suite.db.Create(&models.StockPrice{
+			Symbol: s.symbol, Market: s.market, ClosePrice: 100, ChangeRate: 99, Volume: s.volume,
+			Granularity: models.GranularityIntraday, Timestamp: now.Add(-time.Hour),
+		}).Error)
+		suite.Require().NoError(suite.db.Create(&models.StockPrice{
+			Symbol: s.symbol, Market: s.market, ClosePrice: 100 + s.changeRate, ChangeRate: s.changeRate, Volume: s.volume,
+			Granularity: models.GranularityIntraday, Timestamp: now,
+		}).Error)
+	}
+
+	assert.Equal(suite.T(), []string{"MOVE03", "MOVE02", "MOVE04", "MOVE01"}, moverSymbols(suite.getMovers("")))
+	assert.Equal(suite.T(), []string{"MOVE02", "MOVE04"}, moverSymbols(suite.getMovers("min_volume=1000&limit=2")))
+	assert.Equal(suite.T(), []string{"MOVE02", "MOVE01"}, moverSymbols(suite.getMovers("market=KR&min_volume=1000")))
+	assert.Equal(suite.T(), []string{"MOVE06", "MOVE05"}, moverSymbols(suite.getMovers("direction=down")))
+	assert.Equal(suite.T(), []string{"MOVE06"}, moverSymbols(suite.getMovers("direction=down&min_volume=500000")))
+
+	top := suite.getMovers("limit=1")
+	suite.Require().Len(top, 1)
+	assert.Equal(suite.T(), 29.9, top[0].ChangeRate)
+	assert.Equal(suite.T(), int64(100), top[0].Volume)
+
+	req, _ := http.NewRequest("GET", "/api/v1/analytics/movers?direction=sideways", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,230 @@
+package tests
+
+import (
+	"errors"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingNotifier struct {
+	sent []services.Notification
+}
+
+func (n *recordingNotifier) Send(notification services.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestQuietHoursWrapMidnight(t *testing.T) {
+	hours, err := services.NewQuietHours("22:00", "07:00", "Asia/Seoul")
+	require.NoError(t, err)
+
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	assert.True(t, hours.Contains(time.Date(2024, 6, 3, 23, 30, 0, 0, seoul)))
+	assert.True(t, hours.Contains(time.Date(2024, 6, 4, 3, 0, 0, 0, seoul)))
+	assert.False(t, hours.Contains(time.Date(2024, 6, 4, 7, 0, 0, 0, seoul)))
+	assert.False(t, hours.Contains(time.Date(2024, 6, 4, 12, 0, 0, 0, seoul)))
+
+	// 시간대가 다른 시각도 사용자 시간대로 판단 (UTC 18:00 = 서울 03:00)
+	utc := time.Date(2024, 6, 3, 18, 0, 0, 0, time.UTC)
+	assert.True(t, hours.Contains(utc))
+	assert.True(t, time.Date(2024, 6, 4, 7, 0, 0, 0, seoul).Equal(hours.EndAfter(utc)))
+
+	_, err = services.NewQuietHours("25:00", "07:00", "Asia/Seoul")
+	assert.Error(t, err)
+	_, err = services.NewQuietHours("22:00", "07:00", "Mars/Olympus")
+	assert.Error(t, err)
+}
+
+func TestNotificationDeferredDuringQuietHours(t *testing.T) {
+	notifier := &recordingNotifier{}
+	service := services.NewNotificationService(notifier)
+
+	hours, err := services.NewQuietHours("22:00", "07:00", "Asia/Seoul")
+	require.NoError(t, err)
+	service.SetQuietHours("user-1", hours)
+
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	threeAM := time.Date(2024, 6, 4, 3, 0, 0, 0, seoul)
+
+	delivered, err := service.NotifyAt(services.Notification{UserID: "user-1", Title: "BUY 005930", Severity: services.SeverityInfo}, threeAM)
+	require.NoError(t, err)
+	assert.False(t, delivered)
+	assert.Empty(t, notifier.sent)
+	assert.Len(t, service.Pending("user-1"), 1)
+
+	// 방해 금지 시간이 끝나기 전에는 보류 상태 유지
+	sent, err := service.FlushDue(time.Date(2024, 6, 4, 6, 59, 0, 0, seoul))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	sent, err = service.FlushDue(time.Date(2024, 6, 4, 7, 0, 0, 0, seoul))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "BUY 005930", notifier.sent[0].Title)
+	assert.Empty(t, service.Pending("user-1"))
+}
+
+func TestCriticalNotificationBypassesQuietHours(t *testing.T) {
+	notifier := &recordingNotifier{}
+	service := services.NewNotificationService(notifier)
+
+	hours, err := services.NewQuietHours("22:00", "07:00", "Asia/Seoul")
+	require.NoError(t, err)
+	service.SetQuietHours("user-1", hours)
+
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	threeAM := time.Date(2024, 6, 4, 3, 0, 0, 0, seoul)
+
+	delivered, err := service.NotifyAt(services.Notification{UserID: "user-1", Title: "Stop loss hit", Severity: services.SeverityCritical}, threeAM)
+	require.NoError(t, err)
+	assert.True(t, delivered)
+	require.Len(t, notifier.sent, 1)
+	assert.Empty(t, service.Pending("user-1"))
+
+	// 방해 금지 시간이 없는 사용자는 바로 받는다
+	delivered, err = service.NotifyAt(services.Notification{UserID: "user-2", Title: "SELL AAPL", Severity: services.SeverityInfo}, threeAM)
+	require.NoError(t, err)
+	assert.True(t, delivered)
+	assert.Len(t, notifier.sent, 2)
+}
+
+func TestSignalNotificationsDigestWithinWindow(t *testing.T) {
+	notifier := &recordingNotifier{}
+	service := services.NewNotificationService(notifier).WithDigestWindow(time.Minute)
+	service.Subscribe("user-1", "push")
+	service.Subscribe("user-1", "email")
+
+	start := time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)
+	signals := []models.TradingSignal{
+		{Symbol: "005930", SignalType: "BUY", Strength: 0.7, Confidence: 0.72},
+		{Symbol: "000660", SignalType: "SELL", Strength: 0.6, Confidence: 0.65},
+		{Symbol: "AAPL", SignalType: "BUY", Strength: 0.8, Confidence: 0.81},
+	}
+	for i := range signals {
+		require.NoError(t, service.NotifySignal(&signals[i], start.Add(time.Duration(i)*10*time.Second)))
+	}
+
+	// 창이 닫히기 전에는 보내지 않는다
+	sent, err := service.FlushDue(start.Add(59 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, notifier.sent)
+
+	// 사용자/채널별로 요약 알림 하나씩, 신호별 내용은 Details 에 담긴다
+	sent, err = service.FlushDue(start.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, notifier.sent, 2)
+
+	channels := map[string]services.Notification{}
+	for _, notification := range notifier.sent {
+		channels[notification.Channel] = notification
+	}
+	require.Contains(t, channels, "push")
+	require.Contains(t, channels, "email")
+	digest := channels["push"]
+	assert.Equal(t, "user-1", digest.UserID)
+	assert.Equal(t, "3 new trading signals", digest.Title)
+	require.Len(t, digest.Details, 3)
+	assert.Contains(t, digest.Details[0], "BUY 005930")
+	assert.Contains(t, digest.Details[1], "SELL 000660")
+
+	// 다음 신호는 새 창을 연다
+	require.NoError(t, service.NotifySignal(&signals[0], start.Add(2*time.Minute)))
+	sent, err = service.FlushDue(start.Add(3 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, "BUY signal for 005930", notifier.sent[len(notifier.sent)-1].Title)
+}
+
+// flakyNotifier 처음 failures 번은 전달에 실패하는 채널
+type flakyNotifier struct {
+	recordingNotifier
+	failures int
+}
+
+func (n *flakyNotifier) Send(notification services.Notification) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("push gateway unavailable")
+	}
+	return n.recordingNotifier.Send(notification)
+}
+
+func TestSignalDigestRetriedAfterSendFailure(t *testing.T) {
+	notifier := &flakyNotifier{failures: 1}
+	service := services.NewNotificationService(notifier).WithDigestWindow(time.Minute)
+	service.Subscribe("user-1", "push")
+
+	start := time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, service.NotifySignal(&models.TradingSignal{Symbol: "005930", SignalType: "BUY"}, start))
+
+	// 보내지 못한 묶음은 버리지 않는다
+	sent, err := service.FlushDue(start.Add(time.Minute))
+	assert.Error(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, notifier.sent)
+
+	// 그사이 들어온 신호는 되돌린 묶음 뒤에 합쳐 다음 FlushDue 에서 함께 보낸다
+	require.NoError(t, service.NotifySignal(&models.TradingSignal{Symbol: "AAPL", SignalType: "SELL"}, start.Add(70*time.Second)))
+	sent, err = service.FlushDue(start.Add(80 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, notifier.sent, 1)
+	require.Len(t, notifier.sent[0].Details, 2)
+	assert.Contains(t, notifier.sent[0].Details[0], "BUY 005930")
+	assert.Contains(t, notifier.sent[0].Details[1], "SELL AAPL")
+}
+
+func TestSignalDigestRespectsQuietHours(t *testing.T) {
+	notifier := &recordingNotifier{}
+	service := services.NewNotificationService(notifier).WithDigestWindow(time.Minute)
+	service.Subscribe("user-1", "push")
+
+	hours, err := services.NewQuietHours("22:00", "07:00", "UTC")
+	require.NoError(t, err)
+	service.SetQuietHours("user-1", hours)
+
+	night := time.Date(2024, 6, 4, 3, 0, 0, 0, time.UTC)
+	require.NoError(t, service.NotifySignal(&models.TradingSignal{Symbol: "AAPL", SignalType: "BUY"}, night))
+
+	// 창이 닫혀도 방해 금지 시간이면 보류된다
+	sent, err := service.FlushDue(night.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, service.Pending("user-1"), 1)
+
+	sent, err = service.FlushDue(time.Date(2024, 6, 4, 7, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestNotificationFlusherDeliversClosedDigests(t *testing.T) {
+	subscriptions, err := services.ParseNotificationSubscriptions([]string{"ops:push", "desk"})
+	require.NoError(t, err)
+	assert.Equal(t, []services.NotificationSubscription{{UserID: "ops", Channel: "push"}, {UserID: "desk", Channel: "log"}}, subscriptions)
+	_, err = services.ParseNotificationSubscriptions([]string{":push"})
+	assert.Error(t, err)
+
+	notifier := &recordingNotifier{}
+	service := services.NewNotificationService(notifier).WithDigestWindow(time.Minute)
+	for _, subscription := range subscriptions {
+		service.Subscribe(subscription.UserID, subscription.Channel)
+	}
+	start := time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, service.NotifySignal(&models.TradingSignal{Symbol: "005930", SignalType: "BUY"}, start))
+
+	// 신호 생성이 다시 돌지 않아도 주기 전달이 닫힌 묶음을 보낸다
+	flusher := workers.NewNotificationFlusher(service, time.Second)
+	assert.Equal(t, 0, flusher.Flush(start.Add(30*time.Second)))
+	assert.Equal(t, 2, flusher.Flush(start.Add(time.Minute)))
+	assert.Len(t, notifier.sent, 2)
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// obvBars 종가가 오르내리는 연속 봉 (같은 종가가 이어지는 구간 포함)
+func obvBars(symbol string, n int) []models.StockPrice {
+	closes := []float64{100, 102, 101, 101, 105, 103, 104, 104, 100, 108}
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	bars := make([]models.StockPrice, n)
+	for i := range bars {
+		bars[i] = models.StockPrice{
+			Symbol:     symbol,
+			Market:     "KR",
+			ClosePrice: closes[i%len(closes)] + float64(i/len(closes)),
+			Volume:     int64(1000 + 100*i),
+			Timestamp:  start.AddDate(0, 0, i),
+		}
+	}
+	return bars
+}
+
+// fullHistoryOBV 전체 봉으로 처음부터 계산한 OBV
+func fullHistoryOBV(bars []models.StockPrice) float64 {
+	obv := 0.0
+	for i := 1; i < len(bars); i++ {
+		if bars[i].ClosePrice > bars[i-1].ClosePrice {
+			obv += float64(bars[i].Volume)
+		} else if bars[i].ClosePrice < bars[i-1].ClosePrice {
+			obv -= float64(bars[i].Volume)
+		}
+	}
+	return obv
+}
+
+func TestAccumulateOBVAcrossWindows(t *testing.T) {
+	bars := obvBars("OBV01", 30)
+
+	// 매 주기 최근 10봉 창을 (역순으로) 넘겨도 새 봉만 한 번씩 반영된다
+	var state models.OBVState
+	for end := 10; end <= len(bars); end += 4 {
+		window := make([]models.StockPrice, 0, 10)
+		for i := end - 1; i >= end-10; i-- {
+			window = append(window, bars[i])
+		}
+		state = services.AccumulateOBV(state, window)
+		assert.Equal(t, fullHistoryOBV(bars[:end]), state.OBV, "after bar %d", end)
+	}
+
+	state = services.AccumulateOBV(state, bars[20:])
+	assert.Equal(t, fullHistoryOBV(bars), state.OBV)
+	assert.Equal(t, bars[len(bars)-1].Timestamp, state.LastBarAt)
+	assert.Equal(t, bars[len(bars)-1].ClosePrice, state.LastClose)
+
+	var volume int64
+	for _, bar := range bars {
+		volume += bar.Volume
+	}
+	assert.Equal(t, volume, state.CumulativeVolume)
+}
+
+func TestAccumulateOBVIgnoresOldBars(t *testing.T) {
+	bars := obvBars("OBV02", 10)
+	state := services.AccumulateOBV(models.OBVState{}, bars)
+
+	again := services.AccumulateOBV(state, bars[3:])
+	assert.Equal(t, state, again)
+}
+
+func (suite *IntegrationTestSuite) TestOBVTrackerPersistsAcrossCycles() {
+	tracker := services.NewOBVTracker(suite.db)
+	bars := obvBars("OBV03", 80)
+
+	// 워커처럼 매 주기 최근 50봉만 넘긴다
+	for end := 50; end <= len(bars); end += 5 {
+		state, err := tracker.Update("OBV03", bars[end-50:end])
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), fullHistoryOBV(bars[:end]), state.OBV, "after bar %d", end)
+	}
+
+	stored, err := tracker.Get("OBV03")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), fullHistoryOBV(bars), stored.OBV)
+	assert.True(suite.T(), bars[len(bars)-1].Timestamp.Equal(stored.LastBarAt))
+
+	// 창 안에서만 계산한 OBV 와 달리 누적 값은 처음 봉부터의 추세를 유지한다
+	windowOBV := fullHistoryOBV(bars[len(bars)-50:])
+	assert.NotEqual(suite.T(), windowOBV, stored.OBV)
+
+	var count int64
+	suite.db.Model(&models.OBVState{}).Where("symbol = ?", "OBV03").Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
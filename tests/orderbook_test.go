@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestGetOrderBook() {
+	suite.db.Exec("TRUNCATE TABLE asking_prices RESTART IDENTITY CASCADE")
+	suite.db.Create(&models.AskingPrice{
+		Symbol:    "005930",
+		AskPrice1: 55600, AskPrice2: 55700, AskPrice3: 55800, AskPrice4: 55900, AskPrice5: 56000,
+		BidPrice1: 55500, BidPrice2: 55400, BidPrice3: 55300, BidPrice4: 55200, BidPrice5: 55100,
+		AskVolume1: 100, AskVolume2: 200, AskVolume3: 300, AskVolume4: 400, AskVolume5: 500,
+		BidVolume1: 600, BidVolume2: 700, BidVolume3: 800, BidVolume4: 900, BidVolume5: 1000,
+		TotalAskVol: 1500,
+		TotalBidVol: 4000,
+		Timestamp:   time.Now(),
+	})
+
+	req, _ := http.NewRequest("GET", "/api/v1/stocks/005930/orderbook", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		OrderBook services.OrderBook `json:"orderbook"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+	book := response.OrderBook
+	suite.Require().Len(book.Asks, 5)
+	suite.Require().Len(book.Bids, 5)
+	assert.Equal(suite.T(), services.OrderBookLevel{Price: 55600, Volume: 100}, book.Asks[0])
+	assert.Equal(suite.T(), services.OrderBookLevel{Price: 55100, Volume: 1000}, book.Bids[4])
+	assert.Equal(suite.T(), int64(1500), book.TotalAskVolume)
+	assert.Equal(suite.T(), int64(4000), book.TotalBidVolume)
+	assert.InDelta(suite.T(), 2500.0/5500.0, book.Imbalance, 1e-9)
+
+	req, _ = http.NewRequest("GET", "/api/v1/stocks/UNKNOWN/orderbook", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func TestOrderBookVote(t *testing.T) {
+	now := time.Now()
+	book := func(bid, ask int64, age time.Duration) *services.OrderBook {
+		return services.NewOrderBook(&models.AskingPrice{
+			Symbol:      "005930",
+			TotalBidVol: bid,
+			TotalAskVol: ask,
+			Timestamp:   now.Add(-age),
+		})
+	}
+
+	assert.Equal(t, 1, services.OrderBookVote(book(8000, 2000, time.Minute), now), "bid-heavy book is a bullish vote")
+	assert.Equal(t, 0, services.OrderBookVote(book(5000, 4800, time.Minute), now), "balanced book has no vote")
+	assert.Equal(t, -1, services.OrderBookVote(book(1000, 9000, time.Minute), now), "ask-heavy book is a bearish vote")
+	assert.Equal(t, 0, services.OrderBookVote(book(8000, 2000, time.Hour), now), "stale book is ignored")
+	assert.Equal(t, 0, services.OrderBookVote(nil, now))
+}
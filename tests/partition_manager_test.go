@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePartitionInterval(t *testing.T) {
+	interval, err := services.ParsePartitionInterval("")
+	require.NoError(t, err)
+	assert.Equal(t, services.PartitionMonthly, interval)
+
+	interval, err = services.ParsePartitionInterval(" Weekly ")
+	require.NoError(t, err)
+	assert.Equal(t, services.PartitionWeekly, interval)
+
+	_, err = services.ParsePartitionInterval("hourly")
+	assert.Error(t, err)
+}
+
+func TestPartitionRangeBoundaries(t *testing.T) {
+	// 2024-12-30 (월) 은 ISO 2025년 1주차, 2025-01-01 은 수요일
+	from := time.Date(2025, 1, 1, 13, 45, 0, 0, time.UTC)
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		interval services.PartitionInterval
+		expected []services.PartitionRange
+	}{
+		{services.PartitionDaily, []services.PartitionRange{
+			{Name: "stock_prices_2025_01_01", Start: day(2025, 1, 1), End: day(2025, 1, 2)},
+			{Name: "stock_prices_2025_01_02", Start: day(2025, 1, 2), End: day(2025, 1, 3)},
+			{Name: "stock_prices_2025_01_03", Start: day(2025, 1, 3), End: day(2025, 1, 4)},
+		}},
+		{services.PartitionWeekly, []services.PartitionRange{
+			{Name: "stock_prices_2025_w01", Start: day(2024, 12, 30), End: day(2025, 1, 6)},
+			{Name: "stock_prices_2025_w02", Start: day(2025, 1, 6), End: day(2025, 1, 13)},
+			{Name: "stock_prices_2025_w03", Start: day(2025, 1, 13), End: day(2025, 1, 20)},
+		}},
+		{services.PartitionMonthly, []services.PartitionRange{
+			{Name: "stock_prices_2025_01", Start: day(2025, 1, 1), End: day(2025, 2, 1)},
+			{Name: "stock_prices_2025_02", Start: day(2025, 2, 1), End: day(2025, 3, 1)},
+			{Name: "stock_prices_2025_03", Start: day(2025, 3, 1), End: day(2025, 4, 1)},
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.interval), func(t *testing.T) {
+			ranges := services.PartitionRanges(tc.interval, from, len(tc.expected))
+			require.Len(t, ranges, len(tc.expected))
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected.Name, ranges[i].Name)
+				assert.True(t, expected.Start.Equal(ranges[i].Start), "%s start = %v", expected.Name, ranges[i].Start)
+				assert.True(t, expected.End.Equal(ranges[i].End), "%s end = %v", expected.Name, ranges[i].End)
+				assert.Equal(t, tc.interval, ranges[i].Interval)
+
+				// 정리 작업이 이름만으로 같은 구간을 복원할 수 있어야 한다
+				parsed, ok := services.ParsePartitionName(ranges[i].Name)
+				require.True(t, ok, ranges[i].Name)
+				assert.Equal(t, ranges[i], parsed)
+			}
+		})
+	}
+}
+
+func TestParsePartitionName(t *testing.T) {
+	parsed, ok := services.ParsePartitionName("stock_prices_2024_01")
+	require.True(t, ok)
+	assert.Equal(t, services.PartitionMonthly, parsed.Interval)
+
+	// 53주가 있는 해
+	parsed, ok = services.ParsePartitionName("stock_prices_2020_w53")
+	require.True(t, ok)
+	assert.True(t, parsed.Start.Equal(time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC)))
+
+	for _, name := range []string{
+		"stock_prices",
+		"stock_prices_2024_13",
+		"stock_prices_2024_02_30",
+		"stock_prices_2024_w00",
+		"stock_prices_2023_w53", // 2023 년은 52주
+		"stock_prices_2024_w5",
+		"stock_prices_2024_01_extra",
+	} {
+		_, ok := services.ParsePartitionName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestPlanPartitionsFillsGapsAfterIntervalChange(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	from := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	names := func(ranges []services.PartitionRange) []string {
+		var result []string
+		for _, r := range ranges {
+			result = append(result, r.Name)
+		}
+		return result
+	}
+
+	// 기존 파티션이 없으면 PartitionRanges 와 같다
+	assert.Equal(t, services.PartitionRanges(services.PartitionMonthly, from, 3),
+		services.PlanPartitions(services.PartitionMonthly, nil, from, 3))
+
+	// 주 단위(1/20 까지 생성) → 월 단위: 2월 경계까지 일 단위로 채운 뒤 월 파티션
+	weekly := services.PartitionRanges(services.PartitionWeekly, from, 3)
+	planned := services.PlanPartitions(services.PartitionMonthly, weekly, from, 3)
+	require.Len(t, planned, 14)
+	assert.Equal(t, "stock_prices_2025_01_20", planned[0].Name)
+	assert.Equal(t, "stock_prices_2025_01_31", planned[11].Name)
+	assert.Equal(t, []string{"stock_prices_2025_02", "stock_prices_2025_03"}, names(planned[12:]))
+
+	// 월 단위(2월까지 생성) → 주 단위: 월 파티션과 겹치는 주의 나머지 날은 일 단위로, 3/3 주부터 다시 주 단위
+	monthly := services.PartitionRanges(services.PartitionMonthly, from, 2)
+	planned = services.PlanPartitions(services.PartitionWeekly, monthly, from, 12)
+	assert.Equal(t, []string{
+		"stock_prices_2024_12_30", "stock_prices_2024_12_31",
+		"stock_prices_2025_03_01", "stock_prices_2025_03_02",
+		"stock_prices_2025_w10", "stock_prices_2025_w11", "stock_prices_2025_w12",
+	}, names(planned))
+	assert.True(t, planned[4].Start.Equal(day(3, 3)))
+
+	// 빈틈과 겹침이 없어야 한다
+	all := append(append([]services.PartitionRange{}, monthly...), planned...)
+	for cursor := services.PartitionWeekly.Start(from); cursor.Before(day(3, 24)); cursor = cursor.AddDate(0, 0, 1) {
+		covering := 0
+		for _, r := range all {
+			if !cursor.Before(r.Start) && cursor.Before(r.End) {
+				covering++
+			}
+		}
+		assert.Equal(t, 1, covering, cursor.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/workers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPeriodBarStore 받은 종목마다 기간 마지막 날짜의 봉 하나를 저장한 것처럼 기록 (fail 이면 모두 실패)
+type recordingPeriodBarStore struct {
+	bars  []models.StockPrice
+	now   *time.Time
+	fail  bool
+	calls int
+}
+
+func (s *recordingPeriodBarStore) CollectPeriodBars(_ context.Context, stock models.Stock, granularity string) error {
+	s.calls++
+	if s.fail {
+		return errors.New("chart API unavailable")
+	}
+	s.bars = append(s.bars, models.StockPrice{
+		Symbol:      stock.Symbol,
+		Market:      stock.Market,
+		Granularity: granularity,
+		Timestamp:   *s.now,
+	})
+	return nil
+}
+
+func (s *recordingPeriodBarStore) symbols(granularity string) []string {
+	var symbols []string
+	for _, bar := range s.bars {
+		if bar.Granularity == granularity {
+			symbols = append(symbols, bar.Symbol)
+		}
+	}
+	return symbols
+}
+
+func newPeriodCloseFixture(t *testing.T) (*workers.PeriodCloseScheduler, *recordingPeriodBarStore, *time.Time) {
+	session, err := workers.NewMarketSession("US", "America/New_York", "16:00")
+	require.NoError(t, err)
+
+	source := &fakeMarketSymbolSource{stocks: []models.Stock{
+		{Symbol: "AAPL", Market: "US", IsActive: true},
+		{Symbol: "005930", Market: "KR", IsActive: true},
+		{Symbol: "MSFT", Market: "US", IsActive: true},
+	}}
+	now := new(time.Time)
+	store := &recordingPeriodBarStore{now: now}
+	return workers.NewPeriodCloseScheduler(source, store, []workers.MarketSession{session}), store, now
+}
+
+func TestPeriodCloseRefreshesWeeklyBarsAtWeekClose(t *testing.T) {
+	scheduler, store, now := newPeriodCloseFixture(t)
+	newYork, _ := time.LoadLocation("America/New_York")
+	tick := func(at time.Time) []string {
+		*now = at
+		return scheduler.Tick(at)
+	}
+
+	// 처음 도는 Tick 은 가장 최근에 끝난 주(5/31 마감)와 월(5월)을 따라잡는다
+	assert.Equal(t, []string{"US/weekly", "US/monthly"}, tick(time.Date(2024, 6, 6, 16, 30, 0, 0, newYork)))
+	store.bars = nil
+
+	// 2024-06-06(목) 마감 후 - 금요일이 남아 있으므로 이번 주는 아직 끝나지 않았다
+	assert.Empty(t, tick(time.Date(2024, 6, 6, 17, 0, 0, 0, newYork)))
+	// 2024-06-07(금) 마감 전
+	assert.Empty(t, tick(time.Date(2024, 6, 7, 15, 59, 0, 0, newYork)))
+	assert.Empty(t, store.bars)
+
+	// 주의 마지막 거래일 마감 후 US 활성 종목의 주봉을 저장
+	weekClose := time.Date(2024, 6, 7, 16, 1, 0, 0, newYork)
+	assert.Equal(t, []string{"US/weekly"}, tick(weekClose))
+	assert.Equal(t, []string{"AAPL", "MSFT"}, store.symbols(models.GranularityWeekly))
+	for _, bar := range store.bars {
+		assert.Equal(t, weekClose, bar.Timestamp)
+	}
+
+	// 같은 주에는 다시 돌지 않는다 (주말 포함)
+	assert.Empty(t, tick(time.Date(2024, 6, 7, 20, 0, 0, 0, newYork)))
+	assert.Empty(t, tick(time.Date(2024, 6, 8, 16, 30, 0, 0, newYork)))
+	assert.Len(t, store.bars, 2)
+
+	// 다음 주 금요일은 다시 주 마감
+	assert.Equal(t, []string{"US/weekly"}, tick(time.Date(2024, 6, 14, 16, 5, 0, 0, newYork)))
+	assert.Len(t, store.bars, 4)
+	assert.Empty(t, store.symbols(models.GranularityMonthly))
+}
+
+func TestPeriodCloseFollowsMarketCalendar(t *testing.T) {
+	scheduler, store, now := newPeriodCloseFixture(t)
+	newYork, _ := time.LoadLocation("America/New_York")
+	tick := func(at time.Time) []string {
+		*now = at
+		return scheduler.Tick(at)
+	}
+
+	// 2024-03-29 성금요일 휴장 → 3/28(목)이 주와 월의 마지막 거래일
+	assert.Equal(t, []string{"US/weekly", "US/monthly"}, tick(time.Date(2024, 3, 28, 16, 0, 0, 0, newYork)))
+	assert.Equal(t, []string{"AAPL", "MSFT"}, store.symbols(models.GranularityMonthly))
+	assert.Empty(t, tick(time.Date(2024, 3, 29, 16, 0, 0, 0, newYork)))
+
+	// 임시 휴장일을 넣은 달력으로 바꾸면 그 전 거래일에 주 마감 처리 (5월은 아직 갱신하지 않았으므로 함께)
+	calendar := apimodels.NewMarketCalendar()
+	calendar.AddHolidays("US", "2024-06-07")
+	scheduler.WithCalendar(calendar)
+	assert.Equal(t, []string{"US/weekly", "US/monthly"}, tick(time.Date(2024, 6, 6, 16, 0, 0, 0, newYork)))
+	assert.Empty(t, tick(time.Date(2024, 6, 7, 16, 0, 0, 0, newYork)))
+}
+
+func TestPeriodCloseRetriesFailedAndMissedPeriods(t *testing.T) {
+	scheduler, store, now := newPeriodCloseFixture(t)
+	newYork, _ := time.LoadLocation("America/New_York")
+	tick := func(at time.Time) []string {
+		*now = at
+		return scheduler.Tick(at)
+	}
+	require.Equal(t, []string{"US/weekly", "US/monthly"}, tick(time.Date(2024, 6, 3, 16, 30, 0, 0, newYork)))
+	store.bars, store.calls = nil, 0
+
+	// 6/7(금) 마감 시각에 내려가 있었어도 주말에 놓친 주를 따라잡는다. 모두 실패하면 처리한 것으로 기록하지 않는다
+	store.fail = true
+	saturday := time.Date(2024, 6, 8, 10, 0, 0, 0, newYork)
+	assert.Empty(t, tick(saturday))
+	assert.Equal(t, 2, store.calls)
+
+	// 재시도 대기 중에는 API 를 다시 부르지 않는다
+	assert.Empty(t, tick(saturday.Add(5*time.Minute)))
+	assert.Equal(t, 2, store.calls)
+
+	store.fail = false
+	assert.Equal(t, []string{"US/weekly"}, tick(saturday.Add(16*time.Minute)))
+	assert.Equal(t, []string{"AAPL", "MSFT"}, store.symbols(models.GranularityWeekly))
+	assert.Empty(t, tick(saturday.Add(time.Hour)))
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPriceBookConcurrentUpdatesKeepLatest 수집기 여러 개가 순서 없이 갱신하고 API/스트림이 동시에 읽어도
+// 가격 북은 종목마다 가장 최근 시각의 가격을 유지한다 (go test -race 로 실행)
+func TestPriceBookConcurrentUpdatesKeepLatest(t *testing.T) {
+	const (
+		symbols = 16
+		updates = 200
+		writers = 8
+	)
+	book := services.NewPriceBook(symbols, time.Hour)
+	base := time.Date(2024, 6, 7, 9, 30, 0, 0, time.UTC)
+	symbol := func(n int) string { return fmt.Sprintf("BOOK%02d", n) }
+
+	stream, cancel := book.Subscribe(16)
+	streamed := make(chan int)
+	go func() {
+		count := 0
+		for range stream {
+			count++
+		}
+		streamed <- count
+	}()
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			seen := make(map[string]time.Time)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for n := 0; n < symbols; n++ {
+					price, ok := book.Latest(symbol(n))
+					if !ok {
+						continue
+					}
+					// 가격과 시각은 같은 갱신에서 온 값이고, 한 번 본 시각보다 과거로 돌아가지 않는다
+					assert.Equal(t, float64(price.Timestamp.Sub(base)/time.Second), price.ClosePrice)
+					assert.False(t, price.Timestamp.Before(seen[price.Symbol]), price.Symbol)
+					seen[price.Symbol] = price.Timestamp
+				}
+				book.Snapshot()
+			}
+		}()
+	}
+
+	var writersDone sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		writersDone.Add(1)
+		go func(w int) {
+			defer writersDone.Done()
+			rng := rand.New(rand.NewSource(int64(w)))
+			for _, i := range rng.Perm(updates * symbols) {
+				if i%writers != w {
+					continue
+				}
+				step := i / symbols
+				book.Update(models.StockPrice{
+					Symbol:     symbol(i % symbols),
+					Market:     "US",
+					ClosePrice: float64(step),
+					Timestamp:  base.Add(time.Duration(step) * time.Second),
+				})
+			}
+		}(w)
+	}
+	writersDone.Wait()
+	close(done)
+	readers.Wait()
+	cancel()
+
+	assert.Equal(t, symbols, book.Len())
+	for n := 0; n < symbols; n++ {
+		price, ok := book.Latest(symbol(n))
+		require.True(t, ok, symbol(n))
+		assert.Equal(t, float64(updates-1), price.ClosePrice, symbol(n))
+		assert.Equal(t, base.Add((updates-1)*time.Second), price.Timestamp, symbol(n))
+	}
+	assert.Positive(t, <-streamed)
+}
+
+func TestPriceBookEvictsInactiveSymbols(t *testing.T) {
+	now := time.Date(2024, 6, 7, 9, 30, 0, 0, time.UTC)
+	book := services.NewPriceBook(2, 10*time.Minute).WithClock(func() time.Time { return now })
+	update := func(symbol string, closePrice float64) bool {
+		return book.Update(models.StockPrice{Symbol: symbol, ClosePrice: closePrice, Timestamp: now})
+	}
+
+	require.True(t, update("AAPL", 1))
+	now = now.Add(time.Minute)
+	require.True(t, update("MSFT", 2))
+	now = now.Add(time.Minute)
+	require.True(t, update("AAPL", 3))
+
+	// 가득 차면 가장 오래 갱신되지 않은 종목을 뺀다
+	now = now.Add(time.Minute)
+	require.True(t, update("TSLA", 4))
+	assert.Equal(t, 2, book.Len())
+	_, ok := book.Latest("MSFT")
+	assert.False(t, ok)
+	price, ok := book.Latest("AAPL")
+	require.True(t, ok)
+	assert.Equal(t, 3.0, price.ClosePrice)
+
+	// 이전 시각의 가격은 최신 가격을 덮어쓰지 않는다
+	assert.False(t, book.Update(models.StockPrice{Symbol: "AAPL", ClosePrice: 0, Timestamp: now.Add(-time.Hour)}))
+
+	// idle 동안 갱신이 없으면 조회되지 않고 EvictIdle 로 빠진다
+	now = now.Add(9 * time.Minute)
+	require.True(t, update("TSLA", 5))
+	now = now.Add(2 * time.Minute)
+	_, ok = book.Latest("AAPL")
+	assert.False(t, ok)
+	assert.Len(t, book.Snapshot(), 1)
+	assert.Equal(t, 1, book.EvictIdle())
+	assert.Equal(t, 1, book.Len())
+}
+
+func (suite *IntegrationTestSuite) TestDeactivatedStockLeavesPriceBook() {
+	suite.db.Create(&models.Stock{Symbol: "BOOKOFF", Name: "Book", Market: "KR", IsActive: true})
+	services.DefaultPriceBook.Update(models.StockPrice{Symbol: "BOOKOFF", ClosePrice: 100, Timestamp: time.Now()})
+	_, ok := services.DefaultPriceBook.Latest("BOOKOFF")
+	suite.Require().True(ok)
+
+	// 비활성화하면 idle 을 기다리지 않고 바로 가격 북에서 빠진다
+	req, _ := http.NewRequest("PUT", "/api/v1/admin/stocks/BOOKOFF/status", strings.NewReader(`{"is_active": false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	_, ok = services.DefaultPriceBook.Latest("BOOKOFF")
+	assert.False(suite.T(), ok)
+}
+
+func TestPriceStreamSendsBookAndUpdates(t *testing.T) {
+	book := services.NewPriceBook(10, time.Hour)
+	book.Update(models.StockPrice{Symbol: "AAPL", ClosePrice: 190, Timestamp: time.Now()})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/prices/stream", handlers.NewStockHandler(nil, nil).WithPriceBook(book).StreamPrices)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	ctx, stop := context.WithTimeout(context.Background(), 5*time.Second)
+	defer stop()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/prices/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	lines := bufio.NewScanner(resp.Body)
+	nextPrice := func() string {
+		for lines.Scan() {
+			if data, ok := strings.CutPrefix(lines.Text(), "data:"); ok {
+				return data
+			}
+		}
+		return ""
+	}
+
+	// 연결하면 가격 북 내용부터, 이어서 갱신된 가격
+	assert.Contains(t, nextPrice(), `"symbol":"AAPL"`)
+	book.Update(models.StockPrice{Symbol: "MSFT", ClosePrice: 420, Timestamp: time.Now()})
+	data := nextPrice()
+	assert.Contains(t, data, `"symbol":"MSFT"`)
+	assert.Contains(t, data, `"close_price":420`)
+}
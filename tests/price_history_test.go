@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"stock-recommender/backend/models"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type priceHistoryResponse struct {
+	Prices     []models.StockPrice `json:"prices"`
+	NextCursor *string             `json:"next_cursor"`
+}
+
+func (suite *IntegrationTestSuite) getPriceHistory(query url.Values) priceHistoryResponse {
+	req, _ := http.NewRequest("GET", "/api/v1/stocks/PAGE01/prices?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var response priceHistoryResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func (suite *IntegrationTestSuite) TestPriceHistoryCursorPagination() {
+	start := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	var want []uint
+	for i := 0; i < 23; i++ {
+		// 두 봉씩 같은 시각을 갖도록 해 페이지 경계에서 시각이 겹치는 경우도 확인
+		price := models.StockPrice{
+			Symbol:      "PAGE01",
+			Market:      "KR",
+			ClosePrice:  float64(1000 + i),
+			Granularity: models.GranularityIntraday,
+			Timestamp:   start.Add(time.Duration(i/2) * time.Minute),
+		}
+		suite.Require().NoError(suite.db.Create(&price).Error)
+		want = append([]uint{price.ID}, want...) // 최신순
+	}
+	// 다른 단위의 봉은 interval 필터로 제외된다
+	suite.Require().NoError(suite.db.Create(&models.StockPrice{
+		Symbol: "PAGE01", Market: "KR", Granularity: models.GranularityDaily, Timestamp: start,
+	}).Error)
+
+	var got []uint
+	query := url.Values{"interval": {"intraday"}, "limit": {"5"}}
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 10, "pagination did not terminate")
+
+		response := suite.getPriceHistory(query)
+		suite.Require().LessOrEqual(len(response.Prices), 5)
+		for _, price := range response.Prices {
+			got = append(got, price.ID)
+		}
+
+		if response.NextCursor == nil {
+			break
+		}
+		query.Set("cursor", *response.NextCursor)
+	}
+
+	// 빠지거나 중복된 행 없이 최신순으로 전부 조회된다
+	assert.Equal(suite.T(), want, got)
+}
+
+func (suite *IntegrationTestSuite) TestPriceHistoryLastPageHasNoCursor() {
+	price := models.StockPrice{Symbol: "PAGE01", Market: "KR", Granularity: models.GranularityDaily, Timestamp: time.Now()}
+	suite.Require().NoError(suite.db.Create(&price).Error)
+
+	response := suite.getPriceHistory(url.Values{"limit": {"1"}})
+
+	suite.Require().Len(response.Prices, 1)
+	assert.Equal(suite.T(), price.ID, response.Prices[0].ID)
+	assert.Nil(suite.T(), response.NextCursor)
+}
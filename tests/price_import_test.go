@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOHLC(t *testing.T) {
+	valid := models.StockPrice{OpenPrice: 100, HighPrice: 105, LowPrice: 98, ClosePrice: 103, Volume: 1000}
+	assert.NoError(t, services.ValidateOHLC(valid))
+
+	cases := map[string]func(*models.StockPrice){
+		"high below close": func(p *models.StockPrice) { p.HighPrice = 101 },
+		"low above open":   func(p *models.StockPrice) { p.LowPrice = 100.5 },
+		"low above high":   func(p *models.StockPrice) { p.LowPrice = 110 },
+		"zero price":       func(p *models.StockPrice) { p.OpenPrice = 0 },
+		"negative volume":  func(p *models.StockPrice) { p.Volume = -1 },
+	}
+	for name, mutate := range cases {
+		bar := valid
+		mutate(&bar)
+		assert.Error(t, services.ValidateOHLC(bar), name)
+	}
+}
+
+func (suite *IntegrationTestSuite) TestImportPricesCSV() {
+	suite.db.Create(&models.Stock{Symbol: "IMPORT1", Name: "Import", Market: "KR", IsActive: true})
+
+	// 이미 같은 값으로 저장된 일봉은 건너뛴다
+	suite.db.Create(&models.StockPrice{
+		Symbol: "IMPORT1", Market: "KR", Granularity: models.GranularityDaily,
+		OpenPrice: 100, HighPrice: 104, LowPrice: 99, ClosePrice: 103, Volume: 1500,
+		Timestamp: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+	})
+	// 시장 현지 자정에 저장된 일봉도 같은 날짜로 본다
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	suite.db.Create(&models.StockPrice{
+		Symbol: "IMPORT1", Market: "KR", Granularity: models.GranularityDaily,
+		OpenPrice: 103, HighPrice: 106, LowPrice: 102, ClosePrice: 105, Volume: 1800,
+		Timestamp: time.Date(2024, 3, 5, 0, 0, 0, 0, seoul),
+	})
+	// 같은 날짜의 장중 스냅샷이 있어도 일봉은 따로 저장한다
+	suite.db.Create(&models.StockPrice{
+		Symbol: "IMPORT1", Market: "KR", Granularity: models.GranularityIntraday,
+		OpenPrice: 102, HighPrice: 103, LowPrice: 100, ClosePrice: 100.5, Volume: 900,
+		Timestamp: time.Date(2024, 3, 7, 13, 0, 0, 0, seoul),
+	})
+
+	csv := "symbol,date,open,high,low,close,volume\n" +
+		"IMPORT1,2024-03-04,100,104,99,103,1500\n" + // 기존과 동일 → skipped
+		"IMPORT1,2024-03-05,103,106,102,105,1800\n" + // 현지 자정 일봉과 동일 → skipped
+		"IMPORT1,2024-03-06,105,104,101,102,1700\n" + // 고가 < 시가 → rejected
+		"IMPORT1,2024-03-07,102,103,100,101,1600\n" // 장중 스냅샷만 있음 → imported
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "prices.csv")
+	suite.Require().NoError(err)
+	part.Write([]byte(csv))
+	suite.Require().NoError(writer.Close())
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/import/prices", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var response struct {
+		Import services.PriceImportReport `json:"import"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 1, response.Import.Imported)
+	assert.Equal(suite.T(), 2, response.Import.Skipped)
+	assert.Equal(suite.T(), 1, response.Import.Rejected)
+	suite.Require().Len(response.Import.Rejections, 1)
+	assert.Equal(suite.T(), 4, response.Import.Rejections[0].Line)
+
+	var count int64
+	suite.db.Model(&models.StockPrice{}).
+		Where("symbol = ? AND granularity = ?", "IMPORT1", models.GranularityDaily).
+		Count(&count)
+	assert.Equal(suite.T(), int64(3), count)
+
+	var intraday models.StockPrice
+	suite.Require().NoError(suite.db.Where("symbol = ? AND granularity = ?", "IMPORT1", models.GranularityIntraday).First(&intraday).Error)
+	assert.Equal(suite.T(), 100.5, intraday.ClosePrice)
+
+	// 같은 파일을 다시 올리면 모두 건너뛴다 (업서트)
+	req, _ = http.NewRequest("POST", "/api/v1/admin/import/prices", bytes.NewBufferString(csv))
+	req.Header.Set("Content-Type", "text/csv")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 0, response.Import.Imported)
+	assert.Equal(suite.T(), 3, response.Import.Skipped)
+	assert.Equal(suite.T(), 1, response.Import.Rejected)
+}
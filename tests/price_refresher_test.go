@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingPriceFetcher struct {
+	mu      sync.Mutex
+	symbols []string
+}
+
+func (f *recordingPriceFetcher) FetchCurrentPrice(symbol, market string) (*apimodels.ParsedStockPrice, error) {
+	f.mu.Lock()
+	f.symbols = append(f.symbols, symbol)
+	f.mu.Unlock()
+
+	return &apimodels.ParsedStockPrice{
+		Symbol:       symbol,
+		Market:       market,
+		CurrentPrice: 100,
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+type recordingPricePublisher struct {
+	symbols []string
+}
+
+func (p *recordingPricePublisher) PublishPriceUpdate(symbol, market string, data interface{}) error {
+	p.symbols = append(p.symbols, symbol)
+	return nil
+}
+
+func (suite *IntegrationTestSuite) TestPriceRefresherOnlyRefreshesWatchedSymbols() {
+	suite.db.Exec("TRUNCATE TABLE watchlist_items RESTART IDENTITY CASCADE")
+
+	stocks := []models.Stock{
+		{Symbol: "WATCH1", Name: "Watched", Market: "KR", IsActive: true},
+		{Symbol: "PLAIN1", Name: "Not watched", Market: "KR", IsActive: true},
+		{Symbol: "WATCH2", Name: "Watched but inactive", Market: "US", IsActive: true},
+	}
+	for _, stock := range stocks {
+		suite.db.Create(&stock)
+	}
+	suite.db.Model(&models.Stock{}).Where("symbol = ?", "WATCH2").Update("is_active", false)
+
+	suite.db.Create(&models.WatchlistItem{Symbol: "WATCH1", Market: "KR"})
+	suite.db.Create(&models.WatchlistItem{Symbol: "WATCH2", Market: "US"})
+
+	fetcher := &recordingPriceFetcher{}
+	publisher := &recordingPricePublisher{}
+	collector := services.NewDataCollectorService(suite.db, suite.cfg)
+	refresher := workers.NewPriceRefresher(services.NewWatchlistService(suite.db), fetcher, collector, publisher, time.Minute)
+
+	// 2024-06-03(월) 10:00 KST, 국내 장 중
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	refreshed := refresher.RefreshOnce(time.Date(2024, 6, 3, 10, 0, 0, 0, seoul))
+
+	assert.Equal(suite.T(), 1, refreshed)
+	assert.Equal(suite.T(), []string{"WATCH1"}, fetcher.symbols)
+	assert.Equal(suite.T(), []string{"WATCH1"}, publisher.symbols)
+
+	var count int64
+	suite.db.Model(&models.StockPrice{}).Where("symbol = ?", "WATCH1").Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func TestPriceRefresherSkipsClosedMarkets(t *testing.T) {
+	source := &fakeWatchedSymbols{stocks: []models.Stock{
+		{Symbol: "005930", Market: "KR"},
+		{Symbol: "AAPL", Market: "US"},
+	}}
+	fetcher := &recordingPriceFetcher{}
+	refresher := workers.NewPriceRefresher(source, fetcher, discardPriceStore{}, &recordingPricePublisher{}, time.Minute)
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+
+	// 국내 장 중에는 미국 장이 닫혀 있다
+	assert.Equal(t, 1, refresher.RefreshOnce(time.Date(2024, 6, 3, 10, 0, 0, 0, seoul)))
+	assert.Equal(t, []string{"005930"}, fetcher.symbols)
+
+	// 2024-06-03 23:00 KST = 뉴욕 10:00, 미국 장 중
+	fetcher.symbols = nil
+	assert.Equal(t, 1, refresher.RefreshOnce(time.Date(2024, 6, 3, 23, 0, 0, 0, seoul)))
+	assert.Equal(t, []string{"AAPL"}, fetcher.symbols)
+
+	// 현충일(2024-06-06) 국내 휴장, 주말은 두 시장 모두 휴장
+	fetcher.symbols = nil
+	assert.Zero(t, refresher.RefreshOnce(time.Date(2024, 6, 6, 10, 0, 0, 0, seoul)))
+	assert.Zero(t, refresher.RefreshOnce(time.Date(2024, 6, 8, 23, 0, 0, 0, seoul)))
+	assert.Empty(t, fetcher.symbols)
+}
+
+type fakeWatchedSymbols struct {
+	stocks []models.Stock
+}
+
+func (s *fakeWatchedSymbols) WatchedSymbols() ([]models.Stock, error) {
+	return s.stocks, nil
+}
+
+type discardPriceStore struct{}
+
+func (discardPriceStore) SaveStockPrice(*apimodels.ParsedStockPrice) error { return nil }
+
+func (suite *IntegrationTestSuite) TestWatchlistEndpointsMatchSymbolAndMarket() {
+	suite.db.Exec("TRUNCATE TABLE watchlist_items RESTART IDENTITY CASCADE")
+	suite.db.Create(&models.Stock{Symbol: "WATCH3", Name: "Watched", Market: "US", IsActive: true})
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	// 다른 시장으로 등록된 종목은 관심 종목에 넣을 수 없다
+	assert.Equal(suite.T(), http.StatusNotFound, send("POST", "/api/v1/admin/watchlist", `{"symbol": "WATCH3", "market": "KR"}`).Code)
+	assert.Equal(suite.T(), http.StatusBadRequest, send("POST", "/api/v1/admin/watchlist", `{"symbol": "WATCH3", "market": "MOON"}`).Code)
+
+	// 시장 별칭(NASDAQ)은 stocks 의 시장(US)으로 바꿔 저장하고, 두 번 넣어도 한 번만 저장한다
+	assert.Equal(suite.T(), http.StatusOK, send("POST", "/api/v1/admin/watchlist", `{"symbol": "WATCH3", "market": "NASDAQ"}`).Code)
+	assert.Equal(suite.T(), http.StatusOK, send("POST", "/api/v1/admin/watchlist", `{"symbol": "WATCH3", "market": "US"}`).Code)
+
+	watched, err := services.NewWatchlistService(suite.db).WatchedSymbols()
+	suite.Require().NoError(err)
+	suite.Require().Len(watched, 1)
+	assert.Equal(suite.T(), "WATCH3", watched[0].Symbol)
+
+	// 시장이 다른 관심 종목 행은 종목과 짝지어지지 않는다
+	suite.db.Exec("UPDATE watchlist_items SET market = ? WHERE symbol = ?", "KR", "WATCH3")
+	watched, err = services.NewWatchlistService(suite.db).WatchedSymbols()
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), watched)
+	suite.db.Exec("UPDATE watchlist_items SET market = ? WHERE symbol = ?", "US", "WATCH3")
+
+	assert.Equal(suite.T(), http.StatusNotFound, send("DELETE", "/api/v1/admin/watchlist/WATCH3?market=KR", "").Code)
+	assert.Equal(suite.T(), http.StatusOK, send("DELETE", "/api/v1/admin/watchlist/WATCH3?market=US", "").Code)
+	watched, err = services.NewWatchlistService(suite.db).WatchedSymbols()
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), watched)
+}
@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/handlers"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueryParamsRouter(captured *handlers.QueryParams) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/params", func(c *gin.Context) {
+		params, _ := handlers.ParseQueryParams(c)
+		*captured = params
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestValidateQueryParamsRejectsBadInput(t *testing.T) {
+	var captured handlers.QueryParams
+	r := newQueryParamsRouter(&captured)
+
+	cases := []string{
+		"from=2024-13-01",
+		"to=yesterday",
+		"from=2024-06-30&to=2024-01-01",
+		"market=JP",
+		"interval=3min",
+		"limit=0",
+		"limit=abc",
+		"offset=-5",
+		"adjusted=maybe",
+		"cursor=2024-01-02",
+		"cursor=yesterday_12",
+		"cursor=2024-01-02T09:00:00Z_abc",
+	}
+
+	for _, query := range cases {
+		req, _ := http.NewRequest("GET", "/params?"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+
+		var response handlers.ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response), query)
+		assert.Equal(t, handlers.ErrCodeBadRequest, response.Error.Code, query)
+		assert.NotEmpty(t, response.Error.Message, query)
+	}
+}
+
+func TestValidateQueryParamsPassesValidInput(t *testing.T) {
+	var captured handlers.QueryParams
+	r := newQueryParamsRouter(&captured)
+
+	req, _ := http.NewRequest("GET", "/params?from=2024-01-01&to=20240630&market=kr&interval=daily&limit=10&offset=20&adjusted=false&cursor=2024-03-04T09:30:00.5Z_42&fields=date,%20close,,date", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, captured.From)
+	require.NotNil(t, captured.To)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *captured.From)
+	assert.Equal(t, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), *captured.To)
+	assert.Equal(t, "KR", captured.Market)
+	assert.Equal(t, "daily", captured.Interval)
+	assert.Equal(t, 10, captured.Limit)
+	assert.Equal(t, 20, captured.Offset)
+	require.NotNil(t, captured.Adjusted)
+	assert.False(t, *captured.Adjusted)
+	require.NotNil(t, captured.Cursor)
+	assert.Equal(t, handlers.Cursor{Timestamp: time.Date(2024, 3, 4, 9, 30, 0, 500000000, time.UTC), ID: 42}, *captured.Cursor)
+	assert.Equal(t, []string{"date", "close"}, captured.Fields)
+
+	// 파라미터가 없으면 제로값으로 통과
+	req, _ = http.NewRequest("GET", "/params", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, handlers.QueryParams{}, captured)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	kst := time.FixedZone("KST", 9*60*60)
+	cursor := handlers.Cursor{Timestamp: time.Date(2024, 3, 4, 18, 30, 0, 123456789, kst), ID: 7}
+
+	parsed, err := handlers.ParseCursor(cursor.String())
+	require.NoError(t, err)
+	assert.True(t, cursor.Timestamp.Equal(parsed.Timestamp))
+	assert.Equal(t, cursor.ID, parsed.ID)
+	assert.Equal(t, "2024-03-04T09:30:00.123456789Z_7", cursor.String())
+}
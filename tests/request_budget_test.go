@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/services"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBudgetCapsConcurrentHeavyEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const capacity = 3
+	budget := services.NewRequestBudget(capacity)
+	heavy := handlers.RequestBudgetMiddleware(budget)
+
+	var current, peak, served int32
+	work := func(c *gin.Context) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		atomic.AddInt32(&served, 1)
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	}
+
+	r := gin.New()
+	r.GET("/levels", heavy, work)
+	r.GET("/drawdown", heavy, work)
+	r.GET("/screener", heavy, work)
+	r.GET("/price", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+
+	paths := []string{"/levels", "/drawdown", "/screener"}
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, paths[i%len(paths)], nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}()
+	}
+
+	// 무거운 요청이 밀려 있어도 예산을 쓰지 않는 시세 조회는 바로 처리된다
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/price", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	wg.Wait()
+	assert.Equal(t, int32(30), served)
+	assert.LessOrEqual(t, peak, int32(capacity))
+	assert.Equal(t, 0, budget.InFlight())
+}
+
+func TestRequestBudgetServesWaitersInArrivalOrder(t *testing.T) {
+	budget := services.NewRequestBudget(1)
+	require.NoError(t, budget.Acquire(context.Background()))
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, budget.Acquire(context.Background()))
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+			budget.Release()
+		}()
+		// 대기열에 순서대로 들어가도록 간격을 둔다
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	budget.Release()
+	wg.Wait()
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+}
+
+func TestRequestBudgetCancelledWaiterLeavesQueue(t *testing.T) {
+	budget := services.NewRequestBudget(1)
+	require.NoError(t, budget.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, budget.Acquire(ctx), context.DeadlineExceeded)
+
+	// 취소된 대기자가 슬롯을 가져가지 않았으므로 반납 후 바로 다시 얻을 수 있다
+	budget.Release()
+	assert.Equal(t, 0, budget.InFlight())
+	require.NoError(t, budget.Acquire(context.Background()))
+	assert.Equal(t, 1, budget.InFlight())
+}
+
+func TestRequestBudgetMiddlewareReturnsUnavailableWhenCancelled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	budget := services.NewRequestBudget(1)
+	require.NoError(t, budget.Acquire(context.Background()))
+
+	r := gin.New()
+	r.GET("/levels", handlers.RequestBudgetMiddleware(budget), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/levels", nil).WithContext(ctx))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), handlers.ErrCodeUnavailable)
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartRequestCancellationAbortsUpstreamCall(t *testing.T) {
+	started := make(chan struct{}, 1)
+	aborted := make(chan struct{}, 1)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		// 본문을 다 읽어야 서버가 연결 종료를 감지해 요청 컨텍스트를 취소한다
+		io.Copy(io.Discard, r.Body)
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+			aborted <- struct{}{}
+		case <-time.After(10 * time.Second):
+			w.Write([]byte(`{"rsp_cd":"00000","Out":[]}`))
+		}
+	}))
+	defer upstream.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = upstream.URL
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/stocks/:symbol/chart/day", handlers.NewForeignChartHandler(client.NewDBSecClient(cfg), 0).GetDayChart)
+	api := httptest.NewServer(r)
+	defer api.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", api.URL+"/stocks/AAPL/chart/day?exchange=NASDAQ", nil)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream chart call was never made")
+	}
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upstream chart call was not cancelled after the client disconnected")
+	}
+}
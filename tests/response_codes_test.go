@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/openapi/client"
+	apierrors "stock-recommender/backend/openapi/errors"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCodeClassification(t *testing.T) {
+	cases := []struct {
+		code, message string
+		class         apierrors.ResponseClass
+	}{
+		{"00000", "정상 처리 되었습니다.", apierrors.ResponseSuccess},
+		{"IGW00201", "호출 거래건수를 초과하였습니다", apierrors.ResponseQuota},
+		{"IGW00123", "기간이 만료된 token 입니다", apierrors.ResponseAuth},
+		{"99999", "시스템 오류", apierrors.ResponseRetryable},
+		{"40570000", "일시적인 오류가 발생했습니다. 잠시 후 다시 시도하세요", apierrors.ResponseRetryable},
+		{"40310000", "종목코드를 확인하세요", apierrors.ResponseUnknown}, // 메시지의 단어만으로 입력 오류로 보지 않는다
+		{"40580000", "장운영시간이 아닙니다", apierrors.ResponseMarketClosed},
+		{"12345678", "알 수 없는 오류", apierrors.ResponseUnknown},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.class, apierrors.ResponseCodes.Classify(tc.code, tc.message), tc.code)
+	}
+
+	// 분류가 재시도/호출 한도/인증 판단에 쓰는 에러 코드로 이어진다
+	assert.NoError(t, apierrors.CheckResponse("00000", ""))
+	quota := apierrors.CheckResponse("IGW00201", "호출 거래건수를 초과하였습니다")
+	assert.True(t, apierrors.IsRateLimitError(quota))
+	assert.False(t, apierrors.IsRetryableError(quota))
+	auth := apierrors.CheckResponse("IGW00121", "")
+	assert.True(t, apierrors.IsAuthError(auth))
+	status, _ := handlers.StatusForError(auth)
+	assert.Equal(t, http.StatusBadGateway, status)
+	assert.True(t, apierrors.IsRetryableError(apierrors.CheckResponse("99999", "")))
+	unknown := apierrors.CheckResponse("12345678", "")
+	assert.False(t, apierrors.IsRetryableError(unknown))
+	status, _ = handlers.StatusForError(unknown)
+	assert.Equal(t, http.StatusBadGateway, status)
+	closed := apierrors.CheckResponse("40580000", "장운영시간이 아닙니다")
+	assert.True(t, apierrors.IsMarketClosedError(closed))
+	assert.False(t, apierrors.IsRetryableError(closed))
+	status, _ = handlers.StatusForError(closed)
+	assert.Equal(t, http.StatusConflict, status)
+
+	// 새 코드는 표에 등록하면 되고, 코드 규칙이 메시지 규칙보다 우선한다
+	table := apierrors.NewResponseCodeTable()
+	assert.Equal(t, apierrors.ResponseUnknown, table.Classify("IGW00999", "토큰"))
+	require.NoError(t, table.LoadRules("IGW00999=quota, 00000=success"))
+	assert.Equal(t, apierrors.ResponseQuota, table.Classify("IGW00999", "토큰"))
+	assert.NoError(t, table.Check("00000", ""))
+	require.NoError(t, table.LoadRules("40310000=invalid_input"))
+	invalid := table.Check("40310000", "종목코드를 확인하세요")
+	assert.False(t, apierrors.IsRetryableError(invalid))
+	status, _ = handlers.StatusForError(invalid)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Error(t, table.LoadRules("IGW00999=sometimes"))
+	assert.Error(t, table.LoadRules("quota"))
+}
+
+func TestQuotaResponseSurfacesAsRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"rsp_cd": "IGW00201", "rsp_msg": "호출 거래건수를 초과하였습니다"})
+	}))
+	defer server.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+	apiClient := client.NewDBSecClient(cfg)
+
+	_, err := apiClient.GetForeignStockPrice("AAPL", apimodels.ForeignMarketNASDAQ)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsRateLimitError(err))
+	assert.Contains(t, err.Error(), "IGW00201")
+
+	status, code := handlers.StatusForError(err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, string(apierrors.ErrCodeRateLimit), code)
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestScreenerFiltersByFundamentals() {
+	ptr := func(v float64) *float64 { return &v }
+	stocks := []models.Stock{
+		{Symbol: "VALUE1", Market: "KR", IsActive: true, PER: ptr(8), PBR: ptr(0.7)},
+		{Symbol: "VALUE2", Market: "KR", IsActive: true, PER: ptr(9.5), PBR: ptr(0.9)},
+		{Symbol: "PRICEY", Market: "KR", IsActive: true, PER: ptr(35), PBR: ptr(4.2)},
+		{Symbol: "CHEAPPE", Market: "KR", IsActive: true, PER: ptr(6), PBR: ptr(1.8)},
+		{Symbol: "LOSS", Market: "KR", IsActive: true, PER: ptr(-12), PBR: ptr(0.5)},
+		{Symbol: "NODATA", Market: "KR", IsActive: true},
+		{Symbol: "USVAL", Market: "US", IsActive: true, PER: ptr(7)},
+	}
+	for i := range stocks {
+		suite.db.Create(&stocks[i])
+	}
+
+	screen := func(query string) []string {
+		req, _ := http.NewRequest("GET", "/api/v1/screener?"+query, nil)
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var response struct {
+			Stocks []models.Stock `json:"stocks"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		symbols := make([]string, 0, len(response.Stocks))
+		for _, stock := range response.Stocks {
+			symbols = append(symbols, stock.Symbol)
+		}
+		return symbols
+	}
+
+	assert.Equal(suite.T(), []string{"CHEAPPE", "USVAL", "VALUE1", "VALUE2"}, screen("per_lt=10"))
+	assert.Equal(suite.T(), []string{"VALUE1", "VALUE2"}, screen("per_lt=10&pbr_lt=1"))
+	assert.Equal(suite.T(), []string{"CHEAPPE", "VALUE1", "VALUE2"}, screen("per_lt=10&market=KR"))
+
+	// 최신 신호가 BUY 인 종목만
+	suite.db.Create(&models.TradingSignal{Symbol: "VALUE1", SignalType: "SELL", Reasons: "[]", CreatedAt: time.Now().Add(-time.Hour)})
+	suite.db.Create(&models.TradingSignal{Symbol: "VALUE1", SignalType: "BUY", Reasons: "[]", CreatedAt: time.Now()})
+	suite.db.Create(&models.TradingSignal{Symbol: "VALUE2", SignalType: "SELL", Reasons: "[]", CreatedAt: time.Now()})
+	assert.Equal(suite.T(), []string{"VALUE1"}, screen("per_lt=10&pbr_lt=1&signal=BUY"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/screener?per_lt=abc", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
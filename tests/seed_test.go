@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestSeedDemoData() {
+	seeder := services.NewSeedService(suite.db, services.NewIndicatorService())
+	opts := services.SeedOptions{
+		Stocks:        services.DefaultSeedStocks(),
+		Bars:          60,
+		SignalSamples: 2,
+		EndDate:       time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+	}
+	stockCount := int64(len(opts.Stocks))
+
+	report, err := seeder.Seed(opts)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int(stockCount), report.Stocks)
+
+	assertCounts := func() {
+		var count int64
+		suite.db.Model(&models.Stock{}).Count(&count)
+		assert.Equal(suite.T(), stockCount, count, "stocks")
+
+		suite.db.Model(&models.StockPrice{}).Count(&count)
+		assert.Equal(suite.T(), stockCount*60, count, "stock_prices")
+
+		suite.db.Model(&models.TechnicalIndicator{}).Count(&count)
+		assert.Equal(suite.T(), stockCount*16, count, "technical_indicators")
+
+		suite.db.Model(&models.TradingSignal{}).Count(&count)
+		assert.Equal(suite.T(), stockCount*2, count, "trading_signals")
+	}
+	assertCounts()
+
+	// 두 번째 실행은 아무 행도 추가하지 않는다
+	report, err = seeder.Seed(opts)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), services.SeedReport{}, *report)
+	assertCounts()
+}
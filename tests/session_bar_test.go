@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionBarAggregatesMinuteBars(t *testing.T) {
+	aggregator := services.NewSessionBarAggregator(apimodels.DefaultMarketCalendar)
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	minute := func(day, hour, min int) time.Time { return time.Date(2024, 6, day, hour, min, 0, 0, seoul) }
+	bar := func(ts time.Time, open, high, low, close float64, volume int64) services.MinuteBar {
+		return services.MinuteBar{Symbol: "005930", Market: "KR", Timestamp: ts, Open: open, High: high, Low: low, Close: close, Volume: volume}
+	}
+
+	// 개장 전 분봉은 무시
+	_, ok := aggregator.Add(bar(minute(3, 8, 59), 70000, 70100, 69900, 70000, 10))
+	assert.False(t, ok)
+	_, ok = aggregator.Current("005930", "KR", minute(3, 9, 0))
+	assert.False(t, ok)
+
+	steps := []struct {
+		bar                    services.MinuteBar
+		open, high, low, close float64
+		volume                 int64
+	}{
+		{bar(minute(3, 9, 0), 71000, 71200, 70900, 71100, 100), 71000, 71200, 70900, 71100, 100},
+		{bar(minute(3, 9, 1), 71100, 71500, 71000, 71400, 50), 71000, 71500, 70900, 71400, 150},
+		{bar(minute(3, 9, 2), 71400, 71450, 70500, 70600, 80), 71000, 71500, 70500, 70600, 230},
+		{bar(minute(3, 15, 30), 70600, 70700, 70550, 70650, 20), 71000, 71500, 70500, 70650, 250},
+	}
+	for i, step := range steps {
+		session, ok := aggregator.Add(step.bar)
+		require.True(t, ok, "step %d", i)
+		assert.Equal(t, step.open, session.Open, "step %d open", i)
+		assert.Equal(t, step.high, session.High, "step %d high", i)
+		assert.Equal(t, step.low, session.Low, "step %d low", i)
+		assert.Equal(t, step.close, session.Close, "step %d close", i)
+		assert.Equal(t, step.volume, session.Volume, "step %d volume", i)
+		assert.Equal(t, i+1, session.Bars)
+	}
+
+	// 마감 후 분봉은 반영하지 않고, 마감 후에도 오늘 세션 일봉을 돌려준다
+	_, ok = aggregator.Add(bar(minute(3, 16, 0), 70650, 80000, 60000, 70000, 999))
+	assert.False(t, ok)
+	session, ok := aggregator.Current("005930", "KR", minute(3, 18, 0))
+	require.True(t, ok)
+	assert.Equal(t, 70650.0, session.Close)
+	assert.Equal(t, minute(3, 9, 0), session.OpenedAt)
+	assert.Equal(t, minute(3, 15, 30), session.UpdatedAt)
+
+	// 다음 거래일 개장 전에는 세션 없음, 개장 후 첫 분봉에서 새 세션 시작
+	_, ok = aggregator.Current("005930", "KR", minute(4, 8, 30))
+	assert.False(t, ok)
+	session, ok = aggregator.Add(bar(minute(4, 9, 0), 70800, 70900, 70700, 70750, 40))
+	require.True(t, ok)
+	assert.Equal(t, 70800.0, session.Open)
+	assert.Equal(t, int64(40), session.Volume)
+	assert.Equal(t, 1, session.Bars)
+
+	// 지난 세션의 늦게 도착한 분봉은 무시
+	_, ok = aggregator.Add(bar(minute(3, 15, 0), 1, 1, 1, 1, 1))
+	assert.False(t, ok)
+
+	// 휴장일(현충일) 분봉은 무시
+	_, ok = aggregator.Add(bar(minute(6, 10, 0), 70000, 70000, 70000, 70000, 1))
+	assert.False(t, ok)
+}
+
+func TestSessionOpenUsesMarketTimezone(t *testing.T) {
+	// UTC 13:30 = 뉴욕 09:30 (서머타임) 개장
+	open, ok := apimodels.DefaultMarketCalendar.SessionOpen(time.Date(2024, 6, 3, 13, 45, 0, 0, time.UTC), "NASDAQ")
+	require.True(t, ok)
+	assert.True(t, open.Equal(time.Date(2024, 6, 3, 13, 30, 0, 0, time.UTC)))
+
+	_, ok = apimodels.DefaultMarketCalendar.SessionOpen(time.Date(2024, 6, 3, 13, 29, 0, 0, time.UTC), "US")
+	assert.False(t, ok)
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMarketSymbolSource struct {
+	stocks []models.Stock
+}
+
+func (f *fakeMarketSymbolSource) ActiveStocks(market string) ([]models.Stock, error) {
+	var stocks []models.Stock
+	for _, stock := range f.stocks {
+		if stock.Market == market {
+			stocks = append(stocks, stock)
+		}
+	}
+	return stocks, nil
+}
+
+type recordingSwingGenerator struct {
+	symbols    []string
+	strategies []string
+}
+
+func (g *recordingSwingGenerator) GenerateSignalWithStrategy(symbol, market string, strategy services.SignalStrategy) (*models.TradingSignal, error) {
+	g.symbols = append(g.symbols, symbol)
+	g.strategies = append(g.strategies, strategy.Name)
+	if symbol == "BROKEN" {
+		return nil, errors.New("no daily bars")
+	}
+	return &models.TradingSignal{Symbol: symbol, SignalType: "HOLD"}, nil
+}
+
+func newSessionCloseFixture(t *testing.T) (*workers.SessionCloseScheduler, *recordingSwingGenerator) {
+	sessions, err := workers.DefaultMarketSessions("15:30", "16:00")
+	require.NoError(t, err)
+
+	source := &fakeMarketSymbolSource{stocks: []models.Stock{
+		{Symbol: "005930", Market: "KR", IsActive: true},
+		{Symbol: "BROKEN", Market: "KR", IsActive: true},
+		{Symbol: "000660", Market: "KR", IsActive: true},
+		{Symbol: "AAPL", Market: "US", IsActive: true},
+	}}
+	generator := &recordingSwingGenerator{}
+	return workers.NewSessionCloseScheduler(source, generator, sessions), generator
+}
+
+func TestSessionCloseTriggersAfterConfiguredClose(t *testing.T) {
+	scheduler, generator := newSessionCloseFixture(t)
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+
+	// 2024-06-03(월) 서울 15:29 - 마감 전
+	assert.Empty(t, scheduler.Tick(time.Date(2024, 6, 3, 15, 29, 0, 0, seoul)))
+	assert.Empty(t, generator.symbols)
+
+	// 마감 시각이 지나면 KR 종목만 일봉 스윙 전략으로 처리 (실패한 종목이 있어도 나머지는 계속)
+	assert.Equal(t, []string{"KR"}, scheduler.Tick(time.Date(2024, 6, 3, 15, 31, 0, 0, seoul)))
+	assert.Equal(t, []string{"005930", "BROKEN", "000660"}, generator.symbols)
+	for _, strategy := range generator.strategies {
+		assert.Equal(t, services.StrategySwing.Name, strategy)
+	}
+
+	// 같은 날에는 다시 돌지 않는다
+	assert.Empty(t, scheduler.Tick(time.Date(2024, 6, 3, 18, 0, 0, 0, seoul)))
+	assert.Len(t, generator.symbols, 3)
+}
+
+func TestSessionCloseUsesMarketTimezone(t *testing.T) {
+	scheduler, generator := newSessionCloseFixture(t)
+
+	// UTC 19:59 = 뉴욕 15:59 (서머타임), 서울은 다음 날 04:59 라 어느 시장도 마감 전
+	assert.Empty(t, scheduler.Tick(time.Date(2024, 6, 3, 19, 59, 0, 0, time.UTC)))
+
+	// UTC 20:00 = 뉴욕 16:00 → US 만 처리
+	assert.Equal(t, []string{"US"}, scheduler.Tick(time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"AAPL"}, generator.symbols)
+}
+
+func TestSessionCloseSkipsHolidays(t *testing.T) {
+	scheduler, generator := newSessionCloseFixture(t)
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+
+	// 2024-06-06 현충일 (KRX 휴장), 2024-06-08 토요일
+	assert.Empty(t, scheduler.Tick(time.Date(2024, 6, 6, 16, 0, 0, 0, seoul)))
+	assert.Empty(t, scheduler.Tick(time.Date(2024, 6, 8, 16, 0, 0, 0, seoul)))
+	assert.Empty(t, generator.symbols)
+
+	_, err := workers.NewMarketSession("KR", "Asia/Seoul", "3pm")
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestConcurrentSignalGenerationRespectsThrottle() {
+	const symbols = 12
+	start := time.Now().AddDate(0, 0, -59)
+	stocks := make([]models.Stock, symbols)
+	for n := range stocks {
+		stocks[n] = models.Stock{Symbol: fmt.Sprintf("BATCH%02d", n), Name: "Batch", Market: "KR", IsActive: true}
+		suite.Require().NoError(suite.db.Create(&stocks[n]).Error)
+		for i := 0; i < 60; i++ {
+			price := 100 + float64(i+n)
+			suite.Require().NoError(suite.db.Create(&models.StockPrice{
+				Symbol: stocks[n].Symbol, Market: "KR",
+				OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+				Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+			}).Error)
+		}
+	}
+
+	var inFlight, maxInFlight, calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(40 * time.Millisecond)
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: "BUY", Confidence: 0.7})
+	}))
+	defer server.Close()
+
+	// 초당 50회, 버스트 1: 12번 호출에 최소 220ms
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL, Timeout: 5 * time.Second, RateLimit: 50, RateBurst: 1}}
+	run := func(concurrency int) *services.SignalBatchReport {
+		atomic.StoreInt32(&maxInFlight, 0)
+		atomic.StoreInt32(&calls, 0)
+		generator := services.NewSignalGeneratorService(
+			suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), services.NewCacheService(suite.cfg), nil).
+			WithConcurrency(concurrency)
+		return generator.GenerateSignalsForStocks(stocks)
+	}
+
+	sequential := run(1)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&maxInFlight))
+
+	concurrent := run(4)
+	assert.Equal(suite.T(), int32(symbols), atomic.LoadInt32(&calls))
+	assert.LessOrEqual(suite.T(), atomic.LoadInt32(&maxInFlight), int32(4))
+	assert.Greater(suite.T(), atomic.LoadInt32(&maxInFlight), int32(1))
+	assert.Less(suite.T(), concurrent.Elapsed, sequential.Elapsed)
+	assert.GreaterOrEqual(suite.T(), concurrent.Elapsed, 200*time.Millisecond, "AI throttle still paces calls")
+
+	// 종목마다 결과 하나, 신호 하나 (결과는 종목 순서)
+	for _, report := range []*services.SignalBatchReport{sequential, concurrent} {
+		suite.Require().Len(report.Results, symbols)
+		assert.Equal(suite.T(), symbols, report.Count(services.SignalGenerated))
+		for n, result := range report.Results {
+			assert.Equal(suite.T(), stocks[n].Symbol, result.Symbol)
+			suite.Require().NotNil(result.Signal, result.Symbol)
+			assert.Equal(suite.T(), "AI", result.Signal.Source)
+		}
+	}
+	for _, stock := range stocks {
+		var count int64
+		suite.Require().NoError(suite.db.Model(&models.TradingSignal{}).Where("symbol = ?", stock.Symbol).Count(&count).Error)
+		assert.Equal(suite.T(), int64(2), count, stock.Symbol)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainSignal(t *testing.T) {
+	signal := &models.TradingSignal{
+		ID:                7,
+		Symbol:            "005930",
+		SignalType:        "BUY",
+		Confidence:        0.8,
+		Strength:          0.86,
+		Reasons:           `["RSI oversold","MACD positive"]`,
+		IndicatorSnapshot: `{"rsi":25.5,"macd":1.2}`,
+		Source:            "AI",
+		Model:             "decision-v2",
+		CreatedAt:         time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC),
+	}
+
+	explanation, err := services.ExplainSignal(signal)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(7), explanation.SignalID)
+	assert.Equal(t, []string{"RSI oversold", "MACD positive"}, explanation.Reasons)
+	assert.Equal(t, map[string]float64{"rsi": 25.5, "macd": 1.2}, explanation.Indicators)
+	assert.Contains(t, explanation.Summary, "BUY 005930 (confidence 80%")
+	assert.Contains(t, explanation.Summary, "  - RSI oversold")
+	assert.Contains(t, explanation.Summary, "  - rsi: 25.50")
+
+	// 스냅샷이 없는 과거 신호도 설명할 수 있다
+	signal.Source = "RULE"
+	signal.IndicatorSnapshot = ""
+	explanation, err = services.ExplainSignal(signal)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RSI oversold", "MACD positive"}, explanation.Reasons)
+	assert.Empty(t, explanation.Indicators)
+}
+
+func (suite *IntegrationTestSuite) TestExplainSignalEndpoint() {
+	start := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.db.Create(&models.StockPrice{
+			Symbol: "EXPLAIN", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		})
+	}
+
+	generator := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil)
+	signal, err := generator.GenerateSignal("EXPLAIN", "KR")
+	suite.Require().NoError(err)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/signals/explain/%d", signal.ID), nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Explanation services.SignalExplanation `json:"explanation"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+	explanation := response.Explanation
+	assert.Equal(suite.T(), signal.SignalType, explanation.Decision)
+	// 규칙 기반 판단에 쓰인 지표가 스냅샷에 포함되어야 한다
+	for _, name := range []string{"rsi", "macd", "sma_20", "sma_50"} {
+		assert.Contains(suite.T(), explanation.Indicators, name)
+	}
+	assert.Contains(suite.T(), explanation.Reasons, "SMA20 > SMA50")
+	assert.Contains(suite.T(), explanation.Summary, "sma_20")
+
+	req, _ = http.NewRequest("GET", "/api/v1/signals/explain/999999", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/v1/signals/explain/abc", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
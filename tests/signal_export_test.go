@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) createExportSignals() {
+	days := []time.Time{
+		time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC),
+	}
+	for i, createdAt := range days {
+		signal := models.TradingSignal{
+			Symbol: "EXPORT", SignalType: "BUY", Strength: 0.6, Confidence: 0.7,
+			Reasons: `["uptrend"]`, Source: "RULE", CreatedAt: createdAt,
+		}
+		if i == 1 {
+			signal.Outcome = models.SignalOutcomeOpen
+			signal.IndicatorSnapshot = `{"rsi": 41.5}`
+		}
+		if i == 2 {
+			signal.Reasons = `["rsi_oversold", "uptrend"]`
+		}
+		suite.Require().NoError(suite.db.Create(&signal).Error)
+	}
+}
+
+func (suite *IntegrationTestSuite) TestExportSignalsCSV() {
+	suite.createExportSignals()
+
+	req, _ := http.NewRequest("GET", "/api/v1/signals/export?from=2024-05-02&to=2024-05-03&format=csv", nil)
+	req.Header.Set("Accept-Language", "ko-KR,ko;q=0.9")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(suite.T(), w.Header().Get("Content-Type"), "text/csv")
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(records, 3) // 헤더 + 5/2, 5/3 신호
+	assert.Equal(suite.T(), []string{
+		"id", "symbol", "signal_type", "strength", "confidence", "source", "model", "provider",
+		"reasons", "outcome", "indicator_snapshot", "created_at",
+	}, records[0])
+	assert.Equal(suite.T(), "2024-05-02T15:00:00Z", records[1][11])
+	assert.Equal(suite.T(), models.SignalOutcomeOpen, records[1][9])
+	assert.JSONEq(suite.T(), `{"rsi": 41.5}`, records[1][10])
+	assert.Equal(suite.T(), "2024-05-03T10:00:00Z", records[2][11])
+
+	// 근거 코드는 요청 언어 문구로, 코드가 아닌 근거는 그대로 내보낸다
+	assert.JSONEq(suite.T(), `["RSI 과매도", "uptrend"]`, records[2][8])
+	assert.Equal(suite.T(), "ko", w.Header().Get("Content-Language"))
+}
+
+func (suite *IntegrationTestSuite) TestExportSignalsJSON() {
+	suite.createExportSignals()
+
+	req, _ := http.NewRequest("GET", "/api/v1/signals/export?from=2024-05-01&to=2024-05-01&format=json", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var exported []map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &exported))
+	suite.Require().Len(exported, 1)
+	assert.Equal(suite.T(), []interface{}{"uptrend"}, exported[0]["reasons"])
+	assert.Nil(suite.T(), exported[0]["outcome"])
+	assert.Nil(suite.T(), exported[0]["indicator_snapshot"])
+
+	req, _ = http.NewRequest("GET", "/api/v1/signals/export?format=xml", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateReasonLanguage(t *testing.T) {
+	cases := []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"ko", "ko"},
+		{"ko-KR,ko;q=0.9,en-US;q=0.8,en;q=0.7", "ko"},
+		{"en-US,en;q=0.9,ko;q=0.8", "en"},
+		{"ja;q=1.0, ko;q=0.5", "ko"}, // 지원하지 않는 언어는 건너뛴다
+		{"en;q=0.3, ko;q=0.8", "ko"},
+		{"ko;q=0, en;q=0.1", "en"},
+		{"fr, de", "en"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, services.NegotiateReasonLanguage(tc.header, services.LanguageEnglish), tc.header)
+	}
+	assert.Equal(t, "ko", services.NegotiateReasonLanguage("fr", services.LanguageKorean))
+}
+
+func TestLocalizeReasons(t *testing.T) {
+	stored := fmt.Sprintf(`["%s","%s","Strong earnings momentum"]`, services.ReasonRSIOversold, services.ReasonSMABullish)
+
+	var korean, english []string
+	require.NoError(t, json.Unmarshal([]byte(services.LocalizeReasonsJSON(stored, services.LanguageKorean)), &korean))
+	require.NoError(t, json.Unmarshal([]byte(services.LocalizeReasonsJSON(stored, services.LanguageEnglish)), &english))
+
+	// 코드가 아닌 AI 근거는 그대로 둔다
+	assert.Equal(t, []string{"RSI 과매도", "20일 이동평균이 50일 이동평균 위", "Strong earnings momentum"}, korean)
+	assert.Equal(t, []string{"RSI oversold", "SMA20 > SMA50", "Strong earnings momentum"}, english)
+
+	// 코드 도입 전에 영어 문구로 저장된 근거도 변환된다
+	assert.Equal(t, "MACD 양수", services.LocalizeReason("MACD positive", services.LanguageKorean))
+	// 지원하지 않는 언어는 기본 언어, JSON 배열이 아니면 그대로
+	assert.Equal(t, "RSI overbought", services.LocalizeReason(services.ReasonRSIOverbought, "ja"))
+	assert.Equal(t, "not json", services.LocalizeReasonsJSON("not json", services.LanguageKorean))
+}
+
+func (suite *IntegrationTestSuite) TestSignalReasonsFollowAcceptLanguage() {
+	signal := models.TradingSignal{
+		Symbol:     "LOCALE01",
+		SignalType: "BUY",
+		Strength:   0.6,
+		Confidence: 0.6,
+		Reasons:    fmt.Sprintf(`["%s","%s"]`, services.ReasonRSIOversold, services.ReasonMACDPositive),
+		Source:     "RULE",
+	}
+	suite.Require().NoError(suite.db.Create(&signal).Error)
+
+	fetch := func(path, language string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		if language != "" {
+			req.Header.Set("Accept-Language", language)
+		}
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		return w
+	}
+	listReasons := func(language string) []string {
+		w := fetch("/api/v1/signals/LOCALE01", language)
+		var response struct {
+			Signals []models.TradingSignal `json:"signals"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		suite.Require().Len(response.Signals, 1)
+
+		var reasons []string
+		suite.Require().NoError(json.Unmarshal([]byte(response.Signals[0].Reasons), &reasons))
+		return reasons
+	}
+
+	assert.Equal(suite.T(), []string{"RSI 과매도", "MACD 양수"}, listReasons("ko-KR,ko;q=0.9,en;q=0.8"))
+	assert.Equal(suite.T(), []string{"RSI oversold", "MACD positive"}, listReasons("en-US"))
+	assert.Equal(suite.T(), []string{"RSI oversold", "MACD positive"}, listReasons(""))
+
+	w := fetch(fmt.Sprintf("/api/v1/signals/explain/%d", signal.ID), "ko")
+	assert.Equal(suite.T(), "ko", w.Header().Get("Content-Language"))
+	var response struct {
+		Explanation services.SignalExplanation `json:"explanation"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), []string{"RSI 과매도", "MACD 양수"}, response.Explanation.Reasons)
+	assert.Contains(suite.T(), response.Explanation.Summary, "  - RSI 과매도")
+
+	// 저장된 값은 코드 그대로
+	var stored models.TradingSignal
+	suite.Require().NoError(suite.db.First(&stored, signal.ID).Error)
+	assert.Equal(suite.T(), signal.Reasons, stored.Reasons)
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestPurgeSignalsKeepsRecentAndOpen() {
+	suite.db.Exec("TRUNCATE TABLE trading_signals RESTART IDENTITY CASCADE")
+
+	now := time.Now()
+	signals := []models.TradingSignal{
+		{Symbol: "OLD1", SignalType: "BUY", Reasons: "[]", Outcome: models.SignalOutcomeClosed, CreatedAt: now.AddDate(0, 0, -60)},
+		{Symbol: "OLD2", SignalType: "SELL", Reasons: "[]", CreatedAt: now.AddDate(0, 0, -45)},
+		{Symbol: "OLDOPEN", SignalType: "BUY", Reasons: "[]", Outcome: models.SignalOutcomeOpen, CreatedAt: now.AddDate(0, 0, -90)},
+		{Symbol: "RECENT", SignalType: "HOLD", Reasons: "[]", CreatedAt: now.AddDate(0, 0, -1)},
+		{Symbol: "RECENTOPEN", SignalType: "BUY", Reasons: "[]", Outcome: models.SignalOutcomeOpen, CreatedAt: now.Add(-time.Hour)},
+	}
+	for i := range signals {
+		suite.Require().NoError(suite.db.Create(&signals[i]).Error)
+	}
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/admin/signals?older_than=30d", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Deleted int64 `json:"deleted"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), int64(2), response.Deleted)
+
+	var remaining []string
+	suite.db.Model(&models.TradingSignal{}).Order("symbol").Pluck("symbol", &remaining)
+	assert.Equal(suite.T(), []string{"OLDOPEN", "RECENT", "RECENTOPEN"}, remaining)
+
+	req, _ = http.NewRequest("DELETE", "/api/v1/admin/signals?older_than=soon", nil)
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/config"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestSwingStrategyUsesDailyBarsOnly() {
+	var captured models.AIDecisionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&captured)
+		json.NewEncoder(w).Encode(models.AIDecisionResponse{Decision: "HOLD", Confidence: 0.5})
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	for i := 0; i < 60; i++ {
+		suite.db.Create(&models.StockPrice{
+			Symbol: "MIXED", Market: "KR", ClosePrice: 100, HighPrice: 101, LowPrice: 99, Volume: 1000,
+			Granularity: models.GranularityDaily, Timestamp: now.AddDate(0, 0, -60+i),
+		})
+	}
+	// 최근 장중 스냅샷은 일봉보다 최신이고 가격대가 다르다
+	for i := 0; i < 60; i++ {
+		suite.db.Create(&models.StockPrice{
+			Symbol: "MIXED", Market: "KR", ClosePrice: 200, HighPrice: 201, LowPrice: 199, Volume: 10,
+			Granularity: models.GranularityIntraday, Timestamp: now.Add(time.Duration(-60+i) * time.Minute),
+		})
+	}
+
+	cfg := &config.Config{AI: config.AIConfig{Endpoint: server.URL}}
+	generator := services.NewSignalGeneratorService(
+		suite.db, services.NewIndicatorService(), services.NewAIClient(cfg), services.NewCacheService(suite.cfg), nil)
+
+	_, err := generator.GenerateSignalWithStrategy("MIXED", "KR", services.StrategySwing)
+	suite.Require().NoError(err)
+
+	assert.Equal(suite.T(), models.GranularityDaily, captured.Price.Granularity)
+	assert.Equal(suite.T(), 100.0, captured.Price.ClosePrice)
+	assert.Equal(suite.T(), 100.0, captured.Indicators["sma_50"], "only daily closes should feed indicators")
+	assert.Equal(suite.T(), "swing", captured.Metadata["strategy"])
+}
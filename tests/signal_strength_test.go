@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"math"
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalStrengthConsistentAcrossPaths(t *testing.T) {
+	mapping := services.StrengthMapping{Floor: 0.2, Ceiling: 0.9}
+	generator := services.NewSignalGeneratorService(nil, services.NewIndicatorService(), nil, nil, nil).
+		WithStrengthMapping(mapping)
+	worker := workers.NewQueueWorker(nil, nil, services.NewIndicatorService(), generator, nil, nil)
+
+	previous := -1.0
+	for _, confidence := range []float64{0, 0.25, 0.41, 0.5, 0.61, 0.79, 0.81, 1} {
+		strength := generator.Strength(confidence)
+		assert.Equal(t, strength, worker.SignalStrength(confidence), "confidence %.2f", confidence)
+		assert.GreaterOrEqual(t, strength, previous, "strength must not decrease with confidence")
+		previous = strength
+	}
+
+	assert.InDelta(t, 0.2, generator.Strength(0), 1e-9)
+	assert.InDelta(t, 0.55, generator.Strength(0.5), 1e-9)
+	assert.InDelta(t, 0.9, generator.Strength(1), 1e-9)
+}
+
+func TestStrengthMappingClampsConfidence(t *testing.T) {
+	mapping := services.DefaultStrengthMapping
+
+	assert.InDelta(t, 0.3, mapping.Strength(-0.5), 1e-9)
+	assert.InDelta(t, 1.0, mapping.Strength(1.5), 1e-9)
+	assert.InDelta(t, 0.86, mapping.Strength(0.8), 1e-9)
+}
+
+func TestStrengthMappingValidate(t *testing.T) {
+	assert.NoError(t, services.DefaultStrengthMapping.Validate())
+	assert.NoError(t, services.StrengthMapping{Floor: 0, Ceiling: 1}.Validate())
+	assert.NoError(t, services.StrengthMapping{Floor: 0.5, Ceiling: 0.5}.Validate())
+
+	assert.Error(t, services.StrengthMapping{Floor: -0.1, Ceiling: 1}.Validate())
+	assert.Error(t, services.StrengthMapping{Floor: 0.3, Ceiling: 80}.Validate())
+	assert.Error(t, services.StrengthMapping{Floor: 0.9, Ceiling: 0.2}.Validate())
+}
+
+func TestValidateConfidenceFloor(t *testing.T) {
+	for _, floor := range []float64{0, 0.55, 1} {
+		assert.NoError(t, services.ValidateConfidenceFloor(floor), floor)
+	}
+	for _, floor := range []float64{-0.1, 1.5, 60, math.NaN()} {
+		assert.Error(t, services.ValidateConfidenceFloor(floor), floor)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"stock-recommender/backend/services"
+	"stock-recommender/backend/workers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingAllStocksGenerator struct {
+	runs int
+}
+
+func (g *countingAllStocksGenerator) GenerateSignalsForAllStocks() error {
+	g.runs++
+	return nil
+}
+
+func TestParseSignalTrigger(t *testing.T) {
+	trigger, err := services.ParseSignalTrigger("")
+	require.NoError(t, err)
+	assert.Equal(t, services.TriggerPriceUpdate, trigger)
+
+	trigger, err = services.ParseSignalTrigger(" Daily_Close ")
+	require.NoError(t, err)
+	assert.Equal(t, services.TriggerDailyClose, trigger)
+
+	_, err = services.ParseSignalTrigger("hourly")
+	assert.Error(t, err)
+}
+
+func TestSignalTriggerPolicies(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	afterClose := time.Date(2024, 6, 3, 15, 31, 0, 0, seoul)
+
+	cases := []struct {
+		trigger     services.SignalTrigger
+		priceUpdate bool
+		dailyClose  bool
+		scheduled   bool
+	}{
+		{services.TriggerPriceUpdate, true, true, false},
+		{services.TriggerDailyClose, false, true, false},
+		{services.TriggerSchedule, false, false, true},
+		{services.TriggerManual, false, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.trigger), func(t *testing.T) {
+			// 가격 갱신: 큐 워커는 이 판단으로 AI/신호 요청 발행 여부를 정한다
+			assert.Equal(t, tc.priceUpdate, tc.trigger.Allows(services.EventPriceUpdate))
+
+			// 장 마감: 마감 후 스케줄러가 종목 신호를 만드는지
+			scheduler, generator := newSessionCloseFixture(t)
+			processed := scheduler.WithTrigger(tc.trigger).Tick(afterClose)
+			if tc.dailyClose {
+				assert.Equal(t, []string{"KR"}, processed)
+				assert.NotEmpty(t, generator.symbols)
+			} else {
+				assert.Empty(t, processed)
+				assert.Empty(t, generator.symbols)
+			}
+
+			// 고정 주기
+			allStocks := &countingAllStocksGenerator{}
+			ran := workers.NewSignalScheduler(allStocks, tc.trigger, time.Hour).RunOnce()
+			assert.Equal(t, tc.scheduled, ran)
+			assert.Equal(t, map[bool]int{true: 1, false: 0}[tc.scheduled], allStocks.runs)
+
+			// 수동 요청은 어떤 정책에서도 허용
+			assert.True(t, tc.trigger.Allows(services.EventManual))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStalePriceGuard(t *testing.T) {
+	now := time.Now()
+	guard := services.StalePriceGuard{MaxAge: 48 * time.Hour, Action: services.StalePriceDowngrade, Confidence: 0.3}
+
+	assert.False(t, guard.Stale(models.StockPrice{Timestamp: now.Add(-47 * time.Hour)}, now))
+	assert.True(t, guard.Stale(models.StockPrice{Timestamp: now.Add(-49 * time.Hour)}, now))
+	assert.False(t, services.StalePriceGuard{}.Stale(models.StockPrice{Timestamp: now.AddDate(0, 0, -30)}, now), "zero max age disables the guard")
+
+	signal := &models.TradingSignal{SignalType: "BUY", Confidence: 0.8, Strength: 0.8, Reasons: `["rsi_oversold"]`}
+	guard.Downgrade(signal)
+	assert.Equal(t, 0.3, signal.Confidence)
+	assert.InDelta(t, 0.3, signal.Strength, 1e-9)
+	var reasons []string
+	require.NoError(t, json.Unmarshal([]byte(signal.Reasons), &reasons))
+	assert.Equal(t, []string{services.ReasonRSIOversold, services.ReasonStalePrice}, reasons)
+	assert.Equal(t, "최신 가격이 오래되어 신뢰도를 낮춤", services.LocalizeReason(services.ReasonStalePrice, services.LanguageKorean))
+
+	// 이미 상한보다 낮은 신뢰도는 그대로 둔다
+	weak := &models.TradingSignal{Confidence: 0.2, Strength: 0.16}
+	guard.Downgrade(weak)
+	assert.Equal(t, 0.2, weak.Confidence)
+	assert.Equal(t, 0.16, weak.Strength)
+
+	action, err := services.ParseStalePriceAction("")
+	require.NoError(t, err)
+	assert.Equal(t, services.StalePriceSkip, action)
+	action, err = services.ParseStalePriceAction("Downgrade")
+	require.NoError(t, err)
+	assert.Equal(t, services.StalePriceDowngrade, action)
+	_, err = services.ParseStalePriceAction("ignore")
+	assert.Error(t, err)
+}
+
+func (suite *IntegrationTestSuite) TestStalePriceGuardSkipsOrDowngradesSignals() {
+	suite.Require().NoError(suite.db.Create(&models.Stock{Symbol: "STALE01", Name: "Stale Price", Market: "KR", IsActive: true}).Error)
+	// 최신 봉이 3일 전 (봉 검사 기준 96시간 이내라 지표는 계산된다)
+	start := time.Now().Add(-3*24*time.Hour).AddDate(0, 0, -59)
+	for i := 0; i < 60; i++ {
+		price := 100 + float64(i)
+		suite.Require().NoError(suite.db.Create(&models.StockPrice{
+			Symbol: "STALE01", Market: "KR",
+			OpenPrice: price, HighPrice: price + 1, LowPrice: price - 1, ClosePrice: price,
+			Volume: 1000, Timestamp: start.AddDate(0, 0, i),
+		}).Error)
+	}
+	countSignals := func() int64 {
+		var count int64
+		suite.Require().NoError(suite.db.Model(&models.TradingSignal{}).Where("symbol = ?", "STALE01").Count(&count).Error)
+		return count
+	}
+	generator := func(guard services.StalePriceGuard) *services.SignalGeneratorService {
+		return services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil).WithStalePriceGuard(guard)
+	}
+
+	// skip: 신호를 만들지 않는다
+	skip := generator(services.StalePriceGuard{MaxAge: 48 * time.Hour, Action: services.StalePriceSkip})
+	signal, err := skip.GenerateSignal("STALE01", "KR")
+	assert.ErrorIs(suite.T(), err, services.ErrStalePrice)
+	assert.Nil(suite.T(), signal)
+	suite.Require().NoError(skip.GenerateSignalsForAllStocks())
+	assert.Zero(suite.T(), countSignals())
+
+	// 나이 제한 안이면 그대로 만든다
+	fresh, err := generator(services.StalePriceGuard{MaxAge: 96 * time.Hour, Action: services.StalePriceSkip}).GenerateSignal("STALE01", "KR")
+	suite.Require().NoError(err)
+	assert.NotContains(suite.T(), fresh.Reasons, services.ReasonStalePrice)
+	assert.Equal(suite.T(), int64(1), countSignals())
+
+	// downgrade: 신뢰도를 낮추고 근거를 남겨 저장한다
+	downgraded, err := generator(services.StalePriceGuard{MaxAge: 48 * time.Hour, Action: services.StalePriceDowngrade, Confidence: 0.25}).
+		GenerateSignal("STALE01", "KR")
+	suite.Require().NoError(err)
+	assert.LessOrEqual(suite.T(), downgraded.Confidence, 0.25)
+	assert.Contains(suite.T(), downgraded.Reasons, services.ReasonStalePrice)
+	assert.NotZero(suite.T(), downgraded.ID)
+	assert.Equal(suite.T(), int64(2), countSignals())
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"math"
+	"stock-recommender/backend/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStochRSIKnownSeries(t *testing.T) {
+	// 3개 구간 위치: 100, 75, 0, 100, 70 → %K(2) 87.5, 37.5, 50, 85 → %D(2) 67.5
+	rsi := []float64{50, 40, 60, 55, 30, 80, 65}
+
+	k, d := services.StochRSI(rsi, 3, 2, 2)
+	assert.InDelta(t, 85.0, k, 1e-9)
+	assert.InDelta(t, 67.5, d, 1e-9)
+
+	// 데이터가 부족하면 중립값
+	k, d = services.StochRSI(rsi[:4], 3, 2, 2)
+	assert.Equal(t, 50.0, k)
+	assert.Equal(t, 50.0, d)
+}
+
+func TestStochRSIClampedToPercentRange(t *testing.T) {
+	rising := []float64{10, 20, 30, 40, 50, 60, 70, 80}
+	k, d := services.StochRSI(rising, 3, 3, 3)
+	assert.Equal(t, 100.0, k)
+	assert.Equal(t, 100.0, d)
+
+	falling := []float64{80, 70, 60, 50, 40, 30, 20, 10}
+	k, d = services.StochRSI(falling, 3, 3, 3)
+	assert.Equal(t, 0.0, k)
+	assert.Equal(t, 0.0, d)
+
+	flat := []float64{55, 55, 55, 55, 55, 55}
+	k, d = services.StochRSI(flat, 3, 2, 2)
+	assert.Equal(t, 50.0, k)
+	assert.Equal(t, 50.0, d)
+
+	for n := 20; n < 60; n++ {
+		series := make([]float64, n)
+		for i := range series {
+			series[i] = 50 + 45*math.Sin(float64(i*n)/7)
+		}
+		k, d := services.StochRSI(series, 14, 3, 3)
+		assert.True(t, k >= 0 && k <= 100, "k=%v", k)
+		assert.True(t, d >= 0 && d <= 100, "d=%v", d)
+	}
+}
+
+func TestRSISeriesMatchesIndicatorRSI(t *testing.T) {
+	prices := obvBars("RSISERIES", 60)
+	closes := make([]float64, len(prices))
+	for i, price := range prices {
+		closes[i] = price.ClosePrice
+	}
+
+	series := services.RSISeries(closes, 14)
+	require.Len(t, series, len(closes)-14)
+	for _, value := range series {
+		assert.True(t, value >= 0 && value <= 100)
+	}
+
+	result := services.NewIndicatorService().CalculateAll(prices)
+	require.NotNil(t, result)
+	assert.InDelta(t, series[len(series)-1], result.RSI, 1e-9)
+
+	k, d := services.StochRSI(series, 14, 3, 3)
+	assert.InDelta(t, k, result.StochRSIK, 1e-9)
+	assert.InDelta(t, d, result.StochRSID, 1e-9)
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolLocksReadersSeeCompleteWrites(t *testing.T) {
+	locks := services.NewSymbolLocks()
+
+	// 쓰기는 두 값을 차례로 바꾸고, 읽기는 두 값이 항상 같은지 확인
+	var first, second int
+	var wg sync.WaitGroup
+	var inconsistent sync.Map
+
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				unlock := locks.Lock("005930")
+				first = w*1000 + i
+				time.Sleep(time.Microsecond)
+				second = w*1000 + i
+				unlock()
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				unlock := locks.RLock("005930")
+				if first != second {
+					inconsistent.Store(first, second)
+				}
+				unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	inconsistent.Range(func(key, value interface{}) bool {
+		t.Errorf("read half-written state: %v != %v", key, value)
+		return true
+	})
+}
+
+func TestSymbolLocksAreIndependentPerSymbol(t *testing.T) {
+	locks := services.NewSymbolLocks()
+
+	unlock := locks.LockAll([]string{"AAPL", "005930", "AAPL"})
+	done := make(chan struct{})
+	go func() {
+		locks.Lock("MSFT")()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on another symbol should not wait")
+	}
+
+	blocked := make(chan struct{})
+	go func() {
+		locks.RLock("AAPL")()
+		close(blocked)
+	}()
+	select {
+	case <-blocked:
+		t.Fatal("reader should wait for the writer")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	unlock()
+	<-blocked
+}
+
+func (suite *IntegrationTestSuite) TestConcurrentImportAndSignalReadConsistentBars() {
+	suite.db.Create(&models.Stock{Symbol: "LOCKED", Name: "Locked", Market: "KR", IsActive: true})
+
+	// 30 거래일 일봉을 매번 같은 가격 v 로 다시 쓰면, 일관된 읽기에서는 sma_20 이 항상 어떤 v 와 같다
+	var dates []string
+	for day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC); len(dates) < 30; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			dates = append(dates, day.Format("2006-01-02"))
+		}
+	}
+	barsCSV := func(price int) string {
+		var b strings.Builder
+		for _, date := range dates {
+			fmt.Fprintf(&b, "LOCKED,%s,%d,%d,%d,%d,1000\n", date, price, price, price, price)
+		}
+		return b.String()
+	}
+
+	importer := services.NewPriceImportService(suite.db)
+	_, err := importer.ImportCSV(strings.NewReader(barsCSV(100)))
+	suite.Require().NoError(err)
+
+	generator := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for price := 101; price <= 120; price++ {
+			_, err := importer.ImportCSV(strings.NewReader(barsCSV(price)))
+			assert.NoError(suite.T(), err)
+		}
+	}()
+
+	var signals []*models.TradingSignal
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			signal, err := generator.GenerateSignalWithStrategy("LOCKED", "KR", services.StrategySwing)
+			if assert.NoError(suite.T(), err) {
+				signals = append(signals, signal)
+			}
+		}
+	}()
+	wg.Wait()
+
+	suite.Require().NotEmpty(signals)
+	for _, signal := range signals {
+		var snapshot map[string]float64
+		suite.Require().NoError(json.Unmarshal([]byte(signal.IndicatorSnapshot), &snapshot))
+		sma := snapshot["sma_20"]
+		assert.Equal(suite.T(), float64(int(sma)), sma, "sma_20 mixed bars from two imports")
+		assert.True(suite.T(), sma >= 100 && sma <= 120)
+	}
+}
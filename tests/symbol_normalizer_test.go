@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolNormalizer(t *testing.T) {
+	normalizer := apimodels.NewSymbolNormalizer()
+
+	// 등록된 변환이 없으면 받은 종목코드를 그대로 보낸다 (구분자 치환, 대소문자 변환 없음)
+	assert.Equal(t, "BRK.B", normalizer.ToAPI("BRK.B", "NYSE"))
+	assert.Equal(t, "BRK/B", normalizer.FromAPI("BRK/B", "FY"))
+	assert.Equal(t, "aapl", normalizer.ToAPI("aapl", "NASDAQ"))
+	assert.Equal(t, "005930", normalizer.ToAPI("005930", "KR"))
+	assert.Equal(t, "UNKNOWN.X", normalizer.ToAPI("UNKNOWN.X", "TSE"))
+
+	// 거래소별 변환은 등록한 거래소에만 적용된다
+	require.NoError(t, normalizer.LoadMappings("NYSE:BF.B=BF/B, AMEX:BRK.B=BRK-B"))
+	assert.Equal(t, "BF/B", normalizer.ToAPI("bf.b", "NY"))
+	assert.Equal(t, "BF.B", normalizer.FromAPI("BF/B", "FY"))
+	assert.Equal(t, "BRK-B", normalizer.ToAPI("BRK.B", "AMEX"))
+	assert.Equal(t, "BRK.B", normalizer.ToAPI("BRK.B", "NYSE"))
+	assert.Equal(t, "BF/B", normalizer.FromAPI("BF/B", "NASDAQ"))
+
+	assert.Error(t, normalizer.LoadMappings("BRK.B=BRKB"))
+	assert.Error(t, normalizer.LoadMappings("TSE:BRK.B=BRKB"))
+	assert.Error(t, normalizer.AddMapping("NYSE", "", "BRKB"))
+}
+
+func TestClassShareSymbolRoundTrip(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		switch r.URL.Path {
+		case apimodels.PathForeignStockCurrentPrice:
+			var request apimodels.ForeignCurrentPriceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			sent = append(sent, request.In.InputIscd1)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"rsp_cd": "00000",
+				"Out":    map[string]string{"Prpr": "412.50", "Oprc": "410.00", "Hprc": "413.00", "Lprc": "409.00"},
+			})
+		default:
+			json.NewEncoder(w).Encode(apimodels.ForeignStockTickerResponse{
+				RspCd: "00000",
+				Out:   []apimodels.ForeignStockTickerOutput{{Iscd: "BRK/B", ExchClsCode2: "NY"}, {Iscd: "IBM", ExchClsCode2: "NY"}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	// 클래스 주식 변환은 DBSEC_SYMBOL_MAP 으로 등록한다
+	defer func(normalizer *apimodels.SymbolNormalizer) { apimodels.DefaultSymbolNormalizer = normalizer }(apimodels.DefaultSymbolNormalizer)
+	apimodels.DefaultSymbolNormalizer = apimodels.NewSymbolNormalizer()
+	require.NoError(t, apimodels.DefaultSymbolNormalizer.LoadMappings("NYSE:BRK.B=BRK/B"))
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = server.URL
+	apiClient := client.NewDBSecClient(cfg)
+
+	// 요청에는 DB증권 형식으로, 결과에는 클라이언트가 보낸 형식으로
+	data, err := foreign.NewForeignCurrentPriceService(apiClient).GetNYStockPrice("BRK.B")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BRK/B"}, sent)
+	assert.Equal(t, "BRK.B", data.StockCode)
+
+	price, err := apiClient.GetForeignStockPrice("BRK.B", apimodels.ForeignMarketNY)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BRK/B", "BRK/B"}, sent)
+	assert.Equal(t, "BRK.B", price.Symbol)
+
+	// 종목 목록 응답은 클라이언트 형식으로 돌려준다
+	tickers, err := foreign.NewForeignStockTickerService(apiClient).GetAllForeignStockTickers(apimodels.ExchangeNY)
+	require.NoError(t, err)
+	require.Len(t, tickers, 2)
+	assert.Equal(t, "BRK.B", tickers[0].StockCode)
+	assert.Equal(t, "IBM", tickers[1].StockCode)
+}
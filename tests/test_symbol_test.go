@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"net/http"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+	"sync/atomic"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *IntegrationTestSuite) TestTestSymbolPipelineWithoutAPI() {
+	var calls int32
+	mockServer := utils.NewMockServer(suite.T(), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer mockServer.Close()
+
+	// 인증 정보가 있어도 TEST. 종목은 API 를 호출하지 않는다
+	collector := services.NewDataCollectorService(suite.db, utils.CreateMockServerConfig(mockServer))
+
+	symbol := "TEST.AAA"
+	suite.Require().NoError(suite.db.Create(&models.Stock{Symbol: symbol, Name: "Synthetic", Market: "KR", IsActive: true}).Error)
+
+	suite.Require().NoError(collector.CollectDailyData(symbol, 90))
+	suite.Require().NoError(collector.CollectStockData(symbol, "KR"))
+
+	var latest models.StockPrice
+	suite.Require().NoError(suite.db.Where("symbol = ?", symbol).Order("timestamp desc").First(&latest).Error)
+	assert.Greater(suite.T(), latest.ClosePrice, 0.0)
+
+	var prices []models.StockPrice
+	suite.db.Where("symbol = ?", symbol).Order("timestamp desc").Limit(50).Find(&prices)
+	indicators := services.NewIndicatorService().CalculateAll(prices)
+	suite.Require().NotNil(indicators)
+	assert.Greater(suite.T(), indicators.RSI, 0.0)
+	assert.Less(suite.T(), indicators.RSI, 100.0)
+	assert.Greater(suite.T(), indicators.SMA20, 0.0)
+
+	generator := services.NewSignalGeneratorService(suite.db, services.NewIndicatorService(), nil, nil, nil)
+	signal, err := generator.GenerateSignal(symbol, "KR")
+	suite.Require().NoError(err)
+	assert.Contains(suite.T(), []string{"BUY", "SELL", "HOLD"}, signal.SignalType)
+	assert.NotZero(suite.T(), signal.ID)
+
+	assert.Equal(suite.T(), int32(0), atomic.LoadInt32(&calls))
+}
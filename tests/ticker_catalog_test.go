@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/client"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type catalogResponse struct {
+	Exchange string                        `json:"exchange"`
+	Total    int                           `json:"total"`
+	Tickers  []services.TickerCatalogEntry `json:"tickers"`
+}
+
+func (suite *IntegrationTestSuite) TestForeignTickerCatalogServedFromCache() {
+	var tickerCalls int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth2/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+			return
+		}
+		atomic.AddInt32(&tickerCalls, 1)
+		json.NewEncoder(w).Encode(apimodels.ForeignStockTickerResponse{
+			RspCd: "00000",
+			Out: []apimodels.ForeignStockTickerOutput{
+				{Iscd: "NVDA", KorIsnm: "엔비디아", BstpLargName: "반도체", ExchClsCode2: "NA", Zdiv: "4"},
+				{Iscd: "AAPL", KorIsnm: "애플", BstpLargName: "하드웨어", ExchClsCode2: "NA", Zdiv: "4"},
+				{Iscd: "AMD", KorIsnm: "AMD", BstpLargName: "반도체", ExchClsCode2: "NA", Zdiv: "4"},
+			},
+		})
+	}))
+	defer upstream.Close()
+
+	// 영문명은 stocks 에 등록된 영문 종목명에서 가져온다
+	suite.Require().NoError(suite.db.Create(&models.Stock{Symbol: "AAPL", Name: "Apple Inc.", Market: "US", Exchange: "NASDAQ", IsActive: true}).Error)
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = upstream.URL
+	tickers := foreign.NewForeignStockTickerService(client.NewDBSecClient(cfg))
+	catalog := services.NewTickerCatalog(tickers.GetAllForeignStockTickers, 0).WithNames(services.StockEnglishNames(suite.db))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/api/v1/catalog/foreign", handlers.ValidateQueryParams(), handlers.NewCatalogHandler(catalog).GetForeignCatalog)
+	get := func(query string) catalogResponse {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/catalog/foreign?"+query, nil))
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var response catalogResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	first := get("exchange=NASDAQ")
+	assert.Equal(suite.T(), apimodels.MarketNASDAQ, first.Exchange)
+	assert.Equal(suite.T(), 3, first.Total)
+	suite.Require().Len(first.Tickers, 3)
+	assert.Equal(suite.T(), "AAPL", first.Tickers[0].Symbol)
+	assert.Equal(suite.T(), "애플", first.Tickers[0].KoreanName)
+	assert.Equal(suite.T(), "Apple Inc.", first.Tickers[0].EnglishName)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&tickerCalls))
+
+	// 두 번째 요청은 캐시에서 응답한다
+	second := get("exchange=NASDAQ")
+	assert.Equal(suite.T(), first.Tickers, second.Tickers)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&tickerCalls))
+
+	// 업종 필터와 이름 검색
+	semis := get("exchange=NA&sector=반도체")
+	assert.Equal(suite.T(), 2, semis.Total)
+	for _, ticker := range semis.Tickers {
+		assert.Equal(suite.T(), "반도체", ticker.Sector)
+	}
+	apple := get("exchange=NASDAQ&q=apple")
+	suite.Require().Len(apple.Tickers, 1)
+	assert.Equal(suite.T(), "AAPL", apple.Tickers[0].Symbol)
+	nvidia := get("exchange=NASDAQ&q=엔비디아&sector=반도체")
+	suite.Require().Len(nvidia.Tickers, 1)
+	assert.Equal(suite.T(), "NVDA", nvidia.Tickers[0].Symbol)
+	assert.Equal(suite.T(), int32(1), atomic.LoadInt32(&tickerCalls))
+
+	// 국내 시장은 카탈로그가 없다
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/catalog/foreign?exchange=KR", nil))
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func TestTickerCatalogServesStaleWhileRefreshing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	fetch := func(exchangeCode string) ([]apimodels.ForeignStockData, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n > 1 {
+			<-release
+		}
+		return []apimodels.ForeignStockData{{StockCode: fmt.Sprintf("T%d", n)}}, nil
+	}
+	catalog := services.NewTickerCatalog(fetch, 200*time.Millisecond)
+
+	first, err := catalog.Get(apimodels.ExchangeNASDAQ)
+	require.NoError(t, err)
+	assert.Equal(t, "T1", first.Entries[0].Symbol)
+
+	// 오래된 카탈로그는 갱신을 기다리지 않고 응답하고, 동시에 들어온 요청도 갱신은 한 번만 한다
+	time.Sleep(250 * time.Millisecond)
+	stale, err := catalog.Get(apimodels.ExchangeNASDAQ)
+	require.NoError(t, err)
+	assert.Equal(t, "T1", stale.Entries[0].Symbol)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 2 }, time.Second, time.Millisecond)
+	for i := 0; i < 5; i++ {
+		stale, err := catalog.Get(apimodels.ExchangeNASDAQ)
+		require.NoError(t, err)
+		assert.Equal(t, "T1", stale.Entries[0].Symbol)
+	}
+	close(release)
+	assert.Eventually(t, func() bool {
+		snapshot, _ := catalog.Get(apimodels.ExchangeNASDAQ)
+		return snapshot.Entries[0].Symbol == "T2"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestTickerCatalogBacksOffAfterFailure(t *testing.T) {
+	var calls int32
+	catalog := services.NewTickerCatalog(func(exchangeCode string) ([]apimodels.ForeignStockData, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("upstream down")
+	}, 0)
+
+	// 캐시가 없는 거래소를 받아 오지 못하면 잠시 동안은 API 를 다시 부르지 않고 같은 에러를 돌려준다
+	for i := 0; i < 3; i++ {
+		_, err := catalog.Get(apimodels.ExchangeNY)
+		assert.ErrorContains(t, err, "upstream down")
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
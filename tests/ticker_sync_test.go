@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/models"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+	"stock-recommender/backend/openapi/utils"
+	"stock-recommender/backend/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickerHashCoversSyncedFields(t *testing.T) {
+	ticker := apimodels.ForeignStockData{StockCode: "AAPL", KoreanName: "애플", SectorName: "Technology", Exchange: "NASDAQ", Precision: 2}
+	hash := services.TickerHash(ticker)
+	assert.Len(t, hash, 64)
+
+	// stocks 에 쓰지 않는 필드는 해시에 영향 없음
+	unitsChanged := ticker
+	unitsChanged.BuyUnit = 10
+	assert.Equal(t, hash, services.TickerHash(unitsChanged))
+
+	renamed := ticker
+	renamed.KoreanName = "애플 인크"
+	assert.NotEqual(t, hash, services.TickerHash(renamed))
+
+	precision := ticker
+	precision.Precision = 4
+	assert.NotEqual(t, hash, services.TickerHash(precision))
+}
+
+func syncTickers() []apimodels.ForeignStockData {
+	return []apimodels.ForeignStockData{
+		{StockCode: "SYNCA", KoreanName: "에이", SectorName: "Technology", Exchange: "NASDAQ", Precision: 2},
+		{StockCode: "SYNCB", KoreanName: "비", SectorName: "Energy", Exchange: "NASDAQ", Precision: 2},
+		{StockCode: "SYNCC", KoreanName: "씨", SectorName: "Utilities", Exchange: "NASDAQ", Precision: 0},
+	}
+}
+
+func (suite *IntegrationTestSuite) TestTickerSyncOnlyWritesChangedSymbols() {
+	sync := services.NewTickerSyncService(suite.db)
+
+	run, err := sync.Sync(apimodels.ForeignMarketNASDAQ, syncTickers())
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 3, run.Added)
+	assert.Equal(suite.T(), models.TickerSyncDone, run.Status)
+
+	var before []models.Stock
+	suite.db.Where("symbol LIKE ?", "SYNC%").Order("symbol").Find(&before)
+	suite.Require().Len(before, 3)
+	assert.False(suite.T(), before[0].IsActive, "new symbols are added inactive")
+	assert.Equal(suite.T(), 2, before[0].Precision)
+	assert.Equal(suite.T(), 0, before[2].Precision, "zero precision is not replaced by the column default")
+
+	time.Sleep(10 * time.Millisecond)
+	tickers := syncTickers()
+	tickers[1].KoreanName = "비 홀딩스"
+	run, err = sync.Sync(apimodels.ForeignMarketNASDAQ, tickers)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 0, run.Added)
+	assert.Equal(suite.T(), 1, run.Updated)
+	assert.Equal(suite.T(), 2, run.Unchanged)
+	assert.Equal(suite.T(), 0, run.Deactivated)
+
+	var after []models.Stock
+	suite.db.Where("symbol LIKE ?", "SYNC%").Order("symbol").Find(&after)
+	suite.Require().Len(after, 3)
+	assert.Equal(suite.T(), before[0].UpdatedAt, after[0].UpdatedAt)
+	assert.True(suite.T(), after[1].UpdatedAt.After(before[1].UpdatedAt))
+	assert.Equal(suite.T(), "비 홀딩스", after[1].Name)
+	assert.Equal(suite.T(), before[2].UpdatedAt, after[2].UpdatedAt)
+}
+
+func (suite *IntegrationTestSuite) TestTickerSyncDeactivatesDelistedAndResumes() {
+	sync := services.NewTickerSyncService(suite.db)
+	_, err := sync.Sync(apimodels.ForeignMarketNASDAQ, syncTickers())
+	suite.Require().NoError(err)
+	suite.db.Model(&models.Stock{}).Where("symbol LIKE ?", "SYNC%").Update("is_active", true)
+
+	// SYNCB 처리 직후 중단된 실행 (SYNCA 는 이미 처리)
+	suite.db.Create(&models.TickerSyncRun{
+		Exchange: apimodels.ForeignMarketNASDAQ, Status: models.TickerSyncRunning, Cursor: "SYNCB", Updated: 1,
+	})
+
+	tickers := syncTickers()
+	tickers[0].KoreanName = "에이 (처리됨)" // 커서 이전 종목은 다시 쓰지 않는다
+	tickers = append(tickers[:2], apimodels.ForeignStockData{StockCode: "SYNCD", KoreanName: "디", Exchange: "NASDAQ", Precision: 2})
+
+	run, err := sync.Sync(apimodels.ForeignMarketNASDAQ, tickers)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, run.Updated, "carried over from the interrupted run")
+	assert.Equal(suite.T(), 1, run.Added)
+	assert.Equal(suite.T(), 1, run.Deactivated)
+	assert.Equal(suite.T(), "SYNCD", run.Cursor)
+
+	var first, delisted models.Stock
+	suite.db.Where("symbol = ?", "SYNCA").First(&first)
+	assert.Equal(suite.T(), "에이", first.Name)
+	suite.db.Where("symbol = ?", "SYNCC").First(&delisted)
+	assert.False(suite.T(), delisted.IsActive)
+
+	var running int64
+	suite.db.Model(&models.TickerSyncRun{}).Where("status = ?", models.TickerSyncRunning).Count(&running)
+	assert.Equal(suite.T(), int64(0), running)
+}
+
+func (suite *IntegrationTestSuite) TestExchangeCacheLoadsFromSyncedStocks() {
+	_, err := services.NewTickerSyncService(suite.db).Sync(apimodels.ForeignMarketNASDAQ, syncTickers())
+	suite.Require().NoError(err)
+
+	cache := foreign.NewExchangeCache().WithLoader(services.StockExchangeLoader(suite.db))
+	marketDiv, ok := cache.Lookup("synca")
+	suite.Require().True(ok)
+	assert.Equal(suite.T(), apimodels.ForeignMarketNASDAQ, marketDiv)
+
+	_, ok = cache.Lookup("NOTSYNCED")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *IntegrationTestSuite) TestScheduledForeignSyncUsesExchangeCodes() {
+	var requested []string
+	var mu sync.Mutex
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth2/token":
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in": 86400})
+		case apimodels.PathForeignStockTicker:
+			var req apimodels.ForeignStockTickerRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			requested = append(requested, req.In.InputDataCode)
+			mu.Unlock()
+
+			response := apimodels.ForeignStockTickerResponse{RspCd: "00000"}
+			if req.In.InputDataCode == apimodels.ExchangeNASDAQ {
+				response.Out = []apimodels.ForeignStockTickerOutput{
+					{Iscd: "SCHEDA", KorIsnm: "스케줄", BstpLargName: "반도체", ExchClsCode2: "NA", Zdiv: "4"},
+				}
+			}
+			json.NewEncoder(w).Encode(response)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer upstream.Close()
+
+	cfg := utils.CreateTestConfig()
+	cfg.API.DBSecBaseURL = upstream.URL
+	services.NewDataCollectorService(suite.db, cfg).SyncAllForeignStocks()
+
+	// 종목 목록은 해외증시구분코드로 받고, 실행 기록은 해외 시장구분코드로 남긴다
+	mu.Lock()
+	assert.ElementsMatch(suite.T(), []string{apimodels.ExchangeNY, apimodels.ExchangeNASDAQ, apimodels.ExchangeAMEX}, requested)
+	mu.Unlock()
+
+	var stock models.Stock
+	suite.Require().NoError(suite.db.Where("symbol = ?", "SCHEDA").First(&stock).Error)
+	assert.Equal(suite.T(), "반도체", stock.Sector)
+
+	var run models.TickerSyncRun
+	suite.Require().NoError(suite.db.Where("exchange = ?", apimodels.ForeignMarketNASDAQ).Order("id desc").First(&run).Error)
+	assert.Equal(suite.T(), 1, run.Added)
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"stock-recommender/backend/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type universeResponse struct {
+	Universe []models.Stock `json:"universe"`
+	Total    int            `json:"total"`
+}
+
+func (suite *IntegrationTestSuite) putUniverse(body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PUT", "/api/v1/admin/universe", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *IntegrationTestSuite) getUniverse() universeResponse {
+	req, _ := http.NewRequest("GET", "/api/v1/admin/universe", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response universeResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func (suite *IntegrationTestSuite) TestUniverseListsByPriority() {
+	stocks := []models.Stock{
+		{Symbol: "005930", Name: "삼성전자", Market: "KR", IsActive: true, Priority: 5},
+		{Symbol: "AAPL", Name: "Apple Inc.", Market: "US", IsActive: true, Priority: 10},
+		{Symbol: "TSLA", Name: "Tesla Inc.", Market: "US", IsActive: true},
+	}
+	for i := range stocks {
+		suite.Require().NoError(suite.db.Create(&stocks[i]).Error)
+	}
+
+	response := suite.getUniverse()
+
+	assert.Equal(suite.T(), 3, response.Total)
+	var symbols []string
+	for _, stock := range response.Universe {
+		symbols = append(symbols, stock.Symbol)
+	}
+	assert.Equal(suite.T(), []string{"AAPL", "005930", "TSLA"}, symbols)
+}
+
+func (suite *IntegrationTestSuite) TestUniverseAddsSymbol() {
+	w := suite.putUniverse(`{"symbols": [{"symbol": "msft", "market": "NASDAQ", "name": "Microsoft Corp.", "priority": 3}]}`)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var stock models.Stock
+	suite.Require().NoError(suite.db.Where("symbol = ?", "MSFT").First(&stock).Error)
+	assert.Equal(suite.T(), "US", stock.Market)
+	assert.Equal(suite.T(), "Microsoft Corp.", stock.Name)
+	assert.Equal(suite.T(), 3, stock.Priority)
+	assert.True(suite.T(), stock.IsActive)
+
+	// 형식이 맞지 않는 종목이 섞여 있으면 아무것도 반영하지 않는다
+	w = suite.putUniverse(`{"symbols": [{"symbol": "NVDA", "market": "US"}, {"symbol": "12345", "market": "KR"}]}`)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = suite.putUniverse(`{"symbols": [{"symbol": "SONY", "market": "JP"}]}`)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	var count int64
+	suite.db.Model(&models.Stock{}).Where("symbol IN ?", []string{"NVDA", "SONY"}).Count(&count)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
+func (suite *IntegrationTestSuite) TestUniverseDeactivatesSymbol() {
+	stock := models.Stock{Symbol: "000660", Name: "SK하이닉스", Market: "KR", IsActive: true, Priority: 1}
+	suite.Require().NoError(suite.db.Create(&stock).Error)
+
+	w := suite.putUniverse(`{"symbols": [{"symbol": "000660", "market": "KR", "is_active": false}]}`)
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	response := suite.getUniverse()
+	suite.Require().Len(response.Universe, 1)
+	assert.False(suite.T(), response.Universe[0].IsActive)
+	assert.Equal(suite.T(), 1, response.Universe[0].Priority) // 지정하지 않은 필드는 그대로
+	assert.Equal(suite.T(), "SK하이닉스", response.Universe[0].Name)
+
+	// 다음 수집 주기의 대상(is_active)에서 빠진다
+	var active int64
+	suite.db.Model(&models.Stock{}).Where("is_active = ?", true).Count(&active)
+	assert.Equal(suite.T(), int64(0), active)
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"stock-recommender/backend/buildinfo"
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionEndpointReportsBuildInfo(t *testing.T) {
+	// ldflags 로 주입하는 값을 테스트에서 직접 넣는다
+	version, commit, buildTime := buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime
+	buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = "2.3.4", "abc1234", "2024-06-07T09:30:00Z"
+	defer func() { buildinfo.Version, buildinfo.Commit, buildinfo.BuildTime = version, commit, buildTime }()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	features := services.NewFeatureFlags(nil, map[string]bool{services.FlagBacktestAPI: false})
+	r.GET("/version", handlers.NewVersionHandler(features).GetVersion)
+
+	req, _ := http.NewRequest("GET", "/version", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Version   string                      `json:"version"`
+		Commit    string                      `json:"commit"`
+		BuildTime string                      `json:"build_time"`
+		GoVersion string                      `json:"go_version"`
+		Features  []services.FeatureFlagState `json:"features"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "2.3.4", response.Version)
+	assert.Equal(t, "abc1234", response.Commit)
+	assert.Equal(t, "2024-06-07T09:30:00Z", response.BuildTime)
+	assert.Equal(t, runtime.Version(), response.GoVersion)
+
+	flags := make(map[string]services.FeatureFlagState)
+	for _, state := range response.Features {
+		flags[state.Name] = state
+	}
+	assert.Len(t, flags, len(features.List()))
+	assert.False(t, flags[services.FlagBacktestAPI].Enabled)
+	assert.Equal(t, services.FlagSourceConfig, flags[services.FlagBacktestAPI].Source)
+	assert.True(t, flags[services.FlagStochRSI].Enabled)
+	assert.Equal(t, services.FlagSourceDefault, flags[services.FlagStochRSI].Source)
+}
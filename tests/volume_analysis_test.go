@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock-recommender/backend/handlers"
+	"stock-recommender/backend/openapi/foreign"
+	apimodels "stock-recommender/backend/openapi/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// illiquidDayChart 거래가 없거나 거의 없는 봉이 섞인 일차트 (API 응답처럼 최신순)
+func illiquidDayChart() []apimodels.ForeignDayChartData {
+	return []apimodels.ForeignDayChartData{
+		{StockCode: "AAPL", Date: "2024-06-07", High: 106, Low: 104, Close: 105, Volume: 2000},
+		{StockCode: "AAPL", Date: "2024-06-06", High: 131, Low: 129, Close: 130, Volume: 5},
+		{StockCode: "AAPL", Date: "2024-06-05", High: 96, Low: 94, Close: 95, Volume: 1000},
+		{StockCode: "AAPL", Date: "2024-06-04", High: 91, Low: 89, Close: 90, Volume: 0},
+		{StockCode: "AAPL", Date: "2024-06-03", High: 101, Low: 99, Close: 100, Volume: 1000},
+	}
+}
+
+// illiquidMinChart illiquidDayChart 와 같은 봉을 오래된 봉부터 담은 분차트
+func illiquidMinChart(t *testing.T) []apimodels.ForeignMinChartData {
+	data := illiquidDayChart()
+	minData := make([]apimodels.ForeignMinChartData, len(data))
+	for i, d := range data {
+		timestamp, err := time.ParseInLocation("2006-01-02 15:04:05", d.Date+" 09:30:00", apimodels.MarketTradingHours("NASDAQ").Location)
+		require.NoError(t, err)
+		minData[len(data)-1-i] = apimodels.ForeignMinChartData{
+			StockCode: d.StockCode, DateTime: d.Date + " 09:30:00", Timestamp: timestamp, High: d.High, Low: d.Low, Close: d.Close, Volume: d.Volume,
+		}
+	}
+	return minData
+}
+
+func TestMinimumVolumeFilterExcludesIlliquidBars(t *testing.T) {
+	data := illiquidDayChart()
+
+	// 기준 미만 봉은 VWAP/평균 거래량에서 빠진다
+	filtered := foreign.NewForeignDayChartService(nil).WithMinVolume(100).GetVolumeAnalysis(data)
+	assert.Equal(t, 3, filtered.IncludedBars)
+	assert.Equal(t, 2, filtered.ExcludedBars)
+	assert.Equal(t, int64(4000), filtered.TotalVolume)
+	assert.InDelta(t, (100*1000+95*1000+105*2000)/4000.0, filtered.VWAP, 1e-9)
+	assert.InDelta(t, 4000/3.0, filtered.AvgVolume, 1e-9)
+	// 가격 흐름은 유지: 06-05 종가는 빠진 06-04 종가(90)와 비교해 상승, 06-07 은 06-06(130) 대비 하락
+	assert.Equal(t, 1000.0-2000.0, filtered.OBV)
+
+	var profileVolume int64
+	for _, level := range filtered.Profile {
+		profileVolume += level.Volume
+	}
+	assert.Equal(t, filtered.TotalVolume, profileVolume)
+	require.NotEmpty(t, filtered.Profile)
+	assert.Equal(t, 94.0, filtered.Profile[0].Low, "profile spans liquid bars only")
+	assert.Equal(t, 106.0, filtered.Profile[len(filtered.Profile)-1].High)
+
+	// 기준이 없으면 모든 봉을 쓴다
+	all := foreign.NewForeignDayChartService(nil).GetVolumeAnalysis(data)
+	assert.Equal(t, 5, all.IncludedBars)
+	assert.Zero(t, all.ExcludedBars)
+	assert.InDelta(t, (405000+130*5)/4005.0, all.VWAP, 1e-9)
+	assert.Equal(t, 1000.0+5-2000, all.OBV)
+
+	// 가격 통계는 빠진 봉도 포함하고, 거래량 통계만 기준을 따른다
+	stats := foreign.NewForeignDayChartService(nil).WithMinVolume(100).GetPriceStatistics(data)
+	assert.Equal(t, 131.0, stats["max_high"])
+	assert.Equal(t, 89.0, stats["min_low"])
+	assert.InDelta(t, 104.0, stats["avg_close"], 1e-9)
+	assert.InDelta(t, filtered.AvgVolume, stats["avg_volume"], 1e-9)
+	assert.InDelta(t, filtered.VWAP, stats["vwap"], 1e-9)
+
+	// 분차트도 같은 기준을 쓴다 (오래된 봉부터 와도 같은 결과)
+	minAnalysis := foreign.NewForeignMinChartService(nil).WithMinVolume(100).GetVolumeAnalysis(illiquidMinChart(t))
+	assert.Equal(t, filtered, minAnalysis)
+}
+
+// illiquidChartService 거래가 거의 없는 봉이 섞인 일/분차트를 돌려주는 차트 서비스 (거래량 분석은 실제 분차트 서비스)
+type illiquidChartService struct {
+	*foreign.ForeignMinChartService
+	t       *testing.T
+	minDays int
+}
+
+func (f *illiquidChartService) GetDayChartWithDaysContext(_ context.Context, _, _ string, _ int, _ bool) ([]apimodels.ForeignDayChartData, error) {
+	return illiquidDayChart(), nil
+}
+
+func (f *illiquidChartService) GetMinChartWithOptions(_, _, _ string, days int, _ bool) ([]apimodels.ForeignMinChartData, error) {
+	f.minDays = days
+	return illiquidMinChart(f.t), nil
+}
+
+func TestChartVolumeEndpointAppliesMinVolume(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &illiquidChartService{ForeignMinChartService: foreign.NewForeignMinChartService(nil).WithMinVolume(100), t: t}
+	periods := &fakeChartService{}
+	h := handlers.NewChartHandler(service, periods, periods).WithDefaultMarket(apimodels.MarketNASDAQ).
+		WithVolumeAnalysis(foreign.NewForeignDayChartService(nil).WithMinVolume(100), service)
+	r := gin.New()
+	r.Use(handlers.ValidateQueryParams())
+	r.GET("/stocks/:symbol/chart/volume", h.GetVolumeAnalysis)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	expected := foreign.NewForeignDayChartService(nil).WithMinVolume(100).GetVolumeAnalysis(illiquidDayChart())
+
+	// 일차트와 분차트 모두 설정한 기준 미만 봉을 뺀다
+	for _, path := range []string{"/stocks/AAPL/chart/volume", "/stocks/AAPL/chart/volume?interval=intraday&bar=5min&days=2"} {
+		w := get(path)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Bars   int                      `json:"bars"`
+			Volume apimodels.VolumeAnalysis `json:"volume"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 5, response.Bars, path)
+		assert.Equal(t, expected, response.Volume, path)
+		assert.Equal(t, int64(100), response.Volume.MinVolume, path)
+	}
+	assert.Equal(t, 2, service.minDays)
+
+	assert.Equal(t, http.StatusBadRequest, get("/stocks/AAPL/chart/volume?interval=intraday&days=6").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/stocks/AAPL/chart/volume?interval=intraday&bar=3min").Code)
+
+	// 거래량 분석을 설정하지 않은 핸들러는 503
+	unconfigured := gin.New()
+	unconfigured.GET("/stocks/:symbol/chart/volume", handlers.NewChartHandler(periods, periods, periods).GetVolumeAnalysis)
+	w := httptest.NewRecorder()
+	unconfigured.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stocks/AAPL/chart/volume", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}